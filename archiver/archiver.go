@@ -0,0 +1,203 @@
+package archiver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/infevocorp/goflexstore/opscope"
+	"github.com/infevocorp/goflexstore/query"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// ErrNoRetentionParams is returned by the runs without filter params, which would archive every entity.
+var ErrNoRetentionParams = errors.New("archiver: no retention filter")
+
+// Chunk describes a committed chunk.
+//
+// Fields:
+//   - Entities: The number of entities archived or deleted by the chunk.
+//   - Elapsed: The duration of the transaction of the chunk.
+type Chunk struct {
+	Entities int
+	Elapsed  time.Duration
+}
+
+// Report is the outcome of a run.
+//
+// Fields:
+//   - Chunks: The number of committed chunks.
+//   - Entities: The number of entities archived or deleted by the committed chunks.
+type Report struct {
+	Chunks   int
+	Entities int
+}
+
+// Retention computes the retention params of a run starting at now, see Schedule.
+type Retention func(now time.Time) []query.Param
+
+// OlderThan returns the Retention of the entities whose field, a time, is older than age.
+//
+// Example:
+//
+//	archiver.OlderThan("CreatedAt", 30*24*time.Hour)
+func OlderThan(field string, age time.Duration) Retention {
+	return func(now time.Time) []query.Param {
+		return []query.Param{query.Filter(field, now.Add(-age)).WithOP(query.LT)}
+	}
+}
+
+// Archiver moves or deletes the entities of a store matching retention params, see New.
+type Archiver[T store.Entity[ID], ID comparable] struct {
+	options[T, ID]
+	scope opscope.Scope
+	live  store.Store[T, ID]
+}
+
+// New creates an Archiver of the entities of the live store.
+//
+// Parameters:
+//   - scope: The operation scope of the stores, beginning the transactions of the chunks.
+//   - live: The store whose entities are archived.
+//   - opts: The options of the archiver.
+func New[T store.Entity[ID], ID comparable](
+	scope opscope.Scope,
+	live store.Store[T, ID],
+	opts ...Option[T, ID],
+) *Archiver[T, ID] {
+	o := options[T, ID]{
+		chunkSize: 500,
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.chunkSize < 1 {
+		o.chunkSize = 1
+	}
+
+	return &Archiver[T, ID]{
+		options: o,
+		scope:   scope,
+		live:    live,
+	}
+}
+
+// Run archives the entities matching the retention params, which must include a filter, by chunks until none
+// is left. The OrderBy and Paginate params are ignored.
+//
+// It returns the report of the committed chunks, with the error of the failed chunk if any.
+func (a *Archiver[T, ID]) Run(ctx context.Context, params ...query.Param) (Report, error) {
+	var (
+		report  Report
+		p       = query.NewParams(params...)
+		retains = len(p.Get(query.TypeFilter)) > 0 || len(p.Get(query.TypeOR)) > 0 ||
+			len(p.Get(query.TypeGroup)) > 0 || len(p.Get(query.TypeNot)) > 0 || len(p.Get(query.TypeRaw)) > 0
+	)
+
+	if !retains {
+		return report, ErrNoRetentionParams
+	}
+
+	chunkParams := make([]query.Param, 0, p.Len()+3)
+	for _, param := range p.Params() {
+		switch param.(type) {
+		case query.OrderByParam, query.PaginateParam:
+		default:
+			chunkParams = append(chunkParams, param)
+		}
+	}
+
+	chunkParams = append(chunkParams,
+		query.OrderBy("ID", false),
+		query.Paginate(0, a.chunkSize),
+		query.WithLock(query.LockTypeForUpdate),
+	)
+
+	for {
+		start := time.Now()
+
+		n, err := a.chunk(ctx, chunkParams)
+		if err != nil {
+			return report, fmt.Errorf("archiver: chunk %d: %w", report.Chunks+1, err)
+		}
+
+		if n == 0 {
+			return report, nil
+		}
+
+		chunk := Chunk{Entities: n, Elapsed: time.Since(start)}
+
+		report.Chunks++
+		report.Entities += chunk.Entities
+
+		if a.onChunk != nil {
+			a.onChunk(chunk)
+		}
+
+		if chunk.Entities < a.chunkSize {
+			return report, nil
+		}
+	}
+}
+
+// chunk archives the first chunk of the matching entities within a transaction, and returns their number.
+func (a *Archiver[T, ID]) chunk(ctx context.Context, params []query.Param) (n int, err error) {
+	ctx, err = a.scope.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer a.scope.EndWithRecover(ctx, &err)
+
+	entities, err := a.live.List(ctx, params...)
+	if err != nil || len(entities) == 0 {
+		return 0, err
+	}
+
+	if a.archive != nil {
+		if err := a.archive.CreateMany(ctx, entities); err != nil {
+			return 0, fmt.Errorf("create archived entities: %w", err)
+		}
+	}
+
+	if a.verify != nil {
+		if err := a.verify(ctx, entities); err != nil {
+			return 0, fmt.Errorf("verify: %w", err)
+		}
+	}
+
+	ids := make([]ID, len(entities))
+	for i, entity := range entities {
+		ids[i] = entity.GetID()
+	}
+
+	if err := a.live.Delete(ctx, query.Filter("ID", ids)); err != nil {
+		return 0, fmt.Errorf("delete entities: %w", err)
+	}
+
+	return len(entities), nil
+}
+
+// Schedule runs the archiver every interval, with the params computed by retention at each run, until the
+// context is cancelled, and returns the error of the context. The outcome of each run is passed to the function
+// set by WithOnRun: a failed run does not stop the schedule.
+func (a *Archiver[T, ID]) Schedule(ctx context.Context, every time.Duration, retention Retention) error {
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case now := <-ticker.C:
+			report, err := a.Run(ctx, retention(now)...)
+
+			if a.onRun != nil {
+				a.onRun(report, err)
+			}
+		}
+	}
+}
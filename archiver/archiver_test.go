@@ -0,0 +1,145 @@
+package archiver_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infevocorp/goflexstore/archiver"
+	mockopscope "github.com/infevocorp/goflexstore/mocks/opscope"
+	"github.com/infevocorp/goflexstore/query"
+	"github.com/infevocorp/goflexstore/storetest"
+)
+
+type Order struct {
+	ID       int64
+	ClosedAt time.Time
+}
+
+func (o *Order) GetID() int64 {
+	return o.ID
+}
+
+var now = time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+// newOrders returns a store of five orders closed a year ago and one closed yesterday.
+func newOrders() *storetest.Fake[*Order, int64] {
+	orders := storetest.NewFake[*Order, int64]()
+
+	for id := int64(1); id <= 5; id++ {
+		orders.Seed(&Order{ID: id, ClosedAt: now.AddDate(-1, 0, 0)})
+	}
+
+	orders.Seed(&Order{ID: 6, ClosedAt: now.AddDate(0, 0, -1)})
+
+	return orders
+}
+
+// newScope returns a scope beginning transactions with the given context.
+func newScope(t *testing.T) *mockopscope.Scope {
+	scope := mockopscope.NewScope(t)
+	scope.EXPECT().Begin(mock.Anything).RunAndReturn(func(ctx context.Context) (context.Context, error) {
+		return ctx, nil
+	})
+
+	return scope
+}
+
+func ids(orders []*Order) []int64 {
+	result := make([]int64, 0, len(orders))
+	for _, o := range orders {
+		result = append(result, o.ID)
+	}
+
+	return result
+}
+
+func Test_Archiver_Run(t *testing.T) {
+	retention := archiver.OlderThan("ClosedAt", 30*24*time.Hour)(now)
+
+	t.Run("should-move-entities-by-chunks", func(t *testing.T) {
+		// GIVEN
+		var (
+			scope   = newScope(t)
+			live    = newOrders()
+			archive = storetest.NewFake[*Order, int64]()
+			chunks  []int
+		)
+
+		scope.EXPECT().EndWithRecover(mock.Anything, mock.Anything).Times(3)
+
+		a := archiver.New[*Order, int64](scope, live,
+			archiver.WithArchive[*Order, int64](archive),
+			archiver.WithChunkSize[*Order, int64](2),
+			archiver.WithOnChunk[*Order, int64](func(c archiver.Chunk) { chunks = append(chunks, c.Entities) }),
+		)
+
+		// WHEN
+		report, err := a.Run(context.Background(), retention...)
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, archiver.Report{Chunks: 3, Entities: 5}, report)
+		assert.Equal(t, []int{2, 2, 1}, chunks)
+		assert.Equal(t, []int64{1, 2, 3, 4, 5}, ids(archive.Entities()))
+		assert.Equal(t, []int64{6}, ids(live.Entities()))
+		live.AssertCalled(t, "List", storetest.HasParamType(query.TypeWithLock))
+	})
+
+	t.Run("should-delete-entities-without-archive", func(t *testing.T) {
+		// GIVEN
+		var (
+			scope = newScope(t)
+			live  = newOrders()
+		)
+
+		scope.EXPECT().EndWithRecover(mock.Anything, mock.Anything).Once()
+
+		// WHEN
+		report, err := archiver.New[*Order, int64](scope, live).Run(context.Background(), retention...)
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, archiver.Report{Chunks: 1, Entities: 5}, report)
+		assert.Equal(t, []int64{6}, ids(live.Entities()))
+	})
+
+	t.Run("should-stop-when-verification-fails", func(t *testing.T) {
+		// GIVEN
+		var (
+			scope     = newScope(t)
+			live      = newOrders()
+			verifyErr = errors.New("archived count mismatch")
+		)
+
+		scope.EXPECT().EndWithRecover(mock.Anything, mock.Anything).Run(func(_ context.Context, err *error) {
+			assert.ErrorIs(t, *err, verifyErr)
+		}).Once()
+
+		a := archiver.New[*Order, int64](scope, live,
+			archiver.WithArchive[*Order, int64](storetest.NewFake[*Order, int64]()),
+			archiver.WithVerifier[*Order, int64](func(context.Context, []*Order) error { return verifyErr }),
+		)
+
+		// WHEN
+		report, err := a.Run(context.Background(), retention...)
+
+		// THEN
+		assert.ErrorIs(t, err, verifyErr)
+		assert.Equal(t, archiver.Report{}, report)
+		assert.Len(t, live.Entities(), 6)
+	})
+
+	t.Run("should-require-retention-filter", func(t *testing.T) {
+		// WHEN
+		_, err := archiver.New[*Order, int64](mockopscope.NewScope(t), newOrders()).Run(context.Background())
+
+		// THEN
+		assert.ErrorIs(t, err, archiver.ErrNoRetentionParams)
+	})
+}
@@ -0,0 +1,23 @@
+// Package archiver enforces the retention of the entities of a store, moving the entities matching retention
+// params to an archive store, e.g. of an archive table, or deleting them.
+//
+// An Archiver processes the matching entities by chunks in the order of their ID, each chunk within its own
+// transaction of the operation scope: the chunk is listed and locked, created in the archive store, verified
+// and deleted from the live store. A failed chunk is rolled back, and the chunks committed before it are kept.
+// The archive store must therefore share the operation scope, and the database, of the live store.
+//
+// Run archives the entities on demand, and Schedule periodically until its context is cancelled, computing the
+// retention params at each run, e.g. with OlderThan.
+//
+// Example:
+//
+//	archiver := archiver.New[*model.Order, int64](scope, orderStore,
+//		archiver.WithArchive[*model.Order, int64](archivedOrderStore),
+//		archiver.WithChunkSize[*model.Order, int64](1000),
+//		archiver.WithOnRun[*model.Order, int64](func(report archiver.Report, err error) {
+//			archivedOrders.Add(float64(report.Entities))
+//		}),
+//	)
+//
+//	go archiver.Schedule(ctx, time.Hour, archiver.OlderThan("ClosedAt", 90*24*time.Hour))
+package archiver
@@ -0,0 +1,58 @@
+package archiver
+
+import (
+	"context"
+
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// Option is a function that configures the Archiver.
+type Option[T store.Entity[ID], ID comparable] func(*options[T, ID])
+
+type options[T store.Entity[ID], ID comparable] struct {
+	archive   store.Store[T, ID]
+	chunkSize int
+	verify    func(ctx context.Context, archived []T) error
+	onChunk   func(Chunk)
+	onRun     func(Report, error)
+}
+
+// WithArchive sets the store the entities are moved to, which must share the operation scope of the live store.
+// Without archive store, the entities are deleted.
+func WithArchive[T store.Entity[ID], ID comparable](archive store.Store[T, ID]) Option[T, ID] {
+	return func(o *options[T, ID]) {
+		o.archive = archive
+	}
+}
+
+// WithChunkSize sets the number of entities archived within each transaction. It defaults to 500.
+func WithChunkSize[T store.Entity[ID], ID comparable](size int) Option[T, ID] {
+	return func(o *options[T, ID]) {
+		o.chunkSize = size
+	}
+}
+
+// WithVerifier sets a function verifying each chunk before its entities are deleted from the live store, e.g.
+// by counting them in the archive store. It runs within the transaction of the chunk: when it returns an error,
+// the chunk is rolled back and the run fails.
+func WithVerifier[T store.Entity[ID], ID comparable](
+	verify func(ctx context.Context, archived []T) error,
+) Option[T, ID] {
+	return func(o *options[T, ID]) {
+		o.verify = verify
+	}
+}
+
+// WithOnChunk sets a function called after each committed chunk, e.g. to record metrics.
+func WithOnChunk[T store.Entity[ID], ID comparable](onChunk func(Chunk)) Option[T, ID] {
+	return func(o *options[T, ID]) {
+		o.onChunk = onChunk
+	}
+}
+
+// WithOnRun sets a function called with the outcome of each run of Schedule, e.g. to log its errors.
+func WithOnRun[T store.Entity[ID], ID comparable](onRun func(Report, error)) Option[T, ID] {
+	return func(o *options[T, ID]) {
+		o.onRun = onRun
+	}
+}
@@ -0,0 +1,123 @@
+package authz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// ErrForbidden is the error wrapped by the errors of the denied operations, see Forbidden.
+var ErrForbidden = errors.New("forbidden")
+
+// Forbidden returns an error wrapping ErrForbidden with the reason of the denial.
+func Forbidden(reason string) error {
+	return fmt.Errorf("%w: %s", ErrForbidden, reason)
+}
+
+// Policy decides whether operations are allowed.
+type Policy interface {
+	// Allow returns an error if the operation is denied. It may scope the operation by changing its params,
+	// e.g. appending filters. It is called for every operation, including Ping.
+	Allow(ctx context.Context, op *store.Operation) error
+}
+
+// EntityPolicy is a Policy also checking the entities read by the operations.
+type EntityPolicy interface {
+	Policy
+
+	// AllowEntity returns an error if the entity read by the operation is denied. A Get of a denied entity
+	// fails with the error, while a List or a ListWithCount omits the denied entities, the count of ListWithCount
+	// still including them like Count.
+	AllowEntity(ctx context.Context, op *store.Operation, entity any) error
+}
+
+// PolicyFunc is a function implementing Policy.
+type PolicyFunc func(ctx context.Context, op *store.Operation) error
+
+// Allow calls the function.
+func (f PolicyFunc) Allow(ctx context.Context, op *store.Operation) error {
+	return f(ctx, op)
+}
+
+// NewStore decorates a store so that every operation is allowed by the policy first, see Middleware.
+func NewStore[T store.Entity[ID], ID comparable](inner store.Store[T, ID], policy Policy) store.Store[T, ID] {
+	return store.Chain[T, ID](inner, Middleware(policy))
+}
+
+// Middleware returns a store.Middleware running the operations allowed by the policy, and failing the other
+// ones with the error of the policy. When the policy is an EntityPolicy, the entities read by Get, List and
+// ListWithCount are checked too.
+func Middleware(policy Policy) store.Middleware {
+	entityPolicy, checksEntities := policy.(EntityPolicy)
+
+	return func(next store.OperationFunc) store.OperationFunc {
+		return func(ctx context.Context, op *store.Operation) (any, error) {
+			if err := policy.Allow(ctx, op); err != nil {
+				return nil, err
+			}
+
+			result, err := next(ctx, op)
+			if err != nil || !checksEntities {
+				return result, err
+			}
+
+			switch op.Method {
+			case store.MethodGet:
+				if err := entityPolicy.AllowEntity(ctx, op, result); err != nil {
+					return nil, err
+				}
+			case store.MethodList:
+				return allowedEntities(ctx, entityPolicy, op, result)
+			case store.MethodListWithCount:
+				return allowedPage(ctx, entityPolicy, op, result)
+			}
+
+			return result, nil
+		}
+	}
+}
+
+// allowedPage returns a copy of a store.ListWithCountResult holding the entities allowed by the policy.
+func allowedPage(ctx context.Context, policy EntityPolicy, op *store.Operation, result any) (any, error) {
+	page := reflect.New(reflect.TypeOf(result)).Elem()
+	page.Set(reflect.ValueOf(result))
+
+	entities := page.FieldByName("Entities")
+
+	allowed, err := allowedEntities(ctx, policy, op, entities.Interface())
+	if err != nil {
+		return nil, err
+	}
+
+	entities.Set(reflect.ValueOf(allowed))
+
+	return page.Interface(), nil
+}
+
+// allowedEntities returns the entities of the list allowed by the policy, in a slice of the same type.
+// It returns the errors of the policy not wrapping ErrForbidden.
+func allowedEntities(ctx context.Context, policy EntityPolicy, op *store.Operation, list any) (any, error) {
+	entities := reflect.ValueOf(list)
+	if entities.Kind() != reflect.Slice || entities.IsNil() {
+		return list, nil
+	}
+
+	allowed := reflect.MakeSlice(entities.Type(), 0, entities.Len())
+
+	for i := 0; i < entities.Len(); i++ {
+		entity := entities.Index(i)
+
+		err := policy.AllowEntity(ctx, op, entity.Interface())
+		switch {
+		case err == nil:
+			allowed = reflect.Append(allowed, entity)
+		case !errors.Is(err, ErrForbidden):
+			return nil, err
+		}
+	}
+
+	return allowed.Interface(), nil
+}
@@ -0,0 +1,133 @@
+package authz_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infevocorp/goflexstore/authz"
+	"github.com/infevocorp/goflexstore/query"
+	"github.com/infevocorp/goflexstore/store"
+	"github.com/infevocorp/goflexstore/storetest"
+)
+
+type Document struct {
+	ID      int64
+	OwnerID int64
+	Public  bool
+}
+
+func (d *Document) GetID() int64 {
+	return d.ID
+}
+
+type userKey struct{}
+
+func withUser(ctx context.Context, userID int64) context.Context {
+	return context.WithValue(ctx, userKey{}, userID)
+}
+
+// ownerPolicy restricts the operations to the documents of the user of the context.
+var ownerPolicy = authz.PolicyFunc(func(ctx context.Context, op *store.Operation) error {
+	userID, ok := ctx.Value(userKey{}).(int64)
+	if !ok {
+		return authz.Forbidden("anonymous user")
+	}
+
+	if doc, ok := op.Input.(*Document); ok && doc.OwnerID != userID {
+		return authz.Forbidden("document of another user")
+	}
+
+	op.Params = append(op.Params, query.Filter("OwnerID", userID))
+
+	return nil
+})
+
+// publicPolicy allows every operation, but only the public documents to be read.
+type publicPolicy struct{}
+
+func (publicPolicy) Allow(context.Context, *store.Operation) error {
+	return nil
+}
+
+func (publicPolicy) AllowEntity(_ context.Context, _ *store.Operation, entity any) error {
+	if !entity.(*Document).Public {
+		return authz.Forbidden("private document")
+	}
+
+	return nil
+}
+
+func newDocuments() *storetest.Fake[*Document, int64] {
+	return storetest.NewFake[*Document, int64](
+		&Document{ID: 1, OwnerID: 10, Public: true},
+		&Document{ID: 2, OwnerID: 20},
+		&Document{ID: 3, OwnerID: 10},
+	)
+}
+
+func Test_Middleware(t *testing.T) {
+	t.Run("should-scope-operations-with-policy-filters", func(t *testing.T) {
+		// GIVEN
+		s := authz.NewStore[*Document, int64](newDocuments(), ownerPolicy)
+
+		// WHEN
+		documents, err := s.List(withUser(context.Background(), 10))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, []*Document{{ID: 1, OwnerID: 10, Public: true}, {ID: 3, OwnerID: 10}}, documents)
+	})
+
+	t.Run("should-deny-operations", func(t *testing.T) {
+		// GIVEN
+		var (
+			inner = newDocuments()
+			s     = authz.NewStore[*Document, int64](inner, ownerPolicy)
+		)
+
+		// WHEN
+		_, anonymousErr := s.Count(context.Background())
+		err := s.Update(withUser(context.Background(), 10), &Document{ID: 2, OwnerID: 20, Public: true})
+
+		// THEN
+		assert.ErrorIs(t, anonymousErr, authz.ErrForbidden)
+		assert.EqualError(t, err, "forbidden: document of another user")
+		assert.Empty(t, inner.Calls())
+	})
+
+	t.Run("should-check-entities-read", func(t *testing.T) {
+		// GIVEN
+		s := authz.NewStore[*Document, int64](newDocuments(), publicPolicy{})
+
+		// WHEN
+		documents, err := s.List(context.Background())
+		_, getErr := s.Get(context.Background(), query.Filter("ID", int64(2)))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, []*Document{{ID: 1, OwnerID: 10, Public: true}}, documents)
+		assert.ErrorIs(t, getErr, authz.ErrForbidden)
+	})
+
+	t.Run("should-return-store-errors", func(t *testing.T) {
+		// GIVEN
+		var (
+			inner    = newDocuments()
+			storeErr = errors.New("connection refused")
+		)
+
+		inner.FailWith(store.MethodGet, storeErr)
+
+		s := authz.NewStore[*Document, int64](inner, publicPolicy{})
+
+		// WHEN
+		_, err := s.Get(context.Background())
+
+		// THEN
+		assert.ErrorIs(t, err, storeErr)
+	})
+}
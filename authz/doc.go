@@ -0,0 +1,32 @@
+// Package authz enforces access control at the store layer, consulting a Policy before every operation.
+//
+// A Policy receives the store.Operation about to run: its method, its entity name, its params and, for writes,
+// the entities written. It denies the operation by returning an error, typically wrapping ErrForbidden, and may
+// scope it by appending filters to its params, e.g. restricting the reads of a user to the entities they own.
+// Policies implementing EntityPolicy also check the entities read by Get, List and ListWithCount, for the rules
+// depending on attributes of the stored entities that cannot be expressed as filters.
+//
+// Example:
+// Users only see and modify their own documents, unless they are administrators:
+//
+//	policy := authz.PolicyFunc(func(ctx context.Context, op *store.Operation) error {
+//		user, ok := auth.UserFrom(ctx)
+//		if !ok {
+//			return authz.Forbidden("anonymous user")
+//		}
+//
+//		if user.IsAdmin || op.Method == store.MethodPing {
+//			return nil
+//		}
+//
+//		if doc, ok := op.Input.(*model.Document); ok && doc.OwnerID != user.ID {
+//			return authz.Forbidden("document of another user")
+//		}
+//
+//		op.Params = append(op.Params, query.Filter("OwnerID", user.ID))
+//
+//		return nil
+//	})
+//
+//	documentStore := authz.NewStore[*model.Document, int64](inner, policy)
+package authz
@@ -0,0 +1,127 @@
+package cachestore
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// Cache is the backend holding the cached reads of the stores, see NewLRU and the rediscache package of the
+// redis module.
+type Cache interface {
+	// Get returns the value of the key, and false if the key is missing or expired.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set sets the value of the key, expiring after ttl, or never if ttl is 0.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Delete deletes the key, if any.
+	Delete(ctx context.Context, key string) error
+}
+
+// LRU is an in-memory Cache holding a maximum number of keys, evicting the least recently used ones.
+// It is safe for concurrent use.
+type LRU struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	items    map[string]*list.Element
+	now      func() time.Time
+}
+
+// lruItem is an element of the order of an LRU.
+type lruItem struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+var _ Cache = (*LRU)(nil)
+
+// NewLRU creates an LRU holding at most capacity keys. It panics if capacity is not positive.
+func NewLRU(capacity int) *LRU {
+	if capacity <= 0 {
+		panic("cachestore: LRU capacity must be positive")
+	}
+
+	return &LRU{
+		capacity: capacity,
+		order:    list.New(),
+		items:    make(map[string]*list.Element, capacity),
+		now:      time.Now,
+	}
+}
+
+// Get returns the value of the key, and false if the key is missing or expired.
+func (c *LRU) Get(_ context.Context, key string) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	item := elem.Value.(*lruItem)
+	if !item.expiresAt.IsZero() && !c.now().Before(item.expiresAt) {
+		c.remove(elem)
+
+		return nil, false, nil
+	}
+
+	c.order.MoveToFront(elem)
+
+	return item.value, true, nil
+}
+
+// Set sets the value of the key, expiring after ttl, or never if ttl is 0, and evicts the least recently used
+// key if the LRU is full.
+func (c *LRU) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = c.now().Add(ttl)
+	}
+
+	if elem, ok := c.items[key]; ok {
+		item := elem.Value.(*lruItem)
+		item.value, item.expiresAt = value, expiresAt
+		c.order.MoveToFront(elem)
+
+		return nil
+	}
+
+	c.items[key] = c.order.PushFront(&lruItem{key: key, value: value, expiresAt: expiresAt})
+
+	if c.order.Len() > c.capacity {
+		c.remove(c.order.Back())
+	}
+
+	return nil
+}
+
+// Delete deletes the key, if any.
+func (c *LRU) Delete(_ context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		c.remove(elem)
+	}
+
+	return nil
+}
+
+// Len returns the number of keys held by the LRU, including the expired ones not evicted yet.
+func (c *LRU) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+func (c *LRU) remove(elem *list.Element) {
+	c.order.Remove(elem)
+	delete(c.items, elem.Value.(*lruItem).key)
+}
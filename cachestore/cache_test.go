@@ -0,0 +1,56 @@
+package cachestore_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infevocorp/goflexstore/cachestore"
+)
+
+func Test_LRU(t *testing.T) {
+	t.Run("should-evict-least-recently-used", func(t *testing.T) {
+		// GIVEN
+		var (
+			ctx   = context.Background()
+			cache = cachestore.NewLRU(2)
+		)
+
+		require.NoError(t, cache.Set(ctx, "a", []byte("1"), 0))
+		require.NoError(t, cache.Set(ctx, "b", []byte("2"), 0))
+		_, _, _ = cache.Get(ctx, "a")
+
+		// WHEN
+		require.NoError(t, cache.Set(ctx, "c", []byte("3"), 0))
+
+		// THEN
+		_, hasB, _ := cache.Get(ctx, "b")
+		a, hasA, _ := cache.Get(ctx, "a")
+		assert.False(t, hasB)
+		assert.True(t, hasA)
+		assert.Equal(t, []byte("1"), a)
+		assert.Equal(t, 2, cache.Len())
+	})
+
+	t.Run("should-expire-entries", func(t *testing.T) {
+		// GIVEN
+		var (
+			ctx   = context.Background()
+			cache = cachestore.NewLRU(2)
+		)
+
+		require.NoError(t, cache.Set(ctx, "a", []byte("1"), time.Millisecond))
+
+		// WHEN
+		time.Sleep(2 * time.Millisecond)
+		_, ok, err := cache.Get(ctx, "a")
+
+		// THEN
+		require.NoError(t, err)
+		assert.False(t, ok)
+		assert.Zero(t, cache.Len())
+	})
+}
@@ -0,0 +1,24 @@
+// Package cachestore caches the reads of stores in a shared cache, such as an in-memory LRU or Redis, so that
+// the hot entities and lists are not read from the database again until they change.
+//
+// New decorates a store: Get and List read through the cache, keyed by the entity and the normalized query
+// params, where the order of the filters does not matter. When the inner store scopes its reads by the context,
+// e.g. by tenant, the keys must include the scope with WithKeyFunc. The results are encoded in JSON, and cached for the
+// TTL set by WithTTL. The writes of the store invalidate every cached read of its entity, by changing the
+// generation of the entity that the keys include: the former entries are no longer read, and expire.
+//
+// The cache is best effort: its errors are reported to the handler set by WithErrorHandler and the operations
+// go on with the store. Reads within a transaction bypass the cache, so that they see their own writes, and the
+// other stores sharing the cache only see the writes made through a decorated store of the same entity.
+// The writes within a transaction invalidate the cached reads when they are made, and again after the commit
+// when the operation scope is set by WithScope, since the reads made outside the transaction in between cache
+// the former entities.
+//
+// Example:
+//
+//	articleStore := cachestore.New[*model.Article, int64](inner, cachestore.NewLRU(10000),
+//		cachestore.WithTTL(time.Minute),
+//	)
+//
+//	articleStore := cachestore.New[*model.Article, int64](inner, rediscache.New(redisClient))
+package cachestore
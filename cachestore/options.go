@@ -0,0 +1,69 @@
+package cachestore
+
+import (
+	"context"
+	"time"
+
+	"github.com/infevocorp/goflexstore/opscope"
+)
+
+// Option is a function that configures the Store.
+type Option func(*options)
+
+type options struct {
+	ttl     time.Duration
+	prefix  string
+	onError func(ctx context.Context, err error)
+	keyFunc func(ctx context.Context) string
+	// notifier defers the invalidations of the writes within transactions after their commit.
+	notifier opscope.CommitNotifier
+}
+
+// WithTTL sets how long the reads stay cached. It defaults to one minute.
+func WithTTL(ttl time.Duration) Option {
+	return func(o *options) {
+		o.ttl = ttl
+	}
+}
+
+// WithPrefix sets the prefix of the keys of the store, e.g. to share a Redis database between services.
+// It defaults to "goflexstore".
+func WithPrefix(prefix string) Option {
+	return func(o *options) {
+		o.prefix = prefix
+	}
+}
+
+// WithErrorHandler sets the function called with the errors of the cache, e.g. to log them. The operations
+// go on with the store whatever the error. By default, the errors are ignored.
+func WithErrorHandler(onError func(ctx context.Context, err error)) Option {
+	return func(o *options) {
+		o.onError = onError
+	}
+}
+
+// WithScope sets the operation scope shared with the decorated store. If the scope implements
+// opscope.CommitNotifier, the writes within a transaction invalidate the cached reads again once the outermost
+// scope commits, so that the reads made outside the transaction before the commit, which cached the former
+// entities, are no longer read.
+func WithScope(scope opscope.Scope) Option {
+	return func(o *options) {
+		if notifier, ok := scope.(opscope.CommitNotifier); ok {
+			o.notifier = notifier
+		}
+	}
+}
+
+// WithKeyFunc sets the function returning the part of the keys of the reads derived from their context, when the
+// results of the inner store depend on it. It is required when the inner store scopes its reads by the context,
+// e.g. a tenancy.Store, otherwise the entities of a tenant would be read by the others:
+//
+//	cachestore.WithKeyFunc(func(ctx context.Context) string {
+//		tenantID, _ := tenancy.FromContext(ctx)
+//		return tenantID
+//	})
+func WithKeyFunc(keyFunc func(ctx context.Context) string) Option {
+	return func(o *options) {
+		o.keyFunc = keyFunc
+	}
+}
@@ -0,0 +1,250 @@
+package cachestore
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/infevocorp/goflexstore/opscope"
+	"github.com/infevocorp/goflexstore/query"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// Store is a store.Store decorator caching the Get and List reads of the inner store, see New.
+type Store[T store.Entity[ID], ID comparable] struct {
+	store.Store[T, ID]
+
+	cache   Cache
+	entity  string
+	options options
+}
+
+var _ store.Store[store.Entity[int], int] = (*Store[store.Entity[int], int])(nil)
+
+// New decorates the inner store with read-through caching in the cache, see the package documentation.
+//
+// Example:
+//
+//	articleStore := cachestore.New[*model.Article, int64](inner, cachestore.NewLRU(10000))
+func New[T store.Entity[ID], ID comparable](inner store.Store[T, ID], cache Cache, opts ...Option) *Store[T, ID] {
+	o := options{
+		ttl:     time.Minute,
+		prefix:  "goflexstore",
+		onError: func(context.Context, error) {},
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return &Store[T, ID]{
+		Store:   inner,
+		cache:   cache,
+		entity:  store.EntityName[T](),
+		options: o,
+	}
+}
+
+// Get returns the cached entity matching the params, or reads it from the inner store and caches it.
+// store.ErrNotFound is not cached.
+func (s *Store[T, ID]) Get(ctx context.Context, params ...query.Param) (T, error) {
+	return readThrough(ctx, s, store.MethodGet, params, func() (T, error) {
+		return s.Store.Get(ctx, params...)
+	})
+}
+
+// List returns the cached entities matching the params, or reads them from the inner store and caches them.
+func (s *Store[T, ID]) List(ctx context.Context, params ...query.Param) ([]T, error) {
+	return readThrough(ctx, s, store.MethodList, params, func() ([]T, error) {
+		return s.Store.List(ctx, params...)
+	})
+}
+
+// Create creates the entity and invalidates the cached reads.
+func (s *Store[T, ID]) Create(ctx context.Context, entity T) (ID, error) {
+	defer s.invalidate(ctx)
+
+	return s.Store.Create(ctx, entity)
+}
+
+// CreateMany creates the entities and invalidates the cached reads.
+func (s *Store[T, ID]) CreateMany(ctx context.Context, entities []T) error {
+	defer s.invalidate(ctx)
+
+	return s.Store.CreateMany(ctx, entities)
+}
+
+// Upsert creates or updates the entity and invalidates the cached reads.
+func (s *Store[T, ID]) Upsert(ctx context.Context, entity T, onConflict store.OnConflict) (ID, error) {
+	defer s.invalidate(ctx)
+
+	return s.Store.Upsert(ctx, entity, onConflict)
+}
+
+// Update updates the entity and invalidates the cached reads.
+func (s *Store[T, ID]) Update(ctx context.Context, entity T, params ...query.Param) error {
+	defer s.invalidate(ctx)
+
+	return s.Store.Update(ctx, entity, params...)
+}
+
+// PartialUpdate updates the non-zero fields of the entity and invalidates the cached reads.
+func (s *Store[T, ID]) PartialUpdate(ctx context.Context, entity T, params ...query.Param) error {
+	defer s.invalidate(ctx)
+
+	return s.Store.PartialUpdate(ctx, entity, params...)
+}
+
+// UpdateMany updates the matching entities and invalidates the cached reads.
+func (s *Store[T, ID]) UpdateMany(ctx context.Context, updates map[string]any, params ...query.Param) (int64, error) {
+	defer s.invalidate(ctx)
+
+	return s.Store.UpdateMany(ctx, updates, params...)
+}
+
+// Delete deletes the matching entities and invalidates the cached reads.
+func (s *Store[T, ID]) Delete(ctx context.Context, params ...query.Param) error {
+	defer s.invalidate(ctx)
+
+	return s.Store.Delete(ctx, params...)
+}
+
+// DeleteReturning deletes the matching entities, returns them and invalidates the cached reads.
+func (s *Store[T, ID]) DeleteReturning(ctx context.Context, params ...query.Param) ([]T, error) {
+	defer s.invalidate(ctx)
+
+	return s.Store.DeleteReturning(ctx, params...)
+}
+
+// readThrough returns the cached result of the read, or runs it and caches its result.
+func readThrough[T store.Entity[ID], ID comparable, R any](
+	ctx context.Context,
+	s *Store[T, ID],
+	method string,
+	params []query.Param,
+	read func() (R, error),
+) (R, error) {
+	if opscope.InTransaction(ctx) {
+		return read()
+	}
+
+	gen, err := s.generation(ctx)
+	if err != nil {
+		s.options.onError(ctx, err)
+
+		return read()
+	}
+
+	key := s.key(ctx, gen, method, params)
+
+	if value, ok, err := s.cache.Get(ctx, key); err != nil {
+		s.options.onError(ctx, err)
+	} else if ok {
+		var result R
+		if err := json.Unmarshal(value, &result); err == nil {
+			return result, nil
+		}
+
+		s.options.onError(ctx, fmt.Errorf("cachestore: decode %s: %w", key, err))
+	}
+
+	result, err := read()
+	if err != nil {
+		return result, err
+	}
+
+	value, err := json.Marshal(result)
+	if err == nil {
+		err = s.cache.Set(ctx, key, value, s.options.ttl)
+	}
+
+	if err != nil {
+		s.options.onError(ctx, err)
+	}
+
+	return result, nil
+}
+
+// generation returns the generation of the entity, which changes when the cached reads are invalidated,
+// creating it if it is missing.
+func (s *Store[T, ID]) generation(ctx context.Context) (string, error) {
+	genKey := s.generationKey()
+
+	gen, ok, err := s.cache.Get(ctx, genKey)
+	if err != nil || ok {
+		return string(gen), err
+	}
+
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	gen = []byte(hex.EncodeToString(b))
+
+	return string(gen), s.cache.Set(ctx, genKey, gen, 0)
+}
+
+// invalidate deletes the generation of the entity, so that the cached reads are no longer read. Within a
+// transaction, the generation is deleted again after the commit: until then, the reads outside the transaction
+// still read and cache the former entities under the new generation.
+func (s *Store[T, ID]) invalidate(ctx context.Context) {
+	s.deleteGeneration(ctx)
+
+	if s.options.notifier != nil && opscope.InTransaction(ctx) {
+		s.options.notifier.OnCommit(ctx, s.deleteGeneration)
+	}
+}
+
+// deleteGeneration deletes the generation of the entity.
+func (s *Store[T, ID]) deleteGeneration(ctx context.Context) {
+	if err := s.cache.Delete(ctx, s.generationKey()); err != nil {
+		s.options.onError(ctx, err)
+	}
+}
+
+func (s *Store[T, ID]) generationKey() string {
+	return s.options.prefix + ":" + s.entity + ":gen"
+}
+
+// key returns the key of a read, where the conditions of the params are sorted so that their order does not
+// change the key, unlike the order of the other params, such as OrderBy. The key includes the part derived from
+// the context by the function set by WithKeyFunc.
+func (s *Store[T, ID]) key(ctx context.Context, gen, method string, params []query.Param) string {
+	var (
+		flattened  = query.NewParams(params...).Params()
+		conditions = make([]string, 0, len(flattened))
+		others     = make([]query.Param, 0, len(flattened))
+	)
+
+	for _, param := range flattened {
+		switch param.(type) {
+		case query.FilterParam, query.ORParam, query.GroupParam, query.NotParam, query.RawParam:
+			conditions = append(conditions, query.NewParams(param).Hash())
+		default:
+			others = append(others, param)
+		}
+	}
+
+	sort.Strings(conditions)
+
+	h := sha256.New()
+
+	if s.options.keyFunc != nil {
+		h.Write([]byte(s.options.keyFunc(ctx)))
+		h.Write([]byte{0})
+	}
+
+	for _, condition := range conditions {
+		h.Write([]byte(condition))
+	}
+
+	h.Write([]byte(query.NewParams(others...).Hash()))
+
+	return s.options.prefix + ":" + s.entity + ":" + gen + ":" + method + ":" + hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,251 @@
+package cachestore_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infevocorp/goflexstore/cachestore"
+	"github.com/infevocorp/goflexstore/opscope"
+	"github.com/infevocorp/goflexstore/query"
+	"github.com/infevocorp/goflexstore/store"
+	"github.com/infevocorp/goflexstore/storetest"
+	"github.com/infevocorp/goflexstore/tenancy"
+)
+
+type Article struct {
+	ID     int
+	Status string
+}
+
+func (a *Article) GetID() int {
+	return a.ID
+}
+
+type Project struct {
+	ID       int
+	TenantID string
+}
+
+func (p *Project) GetID() int {
+	return p.ID
+}
+
+func newStore(cache cachestore.Cache, opts ...cachestore.Option) (*storetest.Fake[*Article, int], *cachestore.Store[*Article, int]) {
+	inner := storetest.NewFake[*Article, int](
+		&Article{ID: 1, Status: "active"},
+		&Article{ID: 2, Status: "draft"},
+	)
+
+	return inner, cachestore.New[*Article, int](inner, cache, opts...)
+}
+
+// txInspector reports an active transaction.
+type txInspector struct{}
+
+func (txInspector) TxInfo() (opscope.Info, bool) {
+	return opscope.Info{Name: "test", Level: 1}, true
+}
+
+// deferredScope is an opscope.Scope that holds OnCommit callbacks until commit is called.
+type deferredScope struct {
+	opscope.Scope
+	callbacks []func(ctx context.Context)
+}
+
+func (s *deferredScope) OnCommit(_ context.Context, fn func(ctx context.Context)) {
+	s.callbacks = append(s.callbacks, fn)
+}
+
+func (s *deferredScope) commit(ctx context.Context) {
+	for _, fn := range s.callbacks {
+		fn(ctx)
+	}
+}
+
+// failingCache fails every operation.
+type failingCache struct {
+	err error
+}
+
+func (c failingCache) Get(context.Context, string) ([]byte, bool, error) {
+	return nil, false, c.err
+}
+
+func (c failingCache) Set(context.Context, string, []byte, time.Duration) error {
+	return c.err
+}
+
+func (c failingCache) Delete(context.Context, string) error {
+	return c.err
+}
+
+func Test_Store(t *testing.T) {
+	t.Run("should-read-through-cache", func(t *testing.T) {
+		// GIVEN
+		inner, s := newStore(cachestore.NewLRU(100))
+		ctx := context.Background()
+
+		// WHEN
+		first, err := s.List(ctx, query.Filter("Status", "active"), query.Filter("ID", 1))
+		require.NoError(t, err)
+
+		second, err := s.List(ctx, query.Filter("ID", 1), query.Filter("Status", "active"))
+		require.NoError(t, err)
+
+		article, err := s.Get(ctx, query.Filter("ID", 2))
+		require.NoError(t, err)
+
+		cached, err := s.Get(ctx, query.Filter("ID", 2))
+		require.NoError(t, err)
+
+		// THEN
+		assert.Equal(t, []*Article{{ID: 1, Status: "active"}}, first)
+		assert.Equal(t, first, second)
+		assert.Equal(t, &Article{ID: 2, Status: "draft"}, article)
+		assert.Equal(t, article, cached)
+		assert.Len(t, inner.Calls("List"), 1)
+		assert.Len(t, inner.Calls("Get"), 1)
+	})
+
+	t.Run("should-invalidate-reads-on-writes", func(t *testing.T) {
+		// GIVEN
+		inner, s := newStore(cachestore.NewLRU(100))
+		ctx := context.Background()
+
+		_, err := s.List(ctx)
+		require.NoError(t, err)
+
+		// WHEN
+		require.NoError(t, s.PartialUpdate(ctx, &Article{Status: "archived"}, query.Filter("ID", 2)))
+
+		articles, err := s.List(ctx)
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, []*Article{{ID: 1, Status: "active"}, {ID: 2, Status: "archived"}}, articles)
+		assert.Len(t, inner.Calls("List"), 2)
+	})
+
+	t.Run("should-not-cache-not-found", func(t *testing.T) {
+		// GIVEN
+		inner, s := newStore(cachestore.NewLRU(100))
+		ctx := context.Background()
+
+		// WHEN
+		_, err := s.Get(ctx, query.Filter("ID", 3))
+		_, again := s.Get(ctx, query.Filter("ID", 3))
+
+		// THEN
+		assert.ErrorIs(t, err, store.ErrNotFound)
+		assert.ErrorIs(t, again, store.ErrNotFound)
+		assert.Len(t, inner.Calls("Get"), 2)
+	})
+
+	t.Run("should-bypass-cache-in-transaction", func(t *testing.T) {
+		// GIVEN
+		inner, s := newStore(cachestore.NewLRU(100))
+		ctx := opscope.WithTxInspector(context.Background(), txInspector{})
+
+		// WHEN
+		_, err := s.List(ctx)
+		require.NoError(t, err)
+
+		_, err = s.List(ctx)
+		require.NoError(t, err)
+
+		// THEN
+		assert.Len(t, inner.Calls("List"), 2)
+	})
+
+	t.Run("should-invalidate-reads-after-commit", func(t *testing.T) {
+		// GIVEN
+		var (
+			scope = &deferredScope{}
+			ctx   = context.Background()
+			txCtx = opscope.WithTxInspector(ctx, txInspector{})
+		)
+
+		inner, s := newStore(cachestore.NewLRU(100), cachestore.WithScope(scope))
+
+		// WHEN
+		require.NoError(t, s.PartialUpdate(txCtx, &Article{Status: "archived"}, query.Filter("ID", 2)))
+
+		// the read outside the transaction, before its commit, caches the entities under the new generation.
+		_, err := s.List(ctx)
+		require.NoError(t, err)
+
+		_, err = s.List(ctx)
+		require.NoError(t, err)
+
+		callsBeforeCommit := len(inner.Calls("List"))
+
+		scope.commit(ctx)
+
+		_, err = s.List(ctx)
+		require.NoError(t, err)
+
+		// THEN
+		assert.Equal(t, 1, callsBeforeCommit)
+		assert.Len(t, inner.Calls("List"), 2)
+	})
+
+	t.Run("should-key-reads-by-context", func(t *testing.T) {
+		// GIVEN
+		var (
+			inner = storetest.NewFake[*Project, int](
+				&Project{ID: 1, TenantID: "acme"},
+				&Project{ID: 2, TenantID: "globex"},
+			)
+			s = cachestore.New[*Project, int](tenancy.NewStore[*Project, int](inner), cachestore.NewLRU(100),
+				cachestore.WithKeyFunc(func(ctx context.Context) string {
+					tenantID, _ := tenancy.FromContext(ctx)
+					return tenantID
+				}),
+			)
+			acme   = tenancy.WithTenant(context.Background(), "acme")
+			globex = tenancy.WithTenant(context.Background(), "globex")
+		)
+
+		_, err := s.List(acme)
+		require.NoError(t, err)
+
+		// WHEN
+		acmeProjects, err := s.List(acme)
+		require.NoError(t, err)
+
+		globexProjects, err := s.List(globex)
+		require.NoError(t, err)
+
+		// THEN
+		assert.Equal(t, []*Project{{ID: 1, TenantID: "acme"}}, acmeProjects)
+		assert.Equal(t, []*Project{{ID: 2, TenantID: "globex"}}, globexProjects)
+		assert.Len(t, inner.Calls("List"), 2)
+	})
+
+	t.Run("should-read-store-when-cache-fails", func(t *testing.T) {
+		// GIVEN
+		var (
+			cacheErr = errors.New("cache down")
+			reported []error
+		)
+
+		_, s := newStore(failingCache{err: cacheErr}, cachestore.WithErrorHandler(func(_ context.Context, err error) {
+			reported = append(reported, err)
+		}))
+
+		// WHEN
+		articles, err := s.List(context.Background())
+		_, createErr := s.Create(context.Background(), &Article{ID: 3})
+
+		// THEN
+		require.NoError(t, err)
+		assert.Len(t, articles, 2)
+		require.NoError(t, createErr)
+		assert.Equal(t, []error{cacheErr, cacheErr}, reported)
+	})
+}
@@ -0,0 +1,66 @@
+package changelog
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Operation identifies the kind of mutation a Change records.
+type Operation string
+
+const (
+	// OperationCreate is recorded when an entity is created.
+	OperationCreate Operation = "create"
+
+	// OperationUpdate is recorded when an entity is updated, partially updated or upserted.
+	OperationUpdate Operation = "update"
+
+	// OperationDelete is recorded when an entity is deleted.
+	OperationDelete Operation = "delete"
+)
+
+// Change is a single entry of the change log.
+//
+// Fields:
+//   - ID: The identifier of the change log entry.
+//   - Entity: The name of the changed entity type, e.g. "Article".
+//   - EntityID: The identifier of the changed entity, formatted as a string.
+//   - Operation: The kind of mutation.
+//   - Actor: Who performed the mutation, as found in the context (see WithActor).
+//   - Before: A JSON snapshot of the entity before the mutation, empty for creations.
+//   - After: A JSON snapshot of the entity after the mutation, empty for deletions.
+//   - ChangedAt: When the mutation happened.
+type Change struct {
+	ID        int64
+	Entity    string
+	EntityID  string
+	Operation Operation
+	Actor     string
+	Before    json.RawMessage
+	After     json.RawMessage
+	ChangedAt time.Time
+}
+
+// GetID returns the identifier of the change log entry.
+func (c *Change) GetID() int64 {
+	return c.ID
+}
+
+type actorKey struct{}
+
+// WithActor returns a copy of ctx carrying the actor recorded in changes made with it.
+//
+// Example:
+//
+//	ctx = changelog.WithActor(ctx, "user:42")
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor stored in ctx by WithActor, or an empty string.
+func ActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+
+	return actor
+}
@@ -0,0 +1,23 @@
+// Package changelog provides a change-data-capture style store decorator.
+//
+// Every mutation performed through the decorated store is recorded as a Change holding JSON snapshots of the
+// affected entity before and after the operation, together with the actor who performed it and the time it
+// happened. Changes are written through a regular store.Store inside the same operation scope as the mutation,
+// so a change is only persisted if the mutation itself is committed.
+//
+// The history of an entity can then be queried with Store.History.
+//
+// Example:
+//
+//	changes := gormchangelog.NewStore(scope)
+//	articles := changelog.NewStore[*model.Article, int64](
+//		gormstore.New[*model.Article, *dto.Article, int64](scope),
+//		changes,
+//		scope,
+//	)
+//
+//	ctx = changelog.WithActor(ctx, "user:42")
+//	err := articles.Update(ctx, article)
+//
+//	history, err := articles.History(ctx, article.ID)
+package changelog
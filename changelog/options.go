@@ -0,0 +1,46 @@
+package changelog
+
+import (
+	"context"
+	"time"
+)
+
+// Option is a function that configures the change log Store.
+type Option func(*options)
+
+type options struct {
+	name   string
+	idName string
+	actor  func(ctx context.Context) string
+	now    func() time.Time
+}
+
+// WithName sets the entity name recorded in changes.
+// By default the name of the entity type is used, e.g. "Article" for *model.Article.
+func WithName(name string) Option {
+	return func(o *options) {
+		o.name = name
+	}
+}
+
+// WithIDField sets the name of the identifier field used to look up entities by ID. It defaults to "ID".
+func WithIDField(name string) Option {
+	return func(o *options) {
+		o.idName = name
+	}
+}
+
+// WithActorFunc sets the function resolving the actor of a change from the context.
+// It defaults to ActorFromContext.
+func WithActorFunc(fn func(ctx context.Context) string) Option {
+	return func(o *options) {
+		o.actor = fn
+	}
+}
+
+// WithClock sets the function used to timestamp changes. It defaults to time.Now.
+func WithClock(now func() time.Time) Option {
+	return func(o *options) {
+		o.now = now
+	}
+}
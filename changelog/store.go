@@ -0,0 +1,385 @@
+package changelog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/infevocorp/goflexstore/internal/entityutil"
+	"github.com/infevocorp/goflexstore/opscope"
+	"github.com/infevocorp/goflexstore/query"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// NewStore decorates a store so that every mutation is recorded in the change log.
+//
+// Each mutation runs inside the given operation scope together with the reads needed to snapshot the affected
+// entities and the writes to the change log, so the mutation and its changes are committed or rolled back together.
+//
+// Type parameters:
+//   - T: The entity type.
+//   - ID: The type of the entity's identifier.
+//
+// Parameters:
+//   - inner: The store to decorate.
+//   - changes: The store the changes are written to.
+//   - scope: The operation scope shared by inner and changes.
+//   - opts: Options customizing the decorator.
+//
+// Returns:
+// A Store implementing store.Store[T, ID].
+func NewStore[T store.Entity[ID], ID comparable](
+	inner store.Store[T, ID],
+	changes store.Store[*Change, int64],
+	scope opscope.Scope,
+	opts ...Option,
+) *Store[T, ID] {
+	o := options{
+		name:   store.EntityName[T](),
+		idName: "ID",
+		actor:  ActorFromContext,
+		now:    time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return &Store[T, ID]{
+		Store:   inner,
+		Changes: changes,
+		Scope:   scope,
+		options: o,
+	}
+}
+
+// Store is a store.Store decorator recording the mutations it performs in a change log.
+type Store[T store.Entity[ID], ID comparable] struct {
+	store.Store[T, ID]
+	Changes store.Store[*Change, int64]
+	Scope   opscope.Scope
+
+	options options
+}
+
+// History returns the changes recorded for the entity with the given ID, oldest first.
+//
+// Parameters:
+//   - ctx: A context.Context to control the request's deadline and cancellation.
+//   - id: The identifier of the entity.
+//   - params: Additional query parameters, e.g. pagination or a filter on the actor.
+//
+// Returns:
+// The changes of the entity, ordered by time.
+func (s *Store[T, ID]) History(ctx context.Context, id ID, params ...query.Param) ([]*Change, error) {
+	return s.Changes.List(ctx, append([]query.Param{
+		query.Filter("Entity", s.options.name),
+		query.Filter("EntityID", formatID(id)),
+		query.OrderBy("ChangedAt", false),
+		query.OrderBy("ID", false),
+	}, params...)...)
+}
+
+// Create creates the entity and records its snapshot.
+func (s *Store[T, ID]) Create(ctx context.Context, entity T) (id ID, err error) {
+	ctx, err = s.Scope.Begin(ctx)
+	if err != nil {
+		return id, err
+	}
+
+	defer s.Scope.EndWithRecover(ctx, &err)
+
+	if id, err = s.Store.Create(ctx, entity); err != nil {
+		return id, err
+	}
+
+	change, err := s.newChange(ctx, OperationCreate, id, nil, entity)
+	if err != nil {
+		return id, err
+	}
+
+	_, err = s.Changes.Create(ctx, change)
+
+	return id, err
+}
+
+// CreateMany creates the entities and records one snapshot per entity.
+func (s *Store[T, ID]) CreateMany(ctx context.Context, entities []T) (err error) {
+	ctx, err = s.Scope.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer s.Scope.EndWithRecover(ctx, &err)
+
+	if err = s.Store.CreateMany(ctx, entities); err != nil {
+		return err
+	}
+
+	changes := make([]*Change, len(entities))
+
+	for i, entity := range entities {
+		if changes[i], err = s.newChange(ctx, OperationCreate, entity.GetID(), nil, entity); err != nil {
+			return err
+		}
+	}
+
+	return s.Changes.CreateMany(ctx, changes)
+}
+
+// Update updates the entity and records the snapshots of every updated entity.
+func (s *Store[T, ID]) Update(ctx context.Context, entity T, params ...query.Param) error {
+	return s.mutate(ctx, OperationUpdate, s.targets(entity, params), func(ctx context.Context) error {
+		return s.Store.Update(ctx, entity, params...)
+	})
+}
+
+// PartialUpdate partially updates the entity and records the snapshots of every updated entity.
+func (s *Store[T, ID]) PartialUpdate(ctx context.Context, entity T, params ...query.Param) error {
+	return s.mutate(ctx, OperationUpdate, s.targets(entity, params), func(ctx context.Context) error {
+		return s.Store.PartialUpdate(ctx, entity, params...)
+	})
+}
+
+// UpdateMany updates the matching entities and records the snapshots of every updated entity.
+func (s *Store[T, ID]) UpdateMany(ctx context.Context, updates map[string]any, params ...query.Param) (int64, error) {
+	var updated int64
+
+	err := s.mutate(ctx, OperationUpdate, params, func(ctx context.Context) (err error) {
+		updated, err = s.Store.UpdateMany(ctx, updates, params...)
+
+		return err
+	})
+
+	return updated, err
+}
+
+// Delete deletes the matching entities and records their last snapshot.
+func (s *Store[T, ID]) Delete(ctx context.Context, params ...query.Param) error {
+	return s.mutate(ctx, OperationDelete, params, func(ctx context.Context) error {
+		return s.Store.Delete(ctx, params...)
+	})
+}
+
+// DeleteReturning deletes the matching entities and records the returned snapshots.
+func (s *Store[T, ID]) DeleteReturning(ctx context.Context, params ...query.Param) (_ []T, err error) {
+	ctx, err = s.Scope.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer s.Scope.EndWithRecover(ctx, &err)
+
+	entities, err := s.Store.DeleteReturning(ctx, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	return entities, s.record(ctx, OperationDelete, entities, nil)
+}
+
+// Upsert creates or updates the entity and records its snapshots. The entity it conflicts with is looked up by the
+// values of the onConflict.Columns, or by ID without conflict columns, and the upsert is recorded as a creation when
+// there is none.
+func (s *Store[T, ID]) Upsert(ctx context.Context, entity T, onConflict store.OnConflict) (id ID, err error) {
+	ctx, err = s.Scope.Begin(ctx)
+	if err != nil {
+		return id, err
+	}
+
+	defer s.Scope.EndWithRecover(ctx, &err)
+
+	var (
+		targets = s.conflictTargets(entity, onConflict)
+		before  []T
+		after   []T
+	)
+
+	if len(targets) > 0 {
+		if before, err = s.Store.List(ctx, targets...); err != nil {
+			return id, err
+		}
+	}
+
+	if id, err = s.Store.Upsert(ctx, entity, onConflict); err != nil {
+		return id, err
+	}
+
+	if id != *new(ID) {
+		targets = []query.Param{s.byID(id)}
+	}
+
+	if len(targets) > 0 {
+		if after, err = s.Store.List(ctx, targets...); err != nil {
+			return id, err
+		}
+	}
+
+	op := OperationUpdate
+	if len(before) == 0 {
+		op = OperationCreate
+	}
+
+	return id, s.record(ctx, op, before, after)
+}
+
+// mutate runs fn inside the scope, snapshotting the entities matched by targets before and after it.
+func (s *Store[T, ID]) mutate(
+	ctx context.Context,
+	op Operation,
+	targets []query.Param,
+	fn func(ctx context.Context) error,
+) (err error) {
+	ctx, err = s.Scope.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer s.Scope.EndWithRecover(ctx, &err)
+
+	before, err := s.Store.List(ctx, targets...)
+	if err != nil {
+		return err
+	}
+
+	if err = fn(ctx); err != nil {
+		return err
+	}
+
+	var after []T
+
+	if op != OperationDelete {
+		if after, err = s.Store.List(ctx, s.afterTargets(before, targets)...); err != nil {
+			return err
+		}
+	}
+
+	return s.record(ctx, op, before, after)
+}
+
+// record pairs the before and after snapshots by entity ID and writes one change per entity.
+func (s *Store[T, ID]) record(ctx context.Context, op Operation, before, after []T) error {
+	var (
+		changes []*Change
+		seen    = make(map[ID]bool, len(after))
+		olds    = make(map[ID]T, len(before))
+	)
+
+	for _, entity := range before {
+		olds[entity.GetID()] = entity
+	}
+
+	for _, entity := range after {
+		id := entity.GetID()
+		seen[id] = true
+
+		var old any
+		if o, ok := olds[id]; ok {
+			old = o
+		}
+
+		change, err := s.newChange(ctx, op, id, old, entity)
+		if err != nil {
+			return err
+		}
+
+		changes = append(changes, change)
+	}
+
+	for _, entity := range before {
+		if seen[entity.GetID()] {
+			continue
+		}
+
+		change, err := s.newChange(ctx, op, entity.GetID(), entity, nil)
+		if err != nil {
+			return err
+		}
+
+		changes = append(changes, change)
+	}
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	return s.Changes.CreateMany(ctx, changes)
+}
+
+func (s *Store[T, ID]) newChange(ctx context.Context, op Operation, id ID, before, after any) (*Change, error) {
+	beforeJSON, err := marshal(before)
+	if err != nil {
+		return nil, err
+	}
+
+	afterJSON, err := marshal(after)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Change{
+		Entity:    s.options.name,
+		EntityID:  formatID(id),
+		Operation: op,
+		Actor:     s.options.actor(ctx),
+		Before:    beforeJSON,
+		After:     afterJSON,
+		ChangedAt: s.options.now(),
+	}, nil
+}
+
+// targets returns the params selecting the entities affected by an update.
+func (s *Store[T, ID]) targets(entity T, params []query.Param) []query.Param {
+	if len(params) > 0 {
+		return params
+	}
+
+	return []query.Param{s.byID(entity.GetID())}
+}
+
+// afterTargets returns the params selecting the updated entities once the update has been applied.
+// Entities are looked up by ID when possible because the update may have changed the filtered fields.
+func (s *Store[T, ID]) afterTargets(before []T, targets []query.Param) []query.Param {
+	if len(before) == 0 {
+		return targets
+	}
+
+	ids := make([]ID, len(before))
+	for i, entity := range before {
+		ids[i] = entity.GetID()
+	}
+
+	return []query.Param{query.Filter(s.options.idName, ids)}
+}
+
+// conflictTargets returns the params selecting the entity an upsert of entity conflicts with: the filters on the
+// fields of the entity matching the conflict columns, or on its ID when there are no conflict columns or one of them
+// matches no field. It returns no params when the ID is zero too.
+func (s *Store[T, ID]) conflictTargets(entity T, onConflict store.OnConflict) []query.Param {
+	if targets, ok := entityutil.ConflictFilters(entity, onConflict.Columns); ok {
+		return targets
+	}
+
+	if entity.GetID() == *new(ID) {
+		return nil
+	}
+
+	return []query.Param{s.byID(entity.GetID())}
+}
+
+func (s *Store[T, ID]) byID(id ID) query.Param {
+	return query.Filter(s.options.idName, id)
+}
+
+func marshal(v any) (json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(v)
+}
+
+func formatID(id any) string {
+	return fmt.Sprint(id)
+}
@@ -0,0 +1,279 @@
+package changelog_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infevocorp/goflexstore/changelog"
+	mockopscope "github.com/infevocorp/goflexstore/mocks/opscope"
+	mockstore "github.com/infevocorp/goflexstore/mocks/store"
+	"github.com/infevocorp/goflexstore/query"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+type User struct {
+	ID   int
+	Name string
+}
+
+func (u *User) GetID() int {
+	return u.ID
+}
+
+var now = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+type deps struct {
+	inner   *mockstore.Store[*User, int]
+	changes *mockstore.Store[*changelog.Change, int64]
+	scope   *mockopscope.Scope
+}
+
+func newStore(t *testing.T) (*changelog.Store[*User, int], deps) {
+	d := deps{
+		inner:   mockstore.NewStore[*User, int](t),
+		changes: mockstore.NewStore[*changelog.Change, int64](t),
+		scope:   mockopscope.NewScope(t),
+	}
+
+	d.scope.EXPECT().Begin(mock.Anything).RunAndReturn(func(ctx context.Context) (context.Context, error) {
+		return ctx, nil
+	}).Maybe()
+	d.scope.EXPECT().EndWithRecover(mock.Anything, mock.Anything).Maybe()
+
+	s := changelog.NewStore[*User, int](d.inner, d.changes, d.scope,
+		changelog.WithClock(func() time.Time { return now }),
+	)
+
+	return s, d
+}
+
+func Test_Store_Create(t *testing.T) {
+	// GIVEN
+	var (
+		s, d = newStore(t)
+		ctx  = changelog.WithActor(context.Background(), "admin")
+		user = &User{Name: "john"}
+	)
+
+	d.inner.EXPECT().Create(ctx, user).Return(1, nil)
+	d.changes.EXPECT().Create(ctx, &changelog.Change{
+		Entity:    "User",
+		EntityID:  "1",
+		Operation: changelog.OperationCreate,
+		Actor:     "admin",
+		After:     json.RawMessage(`{"ID":0,"Name":"john"}`),
+		ChangedAt: now,
+	}).Return(1, nil)
+
+	// WHEN
+	id, err := s.Create(ctx, user)
+
+	// THEN
+	require.NoError(t, err)
+	assert.Equal(t, 1, id)
+}
+
+func Test_Store_Update(t *testing.T) {
+	// GIVEN
+	var (
+		s, d = newStore(t)
+		ctx  = context.Background()
+		user = &User{ID: 1, Name: "jenny"}
+	)
+
+	d.inner.EXPECT().List(ctx, query.Filter("ID", 1)).Return([]*User{{ID: 1, Name: "john"}}, nil).Once()
+	d.inner.EXPECT().Update(ctx, user).Return(nil)
+	d.inner.EXPECT().List(ctx, query.Filter("ID", []int{1})).Return([]*User{user}, nil).Once()
+	d.changes.EXPECT().CreateMany(ctx, []*changelog.Change{
+		{
+			Entity:    "User",
+			EntityID:  "1",
+			Operation: changelog.OperationUpdate,
+			Before:    json.RawMessage(`{"ID":1,"Name":"john"}`),
+			After:     json.RawMessage(`{"ID":1,"Name":"jenny"}`),
+			ChangedAt: now,
+		},
+	}).Return(nil)
+
+	// WHEN
+	err := s.Update(ctx, user)
+
+	// THEN
+	require.NoError(t, err)
+}
+
+func Test_Store_Upsert(t *testing.T) {
+	t.Run("should-record-update-of-conflicting-entity", func(t *testing.T) {
+		// GIVEN
+		var (
+			s, d       = newStore(t)
+			ctx        = context.Background()
+			user       = &User{Name: "john"}
+			onConflict = store.OnConflict{Columns: []string{"name"}, UpdateAll: true}
+		)
+
+		d.inner.EXPECT().List(ctx, query.Filter("Name", "john")).Return([]*User{{ID: 1, Name: "john"}}, nil).Once()
+		d.inner.EXPECT().Upsert(ctx, user, onConflict).Return(1, nil)
+		d.inner.EXPECT().List(ctx, query.Filter("ID", 1)).Return([]*User{{ID: 1, Name: "john"}}, nil).Once()
+		d.changes.EXPECT().CreateMany(ctx, []*changelog.Change{
+			{
+				Entity:    "User",
+				EntityID:  "1",
+				Operation: changelog.OperationUpdate,
+				Before:    json.RawMessage(`{"ID":1,"Name":"john"}`),
+				After:     json.RawMessage(`{"ID":1,"Name":"john"}`),
+				ChangedAt: now,
+			},
+		}).Return(nil)
+
+		// WHEN
+		id, err := s.Upsert(ctx, user, onConflict)
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, 1, id)
+	})
+
+	t.Run("should-record-create-without-conflicting-entity", func(t *testing.T) {
+		// GIVEN
+		var (
+			s, d = newStore(t)
+			ctx  = context.Background()
+			user = &User{ID: 2, Name: "jenny"}
+		)
+
+		d.inner.EXPECT().List(ctx, query.Filter("ID", 2)).Return(nil, nil).Once()
+		d.inner.EXPECT().Upsert(ctx, user, store.OnConflict{}).Return(2, nil)
+		d.inner.EXPECT().List(ctx, query.Filter("ID", 2)).Return([]*User{user}, nil).Once()
+		d.changes.EXPECT().CreateMany(ctx, []*changelog.Change{
+			{
+				Entity:    "User",
+				EntityID:  "2",
+				Operation: changelog.OperationCreate,
+				After:     json.RawMessage(`{"ID":2,"Name":"jenny"}`),
+				ChangedAt: now,
+			},
+		}).Return(nil)
+
+		// WHEN
+		id, err := s.Upsert(ctx, user, store.OnConflict{})
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, 2, id)
+	})
+}
+
+func Test_Store_Delete(t *testing.T) {
+	t.Run("should-record-deleted-entities", func(t *testing.T) {
+		// GIVEN
+		var (
+			s, d   = newStore(t)
+			ctx    = context.Background()
+			filter = query.Filter("Name", "john")
+		)
+
+		d.inner.EXPECT().List(ctx, filter).Return([]*User{{ID: 1, Name: "john"}, {ID: 2, Name: "john"}}, nil)
+		d.inner.EXPECT().Delete(ctx, filter).Return(nil)
+		d.changes.EXPECT().CreateMany(ctx, mock.MatchedBy(func(changes []*changelog.Change) bool {
+			return len(changes) == 2 &&
+				changes[0].EntityID == "1" && changes[1].EntityID == "2" &&
+				changes[0].Operation == changelog.OperationDelete &&
+				changes[0].After == nil
+		})).Return(nil)
+
+		// WHEN
+		err := s.Delete(ctx, filter)
+
+		// THEN
+		require.NoError(t, err)
+	})
+
+	t.Run("should-not-record-if-delete-fails", func(t *testing.T) {
+		// GIVEN
+		var (
+			s, d = newStore(t)
+			ctx  = context.Background()
+		)
+
+		d.inner.EXPECT().List(ctx).Return([]*User{{ID: 1}}, nil)
+		d.inner.EXPECT().Delete(ctx).Return(assert.AnError)
+
+		// WHEN
+		err := s.Delete(ctx)
+
+		// THEN
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func Test_Store_DeleteReturning(t *testing.T) {
+	t.Run("should-record-returned-entities", func(t *testing.T) {
+		// GIVEN
+		var (
+			s, d   = newStore(t)
+			ctx    = context.Background()
+			filter = query.Filter("Name", "john")
+		)
+
+		d.inner.EXPECT().DeleteReturning(ctx, filter).Return([]*User{{ID: 1, Name: "john"}}, nil)
+		d.changes.EXPECT().CreateMany(ctx, mock.MatchedBy(func(changes []*changelog.Change) bool {
+			return len(changes) == 1 &&
+				changes[0].EntityID == "1" &&
+				changes[0].Operation == changelog.OperationDelete &&
+				changes[0].After == nil
+		})).Return(nil)
+
+		// WHEN
+		deleted, err := s.DeleteReturning(ctx, filter)
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, []*User{{ID: 1, Name: "john"}}, deleted)
+	})
+
+	t.Run("should-not-record-if-delete-fails", func(t *testing.T) {
+		// GIVEN
+		var (
+			s, d = newStore(t)
+			ctx  = context.Background()
+		)
+
+		d.inner.EXPECT().DeleteReturning(ctx).Return(nil, assert.AnError)
+
+		// WHEN
+		_, err := s.DeleteReturning(ctx)
+
+		// THEN
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func Test_Store_History(t *testing.T) {
+	// GIVEN
+	var (
+		s, d = newStore(t)
+		ctx  = context.Background()
+		want = []*changelog.Change{{ID: 1, Entity: "User", EntityID: "1"}}
+	)
+
+	d.changes.EXPECT().List(ctx,
+		query.Filter("Entity", "User"),
+		query.Filter("EntityID", "1"),
+		query.OrderBy("ChangedAt", false),
+		query.OrderBy("ID", false),
+	).Return(want, nil)
+
+	// WHEN
+	got, err := s.History(ctx, 1)
+
+	// THEN
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
@@ -0,0 +1,184 @@
+package circuitbreaker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// ErrCircuitOpen is returned by the operations rejected by an open circuit, without calling the database.
+var ErrCircuitOpen = errors.New("circuit open")
+
+// State is the state of the circuit of a Breaker.
+type State int
+
+const (
+	// StateClosed lets every operation through.
+	StateClosed State = iota
+	// StateOpen rejects every operation with ErrCircuitOpen.
+	StateOpen
+	// StateHalfOpen lets a single operation through to probe the database.
+	StateHalfOpen
+)
+
+// String returns the name of the state.
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// Breaker is a circuit breaker shared by the stores and scopes of a database, see New.
+type Breaker struct {
+	threshold     int
+	openTimeout   time.Duration
+	isFailure     func(err error) bool
+	onStateChange func(from, to State)
+	now           func() time.Time
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// New creates a closed Breaker.
+func New(opts ...Option) *Breaker {
+	b := &Breaker{
+		threshold:   5,
+		openTimeout: 30 * time.Second,
+		isFailure:   isFailure,
+		now:         time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	if b.threshold < 1 {
+		b.threshold = 1
+	}
+
+	return b
+}
+
+// State returns the current state of the circuit.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.halfOpenIfExpired()
+
+	return b.state
+}
+
+// Do calls fn unless the circuit is open, in which case it returns ErrCircuitOpen, and records its outcome.
+func (b *Breaker) Do(fn func() error) error {
+	if err := b.allow(); err != nil {
+		return err
+	}
+
+	err := fn()
+	b.record(err)
+
+	return err
+}
+
+// Middleware returns a store.Middleware failing the operations with ErrCircuitOpen while the circuit is open.
+// Ping is neither rejected nor recorded, so that health checks keep reporting the state of the database.
+//
+// Example:
+//
+//	articleStore := store.Chain[*model.Article, int64](inner, breaker.Middleware())
+func (b *Breaker) Middleware() store.Middleware {
+	return func(next store.OperationFunc) store.OperationFunc {
+		return func(ctx context.Context, op *store.Operation) (any, error) {
+			if op.Method == store.MethodPing {
+				return next(ctx, op)
+			}
+
+			var result any
+
+			err := b.Do(func() error {
+				var err error
+				result, err = next(ctx, op)
+
+				return err
+			})
+
+			return result, err
+		}
+	}
+}
+
+// allow returns ErrCircuitOpen if the circuit rejects the operations.
+func (b *Breaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.halfOpenIfExpired()
+
+	switch {
+	case b.state == StateOpen:
+		return ErrCircuitOpen
+	case b.state == StateHalfOpen && b.probing:
+		return ErrCircuitOpen
+	case b.state == StateHalfOpen:
+		b.probing = true
+	}
+
+	return nil
+}
+
+// record records the outcome of an operation let through by allow.
+func (b *Breaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+
+	if err == nil || !b.isFailure(err) {
+		b.failures = 0
+		b.setState(StateClosed)
+
+		return
+	}
+
+	b.failures++
+
+	if b.state == StateHalfOpen || b.failures >= b.threshold {
+		b.openedAt = b.now()
+		b.setState(StateOpen)
+	}
+}
+
+// halfOpenIfExpired moves an open circuit to half-open once its open timeout elapsed.
+func (b *Breaker) halfOpenIfExpired() {
+	if b.state == StateOpen && b.now().Sub(b.openedAt) >= b.openTimeout {
+		b.setState(StateHalfOpen)
+	}
+}
+
+func (b *Breaker) setState(state State) {
+	if b.state == state {
+		return
+	}
+
+	from := b.state
+	b.state = state
+
+	if b.onStateChange != nil {
+		b.onStateChange(from, state)
+	}
+}
@@ -0,0 +1,169 @@
+package circuitbreaker_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infevocorp/goflexstore/circuitbreaker"
+	mockopscope "github.com/infevocorp/goflexstore/mocks/opscope"
+	mockstore "github.com/infevocorp/goflexstore/mocks/store"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+type Article struct {
+	ID int
+}
+
+func (a *Article) GetID() int {
+	return a.ID
+}
+
+// clock returns a clock starting at a fixed time, and a function advancing it.
+func clock() (func() time.Time, func(time.Duration)) {
+	now := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	return func() time.Time { return now }, func(d time.Duration) { now = now.Add(d) }
+}
+
+func Test_Breaker_Middleware(t *testing.T) {
+	t.Run("should-open-after-consecutive-failures-and-close-after-successful-probe", func(t *testing.T) {
+		// GIVEN
+		var (
+			ctx          = context.Background()
+			inner        = mockstore.NewStore[*Article, int](t)
+			now, advance = clock()
+			dbErr        = errors.New("too many connections")
+			changes      []string
+		)
+
+		breaker := circuitbreaker.New(
+			circuitbreaker.WithFailureThreshold(2),
+			circuitbreaker.WithOpenTimeout(10*time.Second),
+			circuitbreaker.WithClock(now),
+			circuitbreaker.WithOnStateChange(func(from, to circuitbreaker.State) {
+				changes = append(changes, from.String()+"->"+to.String())
+			}),
+		)
+
+		s := store.Chain[*Article, int](inner, breaker.Middleware())
+
+		inner.EXPECT().Count(ctx).Return(0, dbErr).Twice()
+
+		_, err := s.Count(ctx)
+		require.ErrorIs(t, err, dbErr)
+		_, err = s.Count(ctx)
+		require.ErrorIs(t, err, dbErr)
+
+		// WHEN
+		_, err = s.Count(ctx)
+
+		// THEN
+		assert.ErrorIs(t, err, circuitbreaker.ErrCircuitOpen)
+		assert.Equal(t, circuitbreaker.StateOpen, breaker.State())
+
+		advance(10 * time.Second)
+		assert.Equal(t, circuitbreaker.StateHalfOpen, breaker.State())
+
+		inner.EXPECT().Count(ctx).Return(3, nil).Once()
+
+		count, err := s.Count(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), count)
+		assert.Equal(t, circuitbreaker.StateClosed, breaker.State())
+		assert.Equal(t, []string{"closed->open", "open->half-open", "half-open->closed"}, changes)
+	})
+
+	t.Run("should-reopen-after-failed-probe", func(t *testing.T) {
+		// GIVEN
+		var (
+			ctx          = context.Background()
+			inner        = mockstore.NewStore[*Article, int](t)
+			now, advance = clock()
+			dbErr        = errors.New("too many connections")
+		)
+
+		breaker := circuitbreaker.New(
+			circuitbreaker.WithFailureThreshold(1),
+			circuitbreaker.WithOpenTimeout(time.Second),
+			circuitbreaker.WithClock(now),
+		)
+
+		s := store.Chain[*Article, int](inner, breaker.Middleware())
+
+		inner.EXPECT().List(ctx).Return(nil, dbErr).Twice()
+
+		_, err := s.List(ctx)
+		require.ErrorIs(t, err, dbErr)
+
+		advance(time.Second)
+
+		// WHEN
+		_, err = s.List(ctx)
+
+		// THEN
+		assert.ErrorIs(t, err, dbErr)
+		assert.Equal(t, circuitbreaker.StateOpen, breaker.State())
+
+		_, err = s.List(ctx)
+		assert.ErrorIs(t, err, circuitbreaker.ErrCircuitOpen)
+	})
+
+	t.Run("should-not-count-not-found-and-ping", func(t *testing.T) {
+		// GIVEN
+		var (
+			ctx   = context.Background()
+			inner = mockstore.NewStore[*Article, int](t)
+		)
+
+		breaker := circuitbreaker.New(circuitbreaker.WithFailureThreshold(1))
+		s := store.Chain[*Article, int](inner, breaker.Middleware())
+
+		inner.EXPECT().Get(ctx).Return(nil, store.ErrNotFound).Once()
+		inner.EXPECT().Ping(ctx).Return(errors.New("connection refused")).Twice()
+
+		// WHEN
+		_, getErr := s.Get(ctx)
+		pingErr := s.Ping(ctx)
+		pingErr2 := s.Ping(ctx)
+
+		// THEN
+		assert.ErrorIs(t, getErr, store.ErrNotFound)
+		assert.EqualError(t, pingErr, "connection refused")
+		assert.EqualError(t, pingErr2, "connection refused")
+		assert.Equal(t, circuitbreaker.StateClosed, breaker.State())
+	})
+}
+
+func Test_Breaker_Scope(t *testing.T) {
+	t.Run("should-fail-begin-fast-when-open", func(t *testing.T) {
+		// GIVEN
+		var (
+			ctx   = context.Background()
+			inner = mockopscope.NewScope(t)
+		)
+
+		breaker := circuitbreaker.New(circuitbreaker.WithFailureThreshold(1))
+		scope := breaker.Scope(inner)
+
+		inner.EXPECT().Begin(ctx).Return(ctx, errors.New("connection refused")).Once()
+
+		_, err := scope.Begin(ctx)
+		require.EqualError(t, err, "connection refused")
+
+		// WHEN
+		_, err = scope.Begin(ctx)
+
+		// THEN
+		assert.ErrorIs(t, err, circuitbreaker.ErrCircuitOpen)
+
+		_, ok := scope.(interface {
+			OnCommit(ctx context.Context, fn func(ctx context.Context))
+		})
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,26 @@
+// Package circuitbreaker fails fast the operations of stores while their database is struggling, instead of
+// piling up requests on it during an incident.
+//
+// A Breaker counts the consecutive failures of the operations it protects. Once they reach the threshold, the
+// circuit opens: operations fail with ErrCircuitOpen without reaching the database. After the open timeout, the
+// circuit is half-open and lets a single operation through to probe the database: its success closes the circuit,
+// its failure opens it again.
+//
+// The Middleware of a Breaker decorates stores composed with store.Chain, and Scope protects the Begin of an
+// operation scope, e.g. a gormopscope.TransactionScope. The same Breaker can protect every store and scope of a
+// database, so that the failures of any of them open the circuit of all of them.
+//
+// Example:
+//
+//	breaker := circuitbreaker.New(
+//		circuitbreaker.WithFailureThreshold(10),
+//		circuitbreaker.WithOpenTimeout(15*time.Second),
+//	)
+//
+//	scope := breaker.Scope(gormopscope.NewWriteTransactionScope("writeTx", db))
+//	articleStore := store.Chain[*model.Article, int64](inner, breaker.Middleware())
+//
+//	if _, err := articleStore.List(ctx); errors.Is(err, circuitbreaker.ErrCircuitOpen) {
+//		w.WriteHeader(http.StatusServiceUnavailable)
+//	}
+package circuitbreaker
@@ -0,0 +1,57 @@
+package circuitbreaker
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// Option is a function that configures the Breaker.
+type Option func(*Breaker)
+
+// WithFailureThreshold sets the number of consecutive failures opening the circuit. It defaults to 5.
+func WithFailureThreshold(n int) Option {
+	return func(b *Breaker) {
+		b.threshold = n
+	}
+}
+
+// WithOpenTimeout sets how long the circuit stays open before letting an operation probe the database.
+// It defaults to 30 seconds.
+func WithOpenTimeout(timeout time.Duration) Option {
+	return func(b *Breaker) {
+		b.openTimeout = timeout
+	}
+}
+
+// WithFailurePredicate sets the function reporting whether the error of an operation is a failure of the database.
+// By default, every error is a failure except store.ErrNotFound and the cancellation of the context by the
+// caller, which do not tell anything about the health of the database.
+func WithFailurePredicate(isFailure func(err error) bool) Option {
+	return func(b *Breaker) {
+		b.isFailure = isFailure
+	}
+}
+
+// WithOnStateChange sets a function called when the circuit changes state, e.g. to log or alert on its opening.
+// It is called while the Breaker is locked, so it must not call the Breaker.
+func WithOnStateChange(fn func(from, to State)) Option {
+	return func(b *Breaker) {
+		b.onStateChange = fn
+	}
+}
+
+// WithClock sets the function returning the current time, e.g. to control the open timeout in tests.
+// It defaults to time.Now.
+func WithClock(now func() time.Time) Option {
+	return func(b *Breaker) {
+		b.now = now
+	}
+}
+
+// isFailure is the default failure predicate, see WithFailurePredicate.
+func isFailure(err error) bool {
+	return !errors.Is(err, store.ErrNotFound) && !errors.Is(err, context.Canceled)
+}
@@ -0,0 +1,50 @@
+package circuitbreaker
+
+import (
+	"context"
+
+	"github.com/infevocorp/goflexstore/opscope"
+)
+
+// Scope returns an operation scope whose Begin fails with ErrCircuitOpen while the circuit is open, and records
+// the outcome of the Begin of inner otherwise, e.g. the failure to open a connection. End and EndWithRecover are
+// forwarded to inner. The returned scope implements opscope.CommitNotifier if inner does.
+//
+// Example:
+//
+//	scope := breaker.Scope(gormopscope.NewWriteTransactionScope("writeTx", db))
+func (b *Breaker) Scope(inner opscope.Scope) opscope.Scope {
+	s := &scope{Scope: inner, breaker: b}
+
+	if notifier, ok := inner.(opscope.CommitNotifier); ok {
+		return &notifierScope{scope: s, CommitNotifier: notifier}
+	}
+
+	return s
+}
+
+// scope is the opscope.Scope returned by Breaker.Scope.
+type scope struct {
+	opscope.Scope
+	breaker *Breaker
+}
+
+// Begin begins inner unless the circuit is open.
+func (s *scope) Begin(ctx context.Context) (context.Context, error) {
+	result := ctx
+
+	err := s.breaker.Do(func() error {
+		var err error
+		result, err = s.Scope.Begin(ctx)
+
+		return err
+	})
+
+	return result, err
+}
+
+// notifierScope is the scope returned by Breaker.Scope for the scopes implementing opscope.CommitNotifier.
+type notifierScope struct {
+	*scope
+	opscope.CommitNotifier
+}
@@ -0,0 +1,125 @@
+// Command flexstore generates the boilerplate needed to expose entities through flex stores.
+//
+// Usage:
+//
+//	flexstore new [flags] <Entity>
+//
+// Flags:
+//
+//	-module string   import path of the target module (defaults to the module declared in ./go.mod)
+//	-dir string      root directory of the target module (default ".")
+//	-fields string   comma separated list of Name:type fields, e.g. "Title:string,AuthorID:int64"
+//	-id string       type of the entity's identifier (default "int64")
+//	-force           overwrite existing files
+//	-dry-run         print the generated files instead of writing them
+//
+// Example:
+//
+//	flexstore new -fields "Title:string,Content:string,AuthorID:int64" Article
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/infevocorp/goflexstore/cmd/flexstore/scaffold"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "flexstore:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	if len(args) == 0 || args[0] != "new" {
+		return errors.New("usage: flexstore new [flags] <Entity>")
+	}
+
+	fs := flag.NewFlagSet("new", flag.ContinueOnError)
+
+	var (
+		module = fs.String("module", "", "import path of the target module")
+		dir    = fs.String("dir", ".", "root directory of the target module")
+		fields = fs.String("fields", "", "comma separated list of Name:type fields")
+		idType = fs.String("id", "int64", "type of the entity's identifier")
+		force  = fs.Bool("force", false, "overwrite existing files")
+		dryRun = fs.Bool("dry-run", false, "print the generated files instead of writing them")
+	)
+
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	if fs.NArg() != 1 {
+		return errors.New("exactly one entity name is required")
+	}
+
+	if *module == "" {
+		m, err := readModulePath(filepath.Join(*dir, "go.mod"))
+		if err != nil {
+			return errors.Wrap(err, "cannot detect module, use -module")
+		}
+
+		*module = m
+	}
+
+	parsedFields, err := scaffold.ParseFields(*fields)
+	if err != nil {
+		return err
+	}
+
+	cfg := scaffold.Config{
+		Module: *module,
+		Entity: fs.Arg(0),
+		IDType: *idType,
+		Fields: parsedFields,
+	}
+
+	files, err := scaffold.Generate(cfg)
+	if err != nil {
+		return err
+	}
+
+	if *dryRun {
+		for _, f := range files {
+			fmt.Printf("// %s\n%s\n", f.Path, f.Content)
+		}
+	} else {
+		if err := scaffold.Write(*dir, files, *force); err != nil {
+			return err
+		}
+
+		for _, f := range files {
+			fmt.Println("created", f.Path)
+		}
+	}
+
+	fmt.Printf("\nRegister the store:\n\n%s", scaffold.RegistrationSnippet(cfg))
+
+	return nil
+}
+
+func readModulePath(goMod string) (string, error) {
+	f, err := os.Open(goMod)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); strings.HasPrefix(line, "module ") {
+			return strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "module ")), `"`), nil
+		}
+	}
+
+	return "", errors.Errorf("no module directive in %s", goMod)
+}
@@ -0,0 +1,14 @@
+// Package scaffold generates the boilerplate needed to expose an entity through flex stores.
+//
+// For an entity it renders the model, the store interface, the GORM DTO, the typed filters, a manual
+// converter stub and the GORM store wiring, following the layout of the CMS example:
+//
+//	model/<entity>.go
+//	store/<entity>.go
+//	filters/<entity>.go
+//	store/sql/dto/<entity>.go
+//	store/sql/<entity>.go
+//	store/sql/<entity>_converter.go
+//
+// It is used by the flexstore command but can also be embedded in custom generators.
+package scaffold
@@ -0,0 +1,208 @@
+package scaffold
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm/schema"
+)
+
+// Field describes a field of the generated entity.
+//
+// Fields:
+//   - Name: The Go name of the field, e.g. "AuthorID".
+//   - Type: The Go type of the field, e.g. "int64".
+type Field struct {
+	Name string
+	Type string
+}
+
+// Column returns the database column name of the field, e.g. "author_id" for "AuthorID".
+func (f Field) Column() string {
+	return SnakeCase(f.Name)
+}
+
+// Config describes the entity to generate.
+//
+// Fields:
+//   - Module: The import path of the module the files are generated in, e.g. "github.com/acme/cms".
+//   - Entity: The name of the entity, e.g. "Article".
+//   - IDType: The Go type of the entity's identifier. Defaults to "int64".
+//   - Fields: The fields of the entity besides ID.
+type Config struct {
+	Module string
+	Entity string
+	IDType string
+	Fields []Field
+}
+
+// File is a generated source file.
+//
+// Fields:
+//   - Path: The path of the file, relative to the module root.
+//   - Content: The formatted Go source.
+type File struct {
+	Path    string
+	Content []byte
+}
+
+// ParseFields parses a comma separated list of `Name:type` pairs, e.g. "Title:string,AuthorID:int64".
+func ParseFields(s string) ([]Field, error) {
+	var fields []Field
+
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		name, typ, ok := strings.Cut(part, ":")
+		if !ok || name == "" || typ == "" {
+			return nil, errors.Errorf("invalid field %q, expected Name:type", part)
+		}
+
+		fields = append(fields, Field{Name: name, Type: typ})
+	}
+
+	return fields, nil
+}
+
+// Generate renders the files of the entity described by cfg.
+//
+// Returns:
+// The generated files, or an error if the configuration is invalid.
+func Generate(cfg Config) ([]File, error) {
+	if cfg.Module == "" {
+		return nil, errors.New("module is required")
+	}
+
+	if cfg.Entity == "" || !unicode.IsUpper([]rune(cfg.Entity)[0]) {
+		return nil, errors.Errorf("entity %q must be an exported Go identifier", cfg.Entity)
+	}
+
+	if cfg.IDType == "" {
+		cfg.IDType = "int64"
+	}
+
+	data := templateData{
+		Config: cfg,
+		File:   SnakeCase(cfg.Entity),
+		Var:    lowerFirst(cfg.Entity),
+		Recv:   strings.ToLower(cfg.Entity[:1]),
+		Table:  schema.NamingStrategy{}.TableName(cfg.Entity),
+	}
+
+	files := make([]File, 0, len(templates))
+
+	for _, t := range templates {
+		var buf bytes.Buffer
+
+		if err := t.tmpl.Execute(&buf, data); err != nil {
+			return nil, errors.Wrapf(err, "cannot render %s", t.path)
+		}
+
+		src, err := format.Source(buf.Bytes())
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot format %s", t.path)
+		}
+
+		files = append(files, File{
+			Path:    fmt.Sprintf(t.path, data.File),
+			Content: src,
+		})
+	}
+
+	return files, nil
+}
+
+// Write writes the files under dir, readable by everyone like the other source files. Existing files are only
+// overwritten if force is true.
+func Write(dir string, files []File, force bool) error {
+	for _, f := range files {
+		path := filepath.Join(dir, f.Path)
+
+		if _, err := os.Stat(path); err == nil && !force {
+			return errors.Errorf("%s already exists", path)
+		}
+
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+
+		if err := os.WriteFile(path, f.Content, 0o644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RegistrationSnippet returns the code to add to the Stores struct and its constructor to register the store.
+func RegistrationSnippet(cfg Config) string {
+	return fmt.Sprintf(`// store/store.go
+type Stores struct {
+	// ...
+	%[1]s %[1]sStore
+}
+
+// store/sql/store.go
+func NewStores(scope *gormopscope.TransactionScope) store.Stores {
+	return store.Stores{
+		// ...
+		%[1]s: New%[1]sStore(scope),
+	}
+}
+`, cfg.Entity)
+}
+
+// SnakeCase converts a Go identifier to snake case, keeping initialisms together, e.g. "AuthorID" to "author_id".
+func SnakeCase(s string) string {
+	var (
+		sb    strings.Builder
+		runes = []rune(s)
+	)
+
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			prevLower := i > 0 && !unicode.IsUpper(runes[i-1])
+			nextLower := i > 0 && i+1 < len(runes) && unicode.IsLower(runes[i+1])
+
+			if prevLower || nextLower {
+				sb.WriteRune('_')
+			}
+
+			r = unicode.ToLower(r)
+		}
+
+		sb.WriteRune(r)
+	}
+
+	return sb.String()
+}
+
+func lowerFirst(s string) string {
+	runes := []rune(s)
+	runes[0] = unicode.ToLower(runes[0])
+
+	return string(runes)
+}
+
+type templateData struct {
+	Config
+	File  string
+	Var   string
+	Recv  string
+	Table string
+}
+
+type fileTemplate struct {
+	path string
+	tmpl *template.Template
+}
@@ -0,0 +1,125 @@
+package scaffold_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infevocorp/goflexstore/cmd/flexstore/scaffold"
+)
+
+func Test_SnakeCase(t *testing.T) {
+	tests := map[string]string{
+		"ID":        "id",
+		"Title":     "title",
+		"AuthorID":  "author_id",
+		"CreatedAt": "created_at",
+		"HTTPCode":  "http_code",
+	}
+
+	for in, want := range tests {
+		assert.Equal(t, want, scaffold.SnakeCase(in), in)
+	}
+}
+
+func Test_ParseFields(t *testing.T) {
+	t.Run("should-parse-fields", func(t *testing.T) {
+		fields, err := scaffold.ParseFields("Title:string, AuthorID:int64,")
+
+		require.NoError(t, err)
+		assert.Equal(t, []scaffold.Field{
+			{Name: "Title", Type: "string"},
+			{Name: "AuthorID", Type: "int64"},
+		}, fields)
+	})
+
+	t.Run("should-return-err-if-invalid", func(t *testing.T) {
+		_, err := scaffold.ParseFields("Title")
+
+		assert.Error(t, err)
+	})
+}
+
+func Test_Generate(t *testing.T) {
+	t.Run("should-generate-files", func(t *testing.T) {
+		// GIVEN
+		cfg := scaffold.Config{
+			Module: "example.com/cms",
+			Entity: "BlogPost",
+			Fields: []scaffold.Field{{Name: "AuthorID", Type: "int64"}},
+		}
+
+		// WHEN
+		files, err := scaffold.Generate(cfg)
+
+		// THEN
+		require.NoError(t, err)
+
+		paths := make([]string, len(files))
+		for i, f := range files {
+			paths[i] = f.Path
+		}
+
+		assert.Equal(t, []string{
+			"model/blog_post.go",
+			"store/blog_post.go",
+			"filters/blog_post.go",
+			"store/sql/dto/blog_post.go",
+			"store/sql/blog_post.go",
+			"store/sql/blog_post_converter.go",
+		}, paths)
+		assert.Contains(t, string(files[0].Content), "func (b *BlogPost) GetID() int64 {")
+		assert.Contains(t, string(files[2].Content), `func BlogPostAuthorID(values ...int64) query.FilterParam {`)
+		assert.Contains(t, string(files[3].Content), "`gorm:\"column:author_id\"`")
+		assert.Contains(t, string(files[3].Content), `return "blog_posts"`)
+		assert.Contains(t, string(files[4].Content), `"example.com/cms/store/sql/dto"`)
+	})
+
+	t.Run("should-name-tables-like-gorm", func(t *testing.T) {
+		for entity, table := range map[string]string{
+			"Category": "categories",
+			"Status":   "statuses",
+			"APIKey":   "api_keys",
+		} {
+			// WHEN
+			files, err := scaffold.Generate(scaffold.Config{Module: "example.com/cms", Entity: entity})
+
+			// THEN
+			require.NoError(t, err)
+			assert.Contains(t, string(files[3].Content), `return "`+table+`"`, entity)
+		}
+	})
+
+	t.Run("should-return-err-if-entity-is-not-exported", func(t *testing.T) {
+		_, err := scaffold.Generate(scaffold.Config{Module: "example.com/cms", Entity: "post"})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("should-return-err-if-module-is-missing", func(t *testing.T) {
+		_, err := scaffold.Generate(scaffold.Config{Entity: "Post"})
+
+		assert.Error(t, err)
+	})
+}
+
+func Test_Write(t *testing.T) {
+	dir := t.TempDir()
+	files := []scaffold.File{{Path: "model/post.go", Content: []byte("package model\n")}}
+
+	require.NoError(t, scaffold.Write(dir, files, false))
+
+	content, err := os.ReadFile(filepath.Join(dir, "model/post.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "package model\n", string(content))
+
+	info, err := os.Stat(filepath.Join(dir, "model/post.go"))
+	require.NoError(t, err)
+	assert.NotZero(t, info.Mode().Perm()&0o044, "should be readable by group and others")
+
+	assert.Error(t, scaffold.Write(dir, files, false), "should not overwrite")
+	assert.NoError(t, scaffold.Write(dir, files, true))
+}
@@ -0,0 +1,130 @@
+package scaffold
+
+import "text/template"
+
+var templates = []fileTemplate{
+	newTemplate("model/%s.go", `package model
+
+type {{.Entity}} struct {
+	ID {{.IDType}}
+{{- range .Fields}}
+	{{.Name}} {{.Type}}
+{{- end}}
+}
+
+func ({{.Recv}} *{{.Entity}}) GetID() {{.IDType}} {
+	return {{.Recv}}.ID
+}
+`),
+	newTemplate("store/%s.go", `package store
+
+import (
+	"github.com/infevocorp/goflexstore/store"
+
+	"{{.Module}}/model"
+)
+
+// {{.Entity}}Store is a store for {{.Var}} entities.
+type {{.Entity}}Store interface {
+	store.Store[*model.{{.Entity}}, {{.IDType}}]
+}
+`),
+	newTemplate("filters/%s.go", `package filters
+
+import "github.com/infevocorp/goflexstore/query"
+
+// {{.Entity}}IDs filters {{.Var}} entities by ID.
+func {{.Entity}}IDs(ids ...{{.IDType}}) query.FilterParam {
+	return query.Filter("ID", ids)
+}
+{{range .Fields}}
+// {{$.Entity}}{{.Name}} filters {{$.Var}} entities by {{.Name}}.
+func {{$.Entity}}{{.Name}}(values ...{{.Type}}) query.FilterParam {
+	return query.Filter("{{.Name}}", values)
+}
+
+var Get{{$.Entity}}{{.Name}} = query.FilterGetter("{{.Name}}")
+{{end}}`),
+	newTemplate("store/sql/dto/%s.go", `package dto
+
+type {{.Entity}} struct {
+	ID {{.IDType}} `+"`"+`gorm:"column:id;primaryKey"`+"`"+`
+{{- range .Fields}}
+	{{.Name}} {{.Type}} `+"`"+`gorm:"column:{{.Column}}"`+"`"+`
+{{- end}}
+}
+
+func ({{.Entity}}) TableName() string {
+	return "{{.Table}}"
+}
+
+func ({{.Recv}} *{{.Entity}}) GetID() {{.IDType}} {
+	return {{.Recv}}.ID
+}
+`),
+	newTemplate("store/sql/%s.go", `package sql
+
+import (
+	gormopscope "github.com/infevocorp/goflexstore/gorm/opscope"
+	gormquery "github.com/infevocorp/goflexstore/gorm/query"
+	gormstore "github.com/infevocorp/goflexstore/gorm/store"
+	gormutils "github.com/infevocorp/goflexstore/gorm/utils"
+
+	"{{.Module}}/model"
+	"{{.Module}}/store/sql/dto"
+)
+
+type {{.Entity}}Store struct {
+	*gormstore.Store[*model.{{.Entity}}, *dto.{{.Entity}}, {{.IDType}}]
+}
+
+func New{{.Entity}}Store(scope *gormopscope.TransactionScope) *{{.Entity}}Store {
+	return &{{.Entity}}Store{
+		Store: gormstore.New[*model.{{.Entity}}, *dto.{{.Entity}}, {{.IDType}}](
+			scope,
+			gormstore.WithConverter[*model.{{.Entity}}, *dto.{{.Entity}}, {{.IDType}}](new{{.Entity}}Converter()),
+			gormstore.WithScopeBuilderOption[*model.{{.Entity}}, *dto.{{.Entity}}, {{.IDType}}](
+				gormquery.WithFieldToColMap(gormutils.FieldToColMap(dto.{{.Entity}}{})),
+			),
+		),
+	}
+}
+`),
+	newTemplate("store/sql/%s_converter.go", `package sql
+
+import (
+	"github.com/infevocorp/goflexstore/converter"
+
+	"{{.Module}}/model"
+	"{{.Module}}/store/sql/dto"
+)
+
+func new{{.Entity}}Converter() converter.Converter[*model.{{.Entity}}, *dto.{{.Entity}}, {{.IDType}}] {
+	return converter.NewManual[*model.{{.Entity}}, *dto.{{.Entity}}, {{.IDType}}](
+		func(d *dto.{{.Entity}}) *model.{{.Entity}} {
+			return &model.{{.Entity}}{
+				ID: d.ID,
+			{{- range .Fields}}
+				{{.Name}}: d.{{.Name}},
+			{{- end}}
+			}
+		},
+		func(m *model.{{.Entity}}) *dto.{{.Entity}} {
+			return &dto.{{.Entity}}{
+				ID: m.ID,
+			{{- range .Fields}}
+				{{.Name}}: m.{{.Name}},
+			{{- end}}
+			}
+		},
+	)
+}
+`),
+}
+
+func newTemplate(path, text string) fileTemplate {
+	return fileTemplate{
+		path: path,
+		tmpl: template.Must(template.New(path).Parse(text)),
+	}
+}
@@ -36,15 +36,30 @@ type Converter[Entity store.Entity[ID], DTO store.Entity[ID], ID comparable] int
 //
 // Returns:
 // A slice of type B with each item converted from type A using the provided conversion function.
+// The result is allocated once with the length of items. It is nil when items is empty,
+// use ToManyNonNil when an empty, non-nil slice is required (e.g. to encode `[]` instead of `null` in JSON).
 //
 // This function is useful in situations where you have a collection of items of one type that
 // need to be transformed into another type, such as converting a slice of database entities
 // into a slice of DTOs for API responses.
 func ToMany[A any, B any](items []A, convFn func(A) B) []B {
-	var result []B
-	for _, item := range items {
-		result = append(result, convFn(item))
+	if len(items) == 0 {
+		return nil
+	}
+
+	result := make([]B, len(items))
+	for i, item := range items {
+		result[i] = convFn(item)
 	}
 
 	return result
 }
+
+// ToManyNonNil behaves like ToMany but returns an empty, non-nil slice when items is empty.
+func ToManyNonNil[A any, B any](items []A, convFn func(A) B) []B {
+	if result := ToMany(items, convFn); result != nil {
+		return result
+	}
+
+	return []B{}
+}
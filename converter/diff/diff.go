@@ -0,0 +1,141 @@
+package diff
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Change is a field whose value differs between two versions of an entity, see Changes.
+//
+// Fields:
+//   - Field: The name of the field, e.g. "Title". Fields promoted from embedded structs have their own name.
+//   - Old: The value of the field in the old version, or nil if the old version is a nil pointer.
+//   - New: The value of the field in the new version, or nil if the new version is a nil pointer.
+type Change struct {
+	Field string
+	Old   any
+	New   any
+}
+
+// String describes the change, e.g. `Title: "Draft" -> "Hello"`.
+func (c Change) String() string {
+	return fmt.Sprintf("%s: %#v -> %#v", c.Field, c.Old, c.New)
+}
+
+// Changes returns the exported fields whose value differs between old and new, in the order of their
+// declaration. T is a struct or a pointer to a struct; a nil pointer stands for a missing version, such as
+// the old version of a created entity, and every field set in the other version is reported as changed.
+//
+// Values are compared with their Equal method when they have one, such as time.Time, and with
+// reflect.DeepEqual otherwise. Changes returns nil if T is not a struct or pointer to a struct.
+func Changes[T any](old, new T) []Change {
+	var (
+		oldValue, oldOK = structValue(reflect.ValueOf(&old).Elem())
+		newValue, newOK = structValue(reflect.ValueOf(&new).Elem())
+	)
+
+	if !oldOK && !newOK {
+		return nil
+	}
+
+	t := oldValue.Type()
+	if !oldOK {
+		t = newValue.Type()
+	}
+
+	var changes []Change
+
+	for _, field := range fieldsOf(t) {
+		var (
+			change = Change{Field: field.name}
+			before reflect.Value
+			after  reflect.Value
+		)
+
+		if oldOK {
+			before = oldValue.FieldByIndex(field.index)
+			change.Old = before.Interface()
+		}
+
+		if newOK {
+			after = newValue.FieldByIndex(field.index)
+			change.New = after.Interface()
+		}
+
+		if !oldOK && after.IsZero() || !newOK && before.IsZero() {
+			continue
+		}
+
+		if oldOK && newOK && equal(field, before, after) {
+			continue
+		}
+
+		changes = append(changes, change)
+	}
+
+	return changes
+}
+
+// field is the metadata of a compared field, cached by type in fieldCache.
+type field struct {
+	name  string
+	index []int
+	equal reflect.Value
+}
+
+var fieldCache sync.Map // map[reflect.Type][]field
+
+// fieldsOf returns the exported fields of the struct type, including the fields promoted from embedded structs
+// instead of the embedded structs themselves.
+func fieldsOf(t reflect.Type) []field {
+	if cached, ok := fieldCache.Load(t); ok {
+		return cached.([]field)
+	}
+
+	var fields []field
+
+	for _, sf := range reflect.VisibleFields(t) {
+		if !sf.IsExported() || sf.Anonymous && sf.Type.Kind() == reflect.Struct {
+			continue
+		}
+
+		f := field{name: sf.Name, index: sf.Index}
+
+		if method, ok := sf.Type.MethodByName("Equal"); ok && isEqualMethod(method.Type, sf.Type) {
+			f.equal = method.Func
+		}
+
+		fields = append(fields, f)
+	}
+
+	cached, _ := fieldCache.LoadOrStore(t, fields)
+
+	return cached.([]field)
+}
+
+// isEqualMethod reports whether the method of type t has the signature func(t, t) bool.
+func isEqualMethod(method, t reflect.Type) bool {
+	return method.NumIn() == 2 && method.In(1) == t && method.NumOut() == 1 && method.Out(0).Kind() == reflect.Bool
+}
+
+func equal(f field, a, b reflect.Value) bool {
+	if f.equal.IsValid() && (a.Kind() != reflect.Pointer || !a.IsNil() && !b.IsNil()) {
+		return f.equal.Call([]reflect.Value{a, b})[0].Bool()
+	}
+
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
+
+// structValue dereferences the value down to a struct, returning false if it is a nil pointer or not a struct.
+func structValue(v reflect.Value) (reflect.Value, bool) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return v, false
+		}
+
+		v = v.Elem()
+	}
+
+	return v, v.Kind() == reflect.Struct
+}
@@ -0,0 +1,100 @@
+package diff_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infevocorp/goflexstore/converter/diff"
+)
+
+type Audit struct {
+	UpdatedAt time.Time
+}
+
+type Article struct {
+	Audit
+
+	ID    int
+	Title string
+	Tags  []string
+	Draft *bool
+
+	views int
+}
+
+func Test_Changes(t *testing.T) {
+	var (
+		now  = time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+		yes  = true
+		also = true
+	)
+
+	t.Run("should-return-changed-fields-in-declaration-order", func(t *testing.T) {
+		// GIVEN
+		old := Article{ID: 1, Title: "Draft", Tags: []string{"go"}, Draft: &yes, views: 1}
+		new := Article{ID: 1, Title: "Hello", Tags: []string{"go", "sql"}, Draft: &also, views: 2}
+
+		// WHEN
+		changes := diff.Changes(old, new)
+
+		// THEN
+		assert.Equal(t, []diff.Change{
+			{Field: "Title", Old: "Draft", New: "Hello"},
+			{Field: "Tags", Old: []string{"go"}, New: []string{"go", "sql"}},
+		}, changes)
+		assert.Equal(t, `Title: "Draft" -> "Hello"`, changes[0].String())
+	})
+
+	t.Run("should-compare-with-equal-method", func(t *testing.T) {
+		// GIVEN
+		old := &Article{Audit: Audit{UpdatedAt: now}}
+		new := &Article{Audit: Audit{UpdatedAt: now.In(time.FixedZone("CET", 3600))}}
+
+		// WHEN
+		changes := diff.Changes(old, new)
+
+		// THEN
+		assert.Empty(t, changes)
+	})
+
+	t.Run("should-report-promoted-fields", func(t *testing.T) {
+		// GIVEN
+		old := &Article{Audit: Audit{UpdatedAt: now}}
+		new := &Article{Audit: Audit{UpdatedAt: now.Add(time.Hour)}}
+
+		// WHEN
+		changes := diff.Changes(old, new)
+
+		// THEN
+		assert.Equal(t, []diff.Change{{Field: "UpdatedAt", Old: now, New: now.Add(time.Hour)}}, changes)
+	})
+
+	t.Run("should-report-set-fields-of-created-entity", func(t *testing.T) {
+		// WHEN
+		changes := diff.Changes(nil, &Article{ID: 1, Title: "Hello"})
+
+		// THEN
+		assert.Equal(t, []diff.Change{
+			{Field: "ID", New: 1},
+			{Field: "Title", New: "Hello"},
+		}, changes)
+	})
+
+	t.Run("should-report-set-fields-of-deleted-entity", func(t *testing.T) {
+		// WHEN
+		changes := diff.Changes(&Article{ID: 1}, nil)
+
+		// THEN
+		assert.Equal(t, []diff.Change{{Field: "ID", Old: 1}}, changes)
+	})
+
+	t.Run("should-return-nil-for-non-struct", func(t *testing.T) {
+		// WHEN
+		changes := diff.Changes(1, 2)
+
+		// THEN
+		assert.Nil(t, changes)
+	})
+}
@@ -0,0 +1,9 @@
+// Package diff compares two versions of an entity field by field, e.g. to describe an update in an audit
+// log, or to send only the changed fields of a form.
+//
+// Example:
+//
+//	for _, change := range diff.Changes(before, after) {
+//		fmt.Println(change) // Title: "Draft" -> "Hello"
+//	}
+package diff
@@ -33,4 +33,19 @@
 // In this example, `fieldMapping` is used to define custom mappings between field names
 // in the Entity (`MyEntity`) and the DTO (`MyDTO`). If field names are the same, they are
 // automatically mapped without needing to be specified in `fieldMapping`.
+//
+// With the WithJSONFallback option, the fields that cannot be mapped by reflection, such as a map and a struct,
+// are converted by marshaling them to JSON and unmarshaling the result.
+//
+// With the WithStructTags option, the field mappings are read from the `flex` (or `convert`) struct tags of the
+// fields, e.g. `flex:"DTOFieldName"`, nested structs and slices included, instead of a mapping map.
+//
+// The WithTypeConverter option registers the conversion of the fields of a pair of types the Reflect converter
+// cannot assign by itself, such as time.Time to int64 epochs or enums to strings.
+//
+// The Ignore, IgnoreToDTO and IgnoreToEntity options exclude fields, such as secrets, from both or one of the
+// conversions, and MapToDTO and MapToEntity map fields in one direction only.
+//
+// When the Entity and the DTO are the same type, the Identity converter returns the values unchanged, without
+// reflection nor copy. gormstore uses it by default for such stores, e.g. the ones created with NewSimple.
 package converter
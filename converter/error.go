@@ -0,0 +1,20 @@
+package converter
+
+// ConversionError is the value converters panic with when a value cannot be converted.
+//
+// The Converter interface has no way to return errors, so conversion failures are reported by panicking.
+// Callers that must not crash, such as gormstore in lenient mode, can recover ConversionError values and
+// return them as regular errors.
+type ConversionError struct {
+	Err error
+}
+
+// Error returns the message of the underlying error.
+func (e *ConversionError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *ConversionError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,56 @@
+package converter
+
+import (
+	"fmt"
+	"reflect"
+	"unsafe"
+
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// NewIdentity creates a new Identity converter, for the stores whose Entity and DTO are the same type.
+//
+// Type parameters:
+//   - Entity: The type representing the Entity.
+//   - DTO: The type representing the Data Transfer Object, identical to Entity.
+//   - ID: The type of the identifier for the Entity and DTO.
+//
+// Returns:
+// An Identity converter. It panics if Entity and DTO are different types.
+func NewIdentity[Entity store.Entity[ID], DTO store.Entity[ID], ID comparable]() *Identity[Entity, DTO, ID] {
+	if !SameType[Entity, DTO]() {
+		panic(fmt.Sprintf("converter: identity between different types %T and %T", *new(Entity), *new(DTO)))
+	}
+
+	return &Identity[Entity, DTO, ID]{}
+}
+
+// SameType reports whether A and B are the same type, in which case NewIdentity can convert between them.
+func SameType[A any, B any]() bool {
+	return reflect.TypeOf((*A)(nil)).Elem() == reflect.TypeOf((*B)(nil)).Elem()
+}
+
+// Identity is a Converter between an Entity and a DTO of the same type, returning the values unchanged
+// instead of copying them field by field with reflection.
+type Identity[Entity store.Entity[ID], DTO store.Entity[ID], ID comparable] struct{}
+
+// ToEntity returns the DTO as an Entity.
+func (Identity[Entity, DTO, ID]) ToEntity(dto DTO) Entity {
+	// NewIdentity guarantees that Entity and DTO are the same type.
+	return *(*Entity)(unsafe.Pointer(&dto))
+}
+
+// ToDTO returns the Entity as a DTO.
+func (Identity[Entity, DTO, ID]) ToDTO(entity Entity) DTO {
+	return *(*DTO)(unsafe.Pointer(&entity))
+}
+
+// ToEntities returns the DTOs as a slice of Entities sharing their backing array, without copying them.
+// It returns nil when dtos is empty, like ToMany.
+func (Identity[Entity, DTO, ID]) ToEntities(dtos []DTO) []Entity {
+	if len(dtos) == 0 {
+		return nil
+	}
+
+	return unsafe.Slice((*Entity)(unsafe.Pointer(&dtos[0])), len(dtos))
+}
@@ -0,0 +1,40 @@
+package converter_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infevocorp/goflexstore/converter"
+)
+
+func Test_Identity(t *testing.T) {
+	t.Run("should-return-values-unchanged", func(t *testing.T) {
+		// GIVEN
+		c := converter.NewIdentity[User, User, int]()
+		user := User{ID: 1, Name: "john", Age: 30}
+
+		// THEN
+		assert.Equal(t, user, c.ToEntity(user))
+		assert.Equal(t, user, c.ToDTO(user))
+	})
+
+	t.Run("should-share-slice-of-entities", func(t *testing.T) {
+		// GIVEN
+		c := converter.NewIdentity[*User, *User, int]()
+		dtos := []*User{{ID: 1}, {ID: 2}}
+
+		// WHEN
+		entities := c.ToEntities(dtos)
+
+		// THEN
+		assert.Equal(t, dtos, entities)
+		assert.Same(t, &dtos[0], &entities[0])
+		assert.Nil(t, c.ToEntities(nil))
+	})
+
+	t.Run("should-panic-with-different-types", func(t *testing.T) {
+		assert.False(t, converter.SameType[User, UserDTO]())
+		assert.Panics(t, func() { converter.NewIdentity[User, UserDTO, int]() })
+	})
+}
@@ -0,0 +1,175 @@
+package converter
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+	"sync"
+)
+
+var (
+	scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	valuerType  = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+)
+
+// strategy is the way a field is assigned, as resolved from the types of the source and destination fields.
+type strategy int
+
+const (
+	// strategySet sets the destination with the source value of the same type.
+	strategySet strategy = iota
+	// strategyScan scans the source value into the destination implementing sql.Scanner.
+	strategyScan
+	// strategyValue sets the destination with the value of the source implementing driver.Valuer.
+	strategyValue
+	// strategyStruct copies the fields of the source struct to the destination struct.
+	strategyStruct
+	// strategySlice copies the elements of the source slice to the destination slice.
+	strategySlice
+	// strategyNone has no way to assign the source to the destination but the JSON fallback.
+	strategyNone
+)
+
+// fieldPlan is the compiled conversion of a field of the destination struct.
+//
+// Fields:
+//   - dst: The index of the field in the destination struct.
+//   - src: The index path of the source field, see reflect.Value.FieldByIndex.
+//   - name: The name of the source field, for the error messages.
+//   - strategy: The way the field is assigned.
+//   - convert: The converter registered for the types of the fields with WithTypeConverter, if any. It takes
+//     precedence over the strategy.
+type fieldPlan struct {
+	dst      int
+	src      []int
+	name     string
+	strategy strategy
+	convert  typeConverter
+}
+
+// plan is the compiled conversion of a source struct type to a destination struct type: the fields to set, with
+// their source field and assignment strategy resolved once.
+type plan struct {
+	fields []fieldPlan
+}
+
+// planKey identifies a plan: the source and destination types, and the field rules it applies.
+type planKey struct {
+	src, dst reflect.Type
+	rules    *fieldRules
+}
+
+// planCache caches the plans of a Reflect converter, which differ by their field rules and options.
+// It is safe for concurrent use.
+type planCache struct {
+	plans sync.Map
+}
+
+// get returns the plan converting the src type to the dst type with the field rules and options, compiling it
+// on first use. A nil cache compiles the plan on every call.
+func (c *planCache) get(src, dst reflect.Type, rules *fieldRules, o reflectOptions) *plan {
+	if c == nil {
+		return compilePlan(src, dst, rules, o)
+	}
+
+	key := planKey{src: src, dst: dst, rules: rules}
+
+	if p, ok := c.plans.Load(key); ok {
+		return p.(*plan)
+	}
+
+	p, _ := c.plans.LoadOrStore(key, compilePlan(src, dst, rules, o))
+
+	return p.(*plan)
+}
+
+// compilePlan resolves the source field and the assignment strategy of each settable field of the dst type.
+// The fields without source field, and the ones ignored by the rules, are left out.
+func compilePlan(src, dst reflect.Type, rules *fieldRules, o reflectOptions) *plan {
+	if rules == nil {
+		rules = &fieldRules{}
+	}
+
+	p := &plan{}
+
+	for i := 0; i < dst.NumField(); i++ {
+		dstField := dst.Field(i)
+
+		// Skip the fields that cannot be set (unexported private fields) and the ignored ones.
+		if !dstField.IsExported() || rules.ignore[dstField.Name] {
+			continue
+		}
+
+		var (
+			srcField reflect.StructField
+			ok       bool
+		)
+
+		// Find the field in the source type that matches the destination field, through the field mapping
+		// if one exists, then through the struct tags if enabled.
+		if f, mapped := rules.mapping[dstField.Name]; mapped && f != "" {
+			srcField, ok = src.FieldByName(f)
+		} else if o.structTags {
+			srcField, ok = taggedSourceField(src, dstField)
+		} else {
+			srcField, ok = src.FieldByName(dstField.Name)
+		}
+
+		if !ok || rules.ignore[srcField.Name] {
+			continue
+		}
+
+		p.fields = append(p.fields, fieldPlan{
+			dst:      i,
+			src:      srcField.Index,
+			name:     srcField.Name,
+			strategy: strategyOf(srcField.Type, dstField.Type),
+			convert:  o.typeConverters[typePair{src: srcField.Type, dst: dstField.Type}],
+		})
+	}
+
+	return p
+}
+
+// strategyOf returns the way a value of the src type is assigned to a field of the dst type, trying the
+// strategies in the order of setValue.
+func strategyOf(src, dst reflect.Type) strategy {
+	switch {
+	case src == dst:
+		return strategySet
+	case dst.Implements(scannerType) || dst.Kind() == reflect.Struct && reflect.PointerTo(dst).Implements(scannerType):
+		return strategyScan
+	case getStructType(src).Implements(valuerType):
+		return strategyValue
+	case getStructType(src).Kind() == reflect.Struct && getStructType(dst).Kind() == reflect.Struct:
+		return strategyStruct
+	case src.Kind() == reflect.Slice && dst.Kind() == reflect.Slice:
+		return strategySlice
+	default:
+		return strategyNone
+	}
+}
+
+// apply assigns the source value to the destination with the strategy, falling back to setValue when the
+// strategy does not apply to the value. It reports whether the destination was set.
+func (s strategy) apply(srcVal, dstVal reflect.Value, o reflectOptions) bool {
+	var ok bool
+
+	switch s {
+	case strategySet:
+		dstVal.Set(srcVal)
+		return true
+	case strategyScan:
+		ok = tryIfTargetTypeIsScanner(srcVal, dstVal)
+	case strategyValue:
+		ok = tryIfTargetTypeIsValuer(srcVal, dstVal)
+	case strategyStruct:
+		ok = tryIfStruct(srcVal, dstVal, o)
+	case strategySlice:
+		ok = tryIfSlice(srcVal, dstVal, o)
+	case strategyNone:
+		return false
+	}
+
+	return ok || setValue(srcVal, dstVal, o)
+}
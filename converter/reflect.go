@@ -3,7 +3,9 @@ package converter
 import (
 	"database/sql"
 	"database/sql/driver"
+	"encoding/json"
 	"reflect"
+	"strings"
 
 	"github.com/pkg/errors"
 
@@ -23,6 +25,8 @@ import (
 //
 // Parameters:
 //   - overridesMapping: A map where the key is the Entity's field name and the value is the DTO's field name.
+//     It applies to both conversions, unlike MapToDTO and MapToEntity.
+//   - opts: Options configuring the converter, e.g. WithJSONFallback or Ignore.
 //
 // Returns:
 // A new instance of Reflect converter with the specified field mappings.
@@ -32,15 +36,224 @@ func NewReflect[
 	ID comparable,
 ](
 	overridesMapping map[string]string,
+	opts ...ReflectOption,
 ) Converter[Entity, DTO, ID] {
+	o := reflectOptions{plans: &planCache{}}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	toDTO := &fieldRules{mapping: reverseMapping(overridesMapping), ignore: o.ignoreToDTO}
+	for entityField, dtoField := range o.mapToDTO {
+		toDTO.mapping[dtoField] = entityField
+	}
+
+	toEntity := &fieldRules{mapping: make(map[string]string, len(overridesMapping)), ignore: o.ignoreToEntity}
+	for entityField, dtoField := range overridesMapping {
+		toEntity.mapping[entityField] = dtoField
+	}
+
+	for dtoField, entityField := range o.mapToEntity {
+		toEntity.mapping[entityField] = dtoField
+	}
+
 	return Reflect[Entity, DTO, ID]{
-		dtoFieldsMapping:   overridesMapping,
-		entityFieldMapping: reverseMapping(overridesMapping),
+		toDTO:    toDTO,
+		toEntity: toEntity,
+		options:  o,
 	}
 }
 
+// fieldRules are the rules of the conversion of the fields of the top level struct in one direction.
+//
+// Fields:
+//   - mapping: Map where the key is the destination field name and the value is the source field name.
+//   - ignore: The names of the fields, of the source or of the destination, that are not converted.
+type fieldRules struct {
+	mapping map[string]string
+	ignore  map[string]bool
+}
+
+// ReflectOption is a function that configures a Reflect converter, see NewReflect.
+type ReflectOption func(*reflectOptions)
+
+type reflectOptions struct {
+	jsonFallback   bool
+	structTags     bool
+	typeConverters map[typePair]typeConverter
+	ignoreToDTO    map[string]bool
+	ignoreToEntity map[string]bool
+	// mapToDTO key is Entity's field name. value is DTO's field name.
+	mapToDTO map[string]string
+	// mapToEntity key is DTO's field name. value is Entity's field name.
+	mapToEntity map[string]string
+	// plans caches the compiled conversions of the converter.
+	plans *planCache
+}
+
+// WithJSONFallback makes the Reflect converter fall back to encoding/json when a field cannot be mapped by
+// reflection, e.g. between a struct and a map, or between structs whose nested fields have different types.
+// The source field is marshaled to JSON and unmarshaled into the destination field, which trades speed for
+// robustness on loosely matching structs. The converter still panics with a ConversionError when the JSON
+// round trip fails too.
+//
+// Example:
+//
+//	converter.NewReflect[*model.Article, *dto.Article, int64](nil, converter.WithJSONFallback())
+func WithJSONFallback() ReflectOption {
+	return func(o *reflectOptions) {
+		o.jsonFallback = true
+	}
+}
+
+// WithStructTags makes the Reflect converter read the field mappings from the `flex` struct tags, or the `convert`
+// struct tags, instead of only from the overridesMapping of NewReflect. The tag of a field names the field of the
+// other type it is converted from and to, and it can be set on the fields of either type, nested structs and
+// the elements of slices included. The overridesMapping, if any, takes precedence over the tags. A field tagged
+// with `flex:"-"` is not converted.
+//
+// Example:
+//
+//	type Article struct {
+//		ID       int64
+//		Headline string `flex:"Title"`
+//		Author   Author
+//	}
+//
+//	type Author struct {
+//		Name string `flex:"FullName"`
+//	}
+//
+//	converter.NewReflect[*model.Article, *dto.Article, int64](nil, converter.WithStructTags())
+func WithStructTags() ReflectOption {
+	return func(o *reflectOptions) {
+		o.structTags = true
+	}
+}
+
+// WithTypeConverter registers the function converting the fields of the Src type to the fields of the Dst type,
+// e.g. time.Time to int64 epochs or enums to strings, which the Reflect converter cannot assign otherwise.
+// The function is used for the fields of exactly these types, those of nested structs and slice elements included,
+// and takes precedence over the other assignments. The conversion in the other direction is registered
+// separately. The converter panics with a ConversionError wrapping the error the function returns, if any.
+//
+// Example:
+//
+//	converter.NewReflect[*model.Event, *dto.Event, int64](nil,
+//		converter.WithTypeConverter(func(t time.Time) (int64, error) { return t.Unix(), nil }),
+//		converter.WithTypeConverter(func(sec int64) (time.Time, error) { return time.Unix(sec, 0).UTC(), nil }),
+//		converter.WithTypeConverter(uuid.Parse),
+//		converter.WithTypeConverter(func(id uuid.UUID) (string, error) { return id.String(), nil }),
+//	)
+func WithTypeConverter[Src, Dst any](fn func(Src) (Dst, error)) ReflectOption {
+	pair := typePair{
+		src: reflect.TypeOf((*Src)(nil)).Elem(),
+		dst: reflect.TypeOf((*Dst)(nil)).Elem(),
+	}
+
+	convert := func(src reflect.Value) (reflect.Value, error) {
+		dst, err := fn(src.Interface().(Src))
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		return reflect.ValueOf(&dst).Elem(), nil
+	}
+
+	return func(o *reflectOptions) {
+		if o.typeConverters == nil {
+			o.typeConverters = map[typePair]typeConverter{}
+		}
+
+		o.typeConverters[pair] = convert
+	}
+}
+
+// typePair is a pair of source and destination types, see WithTypeConverter.
+type typePair struct {
+	src, dst reflect.Type
+}
+
+// typeConverter converts a source value to a value of the destination type of its pair, see WithTypeConverter.
+type typeConverter func(src reflect.Value) (reflect.Value, error)
+
+// Ignore excludes the fields of the given names, of the Entity or of the DTO, from both conversions, e.g. sensitive
+// fields that must not leave the persistence layer. It only applies to the fields of the Entity and of the DTO
+// themselves, not to the fields of their nested structs.
+//
+// With WithStructTags, a field is excluded by a `flex:"-"` tag too.
+//
+// Example:
+//
+//	converter.NewReflect[*model.User, *dto.User, int64](nil, converter.Ignore("PasswordHash", "Secret"))
+func Ignore(fields ...string) ReflectOption {
+	return func(o *reflectOptions) {
+		o.ignoreToDTO = addNames(o.ignoreToDTO, fields)
+		o.ignoreToEntity = addNames(o.ignoreToEntity, fields)
+	}
+}
+
+// IgnoreToDTO excludes the fields of the given names from ToDTO only, e.g. the fields derived from the DTO that
+// are not stored, see Ignore.
+func IgnoreToDTO(fields ...string) ReflectOption {
+	return func(o *reflectOptions) {
+		o.ignoreToDTO = addNames(o.ignoreToDTO, fields)
+	}
+}
+
+// IgnoreToEntity excludes the fields of the given names from ToEntity only, e.g. the fields only written by the
+// application, see Ignore.
+func IgnoreToEntity(fields ...string) ReflectOption {
+	return func(o *reflectOptions) {
+		o.ignoreToEntity = addNames(o.ignoreToEntity, fields)
+	}
+}
+
+// MapToDTO maps the field of the Entity to the field of the DTO in ToDTO only, unlike the overridesMapping of
+// NewReflect applying to both conversions. It takes precedence over the overridesMapping.
+//
+// Example:
+// Storing the display name of users, computed by the application, without reading it back:
+//
+//	converter.NewReflect[*model.User, *dto.User, int64](nil, converter.MapToDTO("DisplayName", "NameCache"))
+func MapToDTO(entityField, dtoField string) ReflectOption {
+	return func(o *reflectOptions) {
+		if o.mapToDTO == nil {
+			o.mapToDTO = map[string]string{}
+		}
+
+		o.mapToDTO[entityField] = dtoField
+	}
+}
+
+// MapToEntity maps the field of the DTO to the field of the Entity in ToEntity only, see MapToDTO.
+func MapToEntity(dtoField, entityField string) ReflectOption {
+	return func(o *reflectOptions) {
+		if o.mapToEntity == nil {
+			o.mapToEntity = map[string]string{}
+		}
+
+		o.mapToEntity[dtoField] = entityField
+	}
+}
+
+// addNames returns the set of names with the given names added.
+func addNames(set map[string]bool, names []string) map[string]bool {
+	if set == nil {
+		set = make(map[string]bool, len(names))
+	}
+
+	for _, name := range names {
+		set[name] = true
+	}
+
+	return set
+}
+
 // Reflect is a converter that uses reflection to convert between DTO and Entity.
 // It implements the Converter interface and allows for automated conversion based on field names.
+// The source field and the assignment of each field are resolved once per pair of types and cached by the converter,
+// so converting many rows only pays for the assignments. A converter is safe for concurrent use.
 //
 // Type parameters:
 //   - Entity: The Entity type.
@@ -48,13 +261,17 @@ func NewReflect[
 //   - ID: The type of the identifier for Entity and DTO.
 //
 // Fields:
-//   - dtoFieldsMapping: Map where the key is Entity's field name and the value is DTO's field name.
-//   - entityFieldMapping: Map where the key is DTO's field name and the value is Entity's field name.
+//   - toDTO: The field rules of ToDTO, mapping the DTO's field names to the Entity's field names.
+//   - toEntity: The field rules of ToEntity, mapping the Entity's field names to the DTO's field names.
+//   - options: The options of the converter, e.g. whether the fields that cannot be mapped by reflection are
+//     converted through JSON.
 type Reflect[Entity store.Entity[ID], DTO store.Entity[ID], ID comparable] struct {
-	// fieldMapping key is Entity's field name. value is DTO's field name.
-	dtoFieldsMapping map[string]string
-	// fieldMapping key is DTO's field name. value is Entity's field name.
-	entityFieldMapping map[string]string
+	// toDTO mapping key is DTO's field name. value is Entity's field name.
+	toDTO *fieldRules
+	// toEntity mapping key is Entity's field name. value is DTO's field name.
+	toEntity *fieldRules
+	// options configure the conversion, see ReflectOption.
+	options reflectOptions
 }
 
 // ToEntity converts a DTO to an Entity using reflection.
@@ -68,7 +285,7 @@ type Reflect[Entity store.Entity[ID], DTO store.Entity[ID], ID comparable] struc
 func (c Reflect[Entity, DTO, ID]) ToEntity(dto DTO) Entity {
 	entity := *new(Entity)
 
-	reflectCopy(dto, &entity, c.entityFieldMapping)
+	reflectCopy(dto, &entity, c.toEntity, c.options)
 
 	return entity
 }
@@ -84,19 +301,21 @@ func (c Reflect[Entity, DTO, ID]) ToEntity(dto DTO) Entity {
 func (c Reflect[Entity, DTO, ID]) ToDTO(entity Entity) DTO {
 	dto := *new(DTO)
 
-	reflectCopy(entity, &dto, c.dtoFieldsMapping)
+	reflectCopy(entity, &dto, c.toDTO, c.options)
 
 	return dto
 }
 
 // reflectCopy performs the actual copying of values from the source to the destination.
-// It iterates over the fields of the destination and sets values from the source based on the provided field mapping.
+// It sets the fields of the destination from the source following the plan of their types, see planCache.
 //
 // Parameters:
 //   - src: The source object.
 //   - dst: The destination object.
-//   - fieldMapping: Map where the key is the destination field name and the value is the source field name.
-func reflectCopy(src any, dst any, fieldMapping map[string]string) {
+//   - rules: The field rules of the top level struct, or nil for nested structs.
+//   - o: The options of the conversion, e.g. whether the fields that cannot be set by reflection are converted
+//     through JSON.
+func reflectCopy(src any, dst any, rules *fieldRules, o reflectOptions) {
 	// Obtain a reflection Value of the source object.
 	srcVal := reflect.ValueOf(src)
 
@@ -116,7 +335,7 @@ func reflectCopy(src any, dst any, fieldMapping map[string]string) {
 	dstVal := reflect.ValueOf(dst)
 	// Ensure the destination is a pointer, as we need to modify it.
 	if dstVal.Kind() != reflect.Ptr {
-		panic("dst must be reference type (pointer)")
+		panic(&ConversionError{Err: errors.New("dst must be reference type (pointer)")})
 	}
 
 	// Unwrap the destination value if it's a pointer.
@@ -131,54 +350,89 @@ func reflectCopy(src any, dst any, fieldMapping map[string]string) {
 		dstVal = dstVal.Elem()
 	}
 
-	// Get the type information of the destination.
-	dstType := dstVal.Type()
+	p := o.plans.get(srcVal.Type(), dstVal.Type(), rules, o)
+
+	for _, field := range p.fields {
+		srcField := srcVal.Field(field.src[0])
+		if len(field.src) > 1 {
+			var err error
 
-	// Iterate over each field of the destination.
-	numField := dstVal.NumField()
-	for i := 0; i < numField; i++ {
-		// Get the i-th field of the destination.
-		dstField := dstVal.Field(i)
+			// Skip the fields promoted through a nil embedded pointer.
+			if srcField, err = srcVal.FieldByIndexErr(field.src); err != nil {
+				continue
+			}
+		}
 
-		// Skip if the field cannot be set (unexported private field).
-		if !dstField.CanSet() {
+		// If the source field is a pointer but nil, skip copying.
+		if (srcField.Kind() == reflect.Ptr || srcField.Kind() == reflect.Slice) && srcField.IsNil() {
 			continue
 		}
 
-		// Get the name of the i-th field.
-		dstFieldName := dstType.Field(i).Name
+		dstField := dstVal.Field(field.dst)
 
-		// If a field mapping exists, use it to find the corresponding source field.
-		if fieldMapping != nil {
-			if f, ok := fieldMapping[dstFieldName]; ok && f != "" {
-				dstFieldName = f
+		if field.convert != nil {
+			value, err := field.convert(srcField)
+			if err != nil {
+				panic(&ConversionError{Err: errors.Wrapf(err, "cannot convert src.%s(%s) to dst.%s(%s)",
+					field.name, srcField.Type().String(), field.name, dstField.Type().String())})
 			}
-		}
 
-		// Find the field in the source object that matches the destination field.
-		srcField := srcVal.FieldByName(dstFieldName)
-		// Skip if the source field is not valid (doesn't exist).
-		if !srcField.IsValid() {
-			continue
-		}
+			dstField.Set(value)
 
-		// If the source field is a pointer but nil, skip copying.
-		if (srcField.Kind() == reflect.Ptr || srcField.Kind() == reflect.Slice) && srcField.IsNil() {
 			continue
 		}
 
 		// Attempt to set the destination field with the value of the source field.
-		// Panic with a detailed error message if the assignment is not possible.
-		if !setValue(srcField, dstField) {
-			panic(errors.Errorf(
+		// Panic with a detailed ConversionError if the assignment is not possible.
+		if field.strategy == strategySet {
+			dstField.Set(srcField)
+		} else if !assign(field.strategy, srcField, dstField, o) {
+			panic(&ConversionError{Err: errors.Errorf(
 				"cannot assign src.%s(%s) to dst.%s(%s)",
-				dstFieldName,
+				field.name,
 				srcField.Type().String(),
-				dstFieldName,
+				field.name,
 				dstField.Type().String(),
-			))
+			)})
+		}
+	}
+}
+
+// taggedSourceField returns the field of src the destination field is converted from: the field named by the
+// struct tag of the destination field, or else the field of src whose struct tag names the destination field, or
+// else the field of src having the same name unless its tag maps it to another field.
+func taggedSourceField(src reflect.Type, dstField reflect.StructField) (reflect.StructField, bool) {
+	if name := fieldTag(dstField); name != "" {
+		return src.FieldByName(name)
+	}
+
+	for _, field := range reflect.VisibleFields(src) {
+		if fieldTag(field) == dstField.Name {
+			return field, true
 		}
 	}
+
+	field, ok := src.FieldByName(dstField.Name)
+
+	// a field whose tag maps it to another field is not converted from the field of the same name.
+	if name := fieldTag(field); ok && name != "" && name != dstField.Name {
+		return reflect.StructField{}, false
+	}
+
+	return field, ok
+}
+
+// fieldTag returns the name of the field of the other type the field is mapped to by its `flex` tag, or else by
+// its `convert` tag, or an empty string.
+func fieldTag(field reflect.StructField) string {
+	tag, ok := field.Tag.Lookup("flex")
+	if !ok {
+		tag = field.Tag.Get("convert")
+	}
+
+	name, _, _ := strings.Cut(tag, ",")
+
+	return name
 }
 
 func reverseMapping[K comparable, V comparable](m map[K]V) map[V]K {
@@ -190,7 +444,46 @@ func reverseMapping[K comparable, V comparable](m map[K]V) map[V]K {
 	return reversed
 }
 
-func setValue(srcVal, dstVal reflect.Value) bool {
+// assign sets the destination with the source value with the strategy of the field, through JSON if reflection
+// fails and jsonFallback is set. It reports whether the destination was set.
+func assign(s strategy, srcVal, dstVal reflect.Value, o reflectOptions) bool {
+	if o.jsonFallback {
+		return setValueOrJSON(s, srcVal, dstVal, o)
+	}
+
+	return s.apply(srcVal, dstVal, o)
+}
+
+// setValueOrJSON sets the destination with the source value by reflection, or through a JSON round trip
+// if the reflection fails. It reports whether the destination was set.
+func setValueOrJSON(s strategy, srcVal, dstVal reflect.Value, o reflectOptions) (ok bool) {
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				if _, isConvErr := r.(*ConversionError); !isConvErr {
+					panic(r)
+				}
+			}
+		}()
+
+		ok = s.apply(srcVal, dstVal, o)
+	}()
+
+	if ok {
+		return true
+	}
+
+	data, err := json.Marshal(srcVal.Interface())
+	if err != nil {
+		return false
+	}
+
+	dstVal.Set(reflect.Zero(dstVal.Type()))
+
+	return json.Unmarshal(data, dstVal.Addr().Interface()) == nil
+}
+
+func setValue(srcVal, dstVal reflect.Value, o reflectOptions) bool {
 	// same type
 	if srcVal.Type() == dstVal.Type() {
 		dstVal.Set(srcVal)
@@ -205,11 +498,11 @@ func setValue(srcVal, dstVal reflect.Value) bool {
 		return true
 	}
 
-	if ok := tryIfStruct(srcVal, dstVal); ok {
+	if ok := tryIfStruct(srcVal, dstVal, o); ok {
 		return true
 	}
 
-	if ok := tryIfSlice(srcVal, dstVal); ok {
+	if ok := tryIfSlice(srcVal, dstVal, o); ok {
 		return true
 	}
 
@@ -224,7 +517,7 @@ func tryIfTargetTypeIsScanner(src reflect.Value, dst reflect.Value) bool {
 	}
 
 	// check if dst implements sql.Scanner interface
-	if !dst.Type().Implements(reflect.TypeOf((*sql.Scanner)(nil)).Elem()) {
+	if !dst.Type().Implements(scannerType) {
 		return false
 	}
 
@@ -234,9 +527,8 @@ func tryIfTargetTypeIsScanner(src reflect.Value, dst reflect.Value) bool {
 		dst.Set(reflect.New(dst.Type().Elem()))
 	}
 
-	if results := dst.MethodByName("Scan").Call([]reflect.Value{src}); !results[0].IsNil() {
-		err := results[0].Interface().(error)
-		panic(errors.Errorf("cannot assign %s to %s: %v", src.String(), dst.String(), err))
+	if err := dst.Interface().(sql.Scanner).Scan(src.Interface()); err != nil {
+		panic(&ConversionError{Err: errors.Errorf("cannot assign %s to %s: %v", src.String(), dst.String(), err)})
 	}
 
 	return true
@@ -254,15 +546,14 @@ func tryIfTargetTypeIsValuer(src reflect.Value, dst reflect.Value) bool {
 	}
 
 	// check if src implements driver.Valuer interface
-	if !src.Type().Implements(reflect.TypeOf((*driver.Valuer)(nil)).Elem()) {
+	if !src.Type().Implements(valuerType) {
 		return false
 	}
 
 	// execute Value() method
-	results := src.MethodByName("Value").Call([]reflect.Value{})
+	value, _ := src.Interface().(driver.Valuer).Value()
 
 	// check if Value() method returns nil
-	value := results[0].Interface()
 	if value == nil {
 		return true
 	}
@@ -275,7 +566,7 @@ func tryIfTargetTypeIsValuer(src reflect.Value, dst reflect.Value) bool {
 	return true
 }
 
-func tryIfStruct(src, dst reflect.Value) bool {
+func tryIfStruct(src, dst reflect.Value, o reflectOptions) bool {
 	srcType := src.Type()
 	dstType := dst.Type()
 
@@ -287,12 +578,12 @@ func tryIfStruct(src, dst reflect.Value) bool {
 		dst.Set(reflect.New(getStructType(dstType)))
 	}
 
-	reflectCopy(src.Interface(), dst.Interface(), nil)
+	reflectCopy(src.Interface(), dst.Interface(), nil, o)
 
 	return true
 }
 
-func tryIfSlice(src, dst reflect.Value) bool {
+func tryIfSlice(src, dst reflect.Value, o reflectOptions) bool {
 	srcType := src.Type()
 	dstType := dst.Type()
 
@@ -314,7 +605,7 @@ func tryIfSlice(src, dst reflect.Value) bool {
 			dstEl.Set(reflect.New(dstEl.Type().Elem()))
 		}
 
-		reflectCopy(srcElem.Interface(), dstEl.Interface(), nil)
+		reflectCopy(srcElem.Interface(), dstEl.Interface(), nil, o)
 	}
 
 	dst.Set(tmpArr)
@@ -0,0 +1,52 @@
+package converter_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/infevocorp/goflexstore/converter"
+)
+
+func benchmarkDTOs(n int) []UserDTO {
+	now := time.Now()
+	dtos := make([]UserDTO, n)
+
+	for i := range dtos {
+		dtos[i] = UserDTO{
+			ID:        i,
+			Name:      "name",
+			Age:       30,
+			IsAdmin:   &sql.NullBool{Bool: true, Valid: true},
+			Disabled:  sql.NullBool{Bool: false, Valid: true},
+			CreatedAt: sql.NullTime{Time: now, Valid: true},
+			Referer:   &UserDTO{ID: i + 1, Name: "referer"},
+		}
+	}
+
+	return dtos
+}
+
+func Benchmark_Reflect_ToEntity(b *testing.B) {
+	c := converter.NewReflect[User, UserDTO, int](nil)
+	dtos := benchmarkDTOs(1000)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		converter.ToMany(dtos, c.ToEntity)
+	}
+}
+
+func Benchmark_Reflect_ToDTO(b *testing.B) {
+	c := converter.NewReflect[User, UserDTO, int](nil)
+	entities := converter.ToMany(benchmarkDTOs(1000), c.ToEntity)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		converter.ToMany(entities, c.ToDTO)
+	}
+}
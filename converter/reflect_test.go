@@ -2,10 +2,13 @@ package converter_test
 
 import (
 	"database/sql"
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/infevocorp/goflexstore/converter"
 )
@@ -126,6 +129,17 @@ func Test_Converter_ToEntity(t *testing.T) {
 			_ = converter.ToEntity(dto)
 		})
 	})
+
+	t.Run("should-panic-with-conversion-error", func(t *testing.T) {
+		conv := converter.NewReflect[UnMatchUser, UserDTO, int](nil)
+
+		defer func() {
+			_, ok := recover().(*converter.ConversionError)
+			assert.True(t, ok)
+		}()
+
+		_ = conv.ToEntity(UserDTO{Name: "John"})
+	})
 }
 
 func Test_ToMany(t *testing.T) {
@@ -157,6 +171,28 @@ func Test_ToMany(t *testing.T) {
 	})
 }
 
+func Test_ToManyNonNil(t *testing.T) {
+	t.Run("should-convert-DTOs-to-Entities", func(t *testing.T) {
+		conv := converter.NewReflect[User, UserDTO, int](nil)
+		dtos := []UserDTO{
+			{ID: 1, Name: "name1"},
+		}
+
+		entities := converter.ToManyNonNil(dtos, conv.ToEntity)
+
+		assert.Equal(t, []User{{ID: 1, Name: "name1"}}, entities)
+	})
+
+	t.Run("should-convert-nil-DTOs-to-empty-Entities", func(t *testing.T) {
+		conv := converter.NewReflect[User, UserDTO, int](nil)
+
+		entities := converter.ToManyNonNil(nil, conv.ToEntity)
+
+		assert.NotNil(t, entities)
+		assert.Empty(t, entities)
+	})
+}
+
 func Test_Converter_ToDTO(t *testing.T) {
 	t.Run("should-convert-Entity-to-DTO", func(t *testing.T) {
 		now := time.Now()
@@ -182,3 +218,405 @@ func Test_Converter_ToDTO(t *testing.T) {
 		}, dto)
 	})
 }
+
+type Profile struct {
+	ID       int
+	Settings map[string]any
+	Score    float64
+}
+
+func (p Profile) GetID() int {
+	return p.ID
+}
+
+type ProfileSettings struct {
+	Theme string `json:"theme"`
+	Size  int    `json:"size"`
+}
+
+type ProfileDTO struct {
+	ID       int
+	Settings *ProfileSettings
+	Score    int
+}
+
+func (p ProfileDTO) GetID() int {
+	return p.ID
+}
+
+func Test_Converter_JSONFallback(t *testing.T) {
+	t.Run("should-convert-unmatched-fields-through-json", func(t *testing.T) {
+		conv := converter.NewReflect[Profile, ProfileDTO, int](nil, converter.WithJSONFallback())
+
+		dto := conv.ToDTO(Profile{ID: 1, Settings: map[string]any{"theme": "dark", "size": 12}, Score: 42})
+		entity := conv.ToEntity(dto)
+
+		assert.Equal(t, ProfileDTO{ID: 1, Settings: &ProfileSettings{Theme: "dark", Size: 12}, Score: 42}, dto)
+		assert.Equal(t,
+			Profile{ID: 1, Settings: map[string]any{"theme": "dark", "size": float64(12)}, Score: 42},
+			entity,
+		)
+	})
+
+	t.Run("should-panic-without-fallback", func(t *testing.T) {
+		conv := converter.NewReflect[Profile, ProfileDTO, int](nil)
+
+		assert.PanicsWithError(t,
+			"cannot assign src.Settings(map[string]interface {}) to dst.Settings(*converter_test.ProfileSettings)",
+			func() {
+				_ = conv.ToDTO(Profile{Settings: map[string]any{"theme": "dark"}})
+			},
+		)
+	})
+
+	t.Run("should-panic-if-json-fails", func(t *testing.T) {
+		conv := converter.NewReflect[UnMatchUser, UserDTO, int](nil, converter.WithJSONFallback())
+
+		assert.PanicsWithError(t, "cannot assign src.Name(string) to dst.Name(int)", func() {
+			_ = conv.ToEntity(UserDTO{Name: "John"})
+		})
+	})
+}
+
+type TaggedAuthor struct {
+	Name string `flex:"FullName"`
+}
+
+type TaggedComment struct {
+	Text string `convert:"Body"`
+}
+
+type TaggedArticle struct {
+	ID       int
+	Headline string `flex:"Title"`
+	Author   *TaggedAuthor
+	Comments []TaggedComment
+	Views    int
+}
+
+func (e TaggedArticle) GetID() int {
+	return e.ID
+}
+
+type TaggedAuthorDTO struct {
+	FullName string
+}
+
+type TaggedCommentDTO struct {
+	Body string
+}
+
+type TaggedArticleDTO struct {
+	ID       int
+	Title    string
+	Author   *TaggedAuthorDTO
+	Comments []*TaggedCommentDTO
+	Hits     int `flex:"Views"`
+	Headline string
+}
+
+func (d TaggedArticleDTO) GetID() int {
+	return d.ID
+}
+
+func Test_Converter_StructTags(t *testing.T) {
+	entity := TaggedArticle{
+		ID:       1,
+		Headline: "Go",
+		Author:   &TaggedAuthor{Name: "Rob"},
+		Comments: []TaggedComment{{Text: "nice"}},
+		Views:    7,
+	}
+
+	dto := TaggedArticleDTO{
+		ID:       1,
+		Title:    "Go",
+		Author:   &TaggedAuthorDTO{FullName: "Rob"},
+		Comments: []*TaggedCommentDTO{{Body: "nice"}},
+		Hits:     7,
+	}
+
+	t.Run("should-map-fields-with-tags", func(t *testing.T) {
+		// GIVEN
+		c := converter.NewReflect[TaggedArticle, TaggedArticleDTO, int](nil, converter.WithStructTags())
+
+		// WHEN
+		gotDTO := c.ToDTO(entity)
+		gotEntity := c.ToEntity(dto)
+
+		// THEN
+		assert.Equal(t, dto, gotDTO)
+		assert.Equal(t, entity, gotEntity)
+	})
+
+	t.Run("should-prefer-overrides-mapping", func(t *testing.T) {
+		// GIVEN
+		c := converter.NewReflect[TaggedArticle, TaggedArticleDTO, int](
+			map[string]string{"ID": "Hits"},
+			converter.WithStructTags(),
+		)
+
+		// WHEN
+		got := c.ToDTO(entity)
+
+		// THEN
+		assert.Equal(t, 1, got.Hits)
+	})
+
+	t.Run("should-ignore-tags-by-default", func(t *testing.T) {
+		// GIVEN
+		c := converter.NewReflect[TaggedArticle, TaggedArticleDTO, int](nil)
+
+		// WHEN
+		got := c.ToDTO(TaggedArticle{ID: 1, Headline: "Go", Views: 7})
+
+		// THEN
+		assert.Equal(t, TaggedArticleDTO{ID: 1, Headline: "Go"}, got)
+	})
+}
+
+type Swapped struct {
+	ID     int
+	First  string
+	Second string
+}
+
+func (s Swapped) GetID() int {
+	return s.ID
+}
+
+func Test_Converter_Plans(t *testing.T) {
+	t.Run("should-convert-concurrently", func(t *testing.T) {
+		// GIVEN
+		c := converter.NewReflect[User, UserDTO, int](nil)
+		dto := UserDTO{ID: 1, Name: "name", Referer: &UserDTO{ID: 2}, Friends: []*UserDTO{{ID: 3}}}
+
+		var wg sync.WaitGroup
+
+		// WHEN
+		results := make([]User, 8)
+
+		for i := range results {
+			wg.Add(1)
+
+			go func(i int) {
+				defer wg.Done()
+
+				results[i] = c.ToEntity(dto)
+			}(i)
+		}
+
+		wg.Wait()
+
+		// THEN
+		for _, got := range results {
+			assert.Equal(t, User{ID: 1, Name: "name", Referer: &User{ID: 2}, Friends: []*User{{ID: 3}}}, got)
+		}
+	})
+
+	t.Run("should-keep-plans-of-each-direction", func(t *testing.T) {
+		// GIVEN
+		c := converter.NewReflect[Swapped, Swapped, int](map[string]string{"First": "Second"})
+
+		// WHEN
+		dto := c.ToDTO(Swapped{ID: 1, First: "a", Second: "b"})
+		entity := c.ToEntity(Swapped{ID: 1, First: "a", Second: "b"})
+
+		// THEN
+		assert.Equal(t, Swapped{ID: 1, First: "a", Second: "a"}, dto)
+		assert.Equal(t, Swapped{ID: 1, First: "b", Second: "b"}, entity)
+	})
+}
+
+type Status int
+
+type Event struct {
+	ID       int
+	At       time.Time
+	Status   Status
+	Children []EventChild
+}
+
+func (e Event) GetID() int {
+	return e.ID
+}
+
+type EventChild struct {
+	At time.Time
+}
+
+type EventDTO struct {
+	ID       int
+	At       int64
+	Status   string
+	Children []EventChildDTO
+}
+
+func (d EventDTO) GetID() int {
+	return d.ID
+}
+
+type EventChildDTO struct {
+	At int64
+}
+
+func Test_Converter_TypeConverters(t *testing.T) {
+	statuses := []string{"draft", "published"}
+	at := time.Unix(1700000000, 0).UTC()
+
+	newConverter := func() converter.Converter[Event, EventDTO, int] {
+		return converter.NewReflect[Event, EventDTO, int](nil,
+			converter.WithTypeConverter(func(t time.Time) (int64, error) { return t.Unix(), nil }),
+			converter.WithTypeConverter(func(sec int64) (time.Time, error) { return time.Unix(sec, 0).UTC(), nil }),
+			converter.WithTypeConverter(func(s Status) (string, error) { return statuses[s], nil }),
+			converter.WithTypeConverter(func(s string) (Status, error) {
+				for i, status := range statuses {
+					if status == s {
+						return Status(i), nil
+					}
+				}
+
+				return 0, fmt.Errorf("unknown status %q", s)
+			}),
+		)
+	}
+
+	entity := Event{ID: 1, At: at, Status: 1, Children: []EventChild{{At: at}}}
+	dto := EventDTO{ID: 1, At: 1700000000, Status: "published", Children: []EventChildDTO{{At: 1700000000}}}
+
+	t.Run("should-convert-registered-types", func(t *testing.T) {
+		// GIVEN
+		c := newConverter()
+
+		// WHEN
+		gotDTO := c.ToDTO(entity)
+		gotEntity := c.ToEntity(dto)
+
+		// THEN
+		assert.Equal(t, dto, gotDTO)
+		assert.Equal(t, entity, gotEntity)
+	})
+
+	t.Run("should-panic-with-conversion-error", func(t *testing.T) {
+		// GIVEN
+		c := newConverter()
+
+		// WHEN
+		var convErr *converter.ConversionError
+
+		func() {
+			defer func() {
+				convErr, _ = recover().(*converter.ConversionError)
+			}()
+
+			c.ToEntity(EventDTO{ID: 1, Status: "archived"})
+		}()
+
+		// THEN
+		require.NotNil(t, convErr)
+		assert.ErrorContains(t, convErr, `unknown status "archived"`)
+	})
+}
+
+type Account struct {
+	ID           int
+	Email        string
+	PasswordHash string
+	DisplayName  string
+	Secret       string `flex:"-"`
+}
+
+func (a Account) GetID() int {
+	return a.ID
+}
+
+type AccountDTO struct {
+	ID           int
+	Mail         string
+	PasswordHash string
+	NameCache    string
+	Secret       string
+}
+
+func (d AccountDTO) GetID() int {
+	return d.ID
+}
+
+func Test_Converter_FieldRules(t *testing.T) {
+	entity := Account{ID: 1, Email: "a@example.com", PasswordHash: "hash", DisplayName: "Ann", Secret: "s"}
+	dto := AccountDTO{ID: 1, Mail: "a@example.com", PasswordHash: "hash", NameCache: "Ann", Secret: "s"}
+
+	t.Run("should-map-overrides-from-entity-to-dto-fields", func(t *testing.T) {
+		// GIVEN
+		c := converter.NewReflect[Account, AccountDTO, int](map[string]string{"Email": "Mail"})
+
+		// WHEN
+		gotDTO := c.ToDTO(entity)
+		gotEntity := c.ToEntity(dto)
+
+		// THEN
+		assert.Equal(t, "a@example.com", gotDTO.Mail)
+		assert.Equal(t, "a@example.com", gotEntity.Email)
+	})
+
+	t.Run("should-ignore-fields", func(t *testing.T) {
+		// GIVEN
+		c := converter.NewReflect[Account, AccountDTO, int](nil, converter.Ignore("PasswordHash", "Secret"))
+
+		// WHEN
+		gotDTO := c.ToDTO(entity)
+		gotEntity := c.ToEntity(dto)
+
+		// THEN
+		assert.Equal(t, AccountDTO{ID: 1}, gotDTO)
+		assert.Equal(t, Account{ID: 1}, gotEntity)
+	})
+
+	t.Run("should-ignore-fields-in-one-direction", func(t *testing.T) {
+		// GIVEN
+		c := converter.NewReflect[Account, AccountDTO, int](nil,
+			converter.IgnoreToDTO("Secret"),
+			converter.IgnoreToEntity("PasswordHash"),
+		)
+
+		// WHEN
+		gotDTO := c.ToDTO(entity)
+		gotEntity := c.ToEntity(dto)
+
+		// THEN
+		assert.Equal(t, AccountDTO{ID: 1, PasswordHash: "hash"}, gotDTO)
+		assert.Equal(t, Account{ID: 1, Secret: "s"}, gotEntity)
+	})
+
+	t.Run("should-map-fields-in-one-direction", func(t *testing.T) {
+		// GIVEN
+		c := converter.NewReflect[Account, AccountDTO, int](nil,
+			converter.MapToDTO("DisplayName", "NameCache"),
+			converter.MapToEntity("Mail", "Email"),
+		)
+
+		// WHEN
+		gotDTO := c.ToDTO(entity)
+		gotEntity := c.ToEntity(dto)
+
+		// THEN
+		assert.Equal(t, "Ann", gotDTO.NameCache)
+		assert.Empty(t, gotDTO.Mail)
+		assert.Equal(t, "a@example.com", gotEntity.Email)
+		assert.Empty(t, gotEntity.DisplayName)
+	})
+
+	t.Run("should-ignore-fields-tagged-with-dash", func(t *testing.T) {
+		// GIVEN
+		c := converter.NewReflect[Account, AccountDTO, int](nil, converter.WithStructTags())
+
+		// WHEN
+		gotDTO := c.ToDTO(entity)
+		gotEntity := c.ToEntity(dto)
+
+		// THEN
+		assert.Empty(t, gotDTO.Secret)
+		assert.Empty(t, gotEntity.Secret)
+		assert.Equal(t, "hash", gotEntity.PasswordHash)
+	})
+}
@@ -3,7 +3,31 @@
 // Please refer to sub-packages for more information
 //   - [github.com/infevocorp/goflexstore/query] query interfaces
 //   - [github.com/infevocorp/goflexstore/converter] converters
+//   - [github.com/infevocorp/goflexstore/converter/diff] field changes between entity versions
 //   - [github.com/infevocorp/goflexstore/store] store interfaces
 //   - [github.com/infevocorp/goflexstore/opscope] opscope
 //   - [github.com/infevocorp/goflexstore/filters] default filters
+//   - [github.com/infevocorp/goflexstore/events] entity lifecycle events
+//   - [github.com/infevocorp/goflexstore/events/broker] Kafka and NATS publishers of entity lifecycle events
+//   - [github.com/infevocorp/goflexstore/changelog] change log of entity mutations
+//   - [github.com/infevocorp/goflexstore/tenancy] multi-tenant stores
+//   - [github.com/infevocorp/goflexstore/storetest] in-memory fake store for tests
+//   - [github.com/infevocorp/goflexstore/registry] registry of the stores of an application
+//   - [github.com/infevocorp/goflexstore/health] health checks of the persistence layer
+//   - [github.com/infevocorp/goflexstore/idgen] client-side ID generators
+//   - [github.com/infevocorp/goflexstore/flexlog] structured logging of stores and transactions
+//   - [github.com/infevocorp/goflexstore/ratelimit] rate limiting of store operations
+//   - [github.com/infevocorp/goflexstore/circuitbreaker] circuit breaker of stores and scopes
+//   - [github.com/infevocorp/goflexstore/singleflight] de-duplication of concurrent identical reads
+//   - [github.com/infevocorp/goflexstore/memo] memoization of the reads of a request
+//   - [github.com/infevocorp/goflexstore/importer] bulk import of CSV and ND-JSON streams
+//   - [github.com/infevocorp/goflexstore/export] streaming export of entities as CSV and ND-JSON
+//   - [github.com/infevocorp/goflexstore/search] full-text search combined with filters
+//   - [github.com/infevocorp/goflexstore/saga] workflows with compensations across transactions
+//   - [github.com/infevocorp/goflexstore/shardedstore] routing of operations across sharded stores
+//   - [github.com/infevocorp/goflexstore/archiver] retention of entities, moved to archive stores by chunks
+//   - [github.com/infevocorp/goflexstore/redact] masking of sensitive fields for callers without capability
+//   - [github.com/infevocorp/goflexstore/authz] access control policies consulted before every operation
+//   - [github.com/infevocorp/goflexstore/namedquery] named, reusable query definitions
+//   - [github.com/infevocorp/goflexstore/uow] units of work binding stores to one transaction
 package goflexstore
@@ -0,0 +1,197 @@
+package broker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/infevocorp/goflexstore/events"
+)
+
+// Headers set on every message.
+const (
+	HeaderEventType = "event-type"
+	HeaderEntity    = "entity"
+)
+
+// Message is an event encoded for a broker.
+//
+// Fields:
+//   - Topic: The Kafka topic or the NATS subject of the message.
+//   - Key: The Kafka partitioning key: the ID of the entity, so that the events of an entity are ordered.
+//     It is nil for the events of several entities, and for NATS.
+//   - Value: The JSON Envelope of the event.
+//   - Headers: The type of the event and the name of its entity, see HeaderEventType and HeaderEntity.
+type Message struct {
+	Topic   string
+	Key     []byte
+	Value   []byte
+	Headers map[string]string
+}
+
+// Envelope is the JSON representation of an event.
+//
+// Fields:
+//   - Type: The type of the event, e.g. "created".
+//   - Entity: The name of the entity, e.g. "Article".
+//   - OccurredAt: The time of the change.
+//   - ID: The ID of the created entity, or of the updated one if it has a GetID method.
+//   - Data: The created or updated entity, converted to its DTO if it has a converter.
+//   - Partial: Whether only the non-zero fields of the updated entity were written.
+//   - Deleted: The deleted entities, when they were deleted with DeleteReturning.
+type Envelope struct {
+	Type       events.Type `json:"type"`
+	Entity     string      `json:"entity"`
+	OccurredAt time.Time   `json:"occurred_at"`
+	ID         any         `json:"id,omitempty"`
+	Data       any         `json:"data,omitempty"`
+	Partial    bool        `json:"partial,omitempty"`
+	Deleted    []any       `json:"deleted,omitempty"`
+}
+
+// Sender sends messages to a broker, typically an adapter over the client of the broker.
+type Sender interface {
+	// Send sends the messages, and returns an error if any of them could not be sent.
+	Send(ctx context.Context, messages ...Message) error
+}
+
+// SenderFunc is an adapter allowing the use of an ordinary function as a Sender.
+type SenderFunc func(ctx context.Context, messages ...Message) error
+
+// Send calls f(ctx, messages...).
+func (f SenderFunc) Send(ctx context.Context, messages ...Message) error {
+	return f(ctx, messages...)
+}
+
+// NewKafka creates an events.Bus sending the events to Kafka topics, keyed by the ID of their entity.
+func NewKafka(sender Sender, opts ...Option) events.Bus {
+	return newPublisher(sender, true, func(event events.Event) string {
+		return strings.ToLower(event.EntityName())
+	}, opts)
+}
+
+// NewNATS creates an events.Bus sending the events to NATS subjects, e.g. through JetStream.
+func NewNATS(sender Sender, opts ...Option) events.Bus {
+	return newPublisher(sender, false, func(event events.Event) string {
+		return "events." + strings.ToLower(event.EntityName()) + "." + string(event.EventType())
+	}, opts)
+}
+
+func newPublisher(sender Sender, keyed bool, topic func(events.Event) string, opts []Option) *publisher {
+	o := options{
+		topic:      topic,
+		converters: map[reflect.Type]func(any) any{},
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return &publisher{options: o, sender: sender, keyed: keyed}
+}
+
+// publisher is the events.Bus returned by NewKafka and NewNATS.
+type publisher struct {
+	options
+	sender Sender
+	keyed  bool
+}
+
+// Publish encodes the events and sends them at once.
+func (p *publisher) Publish(ctx context.Context, evts ...events.Event) error {
+	messages := make([]Message, len(evts))
+
+	for i, event := range evts {
+		message, err := p.encode(event)
+		if err != nil {
+			return fmt.Errorf("broker: encode %s %s event: %w", event.EntityName(), event.EventType(), err)
+		}
+
+		messages[i] = message
+	}
+
+	return p.sender.Send(ctx, messages...)
+}
+
+// encode returns the message of the event.
+func (p *publisher) encode(event events.Event) (Message, error) {
+	envelope := Envelope{
+		Type:       event.EventType(),
+		Entity:     event.EntityName(),
+		OccurredAt: event.OccurredAt(),
+	}
+
+	v := reflect.ValueOf(event)
+
+	if id := v.FieldByName("ID"); id.IsValid() {
+		envelope.ID = id.Interface()
+	}
+
+	if entity := v.FieldByName("Entity"); entity.IsValid() {
+		envelope.Data = p.convert(entity.Interface())
+
+		if envelope.ID == nil {
+			envelope.ID = entityID(entity)
+		}
+	}
+
+	if partial := v.FieldByName("Partial"); partial.IsValid() {
+		envelope.Partial = partial.Bool()
+	}
+
+	if deleted := v.FieldByName("Entities"); deleted.IsValid() {
+		for i := 0; i < deleted.Len(); i++ {
+			envelope.Deleted = append(envelope.Deleted, p.convert(deleted.Index(i).Interface()))
+		}
+	}
+
+	value, err := json.Marshal(envelope)
+	if err != nil {
+		return Message{}, err
+	}
+
+	message := Message{
+		Topic: p.topic(event),
+		Value: value,
+		Headers: map[string]string{
+			HeaderEventType: string(envelope.Type),
+			HeaderEntity:    envelope.Entity,
+		},
+	}
+
+	if p.keyed && envelope.ID != nil {
+		message.Key = []byte(fmt.Sprint(envelope.ID))
+	}
+
+	return message, nil
+}
+
+// convert returns the DTO of the entity if it has a converter, the entity otherwise.
+func (p *publisher) convert(entity any) any {
+	if entity == nil {
+		return nil
+	}
+
+	if conv, ok := p.converters[reflect.TypeOf(entity)]; ok {
+		return conv(entity)
+	}
+
+	return entity
+}
+
+// entityID returns the result of the GetID method of the entity, or nil if it has none.
+func entityID(entity reflect.Value) any {
+	if (entity.Kind() == reflect.Pointer || entity.Kind() == reflect.Interface) && entity.IsNil() {
+		return nil
+	}
+
+	getID := entity.MethodByName("GetID")
+	if !getID.IsValid() || getID.Type().NumIn() != 0 || getID.Type().NumOut() != 1 {
+		return nil
+	}
+
+	return getID.Call(nil)[0].Interface()
+}
@@ -0,0 +1,151 @@
+package broker_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infevocorp/goflexstore/events"
+	"github.com/infevocorp/goflexstore/events/broker"
+	"github.com/infevocorp/goflexstore/query"
+)
+
+type Article struct {
+	ID    int64
+	Title string
+	Body  string
+}
+
+func (a *Article) GetID() int64 {
+	return a.ID
+}
+
+// ArticleDTO is the public representation of an article.
+type ArticleDTO struct {
+	ID    int64  `json:"id"`
+	Title string `json:"title"`
+}
+
+func (a *ArticleDTO) GetID() int64 {
+	return a.ID
+}
+
+type articleConverter struct{}
+
+func (articleConverter) ToEntity(dto *ArticleDTO) *Article {
+	return &Article{ID: dto.ID, Title: dto.Title}
+}
+
+func (articleConverter) ToDTO(article *Article) *ArticleDTO {
+	return &ArticleDTO{ID: article.ID, Title: article.Title}
+}
+
+var now = time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+
+// recorder returns a Sender recording the sent messages.
+func recorder(messages *[]broker.Message) broker.Sender {
+	return broker.SenderFunc(func(_ context.Context, sent ...broker.Message) error {
+		*messages = append(*messages, sent...)
+		return nil
+	})
+}
+
+func Test_NewKafka(t *testing.T) {
+	t.Run("should-send-converted-entities-keyed-by-id", func(t *testing.T) {
+		// GIVEN
+		var messages []broker.Message
+
+		bus := broker.NewKafka(recorder(&messages),
+			broker.WithConverter[*Article, *ArticleDTO, int64](articleConverter{}),
+		)
+
+		// WHEN
+		err := bus.Publish(context.Background(),
+			events.EntityCreated[*Article, int64]{
+				Meta:   events.Meta{Name: "Article", Time: now},
+				ID:     1,
+				Entity: &Article{ID: 1, Title: "Hello", Body: "secret draft"},
+			},
+			events.EntityUpdated[*Article]{
+				Meta:    events.Meta{Name: "Article", Time: now},
+				Entity:  &Article{ID: 2, Title: "World"},
+				Params:  []query.Param{query.Filter("ID", 2)},
+				Partial: true,
+			},
+		)
+
+		// THEN
+		require.NoError(t, err)
+		require.Len(t, messages, 2)
+
+		assert.Equal(t, "article", messages[0].Topic)
+		assert.Equal(t, []byte("1"), messages[0].Key)
+		assert.JSONEq(t, `{"type":"created","entity":"Article","occurred_at":"2024-01-02T15:04:05Z",`+
+			`"id":1,"data":{"id":1,"title":"Hello"}}`, string(messages[0].Value))
+		assert.Equal(t, map[string]string{
+			broker.HeaderEventType: "created",
+			broker.HeaderEntity:    "Article",
+		}, messages[0].Headers)
+
+		assert.Equal(t, []byte("2"), messages[1].Key)
+		assert.JSONEq(t, `{"type":"updated","entity":"Article","occurred_at":"2024-01-02T15:04:05Z",`+
+			`"id":2,"data":{"id":2,"title":"World"},"partial":true}`, string(messages[1].Value))
+	})
+
+	t.Run("should-return-sender-error", func(t *testing.T) {
+		// GIVEN
+		sendErr := errors.New("broker unavailable")
+		bus := broker.NewKafka(broker.SenderFunc(func(context.Context, ...broker.Message) error {
+			return sendErr
+		}))
+
+		// WHEN
+		err := bus.Publish(context.Background(), events.EntityDeleted{Meta: events.Meta{Name: "Article", Time: now}})
+
+		// THEN
+		assert.ErrorIs(t, err, sendErr)
+	})
+}
+
+func Test_NewNATS(t *testing.T) {
+	t.Run("should-send-to-subject-of-entity-and-type", func(t *testing.T) {
+		// GIVEN
+		var messages []broker.Message
+
+		bus := broker.NewNATS(recorder(&messages))
+
+		// WHEN
+		err := bus.Publish(context.Background(), events.EntityDeleted{
+			Meta:     events.Meta{Name: "Article", Time: now},
+			Entities: []any{&Article{ID: 3, Title: "Gone"}},
+		})
+
+		// THEN
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+		assert.Equal(t, "events.article.deleted", messages[0].Topic)
+		assert.Nil(t, messages[0].Key)
+		assert.JSONEq(t, `{"type":"deleted","entity":"Article","occurred_at":"2024-01-02T15:04:05Z",`+
+			`"deleted":[{"ID":3,"Title":"Gone","Body":""}]}`, string(messages[0].Value))
+	})
+
+	t.Run("should-use-custom-subject", func(t *testing.T) {
+		// GIVEN
+		var messages []broker.Message
+
+		bus := broker.NewNATS(recorder(&messages), broker.WithTopic(func(event events.Event) string {
+			return "cms." + event.EntityName()
+		}))
+
+		// WHEN
+		err := bus.Publish(context.Background(), events.EntityDeleted{Meta: events.Meta{Name: "Article", Time: now}})
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, "cms.Article", messages[0].Topic)
+	})
+}
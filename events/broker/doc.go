@@ -0,0 +1,44 @@
+// Package broker publishes the entity lifecycle events of the events package to message brokers such as Kafka
+// and NATS JetStream.
+//
+// NewKafka and NewNATS return an events.Bus encoding each event as a JSON Envelope, the entities being converted
+// to their DTO by the converters registered with WithConverter, into a Message addressed the way the broker
+// expects: a topic and a partitioning key for Kafka, a subject for NATS. The messages are handed to a Sender,
+// a thin adapter over the client of the broker, which keeps this package free of client dependencies.
+//
+// The events are published after the commit of the transaction of the mutation when the events.Store shares
+// an operation scope implementing opscope.CommitNotifier, see events.WithScope.
+//
+// Example:
+// Publishing the article events to Kafka with franz-go:
+//
+//	bus := broker.NewKafka(broker.SenderFunc(func(ctx context.Context, messages ...broker.Message) error {
+//		records := make([]*kgo.Record, len(messages))
+//		for i, m := range messages {
+//			records[i] = &kgo.Record{Topic: m.Topic, Key: m.Key, Value: m.Value}
+//		}
+//
+//		return client.ProduceSync(ctx, records...).FirstErr()
+//	}), broker.WithConverter[*model.Article, *dto.Article, int64](articleConverter))
+//
+//	articleStore := events.NewStore[*model.Article, int64](inner, bus, events.WithScope(scope))
+//
+// Example:
+// Publishing to NATS JetStream:
+//
+//	bus := broker.NewNATS(broker.SenderFunc(func(ctx context.Context, messages ...broker.Message) error {
+//		for _, m := range messages {
+//			msg := nats.NewMsg(m.Topic)
+//			msg.Data = m.Value
+//			for k, v := range m.Headers {
+//				msg.Header.Set(k, v)
+//			}
+//
+//			if _, err := js.PublishMsg(ctx, msg); err != nil {
+//				return err
+//			}
+//		}
+//
+//		return nil
+//	}))
+package broker
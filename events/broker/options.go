@@ -0,0 +1,39 @@
+package broker
+
+import (
+	"reflect"
+
+	"github.com/infevocorp/goflexstore/converter"
+	"github.com/infevocorp/goflexstore/events"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// Option is a function that configures the publishers.
+type Option func(*options)
+
+type options struct {
+	topic      func(event events.Event) string
+	converters map[reflect.Type]func(entity any) any
+}
+
+// WithTopic sets the function returning the Kafka topic or the NATS subject of an event.
+//
+// By default, Kafka topics are the lowercase entity name, e.g. "article", and NATS subjects are made of
+// the prefix "events", the lowercase entity name and the event type, e.g. "events.article.created".
+func WithTopic(topic func(event events.Event) string) Option {
+	return func(o *options) {
+		o.topic = topic
+	}
+}
+
+// WithConverter converts the entities of type T to their DTO before encoding them, e.g. to publish a stable
+// public representation instead of the domain model. Entities without converter are encoded as is.
+func WithConverter[T store.Entity[ID], DTO store.Entity[ID], ID comparable](
+	conv converter.Converter[T, DTO, ID],
+) Option {
+	return func(o *options) {
+		o.converters[reflect.TypeOf((*T)(nil)).Elem()] = func(entity any) any {
+			return conv.ToDTO(entity.(T))
+		}
+	}
+}
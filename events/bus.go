@@ -0,0 +1,63 @@
+package events
+
+import (
+	"context"
+)
+
+// Bus publishes events to interested consumers.
+// Implementations may deliver events in-process or forward them to a message broker.
+type Bus interface {
+	// Publish delivers the given events. It returns an error if any of them could not be delivered.
+	Publish(ctx context.Context, events ...Event) error
+}
+
+// BusFunc is an adapter allowing the use of an ordinary function as a Bus.
+type BusFunc func(ctx context.Context, events ...Event) error
+
+// Publish calls f(ctx, events...).
+func (f BusFunc) Publish(ctx context.Context, events ...Event) error {
+	return f(ctx, events...)
+}
+
+// NewChannelBus creates a ChannelBus backed by a channel with the given buffer size.
+//
+// Parameters:
+//   - size: The buffer size of the underlying channel. Publish blocks when the buffer is full.
+//
+// Returns:
+// A new ChannelBus.
+func NewChannelBus(size int) *ChannelBus {
+	return &ChannelBus{
+		ch: make(chan Event, size),
+	}
+}
+
+// ChannelBus is an in-process Bus that delivers events through a Go channel.
+// Consumers receive events by reading from the channel returned by C.
+type ChannelBus struct {
+	ch chan Event
+}
+
+// Publish sends the events to the channel, blocking until there is room in the buffer
+// or the context is done.
+func (b *ChannelBus) Publish(ctx context.Context, events ...Event) error {
+	for _, event := range events {
+		select {
+		case b.ch <- event:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+// C returns the channel from which published events can be received.
+func (b *ChannelBus) C() <-chan Event {
+	return b.ch
+}
+
+// Close closes the underlying channel. Publish must not be called after Close.
+func (b *ChannelBus) Close() {
+	close(b.ch)
+}
@@ -0,0 +1,54 @@
+package events_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infevocorp/goflexstore/events"
+)
+
+func Test_ChannelBus(t *testing.T) {
+	t.Run("should-deliver-events", func(t *testing.T) {
+		// GIVEN
+		bus := events.NewChannelBus(2)
+		event := events.EntityDeleted{Meta: events.Meta{Name: "User"}}
+
+		// WHEN
+		err := bus.Publish(context.Background(), event, event)
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, event, <-bus.C())
+		assert.Equal(t, event, <-bus.C())
+	})
+
+	t.Run("should-return-err-if-context-is-done", func(t *testing.T) {
+		// GIVEN
+		bus := events.NewChannelBus(0)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		// WHEN
+		err := bus.Publish(ctx, events.EntityDeleted{})
+
+		// THEN
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func Test_BusFunc(t *testing.T) {
+	var published []events.Event
+
+	bus := events.BusFunc(func(_ context.Context, events ...events.Event) error {
+		published = append(published, events...)
+		return nil
+	})
+
+	err := bus.Publish(context.Background(), events.EntityDeleted{})
+
+	require.NoError(t, err)
+	assert.Len(t, published, 1)
+}
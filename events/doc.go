@@ -0,0 +1,27 @@
+// Package events provides typed entity lifecycle events and a pluggable bus to publish them.
+//
+// Stores can be decorated with NewStore so that every successful mutation publishes an EntityCreated,
+// EntityUpdated, EntitiesUpdated or EntityDeleted event to a Bus. When the decorated store shares an operation
+// scope that implements opscope.CommitNotifier (such as gormopscope.TransactionScope), events are only published
+// after the outermost scope has been committed, so consumers never observe changes that were rolled back.
+//
+// The Bus interface is intentionally small, making it straightforward to plug in message brokers such as NATS
+// or Kafka. An in-process ChannelBus is provided out of the box, and the events/broker package publishes the
+// events to Kafka and NATS JetStream.
+//
+// Example:
+//
+//	bus := events.NewChannelBus(100)
+//
+//	articleStore := events.NewStore[*model.Article, int64](
+//		gormstore.New[*model.Article, *dto.Article, int64](scope),
+//		bus,
+//		events.WithScope(scope),
+//	)
+//
+//	go func() {
+//		for event := range bus.C() {
+//			log.Println(event.EventType(), event.EntityName())
+//		}
+//	}()
+package events
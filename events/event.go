@@ -0,0 +1,121 @@
+package events
+
+import (
+	"time"
+
+	"github.com/infevocorp/goflexstore/query"
+)
+
+// Type identifies the kind of lifecycle change an event describes.
+type Type string
+
+const (
+	// TypeCreated is the type of events published when entities are created.
+	TypeCreated Type = "created"
+
+	// TypeUpdated is the type of events published when entities are updated or upserted.
+	TypeUpdated Type = "updated"
+
+	// TypeDeleted is the type of events published when entities are deleted.
+	TypeDeleted Type = "deleted"
+)
+
+// Event is the common interface of all entity lifecycle events.
+type Event interface {
+	// EventType returns the kind of change described by the event.
+	EventType() Type
+
+	// EntityName returns the name of the entity the event relates to, e.g. "Article".
+	EntityName() string
+
+	// OccurredAt returns the time the change was made.
+	OccurredAt() time.Time
+}
+
+// Meta holds the information shared by all entity lifecycle events.
+//
+// Fields:
+//   - Name: The name of the entity the event relates to.
+//   - Time: The time the change was made.
+type Meta struct {
+	Name string
+	Time time.Time
+}
+
+// EntityName returns the name of the entity the event relates to.
+func (m Meta) EntityName() string {
+	return m.Name
+}
+
+// OccurredAt returns the time the change was made.
+func (m Meta) OccurredAt() time.Time {
+	return m.Time
+}
+
+// EntityCreated is published after an entity has been created.
+//
+// Fields:
+//   - ID: The identifier of the created entity, as returned by the store.
+//   - Entity: The created entity.
+type EntityCreated[T any, ID comparable] struct {
+	Meta
+	ID     ID
+	Entity T
+}
+
+// EventType returns TypeCreated.
+func (e EntityCreated[T, ID]) EventType() Type {
+	return TypeCreated
+}
+
+// EntityUpdated is published after an entity has been updated, partially updated or upserted.
+//
+// Fields:
+//   - Entity: The entity holding the new values.
+//   - Params: The query parameters used to target the update, if any.
+//   - Partial: Whether only the non-zero fields of Entity have been written.
+type EntityUpdated[T any] struct {
+	Meta
+	Entity  T
+	Params  []query.Param
+	Partial bool
+}
+
+// EventType returns TypeUpdated.
+func (e EntityUpdated[T]) EventType() Type {
+	return TypeUpdated
+}
+
+// EntitiesUpdated is published after the entities matching query parameters have been updated by UpdateMany.
+//
+// Fields:
+//   - Params: The query parameters used to select the updated entities.
+//   - Updates: The new values of the updated fields, keyed by field name.
+//   - Count: The number of updated entities.
+type EntitiesUpdated struct {
+	Meta
+	Params  []query.Param
+	Updates map[string]any
+	Count   int64
+}
+
+// EventType returns TypeUpdated.
+func (e EntitiesUpdated) EventType() Type {
+	return TypeUpdated
+}
+
+// EntityDeleted is published after entities have been deleted.
+//
+// Fields:
+//   - Params: The query parameters used to select the deleted entities.
+//   - Entities: The deleted entities, only known when they were deleted with DeleteReturning.
+type EntityDeleted struct {
+	Meta
+	Params   []query.Param
+	Entities []any
+}
+
+// EventType returns TypeDeleted.
+func (e EntityDeleted) EventType() Type {
+	return TypeDeleted
+}
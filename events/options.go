@@ -0,0 +1,53 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/infevocorp/goflexstore/opscope"
+)
+
+// Option is a function that configures the event publishing Store.
+type Option func(*options)
+
+type options struct {
+	name         string
+	notifier     opscope.CommitNotifier
+	errorHandler func(ctx context.Context, err error)
+	now          func() time.Time
+}
+
+// WithName sets the entity name reported by published events.
+// By default the name of the entity type is used, e.g. "Article" for *model.Article.
+func WithName(name string) Option {
+	return func(o *options) {
+		o.name = name
+	}
+}
+
+// WithScope sets the operation scope shared with the decorated store.
+// If the scope implements opscope.CommitNotifier, events are only published after the outermost scope commits.
+// Otherwise events are published as soon as the operation returns.
+func WithScope(scope opscope.Scope) Option {
+	return func(o *options) {
+		if notifier, ok := scope.(opscope.CommitNotifier); ok {
+			o.notifier = notifier
+		}
+	}
+}
+
+// WithErrorHandler sets the function called when events cannot be published.
+// Because events are published after the data has been persisted, publishing errors are not returned to
+// the caller of the store operation. By default they are ignored.
+func WithErrorHandler(fn func(ctx context.Context, err error)) Option {
+	return func(o *options) {
+		o.errorHandler = fn
+	}
+}
+
+// WithClock sets the function used to timestamp events. It defaults to time.Now.
+func WithClock(now func() time.Time) Option {
+	return func(o *options) {
+		o.now = now
+	}
+}
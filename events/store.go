@@ -0,0 +1,212 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/infevocorp/goflexstore/internal/entityutil"
+	"github.com/infevocorp/goflexstore/query"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// NewStore decorates a store so that every successful mutation publishes a lifecycle event to the bus.
+//
+// Read operations are forwarded to the inner store untouched. Create, CreateMany, Update, PartialUpdate
+// and Delete publish EntityCreated, EntityUpdated and EntityDeleted events respectively, Upsert publishes an
+// EntityCreated or an EntityUpdated event whether it inserts or updates the entity, and UpdateMany publishes an
+// EntitiesUpdated event.
+//
+// Type parameters:
+//   - T: The entity type.
+//   - ID: The type of the entity's identifier.
+//
+// Parameters:
+//   - inner: The store to decorate.
+//   - bus: The bus events are published to.
+//   - opts: Options customizing the decorator, see WithScope, WithName and WithErrorHandler.
+//
+// Returns:
+// A Store implementing store.Store[T, ID].
+//
+// Example:
+//
+//	userStore := events.NewStore[*model.User, int64](inner, bus, events.WithScope(scope))
+func NewStore[T store.Entity[ID], ID comparable](inner store.Store[T, ID], bus Bus, opts ...Option) *Store[T, ID] {
+	o := options{
+		name:         store.EntityName[T](),
+		errorHandler: func(context.Context, error) {},
+		now:          time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return &Store[T, ID]{
+		Store:   inner,
+		Bus:     bus,
+		options: o,
+	}
+}
+
+// Store is a store.Store decorator publishing lifecycle events for the mutations it performs.
+type Store[T store.Entity[ID], ID comparable] struct {
+	store.Store[T, ID]
+	Bus Bus
+
+	options options
+}
+
+// Create creates the entity and publishes an EntityCreated event.
+func (s *Store[T, ID]) Create(ctx context.Context, entity T) (ID, error) {
+	id, err := s.Store.Create(ctx, entity)
+	if err != nil {
+		return id, err
+	}
+
+	s.publish(ctx, EntityCreated[T, ID]{Meta: s.meta(), ID: id, Entity: entity})
+
+	return id, nil
+}
+
+// CreateMany creates the entities and publishes one EntityCreated event per entity.
+func (s *Store[T, ID]) CreateMany(ctx context.Context, entities []T) error {
+	if err := s.Store.CreateMany(ctx, entities); err != nil {
+		return err
+	}
+
+	events := make([]Event, len(entities))
+	meta := s.meta()
+
+	for i, entity := range entities {
+		events[i] = EntityCreated[T, ID]{Meta: meta, ID: entity.GetID(), Entity: entity}
+	}
+
+	s.publish(ctx, events...)
+
+	return nil
+}
+
+// Update updates the entity and publishes an EntityUpdated event.
+func (s *Store[T, ID]) Update(ctx context.Context, entity T, params ...query.Param) error {
+	if err := s.Store.Update(ctx, entity, params...); err != nil {
+		return err
+	}
+
+	s.publish(ctx, EntityUpdated[T]{Meta: s.meta(), Entity: entity, Params: params})
+
+	return nil
+}
+
+// PartialUpdate partially updates the entity and publishes an EntityUpdated event marked as partial.
+func (s *Store[T, ID]) PartialUpdate(ctx context.Context, entity T, params ...query.Param) error {
+	if err := s.Store.PartialUpdate(ctx, entity, params...); err != nil {
+		return err
+	}
+
+	s.publish(ctx, EntityUpdated[T]{Meta: s.meta(), Entity: entity, Params: params, Partial: true})
+
+	return nil
+}
+
+// Upsert creates or updates the entity and publishes an EntityCreated event when it inserts the entity, or an
+// EntityUpdated event when a stored entity conflicts with it. The conflicting entity is looked up before the upsert
+// by the values of the onConflict.Columns, or by ID without conflict columns.
+func (s *Store[T, ID]) Upsert(ctx context.Context, entity T, onConflict store.OnConflict) (ID, error) {
+	conflicts, err := s.conflicts(ctx, entity, onConflict)
+	if err != nil {
+		return *new(ID), err
+	}
+
+	id, err := s.Store.Upsert(ctx, entity, onConflict)
+	if err != nil {
+		return id, err
+	}
+
+	if !conflicts {
+		s.publish(ctx, EntityCreated[T, ID]{Meta: s.meta(), ID: id, Entity: entity})
+		return id, nil
+	}
+
+	s.publish(ctx, EntityUpdated[T]{Meta: s.meta(), Entity: entity})
+
+	return id, nil
+}
+
+// conflicts reports whether a stored entity conflicts with the upsert of entity, an entity without conflict columns
+// nor ID conflicting with none.
+func (s *Store[T, ID]) conflicts(ctx context.Context, entity T, onConflict store.OnConflict) (bool, error) {
+	targets, ok := entityutil.ConflictFilters(entity, onConflict.Columns)
+	if !ok {
+		if entity.GetID() == *new(ID) {
+			return false, nil
+		}
+
+		targets = []query.Param{query.Filter("ID", entity.GetID())}
+	}
+
+	return s.Store.Exists(ctx, targets...)
+}
+
+// UpdateMany updates the matching entities and publishes an EntitiesUpdated event.
+func (s *Store[T, ID]) UpdateMany(ctx context.Context, updates map[string]any, params ...query.Param) (int64, error) {
+	updated, err := s.Store.UpdateMany(ctx, updates, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	s.publish(ctx, EntitiesUpdated{Meta: s.meta(), Params: params, Updates: updates, Count: updated})
+
+	return updated, nil
+}
+
+// Delete deletes the matching entities and publishes an EntityDeleted event.
+func (s *Store[T, ID]) Delete(ctx context.Context, params ...query.Param) error {
+	if err := s.Store.Delete(ctx, params...); err != nil {
+		return err
+	}
+
+	s.publish(ctx, EntityDeleted{Meta: s.meta(), Params: params})
+
+	return nil
+}
+
+// DeleteReturning deletes the matching entities and publishes an EntityDeleted event carrying them.
+func (s *Store[T, ID]) DeleteReturning(ctx context.Context, params ...query.Param) ([]T, error) {
+	entities, err := s.Store.DeleteReturning(ctx, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	deleted := make([]any, len(entities))
+	for i, entity := range entities {
+		deleted[i] = entity
+	}
+
+	s.publish(ctx, EntityDeleted{Meta: s.meta(), Params: params, Entities: deleted})
+
+	return entities, nil
+}
+
+// publish sends the events to the bus, deferring the delivery until commit if the scope supports it.
+func (s *Store[T, ID]) publish(ctx context.Context, events ...Event) {
+	send := func(ctx context.Context) {
+		if err := s.Bus.Publish(ctx, events...); err != nil {
+			s.options.errorHandler(ctx, err)
+		}
+	}
+
+	if s.options.notifier != nil {
+		s.options.notifier.OnCommit(ctx, send)
+		return
+	}
+
+	send(ctx)
+}
+
+func (s *Store[T, ID]) meta() Meta {
+	return Meta{
+		Name: s.options.name,
+		Time: s.options.now(),
+	}
+}
@@ -0,0 +1,341 @@
+package events_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infevocorp/goflexstore/events"
+	mockstore "github.com/infevocorp/goflexstore/mocks/store"
+	"github.com/infevocorp/goflexstore/opscope"
+	"github.com/infevocorp/goflexstore/query"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+type User struct {
+	ID   int
+	Name string
+}
+
+func (u *User) GetID() int {
+	return u.ID
+}
+
+// deferredScope is an opscope.Scope that holds OnCommit callbacks until commit is called.
+type deferredScope struct {
+	opscope.Scope
+	callbacks []func(ctx context.Context)
+}
+
+func (s *deferredScope) OnCommit(_ context.Context, fn func(ctx context.Context)) {
+	s.callbacks = append(s.callbacks, fn)
+}
+
+func (s *deferredScope) commit(ctx context.Context) {
+	for _, fn := range s.callbacks {
+		fn(ctx)
+	}
+}
+
+func newRecorder() (*[]events.Event, events.Bus) {
+	var published []events.Event
+
+	return &published, events.BusFunc(func(_ context.Context, evts ...events.Event) error {
+		published = append(published, evts...)
+		return nil
+	})
+}
+
+func Test_Store_Create(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("should-publish-created-event", func(t *testing.T) {
+		// GIVEN
+		var (
+			ctx            = context.Background()
+			user           = &User{Name: "john"}
+			inner          = mockstore.NewStore[*User, int](t)
+			published, bus = newRecorder()
+		)
+
+		inner.EXPECT().Create(ctx, user).Return(1, nil)
+
+		s := events.NewStore[*User, int](inner, bus, events.WithClock(func() time.Time { return now }))
+
+		// WHEN
+		id, err := s.Create(ctx, user)
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, 1, id)
+		assert.Equal(t, []events.Event{
+			events.EntityCreated[*User, int]{
+				Meta:   events.Meta{Name: "User", Time: now},
+				ID:     1,
+				Entity: user,
+			},
+		}, *published)
+	})
+
+	t.Run("should-not-publish-on-error", func(t *testing.T) {
+		// GIVEN
+		var (
+			ctx            = context.Background()
+			inner          = mockstore.NewStore[*User, int](t)
+			published, bus = newRecorder()
+		)
+
+		inner.EXPECT().Create(ctx, mock.Anything).Return(0, assert.AnError)
+
+		s := events.NewStore[*User, int](inner, bus)
+
+		// WHEN
+		_, err := s.Create(ctx, &User{})
+
+		// THEN
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Empty(t, *published)
+	})
+
+	t.Run("should-publish-after-commit", func(t *testing.T) {
+		// GIVEN
+		var (
+			ctx            = context.Background()
+			inner          = mockstore.NewStore[*User, int](t)
+			scope          = &deferredScope{}
+			published, bus = newRecorder()
+		)
+
+		inner.EXPECT().Create(ctx, mock.Anything).Return(1, nil)
+
+		s := events.NewStore[*User, int](inner, bus, events.WithScope(scope))
+
+		// WHEN
+		_, err := s.Create(ctx, &User{})
+		require.NoError(t, err)
+		assert.Empty(t, *published)
+
+		scope.commit(ctx)
+
+		// THEN
+		assert.Len(t, *published, 1)
+	})
+
+	t.Run("should-report-publish-errors", func(t *testing.T) {
+		// GIVEN
+		var (
+			ctx      = context.Background()
+			inner    = mockstore.NewStore[*User, int](t)
+			reported error
+		)
+
+		inner.EXPECT().Create(ctx, mock.Anything).Return(1, nil)
+
+		s := events.NewStore[*User, int](
+			inner,
+			events.BusFunc(func(context.Context, ...events.Event) error { return assert.AnError }),
+			events.WithErrorHandler(func(_ context.Context, err error) { reported = err }),
+		)
+
+		// WHEN
+		_, err := s.Create(ctx, &User{})
+
+		// THEN
+		assert.NoError(t, err)
+		assert.ErrorIs(t, reported, assert.AnError)
+	})
+}
+
+func Test_Store_Mutations(t *testing.T) {
+	var (
+		ctx            = context.Background()
+		user           = &User{ID: 1, Name: "john"}
+		params         = []query.Param{query.Filter("ID", 1)}
+		inner          = mockstore.NewStore[*User, int](t)
+		published, bus = newRecorder()
+	)
+
+	inner.EXPECT().CreateMany(ctx, []*User{user}).Return(nil)
+	inner.EXPECT().Update(ctx, user, params[0]).Return(nil)
+	inner.EXPECT().PartialUpdate(ctx, user).Return(nil)
+	inner.EXPECT().Exists(ctx, query.Filter("ID", 1)).Return(true, nil)
+	inner.EXPECT().Upsert(ctx, user, store.OnConflict{UpdateAll: true}).Return(1, nil)
+	inner.EXPECT().Delete(ctx, params[0]).Return(nil)
+
+	s := events.NewStore[*User, int](inner, bus, events.WithName("user"))
+
+	require.NoError(t, s.CreateMany(ctx, []*User{user}))
+	require.NoError(t, s.Update(ctx, user, params...))
+	require.NoError(t, s.PartialUpdate(ctx, user))
+	_, err := s.Upsert(ctx, user, store.OnConflict{UpdateAll: true})
+	require.NoError(t, err)
+	require.NoError(t, s.Delete(ctx, params...))
+
+	types := make([]events.Type, len(*published))
+	for i, e := range *published {
+		types[i] = e.EventType()
+
+		assert.Equal(t, "user", e.EntityName())
+	}
+
+	assert.Equal(t, []events.Type{
+		events.TypeCreated,
+		events.TypeUpdated,
+		events.TypeUpdated,
+		events.TypeUpdated,
+		events.TypeDeleted,
+	}, types)
+	assert.True(t, (*published)[2].(events.EntityUpdated[*User]).Partial)
+	assert.Equal(t, params, (*published)[4].(events.EntityDeleted).Params)
+}
+
+func Test_Store_Upsert(t *testing.T) {
+	t.Run("should-publish-created-event-on-insert", func(t *testing.T) {
+		// GIVEN
+		var (
+			ctx            = context.Background()
+			user           = &User{Name: "john"}
+			onConflict     = store.OnConflict{Columns: []string{"name"}, UpdateAll: true}
+			inner          = mockstore.NewStore[*User, int](t)
+			published, bus = newRecorder()
+		)
+
+		inner.EXPECT().Exists(ctx, query.Filter("Name", "john")).Return(false, nil)
+		inner.EXPECT().Upsert(ctx, user, onConflict).Return(7, nil)
+
+		s := events.NewStore[*User, int](inner, bus)
+
+		// WHEN
+		id, err := s.Upsert(ctx, user, onConflict)
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, 7, id)
+		require.Len(t, *published, 1)
+		assert.Equal(t, events.TypeCreated, (*published)[0].EventType())
+		assert.Equal(t, 7, (*published)[0].(events.EntityCreated[*User, int]).ID)
+	})
+
+	t.Run("should-publish-updated-event-on-conflict", func(t *testing.T) {
+		// GIVEN
+		var (
+			ctx            = context.Background()
+			user           = &User{Name: "john"}
+			onConflict     = store.OnConflict{Columns: []string{"name"}, UpdateAll: true}
+			inner          = mockstore.NewStore[*User, int](t)
+			published, bus = newRecorder()
+		)
+
+		inner.EXPECT().Exists(ctx, query.Filter("Name", "john")).Return(true, nil)
+		inner.EXPECT().Upsert(ctx, user, onConflict).Return(1, nil)
+
+		s := events.NewStore[*User, int](inner, bus)
+
+		// WHEN
+		_, err := s.Upsert(ctx, user, onConflict)
+
+		// THEN
+		require.NoError(t, err)
+		require.Len(t, *published, 1)
+		assert.Equal(t, events.TypeUpdated, (*published)[0].EventType())
+	})
+
+	t.Run("should-not-upsert-if-lookup-fails", func(t *testing.T) {
+		// GIVEN
+		var (
+			ctx            = context.Background()
+			user           = &User{ID: 1, Name: "john"}
+			inner          = mockstore.NewStore[*User, int](t)
+			published, bus = newRecorder()
+		)
+
+		inner.EXPECT().Exists(ctx, query.Filter("ID", 1)).Return(false, assert.AnError)
+
+		s := events.NewStore[*User, int](inner, bus)
+
+		// WHEN
+		_, err := s.Upsert(ctx, user, store.OnConflict{})
+
+		// THEN
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Empty(t, *published)
+	})
+}
+
+func Test_Store_DeleteReturning(t *testing.T) {
+	// GIVEN
+	var (
+		ctx            = context.Background()
+		params         = []query.Param{query.Filter("Name", "john")}
+		deleted        = []*User{{ID: 1, Name: "john"}, {ID: 2, Name: "john"}}
+		inner          = mockstore.NewStore[*User, int](t)
+		published, bus = newRecorder()
+	)
+
+	inner.EXPECT().DeleteReturning(ctx, params[0]).Return(deleted, nil)
+
+	s := events.NewStore[*User, int](inner, bus)
+
+	// WHEN
+	got, err := s.DeleteReturning(ctx, params...)
+
+	// THEN
+	require.NoError(t, err)
+	assert.Equal(t, deleted, got)
+	require.Len(t, *published, 1)
+
+	event := (*published)[0].(events.EntityDeleted)
+	assert.Equal(t, params, event.Params)
+	assert.Equal(t, []any{deleted[0], deleted[1]}, event.Entities)
+}
+
+func Test_Store_UpdateMany(t *testing.T) {
+	// GIVEN
+	var (
+		ctx            = context.Background()
+		params         = []query.Param{query.Filter("Name", "john")}
+		updates        = map[string]any{"Name": "jane"}
+		inner          = mockstore.NewStore[*User, int](t)
+		published, bus = newRecorder()
+	)
+
+	inner.EXPECT().UpdateMany(ctx, updates, params[0]).Return(2, nil)
+
+	s := events.NewStore[*User, int](inner, bus)
+
+	// WHEN
+	updated, err := s.UpdateMany(ctx, updates, params...)
+
+	// THEN
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), updated)
+	require.Len(t, *published, 1)
+
+	event := (*published)[0].(events.EntitiesUpdated)
+	assert.Equal(t, events.TypeUpdated, event.EventType())
+	assert.Equal(t, params, event.Params)
+	assert.Equal(t, updates, event.Updates)
+	assert.Equal(t, int64(2), event.Count)
+}
+
+func Test_Store_Reads(t *testing.T) {
+	var (
+		ctx            = context.Background()
+		inner          = mockstore.NewStore[*User, int](t)
+		published, bus = newRecorder()
+	)
+
+	inner.EXPECT().Get(ctx).Return(&User{ID: 1}, nil)
+
+	s := events.NewStore[*User, int](inner, bus)
+
+	user, err := s.Get(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, &User{ID: 1}, user)
+	assert.Empty(t, *published)
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	gormopscope "github.com/infevocorp/goflexstore/gorm/opscope"
+	flexstore "github.com/infevocorp/goflexstore/store"
+
+	"github.com/infevocorp/goflexstore/examples/cms-grpc/model"
+	"github.com/infevocorp/goflexstore/examples/cms-grpc/server"
+	"github.com/infevocorp/goflexstore/examples/cms-grpc/service"
+	"github.com/infevocorp/goflexstore/examples/cms-grpc/store"
+	storesql "github.com/infevocorp/goflexstore/examples/cms-grpc/store/sql"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// open db
+	db, err := gorm.Open(sqlite.Open("cms.db"), &gorm.Config{})
+	panicIfErr(err)
+
+	// run migrations
+	err = storesql.AutoMigrate(db)
+	panicIfErr(err)
+
+	// create scope and stores
+	scope := gormopscope.NewWriteTransactionScope("write", db)
+	stores := storesql.NewStores(scope)
+
+	// seed test data
+	seedData(ctx, stores)
+
+	// new grpc server
+	s := grpc.NewServer()
+
+	// register services
+	server.Register(s, service.NewArticleService(scope, stores))
+	reflection.Register(s)
+
+	lis, err := net.Listen("tcp", ":9090")
+	panicIfErr(err)
+
+	// Serve in a goroutine so that it doesn't block.
+	go func() {
+		if err := s.Serve(lis); err != nil {
+			log.Panicf("server error: %v", err)
+		}
+	}()
+
+	// Block until we receive our signal.
+	<-ctx.Done()
+
+	log.Println("Shutting down server...")
+	s.GracefulStop()
+
+	log.Println("Server shut down gracefully")
+}
+
+func seedData(ctx context.Context, stores store.Stores) {
+	_, err := stores.User.Upsert(ctx, &model.User{
+		ID:    1,
+		Name:  "John Doe",
+		Email: "jonh@email.com",
+	}, flexstore.OnConflict{
+		Columns:   []string{"id"},
+		DoNothing: true,
+	})
+	panicIfErr(err)
+
+	_, err = stores.Article.Upsert(ctx, &model.Article{
+		ID:       1,
+		Title:    "Article 1",
+		Content:  "Content 1",
+		AuthorID: 1,
+		Tags: []*model.Tag{
+			{
+				ID:   1,
+				Slug: "tag-1",
+			},
+		},
+	}, flexstore.OnConflict{
+		Columns:   []string{"id"},
+		UpdateAll: true,
+	})
+	panicIfErr(err)
+}
+
+func panicIfErr(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,29 @@
+module github.com/infevocorp/goflexstore/examples/cms-grpc
+
+go 1.21.6
+
+require (
+	github.com/infevocorp/goflexstore v1.1.0
+	github.com/infevocorp/goflexstore/gorm v1.1.0
+	google.golang.org/grpc v1.62.1
+	google.golang.org/protobuf v1.34.2
+	gorm.io/driver/sqlite v1.5.4
+	gorm.io/gorm v1.25.6
+)
+
+require (
+	github.com/golang/protobuf v1.5.3 // indirect
+	github.com/jinzhu/inflection v1.0.0 // indirect
+	github.com/jinzhu/now v1.1.5 // indirect
+	github.com/mattn/go-sqlite3 v1.14.17 // indirect
+	github.com/pkg/errors v0.9.1 // indirect
+	golang.org/x/net v0.20.0 // indirect
+	golang.org/x/sys v0.16.0 // indirect
+	golang.org/x/text v0.14.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240123012728-ef4313101c80 // indirect
+)
+
+replace (
+	github.com/infevocorp/goflexstore => ../..
+	github.com/infevocorp/goflexstore/gorm => ../../gorm
+)
@@ -0,0 +1,177 @@
+package server
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/infevocorp/goflexstore/query"
+	flexstore "github.com/infevocorp/goflexstore/store"
+
+	"github.com/infevocorp/goflexstore/examples/cms-grpc/filters"
+	cmsv1 "github.com/infevocorp/goflexstore/examples/cms-grpc/gen/cms/v1"
+	"github.com/infevocorp/goflexstore/examples/cms-grpc/model"
+	"github.com/infevocorp/goflexstore/examples/cms-grpc/service"
+)
+
+const (
+	defaultPageSize = 10
+	maxPageSize     = 100
+)
+
+// ArticleServer implements cmsv1.ArticleServiceServer on top of the article use cases.
+type ArticleServer struct {
+	cmsv1.UnimplementedArticleServiceServer
+
+	Articles *service.ArticleService
+}
+
+func Register(s *grpc.Server, articles *service.ArticleService) *ArticleServer {
+	srv := &ArticleServer{
+		Articles: articles,
+	}
+
+	cmsv1.RegisterArticleServiceServer(s, srv)
+
+	return srv
+}
+
+func (s *ArticleServer) GetArticle(ctx context.Context, req *cmsv1.GetArticleRequest) (*cmsv1.Article, error) {
+	article, err := s.Articles.Get(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return articleToProto(article), nil
+}
+
+func (s *ArticleServer) ListArticles(
+	ctx context.Context,
+	req *cmsv1.ListArticlesRequest,
+) (*cmsv1.ListArticlesResponse, error) {
+	limit := int(req.GetLimit())
+	if limit <= 0 {
+		limit = defaultPageSize
+	} else if limit > maxPageSize {
+		limit = maxPageSize
+	}
+
+	params := []query.Param{
+		query.Paginate(int(req.GetOffset()), limit),
+	}
+
+	if req.GetAuthorId() > 0 {
+		params = append(params, filters.AuthorID(req.GetAuthorId()))
+	}
+
+	if req.GetTag() != "" {
+		params = append(params, filters.Tag(req.GetTag()))
+	}
+
+	articles, err := s.Articles.List(ctx, params...)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &cmsv1.ListArticlesResponse{
+		Articles: articlesToProto(articles),
+	}, nil
+}
+
+func (s *ArticleServer) CreateArticle(
+	ctx context.Context,
+	req *cmsv1.CreateArticleRequest,
+) (*cmsv1.Article, error) {
+	if req.GetArticle() == nil {
+		return nil, status.Error(codes.InvalidArgument, "article is required")
+	}
+
+	article := articleFromProto(req.GetArticle())
+	article.ID = 0
+
+	if article.Title == "" {
+		return nil, status.Error(codes.InvalidArgument, "article.title is required")
+	}
+
+	created, err := s.Articles.Create(ctx, article, req.GetTagSlugs())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return articleToProto(created), nil
+}
+
+// UpdateArticle updates the fields of the article listed in the update mask.
+// An empty mask updates every updatable field.
+func (s *ArticleServer) UpdateArticle(
+	ctx context.Context,
+	req *cmsv1.UpdateArticleRequest,
+) (*cmsv1.Article, error) {
+	if req.GetArticle() == nil {
+		return nil, status.Error(codes.InvalidArgument, "article is required")
+	}
+
+	paths := req.GetUpdateMask().GetPaths()
+	if len(paths) == 0 {
+		paths = []string{"title", "content", "author_id"}
+	}
+
+	for _, path := range paths {
+		if _, ok := updatableFields[path]; !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "field %q cannot be updated", path)
+		}
+	}
+
+	src := articleFromProto(req.GetArticle())
+
+	updated, err := s.Articles.Update(ctx, src.ID, func(dst *model.Article) error {
+		for _, path := range paths {
+			updatableFields[path](dst, src)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return articleToProto(updated), nil
+}
+
+func (s *ArticleServer) DeleteArticle(
+	ctx context.Context,
+	req *cmsv1.DeleteArticleRequest,
+) (*cmsv1.DeleteArticleResponse, error) {
+	if err := s.Articles.Delete(ctx, req.GetId()); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &cmsv1.DeleteArticleResponse{}, nil
+}
+
+// updatableFields maps the field mask paths accepted by UpdateArticle
+// to the function copying the field from the request to the stored article.
+var updatableFields = map[string]func(dst, src *model.Article){
+	"title":     func(dst, src *model.Article) { dst.Title = src.Title },
+	"content":   func(dst, src *model.Article) { dst.Content = src.Content },
+	"author_id": func(dst, src *model.Article) { dst.AuthorID = src.AuthorID },
+}
+
+// toStatus maps the errors returned by the use cases to gRPC status errors.
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, flexstore.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrAuthorNotFound):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
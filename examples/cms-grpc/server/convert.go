@@ -0,0 +1,75 @@
+package server
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	cmsv1 "github.com/infevocorp/goflexstore/examples/cms-grpc/gen/cms/v1"
+	"github.com/infevocorp/goflexstore/examples/cms-grpc/model"
+)
+
+// articleToProto converts an article model to its protobuf representation.
+func articleToProto(a *model.Article) *cmsv1.Article {
+	if a == nil {
+		return nil
+	}
+
+	pb := &cmsv1.Article{
+		Id:         a.ID,
+		Title:      a.Title,
+		Content:    a.Content,
+		AuthorId:   a.AuthorID,
+		CreateTime: timestamppb.New(a.CreatedAt),
+		UpdateTime: timestamppb.New(a.UpdatedAt),
+		Author:     userToProto(a.Author),
+	}
+
+	for _, tag := range a.Tags {
+		pb.Tags = append(pb.Tags, tagToProto(tag))
+	}
+
+	return pb
+}
+
+// articleFromProto converts a protobuf article to the article model.
+// Output-only fields (timestamps, author, tags) are ignored.
+func articleFromProto(pb *cmsv1.Article) *model.Article {
+	return &model.Article{
+		ID:       pb.GetId(),
+		Title:    pb.GetTitle(),
+		Content:  pb.GetContent(),
+		AuthorID: pb.GetAuthorId(),
+	}
+}
+
+func articlesToProto(articles []*model.Article) []*cmsv1.Article {
+	pbs := make([]*cmsv1.Article, len(articles))
+
+	for i, a := range articles {
+		pbs[i] = articleToProto(a)
+	}
+
+	return pbs
+}
+
+func userToProto(u *model.User) *cmsv1.User {
+	if u == nil {
+		return nil
+	}
+
+	return &cmsv1.User{
+		Id:    u.ID,
+		Name:  u.Name,
+		Email: u.Email,
+	}
+}
+
+func tagToProto(t *model.Tag) *cmsv1.Tag {
+	if t == nil {
+		return nil
+	}
+
+	return &cmsv1.Tag{
+		Id:   t.ID,
+		Slug: t.Slug,
+	}
+}
@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	flexfilters "github.com/infevocorp/goflexstore/filters"
+	"github.com/infevocorp/goflexstore/opscope"
+	"github.com/infevocorp/goflexstore/query"
+	flexstore "github.com/infevocorp/goflexstore/store"
+
+	"github.com/infevocorp/goflexstore/examples/cms-grpc/filters"
+	"github.com/infevocorp/goflexstore/examples/cms-grpc/model"
+	"github.com/infevocorp/goflexstore/examples/cms-grpc/store"
+)
+
+// ArticleService implements the article use cases. Every mutating use case runs in a single
+// operation scope so that all the store calls it makes are committed or rolled back together.
+type ArticleService struct {
+	Scope  opscope.Scope
+	Stores store.Stores
+}
+
+func NewArticleService(scope opscope.Scope, stores store.Stores) *ArticleService {
+	return &ArticleService{
+		Scope:  scope,
+		Stores: stores,
+	}
+}
+
+// Get returns the article with its author and tags.
+func (s *ArticleService) Get(ctx context.Context, id int64) (*model.Article, error) {
+	return s.Stores.Article.Get(ctx,
+		flexfilters.IDs(id),
+		query.Preload("Author"),
+		query.Preload("Tags"),
+	)
+}
+
+// List returns the articles matching the given params, with their author and tags.
+func (s *ArticleService) List(ctx context.Context, params ...query.Param) ([]*model.Article, error) {
+	return s.Stores.Article.List(ctx, append(params,
+		query.Preload("Author"),
+		query.Preload("Tags"),
+	)...)
+}
+
+// Create creates the article and attaches the given tags, creating the tags that do not exist yet.
+func (s *ArticleService) Create(
+	ctx context.Context,
+	article *model.Article,
+	tagSlugs []string,
+) (_ *model.Article, err error) {
+	ctx, err = s.Scope.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer s.Scope.EndWithRecover(ctx, &err)
+
+	if exists, err := s.Stores.User.Exists(ctx, flexfilters.IDs(article.AuthorID)); err != nil {
+		return nil, err
+	} else if !exists {
+		return nil, ErrAuthorNotFound
+	}
+
+	for _, slug := range tagSlugs {
+		tag, err := s.getOrCreateTag(ctx, slug)
+		if err != nil {
+			return nil, err
+		}
+
+		article.Tags = append(article.Tags, tag)
+	}
+
+	id, err := s.Stores.Article.Create(ctx, article)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Get(ctx, id)
+}
+
+// Update applies mutate to the current state of the article and saves it.
+// The article is locked for the duration of the operation to avoid lost updates.
+func (s *ArticleService) Update(
+	ctx context.Context,
+	id int64,
+	mutate func(article *model.Article) error,
+) (_ *model.Article, err error) {
+	ctx, err = s.Scope.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer s.Scope.EndWithRecover(ctx, &err)
+
+	article, err := s.Stores.Article.Get(ctx,
+		flexfilters.IDs(id),
+		query.WithLock(query.LockTypeForUpdate),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = mutate(article); err != nil {
+		return nil, err
+	}
+
+	if exists, err := s.Stores.User.Exists(ctx, flexfilters.IDs(article.AuthorID)); err != nil {
+		return nil, err
+	} else if !exists {
+		return nil, ErrAuthorNotFound
+	}
+
+	if err = s.Stores.Article.Update(ctx, article, flexfilters.IDs(id)); err != nil {
+		return nil, err
+	}
+
+	return s.Get(ctx, id)
+}
+
+// Delete deletes the article.
+func (s *ArticleService) Delete(ctx context.Context, id int64) (err error) {
+	ctx, err = s.Scope.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer s.Scope.EndWithRecover(ctx, &err)
+
+	if exists, err := s.Stores.Article.Exists(ctx, flexfilters.IDs(id)); err != nil {
+		return err
+	} else if !exists {
+		return flexstore.ErrNotFound
+	}
+
+	return s.Stores.Article.Delete(ctx, flexfilters.IDs(id))
+}
+
+func (s *ArticleService) getOrCreateTag(ctx context.Context, slug string) (*model.Tag, error) {
+	tag, err := s.Stores.Tag.Get(ctx, filters.Slug(slug))
+	if err == nil {
+		return tag, nil
+	}
+
+	if !errors.Is(err, flexstore.ErrNotFound) {
+		return nil, err
+	}
+
+	tag = &model.Tag{Slug: slug}
+
+	if tag.ID, err = s.Stores.Tag.Create(ctx, tag); err != nil {
+		return nil, err
+	}
+
+	return tag, nil
+}
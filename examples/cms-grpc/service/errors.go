@@ -0,0 +1,6 @@
+package service
+
+import "errors"
+
+// ErrAuthorNotFound is returned when an article references an author that does not exist.
+var ErrAuthorNotFound = errors.New("author not found")
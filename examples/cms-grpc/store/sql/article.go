@@ -0,0 +1,45 @@
+package sql
+
+import (
+	"gorm.io/gorm"
+
+	gormopscope "github.com/infevocorp/goflexstore/gorm/opscope"
+	gormquery "github.com/infevocorp/goflexstore/gorm/query"
+	gormstore "github.com/infevocorp/goflexstore/gorm/store"
+	gormutils "github.com/infevocorp/goflexstore/gorm/utils"
+	"github.com/infevocorp/goflexstore/query"
+
+	"github.com/infevocorp/goflexstore/examples/cms-grpc/model"
+	"github.com/infevocorp/goflexstore/examples/cms-grpc/store/sql/dto"
+)
+
+func NewArticleStore(scope *gormopscope.TransactionScope) *ArticleStore {
+	return &ArticleStore{
+		Store: gormstore.New[*model.Article, *dto.Article, int64](
+			scope,
+			gormstore.WithScopeBuilderOption[*model.Article, *dto.Article, int64](
+				gormquery.WithFieldToColMap(gormutils.FieldToColMap(dto.Article{})),
+				gormquery.WithCustomFilters(map[string]gormquery.ScopeBuilderFunc{
+					"tag": filterByTag,
+				}),
+			),
+		),
+	}
+}
+
+type ArticleStore struct {
+	*gormstore.Store[*model.Article, *dto.Article, int64]
+}
+
+// filterByTag selects the articles having one of the given tag slugs.
+func filterByTag(param query.Param) gormquery.ScopeFunc {
+	p := param.(query.FilterParam)
+
+	return func(tx *gorm.DB) *gorm.DB {
+		return tx.Where(
+			"id IN (SELECT article_id FROM article_tags INNER JOIN tags ON tags.id = article_tags.tag_id "+
+				"WHERE tags.slug IN ?)",
+			p.Value,
+		)
+	}
+}
@@ -0,0 +1,14 @@
+package sql
+
+import (
+	"github.com/infevocorp/goflexstore/examples/cms-grpc/store"
+	gormopscope "github.com/infevocorp/goflexstore/gorm/opscope"
+)
+
+func NewStores(scope *gormopscope.TransactionScope) store.Stores {
+	return store.Stores{
+		Article: NewArticleStore(scope),
+		User:    NewUserStore(scope),
+		Tag:     NewTagStore(scope),
+	}
+}
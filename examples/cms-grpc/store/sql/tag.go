@@ -0,0 +1,20 @@
+package sql
+
+import (
+	"github.com/infevocorp/goflexstore/examples/cms-grpc/model"
+	"github.com/infevocorp/goflexstore/examples/cms-grpc/store/sql/dto"
+	gormopscope "github.com/infevocorp/goflexstore/gorm/opscope"
+	gormstore "github.com/infevocorp/goflexstore/gorm/store"
+)
+
+type TagStore struct {
+	*gormstore.Store[*model.Tag, *dto.Tag, int64]
+}
+
+func NewTagStore(scope *gormopscope.TransactionScope) *TagStore {
+	return &TagStore{
+		Store: gormstore.New[*model.Tag, *dto.Tag, int64](
+			scope,
+		),
+	}
+}
@@ -3,4 +3,5 @@ package store
 type Stores struct {
 	User    UserStore
 	Article ArticleStore
+	Tag     TagStore
 }
@@ -0,0 +1,10 @@
+package store
+
+import (
+	"github.com/infevocorp/goflexstore/examples/cms-grpc/model"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+type TagStore interface {
+	store.Store[*model.Tag, int64]
+}
@@ -0,0 +1,11 @@
+package store
+
+import (
+	"github.com/infevocorp/goflexstore/examples/cms-grpc/model"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// UserStore is a store for users
+type UserStore interface {
+	store.Store[*model.User, int64]
+}
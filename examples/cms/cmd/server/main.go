@@ -16,11 +16,11 @@ import (
 	"gorm.io/gorm"
 
 	gormopscope "github.com/infevocorp/goflexstore/gorm/opscope"
+	"github.com/infevocorp/goflexstore/registry"
 	flexstore "github.com/infevocorp/goflexstore/store"
 
 	"github.com/infevocorp/goflexstore/examples/cms/handlers"
 	"github.com/infevocorp/goflexstore/examples/cms/model"
-	"github.com/infevocorp/goflexstore/examples/cms/store"
 	storesql "github.com/infevocorp/goflexstore/examples/cms/store/sql"
 )
 
@@ -66,29 +66,29 @@ func main() {
 	log.Println("Server shut down gracefully")
 }
 
-func newStores(ctx context.Context) store.Stores {
+func newStores(ctx context.Context) *registry.Registry[*gormopscope.TransactionScope] {
 	// open db
 	db, err := gorm.Open(sqlite.Open("cms.db"), &gorm.Config{})
 	panicIfErr(err)
 
-	// run migrations
-	err = storesql.AutoMigrate(db)
-	panicIfErr(err)
-
 	// create scope
 	scope := gormopscope.NewWriteTransactionScope("write", db)
 
 	// create stores
 	stores := storesql.NewStores(scope)
 
+	// run migrations
+	err = storesql.Migrate(ctx, db, stores)
+	panicIfErr(err)
+
 	// seed test data
 	seedData(ctx, stores)
 
 	return stores
 }
 
-func seedData(ctx context.Context, stores store.Stores) {
-	_, err := stores.User.Upsert(ctx, &model.User{
+func seedData(ctx context.Context, stores *registry.Registry[*gormopscope.TransactionScope]) {
+	_, err := registry.MustResolve[*model.User, int64](stores).Upsert(ctx, &model.User{
 		ID:    1,
 		Name:  "John Doe",
 		Email: "jonh@email.com",
@@ -98,7 +98,7 @@ func seedData(ctx context.Context, stores store.Stores) {
 	})
 	panicIfErr(err)
 
-	_, err = stores.Article.Upsert(ctx, &model.Article{
+	_, err = registry.MustResolve[*model.Article, int64](stores).Upsert(ctx, &model.Article{
 		ID:       1,
 		Title:    "Article 1",
 		Content:  "Content 1",
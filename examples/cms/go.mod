@@ -3,8 +3,8 @@ module github.com/infevocorp/goflexstore/examples/cms
 go 1.21.6
 
 require (
-	github.com/infevocorp/goflexstore v1.0.10
-	github.com/infevocorp/goflexstore/gorm v1.0.10
+	github.com/infevocorp/goflexstore v1.1.0
+	github.com/infevocorp/goflexstore/gorm v1.1.0
 	gorm.io/driver/sqlite v1.5.4
 )
 
@@ -25,6 +25,11 @@ require (
 	github.com/jinzhu/inflection v1.0.0 // indirect
 	github.com/jinzhu/now v1.1.5 // indirect
 	github.com/labstack/echo/v4 v4.11.4
-	github.com/pkg/errors v0.9.1 // indirect
+	github.com/pkg/errors v0.9.1
 	gorm.io/gorm v1.25.6
 )
+
+replace (
+	github.com/infevocorp/goflexstore => ../..
+	github.com/infevocorp/goflexstore/gorm => ../../gorm
+)
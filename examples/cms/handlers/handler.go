@@ -3,16 +3,20 @@ package handlers
 import (
 	"github.com/labstack/echo/v4"
 
+	"github.com/infevocorp/goflexstore/opscope"
+	"github.com/infevocorp/goflexstore/registry"
+
+	"github.com/infevocorp/goflexstore/examples/cms/model"
 	"github.com/infevocorp/goflexstore/examples/cms/store"
 )
 
 type Handler struct {
-	Stores store.Stores
+	Articles store.ArticleStore
 }
 
-func Register(stores store.Stores, e *echo.Echo) *Handler {
+func Register[S opscope.Scope](stores *registry.Registry[S], e *echo.Echo) *Handler {
 	h := &Handler{
-		Stores: stores,
+		Articles: registry.MustResolve[*model.Article, int64](stores),
 	}
 
 	h.Register(e)
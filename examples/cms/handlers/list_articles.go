@@ -42,7 +42,7 @@ func (h *Handler) ListArticles(c echo.Context) error {
 		params = append(params, filters.Tag(req.Tag))
 	}
 
-	articles, err := h.Stores.Article.List(c.Request().Context(), params...)
+	articles, err := h.Articles.List(c.Request().Context(), params...)
 	if err != nil {
 		return err
 	}
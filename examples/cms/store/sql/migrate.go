@@ -1,15 +1,34 @@
 package sql
 
 import (
+	"context"
+
 	"gorm.io/gorm"
 
 	"github.com/infevocorp/goflexstore/examples/cms/store/sql/dto"
+	gormmigrate "github.com/infevocorp/goflexstore/gorm/migrate"
+	gormopscope "github.com/infevocorp/goflexstore/gorm/opscope"
+	"github.com/infevocorp/goflexstore/registry"
 )
 
-func AutoMigrate(db *gorm.DB) error {
-	return db.AutoMigrate(
-		dto.Article{},
-		dto.User{},
-		dto.Tag{},
-	)
+// Migrate creates or updates the tables of the registered stores and of the tags, along with their indexes.
+func Migrate(ctx context.Context, db *gorm.DB, stores *registry.Registry[*gormopscope.TransactionScope]) error {
+	return gormmigrate.New(db,
+		gormmigrate.WithStores(stores),
+		// tags are saved along with their articles and have no store of their own
+		gormmigrate.WithModels(&dto.Tag{}),
+		gormmigrate.WithIndexes(
+			gormmigrate.Index{
+				Model:  &dto.User{},
+				Name:   "idx_users_email",
+				Fields: []string{"Email"},
+				Unique: true,
+			},
+			gormmigrate.Index{
+				Model:  &dto.Article{},
+				Name:   "idx_articles_author_created",
+				Fields: []string{"AuthorID", "CreatedAt"},
+			},
+		),
+	).Migrate(ctx)
 }
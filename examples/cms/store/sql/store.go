@@ -1,13 +1,17 @@
 package sql
 
 import (
-	"github.com/infevocorp/goflexstore/examples/cms/store"
+	"github.com/infevocorp/goflexstore/examples/cms/model"
 	gormopscope "github.com/infevocorp/goflexstore/gorm/opscope"
+	"github.com/infevocorp/goflexstore/registry"
 )
 
-func NewStores(scope *gormopscope.TransactionScope) store.Stores {
-	return store.Stores{
-		Article: NewArticleStore(scope),
-		User:    NewUserStore(scope),
-	}
+// NewStores registers the stores of the CMS in a registry sharing the transaction scope.
+func NewStores(scope *gormopscope.TransactionScope) *registry.Registry[*gormopscope.TransactionScope] {
+	stores := registry.New(scope)
+
+	registry.Register[*model.Article, int64](stores, NewArticleStore(scope))
+	registry.Register[*model.User, int64](stores, NewUserStore(scope))
+
+	return stores
 }
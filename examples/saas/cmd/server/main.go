@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+
+	gormopscope "github.com/infevocorp/goflexstore/gorm/opscope"
+	flexstore "github.com/infevocorp/goflexstore/store"
+	"github.com/infevocorp/goflexstore/tenancy"
+
+	"github.com/infevocorp/goflexstore/examples/saas/handlers"
+	"github.com/infevocorp/goflexstore/examples/saas/model"
+	"github.com/infevocorp/goflexstore/examples/saas/store"
+	storesql "github.com/infevocorp/goflexstore/examples/saas/store/sql"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	stores := newStores(ctx)
+
+	// new echo instance
+	e := echo.New()
+
+	// register handlers
+	handlers.Register(stores, e)
+
+	// Initialize the server in a goroutine so that it doesn't block.
+	go func() {
+		if err := e.StartServer(&http.Server{
+			Addr: ":8080",
+			BaseContext: func(net.Listener) context.Context {
+				return ctx
+			},
+			ReadTimeout:  time.Duration(5) * time.Second,
+			WriteTimeout: time.Duration(5) * time.Second,
+		}); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Panicf("server error: %v", err)
+		}
+	}()
+
+	// Block until we receive our signal.
+	<-ctx.Done()
+
+	shutDownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	log.Println("Shutting down server...")
+	if err := e.Shutdown(shutDownCtx); err != nil {
+		log.Fatalf("Could not gracefully shutdown the server: %+v", err)
+	}
+
+	log.Println("Server shut down gracefully")
+}
+
+func newStores(ctx context.Context) store.Stores {
+	// open primary db
+	db, err := gorm.Open(sqlite.Open("saas.db"), &gorm.Config{})
+	panicIfErr(err)
+
+	// run migrations
+	err = storesql.AutoMigrate(db)
+	panicIfErr(err)
+
+	// Route reads to the replica. Queries running in a transaction, and thus every
+	// operation in a write scope, keep using the primary so they read their own writes.
+	// The replica is simulated here with a read-only connection to the same database.
+	err = db.Use(dbresolver.Register(dbresolver.Config{
+		Replicas: []gorm.Dialector{sqlite.Open("file:saas.db?mode=ro")},
+	}))
+	panicIfErr(err)
+
+	// create scope
+	scope := gormopscope.NewWriteTransactionScope("write", db)
+
+	// create stores
+	stores := storesql.NewStores(scope)
+
+	// seed test data
+	seedData(ctx, stores)
+
+	return stores
+}
+
+func seedData(ctx context.Context, stores store.Stores) {
+	for tenantID, key := range map[string]string{
+		"acme":   "acme-secret",
+		"globex": "globex-secret",
+	} {
+		_, err := stores.APIKey.Upsert(ctx, &model.APIKey{
+			Key:      key,
+			TenantID: tenantID,
+		}, flexstore.OnConflict{
+			Columns:   []string{"key"},
+			DoNothing: true,
+		})
+		panicIfErr(err)
+
+		// projects can only be accessed with a tenant in the context
+		tenantCtx := tenancy.WithTenant(ctx, tenantID)
+
+		count, err := stores.Project.Count(tenantCtx)
+		panicIfErr(err)
+
+		if count == 0 {
+			_, err = stores.Project.Create(tenantCtx, &model.Project{
+				Name:        "Welcome",
+				Description: "The first project of " + tenantID,
+			})
+			panicIfErr(err)
+		}
+	}
+}
+
+func panicIfErr(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
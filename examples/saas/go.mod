@@ -0,0 +1,33 @@
+module github.com/infevocorp/goflexstore/examples/saas
+
+go 1.21.6
+
+require (
+	github.com/infevocorp/goflexstore v1.1.0
+	github.com/infevocorp/goflexstore/gorm v1.1.0
+	github.com/labstack/echo/v4 v4.11.4
+	github.com/pkg/errors v0.9.1
+	gorm.io/driver/sqlite v1.5.4
+	gorm.io/gorm v1.25.6
+	gorm.io/plugin/dbresolver v1.5.1
+)
+
+require (
+	github.com/jinzhu/inflection v1.0.0 // indirect
+	github.com/jinzhu/now v1.1.5 // indirect
+	github.com/labstack/gommon v0.4.2 // indirect
+	github.com/mattn/go-colorable v0.1.13 // indirect
+	github.com/mattn/go-isatty v0.0.20 // indirect
+	github.com/mattn/go-sqlite3 v1.14.17 // indirect
+	github.com/valyala/bytebufferpool v1.0.0 // indirect
+	github.com/valyala/fasttemplate v1.2.2 // indirect
+	golang.org/x/crypto v0.17.0 // indirect
+	golang.org/x/net v0.19.0 // indirect
+	golang.org/x/sys v0.15.0 // indirect
+	golang.org/x/text v0.14.0 // indirect
+)
+
+replace (
+	github.com/infevocorp/goflexstore => ../..
+	github.com/infevocorp/goflexstore/gorm => ../../gorm
+)
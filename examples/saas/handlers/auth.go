@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/infevocorp/goflexstore/query"
+	flexstore "github.com/infevocorp/goflexstore/store"
+	"github.com/infevocorp/goflexstore/tenancy"
+)
+
+// Authenticate resolves the tenant from the API key of the request and injects it into the request context,
+// so that every tenant scoped store used by the next handlers only sees the data of this tenant.
+func (h *Handler) Authenticate(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		key, ok := strings.CutPrefix(c.Request().Header.Get(echo.HeaderAuthorization), "Bearer ")
+		if !ok || key == "" {
+			return echo.NewHTTPError(http.StatusUnauthorized, "missing api key")
+		}
+
+		ctx := c.Request().Context()
+
+		apiKey, err := h.Stores.APIKey.Get(ctx, query.Filter("Key", key))
+		if errors.Is(err, flexstore.ErrNotFound) {
+			return echo.NewHTTPError(http.StatusUnauthorized, "invalid api key")
+		} else if err != nil {
+			return err
+		}
+
+		c.SetRequest(c.Request().WithContext(tenancy.WithTenant(ctx, apiKey.TenantID)))
+
+		return next(c)
+	}
+}
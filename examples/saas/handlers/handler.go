@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/infevocorp/goflexstore/examples/saas/store"
+)
+
+type Handler struct {
+	Stores store.Stores
+}
+
+func Register(stores store.Stores, e *echo.Echo) *Handler {
+	h := &Handler{
+		Stores: stores,
+	}
+
+	h.Register(e)
+
+	return h
+}
+
+func (h *Handler) Register(e *echo.Echo) {
+	g := e.Group("", h.Authenticate)
+
+	g.GET("/projects", h.ListProjects)
+	g.POST("/projects", h.CreateProject)
+	g.GET("/projects/:id", h.GetProject)
+	g.PUT("/projects/:id", h.UpdateProject)
+	g.DELETE("/projects/:id", h.DeleteProject)
+}
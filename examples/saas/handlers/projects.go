@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/infevocorp/goflexstore/filters"
+	"github.com/infevocorp/goflexstore/query"
+	flexstore "github.com/infevocorp/goflexstore/store"
+
+	"github.com/infevocorp/goflexstore/examples/saas/model"
+)
+
+type ListProjectsRequest struct {
+	Offset int `query:"offset"`
+	Limit  int `query:"limit"`
+}
+
+type ProjectRequest struct {
+	ID          int64  `param:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+func (h *Handler) ListProjects(c echo.Context) error {
+	req := ListProjectsRequest{
+		Offset: 0,
+		Limit:  10,
+	}
+
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	projects, err := h.Stores.Project.List(c.Request().Context(),
+		query.Paginate(req.Offset, req.Limit),
+		query.OrderBy("ID", false),
+	)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, projects)
+}
+
+func (h *Handler) GetProject(c echo.Context) error {
+	var req ProjectRequest
+
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	project, err := h.Stores.Project.Get(c.Request().Context(), filters.IDs(req.ID))
+	if err != nil {
+		return toHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, project)
+}
+
+func (h *Handler) CreateProject(c echo.Context) error {
+	var req ProjectRequest
+
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	// The tenant is assigned by the store from the request context.
+	project := &model.Project{
+		Name:        req.Name,
+		Description: req.Description,
+	}
+
+	id, err := h.Stores.Project.Create(c.Request().Context(), project)
+	if err != nil {
+		return err
+	}
+
+	project.ID = id
+
+	return c.JSON(http.StatusCreated, project)
+}
+
+func (h *Handler) UpdateProject(c echo.Context) error {
+	var req ProjectRequest
+
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+
+	project, err := h.Stores.Project.Get(ctx, filters.IDs(req.ID))
+	if err != nil {
+		return toHTTPError(err)
+	}
+
+	project.Name = req.Name
+	project.Description = req.Description
+
+	if err := h.Stores.Project.Update(ctx, project); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, project)
+}
+
+// DeleteProject soft deletes the project: it is excluded from queries but kept in the database.
+func (h *Handler) DeleteProject(c echo.Context) error {
+	var req ProjectRequest
+
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+
+	if exists, err := h.Stores.Project.Exists(ctx, filters.IDs(req.ID)); err != nil {
+		return err
+	} else if !exists {
+		return toHTTPError(flexstore.ErrNotFound)
+	}
+
+	if err := h.Stores.Project.Delete(ctx, filters.IDs(req.ID)); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func toHTTPError(err error) error {
+	if errors.Is(err, flexstore.ErrNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+
+	return err
+}
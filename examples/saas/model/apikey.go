@@ -0,0 +1,12 @@
+package model
+
+// APIKey authenticates the requests of a tenant.
+type APIKey struct {
+	ID       int64
+	Key      string
+	TenantID string
+}
+
+func (k *APIKey) GetID() int64 {
+	return k.ID
+}
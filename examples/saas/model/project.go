@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// Project is owned by a single tenant.
+type Project struct {
+	ID          int64
+	TenantID    string
+	Name        string
+	Description string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (p *Project) GetID() int64 {
+	return p.ID
+}
@@ -0,0 +1,12 @@
+package store
+
+import (
+	"github.com/infevocorp/goflexstore/examples/saas/model"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// APIKeyStore is a store for API keys.
+// It is not tenant scoped since it is used to resolve the tenant of a request.
+type APIKeyStore interface {
+	store.Store[*model.APIKey, int64]
+}
@@ -0,0 +1,11 @@
+package store
+
+import (
+	"github.com/infevocorp/goflexstore/examples/saas/model"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// ProjectStore is a tenant scoped store for projects
+type ProjectStore interface {
+	store.Store[*model.Project, int64]
+}
@@ -0,0 +1,19 @@
+package sql
+
+import (
+	gormopscope "github.com/infevocorp/goflexstore/gorm/opscope"
+	gormstore "github.com/infevocorp/goflexstore/gorm/store"
+
+	"github.com/infevocorp/goflexstore/examples/saas/model"
+	"github.com/infevocorp/goflexstore/examples/saas/store/sql/dto"
+)
+
+type APIKeyStore struct {
+	*gormstore.Store[*model.APIKey, *dto.APIKey, int64]
+}
+
+func NewAPIKeyStore(scope *gormopscope.TransactionScope) *APIKeyStore {
+	return &APIKeyStore{
+		Store: gormstore.New[*model.APIKey, *dto.APIKey, int64](scope),
+	}
+}
@@ -0,0 +1,15 @@
+package dto
+
+type APIKey struct {
+	ID       int64  `gorm:"column:id;primaryKey;autoIncrement"`
+	Key      string `gorm:"column:key;uniqueIndex"`
+	TenantID string `gorm:"column:tenant_id"`
+}
+
+func (APIKey) TableName() string {
+	return "api_keys"
+}
+
+func (k APIKey) GetID() int64 {
+	return k.ID
+}
@@ -0,0 +1,24 @@
+package dto
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Project struct {
+	ID          int64  `gorm:"column:id;primaryKey;autoIncrement"`
+	TenantID    string `gorm:"column:tenant_id;index"`
+	Name        string `gorm:"column:name"`
+	Description string `gorm:"column:description"`
+
+	CreatedAt time.Time `gorm:"column:created_at"`
+	UpdatedAt time.Time `gorm:"column:updated_at"`
+
+	// DeletedAt makes deletes soft: deleted projects are kept and excluded from queries.
+	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at;index"`
+}
+
+func (p Project) GetID() int64 {
+	return p.ID
+}
@@ -0,0 +1,14 @@
+package sql
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/infevocorp/goflexstore/examples/saas/store/sql/dto"
+)
+
+func AutoMigrate(db *gorm.DB) error {
+	return db.AutoMigrate(
+		dto.Project{},
+		dto.APIKey{},
+	)
+}
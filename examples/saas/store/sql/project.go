@@ -0,0 +1,23 @@
+package sql
+
+import (
+	gormopscope "github.com/infevocorp/goflexstore/gorm/opscope"
+	gormstore "github.com/infevocorp/goflexstore/gorm/store"
+	"github.com/infevocorp/goflexstore/tenancy"
+
+	"github.com/infevocorp/goflexstore/examples/saas/model"
+	"github.com/infevocorp/goflexstore/examples/saas/store/sql/dto"
+)
+
+type ProjectStore struct {
+	*tenancy.Store[*model.Project, int64]
+}
+
+// NewProjectStore returns a project store restricted to the tenant carried by the context.
+func NewProjectStore(scope *gormopscope.TransactionScope) *ProjectStore {
+	return &ProjectStore{
+		Store: tenancy.NewStore[*model.Project, int64](
+			gormstore.New[*model.Project, *dto.Project, int64](scope),
+		),
+	}
+}
@@ -0,0 +1,14 @@
+package sql
+
+import (
+	gormopscope "github.com/infevocorp/goflexstore/gorm/opscope"
+
+	"github.com/infevocorp/goflexstore/examples/saas/store"
+)
+
+func NewStores(scope *gormopscope.TransactionScope) store.Stores {
+	return store.Stores{
+		Project: NewProjectStore(scope),
+		APIKey:  NewAPIKeyStore(scope),
+	}
+}
@@ -0,0 +1,6 @@
+package store
+
+type Stores struct {
+	Project ProjectStore
+	APIKey  APIKeyStore
+}
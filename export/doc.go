@@ -0,0 +1,18 @@
+// Package export streams the entities of stores as CSV or ND-JSON, e.g. to serve the reporting endpoints
+// exporting millions of rows with a bounded memory.
+//
+// An Exporter lists the entities by pages ordered by ID, each page starting after the last ID of the previous one,
+// so that the queries stay as cheap at the end of the table as at its start, and writes each page before listing
+// the next one.
+//
+// Example:
+//
+//	exporter := export.New[*model.Article, int64](articleStore, export.WithColumns("ID", "Title", "PublishedAt"))
+//
+//	func (h *Handler) ExportArticles(w http.ResponseWriter, r *http.Request) {
+//		w.Header().Set("Content-Type", "text/csv")
+//		if _, err := exporter.Export(r.Context(), w, export.CSV, query.Filter("Status", "published")); err != nil {
+//			log.Printf("export articles: %v", err)
+//		}
+//	}
+package export
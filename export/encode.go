@@ -0,0 +1,145 @@
+package export
+
+import (
+	"encoding"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// encoder writes the entities of an export.
+type encoder interface {
+	begin() error
+	encode(entity any) error
+	flush() error
+}
+
+// csvEncoder writes entities as CSV records, preceded by the header.
+type csvEncoder struct {
+	writer  *csv.Writer
+	entity  reflect.Type
+	columns []string
+	fields  [][]int
+	record  []string
+}
+
+func newCSVEncoder(w io.Writer, entity reflect.Type, columns []string) *csvEncoder {
+	for entity.Kind() == reflect.Pointer {
+		entity = entity.Elem()
+	}
+
+	return &csvEncoder{writer: csv.NewWriter(w), entity: entity, columns: columns}
+}
+
+// begin resolves the columns to the fields of the entity type and writes their names.
+func (e *csvEncoder) begin() error {
+	t := e.entity
+	if t.Kind() != reflect.Struct {
+		return fmt.Errorf("entity %s is not a struct", t)
+	}
+
+	if len(e.columns) == 0 {
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).IsExported() {
+				e.columns = append(e.columns, t.Field(i).Name)
+			}
+		}
+	}
+
+	header := make([]string, len(e.columns))
+	e.fields = make([][]int, len(e.columns))
+	e.record = make([]string, len(e.columns))
+
+	for i, column := range e.columns {
+		field, ok := t.FieldByName(column)
+		if !ok || !field.IsExported() {
+			return fmt.Errorf("entity %s has no exported field %s", t, column)
+		}
+
+		header[i] = column
+		if name, _, _ := strings.Cut(field.Tag.Get("json"), ","); name != "" && name != "-" {
+			header[i] = name
+		}
+
+		e.fields[i] = field.Index
+	}
+
+	return e.writer.Write(header)
+}
+
+func (e *csvEncoder) encode(entity any) error {
+	v := indirect(reflect.ValueOf(entity))
+	if v.Kind() != reflect.Struct {
+		return fmt.Errorf("entity %T is nil", entity)
+	}
+
+	for i, index := range e.fields {
+		cell, err := formatCell(v.FieldByIndex(index))
+		if err != nil {
+			return fmt.Errorf("%s: %w", e.columns[i], err)
+		}
+
+		e.record[i] = cell
+	}
+
+	return e.writer.Write(e.record)
+}
+
+func (e *csvEncoder) flush() error {
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+// formatCell returns the text of a field: empty for nil values, the result of MarshalText if it has the method,
+// the string itself for strings, and the JSON value otherwise, e.g. for numbers and booleans.
+func formatCell(field reflect.Value) (string, error) {
+	if (field.Kind() == reflect.Pointer || field.Kind() == reflect.Interface) && field.IsNil() {
+		return "", nil
+	}
+
+	if m, ok := field.Interface().(encoding.TextMarshaler); ok {
+		text, err := m.MarshalText()
+		return string(text), err
+	}
+
+	field = indirect(field)
+	if field.Kind() == reflect.String {
+		return field.String(), nil
+	}
+
+	data, err := json.Marshal(field.Interface())
+
+	return string(data), err
+}
+
+func indirect(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+
+	return v
+}
+
+// ndjsonEncoder writes entities as JSON objects separated by newlines.
+type ndjsonEncoder struct {
+	encoder *json.Encoder
+}
+
+func newNDJSONEncoder(w io.Writer) *ndjsonEncoder {
+	return &ndjsonEncoder{encoder: json.NewEncoder(w)}
+}
+
+func (e *ndjsonEncoder) begin() error {
+	return nil
+}
+
+func (e *ndjsonEncoder) encode(entity any) error {
+	return e.encoder.Encode(entity)
+}
+
+func (e *ndjsonEncoder) flush() error {
+	return nil
+}
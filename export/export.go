@@ -0,0 +1,119 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/infevocorp/goflexstore/query"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// Format is the format of an exported stream.
+type Format int
+
+const (
+	// CSV is a CSV stream whose first record is the header naming the columns.
+	CSV Format = iota
+	// NDJSON is a stream of JSON objects separated by newlines.
+	NDJSON
+)
+
+// Exporter streams the entities of a store, see New.
+type Exporter[T store.Entity[ID], ID comparable] struct {
+	options
+	store store.Store[T, ID]
+}
+
+// New creates an Exporter listing the entities of the store.
+func New[T store.Entity[ID], ID comparable](s store.Store[T, ID], opts ...Option) *Exporter[T, ID] {
+	o := options{
+		pageSize: 1000,
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.pageSize < 1 {
+		o.pageSize = 1
+	}
+
+	return &Exporter[T, ID]{
+		options: o,
+		store:   s,
+	}
+}
+
+// Export writes the entities matching params to w in the given format, and returns the number of entities written.
+//
+// The entities are written in the order of their ID: the OrderBy and Paginate params are ignored. The pages are
+// listed with the context, so a long export should run in a transaction, e.g. a read-only one, to write a
+// consistent snapshot of the entities.
+func (e *Exporter[T, ID]) Export(ctx context.Context, w io.Writer, format Format, params ...query.Param) (int, error) {
+	enc, err := e.newEncoder(w, format)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := enc.begin(); err != nil {
+		return 0, fmt.Errorf("export: %w", err)
+	}
+
+	base := make([]query.Param, 0, len(params)+3)
+	for _, param := range query.NewParams(params...).Params() {
+		switch param.(type) {
+		case query.OrderByParam, query.PaginateParam:
+		default:
+			base = append(base, param)
+		}
+	}
+
+	base = append(base, query.OrderBy("ID", false), query.Paginate(0, e.pageSize))
+
+	var (
+		count  int
+		lastID ID
+	)
+
+	for {
+		pageParams := base
+		if count > 0 {
+			pageParams = append(pageParams[:len(base):len(base)], query.Filter("ID", lastID).WithOP(query.GT))
+		}
+
+		page, err := e.store.List(ctx, pageParams...)
+		if err != nil {
+			return count, fmt.Errorf("export: list page after %d entities: %w", count, err)
+		}
+
+		for _, entity := range page {
+			if err := enc.encode(entity); err != nil {
+				return count, fmt.Errorf("export: write entity %v: %w", entity.GetID(), err)
+			}
+
+			count++
+			lastID = entity.GetID()
+		}
+
+		if err := enc.flush(); err != nil {
+			return count, fmt.Errorf("export: %w", err)
+		}
+
+		if len(page) < e.pageSize {
+			return count, nil
+		}
+	}
+}
+
+func (e *Exporter[T, ID]) newEncoder(w io.Writer, format Format) (encoder, error) {
+	switch format {
+	case CSV:
+		return newCSVEncoder(w, reflect.TypeOf((*T)(nil)).Elem(), e.columns), nil
+	case NDJSON:
+		return newNDJSONEncoder(w), nil
+	default:
+		return nil, fmt.Errorf("export: unknown format %d", format)
+	}
+}
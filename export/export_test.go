@@ -0,0 +1,114 @@
+package export_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infevocorp/goflexstore/export"
+	"github.com/infevocorp/goflexstore/query"
+	"github.com/infevocorp/goflexstore/store"
+	"github.com/infevocorp/goflexstore/storetest"
+)
+
+type Article struct {
+	ID          int64      `json:"id"`
+	Title       string     `json:"title"`
+	Status      string     `json:"status"`
+	PublishedAt *time.Time `json:"published_at"`
+}
+
+func (a *Article) GetID() int64 {
+	return a.ID
+}
+
+func newArticles() *storetest.Fake[*Article, int64] {
+	publishedAt := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	return storetest.NewFake[*Article, int64](
+		&Article{ID: 3, Title: "Third", Status: "draft"},
+		&Article{ID: 1, Title: "Hello, world", Status: "published", PublishedAt: &publishedAt},
+		&Article{ID: 2, Title: "Second", Status: "published"},
+		&Article{ID: 4, Title: "Fourth", Status: "published"},
+	)
+}
+
+func Test_Exporter_Export(t *testing.T) {
+	t.Run("should-write-csv-by-pages-in-id-order", func(t *testing.T) {
+		// GIVEN
+		var (
+			buf      bytes.Buffer
+			articles = newArticles()
+		)
+
+		exporter := export.New[*Article, int64](articles,
+			export.WithPageSize(2),
+			export.WithColumns("ID", "Title", "PublishedAt"),
+		)
+
+		// WHEN
+		n, err := exporter.Export(context.Background(), &buf, export.CSV,
+			query.Filter("Status", "published"), query.OrderBy("Title", true))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, 3, n)
+		assert.Equal(t, "id,title,published_at\n"+
+			"1,\"Hello, world\",2024-01-02T15:04:05Z\n"+
+			"2,Second,\n"+
+			"4,Fourth,\n", buf.String())
+		assert.Len(t, articles.Calls(store.MethodList), 2)
+	})
+
+	t.Run("should-write-ndjson", func(t *testing.T) {
+		// GIVEN
+		var buf bytes.Buffer
+
+		exporter := export.New[*Article, int64](newArticles(), export.WithPageSize(10))
+
+		// WHEN
+		n, err := exporter.Export(context.Background(), &buf, export.NDJSON, query.Filter("Status", "draft"))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+		assert.JSONEq(t, `{"id":3,"title":"Third","status":"draft","published_at":null}`, buf.String())
+	})
+
+	t.Run("should-write-header-of-empty-csv", func(t *testing.T) {
+		// GIVEN
+		var buf bytes.Buffer
+
+		exporter := export.New[*Article, int64](storetest.NewFake[*Article, int64]())
+
+		// WHEN
+		n, err := exporter.Export(context.Background(), &buf, export.CSV)
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, 0, n)
+		assert.Equal(t, "id,title,status,published_at\n", buf.String())
+	})
+
+	t.Run("should-return-list-error", func(t *testing.T) {
+		// GIVEN
+		var (
+			buf      bytes.Buffer
+			articles = newArticles()
+			dbErr    = errors.New("connection reset")
+		)
+
+		articles.FailWith(store.MethodList, dbErr)
+
+		// WHEN
+		_, err := export.New[*Article, int64](articles).Export(context.Background(), &buf, export.NDJSON)
+
+		// THEN
+		assert.ErrorIs(t, err, dbErr)
+	})
+}
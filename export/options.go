@@ -0,0 +1,25 @@
+package export
+
+// Option is a function that configures the Exporter.
+type Option func(*options)
+
+type options struct {
+	pageSize int
+	columns  []string
+}
+
+// WithPageSize sets the number of entities listed at once. It defaults to 1000.
+func WithPageSize(size int) Option {
+	return func(o *options) {
+		o.pageSize = size
+	}
+}
+
+// WithColumns sets the fields of the entities written as CSV columns, in order.
+// By default, every exported field is written in the order of declaration.
+// ND-JSON objects are encoded like JSON, with every field.
+func WithColumns(fields ...string) Option {
+	return func(o *options) {
+		o.columns = fields
+	}
+}
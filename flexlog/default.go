@@ -0,0 +1,8 @@
+//go:build !go1.21
+
+package flexlog
+
+// Default returns Nop, since log/slog requires Go 1.21.
+func Default() Logger {
+	return Nop
+}
@@ -0,0 +1,21 @@
+// Package flexlog provides the structured logging of the stores and operation scopes of goflexstore.
+//
+// The components logging their operations, such as the Middleware decorating a store, the gormstore stores and
+// the gormopscope transaction scopes, accept a Logger through their options. They log with the same attribute
+// keys, e.g. KeyEntity and KeyDuration, so their logs can be filtered and aggregated together instead of each
+// consumer wiring the logger of GORM separately. The transaction of the context, reported by opscope.TxInfo,
+// is added to every record as KeyTx.
+//
+// Logger is implemented over log/slog by NewSlog, and Default logs to slog.Default(). Operations succeeding are
+// logged at LevelDebug and failing ones at LevelError.
+//
+// Example:
+//
+//	logger := flexlog.NewSlog(slog.New(slog.NewJSONHandler(os.Stdout, nil)))
+//
+//	scope := gormopscope.NewWriteTransactionScope("writeTx", db, gormopscope.WithLogger(logger))
+//	inner := gormstore.New[*model.Article, *dto.Article, int64](scope,
+//		gormstore.WithLogger[*model.Article, *dto.Article, int64](logger),
+//	)
+//	articleStore := store.Chain[*model.Article, int64](inner, flexlog.Middleware(logger))
+package flexlog
@@ -0,0 +1,117 @@
+package flexlog
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/infevocorp/goflexstore/opscope"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// Level is the severity of a log record. Its values are the ones of slog.Level.
+type Level int
+
+// Levels of the records.
+const (
+	LevelDebug Level = -4
+	LevelInfo  Level = 0
+	LevelWarn  Level = 4
+	LevelError Level = 8
+)
+
+// Keys of the attributes logged by goflexstore.
+const (
+	// KeyEntity is the name of the entity type, see store.EntityName.
+	KeyEntity = "entity"
+	// KeyOperation is the store method, e.g. "List", or the transaction step, e.g. "commit".
+	KeyOperation = "operation"
+	// KeyDuration is the duration of the operation.
+	KeyDuration = "duration"
+	// KeyTx is the name of the transaction scope of the context, see opscope.TxInfo.
+	KeyTx = "tx"
+	// KeyRows is the number of rows returned or affected by the operation.
+	KeyRows = "rows"
+	// KeySQL is the SQL statement executed by the operation.
+	KeySQL = "sql"
+	// KeyError is the error the operation failed with.
+	KeyError = "error"
+)
+
+// Logger logs structured records. args are alternating keys and values, as in slog.Logger.Log.
+type Logger interface {
+	Log(ctx context.Context, level Level, msg string, args ...any)
+}
+
+// Func is a function implementing Logger.
+type Func func(ctx context.Context, level Level, msg string, args ...any)
+
+// Log calls f.
+func (f Func) Log(ctx context.Context, level Level, msg string, args ...any) {
+	f(ctx, level, msg, args...)
+}
+
+// Nop is a Logger discarding every record.
+var Nop Logger = Func(func(context.Context, Level, string, ...any) {})
+
+// Record is an operation logged by Log.
+//
+// Fields:
+//   - Entity: The name of the entity type, omitted if empty.
+//   - Operation: The operation, e.g. "List".
+//   - Tx: The name of the transaction, defaulting to the transaction of the context.
+//   - Start: When the operation started, to log its duration.
+//   - Rows: The number of rows returned or affected, omitted if negative.
+//   - SQL: The SQL statement, omitted if empty.
+//   - Err: The error of the operation, if any.
+type Record struct {
+	Entity    string
+	Operation string
+	Tx        string
+	Start     time.Time
+	Rows      int64
+	SQL       string
+	Err       error
+}
+
+// Log logs the record with the name of the transaction of the context, if any. The record is logged at
+// LevelError if it failed, and at LevelDebug if it succeeded or did not find the entity.
+func Log(ctx context.Context, logger Logger, msg string, r Record) {
+	args := make([]any, 0, 14)
+
+	if r.Entity != "" {
+		args = append(args, KeyEntity, r.Entity)
+	}
+
+	args = append(args, KeyOperation, r.Operation, KeyDuration, time.Since(r.Start))
+
+	if r.Tx == "" {
+		if info, ok := opscope.TxInfo(ctx); ok {
+			r.Tx = info.Name
+		}
+	}
+
+	if r.Tx != "" {
+		args = append(args, KeyTx, r.Tx)
+	}
+
+	if r.Rows >= 0 {
+		args = append(args, KeyRows, r.Rows)
+	}
+
+	if r.SQL != "" {
+		args = append(args, KeySQL, r.SQL)
+	}
+
+	level := LevelDebug
+
+	if r.Err != nil {
+		args = append(args, KeyError, r.Err)
+
+		if !errors.Is(r.Err, store.ErrNotFound) {
+			level = LevelError
+		}
+	}
+
+	logger.Log(ctx, level, msg, args...)
+}
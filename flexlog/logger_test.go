@@ -0,0 +1,104 @@
+package flexlog_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infevocorp/goflexstore/flexlog"
+	mockopscope "github.com/infevocorp/goflexstore/mocks/opscope"
+	"github.com/infevocorp/goflexstore/opscope"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// entry is a record logged to the logger returned by recorder.
+type entry struct {
+	level flexlog.Level
+	msg   string
+	attrs map[string]any
+}
+
+// recorder returns a logger appending its records to entries.
+func recorder(entries *[]entry) flexlog.Logger {
+	return flexlog.Func(func(_ context.Context, level flexlog.Level, msg string, args ...any) {
+		attrs := make(map[string]any, len(args)/2)
+		for i := 0; i+1 < len(args); i += 2 {
+			attrs[args[i].(string)] = args[i+1]
+		}
+
+		*entries = append(*entries, entry{level: level, msg: msg, attrs: attrs})
+	})
+}
+
+func Test_Log(t *testing.T) {
+	t.Run("should-log-successful-operation-at-debug", func(t *testing.T) {
+		// GIVEN
+		var (
+			entries   []entry
+			inspector = mockopscope.NewTxInspector(t)
+			ctx       = opscope.WithTxInspector(context.Background(), inspector)
+		)
+
+		inspector.EXPECT().TxInfo().Return(opscope.Info{Name: "writeTx", Level: 1}, true)
+
+		// WHEN
+		flexlog.Log(ctx, recorder(&entries), "store operation", flexlog.Record{
+			Entity:    "Article",
+			Operation: "List",
+			Start:     time.Now().Add(-time.Second),
+			Rows:      3,
+		})
+
+		// THEN
+		require.Len(t, entries, 1)
+		assert.Equal(t, flexlog.LevelDebug, entries[0].level)
+		assert.Equal(t, "store operation", entries[0].msg)
+		assert.Equal(t, "Article", entries[0].attrs[flexlog.KeyEntity])
+		assert.Equal(t, "List", entries[0].attrs[flexlog.KeyOperation])
+		assert.Equal(t, "writeTx", entries[0].attrs[flexlog.KeyTx])
+		assert.Equal(t, int64(3), entries[0].attrs[flexlog.KeyRows])
+		assert.GreaterOrEqual(t, entries[0].attrs[flexlog.KeyDuration], time.Second)
+		assert.NotContains(t, entries[0].attrs, flexlog.KeyError)
+	})
+
+	t.Run("should-log-failed-operation-at-error", func(t *testing.T) {
+		// GIVEN
+		var entries []entry
+
+		// WHEN
+		flexlog.Log(context.Background(), recorder(&entries), "store operation", flexlog.Record{
+			Operation: "Create",
+			Start:     time.Now(),
+			Rows:      -1,
+			Err:       assert.AnError,
+		})
+
+		// THEN
+		require.Len(t, entries, 1)
+		assert.Equal(t, flexlog.LevelError, entries[0].level)
+		assert.Equal(t, assert.AnError, entries[0].attrs[flexlog.KeyError])
+		assert.NotContains(t, entries[0].attrs, flexlog.KeyEntity)
+		assert.NotContains(t, entries[0].attrs, flexlog.KeyTx)
+		assert.NotContains(t, entries[0].attrs, flexlog.KeyRows)
+	})
+
+	t.Run("should-log-not-found-at-debug", func(t *testing.T) {
+		// GIVEN
+		var entries []entry
+
+		// WHEN
+		flexlog.Log(context.Background(), recorder(&entries), "store operation", flexlog.Record{
+			Operation: "Get",
+			Start:     time.Now(),
+			Rows:      -1,
+			Err:       store.ErrNotFound,
+		})
+
+		// THEN
+		require.Len(t, entries, 1)
+		assert.Equal(t, flexlog.LevelDebug, entries[0].level)
+	})
+}
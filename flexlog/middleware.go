@@ -0,0 +1,78 @@
+package flexlog
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// Middleware returns a store middleware logging every operation of the store with its entity, duration,
+// transaction and the number of entities it returned or was given. A nil logger logs to Default().
+//
+// Example:
+//
+//	articleStore := store.Chain[*model.Article, int64](inner, flexlog.Middleware(logger))
+func Middleware(logger Logger) store.Middleware {
+	if logger == nil {
+		logger = Default()
+	}
+
+	return func(next store.OperationFunc) store.OperationFunc {
+		return func(ctx context.Context, op *store.Operation) (any, error) {
+			start := time.Now()
+
+			result, err := next(ctx, op)
+
+			Log(ctx, logger, "store operation", Record{
+				Entity:    op.Entity,
+				Operation: op.Method,
+				Start:     start,
+				Rows:      RowsOf(op, result, err),
+				Err:       err,
+			})
+
+			return result, err
+		}
+	}
+}
+
+// RowsOf returns the number of entities returned by the operation, or given to CreateMany, or updated by
+// UpdateMany, or -1 if it is unknown. It lets other instrumentation report the same rows as Middleware.
+func RowsOf(op *store.Operation, result any, err error) int64 {
+	if err != nil {
+		return -1
+	}
+
+	switch op.Method {
+	case store.MethodGet:
+		return 1
+	case store.MethodList, store.MethodDeleteReturning:
+		return lenOf(result)
+	case store.MethodListWithCount:
+		if rv := reflect.ValueOf(result); rv.Kind() == reflect.Struct {
+			return lenOf(rv.FieldByName("Entities").Interface())
+		}
+
+		return -1
+	case store.MethodCreateMany:
+		return lenOf(op.Input)
+	case store.MethodUpdateMany:
+		if updated, ok := result.(int64); ok {
+			return updated
+		}
+
+		return -1
+	default:
+		return -1
+	}
+}
+
+func lenOf(v any) int64 {
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Slice {
+		return int64(rv.Len())
+	}
+
+	return -1
+}
@@ -0,0 +1,63 @@
+package flexlog_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infevocorp/goflexstore/flexlog"
+	mockstore "github.com/infevocorp/goflexstore/mocks/store"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+type Article struct {
+	ID    int
+	Title string
+}
+
+func (a *Article) GetID() int {
+	return a.ID
+}
+
+func Test_Middleware(t *testing.T) {
+	t.Run("should-log-operations-with-rows", func(t *testing.T) {
+		// GIVEN
+		var (
+			ctx     = context.Background()
+			inner   = mockstore.NewStore[*Article, int](t)
+			entries []entry
+		)
+
+		inner.EXPECT().List(ctx).Return([]*Article{{ID: 1}, {ID: 2}}, nil)
+		inner.EXPECT().CreateMany(ctx, []*Article{{ID: 3}}).Return(nil)
+		inner.EXPECT().Count(ctx).Return(0, assert.AnError)
+
+		s := store.Chain[*Article, int](inner, flexlog.Middleware(recorder(&entries)))
+
+		// WHEN
+		articles, err := s.List(ctx)
+		require.NoError(t, err)
+		require.Len(t, articles, 2)
+
+		require.NoError(t, s.CreateMany(ctx, []*Article{{ID: 3}}))
+
+		_, err = s.Count(ctx)
+
+		// THEN
+		assert.ErrorIs(t, err, assert.AnError)
+		require.Len(t, entries, 3)
+
+		assert.Equal(t, "Article", entries[0].attrs[flexlog.KeyEntity])
+		assert.Equal(t, store.MethodList, entries[0].attrs[flexlog.KeyOperation])
+		assert.Equal(t, int64(2), entries[0].attrs[flexlog.KeyRows])
+
+		assert.Equal(t, store.MethodCreateMany, entries[1].attrs[flexlog.KeyOperation])
+		assert.Equal(t, int64(1), entries[1].attrs[flexlog.KeyRows])
+
+		assert.Equal(t, store.MethodCount, entries[2].attrs[flexlog.KeyOperation])
+		assert.Equal(t, flexlog.LevelError, entries[2].level)
+		assert.NotContains(t, entries[2].attrs, flexlog.KeyRows)
+	})
+}
@@ -0,0 +1,31 @@
+//go:build go1.21
+
+package flexlog
+
+import (
+	"context"
+	"log/slog"
+)
+
+// NewSlog returns a Logger logging to the slog logger.
+func NewSlog(logger *slog.Logger) Logger {
+	return slogLogger{logger: logger}
+}
+
+// Default returns a Logger logging to slog.Default() at the time of each record.
+func Default() Logger {
+	return slogLogger{}
+}
+
+type slogLogger struct {
+	logger *slog.Logger
+}
+
+func (l slogLogger) Log(ctx context.Context, level Level, msg string, args ...any) {
+	logger := l.logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	logger.Log(ctx, slog.Level(level), msg, args...)
+}
@@ -0,0 +1,39 @@
+//go:build go1.21
+
+package flexlog_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infevocorp/goflexstore/flexlog"
+)
+
+func Test_NewSlog(t *testing.T) {
+	t.Run("should-log-to-slog-logger", func(t *testing.T) {
+		// GIVEN
+		var (
+			buf    bytes.Buffer
+			logger = flexlog.NewSlog(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+				Level: slog.LevelDebug,
+				ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+					if a.Key == slog.TimeKey {
+						return slog.Attr{}
+					}
+
+					return a
+				},
+			})))
+		)
+
+		// WHEN
+		logger.Log(context.Background(), flexlog.LevelDebug, "store operation", flexlog.KeyEntity, "Article")
+
+		// THEN
+		assert.Equal(t, "level=DEBUG msg=\"store operation\" entity=Article\n", buf.String())
+	})
+}
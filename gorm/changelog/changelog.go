@@ -0,0 +1,87 @@
+package gormchangelog
+
+import (
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/infevocorp/goflexstore/changelog"
+	"github.com/infevocorp/goflexstore/converter"
+	gormopscope "github.com/infevocorp/goflexstore/gorm/opscope"
+	gormstore "github.com/infevocorp/goflexstore/gorm/store"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// ChangeDTO is the database model of a change log entry.
+type ChangeDTO struct {
+	ID        int64     `gorm:"column:id;primaryKey;autoIncrement"`
+	Entity    string    `gorm:"column:entity;size:191;index:idx_changes_entity,priority:1"`
+	EntityID  string    `gorm:"column:entity_id;size:191;index:idx_changes_entity,priority:2"`
+	Operation string    `gorm:"column:operation;size:16"`
+	Actor     string    `gorm:"column:actor;size:191"`
+	Before    []byte    `gorm:"column:before"`
+	After     []byte    `gorm:"column:after"`
+	ChangedAt time.Time `gorm:"column:changed_at;index"`
+}
+
+// TableName returns the name of the change log table.
+func (ChangeDTO) TableName() string {
+	return "changes"
+}
+
+// GetID returns the identifier of the change log entry.
+func (d *ChangeDTO) GetID() int64 {
+	return d.ID
+}
+
+// NewStore creates a store persisting change log entries with GORM.
+// The scope must be the one shared with the decorated stores so that changes are written
+// in the same transaction as the mutations.
+//
+// Example:
+//
+//	articles := changelog.NewStore[*model.Article, int64](
+//		gormstore.New[*model.Article, *dto.Article, int64](scope),
+//		gormchangelog.NewStore(scope),
+//		scope,
+//	)
+func NewStore(scope *gormopscope.TransactionScope) store.Store[*changelog.Change, int64] {
+	return gormstore.New[*changelog.Change, *ChangeDTO, int64](
+		scope,
+		gormstore.WithConverter[*changelog.Change, *ChangeDTO, int64](
+			converter.NewManual[*changelog.Change, *ChangeDTO, int64](toChange, toDTO),
+		),
+	)
+}
+
+// AutoMigrate creates or updates the change log table.
+func AutoMigrate(db *gorm.DB) error {
+	return db.AutoMigrate(&ChangeDTO{})
+}
+
+func toChange(dto *ChangeDTO) *changelog.Change {
+	return &changelog.Change{
+		ID:        dto.ID,
+		Entity:    dto.Entity,
+		EntityID:  dto.EntityID,
+		Operation: changelog.Operation(dto.Operation),
+		Actor:     dto.Actor,
+		Before:    json.RawMessage(dto.Before),
+		After:     json.RawMessage(dto.After),
+		ChangedAt: dto.ChangedAt,
+	}
+}
+
+func toDTO(change *changelog.Change) *ChangeDTO {
+	return &ChangeDTO{
+		ID:        change.ID,
+		Entity:    change.Entity,
+		EntityID:  change.EntityID,
+		Operation: string(change.Operation),
+		Actor:     change.Actor,
+		Before:    change.Before,
+		After:     change.After,
+		ChangedAt: change.ChangedAt,
+	}
+}
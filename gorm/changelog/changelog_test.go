@@ -0,0 +1,145 @@
+package gormchangelog_test
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/infevocorp/goflexstore/changelog"
+	gormchangelog "github.com/infevocorp/goflexstore/gorm/changelog"
+	gormopscope "github.com/infevocorp/goflexstore/gorm/opscope"
+	gormstore "github.com/infevocorp/goflexstore/gorm/store"
+	gormtest "github.com/infevocorp/goflexstore/gorm/test"
+	"github.com/infevocorp/goflexstore/query"
+)
+
+type User struct {
+	ID   int64
+	Name string
+}
+
+func (u *User) GetID() int64 {
+	return u.ID
+}
+
+type UserDTO struct {
+	ID   int64  `gorm:"column:id;primaryKey;autoIncrement"`
+	Name string `gorm:"column:name"`
+}
+
+func (d *UserDTO) GetID() int64 {
+	return d.ID
+}
+
+func Test_NewStore(t *testing.T) {
+	t.Run("should-create-change", func(t *testing.T) {
+		// GIVEN
+		var (
+			db, sqlMock = gormtest.NewDB(t)
+			s           = gormchangelog.NewStore(gormopscope.NewTransactionScope("test", db, &sql.TxOptions{}))
+			now         = time.Now()
+		)
+
+		sqlMock.ExpectExec(regexp.QuoteMeta(
+			"INSERT INTO `changes` (`entity`,`entity_id`,`operation`,`actor`,`before`,`after`,`changed_at`) "+
+				"VALUES (?,?,?,?,?,?,?)",
+		)).
+			WithArgs("User", "1", "create", "admin", []byte(nil), []byte(`{}`), now).
+			WillReturnResult(sqlmock.NewResult(7, 1))
+
+		// WHEN
+		id, err := s.Create(context.Background(), &changelog.Change{
+			Entity:    "User",
+			EntityID:  "1",
+			Operation: changelog.OperationCreate,
+			Actor:     "admin",
+			After:     []byte(`{}`),
+			ChangedAt: now,
+		})
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, int64(7), id)
+	})
+
+	t.Run("should-list-history", func(t *testing.T) {
+		// GIVEN
+		var (
+			db, sqlMock = gormtest.NewDB(t)
+			s           = gormchangelog.NewStore(gormopscope.NewTransactionScope("test", db, &sql.TxOptions{}))
+		)
+
+		sqlMock.ExpectQuery(regexp.QuoteMeta(
+			"SELECT * FROM `changes` WHERE entity = ? AND entity_id = ? ORDER BY `changed_at`",
+		)).
+			WithArgs("User", "1").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "entity", "entity_id", "operation", "after"}).
+				AddRow(1, "User", "1", "create", []byte(`{}`)))
+
+		// WHEN
+		changes, err := s.List(context.Background(),
+			query.Filter("Entity", "User"),
+			query.Filter("EntityID", "1"),
+			query.OrderBy("ChangedAt", false),
+		)
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, []*changelog.Change{
+			{
+				ID:        1,
+				Entity:    "User",
+				EntityID:  "1",
+				Operation: changelog.OperationCreate,
+				Before:    nil,
+				After:     []byte(`{}`),
+			},
+		}, changes)
+	})
+
+	t.Run("should-record-ids-generated-by-database", func(t *testing.T) {
+		// GIVEN
+		db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+		require.NoError(t, err)
+
+		sqlDB, err := db.DB()
+		require.NoError(t, err)
+
+		// Every connection to ":memory:" opens a distinct database.
+		sqlDB.SetMaxOpenConns(1)
+
+		require.NoError(t, gormchangelog.AutoMigrate(db))
+		require.NoError(t, db.AutoMigrate(&UserDTO{}))
+
+		var (
+			ctx   = context.Background()
+			scope = gormopscope.NewWriteTransactionScope("test", db)
+			users = changelog.NewStore[*User, int64](
+				gormstore.New[*User, *UserDTO, int64](scope),
+				gormchangelog.NewStore(scope),
+				scope,
+			)
+		)
+
+		// WHEN
+		err = users.CreateMany(ctx, []*User{{Name: "john"}, {Name: "jenny"}})
+
+		// THEN
+		require.NoError(t, err)
+
+		history, err := users.History(ctx, 2)
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+		assert.Equal(t, "2", history[0].EntityID)
+		assert.Equal(t, changelog.OperationCreate, history[0].Operation)
+		assert.JSONEq(t, `{"ID":2,"Name":"jenny"}`, string(history[0].After))
+	})
+}
@@ -0,0 +1,7 @@
+// Package gormchangelog provides the GORM persistence of the change log defined in
+// github.com/infevocorp/goflexstore/changelog.
+//
+// It maps changelog.Change to a `changes` table and exposes a ready-to-use store sharing the
+// transaction scope of the decorated stores, so changes are written within the same transaction
+// as the mutations they describe.
+package gormchangelog
@@ -10,11 +10,12 @@ retract [v1.0.1, v1.0.6]
 
 require (
 	github.com/DATA-DOG/go-sqlmock v1.5.2
-	github.com/infevocorp/goflexstore v1.0.10
+	github.com/infevocorp/goflexstore v1.1.0
 	github.com/pkg/errors v0.9.1
 	github.com/stretchr/testify v1.8.4
 	gorm.io/driver/mysql v1.5.2
-	gorm.io/gorm v1.25.5
+	gorm.io/driver/sqlite v1.5.4
+	gorm.io/gorm v1.25.6
 )
 
 require (
@@ -22,6 +23,9 @@ require (
 	github.com/go-sql-driver/mysql v1.7.0 // indirect
 	github.com/jinzhu/inflection v1.0.0 // indirect
 	github.com/jinzhu/now v1.1.5 // indirect
+	github.com/mattn/go-sqlite3 v1.14.17 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
+
+replace github.com/infevocorp/goflexstore => ../
@@ -0,0 +1,12 @@
+// Package gormhealth provides health checks of GORM databases, to be added to a health.Checker.
+//
+// ReplicaLag checks that a PostgreSQL or MySQL replica, e.g. the one configured with dbresolver, does not lag
+// too far behind its primary, so that a service reading from it can be taken out of rotation.
+//
+// Example:
+//
+//	checker := health.NewChecker(
+//		health.WithStores(stores),
+//		health.WithCheck("replica-lag", gormhealth.ReplicaLag(replicaDB, 10*time.Second)),
+//	)
+package gormhealth
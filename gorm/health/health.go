@@ -0,0 +1,93 @@
+package gormhealth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/infevocorp/goflexstore/health"
+)
+
+var (
+	// ErrReplicationLag is returned by ReplicaLag when the replica lags behind more than the maximum lag.
+	ErrReplicationLag = errors.New("replication lag exceeds the maximum")
+	// ErrReplicationStopped is returned by ReplicaLag when the database does not replicate from a primary.
+	ErrReplicationStopped = errors.New("replication is not running")
+	// ErrUnsupportedDialect is returned by ReplicaLag for databases other than PostgreSQL and MySQL.
+	ErrUnsupportedDialect = errors.New("replica lag is not supported by the dialect")
+)
+
+// ReplicaLag returns a check failing when the replica lags behind its primary by more than maxLag.
+//
+// The replica must be a connection to the replica itself. With PostgreSQL, the lag is the time since the
+// last replayed transaction, so it grows when the primary receives no writes. With MySQL, it is the
+// Seconds_Behind_Source column of SHOW REPLICA STATUS, which requires MySQL 8.0.22 or later.
+func ReplicaLag(replica *gorm.DB, maxLag time.Duration) health.Check {
+	return func(ctx context.Context) error {
+		lag, err := replicationLag(replica.WithContext(ctx))
+		if err != nil {
+			return err
+		}
+
+		if lag > maxLag {
+			return fmt.Errorf("%w: %s > %s", ErrReplicationLag, lag, maxLag)
+		}
+
+		return nil
+	}
+}
+
+// replicationLag returns how far the replica is behind its primary.
+func replicationLag(db *gorm.DB) (time.Duration, error) {
+	switch db.Dialector.Name() {
+	case "postgres":
+		var seconds *float64
+
+		if err := db.Raw(
+			"SELECT EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp())",
+		).Scan(&seconds).Error; err != nil {
+			return 0, err
+		}
+
+		if seconds == nil {
+			return 0, ErrReplicationStopped
+		}
+
+		return time.Duration(*seconds * float64(time.Second)), nil
+	case "mysql":
+		status := map[string]any{}
+
+		if err := db.Raw("SHOW REPLICA STATUS").Scan(&status).Error; err != nil {
+			return 0, err
+		}
+
+		seconds, err := parseSeconds(status["Seconds_Behind_Source"])
+		if err != nil {
+			return 0, err
+		}
+
+		return time.Duration(seconds) * time.Second, nil
+	default:
+		return 0, fmt.Errorf("%w: %s", ErrUnsupportedDialect, db.Dialector.Name())
+	}
+}
+
+// parseSeconds parses the Seconds_Behind_Source column, which is NULL when the replication is stopped.
+func parseSeconds(value any) (int64, error) {
+	switch v := value.(type) {
+	case nil:
+		return 0, ErrReplicationStopped
+	case int64:
+		return v, nil
+	case []byte:
+		return strconv.ParseInt(string(v), 10, 64)
+	case string:
+		return strconv.ParseInt(v, 10, 64)
+	default:
+		return 0, fmt.Errorf("unexpected Seconds_Behind_Source value %v (%T)", value, value)
+	}
+}
@@ -0,0 +1,93 @@
+package gormhealth_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	gormhealth "github.com/infevocorp/goflexstore/gorm/health"
+	gormtest "github.com/infevocorp/goflexstore/gorm/test"
+)
+
+// postgresDialector reports the postgres dialect name while running as the wrapped MySQL dialector,
+// which is enough to check the statements generated for PostgreSQL with sqlmock.
+type postgresDialector struct {
+	gorm.Dialector
+}
+
+func (postgresDialector) Name() string {
+	return "postgres"
+}
+
+type sqliteDialector struct {
+	gorm.Dialector
+}
+
+func (sqliteDialector) Name() string {
+	return "sqlite"
+}
+
+const postgresLagSQL = "SELECT EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp())"
+
+func Test_ReplicaLag(t *testing.T) {
+	t.Run("should-pass-when-postgres-lag-is-below-maximum", func(t *testing.T) {
+		// GIVEN
+		db, sqlMock := gormtest.NewDB(t)
+		db.Dialector = postgresDialector{db.Dialector}
+
+		gormtest.ExpectQuery(sqlMock, postgresLagSQL).
+			WillReturnRows(gormtest.Rows([]string{"lag"}, []driver.Value{2.5}))
+
+		// WHEN
+		err := gormhealth.ReplicaLag(db, 10*time.Second)(context.Background())
+
+		// THEN
+		assert.NoError(t, err)
+	})
+
+	t.Run("should-fail-when-postgres-does-not-replicate", func(t *testing.T) {
+		// GIVEN
+		db, sqlMock := gormtest.NewDB(t)
+		db.Dialector = postgresDialector{db.Dialector}
+
+		gormtest.ExpectQuery(sqlMock, postgresLagSQL).
+			WillReturnRows(gormtest.Rows([]string{"lag"}, []driver.Value{nil}))
+
+		// WHEN
+		err := gormhealth.ReplicaLag(db, 10*time.Second)(context.Background())
+
+		// THEN
+		assert.ErrorIs(t, err, gormhealth.ErrReplicationStopped)
+	})
+
+	t.Run("should-fail-when-mysql-lag-exceeds-maximum", func(t *testing.T) {
+		// GIVEN
+		db, sqlMock := gormtest.NewDB(t)
+
+		gormtest.ExpectQuery(sqlMock, "SHOW REPLICA STATUS").
+			WillReturnRows(gormtest.Rows([]string{"Replica_IO_Running", "Seconds_Behind_Source"},
+				[]driver.Value{"Yes", int64(30)}))
+
+		// WHEN
+		err := gormhealth.ReplicaLag(db, 10*time.Second)(context.Background())
+
+		// THEN
+		assert.ErrorIs(t, err, gormhealth.ErrReplicationLag)
+	})
+
+	t.Run("should-fail-with-unsupported-dialect", func(t *testing.T) {
+		// GIVEN
+		db, _ := gormtest.NewDB(t)
+		db.Dialector = sqliteDialector{db.Dialector}
+
+		// WHEN
+		err := gormhealth.ReplicaLag(db, 10*time.Second)(context.Background())
+
+		// THEN
+		assert.ErrorIs(t, err, gormhealth.ErrUnsupportedDialect)
+	})
+}
@@ -0,0 +1,20 @@
+// Package gormmigrate migrates the tables of the stores of an application, along with their indexes.
+//
+// A Migrator runs GORM's AutoMigrate over the models of the stores registered in a registry, the ones given
+// with WithModels, and then creates the indexes declared with WithIndexes. Indexes are declared in Go rather
+// than in struct tags, so that unique, composite and partial indexes can be reviewed in one place, and Plan
+// diffs them against the live schema: missing indexes are created and indexes whose columns or uniqueness
+// changed are dropped and created again.
+//
+// Example:
+//
+//	migrator := gormmigrate.New(db,
+//		gormmigrate.WithStores(stores),
+//		gormmigrate.WithIndexes(
+//			gormmigrate.Index{Model: &dto.User{}, Name: "idx_users_email", Fields: []string{"Email"}, Unique: true},
+//			gormmigrate.Index{Model: &dto.Article{}, Name: "idx_articles_author", Fields: []string{"AuthorID", "CreatedAt"}},
+//		),
+//	)
+//
+//	err := migrator.Migrate(ctx)
+package gormmigrate
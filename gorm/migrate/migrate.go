@@ -0,0 +1,320 @@
+package gormmigrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/infevocorp/goflexstore/opscope"
+	"github.com/infevocorp/goflexstore/registry"
+)
+
+var (
+	// ErrUnknownField is returned when an index refers to a field that is not part of its model.
+	ErrUnknownField = errors.New("unknown field")
+	// ErrPartialIndexUnsupported is returned when a partial index is created on MySQL, which does not support them.
+	ErrPartialIndexUnsupported = errors.New("partial indexes are not supported by the dialect")
+)
+
+// Modeler is implemented by the stores exposing the GORM model of their table, such as gormstore.Store.
+type Modeler interface {
+	Model() any
+}
+
+// Index declares an index of the table of a model.
+//
+// Fields:
+//   - Model: The model of the table, e.g. &dto.User{}.
+//   - Name: The name of the index, used to find it in the live schema.
+//   - Fields: The fields of the model, or their column names, in the order of the index.
+//     Several fields make a composite index.
+//   - Unique: Whether the index is unique.
+//   - Where: The condition of a partial index, e.g. "deleted_at IS NULL". It is supported by PostgreSQL and
+//     SQLite, and is not compared with the live schema.
+type Index struct {
+	Model  any
+	Name   string
+	Fields []string
+	Unique bool
+	Where  string
+}
+
+// Action is the action of a Change.
+type Action string
+
+const (
+	// ActionCreate creates a missing index.
+	ActionCreate Action = "create"
+	// ActionRecreate drops an index whose definition changed and creates it again.
+	ActionRecreate Action = "recreate"
+)
+
+// Change is a difference between the declared indexes and the live schema, see Migrator.Plan.
+type Change struct {
+	Action  Action
+	Index   Index
+	Table   string
+	Columns []string
+}
+
+// String describes the change, e.g. "create unique index idx_users_email on users (email)".
+func (c Change) String() string {
+	var sb strings.Builder
+
+	sb.WriteString(string(c.Action))
+
+	if c.Index.Unique {
+		sb.WriteString(" unique")
+	}
+
+	fmt.Fprintf(&sb, " index %s on %s (%s)", c.Index.Name, c.Table, strings.Join(c.Columns, ", "))
+
+	if c.Index.Where != "" {
+		sb.WriteString(" where " + c.Index.Where)
+	}
+
+	return sb.String()
+}
+
+// Option is a function that configures the Migrator.
+type Option func(*Migrator)
+
+// WithModels adds models to migrate with AutoMigrate, e.g. the ones of tables without a registered store.
+func WithModels(models ...any) Option {
+	return func(m *Migrator) {
+		m.models = append(m.models, models...)
+	}
+}
+
+// WithStores adds the models of the stores registered in the registry when the option is applied.
+// Only the stores implementing Modeler, such as gormstore.Store and the structs embedding it, are migrated.
+func WithStores[S opscope.Scope](stores *registry.Registry[S]) Option {
+	return func(m *Migrator) {
+		for _, entry := range stores.Entries() {
+			if modeler, ok := entry.Inner.(Modeler); ok {
+				m.models = append(m.models, modeler.Model())
+			}
+		}
+	}
+}
+
+// WithIndexes declares indexes to create, see Index.
+func WithIndexes(indexes ...Index) Option {
+	return func(m *Migrator) {
+		m.indexes = append(m.indexes, indexes...)
+	}
+}
+
+// Migrator migrates tables and their indexes, see New.
+type Migrator struct {
+	db      *gorm.DB
+	models  []any
+	indexes []Index
+}
+
+// New creates a Migrator of the database, configured by the options.
+func New(db *gorm.DB, opts ...Option) *Migrator {
+	m := &Migrator{
+		db: db,
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// Migrate runs AutoMigrate over the models, each model being migrated once, and then applies the changes
+// returned by Plan.
+func (m *Migrator) Migrate(ctx context.Context) error {
+	db := m.db.WithContext(ctx)
+
+	if err := db.AutoMigrate(uniqueModels(m.models)...); err != nil {
+		return err
+	}
+
+	changes, err := m.Plan(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, change := range changes {
+		if err := apply(db, change); err != nil {
+			return fmt.Errorf("%s: %w", change, err)
+		}
+	}
+
+	return nil
+}
+
+// Plan diffs the declared indexes against the live schema and returns the changes to apply, without
+// applying them. Indexes of tables that do not exist yet are planned for creation.
+//
+// PostgreSQL and MySQL report the columns and uniqueness of the live indexes, so indexes whose definition
+// changed are planned for recreation. Other databases, such as SQLite, only report whether an index exists.
+func (m *Migrator) Plan(ctx context.Context) ([]Change, error) {
+	var (
+		db      = m.db.WithContext(ctx)
+		changes []Change
+	)
+
+	for _, index := range m.indexes {
+		table, columns, err := resolve(db, index)
+		if err != nil {
+			return nil, err
+		}
+
+		change := Change{Action: ActionCreate, Index: index, Table: table, Columns: columns}
+
+		if !db.Migrator().HasTable(index.Model) {
+			changes = append(changes, change)
+			continue
+		}
+
+		action, err := diff(db, change)
+		if err != nil {
+			return nil, fmt.Errorf("inspect index %s of %s: %w", index.Name, table, err)
+		}
+
+		if action != "" {
+			change.Action = action
+			changes = append(changes, change)
+		}
+	}
+
+	return changes, nil
+}
+
+// resolve returns the table and the columns of the index.
+func resolve(db *gorm.DB, index Index) (string, []string, error) {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(index.Model); err != nil {
+		return "", nil, err
+	}
+
+	columns := make([]string, len(index.Fields))
+
+	for i, name := range index.Fields {
+		field := stmt.Schema.LookUpField(name)
+		if field == nil || field.DBName == "" {
+			return "", nil, fmt.Errorf("%w %s of index %s", ErrUnknownField, name, index.Name)
+		}
+
+		columns[i] = field.DBName
+	}
+
+	return stmt.Schema.Table, columns, nil
+}
+
+// diff returns the action bringing the live index in line with the declared one, or an empty action
+// if they match.
+func diff(db *gorm.DB, change Change) (Action, error) {
+	if name := db.Dialector.Name(); name != "postgres" && name != "mysql" {
+		if db.Migrator().HasIndex(change.Index.Model, change.Index.Name) {
+			return "", nil
+		}
+
+		return ActionCreate, nil
+	}
+
+	live, err := db.Migrator().GetIndexes(change.Index.Model)
+	if err != nil {
+		return "", err
+	}
+
+	for _, index := range live {
+		if index.Name() != change.Index.Name {
+			continue
+		}
+
+		if unique, ok := index.Unique(); ok && unique != change.Index.Unique {
+			return ActionRecreate, nil
+		}
+
+		if !equalColumns(index.Columns(), change.Columns) {
+			return ActionRecreate, nil
+		}
+
+		return "", nil
+	}
+
+	return ActionCreate, nil
+}
+
+// apply creates the index of the change, dropping it first when it is recreated.
+// DDL statements do not accept bind parameters, so the identifiers are quoted by the dialector.
+func apply(db *gorm.DB, change Change) error {
+	if change.Index.Where != "" && db.Dialector.Name() == "mysql" {
+		return ErrPartialIndexUnsupported
+	}
+
+	if change.Action == ActionRecreate {
+		if err := db.Migrator().DropIndex(change.Index.Model, change.Index.Name); err != nil {
+			return err
+		}
+	}
+
+	columns := make([]string, len(change.Columns))
+	for i, column := range change.Columns {
+		columns[i] = db.Statement.Quote(column)
+	}
+
+	var sql strings.Builder
+
+	sql.WriteString("CREATE ")
+
+	if change.Index.Unique {
+		sql.WriteString("UNIQUE ")
+	}
+
+	fmt.Fprintf(&sql, "INDEX %s ON %s (%s)",
+		db.Statement.Quote(change.Index.Name), db.Statement.Quote(change.Table), strings.Join(columns, ", "))
+
+	if change.Index.Where != "" {
+		sql.WriteString(" WHERE " + change.Index.Where)
+	}
+
+	return db.Exec(sql.String()).Error
+}
+
+// equalColumns reports whether the live and declared columns are the same, in the same order.
+func equalColumns(live, declared []string) bool {
+	if len(live) != len(declared) {
+		return false
+	}
+
+	for i := range live {
+		if !strings.EqualFold(live[i], declared[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// uniqueModels removes the models of the same type, keeping the first one.
+func uniqueModels(models []any) []any {
+	var (
+		seen   = make(map[reflect.Type]bool, len(models))
+		unique = make([]any, 0, len(models))
+	)
+
+	for _, model := range models {
+		t := reflect.TypeOf(model)
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+
+		if !seen[t] {
+			seen[t] = true
+			unique = append(unique, model)
+		}
+	}
+
+	return unique
+}
@@ -0,0 +1,197 @@
+package gormmigrate_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	gormmigrate "github.com/infevocorp/goflexstore/gorm/migrate"
+	gormopscope "github.com/infevocorp/goflexstore/gorm/opscope"
+	gormstore "github.com/infevocorp/goflexstore/gorm/store"
+	gormtest "github.com/infevocorp/goflexstore/gorm/test"
+	"github.com/infevocorp/goflexstore/registry"
+)
+
+type User struct {
+	ID    int64
+	Email string
+	OrgID int64
+	Name  string
+}
+
+func (u *User) GetID() int64 {
+	return u.ID
+}
+
+type UserDTO struct {
+	ID        int64  `gorm:"column:id;primaryKey"`
+	Email     string `gorm:"column:email"`
+	OrgID     int64  `gorm:"column:org_id"`
+	Name      string `gorm:"column:name"`
+	DeletedAt *int64 `gorm:"column:deleted_at"`
+}
+
+func (u *UserDTO) GetID() int64 {
+	return u.ID
+}
+
+func (u *UserDTO) TableName() string {
+	return "users"
+}
+
+type Tag struct {
+	ID   int64  `gorm:"column:id;primaryKey"`
+	Slug string `gorm:"column:slug"`
+}
+
+// newSQLiteDB opens an empty in-memory SQLite database.
+func newSQLiteDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+
+	// Every connection to ":memory:" opens a distinct database.
+	sqlDB.SetMaxOpenConns(1)
+
+	return db
+}
+
+// expectCurrentDatabase expects the queries run by the MySQL migrator to find the current database.
+func expectCurrentDatabase(sqlMock sqlmock.Sqlmock) {
+	gormtest.ExpectQueryRegexp(sqlMock, `SELECT DATABASE\(\)`).
+		WillReturnRows(gormtest.Rows([]string{"name"}, []driver.Value{"test"}))
+	gormtest.ExpectQueryRegexp(sqlMock, `SCHEMATA`).
+		WillReturnRows(gormtest.Rows([]string{"SCHEMA_NAME"}, []driver.Value{"test"}))
+}
+
+var (
+	emailIndex = gormmigrate.Index{
+		Model:  &UserDTO{},
+		Name:   "idx_users_email",
+		Fields: []string{"Email"},
+		Unique: true,
+		Where:  "deleted_at IS NULL",
+	}
+	orgIndex = gormmigrate.Index{
+		Model:  &UserDTO{},
+		Name:   "idx_users_org_name",
+		Fields: []string{"OrgID", "name"},
+	}
+)
+
+func Test_Migrator_Migrate(t *testing.T) {
+	t.Run("should-migrate-registered-stores-and-indexes", func(t *testing.T) {
+		// GIVEN
+		var (
+			db     = newSQLiteDB(t)
+			scope  = gormopscope.NewWriteTransactionScope("test", db)
+			stores = registry.New(scope)
+		)
+
+		users := registry.Register[*User, int64](stores, gormstore.New[*User, *UserDTO, int64](scope))
+
+		migrator := gormmigrate.New(db,
+			gormmigrate.WithStores(stores),
+			gormmigrate.WithModels(&Tag{}, &UserDTO{}),
+			gormmigrate.WithIndexes(emailIndex, orgIndex),
+		)
+
+		// WHEN
+		err := migrator.Migrate(context.Background())
+
+		// THEN
+		require.NoError(t, err)
+		assert.True(t, db.Migrator().HasTable(&UserDTO{}))
+		assert.True(t, db.Migrator().HasTable(&Tag{}))
+		assert.True(t, db.Migrator().HasIndex(&UserDTO{}, "idx_users_email"))
+		assert.True(t, db.Migrator().HasIndex(&UserDTO{}, "idx_users_org_name"))
+
+		_, err = users.Create(context.Background(), &User{Email: "john@example.com"})
+		require.NoError(t, err)
+		_, err = users.Create(context.Background(), &User{Email: "john@example.com"})
+		assert.Error(t, err, "the unique index should reject duplicated emails")
+
+		changes, err := migrator.Plan(context.Background())
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+		assert.NoError(t, migrator.Migrate(context.Background()))
+	})
+
+	t.Run("should-refuse-partial-index-on-mysql", func(t *testing.T) {
+		// GIVEN
+		db, sqlMock := gormtest.NewDB(t)
+
+		expectCurrentDatabase(sqlMock)
+		gormtest.ExpectQueryRegexp(sqlMock, `information_schema.tables`).
+			WillReturnRows(gormtest.Rows([]string{"count"}, []driver.Value{0}))
+
+		// WHEN
+		err := gormmigrate.New(db, gormmigrate.WithIndexes(emailIndex)).Migrate(context.Background())
+
+		// THEN
+		assert.ErrorIs(t, err, gormmigrate.ErrPartialIndexUnsupported)
+	})
+}
+
+func Test_Migrator_Plan(t *testing.T) {
+	t.Run("should-plan-indexes-of-missing-tables", func(t *testing.T) {
+		// GIVEN
+		migrator := gormmigrate.New(newSQLiteDB(t), gormmigrate.WithIndexes(emailIndex, orgIndex))
+
+		// WHEN
+		changes, err := migrator.Plan(context.Background())
+
+		// THEN
+		require.NoError(t, err)
+		require.Len(t, changes, 2)
+		assert.Equal(t, gormmigrate.ActionCreate, changes[0].Action)
+		assert.Equal(t, "create unique index idx_users_email on users (email) where deleted_at IS NULL",
+			changes[0].String())
+		assert.Equal(t, "create index idx_users_org_name on users (org_id, name)", changes[1].String())
+	})
+
+	t.Run("should-plan-recreation-of-changed-mysql-index", func(t *testing.T) {
+		// GIVEN
+		db, sqlMock := gormtest.NewDB(t)
+
+		expectCurrentDatabase(sqlMock)
+		gormtest.ExpectQueryRegexp(sqlMock, `information_schema.tables`).
+			WillReturnRows(gormtest.Rows([]string{"count"}, []driver.Value{1}))
+		expectCurrentDatabase(sqlMock)
+		gormtest.ExpectQueryRegexp(sqlMock, `information_schema.STATISTICS`).
+			WillReturnRows(gormtest.Rows([]string{"TABLE_NAME", "COLUMN_NAME", "INDEX_NAME", "NON_UNIQUE"},
+				[]driver.Value{"users", "org_id", "idx_users_org_name", 1},
+			))
+
+		// WHEN
+		changes, err := gormmigrate.New(db, gormmigrate.WithIndexes(orgIndex)).Plan(context.Background())
+
+		// THEN
+		require.NoError(t, err)
+		require.Len(t, changes, 1)
+		assert.Equal(t, gormmigrate.ActionRecreate, changes[0].Action)
+	})
+
+	t.Run("should-fail-with-unknown-field", func(t *testing.T) {
+		// GIVEN
+		migrator := gormmigrate.New(newSQLiteDB(t), gormmigrate.WithIndexes(gormmigrate.Index{
+			Model:  &UserDTO{},
+			Name:   "idx_users_phone",
+			Fields: []string{"Phone"},
+		}))
+
+		// WHEN
+		_, err := migrator.Plan(context.Background())
+
+		// THEN
+		assert.ErrorIs(t, err, gormmigrate.ErrUnknownField)
+	})
+}
@@ -4,13 +4,24 @@ import (
 	"context"
 	"database/sql"
 	stderrs "errors"
+	"fmt"
+	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 	"gorm.io/gorm"
+
+	"github.com/infevocorp/goflexstore/flexlog"
+	"github.com/infevocorp/goflexstore/opscope"
 )
 
 var errBeginTx = errors.New("failed to begin transaction")
 
+// ErrTxCancelled is returned by the operations of a transaction scope whose context was cancelled.
+// The transaction is rolled back as soon as the context is done, and every subsequent operation
+// performed in the scope, including End, fails with an error wrapping ErrTxCancelled and the context error.
+var ErrTxCancelled = errors.New("transaction cancelled")
+
 type (
 	// contextKey is a string type used as a key in the context
 	contextKey string
@@ -18,11 +29,38 @@ type (
 	// scopeValue contains the transaction and the transaction level
 	// in the context
 	scopeValue struct {
-		tx    *gorm.DB
-		level int16
+		tx         *gorm.DB
+		level      int16
+		onCommit   []func(ctx context.Context)
+		onRollback []func(ctx context.Context)
+
+		// mu guards finished and cancelErr, which are set either by End
+		// or by the rollback triggered by the cancellation of the context,
+		// as well as level and the callbacks, which that rollback may read or reset.
+		mu        sync.Mutex
+		finished  bool
+		cancelErr error
+		// ctx is the context the transaction was begun with.
+		ctx context.Context
+		// stopWatch stops watching ctx for cancellation.
+		stopWatch func() bool
+		// cancelTimeout releases the timeout of ctx set by WithTimeout, if any.
+		cancelTimeout context.CancelFunc
+		// useMu serializes the operations run with the handles of the transaction.
+		useMu sync.Mutex
+
+		// name and startedAt are reported by TxInfo.
+		name      string
+		startedAt time.Time
 	}
 )
 
+var (
+	_ opscope.CommitNotifier   = (*TransactionScope)(nil)
+	_ opscope.RollbackNotifier = (*TransactionScope)(nil)
+	_ opscope.TxInspector      = (*scopeValue)(nil)
+)
+
 // NewWriteTransactionScope creates a new write transaction scope.
 // This function initializes a TransactionScope with serializable isolation level, intended for write operations.
 //
@@ -40,10 +78,10 @@ type (
 //
 // This example creates a new write transaction scope with serializable
 // isolation level using the root transaction object 'rootTx'.
-func NewWriteTransactionScope(name string, rootTx *gorm.DB) *TransactionScope {
+func NewWriteTransactionScope(name string, rootTx *gorm.DB, opts ...Option) *TransactionScope {
 	return NewTransactionScope(name, rootTx, &sql.TxOptions{
 		Isolation: sql.LevelSerializable,
-	})
+	}, opts...)
 }
 
 // NewReadTransactionScope creates a new read-only transaction scope.
@@ -64,11 +102,11 @@ func NewWriteTransactionScope(name string, rootTx *gorm.DB) *TransactionScope {
 //
 // This example creates a new read-only transaction scope with read-committed
 // isolation level using the root transaction object 'rootTx'.
-func NewReadTransactionScope(name string, rootTx *gorm.DB) *TransactionScope {
+func NewReadTransactionScope(name string, rootTx *gorm.DB, opts ...Option) *TransactionScope {
 	return NewTransactionScope(name, rootTx, &sql.TxOptions{
 		Isolation: sql.LevelReadCommitted,
 		ReadOnly:  true,
-	})
+	}, opts...)
 }
 
 // NewTransactionScope initializes a new transaction scope with specified settings.
@@ -85,6 +123,7 @@ func NewReadTransactionScope(name string, rootTx *gorm.DB) *TransactionScope {
 //     skipped and nested transactions disabled.
 //   - txOptions: The transaction options specified as *sql.TxOptions. These options
 //     define the isolation level and read-only status of the transaction.
+//   - opts: Options configuring the scope, e.g. WithSessionVar.
 //
 // Returns:
 // A pointer to the newly created TransactionScope instance.
@@ -101,8 +140,13 @@ func NewReadTransactionScope(name string, rootTx *gorm.DB) *TransactionScope {
 //
 // This example demonstrates how to create a new transaction scope named "myWriteScope"
 // with serializable isolation level using a root gorm.DB instance.
-func NewTransactionScope(name string, rootTx *gorm.DB, txOptions *sql.TxOptions) *TransactionScope {
-	return &TransactionScope{
+func NewTransactionScope(
+	name string,
+	rootTx *gorm.DB,
+	txOptions *sql.TxOptions,
+	opts ...Option,
+) *TransactionScope {
+	s := &TransactionScope{
 		Name: name,
 		RootTx: rootTx.Session(&gorm.Session{
 			NewDB:                    true,
@@ -111,6 +155,12 @@ func NewTransactionScope(name string, rootTx *gorm.DB, txOptions *sql.TxOptions)
 		}),
 		TxOptions: txOptions,
 	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
 }
 
 // TransactionScope represents a transaction context for database operations.
@@ -124,6 +174,8 @@ func NewTransactionScope(name string, rootTx *gorm.DB, txOptions *sql.TxOptions)
 //     sessions are derived.
 //   - TxOptions: Options for the transaction, including isolation level and
 //     read-only status. It's a pointer to sql.TxOptions.
+//   - SessionVars: The variables set locally to the transactions right after they begin, see WithSessionVar.
+//   - Logger: The logger of the beginning and end of the transactions, see WithLogger.
 //
 // Example:
 // Creating a new TransactionScope for a read-write transaction:
@@ -135,9 +187,11 @@ func NewTransactionScope(name string, rootTx *gorm.DB, txOptions *sql.TxOptions)
 //
 // This example sets up a new transaction scope with serializable isolation level.
 type TransactionScope struct {
-	Name      string
-	RootTx    *gorm.DB
-	TxOptions *sql.TxOptions
+	Name        string
+	RootTx      *gorm.DB
+	TxOptions   *sql.TxOptions
+	SessionVars []SessionVar
+	Logger      flexlog.Logger
 }
 
 // Begin starts a new transaction or increases the transaction level if already in a transaction.
@@ -145,6 +199,14 @@ type TransactionScope struct {
 // If the context already has an ongoing transaction, it increments the transaction
 // level instead of starting a new one.
 //
+// The transaction is rolled back as soon as ctx is done. From then on, the operations performed
+// in the scope and End return an error wrapping ErrTxCancelled.
+//
+// The returned context reports the transaction to opscope.InTransaction and opscope.TxInfo.
+//
+// The session variables of the scope are set right after the transaction begins. If one cannot be set,
+// the transaction is rolled back and Begin returns the error.
+//
 // Parameters:
 //   - ctx: The current context.Context object.
 //
@@ -162,27 +224,81 @@ func (s *TransactionScope) Begin(ctx context.Context) (context.Context, error) {
 	scopeVal := s.getScopeValue(ctx)
 
 	if scopeVal != nil {
+		scopeVal.mu.Lock()
 		scopeVal.level++
+		scopeVal.mu.Unlock()
+
 		return ctx, nil
 	}
 
-	tx := s.RootTx.WithContext(ctx).Begin(s.TxOptions)
+	return s.begin(ctx, s.TxOptions, 0)
+}
+
+// begin starts the outermost transaction with the options, rolled back once the timeout elapses if it is positive.
+func (s *TransactionScope) begin(
+	ctx context.Context,
+	txOptions *sql.TxOptions,
+	timeout time.Duration,
+) (context.Context, error) {
+	var (
+		parent        = ctx
+		cancelTimeout context.CancelFunc
+	)
+
+	if timeout > 0 {
+		ctx, cancelTimeout = context.WithTimeout(ctx, timeout)
+	}
+
+	start := time.Now()
+
+	tx := s.RootTx.WithContext(ctx).Begin(txOptions)
 	if tx.Error != nil {
-		return ctx, stderrs.Join(errBeginTx, tx.Error)
+		s.logTx(ctx, txBegin, start, tx.Error)
+
+		if cancelTimeout != nil {
+			cancelTimeout()
+		}
+
+		return parent, stderrs.Join(errBeginTx, tx.Error)
+	}
+
+	if err := s.setSessionVars(ctx, tx); err != nil {
+		err = stderrs.Join(errBeginTx, err, tx.Rollback().Error)
+		s.logTx(ctx, txBegin, start, err)
+
+		if cancelTimeout != nil {
+			cancelTimeout()
+		}
+
+		return parent, err
 	}
 
-	scopeVal = &scopeValue{
-		tx:    tx,
-		level: 1,
+	scopeVal := &scopeValue{
+		tx:            tx,
+		level:         1,
+		ctx:           ctx,
+		cancelTimeout: cancelTimeout,
+		name:          s.Name,
+		startedAt:     start,
 	}
 
-	return s.setScopeValue(ctx, scopeVal), nil
+	// roll back as soon as the context is done instead of leaving
+	// the transaction to the connection pool cleanup.
+	scopeVal.stopWatch = context.AfterFunc(ctx, scopeVal.cancel)
+
+	s.logTx(ctx, txBegin, start, nil)
+
+	return opscope.WithTxInspector(s.setScopeValue(ctx, scopeVal), scopeVal), nil
 }
 
 // End finalizes the transaction scope.
 // This method ends the transaction scope by committing or rolling back the
 // transaction. It decrements the transaction level if nested transactions exist.
-// If an error is passed, it triggers a rollback.
+// If an error is passed, it triggers a rollback. If the transaction was rolled back because its
+// context was cancelled, an error wrapping ErrTxCancelled is returned.
+//
+// Once the outermost transaction is finished, the callbacks registered by OnCommit are called if it was
+// committed, and those registered by OnRollback otherwise.
 //
 // Parameters:
 //   - ctx: The current context.Context object.
@@ -208,26 +324,106 @@ func (s *TransactionScope) End(ctx context.Context, err error) error {
 		return nil
 	}
 
-	if scopeVal.level > 1 {
-		scopeVal.level--
+	if scopeVal.nested() {
 		return nil
 	}
 
+	operation, err := scopeVal.end(err)
+	s.logTx(ctx, operation, scopeVal.startedAt, err)
+
+	if scopeVal.cancelTimeout != nil {
+		scopeVal.cancelTimeout()
+	}
+
+	// run the callbacks outside of the finished transaction so that
+	// any store operation they perform does not reuse the committed tx.
+	callbackCtx := s.setScopeValue(ctx, nil)
+
+	onCommit, onRollback := scopeVal.callbacks()
+
 	if err != nil {
-		if err2 := scopeVal.tx.Rollback().Error; err2 != nil {
-			return stderrs.Join(err, errors.Wrap(err2, "cannot rollback transaction"))
+		for _, fn := range onRollback {
+			fn(callbackCtx)
 		}
 
 		return err
 	}
 
-	if err := scopeVal.tx.Commit().Error; err != nil {
-		return errors.Wrap(err, "cannot commit transaction")
+	for _, fn := range onCommit {
+		fn(callbackCtx)
 	}
 
 	return nil
 }
 
+// OnCommit registers a callback to be called after the outermost transaction in the context is committed.
+//
+// Callbacks are called in registration order, only once the transaction has been committed successfully. If the
+// transaction is rolled back, the callbacks are discarded. If the context does not carry an active transaction,
+// fn is called immediately.
+//
+// Parameters:
+//   - ctx: The current context.Context object, possibly containing an ongoing transaction.
+//   - fn: The callback to be called after commit. It receives a context without the finished transaction.
+//
+// Example:
+// Publishing a message only after the data has been persisted:
+//
+//	ctx, err := txScope.Begin(ctx)
+//	if err != nil {
+//		return err
+//	}
+//	defer txScope.EndWithRecover(ctx, &err)
+//
+//	txScope.OnCommit(ctx, func(ctx context.Context) {
+//		publisher.Publish(ctx, msg)
+//	})
+func (s *TransactionScope) OnCommit(ctx context.Context, fn func(ctx context.Context)) {
+	scopeVal := s.getScopeValue(ctx)
+	if scopeVal == nil {
+		fn(ctx)
+		return
+	}
+
+	scopeVal.mu.Lock()
+	defer scopeVal.mu.Unlock()
+
+	scopeVal.onCommit = append(scopeVal.onCommit, fn)
+}
+
+// OnRollback registers a callback to be called after the outermost transaction in the context is rolled back.
+//
+// Callbacks are called in registration order by End, when the transaction is rolled back, including after the
+// cancellation of its context, or fails to commit. If the transaction is committed, the callbacks are discarded.
+// If the context does not carry an active transaction, there is nothing to roll back and fn is discarded.
+//
+// Parameters:
+//   - ctx: The current context.Context object, possibly containing an ongoing transaction.
+//   - fn: The callback to be called after rollback. It receives a context without the finished transaction.
+//
+// Example:
+// Deleting an uploaded file if the article referencing it is not persisted:
+//
+//	txScope.OnRollback(ctx, func(ctx context.Context) {
+//		_ = bucket.Delete(ctx, article.ImageKey)
+//	})
+func (s *TransactionScope) OnRollback(ctx context.Context, fn func(ctx context.Context)) {
+	scopeVal := s.getScopeValue(ctx)
+	if scopeVal == nil {
+		return
+	}
+
+	scopeVal.mu.Lock()
+	defer scopeVal.mu.Unlock()
+
+	scopeVal.onRollback = append(scopeVal.onRollback, fn)
+}
+
+// InTransaction reports whether ctx carries a transaction begun by the scope, which Tx returns.
+func (s *TransactionScope) InTransaction(ctx context.Context) bool {
+	return s.getScopeValue(ctx) != nil
+}
+
 // Tx retrieves the current transaction from the context, if available, or otherwise returns the root transaction.
 //
 // This function checks for an active transaction associated with the current context. If such a transaction exists,
@@ -251,11 +447,22 @@ func (s *TransactionScope) End(ctx context.Context, err error) error {
 // transaction is found in the context, the root transaction of the scope is used for database operations.
 func (s *TransactionScope) Tx(ctx context.Context) *gorm.DB {
 	sv := s.getScopeValue(ctx)
-	if sv != nil {
-		return sv.tx
+	if sv == nil {
+		return s.RootTx
+	}
+
+	if sv.ctx.Err() != nil {
+		sv.cancel()
+	}
+
+	if cancelErr := sv.cancelled(); cancelErr != nil {
+		tx := sv.tx.Session(&gorm.Session{NewDB: true})
+		_ = tx.AddError(cancelErr)
+
+		return tx
 	}
 
-	return s.RootTx
+	return sv.tx
 }
 
 // EndWithRecover implements the OperationScope interface by ending the transaction scope
@@ -326,3 +533,107 @@ func (s *TransactionScope) setScopeValue(ctx context.Context, scopeVal *scopeVal
 func (s *TransactionScope) getCtxKey() contextKey {
 	return contextKey(s.Name)
 }
+
+// nested decrements the level of a nested transaction and reports whether the transaction was nested.
+func (v *scopeValue) nested() bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.level > 1 {
+		v.level--
+		return true
+	}
+
+	return false
+}
+
+// callbacks returns the callbacks registered by OnCommit and OnRollback.
+func (v *scopeValue) callbacks() (onCommit, onRollback []func(ctx context.Context)) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	return v.onCommit, v.onRollback
+}
+
+// end commits the transaction, or rolls it back if err is not nil, and returns the operation it performed.
+// If the transaction was rolled back because its context was cancelled, it returns the cancellation error.
+func (v *scopeValue) end(err error) (string, error) {
+	if cancelErr := v.finish(); cancelErr != nil {
+		if errors.Is(err, ErrTxCancelled) {
+			return txRollback, err
+		}
+
+		return txRollback, stderrs.Join(err, cancelErr)
+	}
+
+	if err != nil {
+		if err2 := v.tx.Rollback().Error; err2 != nil {
+			return txRollback, stderrs.Join(err, errors.Wrap(err2, "cannot rollback transaction"))
+		}
+
+		return txRollback, err
+	}
+
+	if err := v.tx.Commit().Error; err != nil {
+		return txCommit, errors.Wrap(err, "cannot commit transaction")
+	}
+
+	return txCommit, nil
+}
+
+// cancel rolls back the transaction because its context is done, unless the scope is already finished.
+func (v *scopeValue) cancel() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.finished {
+		return
+	}
+
+	v.finished = true
+	v.cancelErr = fmt.Errorf("%w: %w", ErrTxCancelled, v.ctx.Err())
+	v.onCommit = nil
+
+	// the database/sql transaction may already have been rolled back
+	// when its context was cancelled, so the error is irrelevant.
+	_ = v.tx.Rollback()
+}
+
+// TxInfo implements opscope.TxInspector. Once the transaction is finished, it reports the transaction the scope
+// was begun in, if any.
+func (v *scopeValue) TxInfo() (opscope.Info, bool) {
+	v.mu.Lock()
+	finished, level := v.finished, v.level
+	v.mu.Unlock()
+
+	if finished {
+		return opscope.TxInfo(v.ctx)
+	}
+
+	return opscope.Info{Name: v.name, Level: int(level), StartedAt: v.startedAt}, true
+}
+
+// cancelled returns the cancellation error if the transaction was rolled back because of the context.
+func (v *scopeValue) cancelled() error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	return v.cancelErr
+}
+
+// finish stops watching the context and marks the scope as finished so that the transaction can be
+// committed or rolled back by End. It returns the cancellation error if the transaction was cancelled first.
+func (v *scopeValue) finish() error {
+	if !v.stopWatch() {
+		// the context is done: make sure the transaction is rolled back
+		// even if the watching goroutine has not run yet.
+		v.cancel()
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.finished = true
+
+	return v.cancelErr
+}
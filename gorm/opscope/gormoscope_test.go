@@ -3,22 +3,27 @@ package gormopscope_test
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"regexp"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"gorm.io/driver/mysql"
-	"gorm.io/gorm"
 
+	"github.com/infevocorp/goflexstore/flexlog"
 	gormopscope "github.com/infevocorp/goflexstore/gorm/opscope"
+	gormtest "github.com/infevocorp/goflexstore/gorm/test"
+	"github.com/infevocorp/goflexstore/opscope"
 )
 
 func Test_NewWriteTransactionScope(t *testing.T) {
 	// GIVEN
 	var (
 		name  = "test"
-		db, _ = newTestDB(t)
+		db, _ = gormtest.NewDB(t)
 	)
 
 	// WHEN
@@ -35,7 +40,7 @@ func Test_NewReadTransactionScope(t *testing.T) {
 	// GIVEN
 	var (
 		name  = "test"
-		db, _ = newTestDB(t)
+		db, _ = gormtest.NewDB(t)
 	)
 
 	// WHEN
@@ -52,7 +57,7 @@ func Test_NewTransactionScope(t *testing.T) {
 	// GIVEN
 	var (
 		name      = "test"
-		db, _     = newTestDB(t)
+		db, _     = gormtest.NewDB(t)
 		txOptions = &sql.TxOptions{
 			Isolation: sql.LevelReadCommitted,
 			ReadOnly:  true,
@@ -74,7 +79,7 @@ func Test_TransactionScope_Begin(t *testing.T) {
 		// GIVEN
 		var (
 			name        = "test"
-			db, sqlMock = newTestDB(t)
+			db, sqlMock = gormtest.NewDB(t)
 			scope       = gormopscope.NewWriteTransactionScope(name, db)
 			ctx         = context.Background()
 		)
@@ -93,7 +98,7 @@ func Test_TransactionScope_Begin(t *testing.T) {
 		// GIVEN
 		var (
 			name        = "test"
-			db, sqlMock = newTestDB(t)
+			db, sqlMock = gormtest.NewDB(t)
 			scope       = gormopscope.NewWriteTransactionScope(name, db)
 			ctx         = context.Background()
 		)
@@ -112,7 +117,7 @@ func Test_TransactionScope_Begin(t *testing.T) {
 		// GIVEN
 		var (
 			name        = "test"
-			db, sqlMock = newTestDB(t)
+			db, sqlMock = gormtest.NewDB(t)
 			scope       = gormopscope.NewWriteTransactionScope(name, db)
 			ctx         = context.Background()
 		)
@@ -137,7 +142,7 @@ func Test_TransactionScope_End(t *testing.T) {
 		// GIVEN
 		var (
 			name        = "test"
-			db, sqlMock = newTestDB(t)
+			db, sqlMock = gormtest.NewDB(t)
 			scope       = gormopscope.NewWriteTransactionScope(name, db)
 			ctx         = context.Background()
 		)
@@ -157,7 +162,7 @@ func Test_TransactionScope_End(t *testing.T) {
 		// GIVEN
 		var (
 			name  = "test"
-			db, _ = newTestDB(t)
+			db, _ = gormtest.NewDB(t)
 			scope = gormopscope.NewWriteTransactionScope(name, db)
 			ctx   = context.Background()
 		)
@@ -173,7 +178,7 @@ func Test_TransactionScope_End(t *testing.T) {
 		// GIVEN
 		var (
 			name        = "test"
-			db, sqlMock = newTestDB(t)
+			db, sqlMock = gormtest.NewDB(t)
 			scope       = gormopscope.NewWriteTransactionScope(name, db)
 			ctx         = context.Background()
 		)
@@ -197,7 +202,7 @@ func Test_TransactionScope_End(t *testing.T) {
 		// GIVEN
 		var (
 			name        = "test"
-			db, sqlMock = newTestDB(t)
+			db, sqlMock = gormtest.NewDB(t)
 			scope       = gormopscope.NewWriteTransactionScope(name, db)
 			ctx         = context.Background()
 		)
@@ -219,7 +224,7 @@ func Test_TransactionScope_End(t *testing.T) {
 		// GIVEN
 		var (
 			name        = "test"
-			db, sqlMock = newTestDB(t)
+			db, sqlMock = gormtest.NewDB(t)
 			scope       = gormopscope.NewWriteTransactionScope(name, db)
 			ctx         = context.Background()
 		)
@@ -242,7 +247,7 @@ func Test_TransactionScope_End(t *testing.T) {
 		// GIVEN
 		var (
 			name        = "test"
-			db, sqlMock = newTestDB(t)
+			db, sqlMock = gormtest.NewDB(t)
 			scope       = gormopscope.NewWriteTransactionScope(name, db)
 			ctx         = context.Background()
 		)
@@ -265,7 +270,7 @@ func Test_TransactionScope_End(t *testing.T) {
 		// GIVEN
 		var (
 			name        = "test"
-			db, sqlMock = newTestDB(t)
+			db, sqlMock = gormtest.NewDB(t)
 			scope       = gormopscope.NewWriteTransactionScope(name, db)
 			ctx         = context.Background()
 		)
@@ -289,7 +294,7 @@ func Test_TransactionScope_Tx(t *testing.T) {
 		// GIVEN
 		var (
 			name  = "test"
-			db, _ = newTestDB(t)
+			db, _ = gormtest.NewDB(t)
 			scope = gormopscope.NewWriteTransactionScope(name, db)
 			ctx   = context.Background()
 		)
@@ -305,7 +310,7 @@ func Test_TransactionScope_Tx(t *testing.T) {
 		// GIVEN
 		var (
 			name        = "test"
-			db, sqlMock = newTestDB(t)
+			db, sqlMock = gormtest.NewDB(t)
 			scope       = gormopscope.NewWriteTransactionScope(name, db)
 			ctx         = context.Background()
 		)
@@ -324,12 +329,593 @@ func Test_TransactionScope_Tx(t *testing.T) {
 	})
 }
 
+func Test_TransactionScope_BeginWith(t *testing.T) {
+	t.Run("should-begin-transaction-with-timeout", func(t *testing.T) {
+		// GIVEN
+		var (
+			db, sqlMock = gormtest.NewDB(t)
+			scope       = gormopscope.NewWriteTransactionScope("test", db)
+		)
+
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectCommit()
+
+		// WHEN
+		ctx, err := scope.BeginWith(context.Background(),
+			gormopscope.WithIsolation(sql.LevelRepeatableRead),
+			gormopscope.WithReadOnly(true),
+			gormopscope.WithTimeout(time.Minute),
+		)
+
+		// THEN
+		require.NoError(t, err)
+		assert.True(t, opscope.InTransaction(ctx))
+
+		_, hasDeadline := ctx.Deadline()
+		assert.True(t, hasDeadline)
+
+		require.NoError(t, scope.End(ctx, nil))
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("should-rollback-once-timeout-elapses", func(t *testing.T) {
+		// GIVEN
+		var (
+			db, sqlMock = gormtest.NewDB(t)
+			scope       = gormopscope.NewWriteTransactionScope("test", db)
+		)
+
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectRollback()
+
+		ctx, err := scope.BeginWith(context.Background(), gormopscope.WithTimeout(time.Millisecond))
+		require.NoError(t, err)
+
+		assert.Eventually(t, func() bool {
+			return sqlMock.ExpectationsWereMet() == nil
+		}, time.Second, time.Millisecond)
+
+		// WHEN
+		err = scope.End(ctx, nil)
+
+		// THEN
+		assert.ErrorIs(t, err, gormopscope.ErrTxCancelled)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("should-ignore-options-within-transaction", func(t *testing.T) {
+		// GIVEN
+		var (
+			db, sqlMock = gormtest.NewDB(t)
+			scope       = gormopscope.NewWriteTransactionScope("test", db)
+		)
+
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectCommit()
+
+		ctx, err := scope.Begin(context.Background())
+		require.NoError(t, err)
+
+		// WHEN
+		ctx2, err := scope.BeginWith(ctx, gormopscope.WithReadOnly(true), gormopscope.WithTimeout(time.Minute))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, ctx, ctx2)
+
+		info, _ := opscope.TxInfo(ctx2)
+		assert.Equal(t, 2, info.Level)
+
+		require.NoError(t, scope.End(ctx2, nil))
+		require.NoError(t, scope.End(ctx, nil))
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+}
+
+func Test_TransactionScope_Handle(t *testing.T) {
+	t.Run("should-fail-without-transaction", func(t *testing.T) {
+		// GIVEN
+		var (
+			db, _ = gormtest.NewDB(t)
+			scope = gormopscope.NewWriteTransactionScope("test", db)
+		)
+
+		// WHEN
+		_, err := scope.Handle(context.Background())
+
+		// THEN
+		assert.ErrorIs(t, err, gormopscope.ErrNoTx)
+	})
+
+	t.Run("should-run-operations-of-goroutines-within-transaction", func(t *testing.T) {
+		// GIVEN
+		var (
+			db, sqlMock = gormtest.NewDB(t)
+			scope       = gormopscope.NewWriteTransactionScope("test", db)
+			wg          sync.WaitGroup
+		)
+
+		sqlMock.ExpectBegin()
+
+		for i := 0; i < 3; i++ {
+			sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE articles SET views = views + 1")).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+		}
+
+		sqlMock.ExpectCommit()
+
+		ctx, err := scope.Begin(context.Background())
+		require.NoError(t, err)
+
+		handle, err := scope.Handle(ctx)
+		require.NoError(t, err)
+
+		// WHEN
+		errs := make([]error, 3)
+
+		for i := range errs {
+			wg.Add(1)
+
+			go func(i int) {
+				defer wg.Done()
+
+				errs[i] = handle.Do(context.Background(), func(ctx context.Context) error {
+					if !opscope.InTransaction(ctx) {
+						return errors.New("not in transaction")
+					}
+
+					return scope.Tx(ctx).Exec("UPDATE articles SET views = views + 1").Error
+				})
+			}(i)
+		}
+
+		wg.Wait()
+
+		// THEN
+		assert.Equal(t, []error{nil, nil, nil}, errs)
+		require.NoError(t, scope.End(ctx, nil))
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("should-fail-once-transaction-finished", func(t *testing.T) {
+		// GIVEN
+		var (
+			db, sqlMock = gormtest.NewDB(t)
+			scope       = gormopscope.NewWriteTransactionScope("test", db)
+		)
+
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectCommit()
+
+		ctx, err := scope.Begin(context.Background())
+		require.NoError(t, err)
+
+		handle, err := scope.Handle(ctx)
+		require.NoError(t, err)
+		require.NoError(t, scope.End(ctx, nil))
+
+		// WHEN
+		err = handle.Do(context.Background(), func(context.Context) error {
+			t.Fatal("unexpected call")
+			return nil
+		})
+
+		// THEN
+		assert.ErrorIs(t, err, gormopscope.ErrTxFinished)
+	})
+}
+
+func Test_TransactionScope_WithoutCancel(t *testing.T) {
+	t.Run("should-commit-after-parent-cancellation", func(t *testing.T) {
+		// GIVEN
+		var (
+			db, sqlMock = gormtest.NewDB(t)
+			scope       = gormopscope.NewWriteTransactionScope("test", db)
+			ctx, cancel = context.WithCancel(context.Background())
+		)
+
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectCommit()
+
+		ctx2, err := scope.BeginWith(ctx, gormopscope.WithoutCancel())
+		require.NoError(t, err)
+
+		// WHEN
+		cancel()
+		err = scope.End(ctx2, nil)
+
+		// THEN
+		require.NoError(t, err)
+		assert.NoError(t, ctx2.Err())
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+}
+
+func Test_TransactionScope_ContextCancellation(t *testing.T) {
+	t.Run("should-rollback-when-context-is-cancelled", func(t *testing.T) {
+		// GIVEN
+		var (
+			db, sqlMock = gormtest.NewDB(t)
+			scope       = gormopscope.NewWriteTransactionScope("test", db)
+			ctx, cancel = context.WithCancel(context.Background())
+		)
+
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectRollback()
+
+		_, err := scope.Begin(ctx)
+		require.NoError(t, err)
+
+		// WHEN
+		cancel()
+
+		// THEN
+		assert.Eventually(t, func() bool {
+			return sqlMock.ExpectationsWereMet() == nil
+		}, time.Second, time.Millisecond)
+	})
+
+	t.Run("should-fail-subsequent-operations", func(t *testing.T) {
+		// GIVEN
+		var (
+			db, sqlMock = gormtest.NewDB(t)
+			scope       = gormopscope.NewWriteTransactionScope("test", db)
+			ctx, cancel = context.WithCancel(context.Background())
+		)
+
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectRollback()
+
+		ctx2, err := scope.Begin(ctx)
+		require.NoError(t, err)
+
+		cancel()
+
+		// WHEN
+		err = scope.Tx(ctx2).Exec("UPDATE users SET name = ?", "john").Error
+
+		// THEN
+		assert.ErrorIs(t, err, gormopscope.ErrTxCancelled)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("end-should-return-cancelled-error", func(t *testing.T) {
+		// GIVEN
+		var (
+			db, sqlMock = gormtest.NewDB(t)
+			scope       = gormopscope.NewWriteTransactionScope("test", db)
+			ctx, cancel = context.WithCancel(context.Background())
+			called      bool
+		)
+
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectRollback()
+
+		ctx2, err := scope.Begin(ctx)
+		require.NoError(t, err)
+
+		scope.OnCommit(ctx2, func(context.Context) {
+			called = true
+		})
+
+		cancel()
+
+		// WHEN
+		err = scope.End(ctx2, nil)
+
+		// THEN
+		assert.ErrorIs(t, err, gormopscope.ErrTxCancelled)
+		assert.EqualError(t, err, "transaction cancelled: context canceled")
+		assert.False(t, called)
+	})
+
+	t.Run("end-should-not-duplicate-cancelled-error", func(t *testing.T) {
+		// GIVEN
+		var (
+			db, sqlMock = gormtest.NewDB(t)
+			scope       = gormopscope.NewWriteTransactionScope("test", db)
+			ctx, cancel = context.WithTimeout(context.Background(), time.Millisecond)
+		)
+
+		defer cancel()
+
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectRollback()
+
+		ctx2, err := scope.Begin(ctx)
+		require.NoError(t, err)
+
+		<-ctx.Done()
+
+		opErr := scope.Tx(ctx2).Exec("UPDATE users SET name = ?", "john").Error
+
+		// WHEN
+		err = scope.End(ctx2, opErr)
+
+		// THEN
+		assert.Equal(t, opErr, err)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("should-register-hooks-while-context-is-cancelled", func(t *testing.T) {
+		// GIVEN
+		var (
+			db, sqlMock = gormtest.NewDB(t)
+			scope       = gormopscope.NewWriteTransactionScope("test", db)
+			ctx, cancel = context.WithCancel(context.Background())
+			wg          sync.WaitGroup
+			committed   bool
+			rolledBack  int
+		)
+
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectRollback()
+
+		txCtx, err := scope.Begin(ctx)
+		require.NoError(t, err)
+
+		// WHEN
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := 0; i < 100; i++ {
+				scope.OnCommit(txCtx, func(context.Context) { committed = true })
+				scope.OnRollback(txCtx, func(context.Context) { rolledBack++ })
+				opscope.TxInfo(txCtx)
+			}
+		}()
+
+		cancel()
+		wg.Wait()
+
+		err = scope.End(txCtx, nil)
+
+		// THEN
+		assert.ErrorIs(t, err, gormopscope.ErrTxCancelled)
+		assert.False(t, committed)
+		assert.Equal(t, 100, rolledBack)
+	})
+
+	t.Run("should-commit-when-context-is-not-cancelled", func(t *testing.T) {
+		// GIVEN
+		var (
+			db, sqlMock = gormtest.NewDB(t)
+			scope       = gormopscope.NewWriteTransactionScope("test", db)
+			ctx, cancel = context.WithCancel(context.Background())
+		)
+
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectCommit()
+
+		ctx2, err := scope.Begin(ctx)
+		require.NoError(t, err)
+
+		// WHEN
+		err = scope.End(ctx2, nil)
+		cancel()
+
+		// THEN
+		assert.NoError(t, err)
+	})
+}
+
+func Test_TransactionScope_OnCommit(t *testing.T) {
+	t.Run("should-call-immediately-if-not-in-transaction", func(t *testing.T) {
+		// GIVEN
+		var (
+			name   = "test"
+			db, _  = gormtest.NewDB(t)
+			scope  = gormopscope.NewWriteTransactionScope(name, db)
+			ctx    = context.Background()
+			called = 0
+		)
+
+		// WHEN
+		scope.OnCommit(ctx, func(context.Context) {
+			called++
+		})
+
+		// THEN
+		assert.Equal(t, 1, called)
+	})
+
+	t.Run("should-call-after-outermost-commit", func(t *testing.T) {
+		// GIVEN
+		var (
+			name        = "test"
+			db, sqlMock = gormtest.NewDB(t)
+			scope       = gormopscope.NewWriteTransactionScope(name, db)
+			ctx         = context.Background()
+			calls       []string
+		)
+
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectCommit()
+
+		ctx2, err := scope.Begin(ctx)
+		require.NoError(t, err)
+
+		ctx3, err := scope.Begin(ctx2)
+		require.NoError(t, err)
+
+		scope.OnCommit(ctx3, func(ctx context.Context) {
+			calls = append(calls, "first")
+
+			// the committed transaction must not leak into the callback
+			assert.Equal(t, scope.RootTx, scope.Tx(ctx))
+		})
+		scope.OnCommit(ctx2, func(context.Context) {
+			calls = append(calls, "second")
+		})
+
+		// WHEN
+		require.NoError(t, scope.End(ctx3, nil))
+		assert.Empty(t, calls)
+
+		err = scope.End(ctx2, nil)
+
+		// THEN
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"first", "second"}, calls)
+	})
+
+	t.Run("should-not-call-after-rollback", func(t *testing.T) {
+		// GIVEN
+		var (
+			name        = "test"
+			db, sqlMock = gormtest.NewDB(t)
+			scope       = gormopscope.NewWriteTransactionScope(name, db)
+			ctx         = context.Background()
+			called      = 0
+		)
+
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectRollback()
+
+		ctx2, err := scope.Begin(ctx)
+		require.NoError(t, err)
+
+		scope.OnCommit(ctx2, func(context.Context) {
+			called++
+		})
+
+		// WHEN
+		err = scope.End(ctx2, assert.AnError)
+
+		// THEN
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Equal(t, 0, called)
+	})
+}
+
+func Test_TransactionScope_OnRollback(t *testing.T) {
+	t.Run("should-discard-if-not-in-transaction", func(t *testing.T) {
+		// GIVEN
+		var (
+			name   = "test"
+			db, _  = gormtest.NewDB(t)
+			scope  = gormopscope.NewWriteTransactionScope(name, db)
+			ctx    = context.Background()
+			called = 0
+		)
+
+		// WHEN
+		scope.OnRollback(ctx, func(context.Context) {
+			called++
+		})
+
+		// THEN
+		assert.Equal(t, 0, called)
+	})
+
+	t.Run("should-call-after-outermost-rollback", func(t *testing.T) {
+		// GIVEN
+		var (
+			name        = "test"
+			db, sqlMock = gormtest.NewDB(t)
+			scope       = gormopscope.NewWriteTransactionScope(name, db)
+			ctx         = context.Background()
+			calls       []string
+		)
+
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectRollback()
+
+		ctx2, err := scope.Begin(ctx)
+		require.NoError(t, err)
+
+		ctx3, err := scope.Begin(ctx2)
+		require.NoError(t, err)
+
+		scope.OnRollback(ctx3, func(ctx context.Context) {
+			calls = append(calls, "first")
+
+			// the rolled back transaction must not leak into the callback
+			assert.Equal(t, scope.RootTx, scope.Tx(ctx))
+		})
+		scope.OnRollback(ctx2, func(context.Context) {
+			calls = append(calls, "second")
+		})
+		scope.OnCommit(ctx2, func(context.Context) {
+			calls = append(calls, "commit")
+		})
+
+		// WHEN
+		require.NoError(t, scope.End(ctx3, nil))
+		assert.Empty(t, calls)
+
+		err = scope.End(ctx2, assert.AnError)
+
+		// THEN
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Equal(t, []string{"first", "second"}, calls)
+	})
+
+	t.Run("should-call-if-commit-fails", func(t *testing.T) {
+		// GIVEN
+		var (
+			name        = "test"
+			db, sqlMock = gormtest.NewDB(t)
+			scope       = gormopscope.NewWriteTransactionScope(name, db)
+			ctx         = context.Background()
+			called      = 0
+		)
+
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectCommit().WillReturnError(assert.AnError)
+
+		ctx2, err := scope.Begin(ctx)
+		require.NoError(t, err)
+
+		scope.OnRollback(ctx2, func(context.Context) {
+			called++
+		})
+
+		// WHEN
+		err = scope.End(ctx2, nil)
+
+		// THEN
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Equal(t, 1, called)
+	})
+
+	t.Run("should-not-call-after-commit", func(t *testing.T) {
+		// GIVEN
+		var (
+			name        = "test"
+			db, sqlMock = gormtest.NewDB(t)
+			scope       = gormopscope.NewWriteTransactionScope(name, db)
+			ctx         = context.Background()
+			called      = 0
+		)
+
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectCommit()
+
+		ctx2, err := scope.Begin(ctx)
+		require.NoError(t, err)
+
+		scope.OnRollback(ctx2, func(context.Context) {
+			called++
+		})
+
+		// WHEN
+		err = scope.End(ctx2, nil)
+
+		// THEN
+		assert.NoError(t, err)
+		assert.Equal(t, 0, called)
+	})
+}
+
 func Test_TransactionScope_EndWithRecover(t *testing.T) {
 	t.Run("should-panic-if-err-pointer-is-nil", func(t *testing.T) {
 		// GIVEN
 		var (
 			name  = "test"
-			db, _ = newTestDB(t)
+			db, _ = gormtest.NewDB(t)
 			scope = gormopscope.NewWriteTransactionScope(name, db)
 			ctx   = context.Background()
 		)
@@ -344,7 +930,7 @@ func Test_TransactionScope_EndWithRecover(t *testing.T) {
 		// GIVEN
 		var (
 			name        = "test"
-			db, sqlMock = newTestDB(t)
+			db, sqlMock = gormtest.NewDB(t)
 			scope       = gormopscope.NewWriteTransactionScope(name, db)
 			ctx         = context.Background()
 		)
@@ -371,7 +957,7 @@ func Test_TransactionScope_EndWithRecover(t *testing.T) {
 		// GIVEN
 		var (
 			name        = "test"
-			db, sqlMock = newTestDB(t)
+			db, sqlMock = gormtest.NewDB(t)
 			scope       = gormopscope.NewWriteTransactionScope(name, db)
 			ctx         = context.Background()
 		)
@@ -395,21 +981,215 @@ func Test_TransactionScope_EndWithRecover(t *testing.T) {
 	})
 }
 
-func newTestDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
-	db, sqlMock, err := sqlmock.New()
-	require.NoError(t, err)
+func Test_TransactionScope_SessionVars(t *testing.T) {
+	tenantID := func(ctx context.Context) (string, bool) {
+		tenant, ok := ctx.Value(tenantKey{}).(string)
+
+		return tenant, ok
+	}
+
+	t.Run("should-set-session-vars-after-begin", func(t *testing.T) {
+		// GIVEN
+		var (
+			db, sqlMock = gormtest.NewDB(t)
+			scope       = gormopscope.NewWriteTransactionScope("test", db,
+				gormopscope.WithSessionVar("app.tenant_id", tenantID),
+				gormopscope.WithSessionVar("app.user_id", func(context.Context) (string, bool) { return "42", true }),
+			)
+			ctx = context.WithValue(context.Background(), tenantKey{}, "acme")
+		)
+
+		sqlMock.ExpectBegin()
+		gormtest.ExpectExec(sqlMock, "SELECT set_config(?, ?, true)").
+			WithArgs("app.tenant_id", "acme").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		gormtest.ExpectExec(sqlMock, "SELECT set_config(?, ?, true)").
+			WithArgs("app.user_id", "42").
+			WillReturnResult(sqlmock.NewResult(0, 0))
 
-	sqlMock.ExpectQuery("SELECT VERSION()").WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow("8.0.23"))
+		// WHEN
+		_, err := scope.Begin(ctx)
 
-	gormDB, err := gorm.Open(mysql.New(mysql.Config{
-		Conn: db,
-	}), &gorm.Config{
-		DisableAutomaticPing: true,
+		// THEN
+		require.NoError(t, err)
+		assert.Len(t, scope.SessionVars, 2)
 	})
 
-	t.Cleanup(func() {
-		require.NoError(t, sqlMock.ExpectationsWereMet())
+	t.Run("should-skip-unset-session-vars", func(t *testing.T) {
+		// GIVEN
+		var (
+			db, sqlMock = gormtest.NewDB(t)
+			scope       = gormopscope.NewReadTransactionScope("test", db,
+				gormopscope.WithSessionVar("app.tenant_id", tenantID),
+			)
+		)
+
+		sqlMock.ExpectBegin()
+
+		// WHEN
+		_, err := scope.Begin(context.Background())
+
+		// THEN
+		require.NoError(t, err)
 	})
 
-	return gormDB, sqlMock
+	t.Run("should-rollback-if-session-var-cannot-be-set", func(t *testing.T) {
+		// GIVEN
+		var (
+			db, sqlMock = gormtest.NewDB(t)
+			scope       = gormopscope.NewWriteTransactionScope("test", db,
+				gormopscope.WithSessionVar("app.tenant_id", tenantID),
+			)
+			ctx = context.WithValue(context.Background(), tenantKey{}, "acme")
+		)
+
+		sqlMock.ExpectBegin()
+		gormtest.ExpectExec(sqlMock, "SELECT set_config(?, ?, true)").WillReturnError(sql.ErrConnDone)
+		sqlMock.ExpectRollback()
+
+		// WHEN
+		ctx2, err := scope.Begin(ctx)
+		endErr := scope.End(ctx2, err)
+
+		// THEN
+		assert.ErrorIs(t, err, sql.ErrConnDone)
+		assert.ErrorContains(t, err, "set session variable app.tenant_id")
+		assert.Equal(t, ctx, ctx2)
+		assert.NoError(t, endErr)
+	})
+}
+
+type tenantKey struct{}
+
+func Test_TransactionScope_TxInfo(t *testing.T) {
+	t.Run("should-report-no-transaction-outside-scope", func(t *testing.T) {
+		// WHEN
+		info, ok := opscope.TxInfo(context.Background())
+
+		// THEN
+		assert.False(t, ok)
+		assert.Zero(t, info)
+		assert.False(t, opscope.InTransaction(context.Background()))
+	})
+
+	t.Run("should-report-nesting-level", func(t *testing.T) {
+		// GIVEN
+		var (
+			db, sqlMock = gormtest.NewDB(t)
+			scope       = gormopscope.NewWriteTransactionScope("writeTx", db)
+			before      = time.Now()
+		)
+
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectCommit()
+
+		ctx, err := scope.Begin(context.Background())
+		require.NoError(t, err)
+
+		// WHEN
+		info, ok := opscope.TxInfo(ctx)
+
+		// THEN
+		require.True(t, ok)
+		assert.Equal(t, "writeTx", info.Name)
+		assert.Equal(t, 1, info.Level)
+		assert.False(t, info.StartedAt.Before(before))
+
+		_, err = scope.Begin(ctx)
+		require.NoError(t, err)
+
+		info, _ = opscope.TxInfo(ctx)
+		assert.Equal(t, 2, info.Level)
+
+		require.NoError(t, scope.End(ctx, nil))
+
+		info, _ = opscope.TxInfo(ctx)
+		assert.Equal(t, 1, info.Level)
+
+		require.NoError(t, scope.End(ctx, nil))
+		assert.False(t, opscope.InTransaction(ctx))
+	})
+
+	t.Run("should-report-outer-transaction-once-inner-is-finished", func(t *testing.T) {
+		// GIVEN
+		var (
+			writeDB, writeMock = gormtest.NewDB(t)
+			readDB, readMock   = gormtest.NewDB(t)
+			writeScope         = gormopscope.NewWriteTransactionScope("writeTx", writeDB)
+			readScope          = gormopscope.NewReadTransactionScope("readTx", readDB)
+		)
+
+		writeMock.ExpectBegin()
+		readMock.ExpectBegin()
+		readMock.ExpectRollback()
+
+		writeCtx, err := writeScope.Begin(context.Background())
+		require.NoError(t, err)
+
+		readCtx, err := readScope.Begin(writeCtx)
+		require.NoError(t, err)
+
+		info, _ := opscope.TxInfo(readCtx)
+		assert.Equal(t, "readTx", info.Name)
+
+		// WHEN
+		err = readScope.End(readCtx, assert.AnError)
+
+		// THEN
+		assert.ErrorIs(t, err, assert.AnError)
+
+		info, ok := opscope.TxInfo(readCtx)
+		assert.True(t, ok)
+		assert.Equal(t, "writeTx", info.Name)
+	})
+}
+
+func Test_TransactionScope_Logger(t *testing.T) {
+	t.Run("should-log-begin-and-end-of-outermost-transaction", func(t *testing.T) {
+		// GIVEN
+		var (
+			db, sqlMock = gormtest.NewDB(t)
+			operations  []any
+			errs        []any
+		)
+
+		logger := flexlog.Func(func(_ context.Context, _ flexlog.Level, msg string, args ...any) {
+			assert.Equal(t, "transaction", msg)
+
+			attrs := map[string]any{}
+			for i := 0; i+1 < len(args); i += 2 {
+				attrs[args[i].(string)] = args[i+1]
+			}
+
+			assert.Equal(t, "writeTx", attrs[flexlog.KeyTx])
+			operations = append(operations, attrs[flexlog.KeyOperation])
+			errs = append(errs, attrs[flexlog.KeyError])
+		})
+
+		scope := gormopscope.NewWriteTransactionScope("writeTx", db, gormopscope.WithLogger(logger))
+
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectCommit()
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectRollback()
+
+		// WHEN
+		ctx, err := scope.Begin(context.Background())
+		require.NoError(t, err)
+
+		_, err = scope.Begin(ctx)
+		require.NoError(t, err)
+		require.NoError(t, scope.End(ctx, nil))
+		require.NoError(t, scope.End(ctx, nil))
+
+		ctx, err = scope.Begin(context.Background())
+		require.NoError(t, err)
+
+		err = scope.End(ctx, assert.AnError)
+
+		// THEN
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Equal(t, []any{"begin", "commit", "begin", "rollback"}, operations)
+		assert.Equal(t, []any{nil, nil, nil, assert.AnError}, errs)
+	})
 }
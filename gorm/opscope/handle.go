@@ -0,0 +1,94 @@
+package gormopscope
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/infevocorp/goflexstore/opscope"
+)
+
+var (
+	// ErrNoTx is returned by Handle when the context does not carry a transaction of the scope.
+	ErrNoTx = errors.New("no transaction")
+
+	// ErrTxFinished is returned by TxHandle.Do once the transaction of the handle is committed or rolled back.
+	ErrTxFinished = errors.New("transaction finished")
+)
+
+// TxHandle shares the transaction of a context with other goroutines, see TransactionScope.Handle.
+type TxHandle struct {
+	scope    *TransactionScope
+	scopeVal *scopeValue
+}
+
+// Handle returns a handle of the transaction of ctx for the goroutines of a fan-out.
+//
+// A transaction runs on a single connection, which does not support concurrent statements: the goroutines
+// sharing a context carrying a transaction would use it unsafely. Instead, the goroutines run their operations
+// with TxHandle.Do, which runs them one at a time within the transaction, given the context of the goroutine
+// rather than the one of the transaction. While they run, the goroutine owning the transaction must also
+// use the handle, or wait for them, and End must only be called once they are done.
+//
+// It returns ErrNoTx if ctx does not carry a transaction of the scope.
+//
+// Example:
+//
+//	handle, err := txScope.Handle(ctx)
+//	if err != nil {
+//		return err
+//	}
+//
+//	g, gctx := errgroup.WithContext(ctx)
+//	for _, article := range articles {
+//		g.Go(func() error {
+//			return handle.Do(gctx, func(ctx context.Context) error {
+//				return articleStore.Update(ctx, article)
+//			})
+//		})
+//	}
+//
+//	err = g.Wait()
+func (s *TransactionScope) Handle(ctx context.Context) (*TxHandle, error) {
+	scopeVal := s.getScopeValue(ctx)
+	if scopeVal == nil {
+		return nil, errors.Wrapf(ErrNoTx, "scope %s", s.Name)
+	}
+
+	return &TxHandle{scope: s, scopeVal: scopeVal}, nil
+}
+
+// Do runs fn within the transaction of the handle, once the operations run by the other goroutines with the
+// handles of the transaction are done. The context given to fn carries the values, the cancellation and the
+// deadline of ctx, and the transaction.
+//
+// It returns an error wrapping ErrTxFinished without running fn once the transaction is committed or rolled
+// back, or ErrTxCancelled if it was rolled back because its context is done.
+func (h *TxHandle) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	v := h.scopeVal
+
+	v.useMu.Lock()
+	defer v.useMu.Unlock()
+
+	if err := v.active(); err != nil {
+		return err
+	}
+
+	return fn(opscope.WithTxInspector(h.scope.setScopeValue(ctx, v), v))
+}
+
+// active returns the error of the operations performed in the transaction once it is finished.
+func (v *scopeValue) active() error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.cancelErr != nil {
+		return v.cancelErr
+	}
+
+	if v.finished {
+		return errors.Wrapf(ErrTxFinished, "transaction %s", v.name)
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package gormopscope
+
+import (
+	"context"
+	"time"
+
+	"github.com/infevocorp/goflexstore/flexlog"
+)
+
+// Operations of the transactions, logged as flexlog.KeyOperation.
+const (
+	txBegin    = "begin"
+	txCommit   = "commit"
+	txRollback = "rollback"
+)
+
+// WithLogger logs the beginning, commit and rollback of the outermost transactions of the scope, with the name
+// of the scope and the duration of the transaction. Nested scopes are not logged.
+func WithLogger(logger flexlog.Logger) Option {
+	return func(s *TransactionScope) {
+		s.Logger = logger
+	}
+}
+
+// logTx logs an operation of a transaction of the scope started at start.
+func (s *TransactionScope) logTx(ctx context.Context, operation string, start time.Time, err error) {
+	if s.Logger == nil {
+		return
+	}
+
+	flexlog.Log(ctx, s.Logger, "transaction", flexlog.Record{
+		Operation: operation,
+		Tx:        s.Name,
+		Start:     start,
+		Rows:      -1,
+		Err:       err,
+	})
+}
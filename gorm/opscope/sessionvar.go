@@ -0,0 +1,64 @@
+package gormopscope
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// Option is a function that configures a TransactionScope at the time of its creation.
+type Option func(*TransactionScope)
+
+// SessionVarFunc returns the value of a session variable for the context a transaction begins with,
+// and false to leave the variable unset, e.g. when the context carries no tenant.
+type SessionVarFunc func(ctx context.Context) (string, bool)
+
+// SessionVar is a variable set locally to the transactions of a scope, see WithSessionVar.
+//
+// Fields:
+//   - Name: The name of the variable, e.g. "app.tenant_id".
+//   - Value: The function returning the value of the variable.
+type SessionVar struct {
+	Name  string
+	Value SessionVarFunc
+}
+
+// WithSessionVar sets a PostgreSQL configuration parameter (GUC) locally to each transaction of the scope,
+// right after it begins, as SET LOCAL does: the value is reset when the transaction ends, so it never leaks
+// to other transactions of the pooled connection.
+//
+// It enables row-level security policies reading the variable with current_setting to apply transparently
+// to every store sharing the scope. The operations must run within a transaction of the scope for the
+// variable to be set, since the operations outside of a transaction run on any connection of the pool.
+//
+// Example:
+// Restricting the rows visible to the tenant of the request:
+//
+//	// CREATE POLICY tenant_isolation ON articles
+//	//	USING (tenant_id = current_setting('app.tenant_id', true));
+//	scope := gormopscope.NewWriteTransactionScope("write", db,
+//		gormopscope.WithSessionVar("app.tenant_id", tenancy.FromContext),
+//	)
+func WithSessionVar(name string, value SessionVarFunc) Option {
+	return func(s *TransactionScope) {
+		s.SessionVars = append(s.SessionVars, SessionVar{Name: name, Value: value})
+	}
+}
+
+// setSessionVars sets the session variables of the scope locally to the transaction.
+// SET LOCAL does not accept bind parameters, so the variables are set with set_config.
+func (s *TransactionScope) setSessionVars(ctx context.Context, tx *gorm.DB) error {
+	for _, v := range s.SessionVars {
+		value, ok := v.Value(ctx)
+		if !ok {
+			continue
+		}
+
+		if err := tx.Exec("SELECT set_config(?, ?, true)", v.Name, value).Error; err != nil {
+			return fmt.Errorf("set session variable %s: %w", v.Name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package gormopscope
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// TxOption overrides, for a single transaction begun by BeginWith, the options the scope begins its
+// transactions with.
+type TxOption func(*txConfig)
+
+// txConfig is the configuration of a transaction begun by BeginWith.
+type txConfig struct {
+	options       sql.TxOptions
+	timeout       time.Duration
+	withoutCancel bool
+}
+
+// WithIsolation sets the isolation level of the transaction, e.g. sql.LevelRepeatableRead.
+func WithIsolation(level sql.IsolationLevel) TxOption {
+	return func(c *txConfig) {
+		c.options.Isolation = level
+	}
+}
+
+// WithReadOnly sets whether the transaction is read-only.
+func WithReadOnly(readOnly bool) TxOption {
+	return func(c *txConfig) {
+		c.options.ReadOnly = readOnly
+	}
+}
+
+// WithTimeout bounds the duration of the transaction: it is rolled back once the timeout elapses, and the
+// operations performed in it, including End, then fail with an error wrapping ErrTxCancelled and
+// context.DeadlineExceeded.
+func WithTimeout(timeout time.Duration) TxOption {
+	return func(c *txConfig) {
+		c.timeout = timeout
+	}
+}
+
+// WithoutCancel detaches the transaction from the cancellation and the deadline of the context it is begun
+// with, e.g. of a request, so that it is not rolled back when the request is cancelled. The returned context
+// keeps the values of the parent context. WithTimeout still bounds its duration.
+func WithoutCancel() TxOption {
+	return func(c *txConfig) {
+		c.withoutCancel = true
+	}
+}
+
+// BeginWith starts a new transaction like Begin, with the options of the scope overridden by opts, so that an
+// operation can e.g. run in a read-only transaction of a write scope without a second TransactionScope.
+//
+// The options only apply to the outermost transaction: within a transaction of the scope, BeginWith increases
+// its level like Begin and opts are ignored.
+//
+// Example:
+// Reading a consistent report in a read-only transaction bounded to 5 seconds:
+//
+//	ctx, err = writeScope.BeginWith(ctx,
+//		gormopscope.WithIsolation(sql.LevelRepeatableRead),
+//		gormopscope.WithReadOnly(true),
+//		gormopscope.WithTimeout(5*time.Second),
+//	)
+//	if err != nil {
+//		return err
+//	}
+//	defer writeScope.EndWithRecover(ctx, &err)
+func (s *TransactionScope) BeginWith(ctx context.Context, opts ...TxOption) (context.Context, error) {
+	if len(opts) == 0 || s.getScopeValue(ctx) != nil {
+		return s.Begin(ctx)
+	}
+
+	var c txConfig
+
+	if s.TxOptions != nil {
+		c.options = *s.TxOptions
+	}
+
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	if c.withoutCancel {
+		ctx = context.WithoutCancel(ctx)
+	}
+
+	return s.begin(ctx, &c.options, c.timeout)
+}
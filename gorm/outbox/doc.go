@@ -0,0 +1,7 @@
+// Package gormoutbox provides the GORM persistence of the outbox defined in
+// github.com/infevocorp/goflexstore/outbox.
+//
+// It maps outbox.Message to an `outbox_messages` table and exposes a ready-to-use store sharing the
+// transaction scope of the stores of the changes, so messages are enqueued within the same transaction
+// as the changes they announce.
+package gormoutbox
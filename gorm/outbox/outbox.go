@@ -0,0 +1,98 @@
+package gormoutbox
+
+import (
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/infevocorp/goflexstore/converter"
+	gormopscope "github.com/infevocorp/goflexstore/gorm/opscope"
+	gormstore "github.com/infevocorp/goflexstore/gorm/store"
+	"github.com/infevocorp/goflexstore/outbox"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// MessageDTO is the database model of an outbox message.
+type MessageDTO struct {
+	ID        int64     `gorm:"column:id;primaryKey;autoIncrement"`
+	Topic     string    `gorm:"column:topic;size:191"`
+	Key       []byte    `gorm:"column:message_key"`
+	Payload   []byte    `gorm:"column:payload"`
+	Headers   []byte    `gorm:"column:headers"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+	DueAt     time.Time `gorm:"column:due_at;index"`
+	Attempts  int       `gorm:"column:attempts"`
+	LastError string    `gorm:"column:last_error"`
+}
+
+// TableName returns the name of the outbox table.
+func (MessageDTO) TableName() string {
+	return "outbox_messages"
+}
+
+// GetID returns the identifier of the message.
+func (d *MessageDTO) GetID() int64 {
+	return d.ID
+}
+
+// NewStore creates a store persisting outbox messages with GORM.
+// The scope must be the one shared with the stores of the changes so that messages are enqueued
+// in the same transaction as the changes.
+//
+// Example:
+//
+//	messages := outbox.NewStore(gormoutbox.NewStore(scope), scope)
+func NewStore(scope *gormopscope.TransactionScope) store.Store[*outbox.Message, int64] {
+	return gormstore.New[*outbox.Message, *MessageDTO, int64](
+		scope,
+		gormstore.WithConverter[*outbox.Message, *MessageDTO, int64](
+			converter.NewManual[*outbox.Message, *MessageDTO, int64](toMessage, toDTO),
+		),
+	)
+}
+
+// AutoMigrate creates or updates the outbox table.
+func AutoMigrate(db *gorm.DB) error {
+	return db.AutoMigrate(&MessageDTO{})
+}
+
+func toMessage(dto *MessageDTO) *outbox.Message {
+	message := &outbox.Message{
+		ID:        dto.ID,
+		Topic:     dto.Topic,
+		Key:       dto.Key,
+		Payload:   dto.Payload,
+		CreatedAt: dto.CreatedAt,
+		DueAt:     dto.DueAt,
+		Attempts:  dto.Attempts,
+		LastError: dto.LastError,
+	}
+
+	if len(dto.Headers) > 0 {
+		// the headers are written by toDTO, a malformed value is left out.
+		_ = json.Unmarshal(dto.Headers, &message.Headers)
+	}
+
+	return message
+}
+
+func toDTO(message *outbox.Message) *MessageDTO {
+	dto := &MessageDTO{
+		ID:        message.ID,
+		Topic:     message.Topic,
+		Key:       message.Key,
+		Payload:   message.Payload,
+		CreatedAt: message.CreatedAt,
+		DueAt:     message.DueAt,
+		Attempts:  message.Attempts,
+		LastError: message.LastError,
+	}
+
+	if len(message.Headers) > 0 {
+		// a map of strings is always encoded.
+		dto.Headers, _ = json.Marshal(message.Headers)
+	}
+
+	return dto
+}
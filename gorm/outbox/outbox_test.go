@@ -0,0 +1,89 @@
+package gormoutbox_test
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gormopscope "github.com/infevocorp/goflexstore/gorm/opscope"
+	gormoutbox "github.com/infevocorp/goflexstore/gorm/outbox"
+	gormtest "github.com/infevocorp/goflexstore/gorm/test"
+	"github.com/infevocorp/goflexstore/outbox"
+	"github.com/infevocorp/goflexstore/query"
+)
+
+func Test_NewStore(t *testing.T) {
+	t.Run("should-create-message", func(t *testing.T) {
+		// GIVEN
+		var (
+			db, sqlMock = gormtest.NewDB(t)
+			s           = gormoutbox.NewStore(gormopscope.NewTransactionScope("test", db, &sql.TxOptions{}))
+			now         = time.Now()
+		)
+
+		sqlMock.ExpectExec(regexp.QuoteMeta(
+			"INSERT INTO `outbox_messages` "+
+				"(`topic`,`message_key`,`payload`,`headers`,`created_at`,`due_at`,`attempts`,`last_error`) "+
+				"VALUES (?,?,?,?,?,?,?,?)",
+		)).
+			WithArgs("orders", []byte("1"), []byte(`{}`), []byte(`{"type":"created"}`), now, now, 0, "").
+			WillReturnResult(sqlmock.NewResult(7, 1))
+
+		// WHEN
+		id, err := s.Create(context.Background(), &outbox.Message{
+			Topic:     "orders",
+			Key:       []byte("1"),
+			Payload:   []byte(`{}`),
+			Headers:   map[string]string{"type": "created"},
+			CreatedAt: now,
+			DueAt:     now,
+		})
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, int64(7), id)
+	})
+
+	t.Run("should-list-and-lock-due-messages", func(t *testing.T) {
+		// GIVEN
+		var (
+			db, sqlMock = gormtest.NewDB(t)
+			s           = gormoutbox.NewStore(gormopscope.NewTransactionScope("test", db, &sql.TxOptions{}))
+			now         = time.Now()
+		)
+
+		sqlMock.ExpectQuery(regexp.QuoteMeta(
+			"SELECT * FROM `outbox_messages` WHERE due_at <= ? AND attempts < ? ORDER BY `id` LIMIT 100 FOR UPDATE",
+		)).
+			WithArgs(now, 10).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "topic", "payload", "headers", "attempts"}).
+				AddRow(1, "orders", []byte(`{}`), []byte(`{"type":"created"}`), 1))
+
+		// WHEN
+		messages, err := s.List(context.Background(),
+			query.Filter("DueAt", now).WithOP(query.LTE),
+			query.Filter("Attempts", 10).WithOP(query.LT),
+			query.OrderBy("ID", false),
+			query.Paginate(0, 100),
+			query.WithLock(query.LockTypeForUpdate),
+		)
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, []*outbox.Message{
+			{
+				ID:       1,
+				Topic:    "orders",
+				Payload:  []byte(`{}`),
+				Headers:  map[string]string{"type": "created"},
+				Attempts: 1,
+			},
+		}, messages)
+	})
+}
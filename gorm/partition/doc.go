@@ -0,0 +1,17 @@
+// Package gormpartition provides helpers to work with declaratively partitioned tables of PostgreSQL and MySQL.
+//
+// CreateRangePartitions and CreateListPartitions create the partitions of a table partitioned by range or
+// by list, typically from the migrations, and Monthly computes the range partitions of a time based
+// partition key. The partitioned table itself must be created with the PARTITION BY clause beforehand,
+// since GORM's AutoMigrate does not support it.
+//
+// Range builds a filter on a half-open range of the partition key that the database can use to prune the
+// partitions, and gormstore.WithPartitionKey makes a store refuse the queries without such a filter.
+//
+// Example:
+//
+//	err := gormpartition.CreateRangePartitions(db, "events",
+//		gormpartition.Monthly("events", from, from.AddDate(1, 0, 0))...)
+//
+//	events, err := eventStore.List(ctx, gormpartition.Range("CreatedAt", from, to))
+package gormpartition
@@ -0,0 +1,157 @@
+package gormpartition
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/infevocorp/goflexstore/query"
+)
+
+// ErrUnsupportedDialect is returned when partitions are created on a database other than PostgreSQL or MySQL.
+var ErrUnsupportedDialect = errors.New("partitioning is not supported by the dialect")
+
+// RangePartition describes a partition holding the rows whose partition key is in [From, To).
+type RangePartition struct {
+	Name string
+	From any
+	To   any
+}
+
+// ListPartition describes a partition holding the rows whose partition key is one of Values.
+type ListPartition struct {
+	Name   string
+	Values []any
+}
+
+// Range returns the filters selecting the rows whose field is in [from, to).
+//
+// The filters compare the column itself, without wrapping it in a function, so that the database can
+// prune the partitions of a table partitioned by range on the field.
+//
+// Example:
+//
+//	eventStore.List(ctx, gormpartition.Range("CreatedAt", from, from.AddDate(0, 1, 0)))
+func Range(field string, from, to any) query.Params {
+	return query.NewParams(
+		query.Filter(field, from).WithOP(query.GTE),
+		query.Filter(field, to).WithOP(query.LT),
+	)
+}
+
+// Monthly returns a partition per month covering [from, to), named after the table and the month,
+// e.g. events_2024_01. The first partition starts at the beginning of the month of from.
+func Monthly(table string, from, to time.Time) []RangePartition {
+	var (
+		partitions []RangePartition
+		start      = time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, from.Location())
+	)
+
+	for start.Before(to) {
+		end := start.AddDate(0, 1, 0)
+
+		partitions = append(partitions, RangePartition{
+			Name: fmt.Sprintf("%s_%04d_%02d", table, start.Year(), start.Month()),
+			From: start,
+			To:   end,
+		})
+
+		start = end
+	}
+
+	return partitions
+}
+
+// CreateRangePartitions creates the given partitions of a table partitioned by range.
+// Existing partitions are left unchanged, so it is safe to call it on every migration.
+//
+// With PostgreSQL, the partitions are created with CREATE TABLE ... PARTITION OF.
+// With MySQL, they are added with ALTER TABLE ... ADD PARTITION using VALUES LESS THAN To, From is ignored.
+// MySQL only accepts partitions after the last existing one, so they must be given in ascending order,
+// and the table must be partitioned by RANGE COLUMNS for non integer keys such as dates.
+func CreateRangePartitions(db *gorm.DB, table string, partitions ...RangePartition) error {
+	for _, partition := range partitions {
+		var err error
+
+		switch db.Dialector.Name() {
+		case "postgres":
+			err = createPostgresPartition(db, table, partition.Name,
+				"FOR VALUES FROM (?) TO (?)", partition.From, partition.To)
+		case "mysql":
+			err = addMySQLPartition(db, table, partition.Name, "VALUES LESS THAN (?)", partition.To)
+		default:
+			return fmt.Errorf("%w: %s", ErrUnsupportedDialect, db.Dialector.Name())
+		}
+
+		if err != nil {
+			return fmt.Errorf("create partition %s of %s: %w", partition.Name, table, err)
+		}
+	}
+
+	return nil
+}
+
+// CreateListPartitions creates the given partitions of a table partitioned by list.
+// Existing partitions are left unchanged, so it is safe to call it on every migration.
+//
+// With PostgreSQL, the partitions are created with CREATE TABLE ... PARTITION OF.
+// With MySQL, they are added with ALTER TABLE ... ADD PARTITION.
+func CreateListPartitions(db *gorm.DB, table string, partitions ...ListPartition) error {
+	for _, partition := range partitions {
+		var (
+			err          error
+			placeholders = strings.TrimSuffix(strings.Repeat("?, ", len(partition.Values)), ", ")
+		)
+
+		switch db.Dialector.Name() {
+		case "postgres":
+			err = createPostgresPartition(db, table, partition.Name,
+				"FOR VALUES IN ("+placeholders+")", partition.Values...)
+		case "mysql":
+			err = addMySQLPartition(db, table, partition.Name, "VALUES IN ("+placeholders+")", partition.Values...)
+		default:
+			return fmt.Errorf("%w: %s", ErrUnsupportedDialect, db.Dialector.Name())
+		}
+
+		if err != nil {
+			return fmt.Errorf("create partition %s of %s: %w", partition.Name, table, err)
+		}
+	}
+
+	return nil
+}
+
+// createPostgresPartition creates a partition of a PostgreSQL table unless it exists.
+// DDL statements do not accept bind parameters, so the bounds are inlined by the dialector.
+func createPostgresPartition(db *gorm.DB, table, name, bounds string, values ...any) error {
+	sql := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s PARTITION OF %s %s",
+		db.Statement.Quote(name), db.Statement.Quote(table), bounds)
+
+	return db.Exec(db.Dialector.Explain(sql, values...)).Error
+}
+
+// addMySQLPartition adds a partition to a MySQL table unless it exists.
+// DDL statements do not accept bind parameters, so the bounds are inlined by the dialector.
+func addMySQLPartition(db *gorm.DB, table, name, bounds string, values ...any) error {
+	var count int64
+
+	if err := db.Raw(
+		"SELECT COUNT(*) FROM information_schema.partitions "+
+			"WHERE table_schema = DATABASE() AND table_name = ? AND partition_name = ?",
+		table, name,
+	).Scan(&count).Error; err != nil {
+		return err
+	}
+
+	if count > 0 {
+		return nil
+	}
+
+	sql := fmt.Sprintf("ALTER TABLE %s ADD PARTITION (PARTITION %s %s)",
+		db.Statement.Quote(table), db.Statement.Quote(name), bounds)
+
+	return db.Exec(db.Dialector.Explain(sql, values...)).Error
+}
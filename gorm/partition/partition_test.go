@@ -0,0 +1,181 @@
+package gormpartition_test
+
+import (
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	gormpartition "github.com/infevocorp/goflexstore/gorm/partition"
+	gormtest "github.com/infevocorp/goflexstore/gorm/test"
+	"github.com/infevocorp/goflexstore/query"
+)
+
+// postgresDialector reports the postgres dialect name while quoting as the wrapped MySQL dialector,
+// which is enough to check the statements generated for PostgreSQL with sqlmock.
+type postgresDialector struct {
+	gorm.Dialector
+}
+
+func (postgresDialector) Name() string {
+	return "postgres"
+}
+
+const existsSQL = `SELECT COUNT(*) FROM information_schema.partitions
+	WHERE table_schema = DATABASE() AND table_name = ? AND partition_name = ?`
+
+func Test_Range(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	params := gormpartition.Range("CreatedAt", from, to)
+
+	assert.Equal(t, []query.Param{
+		query.Filter("CreatedAt", from).WithOP(query.GTE),
+		query.Filter("CreatedAt", to).WithOP(query.LT),
+	}, params.Params())
+}
+
+func Test_Monthly(t *testing.T) {
+	t.Run("should-cover-range-with-monthly-partitions", func(t *testing.T) {
+		from := time.Date(2023, 12, 15, 10, 0, 0, 0, time.UTC)
+		to := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+		partitions := gormpartition.Monthly("events", from, to)
+
+		assert.Equal(t, []gormpartition.RangePartition{
+			{
+				Name: "events_2023_12",
+				From: time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC),
+				To:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+			{
+				Name: "events_2024_01",
+				From: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				To:   time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+		}, partitions)
+	})
+
+	t.Run("should-return-nil-for-empty-range", func(t *testing.T) {
+		from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		assert.Nil(t, gormpartition.Monthly("events", from, from))
+	})
+}
+
+func Test_CreateRangePartitions(t *testing.T) {
+	partitions := []gormpartition.RangePartition{
+		{Name: "events_2024_01", From: 20240101, To: 20240201},
+		{Name: "events_2024_02", From: 20240201, To: 20240301},
+	}
+
+	t.Run("should-create-postgres-partitions", func(t *testing.T) {
+		// GIVEN
+		db, mock := gormtest.NewDB(t)
+		db.Dialector = postgresDialector{db.Dialector}
+
+		gormtest.ExpectExec(mock, "CREATE TABLE IF NOT EXISTS `events_2024_01` PARTITION OF `events` "+
+			"FOR VALUES FROM (20240101) TO (20240201)").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		gormtest.ExpectExec(mock, "CREATE TABLE IF NOT EXISTS `events_2024_02` PARTITION OF `events` "+
+			"FOR VALUES FROM (20240201) TO (20240301)").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		// WHEN
+		err := gormpartition.CreateRangePartitions(db, "events", partitions...)
+
+		// THEN
+		require.NoError(t, err)
+	})
+
+	t.Run("should-add-missing-mysql-partitions", func(t *testing.T) {
+		// GIVEN
+		db, mock := gormtest.NewDB(t)
+
+		gormtest.ExpectQuery(mock, existsSQL, "events", "events_2024_01").
+			WillReturnRows(gormtest.Rows([]string{"count"}, []driver.Value{1}))
+		gormtest.ExpectQuery(mock, existsSQL, "events", "events_2024_02").
+			WillReturnRows(gormtest.Rows([]string{"count"}, []driver.Value{0}))
+		gormtest.ExpectExec(mock,
+			"ALTER TABLE `events` ADD PARTITION (PARTITION `events_2024_02` VALUES LESS THAN (20240301))").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		// WHEN
+		err := gormpartition.CreateRangePartitions(db, "events", partitions...)
+
+		// THEN
+		require.NoError(t, err)
+	})
+
+	t.Run("should-fail-on-unsupported-dialect", func(t *testing.T) {
+		// GIVEN
+		db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+		require.NoError(t, err)
+
+		// WHEN
+		err = gormpartition.CreateRangePartitions(db, "events", partitions...)
+
+		// THEN
+		assert.ErrorIs(t, err, gormpartition.ErrUnsupportedDialect)
+	})
+}
+
+func Test_CreateListPartitions(t *testing.T) {
+	partitions := []gormpartition.ListPartition{
+		{Name: "events_eu", Values: []any{"fr", "de"}},
+	}
+
+	t.Run("should-create-postgres-partitions", func(t *testing.T) {
+		// GIVEN
+		db, mock := gormtest.NewDB(t)
+		db.Dialector = postgresDialector{db.Dialector}
+
+		gormtest.ExpectExec(mock,
+			"CREATE TABLE IF NOT EXISTS `events_eu` PARTITION OF `events` FOR VALUES IN ('fr', 'de')").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		// WHEN
+		err := gormpartition.CreateListPartitions(db, "events", partitions...)
+
+		// THEN
+		require.NoError(t, err)
+	})
+
+	t.Run("should-add-mysql-partitions", func(t *testing.T) {
+		// GIVEN
+		db, mock := gormtest.NewDB(t)
+
+		gormtest.ExpectQuery(mock, existsSQL, "events", "events_eu").
+			WillReturnRows(gormtest.Rows([]string{"count"}, []driver.Value{0}))
+		gormtest.ExpectExec(mock,
+			"ALTER TABLE `events` ADD PARTITION (PARTITION `events_eu` VALUES IN ('fr', 'de'))").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		// WHEN
+		err := gormpartition.CreateListPartitions(db, "events", partitions...)
+
+		// THEN
+		require.NoError(t, err)
+	})
+
+	t.Run("should-wrap-database-error", func(t *testing.T) {
+		// GIVEN
+		db, mock := gormtest.NewDB(t)
+
+		gormtest.ExpectQuery(mock, existsSQL, "events", "events_eu").
+			WillReturnError(assert.AnError)
+
+		// WHEN
+		err := gormpartition.CreateListPartitions(db, "events", partitions...)
+
+		// THEN
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "create partition events_eu of events")
+	})
+}
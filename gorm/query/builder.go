@@ -7,15 +7,25 @@
 package gormquery
 
 import (
-	"errors"
+	"regexp"
+	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 
+	"github.com/pkg/errors"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
 	"github.com/infevocorp/goflexstore/query"
 )
 
+// collateSetting prefixes the statement settings holding the collation of a column, see ScopeBuilder.Collate.
+const collateSetting = "gormquery:collate:"
+
+// collationName matches the valid collation names, such as utf8mb4_0900_as_cs, C or en-US-x-icu.
+var collationName = regexp.MustCompile(`^[\w.@-]+$`)
+
 // NewBuilder creates a new ScopeBuilder. It accepts various options that can modify the
 // behavior of the scope builder, such as custom mappings between fields and database columns.
 // This function initializes the ScopeBuilder with default handlers for different types of query
@@ -28,14 +38,27 @@ func NewBuilder(options ...Option) *ScopeBuilder {
 	}
 
 	s.Registry = ScopeBuilderRegistry{
-		query.TypeFilter:   s.Filter,
-		query.TypeOR:       s.OR,
-		query.TypePaginate: s.Paginate,
-		query.TypeGroupBy:  s.GroupBy,
-		query.TypeSelect:   s.Select,
-		query.TypeOrderBy:  s.OrderBy,
-		query.TypePreload:  s.Preload,
-		query.TypeWithLock: s.ClauseLockUpdate,
+		query.TypeFilter:        s.Filter,
+		query.TypeOR:            s.OR,
+		query.TypeGroup:         s.Group,
+		query.TypeNot:           s.Not,
+		query.TypeRaw:           s.Raw,
+		query.TypePaginate:      s.Paginate,
+		query.TypeCursor:        s.Cursor,
+		query.TypeGroupBy:       s.GroupBy,
+		query.TypeSelect:        s.Select,
+		query.TypeOrderBy:       s.OrderBy,
+		query.TypePreload:       s.Preload,
+		query.TypePreloadAll:    s.PreloadAll,
+		query.TypeWithLock:      s.ClauseLockUpdate,
+		query.TypeSample:        s.Sample,
+		query.TypeWith:          s.With,
+		query.TypeWithinRadius:  s.WithinRadius,
+		query.TypeInBoundingBox: s.InBoundingBox,
+		query.TypeCollate:       s.Collate,
+		query.TypeFullText:      s.FullText,
+		query.TypeAsOf:          s.AsOf,
+		query.TypeUnscoped:      s.Unscoped,
 	}
 
 	for _, option := range options {
@@ -55,21 +78,116 @@ type ScopeBuilder struct {
 	Registry ScopeBuilderRegistry
 	// CustomFilters allows for the registration of custom filter functions.
 	CustomFilters map[string]ScopeBuilderFunc
+	// Pagination is the pagination policy applied to the paginate params.
+	Pagination Pagination
+	// Temporal is the temporal table configuration used by the as of params.
+	Temporal Temporal
+
+	// whereCache caches the WHERE clause strings by whereKey.
+	whereCache     sync.Map
+	whereCacheSize atomic.Int32
+	// templates caches the scopes of the static parameters of query templates by *query.Template.
+	templates sync.Map
+	// shapes caches the scopes of the static parameters of query params by shape, see ShapeCacheStats.
+	shapesMu    sync.RWMutex
+	shapes      map[string][]templateScope
+	shapeHits   atomic.Uint64
+	shapeMisses atomic.Uint64
+}
+
+// templateScope is the cached scope of a template parameter.
+type templateScope struct {
+	scope  ScopeFunc
+	static bool
 }
 
 // Build constructs a slice of GORM scopes from the provided query parameters.
 // It iterates through the query parameters and uses the registered scope builder functions
 // to create corresponding GORM scopes.
+//
+// When the params are bound from a query.Template, the scopes of the parameters that do not depend on
+// the bound values are built once per template and reused. Otherwise, they are built once per shape of
+// the params, see ShapeCacheStats.
 func (b *ScopeBuilder) Build(params query.Params) []ScopeFunc {
-	var scopes []ScopeFunc
+	if t, ok := params.Template(); ok {
+		return b.buildCached(b.templateScopes(t), params)
+	}
+
+	return b.buildCached(b.shapeScopes(params), params)
+}
+
+// arrange returns the scopes preceded by the scopes of the collate params, which must run before the filters
+// whatever their position in the params, and followed by the scopes of the full-text params, which must run
+// after the OrderBy params to order by relevance first.
+func arrange(collations, scopes, fullTexts []ScopeFunc) []ScopeFunc {
+	if len(collations) == 0 && len(fullTexts) == 0 {
+		return scopes
+	}
+
+	arranged := make([]ScopeFunc, 0, len(collations)+len(scopes)+len(fullTexts))
+	arranged = append(arranged, collations...)
+	arranged = append(arranged, scopes...)
+
+	return append(arranged, fullTexts...)
+}
+
+// buildCached constructs the scopes of params, reusing the cached scopes of their static parameters.
+func (b *ScopeBuilder) buildCached(cached []templateScope, params query.Params) []ScopeFunc {
+	var (
+		scopes     = make([]ScopeFunc, 0, params.Len())
+		collations []ScopeFunc
+		fullTexts  []ScopeFunc
+	)
+
+	for i := 0; i < params.Len(); i++ {
+		scope := cached[i].scope
+
+		if !cached[i].static {
+			if builder, ok := b.Registry[params.At(i).ParamType()]; ok {
+				scope = builder(params.At(i))
+			}
+		}
+
+		switch {
+		case scope == nil:
+		case params.At(i).ParamType() == query.TypeCollate:
+			collations = append(collations, scope)
+		case params.At(i).ParamType() == query.TypeFullText:
+			fullTexts = append(fullTexts, scope)
+		default:
+			scopes = append(scopes, scope)
+		}
+	}
+
+	return arrange(collations, scopes, fullTexts)
+}
+
+// templateScopes returns the cached scopes of the template parameters, building them on first use.
+func (b *ScopeBuilder) templateScopes(t *query.Template) []templateScope {
+	if cached, ok := b.templates.Load(t); ok {
+		return cached.([]templateScope)
+	}
+
+	params := t.Params()
+	cached := make([]templateScope, params.Len())
+
+	for i := range cached {
+		if !t.IsStatic(i) {
+			continue
+		}
+
+		cached[i].static = true
+
+		param := params.At(i)
 
-	for _, param := range params.Params() {
 		if builder, ok := b.Registry[param.ParamType()]; ok {
-			scopes = append(scopes, builder(param))
+			cached[i].scope = builder(param)
 		}
 	}
 
-	return scopes
+	actual, _ := b.templates.LoadOrStore(t, cached)
+
+	return actual.([]templateScope)
 }
 
 // Filter constructs a GORM scope for a filter query parameter.
@@ -85,8 +203,45 @@ func (b *ScopeBuilder) Filter(param query.Param) ScopeFunc {
 	col := b.getColName(p.Name)
 
 	return func(tx *gorm.DB) *gorm.DB {
-		return tx.Where(buildWhere(col, p.Operator, p.Value))
+		where, value, err := b.whereOf(tx, col, p.Operator, p.Value)
+		if err != nil {
+			return fail(tx, err)
+		}
+
+		return tx.Where(where, whereArgs(value)...)
+	}
+}
+
+// Condition builds the clause expression of a filter, with its column qualified by table when not empty,
+// e.g. clause.CurrentTable. It is meant for clauses that cannot be built from scopes, such as the WHERE
+// condition of clause.OnConflict.
+//
+// A clause.Column value is rendered as a column reference rather than a bound value, its name being mapped
+// through FieldToColMap like the filter name. It returns an error wrapping ErrInvalidParam for invalid filters.
+func (b *ScopeBuilder) Condition(filter query.FilterParam, table string) (clause.Expression, error) {
+	value := filter.Value
+
+	if ref, ok := value.(clause.Column); ok {
+		ref.Name = b.getColName(ref.Name)
+		value = ref
 	}
+
+	where, value, err := b.buildWhereAs(filter.Name, "?", "", filter.Operator, value)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := value.(noValue); ok {
+		return clause.Expr{SQL: where}, nil
+	}
+
+	return clause.Expr{
+		SQL: where,
+		Vars: []any{
+			clause.Column{Table: table, Name: b.getColName(filter.Name)},
+			value,
+		},
+	}, nil
 }
 
 // OR constructs a GORM scope for an OR query parameter.
@@ -100,10 +255,15 @@ func (b *ScopeBuilder) OR(param query.Param) ScopeFunc {
 		for i, filter := range p.Params {
 			col := b.getColName(filter.Name)
 
+			where, value, err := b.whereOf(tx, col, filter.Operator, filter.Value)
+			if err != nil {
+				return fail(tx, err)
+			}
+
 			if i == 0 {
-				db = db.Where(buildWhere(col, filter.Operator, filter.Value))
+				db = db.Where(where, whereArgs(value)...)
 			} else {
-				db = db.Or(buildWhere(col, filter.Operator, filter.Value))
+				db = db.Or(where, whereArgs(value)...)
 			}
 		}
 
@@ -111,13 +271,168 @@ func (b *ScopeBuilder) OR(param query.Param) ScopeFunc {
 	}
 }
 
+// Group constructs a GORM scope for a group query parameter.
+// It renders the conditions of the group, nested groups included, combined with AND or OR within parentheses.
+func (b *ScopeBuilder) Group(param query.Param) ScopeFunc {
+	p := param.(query.GroupParam)
+
+	return func(tx *gorm.DB) *gorm.DB {
+		db, ok, err := b.group(tx, p)
+		if err != nil {
+			return fail(tx, err)
+		}
+
+		if !ok {
+			return tx
+		}
+
+		return tx.Where(db)
+	}
+}
+
+// group returns a session whose WHERE clause holds the conditions of the group, rendered for tx.
+// It returns false if the group has no condition.
+func (b *ScopeBuilder) group(tx *gorm.DB, p query.GroupParam) (*gorm.DB, bool, error) {
+	var (
+		db    = tx.Session(&gorm.Session{NewDB: true})
+		empty = true
+	)
+
+	for _, param := range p.Params {
+		var (
+			where any
+			args  []any
+		)
+
+		switch c := param.(type) {
+		case query.FilterParam:
+			col := b.getColName(c.Name)
+
+			cond, value, err := b.whereOf(tx, col, c.Operator, c.Value)
+			if err != nil {
+				return nil, false, err
+			}
+
+			where, args = cond, whereArgs(value)
+		case query.ORParam:
+			params := make([]query.Param, len(c.Params))
+			for i := range c.Params {
+				params[i] = c.Params[i]
+			}
+
+			sub, ok, err := b.group(tx, query.GroupParam{OR: true, Params: params})
+			if err != nil {
+				return nil, false, err
+			}
+
+			if !ok {
+				continue
+			}
+
+			where = sub
+		case query.GroupParam:
+			sub, ok, err := b.group(tx, c)
+			if err != nil {
+				return nil, false, err
+			}
+
+			if !ok {
+				continue
+			}
+
+			where = sub
+		case query.RawParam:
+			where, args = c.SQL, c.Args
+		case query.NotParam:
+			cond, ok, err := b.not(tx, c)
+			if err != nil {
+				return nil, false, err
+			}
+
+			if !ok {
+				continue
+			}
+
+			where = cond
+		default:
+			return nil, false, errors.Wrapf(ErrInvalidParam, "%s param cannot be a condition of a group", param.ParamType())
+		}
+
+		if p.OR && !empty {
+			db = db.Or(where, args...)
+		} else {
+			db = db.Where(where, args...)
+		}
+
+		empty = false
+	}
+
+	return db, !empty, nil
+}
+
+// Not constructs a GORM scope for a not query parameter.
+// It renders the negated condition within `NOT (...)`.
+func (b *ScopeBuilder) Not(param query.Param) ScopeFunc {
+	p := param.(query.NotParam)
+
+	return func(tx *gorm.DB) *gorm.DB {
+		cond, ok, err := b.not(tx, p)
+		if err != nil {
+			return fail(tx, err)
+		}
+
+		if !ok {
+			return tx
+		}
+
+		return tx.Where(cond)
+	}
+}
+
+// not returns the negation of the condition of p, rendered for tx.
+// It returns false if the condition is an empty group.
+func (b *ScopeBuilder) not(tx *gorm.DB, p query.NotParam) (clause.Expression, bool, error) {
+	db, ok, err := b.group(tx, query.GroupParam{Params: []query.Param{p.Param}})
+	if err != nil || !ok {
+		return nil, false, err
+	}
+
+	where, _ := db.Statement.Clauses["WHERE"].Expression.(clause.Where)
+
+	return clause.Expr{SQL: "NOT (?)", Vars: []any{conditions(where)}}, true, nil
+}
+
+// conditions renders the expressions of a WHERE clause, without the WHERE keyword which GORM writes
+// for the clause.Where values.
+type conditions clause.Where
+
+// Build renders the expressions of the WHERE clause.
+func (c conditions) Build(builder clause.Builder) {
+	clause.Where(c).Build(builder)
+}
+
+// Raw constructs a GORM scope for a raw query parameter.
+// It passes the condition and its arguments through as a 'Where' clause.
+func (b *ScopeBuilder) Raw(param query.Param) ScopeFunc {
+	p := param.(query.RawParam)
+
+	return func(tx *gorm.DB) *gorm.DB {
+		return tx.Where(p.SQL, p.Args...)
+	}
+}
+
 // Paginate constructs a GORM scope for a paginate query parameter.
-// It applies an offset and limit to the query based on the paginate parameters.
+// It applies an offset and limit to the query based on the paginate parameters, within the limits of
+// the pagination policy.
 func (b *ScopeBuilder) Paginate(param query.Param) ScopeFunc {
 	p := param.(query.PaginateParam)
 
 	return func(tx *gorm.DB) *gorm.DB {
-		return tx.Offset(p.Offset).Limit(p.Limit)
+		if !b.Pagination.checkOffset(tx, p.Offset) {
+			return tx
+		}
+
+		return tx.Offset(p.Offset).Limit(b.Pagination.limit(p.Limit))
 	}
 }
 
@@ -143,11 +458,14 @@ func (b *ScopeBuilder) GroupBy(param query.Param) ScopeFunc {
 
 		if len(p.Having) > 0 {
 			for _, having := range p.Having {
-				tx = tx.Having(buildWhere(
-					b.getColName(having.Name),
-					having.Operator,
-					having.Value,
-				))
+				col := b.getColName(having.Name)
+
+				where, value, err := b.whereOf(tx, col, having.Operator, having.Value)
+				if err != nil {
+					return fail(tx, err)
+				}
+
+				tx = tx.Having(where, whereArgs(value)...)
 			}
 		}
 
@@ -208,6 +526,43 @@ func (b *ScopeBuilder) Preload(param query.Param) ScopeFunc {
 	}
 }
 
+// PreloadAll constructs a GORM scope for a preload all query parameter.
+// Without exclusions, it preloads clause.Associations. Otherwise, it preloads each relationship of the model
+// that is not excluded, keeping the conditions of the associations preloaded by Preload params.
+func (b *ScopeBuilder) PreloadAll(param query.Param) ScopeFunc {
+	p := param.(query.PreloadAllParam)
+
+	if len(p.Except) == 0 {
+		return func(tx *gorm.DB) *gorm.DB {
+			return tx.Preload(clause.Associations)
+		}
+	}
+
+	return func(tx *gorm.DB) *gorm.DB {
+		if !parseModel(tx) {
+			return tx
+		}
+
+		relations := tx.Statement.Schema.Relationships.Relations
+
+		for _, name := range p.Except {
+			if _, ok := relations[name]; !ok {
+				return fail(tx, errors.Wrapf(ErrInvalidParam, "unknown association %s excluded from preload", name))
+			}
+		}
+
+		for name := range relations {
+			if _, ok := tx.Statement.Preloads[name]; ok || slices.Contains(p.Except, name) {
+				continue
+			}
+
+			tx = tx.Preload(name)
+		}
+
+		return tx
+	}
+}
+
 // ClauseLockUpdate constructs a GORM scope for a locking clause query parameter.
 // It adds a locking clause to the main query.
 func (b *ScopeBuilder) ClauseLockUpdate(param query.Param) ScopeFunc {
@@ -218,10 +573,176 @@ func (b *ScopeBuilder) ClauseLockUpdate(param query.Param) ScopeFunc {
 		}
 	default:
 		return func(tx *gorm.DB) *gorm.DB {
-			_ = tx.AddError(errors.New("invalid lock clause"))
+			_ = tx.AddError(errors.Wrap(ErrInvalidParam, "invalid lock clause"))
+
+			return tx
+		}
+	}
+}
+
+// Unscoped constructs a GORM scope for an unscoped query parameter.
+// It disables the soft delete of the models having a gorm.DeletedAt field: the reads include the soft-deleted
+// rows and the deletes remove the rows.
+func (b *ScopeBuilder) Unscoped(query.Param) ScopeFunc {
+	return func(tx *gorm.DB) *gorm.DB {
+		return tx.Unscoped()
+	}
+}
+
+// Sample constructs a GORM scope for a sample query parameter.
+// On PostgreSQL, it adds a TABLESAMPLE clause after the table of the main query, using the SYSTEM or BERNOULLI
+// method. Other databases cannot sample a table, so the scope reads every row there.
+func (b *ScopeBuilder) Sample(param query.Param) ScopeFunc {
+	p := param.(query.SampleParam)
+
+	return func(tx *gorm.DB) *gorm.DB {
+		if p.Percent < 0 || p.Percent > 100 {
+			return fail(tx, errors.Wrapf(ErrInvalidParam, "sample percent %v is not between 0 and 100", p.Percent))
+		}
+
+		if tx.Dialector.Name() != "postgres" {
+			return tx
+		}
+
+		method := "SYSTEM"
+		if p.Method == query.SampleBernoulli {
+			method = "BERNOULLI"
+		}
 
+		table, ok := replaceableTable(tx)
+		if !ok {
 			return tx
 		}
+
+		return tx.Table("? TABLESAMPLE "+method+" (?)", clause.Table{Name: table}, p.Percent)
+	}
+}
+
+// With constructs a GORM scope for a with query parameter.
+// The main query reads from a derived table selecting the rows of the common table expression, aliased as
+// the table of the model so that the other scopes apply unchanged:
+//
+//	SELECT * FROM (
+//		WITH RECURSIVE subtree AS (
+//			SELECT * FROM categories WHERE id = ?
+//			UNION ALL
+//			SELECT categories.* FROM categories JOIN subtree ON categories.parent_id = subtree.id
+//		) SELECT * FROM subtree
+//	) AS categories
+func (b *ScopeBuilder) With(param query.Param) ScopeFunc {
+	var (
+		p      = param.(query.WithParam)
+		scopes = b.Build(query.NewParams(p.Params...))
+	)
+
+	return func(tx *gorm.DB) *gorm.DB {
+		table, ok := replaceableTable(tx)
+		if !ok {
+			return tx
+		}
+
+		anchor := tx.Session(&gorm.Session{NewDB: true}).Table(table).Scopes(scopes...)
+
+		// The anchor is rendered as a subquery whose errors would be lost, so it is built once beforehand.
+		if err := anchor.Session(&gorm.Session{DryRun: true}).Find(&[]map[string]any{}).Error; err != nil {
+			_ = tx.AddError(err)
+
+			return tx
+		}
+
+		var (
+			name = clause.Table{Name: p.Name}
+			cte  = clause.Expr{SQL: "WITH ? AS (?) SELECT * FROM ?", Vars: []any{name, anchor, name}}
+		)
+
+		if p.Recursive {
+			cte = clause.Expr{
+				SQL: "WITH RECURSIVE ? AS (? UNION ALL SELECT ?.* FROM ? JOIN ? ON ? = ?) SELECT * FROM ?",
+				Vars: []any{
+					name, anchor, clause.Table{Name: table}, clause.Table{Name: table}, name,
+					clause.Column{Table: table, Name: b.getColName(p.Field)},
+					clause.Column{Table: p.Name, Name: b.getColName(p.ParentField)},
+					name,
+				},
+			}
+		}
+
+		return tx.Table("(?) AS ?", cte, clause.Table{Name: table})
+	}
+}
+
+// whereOf builds the WHERE clause of a filter on col for the dialect of tx, with the collation of the column
+// set by a collate param, see buildWhere.
+func (b *ScopeBuilder) whereOf(tx *gorm.DB, col string, operator query.Operator, value any) (string, any, error) {
+	return b.buildWhereAs(col, collated(tx, col), tx.Dialector.Name(), operator, value)
+}
+
+// collated returns the column followed by the COLLATE clause of the collation set on it by a collate param,
+// or the column itself.
+func collated(tx *gorm.DB, col string) string {
+	collation, ok := tx.Get(collateSetting + col)
+	if !ok {
+		return col
+	}
+
+	return col + " COLLATE " + tx.Statement.Quote(collation)
+}
+
+// replaceableTable returns the table of the main query, for the scopes replacing it with a table expression.
+// It reports an error wrapping ErrInvalidParam and returns false if the table was already replaced by
+// another scope.
+func replaceableTable(tx *gorm.DB) (string, bool) {
+	if tx.Statement.TableExpr != nil {
+		fail(tx, errors.Wrap(ErrInvalidParam, "sample, with and as of params cannot be combined"))
+
+		return "", false
+	}
+
+	// The table is only known once the model is parsed, which GORM does after applying the scopes.
+	if tx.Statement.Table == "" && !parseModel(tx) {
+		return "", false
+	}
+
+	return tx.Statement.Table, true
+}
+
+// parseModel parses the model of the statement, or its destination when it has no model, since GORM only
+// parses it after applying the scopes. It adds the error to the statement and returns false on failure.
+func parseModel(tx *gorm.DB) bool {
+	if tx.Statement.Schema != nil {
+		return true
+	}
+
+	model := tx.Statement.Model
+	if model == nil {
+		model = tx.Statement.Dest
+	}
+
+	if err := tx.Statement.Parse(model); err != nil {
+		_ = tx.AddError(err)
+
+		return false
+	}
+
+	return true
+}
+
+// Collate constructs a GORM scope for a collate query parameter.
+// It records the collation of the field on the statement, for the filters on the field to render their column
+// followed by a COLLATE clause, e.g. `name COLLATE utf8mb4_bin = ?`. The collation name is quoted as an
+// identifier of the dialect. Build runs the collate scopes before the other ones.
+func (b *ScopeBuilder) Collate(param query.Param) ScopeFunc {
+	var (
+		p   = param.(query.CollateParam)
+		col = b.getColName(p.Name)
+	)
+
+	return func(tx *gorm.DB) *gorm.DB {
+		if !collationName.MatchString(p.Collation) {
+			return fail(tx, errors.Wrapf(ErrInvalidParam, "invalid collation %q of %s", p.Collation, p.Name))
+		}
+
+		return tx.Set(collateSetting+col, p.Collation)
 	}
 }
 
@@ -0,0 +1,109 @@
+package gormquery_test
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+
+	gormquery "github.com/infevocorp/goflexstore/gorm/query"
+	gormutils "github.com/infevocorp/goflexstore/gorm/utils"
+	"github.com/infevocorp/goflexstore/query"
+)
+
+func benchmarkParams() query.Params {
+	return query.NewParams(
+		query.Filter("Name", "john"),
+		query.Filter("Age", 20).WithOP(query.GTE),
+		query.Filter("ID", []int{1, 2, 3}),
+		query.OR(
+			query.Filter("Name", "jane"),
+			query.Filter("RefererID", 1),
+		),
+		query.Select("ID", "Name", "Age"),
+		query.OrderBy("Name", true),
+		query.Paginate(10, 20),
+	)
+}
+
+func newBenchDB(b *testing.B) *gorm.DB {
+	db, _, err := sqlmock.New()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	return gormDB
+}
+
+func Benchmark_Builder_Build(b *testing.B) {
+	var (
+		builder = gormquery.NewBuilder(gormquery.WithFieldToColMap(gormutils.FieldToColMap(User{})))
+		params  = benchmarkParams()
+	)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = builder.Build(params)
+	}
+}
+
+func Benchmark_Builder_BuildAndApply(b *testing.B) {
+	var (
+		builder = gormquery.NewBuilder(gormquery.WithFieldToColMap(gormutils.FieldToColMap(User{})))
+		params  = benchmarkParams()
+		db      = newBenchDB(b)
+	)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		var users []User
+
+		_ = db.Scopes(builder.Build(params)...).Find(&users)
+	}
+}
+
+func Benchmark_Builder_BuildTemplate(b *testing.B) {
+	var (
+		builder = gormquery.NewBuilder(gormquery.WithFieldToColMap(gormutils.FieldToColMap(User{})))
+		tmpl    = query.Compile(benchmarkParams().Params()...)
+	)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		params, _ := tmpl.Bind("john", 20, []int{1, 2, 3}, "jane", 1)
+		_ = builder.Build(params)
+	}
+}
+
+func Benchmark_Builder_Filter(b *testing.B) {
+	var (
+		builder = gormquery.NewBuilder(gormquery.WithFieldToColMap(gormutils.FieldToColMap(User{})))
+		filter  = query.Filter("Age", 20).WithOP(query.GTE)
+		db      = newBenchDB(b)
+	)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = builder.Filter(filter)(db.Session(&gorm.Session{}))
+	}
+}
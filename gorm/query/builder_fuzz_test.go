@@ -0,0 +1,183 @@
+package gormquery_test
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	gormquery "github.com/infevocorp/goflexstore/gorm/query"
+	gormutils "github.com/infevocorp/goflexstore/gorm/utils"
+	"github.com/infevocorp/goflexstore/query"
+)
+
+// fuzzFields are the fields of User the fuzzed params refer to.
+var fuzzFields = []string{"ID", "Name", "Age", "RefererID"}
+
+// paramReader decodes query params from the bytes generated by the fuzzer.
+// Reading past the end of the data yields zeros, so any input decodes to a valid param tree.
+type paramReader struct {
+	data []byte
+}
+
+func (r *paramReader) byte() byte {
+	if len(r.data) == 0 {
+		return 0
+	}
+
+	b := r.data[0]
+	r.data = r.data[1:]
+
+	return b
+}
+
+func (r *paramReader) int() int {
+	return int(int16(binary.LittleEndian.Uint16([]byte{r.byte(), r.byte()})))
+}
+
+func (r *paramReader) string() string {
+	n := int(r.byte() % 8)
+	b := make([]byte, n)
+
+	for i := range b {
+		b[i] = r.byte()
+	}
+
+	return string(b)
+}
+
+func (r *paramReader) field() string {
+	return fuzzFields[int(r.byte())%len(fuzzFields)]
+}
+
+func (r *paramReader) value() any {
+	switch r.byte() % 8 {
+	case 0:
+		return r.int()
+	case 1:
+		return r.string()
+	case 2:
+		return nil
+	case 3:
+		values := make([]int, r.byte()%5)
+		for i := range values {
+			values[i] = r.int()
+		}
+
+		return values
+	case 4:
+		values := make([]string, r.byte()%5)
+		for i := range values {
+			values[i] = r.string()
+		}
+
+		return values
+	case 5:
+		return [1]int{r.int()}
+	case 6:
+		return r.byte()%2 == 0
+	default:
+		return float64(r.int()) / 3
+	}
+}
+
+func (r *paramReader) filter() query.FilterParam {
+	return query.FilterParam{
+		Name:     r.field(),
+		Operator: query.Operator(r.byte() % 8),
+		Value:    r.value(),
+	}
+}
+
+func (r *paramReader) filters() []query.FilterParam {
+	filters := make([]query.FilterParam, r.byte()%4)
+	for i := range filters {
+		filters[i] = r.filter()
+	}
+
+	return filters
+}
+
+func (r *paramReader) fields() []string {
+	fields := make([]string, r.byte()%3)
+	for i := range fields {
+		fields[i] = r.field()
+	}
+
+	return fields
+}
+
+func (r *paramReader) param() query.Param {
+	switch r.byte() % 8 {
+	case 0, 1:
+		return r.filter()
+	case 2:
+		return query.ORParam{Params: r.filters()}
+	case 3:
+		return query.Paginate(r.int(), r.int())
+	case 4:
+		return query.OrderBy(r.field(), r.byte()%2 == 0)
+	case 5:
+		return query.Select(r.fields()...)
+	case 6:
+		return query.GroupByParam{Names: append(r.fields(), "ID"), Having: r.filters()}
+	default:
+		return query.Preload("Referer", r.filter())
+	}
+}
+
+func (r *paramReader) params() query.Params {
+	params := make([]query.Param, r.byte()%8)
+	for i := range params {
+		params[i] = r.param()
+	}
+
+	return query.NewParams(params...)
+}
+
+func Fuzz_Builder_Build(f *testing.F) {
+	gormquery.SetMode(gormquery.ModeLenient)
+	f.Cleanup(func() { gormquery.SetMode(gormquery.ModeStrict) })
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Discard,
+	})
+	require.NoError(f, err)
+
+	sqlDB, err := db.DB()
+	require.NoError(f, err)
+
+	// Every connection to ":memory:" opens a distinct database.
+	sqlDB.SetMaxOpenConns(1)
+
+	require.NoError(f, db.AutoMigrate(&User{}))
+
+	builder := gormquery.NewBuilder(gormquery.WithFieldToColMap(gormutils.FieldToColMap(User{})))
+
+	f.Add([]byte{})
+	f.Add([]byte{1, 0, 0, 0, 0, 42, 0})                 // filter ID = 42
+	f.Add([]byte{1, 0, 0, 2, 3, 3, 1, 0, 2, 0, 3, 0})   // filter ID IN (1, 2, 3)
+	f.Add([]byte{1, 0, 1, 0, 4, 0})                     // filter ID with empty slice
+	f.Add([]byte{1, 0, 2, 1, 2})                        // filter Age <> nil
+	f.Add([]byte{1, 0, 3, 6, 0, 1, 0})                  // filter RefererID with unknown operator
+	f.Add([]byte{1, 2, 2, 1, 0, 1, 1, 'a', 2, 3, 1, 5}) // OR filters
+	f.Add([]byte{2, 3, 0, 0x80, 0xff, 0x7f, 4, 1, 1})   // paginate extremes and order by
+	f.Add([]byte{3, 5, 2, 1, 2, 6, 1, 0, 1, 2, 0, 0})   // select, group by having
+	f.Add([]byte{1, 7, 1, 2, 0, 0, 1, 0})               // preload with filter
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		r := &paramReader{data: data}
+		params := r.params()
+
+		var users []User
+
+		err := db.Scopes(builder.Build(params)...).Find(&users).Error
+		if err != nil && !errors.Is(err, gormquery.ErrInvalidParam) {
+			t.Fatalf("params %+v generated invalid SQL: %v", params.Params(), err)
+		}
+	})
+}
@@ -1,16 +1,22 @@
 package gormquery_test
 
 import (
+	"context"
+	"fmt"
 	"regexp"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
 
 	gormquery "github.com/infevocorp/goflexstore/gorm/query"
+	gormtest "github.com/infevocorp/goflexstore/gorm/test"
 	gormutils "github.com/infevocorp/goflexstore/gorm/utils"
 	"github.com/infevocorp/goflexstore/query"
 )
@@ -295,36 +301,113 @@ func Test_Builder_Build(t *testing.T) {
 		},
 
 		{
-			name: "lock-for-update",
+			name: "preload-all",
 			args: args{
 				params: query.NewParams(
-					query.Select("Name", "Age"),
-					query.WithLock(query.LockTypeForUpdate),
+					query.Filter("RefererID", 0).WithOP(query.NEQ),
+					query.PreloadAll(),
 				),
 			},
 			expects: expects{
 				err: false,
 				users: []User{
 					{
-						ID:   0,
-						Name: "john",
-						Age:  20,
+						ID:        1,
+						Name:      "john",
+						Age:       20,
+						RefererID: 2,
+						Referer: &User{
+							ID:   2,
+							Name: "jenny",
+							Age:  20,
+						},
 					},
 				},
 			},
 			mock: func(d deps) {
-				d.sql.ExpectQuery(regexp.QuoteMeta("SELECT `name`,`age` FROM `users` FOR UPDATE")).
-					WillReturnRows(sqlmock.NewRows([]string{"name", "age"}).
-						AddRow("john", 20))
+				d.sql.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE referer_id <> ?")).
+					WithArgs(0).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "age", "referer_id"}).
+						AddRow(1, "john", 20, 2))
+
+				d.sql.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE `users`.`id` = ?")).
+					WithArgs(2).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "age"}).
+						AddRow(2, "jenny", 20))
 			},
 		},
 
 		{
-			name: "invalid-lock-type",
+			name: "preload-all-with-preload-filter",
+			args: args{
+				params: query.NewParams(
+					query.Filter("RefererID", 0).WithOP(query.NEQ),
+					query.Preload("Referer", query.Filter("Name", "jenny")),
+					query.PreloadAll(),
+				),
+			},
+			expects: expects{
+				err: false,
+				users: []User{
+					{
+						ID:        1,
+						Name:      "john",
+						Age:       20,
+						RefererID: 2,
+						Referer: &User{
+							ID:   2,
+							Name: "jenny",
+							Age:  20,
+						},
+					},
+				},
+			},
+			mock: func(d deps) {
+				d.sql.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE referer_id <> ?")).
+					WithArgs(0).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "age", "referer_id"}).
+						AddRow(1, "john", 20, 2))
+
+				d.sql.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE name = ? AND `users`.`id` = ?")).
+					WithArgs("jenny", 2).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "age"}).
+						AddRow(2, "jenny", 20))
+			},
+		},
+
+		{
+			name: "preload-all-excluding",
+			args: args{
+				params: query.NewParams(
+					query.Filter("RefererID", 0).WithOP(query.NEQ),
+					query.PreloadAll().Excluding("Referer"),
+				),
+			},
+			expects: expects{
+				err: false,
+				users: []User{
+					{
+						ID:        1,
+						Name:      "john",
+						Age:       20,
+						RefererID: 2,
+					},
+				},
+			},
+			mock: func(d deps) {
+				d.sql.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE referer_id <> ?")).
+					WithArgs(0).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "age", "referer_id"}).
+						AddRow(1, "john", 20, 2))
+			},
+		},
+
+		{
+			name: "lock-for-update",
 			args: args{
 				params: query.NewParams(
 					query.Select("Name", "Age"),
-					query.WithLock(query.LockType(999)),
+					query.WithLock(query.LockTypeForUpdate),
 				),
 			},
 			expects: expects{
@@ -338,16 +421,30 @@ func Test_Builder_Build(t *testing.T) {
 				},
 			},
 			mock: func(d deps) {
-				d.sql.ExpectQuery(regexp.QuoteMeta("SELECT `name`,`age` FROM `users`")).
+				d.sql.ExpectQuery(regexp.QuoteMeta("SELECT `name`,`age` FROM `users` FOR UPDATE")).
 					WillReturnRows(sqlmock.NewRows([]string{"name", "age"}).
 						AddRow("john", 20))
 			},
 		},
+
+		{
+			name: "invalid-lock-type",
+			args: args{
+				params: query.NewParams(
+					query.Select("Name", "Age"),
+					query.WithLock(query.LockType(999)),
+				),
+			},
+			expects: expects{
+				err: true,
+			},
+			mock: func(_ deps) {},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			db, sqlMock := newTestDB(t)
+			db, sqlMock := gormtest.NewDB(t)
 
 			d := deps{
 				sql: sqlMock,
@@ -427,7 +524,7 @@ func Test_ScopeBuilder_CustomFilter(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			db, sqlMock := newTestDB(t)
+			db, sqlMock := gormtest.NewDB(t)
 
 			d := deps{
 				sql: sqlMock,
@@ -449,21 +546,1220 @@ func Test_ScopeBuilder_CustomFilter(t *testing.T) {
 	}
 }
 
-func newTestDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
-	db, sqlMock, err := sqlmock.New()
-	require.NoError(t, err)
+func Test_Builder_Build_InvalidFilter(t *testing.T) {
+	tests := []struct {
+		name   string
+		params query.Params
+		err    string
+	}{
+		{
+			name:   "nil-value",
+			params: query.NewParams(query.Filter("name", nil)),
+			err:    "value of name cannot be nil: invalid query param",
+		},
+		{
+			name:   "empty-slice",
+			params: query.NewParams(query.Filter("name", []string{})),
+			err:    "value of name cannot be empty: invalid query param",
+		},
+		{
+			name:   "unsupported-in-operator",
+			params: query.NewParams(query.Filter("age", []int{1, 2}).WithOP(query.GT)),
+			err:    "GT is unsupported operator for IN clause: invalid query param",
+		},
+		{
+			name:   "unknown-operator",
+			params: query.NewParams(query.Filter("age", 1).WithOP(query.Operator(42))),
+			err:    "UNKNOWN(42) is unsupported operator: invalid query param",
+		},
+		{
+			name:   "nil-value-in-or",
+			params: query.NewParams(query.OR(query.Filter("name", "john"), query.Filter("age", nil))),
+			err:    "value of age cannot be nil: invalid query param",
+		},
+		{
+			name:   "nil-value-in-having",
+			params: query.NewParams(query.GroupBy("name").WithHaving(query.Filter("age", nil))),
+			err:    "value of age cannot be nil: invalid query param",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+"-should-panic-in-strict-mode", func(t *testing.T) {
+			// GIVEN
+			db, _ := gormtest.NewDB(t)
+			scopes := gormquery.NewBuilder().Build(tt.params)
+
+			// WHEN
+			find := func() {
+				var users []User
+				_ = db.Scopes(scopes...).Find(&users)
+			}
+
+			// THEN
+			assert.PanicsWithError(t, tt.err, find)
+		})
+
+		t.Run(tt.name+"-should-return-error-in-lenient-mode", func(t *testing.T) {
+			// GIVEN
+			gormquery.SetMode(gormquery.ModeLenient)
+			t.Cleanup(func() { gormquery.SetMode(gormquery.ModeStrict) })
 
-	sqlMock.ExpectQuery("SELECT VERSION()").WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow("8.0.23"))
+			db, _ := gormtest.NewDB(t)
+			scopes := gormquery.NewBuilder().Build(tt.params)
 
-	gormDB, err := gorm.Open(mysql.New(mysql.Config{
-		Conn: db,
-	}), &gorm.Config{
-		DisableAutomaticPing: true,
+			// WHEN
+			var users []User
+			err := db.Scopes(scopes...).Find(&users).Error
+
+			// THEN
+			require.ErrorIs(t, err, gormquery.ErrInvalidParam)
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func Test_Builder_Build_Template(t *testing.T) {
+	tmpl := query.Compile(
+		query.Filter("Name", ""),
+		query.Select("Name", "Age"),
+		query.OrderBy("Age", true),
+		query.Paginate(0, 10),
+	)
+
+	builder := gormquery.NewBuilder(
+		gormquery.WithFieldToColMap(gormutils.FieldToColMap(User{})),
+	)
+
+	for _, name := range []string{"john", "jane"} {
+		t.Run("should-bind-"+name, func(t *testing.T) {
+			// GIVEN
+			db, sqlMock := gormtest.NewDB(t)
+
+			sqlMock.
+				ExpectQuery(regexp.QuoteMeta(
+					"SELECT `name`,`age` FROM `users` WHERE name = ? ORDER BY `age` DESC LIMIT 10",
+				)).
+				WithArgs(name).
+				WillReturnRows(sqlmock.NewRows([]string{"name", "age"}).AddRow(name, 20))
+
+			params, err := tmpl.Bind(name)
+			require.NoError(t, err)
+
+			// WHEN
+			var users []User
+			err = db.Scopes(builder.Build(params)...).Find(&users).Error
+
+			// THEN
+			require.NoError(t, err)
+			assert.Equal(t, []User{{Name: name, Age: 20}}, users)
+		})
+	}
+}
+
+func Test_Builder_Build_ShapeCache(t *testing.T) {
+	t.Run("should-reuse-scopes-of-same-shape", func(t *testing.T) {
+		// GIVEN
+		builder := gormquery.NewBuilder(
+			gormquery.WithFieldToColMap(gormutils.FieldToColMap(User{})),
+		)
+
+		for i, name := range []string{"john", "jane"} {
+			db, sqlMock := gormtest.NewDB(t)
+
+			sqlMock.
+				ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(
+					"SELECT `name`,`age` FROM `users` WHERE name = ? ORDER BY `age` DESC LIMIT 10 OFFSET %d", 10*(i+1),
+				))).
+				WithArgs(name).
+				WillReturnRows(sqlmock.NewRows([]string{"name", "age"}).AddRow(name, 20))
+
+			params := query.NewParams(
+				query.Filter("Name", name),
+				query.Select("Name", "Age"),
+				query.OrderBy("Age", true),
+				query.Paginate(10*(i+1), 10),
+			)
+
+			// WHEN
+			var users []User
+			err := db.Scopes(builder.Build(params)...).Find(&users).Error
+
+			// THEN
+			require.NoError(t, err)
+			assert.Equal(t, []User{{Name: name, Age: 20}}, users)
+		}
+
+		assert.Equal(t, gormquery.ShapeCacheStats{Hits: 1, Misses: 1, Size: 1}, builder.ShapeCacheStats())
+	})
+
+	t.Run("should-miss-different-shapes", func(t *testing.T) {
+		// GIVEN
+		builder := gormquery.NewBuilder()
+
+		// WHEN
+		builder.Build(query.NewParams(query.Filter("Name", "john"), query.OrderBy("Age", true)))
+		builder.Build(query.NewParams(query.Filter("Name", "john"), query.OrderBy("Age", false)))
+		builder.Build(query.NewParams(query.Filter("Name", "john"), query.Select("Name")))
+		builder.Build(query.NewParams(query.Filter("Age", 20), query.Select("Name")))
+
+		// THEN
+		assert.Equal(t, gormquery.ShapeCacheStats{Hits: 1, Misses: 3, Size: 3}, builder.ShapeCacheStats())
+	})
+
+	t.Run("should-not-count-templates", func(t *testing.T) {
+		// GIVEN
+		builder := gormquery.NewBuilder()
+		params, err := query.Compile(query.Filter("Name", "")).Bind("john")
+		require.NoError(t, err)
+
+		// WHEN
+		builder.Build(params)
+
+		// THEN
+		assert.Equal(t, gormquery.ShapeCacheStats{}, builder.ShapeCacheStats())
+	})
+}
+
+func Test_Builder_Condition(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   query.FilterParam
+		table    string
+		wantSQL  string
+		wantVars []any
+	}{
+		{
+			name:     "value",
+			filter:   query.Filter("Age", 20).WithOP(query.GT),
+			wantSQL:  "SELECT * FROM `users` WHERE `age` > ?",
+			wantVars: []any{20},
+		},
+		{
+			name:     "values-in-current-table",
+			filter:   query.Filter("Name", []string{"john", "jane"}),
+			table:    clause.CurrentTable,
+			wantSQL:  "SELECT * FROM `users` WHERE `users`.`name` IN (?,?)",
+			wantVars: []any{"john", "jane"},
+		},
+		{
+			name:    "empty-in",
+			filter:  query.Filter("Name", []string{}).WithOP(query.IN),
+			table:   clause.CurrentTable,
+			wantSQL: "SELECT * FROM `users` WHERE 1 = 0",
+		},
+		{
+			name:    "column-reference",
+			filter:  query.Filter("Age", clause.Column{Table: "excluded", Name: "Age"}).WithOP(query.LT),
+			table:   clause.CurrentTable,
+			wantSQL: "SELECT * FROM `users` WHERE `users`.`age` < `excluded`.`age`",
+		},
+	}
+
+	for i := range tests {
+		tt := tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			db, _ := gormtest.NewDB(t)
+			builder := gormquery.NewBuilder(gormquery.WithFieldToColMap(gormutils.FieldToColMap(User{})))
+
+			// WHEN
+			expr, err := builder.Condition(tt.filter, tt.table)
+			require.NoError(t, err)
+
+			// THEN
+			gormtest.AssertSQL(t, db, &User{}, []gormquery.ScopeFunc{
+				func(tx *gorm.DB) *gorm.DB { return tx.Where(expr) },
+			}, tt.wantSQL, tt.wantVars...)
+		})
+	}
+
+	t.Run("should-return-invalid-param-error", func(t *testing.T) {
+		_, err := gormquery.NewBuilder().Condition(query.Filter("age", nil), "")
+
+		assert.EqualError(t, err, "value of age cannot be nil: invalid query param")
+	})
+}
+
+// postgresDialector reports the postgres dialect name while quoting as the wrapped MySQL dialector,
+// which is enough to check the statements generated for PostgreSQL.
+type postgresDialector struct {
+	gorm.Dialector
+}
+
+func (postgresDialector) Name() string {
+	return "postgres"
+}
+
+func Test_Builder_Like(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialect  func(gorm.Dialector) gorm.Dialector
+		params   []query.Param
+		wantSQL  string
+		wantVars []any
+	}{
+		{
+			name:     "like",
+			params:   []query.Param{query.Filter("Name", "%john%").WithOP(query.LIKE)},
+			wantSQL:  "SELECT * FROM `users` WHERE name LIKE ?",
+			wantVars: []any{"%john%"},
+		},
+		{
+			name:     "ilike-lower-fallback",
+			params:   []query.Param{query.Filter("Name", "John%").WithOP(query.ILIKE)},
+			wantSQL:  "SELECT * FROM `users` WHERE LOWER(name) LIKE LOWER(?)",
+			wantVars: []any{"John%"},
+		},
+		{
+			name:     "ilike-postgres",
+			dialect:  func(d gorm.Dialector) gorm.Dialector { return postgresDialector{d} },
+			params:   []query.Param{query.Filter("Name", "John%").WithOP(query.ILIKE)},
+			wantSQL:  "SELECT * FROM `users` WHERE name ILIKE ?",
+			wantVars: []any{"John%"},
+		},
+		{
+			name: "ilike-or",
+			params: []query.Param{query.OR(
+				query.Filter("Name", "john%").WithOP(query.ILIKE),
+				query.Filter("Name", "%doe").WithOP(query.LIKE),
+			)},
+			wantSQL:  "SELECT * FROM `users` WHERE LOWER(name) LIKE LOWER(?) OR name LIKE ?",
+			wantVars: []any{"john%", "%doe"},
+		},
+	}
+
+	for i := range tests {
+		tt := tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			db, _ := gormtest.NewDB(t)
+			if tt.dialect != nil {
+				db.Dialector = tt.dialect(db.Dialector)
+			}
+
+			builder := gormquery.NewBuilder(gormquery.WithFieldToColMap(gormutils.FieldToColMap(User{})))
+
+			// THEN
+			gormtest.AssertSQL(t, db, &User{}, builder.Build(query.NewParams(tt.params...)), tt.wantSQL, tt.wantVars...)
+		})
+	}
+
+	t.Run("should-reject-like-with-collection", func(t *testing.T) {
+		// GIVEN
+		gormquery.SetMode(gormquery.ModeLenient)
+		t.Cleanup(func() { gormquery.SetMode(gormquery.ModeStrict) })
+
+		db, _ := gormtest.NewDB(t)
+
+		// WHEN
+		var users []User
+		err := db.Scopes(gormquery.NewBuilder().Build(query.NewParams(
+			query.Filter("name", []string{"john%", "jane%"}).WithOP(query.LIKE),
+		))...).Find(&users).Error
+
+		// THEN
+		assert.EqualError(t, err, "LIKE is unsupported operator for IN clause: invalid query param")
 	})
+}
+
+func Test_Builder_In(t *testing.T) {
+	type names []string
+
+	tests := []struct {
+		name     string
+		params   []query.Param
+		wantSQL  string
+		wantVars []any
+	}{
+		{
+			name:     "in",
+			params:   []query.Param{query.Filter("ID", []int{1, 2}).WithOP(query.IN)},
+			wantSQL:  "SELECT * FROM `users` WHERE id IN (?,?)",
+			wantVars: []any{1, 2},
+		},
+		{
+			name:     "in-single-value",
+			params:   []query.Param{query.Filter("ID", []int{1}).WithOP(query.IN)},
+			wantSQL:  "SELECT * FROM `users` WHERE id = ?",
+			wantVars: []any{1},
+		},
+		{
+			name:     "not-in-custom-type",
+			params:   []query.Param{query.Filter("Name", names{"john", "jane"}).WithOP(query.NOTIN)},
+			wantSQL:  "SELECT * FROM `users` WHERE name NOT IN (?,?)",
+			wantVars: []any{"john", "jane"},
+		},
+		{
+			name:     "not-in-array",
+			params:   []query.Param{query.Filter("Age", [2]int{20, 30}).WithOP(query.NOTIN)},
+			wantSQL:  "SELECT * FROM `users` WHERE age NOT IN (?,?)",
+			wantVars: []any{20, 30},
+		},
+		{
+			name:    "in-empty",
+			params:  []query.Param{query.Filter("ID", []int{}).WithOP(query.IN)},
+			wantSQL: "SELECT * FROM `users` WHERE 1 = 0",
+		},
+		{
+			name:    "not-in-empty",
+			params:  []query.Param{query.Filter("ID", []int(nil)).WithOP(query.NOTIN)},
+			wantSQL: "SELECT * FROM `users` WHERE 1 = 1",
+		},
+		{
+			name: "in-empty-within-or",
+			params: []query.Param{
+				query.OR(query.Filter("Name", "john"), query.Filter("ID", []int{}).WithOP(query.IN)),
+				query.Filter("Age", 20),
+			},
+			wantSQL:  "SELECT * FROM `users` WHERE (name = ? OR 1 = 0) AND age = ?",
+			wantVars: []any{"john", 20},
+		},
+	}
+
+	for i := range tests {
+		tt := tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			db, _ := gormtest.NewDB(t)
+			builder := gormquery.NewBuilder(gormquery.WithFieldToColMap(gormutils.FieldToColMap(User{})))
+
+			// THEN
+			gormtest.AssertSQL(t, db, &User{}, builder.Build(query.NewParams(tt.params...)), tt.wantSQL, tt.wantVars...)
+		})
+	}
+
+	t.Run("should-reject-single-value", func(t *testing.T) {
+		// GIVEN
+		gormquery.SetMode(gormquery.ModeLenient)
+		t.Cleanup(func() { gormquery.SetMode(gormquery.ModeStrict) })
 
-	t.Cleanup(func() {
-		require.NoError(t, sqlMock.ExpectationsWereMet())
+		db, _ := gormtest.NewDB(t)
+
+		// WHEN
+		var users []User
+		err := db.Scopes(gormquery.NewBuilder().Build(query.NewParams(
+			query.Filter("id", 1).WithOP(query.IN),
+		))...).Find(&users).Error
+
+		// THEN
+		assert.EqualError(t, err, "value of id must be a collection with IN: invalid query param")
 	})
+}
 
-	return gormDB, sqlMock
+func Test_Builder_Group(t *testing.T) {
+	tests := []struct {
+		name     string
+		params   []query.Param
+		wantSQL  string
+		wantVars []any
+	}{
+		{
+			name: "or-of-ands",
+			params: []query.Param{query.OR(
+				query.AND(query.Filter("Name", "john"), query.Filter("Age", 20)),
+				query.AND(query.Filter("Name", "jane"), query.Filter("Age", 30).WithOP(query.GT)),
+			)},
+			wantSQL:  "SELECT * FROM `users` WHERE (name = ? AND age = ?) OR (name = ? AND age > ?)",
+			wantVars: []any{"john", 20, "jane", 30},
+		},
+		{
+			name: "nested-groups-and-filter",
+			params: []query.Param{
+				query.OR(
+					query.AND(
+						query.Filter("Name", "john"),
+						query.OR(query.Filter("Age", 20), query.Filter("Age", 30)),
+					),
+					query.Filter("ID", []int{1, 2}),
+				),
+				query.Filter("Age", 40).WithOP(query.LT),
+			},
+			wantSQL: "SELECT * FROM `users` " +
+				"WHERE ((name = ? AND (age = ? OR age = ?)) OR id IN (?,?)) AND age < ?",
+			wantVars: []any{"john", 20, 30, 1, 2, 40},
+		},
+		{
+			name: "and-with-empty-in",
+			params: []query.Param{query.OR(
+				query.AND(query.Filter("ID", []int{}).WithOP(query.IN), query.Filter("Age", 20)),
+				query.Filter("Name", "john"),
+			)},
+			wantSQL:  "SELECT * FROM `users` WHERE (1 = 0 AND age = ?) OR name = ?",
+			wantVars: []any{20, "john"},
+		},
+		{
+			name:    "empty-group",
+			params:  []query.Param{query.AND(query.AND())},
+			wantSQL: "SELECT * FROM `users`",
+		},
+	}
+
+	for i := range tests {
+		tt := tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			db, _ := gormtest.NewDB(t)
+			builder := gormquery.NewBuilder(gormquery.WithFieldToColMap(gormutils.FieldToColMap(User{})))
+
+			// THEN
+			gormtest.AssertSQL(t, db, &User{}, builder.Build(query.NewParams(tt.params...)), tt.wantSQL, tt.wantVars...)
+		})
+	}
+
+	t.Run("should-bind-template-values", func(t *testing.T) {
+		// GIVEN
+		db, _ := gormtest.NewDB(t)
+		builder := gormquery.NewBuilder(gormquery.WithFieldToColMap(gormutils.FieldToColMap(User{})))
+
+		params, err := query.Compile(query.OR(
+			query.AND(query.Filter("Name", ""), query.Filter("Age", 0)),
+			query.Filter("ID", 0),
+		)).Bind("john", 20, 3)
+		require.NoError(t, err)
+
+		// THEN
+		gormtest.AssertSQL(t, db, &User{}, builder.Build(params),
+			"SELECT * FROM `users` WHERE (name = ? AND age = ?) OR id = ?", "john", 20, 3)
+	})
+}
+
+func Test_Builder_Not(t *testing.T) {
+	tests := []struct {
+		name     string
+		params   []query.Param
+		wantSQL  string
+		wantVars []any
+	}{
+		{
+			name:     "filter",
+			params:   []query.Param{query.Not(query.Filter("Name", "john"))},
+			wantSQL:  "SELECT * FROM `users` WHERE NOT (name = ?)",
+			wantVars: []any{"john"},
+		},
+		{
+			name: "and-group",
+			params: []query.Param{
+				query.Not(query.AND(query.Filter("Name", "john"), query.Filter("Age", 20).WithOP(query.GTE))),
+				query.Filter("ID", 1).WithOP(query.GT),
+			},
+			wantSQL:  "SELECT * FROM `users` WHERE NOT (name = ? AND age >= ?) AND id > ?",
+			wantVars: []any{"john", 20, 1},
+		},
+		{
+			name:     "or",
+			params:   []query.Param{query.Not(query.OR(query.Filter("Name", "john"), query.Filter("Name", "jane")))},
+			wantSQL:  "SELECT * FROM `users` WHERE NOT (name = ? OR name = ?)",
+			wantVars: []any{"john", "jane"},
+		},
+		{
+			name: "within-group",
+			params: []query.Param{query.OR(
+				query.Not(query.Filter("ID", []int{1, 2})),
+				query.AND(query.Not(query.Not(query.Filter("Age", 20))), query.Filter("Name", "john")),
+			)},
+			wantSQL:  "SELECT * FROM `users` WHERE NOT (id IN (?,?)) OR (NOT (NOT (age = ?)) AND name = ?)",
+			wantVars: []any{1, 2, 20, "john"},
+		},
+		{
+			name:    "empty-group",
+			params:  []query.Param{query.Not(query.AND())},
+			wantSQL: "SELECT * FROM `users`",
+		},
+	}
+
+	for i := range tests {
+		tt := tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			db, _ := gormtest.NewDB(t)
+			builder := gormquery.NewBuilder(gormquery.WithFieldToColMap(gormutils.FieldToColMap(User{})))
+
+			// THEN
+			gormtest.AssertSQL(t, db, &User{}, builder.Build(query.NewParams(tt.params...)), tt.wantSQL, tt.wantVars...)
+		})
+	}
+}
+
+func Test_Builder_Raw(t *testing.T) {
+	tests := []struct {
+		name     string
+		params   []query.Param
+		wantSQL  string
+		wantVars []any
+	}{
+		{
+			name:     "raw",
+			params:   []query.Param{query.Raw("age > ? AND name IN ?", 18, []string{"john", "jane"})},
+			wantSQL:  "SELECT * FROM `users` WHERE age > ? AND name IN (?,?)",
+			wantVars: []any{18, "john", "jane"},
+		},
+		{
+			name: "raw-with-filter",
+			params: []query.Param{
+				query.Filter("Name", "john"),
+				query.Raw("age % ? = 0", 2),
+			},
+			wantSQL:  "SELECT * FROM `users` WHERE name = ? AND age % ? = 0",
+			wantVars: []any{"john", 2},
+		},
+		{
+			name: "raw-in-groups",
+			params: []query.Param{query.OR(
+				query.Raw("age BETWEEN ? AND ?", 18, 30),
+				query.Not(query.Raw("name LIKE ?", "j%")),
+			)},
+			wantSQL:  "SELECT * FROM `users` WHERE (age BETWEEN ? AND ?) OR NOT (name LIKE ?)",
+			wantVars: []any{18, 30, "j%"},
+		},
+	}
+
+	for i := range tests {
+		tt := tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			db, _ := gormtest.NewDB(t)
+			builder := gormquery.NewBuilder(gormquery.WithFieldToColMap(gormutils.FieldToColMap(User{})))
+
+			// THEN
+			gormtest.AssertSQL(t, db, &User{}, builder.Build(query.NewParams(tt.params...)), tt.wantSQL, tt.wantVars...)
+		})
+	}
+}
+
+func Test_Builder_Cursor(t *testing.T) {
+	builder := gormquery.NewBuilder(gormquery.WithFieldToColMap(gormutils.FieldToColMap(User{})))
+
+	sch, err := schema.Parse(&User{}, &sync.Map{}, schema.NamingStrategy{})
+	require.NoError(t, err)
+
+	cursorOf := func(t *testing.T, last User, orderBy ...query.OrderByParam) string {
+		keyset, err := builder.Keyset(sch, orderBy)
+		require.NoError(t, err)
+
+		cursor, err := keyset.Next(context.Background(), last)
+		require.NoError(t, err)
+
+		return cursor
+	}
+
+	last := User{ID: 7, Name: "john", Age: 20}
+
+	tests := []struct {
+		name     string
+		param    query.Param
+		wantSQL  string
+		wantVars []any
+	}{
+		{
+			name:    "first-page",
+			param:   query.Cursor("", 10, query.OrderBy("Age", false)),
+			wantSQL: "SELECT * FROM `users` ORDER BY `age`,`id` LIMIT 10",
+		},
+		{
+			name:     "primary-key",
+			param:    query.Cursor(cursorOf(t, last), 10),
+			wantSQL:  "SELECT * FROM `users` WHERE `id` > ? ORDER BY `id` LIMIT 10",
+			wantVars: []any{7},
+		},
+		{
+			name:     "row-values",
+			param:    query.Cursor(cursorOf(t, last, query.OrderBy("Age", false)), 10, query.OrderBy("Age", false)),
+			wantSQL:  "SELECT * FROM `users` WHERE (`age`, `id`) > (?, ?) ORDER BY `age`,`id` LIMIT 10",
+			wantVars: []any{20, 7},
+		},
+		{
+			name:  "mixed-directions",
+			param: query.Cursor(cursorOf(t, last, query.OrderBy("Name", true)), 10, query.OrderBy("Name", true)),
+			wantSQL: "SELECT * FROM `users` WHERE (`name` < ?) OR (`name` = ? AND `id` > ?) " +
+				"ORDER BY `name` DESC,`id` LIMIT 10",
+			wantVars: []any{"john", "john", 7},
+		},
+	}
+
+	for i := range tests {
+		tt := tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			db, _ := gormtest.NewDB(t)
+
+			// THEN
+			gormtest.AssertSQL(t, db, &User{}, builder.Build(query.NewParams(tt.param)), tt.wantSQL, tt.wantVars...)
+		})
+	}
+
+	t.Run("should-reject-invalid-cursor", func(t *testing.T) {
+		// GIVEN
+		db, _ := gormtest.NewDB(t)
+		anotherOrder := cursorOf(t, last, query.OrderBy("Name", false))
+
+		for _, cursor := range []string{"not a cursor", anotherOrder} {
+			// WHEN
+			var users []User
+			err := db.Scopes(builder.Build(query.NewParams(query.Cursor(cursor, 10)))...).Find(&users).Error
+
+			// THEN
+			assert.ErrorIs(t, err, gormquery.ErrInvalidCursor)
+		}
+	})
+}
+
+func Test_Builder_Sample(t *testing.T) {
+	tests := []struct {
+		name     string
+		postgres bool
+		params   query.Params
+		wantSQL  string
+		wantVars []any
+	}{
+		{
+			name:     "system",
+			postgres: true,
+			params:   query.NewParams(query.Sample(2.5), query.Filter("Age", 20)),
+			wantSQL:  "SELECT * FROM `users` TABLESAMPLE SYSTEM (?) WHERE age = ?",
+			wantVars: []any{2.5, 20},
+		},
+		{
+			name:     "bernoulli",
+			postgres: true,
+			params:   query.NewParams(query.Sample(10).WithMethod(query.SampleBernoulli)),
+			wantSQL:  "SELECT * FROM `users` TABLESAMPLE BERNOULLI (?)",
+			wantVars: []any{float64(10)},
+		},
+		{
+			name:     "ignored-by-other-dialects",
+			params:   query.NewParams(query.Sample(2.5), query.Filter("Age", 20)),
+			wantSQL:  "SELECT * FROM `users` WHERE age = ?",
+			wantVars: []any{20},
+		},
+	}
+
+	for i := range tests {
+		tt := tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			db, _ := gormtest.NewDB(t)
+			if tt.postgres {
+				db.Dialector = postgresDialector{db.Dialector}
+			}
+
+			builder := gormquery.NewBuilder(gormquery.WithFieldToColMap(gormutils.FieldToColMap(User{})))
+
+			// THEN
+			gormtest.AssertSQL(t, db, &User{}, builder.Build(tt.params), tt.wantSQL, tt.wantVars...)
+		})
+	}
+
+	t.Run("should-reject-invalid-percent", func(t *testing.T) {
+		// GIVEN
+		gormquery.SetMode(gormquery.ModeLenient)
+		t.Cleanup(func() { gormquery.SetMode(gormquery.ModeStrict) })
+
+		db, _ := gormtest.NewDB(t)
+
+		// WHEN
+		var users []User
+		err := db.Scopes(gormquery.NewBuilder().Build(query.NewParams(query.Sample(150)))...).Find(&users).Error
+
+		// THEN
+		assert.ErrorIs(t, err, gormquery.ErrInvalidParam)
+	})
+}
+
+func Test_Builder_With(t *testing.T) {
+	tests := []struct {
+		name     string
+		params   query.Params
+		wantSQL  string
+		wantVars []any
+	}{
+		{
+			name: "with",
+			params: query.NewParams(
+				query.With("adults", query.Filter("Age", 18).WithOP(query.GTE)),
+				query.Filter("Name", "john"),
+			),
+			wantSQL: "SELECT * FROM (WITH `adults` AS (SELECT * FROM `users` WHERE age >= ?) " +
+				"SELECT * FROM `adults`) AS `users` WHERE name = ?",
+			wantVars: []any{18, "john"},
+		},
+		{
+			name: "recursive",
+			params: query.NewParams(
+				query.With("referees", query.Filter("ID", 1)).WithRecursive("RefererID", "ID"),
+				query.OrderBy("Name", false),
+			),
+			wantSQL: "SELECT * FROM (WITH RECURSIVE `referees` AS (SELECT * FROM `users` WHERE id = ? " +
+				"UNION ALL SELECT `users`.* FROM `users` JOIN `referees` ON `users`.`referer_id` = `referees`.`id`) " +
+				"SELECT * FROM `referees`) AS `users` ORDER BY `name`",
+			wantVars: []any{1},
+		},
+	}
+
+	for i := range tests {
+		tt := tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			db, _ := gormtest.NewDB(t)
+			builder := gormquery.NewBuilder(gormquery.WithFieldToColMap(gormutils.FieldToColMap(User{})))
+
+			// THEN
+			gormtest.AssertSQL(t, db, &User{}, builder.Build(tt.params), tt.wantSQL, tt.wantVars...)
+		})
+	}
+
+	t.Run("should-report-invalid-params-in-lenient-mode", func(t *testing.T) {
+		// GIVEN
+		gormquery.SetMode(gormquery.ModeLenient)
+		t.Cleanup(func() { gormquery.SetMode(gormquery.ModeStrict) })
+
+		db, _ := gormtest.NewDB(t)
+		db.Dialector = postgresDialector{db.Dialector}
+
+		for _, params := range []query.Params{
+			query.NewParams(query.With("adults", query.Filter("Age", nil))),
+			query.NewParams(query.Sample(1), query.With("adults", query.Filter("Age", 18))),
+		} {
+			// WHEN
+			var users []User
+			err := db.Scopes(gormquery.NewBuilder().Build(params)...).Find(&users).Error
+
+			// THEN
+			assert.ErrorIs(t, err, gormquery.ErrInvalidParam)
+		}
+	})
+}
+
+// sqliteDialector reports the sqlite dialect name while quoting as the wrapped MySQL dialector.
+type sqliteDialector struct {
+	gorm.Dialector
+}
+
+func (sqliteDialector) Name() string {
+	return "sqlite"
+}
+
+func Test_Builder_Geo(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialect  func(gorm.Dialector) gorm.Dialector
+		param    query.Param
+		wantSQL  string
+		wantVars []any
+	}{
+		{
+			name:    "within-radius-postgres",
+			dialect: func(d gorm.Dialector) gorm.Dialector { return postgresDialector{d} },
+			param:   query.WithinRadius("Name", 48.8566, 2.3522, 2000),
+			wantSQL: "SELECT * FROM `users` " +
+				"WHERE ST_DWithin(`name`::geography, ST_SetSRID(ST_MakePoint(?, ?), 4326)::geography, ?)",
+			wantVars: []any{2.3522, 48.8566, float64(2000)},
+		},
+		{
+			name:     "within-radius-mysql",
+			param:    query.WithinRadius("Name", 48.8566, 2.3522, 2000),
+			wantSQL:  "SELECT * FROM `users` WHERE ST_Distance_Sphere(`name`, POINT(?, ?)) <= ?",
+			wantVars: []any{2.3522, 48.8566, float64(2000)},
+		},
+		{
+			name:     "in-bounding-box-postgres",
+			dialect:  func(d gorm.Dialector) gorm.Dialector { return postgresDialector{d} },
+			param:    query.InBoundingBox("Name", 48.80, 2.25, 48.90, 2.42),
+			wantSQL:  "SELECT * FROM `users` WHERE ST_Within(`name`::geometry, ST_MakeEnvelope(?, ?, ?, ?, 4326))",
+			wantVars: []any{2.25, 48.80, 2.42, 48.90},
+		},
+		{
+			name:     "in-bounding-box-mysql",
+			param:    query.InBoundingBox("Name", 48.80, 2.25, 48.90, 2.42),
+			wantSQL:  "SELECT * FROM `users` WHERE MBRWithin(`name`, ST_MakeEnvelope(POINT(?, ?), POINT(?, ?)))",
+			wantVars: []any{2.25, 48.80, 2.42, 48.90},
+		},
+	}
+
+	for i := range tests {
+		tt := tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			db, _ := gormtest.NewDB(t)
+			if tt.dialect != nil {
+				db.Dialector = tt.dialect(db.Dialector)
+			}
+
+			builder := gormquery.NewBuilder(gormquery.WithFieldToColMap(gormutils.FieldToColMap(User{})))
+
+			// THEN
+			gormtest.AssertSQL(t, db, &User{}, builder.Build(query.NewParams(tt.param)), tt.wantSQL, tt.wantVars...)
+		})
+	}
+
+	invalid := []struct {
+		name  string
+		param query.Param
+		err   string
+	}{
+		{
+			name:  "out-of-range-latitude",
+			param: query.WithinRadius("location", 91, 0, 10),
+			err:   "coordinates (91, 0) of location are out of range: invalid query param",
+		},
+		{
+			name:  "negative-radius",
+			param: query.WithinRadius("location", 0, 0, -1),
+			err:   "radius of location cannot be negative: invalid query param",
+		},
+		{
+			name:  "inverted-bounding-box",
+			param: query.InBoundingBox("location", 10, 0, 0, 10),
+			err:   "bounding box of location has its corners inverted: invalid query param",
+		},
+	}
+
+	for i := range invalid {
+		tt := invalid[i]
+		t.Run(tt.name+"-should-panic", func(t *testing.T) {
+			db, _ := gormtest.NewDB(t)
+			scopes := gormquery.NewBuilder().Build(query.NewParams(tt.param))
+
+			assert.PanicsWithError(t, tt.err, func() {
+				var users []User
+				_ = db.Scopes(scopes...).Find(&users)
+			})
+		})
+	}
+
+	t.Run("should-reject-unsupported-dialect", func(t *testing.T) {
+		// GIVEN
+		gormquery.SetMode(gormquery.ModeLenient)
+		t.Cleanup(func() { gormquery.SetMode(gormquery.ModeStrict) })
+
+		db, _ := gormtest.NewDB(t)
+		db.Dialector = sqliteDialector{db.Dialector}
+
+		// WHEN
+		var users []User
+		err := db.Scopes(gormquery.NewBuilder().Build(query.NewParams(
+			query.WithinRadius("location", 0, 0, 10),
+		))...).Find(&users).Error
+
+		// THEN
+		assert.EqualError(t, err, "geospatial params are not supported by sqlite: invalid query param")
+	})
+}
+
+func Test_Builder_FullText(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialect  func(gorm.Dialector) gorm.Dialector
+		params   []query.Param
+		wantSQL  string
+		wantVars []any
+	}{
+		{
+			name:     "mysql",
+			params:   []query.Param{query.FullText("john doe", "Name")},
+			wantSQL:  "SELECT * FROM `users` WHERE MATCH (`name`) AGAINST (? IN NATURAL LANGUAGE MODE)",
+			wantVars: []any{"john doe"},
+		},
+		{
+			name: "mysql-by-relevance-before-order-by",
+			params: []query.Param{
+				query.FullText("john", "Name", "Age").OrderByRelevance(),
+				query.OrderBy("ID", true),
+			},
+			wantSQL: "SELECT * FROM `users` WHERE MATCH (`name`, `age`) AGAINST (? IN NATURAL LANGUAGE MODE) " +
+				"ORDER BY MATCH (`name`, `age`) AGAINST (? IN NATURAL LANGUAGE MODE) DESC, `id` DESC",
+			wantVars: []any{"john", "john"},
+		},
+		{
+			name:    "postgres-by-relevance",
+			dialect: func(d gorm.Dialector) gorm.Dialector { return postgresDialector{d} },
+			params:  []query.Param{query.FullText("john", "Name", "Age").OrderByRelevance()},
+			wantSQL: "SELECT * FROM `users` " +
+				"WHERE to_tsvector(coalesce(`name`, '') || ' ' || coalesce(`age`, '')) @@ plainto_tsquery(?) " +
+				"ORDER BY ts_rank(to_tsvector(coalesce(`name`, '') || ' ' || coalesce(`age`, '')), " +
+				"plainto_tsquery(?)) DESC",
+			wantVars: []any{"john", "john"},
+		},
+	}
+
+	for i := range tests {
+		tt := tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			db, _ := gormtest.NewDB(t)
+			if tt.dialect != nil {
+				db.Dialector = tt.dialect(db.Dialector)
+			}
+
+			builder := gormquery.NewBuilder(gormquery.WithFieldToColMap(gormutils.FieldToColMap(User{})))
+
+			// THEN
+			gormtest.AssertSQL(t, db, &User{}, builder.Build(query.NewParams(tt.params...)), tt.wantSQL, tt.wantVars...)
+		})
+	}
+
+	t.Run("should-reject-unsupported-dialect", func(t *testing.T) {
+		// GIVEN
+		gormquery.SetMode(gormquery.ModeLenient)
+		t.Cleanup(func() { gormquery.SetMode(gormquery.ModeStrict) })
+
+		db, _ := gormtest.NewDB(t)
+		db.Dialector = sqliteDialector{db.Dialector}
+
+		// WHEN
+		var users []User
+		err := db.Scopes(gormquery.NewBuilder().Build(query.NewParams(
+			query.FullText("john", "name"),
+		))...).Find(&users).Error
+
+		// THEN
+		assert.EqualError(t, err, "full-text params are not supported by sqlite: invalid query param")
+	})
+}
+
+func Test_Builder_AsOf(t *testing.T) {
+	at := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		temporal gormquery.Temporal
+		wantSQL  string
+		wantVars []any
+	}{
+		{
+			name:     "system-versioned",
+			temporal: gormquery.Temporal{SystemVersioned: true},
+			wantSQL:  "SELECT * FROM `users` FOR SYSTEM_TIME AS OF ? WHERE age = ?",
+			wantVars: []any{at, 20},
+		},
+		{
+			name:     "history-table",
+			temporal: gormquery.Temporal{HistoryTable: "users_history"},
+			wantSQL: "SELECT * FROM (SELECT * FROM `users` WHERE `valid_from` <= ? " +
+				"UNION ALL SELECT * FROM `users_history` WHERE `valid_from` <= ? AND `valid_to` > ?) AS `users` " +
+				"WHERE age = ?",
+			wantVars: []any{at, at, at, 20},
+		},
+		{
+			name: "history-table-with-period-columns",
+			temporal: gormquery.Temporal{
+				HistoryTable: "users_versions",
+				ValidFrom:    "sys_start",
+				ValidTo:      "sys_end",
+			},
+			wantSQL: "SELECT * FROM (SELECT * FROM `users` WHERE `sys_start` <= ? " +
+				"UNION ALL SELECT * FROM `users_versions` WHERE `sys_start` <= ? AND `sys_end` > ?) AS `users` " +
+				"WHERE age = ?",
+			wantVars: []any{at, at, at, 20},
+		},
+	}
+
+	for i := range tests {
+		tt := tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			db, _ := gormtest.NewDB(t)
+			builder := gormquery.NewBuilder(
+				gormquery.WithFieldToColMap(gormutils.FieldToColMap(User{})),
+				gormquery.WithTemporal(tt.temporal),
+			)
+
+			// THEN
+			gormtest.AssertSQL(t, db, &User{}, builder.Build(query.NewParams(query.AsOf(at), query.Filter("Age", 20))),
+				tt.wantSQL, tt.wantVars...)
+		})
+	}
+
+	t.Run("should-reject-store-without-temporal-configuration", func(t *testing.T) {
+		// GIVEN
+		gormquery.SetMode(gormquery.ModeLenient)
+		t.Cleanup(func() { gormquery.SetMode(gormquery.ModeStrict) })
+
+		db, _ := gormtest.NewDB(t)
+
+		// WHEN
+		var users []User
+		err := db.Scopes(gormquery.NewBuilder().Build(query.NewParams(query.AsOf(at)))...).Find(&users).Error
+
+		// THEN
+		assert.ErrorIs(t, err, gormquery.ErrInvalidParam)
+	})
+}
+
+func Test_Builder_Collate(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialect  func(gorm.Dialector) gorm.Dialector
+		params   []query.Param
+		wantSQL  string
+		wantVars []any
+	}{
+		{
+			name:     "should-collate-filter",
+			params:   []query.Param{query.Collate("Name", "utf8mb4_bin"), query.Filter("Name", "John")},
+			wantSQL:  "SELECT * FROM `users` WHERE name COLLATE `utf8mb4_bin` = ?",
+			wantVars: []any{"John"},
+		},
+		{
+			name: "should-collate-filter-given-before-collate",
+			params: []query.Param{
+				query.Filter("Name", "John"),
+				query.Filter("Age", 20),
+				query.Collate("Name", "utf8mb4_bin"),
+			},
+			wantSQL:  "SELECT * FROM `users` WHERE name COLLATE `utf8mb4_bin` = ? AND age = ?",
+			wantVars: []any{"John", 20},
+		},
+		{
+			name: "should-collate-or-filters",
+			params: []query.Param{
+				query.Collate("Name", "utf8mb4_bin"),
+				query.OR(query.Filter("Name", "John"), query.Filter("Age", 20)),
+			},
+			wantSQL:  "SELECT * FROM `users` WHERE name COLLATE `utf8mb4_bin` = ? OR age = ?",
+			wantVars: []any{"John", 20},
+		},
+		{
+			name:     "should-collate-filter-postgres",
+			dialect:  func(d gorm.Dialector) gorm.Dialector { return postgresDialector{d} },
+			params:   []query.Param{query.Collate("Name", "en-US-x-icu"), query.Filter("Name", "John")},
+			wantSQL:  "SELECT * FROM `users` WHERE name COLLATE `en-US-x-icu` = ?",
+			wantVars: []any{"John"},
+		},
+	}
+
+	for i := range tests {
+		tt := tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			db, _ := gormtest.NewDB(t)
+			if tt.dialect != nil {
+				db.Dialector = tt.dialect(db.Dialector)
+			}
+
+			builder := gormquery.NewBuilder(gormquery.WithFieldToColMap(gormutils.FieldToColMap(User{})))
+
+			// THEN
+			gormtest.AssertSQL(t, db, &User{}, builder.Build(query.NewParams(tt.params...)), tt.wantSQL, tt.wantVars...)
+		})
+	}
+
+	t.Run("should-collate-bound-template", func(t *testing.T) {
+		// GIVEN
+		db, _ := gormtest.NewDB(t)
+
+		builder := gormquery.NewBuilder(gormquery.WithFieldToColMap(gormutils.FieldToColMap(User{})))
+		params, err := query.Compile(query.Filter("Name", ""), query.Collate("Name", "utf8mb4_bin")).Bind("John")
+		require.NoError(t, err)
+
+		// THEN
+		gormtest.AssertSQL(t, db, &User{}, builder.Build(params),
+			"SELECT * FROM `users` WHERE name COLLATE `utf8mb4_bin` = ?", "John")
+	})
+
+	t.Run("should-reject-invalid-collation", func(t *testing.T) {
+		// GIVEN
+		gormquery.SetMode(gormquery.ModeLenient)
+		t.Cleanup(func() { gormquery.SetMode(gormquery.ModeStrict) })
+
+		db, _ := gormtest.NewDB(t)
+
+		// WHEN
+		var users []User
+		err := db.Scopes(gormquery.NewBuilder().Build(query.NewParams(
+			query.Collate("name", "utf8mb4_bin; DROP TABLE users"),
+			query.Filter("name", "John"),
+		))...).Find(&users).Error
+
+		// THEN
+		assert.EqualError(t, err, `invalid collation "utf8mb4_bin; DROP TABLE users" of name: invalid query param`)
+	})
+}
+
+func Test_Builder_PreloadAll(t *testing.T) {
+	t.Run("should-reject-unknown-excluded-association", func(t *testing.T) {
+		// GIVEN
+		gormquery.SetMode(gormquery.ModeLenient)
+		t.Cleanup(func() { gormquery.SetMode(gormquery.ModeStrict) })
+
+		db, _ := gormtest.NewDB(t)
+
+		// WHEN
+		var users []User
+		err := db.Scopes(gormquery.NewBuilder().Build(query.NewParams(
+			query.PreloadAll().Excluding("Comments"),
+		))...).Find(&users).Error
+
+		// THEN
+		assert.EqualError(t, err, "unknown association Comments excluded from preload: invalid query param")
+	})
+}
+
+func Test_Builder_Pagination(t *testing.T) {
+	pagination := gormquery.Pagination{DefaultLimit: 20, MaxLimit: 100, MaxOffset: 1000}
+
+	tests := []struct {
+		name       string
+		pagination gormquery.Pagination
+		params     []query.Param
+		wantSQL    string
+	}{
+		{
+			name:       "should-keep-limit-within-max",
+			pagination: pagination,
+			params:     []query.Param{query.Paginate(40, 50)},
+			wantSQL:    "SELECT * FROM `users` LIMIT 50 OFFSET 40",
+		},
+		{
+			name:       "should-lower-limit-to-max",
+			pagination: pagination,
+			params:     []query.Param{query.Paginate(0, 1000000)},
+			wantSQL:    "SELECT * FROM `users` LIMIT 100",
+		},
+		{
+			name:       "should-apply-default-limit",
+			pagination: pagination,
+			params:     []query.Param{query.Paginate(20, 0)},
+			wantSQL:    "SELECT * FROM `users` LIMIT 20 OFFSET 20",
+		},
+		{
+			name:       "should-apply-max-limit-without-default",
+			pagination: gormquery.Pagination{MaxLimit: 100},
+			params:     []query.Param{query.Paginate(0, -1)},
+			wantSQL:    "SELECT * FROM `users` LIMIT 100",
+		},
+		{
+			name:    "should-not-limit-without-policy",
+			params:  []query.Param{query.Paginate(0, 1000000)},
+			wantSQL: "SELECT * FROM `users` LIMIT 1000000",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			db, _ := gormtest.NewDB(t)
+			builder := gormquery.NewBuilder(gormquery.WithPagination(tt.pagination))
+
+			// WHEN
+			scopes := builder.Build(query.NewParams(tt.params...))
+
+			// THEN
+			gormtest.AssertSQL(t, db, &User{}, scopes, tt.wantSQL)
+		})
+	}
+
+	t.Run("should-fail-beyond-max-offset", func(t *testing.T) {
+		// GIVEN
+		db, _ := gormtest.NewDB(t)
+		builder := gormquery.NewBuilder(gormquery.WithPagination(pagination))
+
+		// WHEN
+		var users []User
+		err := db.Scopes(builder.Build(query.NewParams(query.Paginate(1001, 10)))...).Find(&users).Error
+
+		// THEN
+		assert.ErrorIs(t, err, gormquery.ErrMaxOffsetExceeded)
+		assert.EqualError(t, err, "offset 1001 is greater than 1000: offset exceeds the maximum, use cursor pagination")
+	})
 }
@@ -0,0 +1,264 @@
+package gormquery
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"github.com/infevocorp/goflexstore/query"
+)
+
+// ErrInvalidCursor is returned by the cursor pagination when the cursor is malformed or was issued for another
+// ordering.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Keyset is the ordering of a cursor pagination resolved on the schema of a model: the ordered columns followed
+// by the columns of the primary key they miss, which break the ties so that no entity is skipped nor repeated
+// across pages. The ordered columns must not be NULL.
+type Keyset struct {
+	columns []keysetColumn
+}
+
+// keysetColumn is a column ordering the pages of a Keyset.
+type keysetColumn struct {
+	field *schema.Field
+	desc  bool
+}
+
+// cursor is the decoded cursor of a Keyset: the ordering it was issued for and the values of the ordered columns
+// of the last entity of the previous page.
+type cursor struct {
+	Order  string            `json:"o"`
+	Values []json.RawMessage `json:"v"`
+}
+
+// Keyset resolves the ordering of the fields orderBy, followed by the primary key, on the schema sch.
+// It returns ErrInvalidParam if a field is unknown.
+func (b *ScopeBuilder) Keyset(sch *schema.Schema, orderBy []query.OrderByParam) (Keyset, error) {
+	var (
+		k       Keyset
+		ordered = make(map[string]bool)
+	)
+
+	for _, o := range orderBy {
+		field := sch.LookUpField(b.getColName(o.Name))
+		if field == nil || field.DBName == "" {
+			return Keyset{}, errors.Wrapf(ErrInvalidParam, "unknown order by field %s", o.Name)
+		}
+
+		if ordered[field.DBName] {
+			continue
+		}
+
+		ordered[field.DBName] = true
+		k.columns = append(k.columns, keysetColumn{field: field, desc: o.Desc})
+	}
+
+	for _, field := range sch.PrimaryFields {
+		if !ordered[field.DBName] {
+			k.columns = append(k.columns, keysetColumn{field: field})
+		}
+	}
+
+	return k, nil
+}
+
+// Order orders the statement of tx by the columns of the keyset.
+func (k Keyset) Order(tx *gorm.DB) *gorm.DB {
+	for _, c := range k.columns {
+		tx = tx.Order(clause.OrderByColumn{Column: clause.Column{Name: c.field.DBName}, Desc: c.desc})
+	}
+
+	return tx
+}
+
+// After decodes the cursor and returns the condition of the rows following it in the ordering of the keyset.
+// The columns ordered in the same direction are compared as a row value, which the databases read from the
+// index of the ordering:
+//
+//	(created_at, id) > (?, ?)
+//
+// and the mixed directions are expanded:
+//
+//	(a > ?) OR (a = ? AND b < ?)
+//
+// It returns ErrInvalidCursor if the cursor is malformed or was issued for another ordering.
+func (k Keyset) After(after string) (clause.Expression, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(after)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidCursor, err.Error())
+	}
+
+	var c cursor
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return nil, errors.Wrap(ErrInvalidCursor, err.Error())
+	}
+
+	if c.Order != k.key() || len(c.Values) != len(k.columns) {
+		return nil, errors.Wrap(ErrInvalidCursor, "issued for another ordering")
+	}
+
+	values := make([]any, len(k.columns))
+
+	for i, col := range k.columns {
+		value := reflect.New(col.field.FieldType)
+		if err := json.Unmarshal(c.Values[i], value.Interface()); err != nil {
+			return nil, errors.Wrapf(ErrInvalidCursor, "value of %s: %v", col.field.Name, err)
+		}
+
+		values[i] = value.Elem().Interface()
+	}
+
+	if k.uniform() {
+		return k.afterRow(values), nil
+	}
+
+	return k.afterExpanded(values), nil
+}
+
+// Next returns the cursor of the page following last, a pointer to or a value of the model of the keyset.
+func (k Keyset) Next(ctx context.Context, last any) (string, error) {
+	var (
+		value = reflect.Indirect(reflect.ValueOf(last))
+		c     = cursor{Order: k.key(), Values: make([]json.RawMessage, len(k.columns))}
+	)
+
+	for i, col := range k.columns {
+		raw, err := json.Marshal(col.field.ReflectValueOf(ctx, value).Interface())
+		if err != nil {
+			return "", errors.Wrapf(err, "encode cursor value of %s", col.field.Name)
+		}
+
+		c.Values[i] = raw
+	}
+
+	raw, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// key identifies the ordering of the keyset, binding the cursors to it.
+func (k Keyset) key() string {
+	keys := make([]string, len(k.columns))
+
+	for i, c := range k.columns {
+		keys[i] = c.field.DBName
+
+		if c.desc {
+			keys[i] += " desc"
+		}
+	}
+
+	return strings.Join(keys, ",")
+}
+
+// uniform reports whether the columns of the keyset are ordered in the same direction.
+func (k Keyset) uniform() bool {
+	for _, c := range k.columns[1:] {
+		if c.desc != k.columns[0].desc {
+			return false
+		}
+	}
+
+	return true
+}
+
+// afterRow returns the condition comparing the columns of the keyset to the values as row values.
+func (k Keyset) afterRow(values []any) clause.Expression {
+	op := " > "
+	if k.columns[0].desc {
+		op = " < "
+	}
+
+	if len(k.columns) == 1 {
+		return clause.Expr{SQL: "?" + op + "?", Vars: []any{clause.Column{Name: k.columns[0].field.DBName}, values[0]}}
+	}
+
+	var (
+		placeholders = "(?" + strings.Repeat(", ?", len(k.columns)-1) + ")"
+		vars         = make([]any, 0, 2*len(k.columns))
+	)
+
+	for _, c := range k.columns {
+		vars = append(vars, clause.Column{Name: c.field.DBName})
+	}
+
+	vars = append(vars, values...)
+
+	return clause.Expr{SQL: placeholders + op + placeholders, Vars: vars}
+}
+
+// afterExpanded returns the condition of the rows following the values as a disjunction over the columns.
+func (k Keyset) afterExpanded(values []any) clause.Expression {
+	var (
+		conditions = make([]string, len(k.columns))
+		vars       []any
+	)
+
+	for i, c := range k.columns {
+		terms := make([]string, 0, i+1)
+
+		for j := 0; j < i; j++ {
+			terms = append(terms, "? = ?")
+			vars = append(vars, clause.Column{Name: k.columns[j].field.DBName}, values[j])
+		}
+
+		op := " > ?"
+		if c.desc {
+			op = " < ?"
+		}
+
+		terms = append(terms, "?"+op)
+		vars = append(vars, clause.Column{Name: c.field.DBName}, values[i])
+
+		conditions[i] = "(" + strings.Join(terms, " AND ") + ")"
+	}
+
+	return clause.Expr{SQL: strings.Join(conditions, " OR "), Vars: vars}
+}
+
+// Cursor constructs a GORM scope for a cursor query parameter.
+// It orders the query by the keyset of the ordering of the param, filters the rows following its cursor and
+// limits the query to the size of the page, lowered to the maximum limit of the pagination policy, if any.
+// A malformed cursor usually comes from the request of a client, so ErrInvalidCursor is returned by the
+// operation even in strict mode.
+func (b *ScopeBuilder) Cursor(param query.Param) ScopeFunc {
+	p := param.(query.CursorParam)
+
+	return func(tx *gorm.DB) *gorm.DB {
+		if p.Size < 1 {
+			return fail(tx, errors.Wrapf(ErrInvalidParam, "cursor size %d", p.Size))
+		}
+
+		if !parseModel(tx) {
+			return tx
+		}
+
+		keyset, err := b.Keyset(tx.Statement.Schema, p.OrderBy)
+		if err != nil {
+			return fail(tx, err)
+		}
+
+		if p.After != "" {
+			after, err := keyset.After(p.After)
+			if err != nil {
+				_ = tx.AddError(err)
+				return tx
+			}
+
+			tx = tx.Where(after)
+		}
+
+		return keyset.Order(tx).Limit(b.Pagination.limit(p.Size))
+	}
+}
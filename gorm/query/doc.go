@@ -13,4 +13,8 @@
 // gormquery is especially useful in conjunction with the github.com/infevocorp/flexstore/store/gorm package,
 // providing the necessary tools to create a generic, reusable store that leverages the power of GORM
 // with enhanced query capabilities.
+//
+// Invalid query parameters, such as a nil filter value, make the query panic by default. Servers building
+// queries from client input can switch to lenient mode with SetMode(ModeLenient), in which these are
+// reported as errors wrapping ErrInvalidParam, returned by the store operations.
 package gormquery
@@ -0,0 +1,72 @@
+package gormquery
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/infevocorp/goflexstore/query"
+)
+
+// FullText constructs a GORM scope for a full-text query parameter.
+//
+// On MySQL, it renders MATCH ... AGAINST in natural language mode, which requires a FULLTEXT index on exactly
+// the searched columns. On PostgreSQL, it matches the text search vector of the concatenated columns against
+// plainto_tsquery, with the default text search configuration, and orders by ts_rank. Other databases report an
+// error wrapping ErrInvalidParam.
+//
+// When the param orders by relevance, the ordering is placed before the orderings of the OrderBy params, which is
+// why Build runs the full-text scopes after the other ones.
+func (b *ScopeBuilder) FullText(param query.Param) ScopeFunc {
+	p := param.(query.FullTextParam)
+
+	cols := make([]any, len(p.Names))
+	for i, name := range p.Names {
+		cols[i] = clause.Column{Name: b.getColName(name)}
+	}
+
+	return func(tx *gorm.DB) *gorm.DB {
+		if len(p.Names) == 0 {
+			return fail(tx, errors.Wrap(ErrInvalidParam, "full-text search has no field"))
+		}
+
+		var match, relevance string
+
+		switch tx.Dialector.Name() {
+		case "mysql":
+			colsSQL := strings.TrimSuffix(strings.Repeat("?, ", len(cols)), ", ")
+			match = "MATCH (" + colsSQL + ") AGAINST (? IN NATURAL LANGUAGE MODE)"
+			relevance = match + " DESC"
+		case "postgres":
+			vector := "to_tsvector(" + strings.TrimSuffix(strings.Repeat("coalesce(?, '') || ' ' || ", len(cols)),
+				" || ' ' || ") + ")"
+			match = vector + " @@ plainto_tsquery(?)"
+			relevance = "ts_rank(" + vector + ", plainto_tsquery(?)) DESC"
+		default:
+			return fail(tx, errors.Wrapf(ErrInvalidParam, "full-text params are not supported by %s",
+				tx.Dialector.Name()))
+		}
+
+		vars := append(append(make([]any, 0, len(cols)+1), cols...), p.Text)
+
+		tx = tx.Where(match, vars...)
+		if !p.ByRelevance {
+			return tx
+		}
+
+		orderBy := clause.OrderBy{Expression: clause.Expr{SQL: relevance, Vars: vars}}
+
+		if existing, ok := tx.Statement.Clauses[orderBy.Name()].Expression.(clause.OrderBy); ok {
+			orderBy.Expression = clause.CommaExpression{Exprs: []clause.Expression{
+				orderBy.Expression,
+				clause.OrderBy{Columns: existing.Columns, Expression: existing.Expression},
+			}}
+		}
+
+		tx.Statement.Clauses[orderBy.Name()] = clause.Clause{Name: orderBy.Name(), Expression: orderBy}
+
+		return tx
+	}
+}
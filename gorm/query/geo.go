@@ -0,0 +1,97 @@
+package gormquery
+
+import (
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/infevocorp/goflexstore/query"
+)
+
+// WithinRadius constructs a GORM scope for a within radius query parameter.
+//
+// On PostgreSQL, it requires PostGIS and renders ST_DWithin over geographies, so the column can be a geography
+// or a geometry of SRID 4326. On MySQL, it renders ST_Distance_Sphere, which expects points of SRID 0 whose x
+// coordinate is the longitude. Other databases report an error wrapping ErrInvalidParam.
+func (b *ScopeBuilder) WithinRadius(param query.Param) ScopeFunc {
+	var (
+		p   = param.(query.WithinRadiusParam)
+		col = clause.Column{Name: b.getColName(p.Name)}
+	)
+
+	return func(tx *gorm.DB) *gorm.DB {
+		if err := validatePoint(p.Name, p.Lat, p.Lng); err != nil {
+			return fail(tx, err)
+		}
+
+		if p.Meters < 0 {
+			return fail(tx, errors.Wrapf(ErrInvalidParam, "radius of %s cannot be negative", p.Name))
+		}
+
+		switch tx.Dialector.Name() {
+		case "postgres":
+			return tx.Where(
+				"ST_DWithin(?::geography, ST_SetSRID(ST_MakePoint(?, ?), 4326)::geography, ?)",
+				col, p.Lng, p.Lat, p.Meters,
+			)
+		case "mysql":
+			return tx.Where("ST_Distance_Sphere(?, POINT(?, ?)) <= ?", col, p.Lng, p.Lat, p.Meters)
+		default:
+			return fail(tx, unsupportedGeoDialect(tx))
+		}
+	}
+}
+
+// InBoundingBox constructs a GORM scope for an in bounding box query parameter.
+//
+// On PostgreSQL, it requires PostGIS and renders ST_Within over an envelope of SRID 4326. On MySQL, it renders
+// MBRWithin over an envelope of SRID 0, whose x coordinates are the longitudes. Other databases report an error
+// wrapping ErrInvalidParam.
+func (b *ScopeBuilder) InBoundingBox(param query.Param) ScopeFunc {
+	var (
+		p   = param.(query.InBoundingBoxParam)
+		col = clause.Column{Name: b.getColName(p.Name)}
+	)
+
+	return func(tx *gorm.DB) *gorm.DB {
+		if err := validatePoint(p.Name, p.MinLat, p.MinLng); err != nil {
+			return fail(tx, err)
+		}
+
+		if err := validatePoint(p.Name, p.MaxLat, p.MaxLng); err != nil {
+			return fail(tx, err)
+		}
+
+		if p.MinLat > p.MaxLat || p.MinLng > p.MaxLng {
+			return fail(tx, errors.Wrapf(ErrInvalidParam, "bounding box of %s has its corners inverted", p.Name))
+		}
+
+		switch tx.Dialector.Name() {
+		case "postgres":
+			return tx.Where(
+				"ST_Within(?::geometry, ST_MakeEnvelope(?, ?, ?, ?, 4326))",
+				col, p.MinLng, p.MinLat, p.MaxLng, p.MaxLat,
+			)
+		case "mysql":
+			return tx.Where(
+				"MBRWithin(?, ST_MakeEnvelope(POINT(?, ?), POINT(?, ?)))",
+				col, p.MinLng, p.MinLat, p.MaxLng, p.MaxLat,
+			)
+		default:
+			return fail(tx, unsupportedGeoDialect(tx))
+		}
+	}
+}
+
+// validatePoint returns an error wrapping ErrInvalidParam if the coordinates are out of range.
+func validatePoint(name string, lat, lng float64) error {
+	if lat < -90 || lat > 90 || lng < -180 || lng > 180 {
+		return errors.Wrapf(ErrInvalidParam, "coordinates (%v, %v) of %s are out of range", lat, lng, name)
+	}
+
+	return nil
+}
+
+func unsupportedGeoDialect(tx *gorm.DB) error {
+	return errors.Wrapf(ErrInvalidParam, "geospatial params are not supported by %s", tx.Dialector.Name())
+}
@@ -0,0 +1,51 @@
+package gormquery
+
+import (
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// Mode controls how invalid query parameters and conversion failures are reported.
+type Mode int32
+
+const (
+	// ModeStrict panics on invalid query parameters, such as a nil filter value or an operator that cannot
+	// be used with a list of values. It is the default mode and surfaces programming errors early.
+	ModeStrict Mode = iota
+	// ModeLenient reports invalid query parameters as errors returned by the store operations,
+	// so a malformed client query cannot crash the server. gormstore also recovers from
+	// converter.ConversionError panics and returns them as errors in this mode.
+	ModeLenient
+)
+
+// ErrInvalidParam is wrapped by the errors reported for invalid query parameters.
+var ErrInvalidParam = errors.New("invalid query param")
+
+var mode atomic.Int32
+
+// SetMode sets the package-wide mode. It is meant to be called once, during the application initialization.
+//
+// Example:
+//
+//	gormquery.SetMode(gormquery.ModeLenient)
+func SetMode(m Mode) {
+	mode.Store(int32(m))
+}
+
+// CurrentMode returns the package-wide mode.
+func CurrentMode() Mode {
+	return Mode(mode.Load())
+}
+
+// fail reports err on tx, either by panicking in strict mode or by adding it to tx in lenient mode.
+func fail(tx *gorm.DB, err error) *gorm.DB {
+	if CurrentMode() == ModeStrict {
+		panic(err)
+	}
+
+	_ = tx.AddError(err)
+
+	return tx
+}
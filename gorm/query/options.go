@@ -64,3 +64,26 @@ func WithFieldToColMap(fieldToColMap map[string]string) Option {
 		b.FieldToColMap = fieldToColMap
 	}
 }
+
+// WithPagination sets the pagination policy of the queries, protecting the database from requests such as
+// `?limit=1000000`, see Pagination.
+//
+// Example:
+//
+//	gormquery.WithPagination(gormquery.Pagination{DefaultLimit: 20, MaxLimit: 100, MaxOffset: 10000})
+func WithPagination(pagination Pagination) Option {
+	return func(b *ScopeBuilder) {
+		b.Pagination = pagination
+	}
+}
+
+// WithTemporal sets the temporal table configuration of the queries, required by the as of params, see Temporal.
+//
+// Example:
+//
+//	gormquery.WithTemporal(gormquery.Temporal{HistoryTable: "articles_history"})
+func WithTemporal(temporal Temporal) Option {
+	return func(b *ScopeBuilder) {
+		b.Temporal = temporal
+	}
+}
@@ -0,0 +1,54 @@
+package gormquery
+
+import (
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// ErrMaxOffsetExceeded is returned by the queries paginated beyond the maximum offset of the pagination policy.
+var ErrMaxOffsetExceeded = errors.New("offset exceeds the maximum, use cursor pagination")
+
+// Pagination is the pagination policy of a ScopeBuilder, see WithPagination. A zero field disables its rule.
+//
+// Fields:
+//   - DefaultLimit: The limit of the paginate params without limit, and of the lists of gormstore
+//     queried without paginate param.
+//   - MaxLimit: The maximum limit. Greater limits are lowered to it, and it is the limit of the queries
+//     without limit when there is no DefaultLimit.
+//   - MaxOffset: The maximum offset. Queries with a greater offset fail with ErrMaxOffsetExceeded,
+//     since the database reads and discards every skipped row: deep pages should be fetched with
+//     cursor pagination, filtering on the last key of the previous page, instead.
+type Pagination struct {
+	DefaultLimit int
+	MaxLimit     int
+	MaxOffset    int
+}
+
+// Limited reports whether the policy limits the queries without limit.
+func (p Pagination) Limited() bool {
+	return p.DefaultLimit > 0 || p.MaxLimit > 0
+}
+
+// limit returns the limit of a query given limit, which is not positive when the query has no limit.
+func (p Pagination) limit(limit int) int {
+	if limit <= 0 && p.DefaultLimit > 0 {
+		limit = p.DefaultLimit
+	}
+
+	if p.MaxLimit > 0 && (limit <= 0 || limit > p.MaxLimit) {
+		limit = p.MaxLimit
+	}
+
+	return limit
+}
+
+// checkOffset reports ErrMaxOffsetExceeded on tx if offset exceeds the maximum. The offset usually comes from
+// the request of a client, so the error is returned by the operation even in strict mode.
+func (p Pagination) checkOffset(tx *gorm.DB, offset int) bool {
+	if p.MaxOffset > 0 && offset > p.MaxOffset {
+		_ = tx.AddError(errors.Wrapf(ErrMaxOffsetExceeded, "offset %d is greater than %d", offset, p.MaxOffset))
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,168 @@
+package gormquery
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/infevocorp/goflexstore/query"
+)
+
+// maxShapeCacheSize bounds the number of shapes cached by a ScopeBuilder,
+// since the selected or ordered fields may come from client input.
+const maxShapeCacheSize = 1024
+
+// ShapeCacheStats holds the metrics of the shape cache of a ScopeBuilder, see ScopeBuilder.ShapeCacheStats.
+type ShapeCacheStats struct {
+	// Hits is the number of builds that reused the scopes of a cached shape.
+	Hits uint64
+	// Misses is the number of builds of a shape that was not cached yet.
+	Misses uint64
+	// Size is the number of cached shapes.
+	Size int
+}
+
+// ShapeCacheStats returns the hit and miss counts of the shape cache, e.g. to export them as metrics.
+//
+// Build caches the scopes of the parameters that do not depend on values, such as Select, OrderBy or Preload,
+// by structural shape of the params: the types of the parameters, in order, and the content of the cached ones.
+// The params of hot endpoints only differing by their filter values or pagination reuse the cached scopes.
+// The cache is never invalidated, so the Registry and CustomFilters must not change once the builder is in use.
+func (b *ScopeBuilder) ShapeCacheStats() ShapeCacheStats {
+	return ShapeCacheStats{
+		Hits:   b.shapeHits.Load(),
+		Misses: b.shapeMisses.Load(),
+		Size:   b.shapesLen(),
+	}
+}
+
+// shapesLen returns the number of cached shapes.
+func (b *ScopeBuilder) shapesLen() int {
+	b.shapesMu.RLock()
+	defer b.shapesMu.RUnlock()
+
+	return len(b.shapes)
+}
+
+// shapeBuffers pools the buffers the shapes are written to.
+var shapeBuffers = sync.Pool{
+	New: func() any {
+		buf := make([]byte, 0, 256)
+
+		return &buf
+	},
+}
+
+// shapeScopes returns the cached scopes of the shape of params, building them on a miss.
+func (b *ScopeBuilder) shapeScopes(params query.Params) []templateScope {
+	buf := shapeBuffers.Get().(*[]byte)
+	defer shapeBuffers.Put(buf)
+
+	*buf = appendShape((*buf)[:0], params)
+
+	b.shapesMu.RLock()
+	cached, ok := b.shapes[string(*buf)]
+	b.shapesMu.RUnlock()
+
+	if ok {
+		b.shapeHits.Add(1)
+
+		return cached
+	}
+
+	b.shapeMisses.Add(1)
+
+	cached = make([]templateScope, params.Len())
+
+	for i := range cached {
+		param := params.At(i)
+
+		if !isShapeStatic(param) {
+			continue
+		}
+
+		cached[i].static = true
+
+		if builder, ok := b.Registry[param.ParamType()]; ok {
+			cached[i].scope = builder(param)
+		}
+	}
+
+	b.shapesMu.Lock()
+	defer b.shapesMu.Unlock()
+
+	if actual, ok := b.shapes[string(*buf)]; ok {
+		return actual
+	}
+
+	if len(b.shapes) < maxShapeCacheSize {
+		if b.shapes == nil {
+			b.shapes = make(map[string][]templateScope)
+		}
+
+		b.shapes[string(*buf)] = cached
+	}
+
+	return cached
+}
+
+// appendShape appends the structural shape of params to buf: the type of every parameter and the content of
+// the parameters whose scope is cached, see isShapeStatic.
+func appendShape(buf []byte, params query.Params) []byte {
+	for i := 0; i < params.Len(); i++ {
+		param := params.At(i)
+		buf = append(buf, param.ParamType()...)
+
+		switch p := param.(type) {
+		case query.SelectParam:
+			buf = appendNames(buf, p.Names)
+		case query.OrderByParam:
+			buf = append(buf, ' ')
+			buf = append(buf, p.Name...)
+			buf = strconv.AppendBool(append(buf, ' '), p.Desc)
+		case query.PreloadParam:
+			if len(p.Params) == 0 {
+				buf = append(buf, ' ')
+				buf = append(buf, p.Name...)
+			}
+		case query.PreloadAllParam:
+			buf = appendNames(buf, p.Except)
+		case query.WithLockParam:
+			buf = strconv.AppendInt(append(buf, ' '), int64(p.LockType), 10)
+		case query.CollateParam:
+			buf = appendNames(buf, []string{p.Name, p.Collation})
+		case query.GroupByParam:
+			if len(p.Having) == 0 {
+				buf = strconv.AppendQuote(append(appendNames(buf, p.Names), ' '), p.Option)
+			}
+		}
+
+		buf = append(buf, ';')
+	}
+
+	return buf
+}
+
+// appendNames appends the quoted names to buf, so that names containing separators cannot collide.
+func appendNames(buf []byte, names []string) []byte {
+	for _, name := range names {
+		buf = strconv.AppendQuote(append(buf, ' '), name)
+	}
+
+	return buf
+}
+
+// isShapeStatic reports whether the scope of param only depends on the content written by appendShape, and not
+// on the values that vary between executions of the same query, such as filter values or pagination.
+func isShapeStatic(param query.Param) bool {
+	switch p := param.(type) {
+	case query.SelectParam, query.OrderByParam, query.PreloadAllParam, query.WithLockParam, query.CollateParam,
+		query.UnscopedParam:
+		return true
+	case query.PreloadParam:
+		return len(p.Params) == 0
+	case query.GroupByParam:
+		return len(p.Having) == 0
+	default:
+		return false
+	}
+}
@@ -3,98 +3,218 @@ package gormquery
 
 import (
 	"reflect"
-	"strings"
 
 	"github.com/pkg/errors"
 
 	"github.com/infevocorp/goflexstore/query"
 )
 
+// maxWhereCacheSize bounds the number of WHERE clause strings cached by a ScopeBuilder,
+// since filter names may come from client input.
+const maxWhereCacheSize = 1024
+
+// operatorStrings holds the SQL operator of each query.Operator.
+var operatorStrings = [...]string{
+	query.EQ:    "=",
+	query.NEQ:   "<>",
+	query.GT:    ">",
+	query.GTE:   ">=",
+	query.LT:    "<",
+	query.LTE:   "<=",
+	query.LIKE:  "LIKE",
+	query.ILIKE: "ILIKE",
+}
+
+// inOperatorStrings holds the SQL IN operator of the query.Operators that support it.
+var inOperatorStrings = [...]string{
+	query.EQ:  "IN",
+	query.NEQ: "NOT IN",
+}
+
+// noValue is the value of the WHERE clauses without placeholder, such as the constant conditions of the IN and
+// NOTIN filters with an empty collection. It must not be bound, see whereArgs.
+type noValue struct{}
+
+// whereArgs returns the arguments binding the value of a WHERE clause.
+func whereArgs(value any) []any {
+	if _, ok := value.(noValue); ok {
+		return nil
+	}
+
+	return []any{value}
+}
+
+// whereKey identifies a cached WHERE clause string.
+type whereKey struct {
+	col     string
+	op      query.Operator
+	in      bool
+	dialect string
+}
+
 // buildWhere constructs a GORM-compatible WHERE clause based on the provided field name, operator, and value.
 // It supports handling both singular and collection types and constructs the appropriate query string.
-// It panics if the provided value is nil to prevent runtime errors.
-func buildWhere(fieldName string, operator query.Operator, value any) (string, any) {
+// It returns an error wrapping ErrInvalidParam if the provided value is nil or an empty collection,
+// or if the operator cannot be used with a collection.
+//
+// The IN and NOTIN operators require a collection. When it is empty, the clause is the constant condition
+// `1 = 0`, respectively `1 = 1`, and the value is noValue.
+//
+// The ILIKE operator is rendered as is for PostgreSQL, and as `LOWER(col) LIKE LOWER(?)` for the other dialects,
+// named as by gorm.Dialector.Name.
+//
+// The clause strings only depend on the column, the operator, the dialect and whether the value is a collection,
+// so they are cached and reused across executions of queries only differing by their values.
+func (b *ScopeBuilder) buildWhere(
+	fieldName, dialect string,
+	operator query.Operator,
+	value any,
+) (string, any, error) {
+	return b.buildWhereAs(fieldName, fieldName, dialect, operator, value)
+}
+
+// buildWhereAs is buildWhere rendering the column as col, while errors refer to the field by fieldName.
+func (b *ScopeBuilder) buildWhereAs(
+	fieldName, col, dialect string,
+	operator query.Operator,
+	value any,
+) (string, any, error) {
 	if value == nil {
-		panic("value cannot be nil")
+		return "", nil, errors.Wrapf(ErrInvalidParam, "value of %s cannot be nil", fieldName)
 	}
 
 	var (
 		valOf = reflect.ValueOf(value)
-		kind  = valOf.Type().Kind()
+		kind  = valOf.Kind()
 	)
 
+	if operator == query.IN || operator == query.NOTIN {
+		return b.buildWhereIn(fieldName, col, dialect, operator, valOf)
+	}
+
 	// Handle collection types (Slice or Array) to build a WHERE IN clause if necessary.
 	if kind == reflect.Slice || kind == reflect.Array {
 		n := valOf.Len()
 
+		// An empty collection cannot be compared to.
+		if n == 0 {
+			return "", nil, errors.Wrapf(ErrInvalidParam, "value of %s cannot be empty", fieldName)
+		}
+
 		// For multiple items, build a WHERE IN clause.
 		if n > 1 {
-			return buildWhereInStr(fieldName, operator), value
+			where, err := b.whereStr(col, dialect, operator, true)
+
+			return where, value, err
 		}
 
 		// For a single item, revert to standard WHERE clause.
-		return buildWhereStr(fieldName, operator), valOf.Index(0).Interface()
+		value = valOf.Index(0).Interface()
+	}
+
+	where, err := b.whereStr(col, dialect, operator, false)
+
+	return where, value, err
+}
+
+// buildWhereIn builds the WHERE clause of an IN or NOTIN filter, as the one of an EQ or NEQ filter with
+// a collection unless the collection is empty.
+func (b *ScopeBuilder) buildWhereIn(
+	fieldName, col, dialect string,
+	operator query.Operator,
+	valOf reflect.Value,
+) (string, any, error) {
+	if kind := valOf.Kind(); kind != reflect.Slice && kind != reflect.Array {
+		return "", nil, errors.Wrapf(ErrInvalidParam, "value of %s must be a collection with %s", fieldName, operator)
+	}
+
+	if valOf.Len() == 0 {
+		if operator == query.IN {
+			return "1 = 0", noValue{}, nil
+		}
+
+		return "1 = 1", noValue{}, nil
+	}
+
+	if operator == query.IN {
+		return b.buildWhereAs(fieldName, col, dialect, query.EQ, valOf.Interface())
 	}
 
-	// For non-collection types, build a standard WHERE clause.
-	return buildWhereStr(fieldName, operator), value
+	return b.buildWhereAs(fieldName, col, dialect, query.NEQ, valOf.Interface())
+}
+
+// whereStr returns the WHERE clause string for the column and operator, building it on cache miss.
+func (b *ScopeBuilder) whereStr(col, dialect string, op query.Operator, in bool) (string, error) {
+	key := whereKey{col: col, op: op, in: in, dialect: dialect}
+
+	if where, ok := b.whereCache.Load(key); ok {
+		return where.(string), nil
+	}
+
+	var (
+		where string
+		err   error
+	)
+
+	if in {
+		where, err = buildWhereInStr(col, op)
+	} else {
+		where, err = buildWhereStr(col, dialect, op)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	if b.whereCacheSize.Load() < maxWhereCacheSize {
+		if _, loaded := b.whereCache.LoadOrStore(key, where); !loaded {
+			b.whereCacheSize.Add(1)
+		}
+	}
+
+	return where, nil
 }
 
 // buildWhereStr constructs a standard SQL WHERE clause string using the given field name and operator.
-func buildWhereStr(fieldName string, operator query.Operator) string {
-	var sb strings.Builder
+func buildWhereStr(fieldName, dialect string, operator query.Operator) (string, error) {
+	if operator == query.ILIKE && dialect != "postgres" {
+		return "LOWER(" + fieldName + ") LIKE LOWER(?)", nil
+	}
 
-	// Construct the WHERE clause.
-	sb.WriteString(fieldName)
-	sb.WriteRune(' ')
-	sb.WriteString(operatorToString(operator))
-	sb.WriteString(" ?")
+	op, err := operatorToString(operator)
+	if err != nil {
+		return "", err
+	}
 
-	return sb.String()
+	return fieldName + " " + op + " ?", nil
 }
 
 // buildWhereInStr constructs a SQL WHERE IN clause string for handling collection types.
-func buildWhereInStr(fieldName string, op query.Operator) string {
-	var sb strings.Builder
-
-	// Construct the WHERE IN clause.
-	sb.WriteString(fieldName)
-	sb.WriteRune(' ')
-	sb.WriteString(inOperatorToString(op))
-	sb.WriteString(" (?)")
+func buildWhereInStr(fieldName string, op query.Operator) (string, error) {
+	inOp, err := inOperatorToString(op)
+	if err != nil {
+		return "", err
+	}
 
-	return sb.String()
+	return fieldName + " " + inOp + " (?)", nil
 }
 
 // operatorToString converts a query.Operator to its equivalent SQL operator string.
-func operatorToString(op query.Operator) string {
-	switch op {
-	case query.EQ:
-		return "="
-	case query.NEQ:
-		return "<>"
-	case query.GT:
-		return ">"
-	case query.GTE:
-		return ">="
-	case query.LT:
-		return "<"
-	case query.LTE:
-		return "<="
-	default:
-		return "UNKNOWN"
+// It returns an error wrapping ErrInvalidParam for unknown operators.
+func operatorToString(op query.Operator) (string, error) {
+	if int(op) < len(operatorStrings) {
+		return operatorStrings[op], nil
 	}
+
+	return "", errors.Wrapf(ErrInvalidParam, "%s is unsupported operator", op.String())
 }
 
 // inOperatorToString converts a query.Operator to its equivalent SQL IN operator string.
-// It supports only the EQ and NEQ operators, defaulting to "UNKNOWN" for others.
-func inOperatorToString(op query.Operator) string {
-	switch op {
-	case query.EQ:
-		return "IN"
-	case query.NEQ:
-		return "NOT IN"
-	default:
-		panic(errors.Errorf("%s is unsupported operator for IN clause", op.String()))
+// It supports only the EQ and NEQ operators and returns an error wrapping ErrInvalidParam for others.
+func inOperatorToString(op query.Operator) (string, error) {
+	if int(op) < len(inOperatorStrings) {
+		return inOperatorStrings[op], nil
 	}
+
+	return "", errors.Wrapf(ErrInvalidParam, "%s is unsupported operator for IN clause", op.String())
 }
@@ -0,0 +1,92 @@
+package gormquery
+
+import (
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/infevocorp/goflexstore/query"
+)
+
+// Temporal is the temporal table configuration of a ScopeBuilder, telling the as of params where the past
+// states of the rows are, see WithTemporal. The zero value disables the as of params.
+//
+// Fields:
+//   - SystemVersioned: Whether the table is system-versioned, as supported by MariaDB and SQL Server: the past
+//     states are read with a FOR SYSTEM_TIME AS OF clause.
+//   - HistoryTable: The table holding the past versions of the rows, with the same columns as the table, e.g.
+//     filled by triggers on PostgreSQL. Both tables hold the validity period of the rows, whose end is only
+//     read from the history table.
+//   - ValidFrom: The column holding the start of the validity period of the rows, "valid_from" by default.
+//   - ValidTo: The column of the history table holding the end of the validity period, "valid_to" by default.
+type Temporal struct {
+	SystemVersioned bool
+	HistoryTable    string
+	ValidFrom       string
+	ValidTo         string
+}
+
+// enabled reports whether the configuration supports the as of params.
+func (t Temporal) enabled() bool {
+	return t.SystemVersioned || t.HistoryTable != ""
+}
+
+// columns returns the columns of the validity period of the rows.
+func (t Temporal) columns() (validFrom, validTo clause.Column) {
+	validFrom, validTo = clause.Column{Name: "valid_from"}, clause.Column{Name: "valid_to"}
+
+	if t.ValidFrom != "" {
+		validFrom.Name = t.ValidFrom
+	}
+
+	if t.ValidTo != "" {
+		validTo.Name = t.ValidTo
+	}
+
+	return validFrom, validTo
+}
+
+// AsOf constructs a GORM scope for an as of query parameter.
+// The main query reads from the state of the table as of the time of the param, aliased as the table of the
+// model so that the other scopes apply unchanged. For system-versioned tables:
+//
+//	SELECT * FROM articles FOR SYSTEM_TIME AS OF ?
+//
+// Otherwise, from the rows of the table and of its history table valid at the time:
+//
+//	SELECT * FROM (
+//		SELECT * FROM articles WHERE valid_from <= ?
+//		UNION ALL
+//		SELECT * FROM articles_history WHERE valid_from <= ? AND valid_to > ?
+//	) AS articles
+//
+// It reports an error wrapping ErrInvalidParam when the builder has no temporal configuration.
+func (b *ScopeBuilder) AsOf(param query.Param) ScopeFunc {
+	var (
+		p                  = param.(query.AsOfParam)
+		temporal           = b.Temporal
+		validFrom, validTo = temporal.columns()
+	)
+
+	return func(tx *gorm.DB) *gorm.DB {
+		if !temporal.enabled() {
+			return fail(tx, errors.Wrap(ErrInvalidParam, "as of params require a temporal configuration"))
+		}
+
+		table, ok := replaceableTable(tx)
+		if !ok {
+			return tx
+		}
+
+		if temporal.SystemVersioned {
+			return tx.Table("? FOR SYSTEM_TIME AS OF ?", clause.Table{Name: table}, p.Time)
+		}
+
+		return tx.Table(
+			"(SELECT * FROM ? WHERE ? <= ? UNION ALL SELECT * FROM ? WHERE ? <= ? AND ? > ?) AS ?",
+			clause.Table{Name: table}, validFrom, p.Time,
+			clause.Table{Name: temporal.HistoryTable}, validFrom, p.Time, validTo, p.Time,
+			clause.Table{Name: table},
+		)
+	}
+}
@@ -0,0 +1,119 @@
+package gormstore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm/clause"
+
+	gormquery "github.com/infevocorp/goflexstore/gorm/query"
+	"github.com/infevocorp/goflexstore/query"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// Aggregate computes the aggregate function over the column of the field of the entities matching the params,
+// see AggregateMany.
+func (s *Store[Entity, DTO, ID]) Aggregate(
+	ctx context.Context,
+	agg store.Aggregation,
+	field string,
+	params ...query.Param,
+) (float64, error) {
+	results, err := s.AggregateMany(ctx, []store.Aggregate{{Aggregation: agg, Field: field}}, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return results[0], nil
+}
+
+// AggregateMany computes the aggregates of the entities matching the params in a single
+// SELECT SUM(col) AS a0, MAX(col) AS a1... query. The OrderBy, Paginate, Cursor, Select and Preload params are
+// ignored, and a NULL aggregate, e.g. the sum of no row, is returned as 0. An empty field counts the rows.
+func (s *Store[Entity, DTO, ID]) AggregateMany(
+	ctx context.Context,
+	aggs []store.Aggregate,
+	params ...query.Param,
+) ([]float64, error) {
+	if len(aggs) == 0 {
+		return nil, fmt.Errorf("%w: no aggregate", gormquery.ErrInvalidParam)
+	}
+
+	var filterParams []query.Param
+
+	for _, param := range params {
+		switch param.(type) {
+		case query.OrderByParam, query.PaginateParam, query.CursorParam, query.SelectParam,
+			query.PreloadParam, query.PreloadAllParam:
+		default:
+			filterParams = append(filterParams, param)
+		}
+	}
+
+	queryParams := query.NewParams(filterParams...)
+	if err := s.requirePartitionKey(queryParams); err != nil {
+		return nil, err
+	}
+
+	var (
+		selects = make([]string, len(aggs))
+		columns = make([]any, 0, len(aggs))
+	)
+
+	for i, agg := range aggs {
+		if !agg.Aggregation.Valid() {
+			return nil, fmt.Errorf("%w: unsupported aggregation %s", gormquery.ErrInvalidParam, agg.Aggregation)
+		}
+
+		if agg.Field == "" {
+			selects[i] = fmt.Sprintf("%s(*) AS a%d", agg.Aggregation, i)
+			continue
+		}
+
+		col := agg.Field
+		if c, ok := s.ScopeBuilder.FieldToColMap[col]; ok {
+			col = c
+		}
+
+		selects[i] = fmt.Sprintf("%s(?) AS a%d", agg.Aggregation, i)
+		columns = append(columns, clause.Column{Name: col})
+	}
+
+	tx := s.getReadTx(ctx, queryParams).Scopes(s.ScopeBuilder.Build(queryParams)...)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	values := make([]sql.NullFloat64, len(aggs))
+	dest := make([]any, len(aggs))
+
+	for i := range values {
+		dest[i] = &values[i]
+	}
+
+	tx = tx.Select(strings.Join(selects, ", "), columns...)
+
+	row := tx.Row()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	// a dry run only records the statement, without row.
+	if row == nil {
+		return make([]float64, len(aggs)), nil
+	}
+
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	results := make([]float64, len(aggs))
+
+	for i, value := range values {
+		results[i] = value.Float64
+	}
+
+	return results, nil
+}
@@ -0,0 +1,67 @@
+package gormstore
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	gormquery "github.com/infevocorp/goflexstore/gorm/query"
+	"github.com/infevocorp/goflexstore/query"
+)
+
+// Increment adds delta to the column of the field of every entity matching the query parameters, in a single
+// UPDATE ... SET col = col + ? statement, so that concurrent increments do not override each other like a read
+// followed by an Update does. The field name is mapped to its column like the filters. GORM refuses to update
+// without conditions, so incrementing every row requires a condition such as query.Raw("1 = 1").
+//
+// Example:
+//
+//	err := articleStore.Increment(ctx, "Views", 1, query.Filter("ID", id))
+func (s *Store[Entity, DTO, ID]) Increment(
+	ctx context.Context,
+	field string,
+	delta int64,
+	params ...query.Param,
+) error {
+	queryParams := query.NewParams(params...)
+	if err := s.requirePartitionKey(queryParams); err != nil {
+		return err
+	}
+
+	if field == "" {
+		return fmt.Errorf("%w: no field to increment", gormquery.ErrInvalidParam)
+	}
+
+	col := field
+	if c, ok := s.ScopeBuilder.FieldToColMap[field]; ok {
+		col = c
+	}
+
+	tx := s.getTx(ctx).Scopes(s.ScopeBuilder.Build(queryParams)...)
+
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	return s.ErrorTranslator(tx.Update(col, gorm.Expr("? + ?", clause.Column{Name: col}, delta)).Error)
+}
+
+// Decrement subtracts delta from the column of the field of every entity matching the query parameters,
+// see Increment.
+//
+// Example:
+//
+//	err := productStore.Decrement(ctx, "Stock", quantity,
+//		query.Filter("ID", productID),
+//		query.Filter("Stock", quantity).WithOP(query.GTE),
+//	)
+func (s *Store[Entity, DTO, ID]) Decrement(
+	ctx context.Context,
+	field string,
+	delta int64,
+	params ...query.Param,
+) error {
+	return s.Increment(ctx, field, -delta, params...)
+}
@@ -0,0 +1,104 @@
+package gormstore
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Statement is a SQL statement built by an operation of a Store in a dry run, see DryRun.
+//
+// Fields:
+//   - SQL: The statement, with the placeholders of the dialect, e.g. "SELECT * FROM `users` WHERE name = ?".
+//   - Vars: The values bound to the placeholders.
+type Statement struct {
+	SQL  string
+	Vars []any
+}
+
+// Recorder collects the statements built by the operations of the stores in a dry run, see DryRun.
+// It is safe for concurrent use.
+type Recorder struct {
+	mu         sync.Mutex
+	statements []Statement
+}
+
+// Statements returns the statements recorded so far, in the order they were built.
+func (r *Recorder) Statements() []Statement {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Statement(nil), r.statements...)
+}
+
+func (r *Recorder) record(stmt Statement) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.statements = append(r.statements, stmt)
+}
+
+// dryRunKey is the context key of the Recorder of a dry run.
+type dryRunKey struct{}
+
+// DryRun returns a context making the operations of the stores build their SQL statements without executing
+// them, and the Recorder collecting the statements. It is meant to preview or verify the statements of
+// operations in tooling, tests or admin features, without touching the database.
+//
+// Since nothing is executed, reads return no entity and no error, and writes return zero IDs. The operations
+// selecting rows before writing them, such as deletes in cascade, only record their SELECT statement.
+//
+// Example:
+//
+//	ctx, recorder := gormstore.DryRun(ctx)
+//	err := articleStore.Delete(ctx, query.Filter("AuthorID", authorID))
+//	for _, stmt := range recorder.Statements() {
+//		fmt.Println(stmt.SQL, stmt.Vars)
+//	}
+func DryRun(ctx context.Context) (context.Context, *Recorder) {
+	recorder := &Recorder{}
+
+	return context.WithValue(ctx, dryRunKey{}, recorder), recorder
+}
+
+// dryRunSession returns a GORM DryRun session of the database recording its statements, or the database
+// itself if the context is not a dry run one.
+func dryRunSession(ctx context.Context, db *gorm.DB) *gorm.DB {
+	recorder, ok := ctx.Value(dryRunKey{}).(*Recorder)
+	if !ok {
+		return db
+	}
+
+	return db.Session(&gorm.Session{
+		DryRun: true,
+		Logger: &dryRunLogger{Interface: db.Logger, recorder: recorder},
+	})
+}
+
+// dryRunLogger records the statements traced by GORM.
+// GORM passes the statement and its values to ParamsFilter when Trace evaluates the statement to log,
+// so Trace evaluates it even though nothing is logged.
+type dryRunLogger struct {
+	logger.Interface
+	recorder *Recorder
+	sql      string
+	vars     []any
+}
+
+func (l *dryRunLogger) LogMode(logger.LogLevel) logger.Interface {
+	return l
+}
+
+func (l *dryRunLogger) ParamsFilter(_ context.Context, sql string, params ...any) (string, []any) {
+	l.sql, l.vars = sql, params
+
+	return sql, params
+}
+
+func (l *dryRunLogger) Trace(_ context.Context, _ time.Time, fc func() (string, int64), _ error) {
+	fc()
+	l.recorder.record(Statement{SQL: l.sql, Vars: l.vars})
+}
@@ -0,0 +1,27 @@
+package gormstore
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/infevocorp/goflexstore/store"
+	"github.com/infevocorp/goflexstore/storeerrors"
+)
+
+// TranslateError is the default error translator of the stores, translating the constraint violations of the writes
+// to a store.ConstraintError with storeerrors.Translate.
+// The gorm.ErrDuplicatedKey and gorm.ErrForeignKeyViolated errors, returned instead of the driver errors when the
+// gorm.DB is opened with TranslateError, are translated too, without constraint name.
+func TranslateError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return &store.ConstraintError{Kind: store.ErrDuplicateKey, Err: err}
+	case errors.Is(err, gorm.ErrForeignKeyViolated):
+		return &store.ConstraintError{Kind: store.ErrForeignKeyViolation, Err: err}
+	default:
+		return storeerrors.Translate(err)
+	}
+}
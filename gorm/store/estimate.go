@@ -0,0 +1,295 @@
+package gormstore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+
+	"github.com/infevocorp/goflexstore/query"
+)
+
+// CountEstimate returns an estimate of the number of entities matching the params, read from the statistics of
+// the query planner instead of counting the rows, which takes seconds on tables of millions of rows. It is meant
+// for the totals of paginated lists, where an approximate count is enough.
+//
+// The count is estimated on PostgreSQL and MySQL when the params only filter on indexed columns, i.e. the primary
+// key or the first column of an index declared in the gorm tags of the DTO, so that the planner statistics are
+// relevant:
+//   - Without filter, it is the number of rows of the table, from pg_class.reltuples on PostgreSQL and
+//     information_schema.TABLES on MySQL.
+//   - With filters, it is the number of rows estimated by the plan of the query, as reported by EXPLAIN.
+//
+// The OrderBy, Paginate, Select, Preload and WithLock params are ignored. Otherwise, and on other dialects, or
+// when the table was never analyzed, CountEstimate returns the exact count of Count.
+func (s *Store[Entity, DTO, ID]) CountEstimate(ctx context.Context, params ...query.Param) (int64, error) {
+	queryParams := query.NewParams(params...)
+	if err := s.requirePartitionKey(queryParams); err != nil {
+		return 0, err
+	}
+
+	if _, dryRun := ctx.Value(dryRunKey{}).(*Recorder); dryRun {
+		return s.Count(ctx, params...)
+	}
+
+	db := s.getTx(ctx)
+
+	switch db.Dialector.Name() {
+	case "postgres", "mysql":
+	default:
+		return s.Count(ctx, params...)
+	}
+
+	filtered, ok, err := s.estimable(db, queryParams)
+	if err != nil {
+		return 0, err
+	}
+
+	if !ok {
+		return s.Count(ctx, params...)
+	}
+
+	var (
+		estimate int64
+		known    bool
+	)
+
+	if filtered {
+		estimate, known, err = s.explainRows(ctx, db, queryParams)
+	} else {
+		estimate, known, err = tableRows(ctx, db)
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	if !known {
+		return s.Count(ctx, params...)
+	}
+
+	return estimate, nil
+}
+
+// estimable reports whether the count of the params can be estimated, and whether they filter the rows.
+func (s *Store[Entity, DTO, ID]) estimable(db *gorm.DB, params query.Params) (filtered, ok bool, err error) {
+	if err := db.Statement.Parse(new(DTO)); err != nil {
+		return false, false, err
+	}
+
+	indexed := indexedColumns(db.Statement.Schema)
+
+	isIndexed := func(name string) bool {
+		if _, custom := s.ScopeBuilder.CustomFilters[name]; custom {
+			return false
+		}
+
+		col := name
+		if mapped, ok := s.ScopeBuilder.FieldToColMap[name]; ok {
+			col = mapped
+		}
+
+		if field := db.Statement.Schema.LookUpField(col); field != nil {
+			col = field.DBName
+		}
+
+		return indexed[col]
+	}
+
+	for _, param := range params.Params() {
+		switch p := param.(type) {
+		case query.FilterParam:
+			if !isIndexed(p.Name) {
+				return false, false, nil
+			}
+
+			filtered = true
+		case query.ORParam:
+			for _, filter := range p.Params {
+				if !isIndexed(filter.Name) {
+					return false, false, nil
+				}
+			}
+
+			filtered = true
+		case query.GroupParam, query.NotParam:
+			for _, filter := range p.(interface{ Filters() []query.FilterParam }).Filters() {
+				if !isIndexed(filter.Name) {
+					return false, false, nil
+				}
+			}
+
+			filtered = true
+		case query.OrderByParam, query.PaginateParam, query.SelectParam, query.PreloadParam, query.PreloadAllParam,
+			query.WithLockParam:
+		default:
+			return false, false, nil
+		}
+	}
+
+	return filtered, true, nil
+}
+
+// indexedColumns returns the columns of the primary key and the first columns of the indexes of the schema.
+func indexedColumns(sch *schema.Schema) map[string]bool {
+	indexed := make(map[string]bool)
+
+	for _, field := range sch.PrimaryFields {
+		indexed[field.DBName] = true
+	}
+
+	for _, index := range sch.ParseIndexes() {
+		if len(index.Fields) > 0 && index.Fields[0].Field != nil {
+			indexed[index.Fields[0].DBName] = true
+		}
+	}
+
+	return indexed
+}
+
+// tableRows returns the number of rows of the table estimated by the statistics of the database. It returns
+// false when the statistics are unknown.
+func tableRows(ctx context.Context, db *gorm.DB) (int64, bool, error) {
+	var (
+		rows  sql.NullFloat64
+		table = db.Statement.Table
+		stmt  string
+	)
+
+	switch db.Dialector.Name() {
+	case "postgres":
+		stmt = "SELECT reltuples FROM pg_class WHERE oid = to_regclass(?)"
+	default:
+		stmt = "SELECT TABLE_ROWS FROM information_schema.TABLES WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?"
+	}
+
+	err := db.Statement.ConnPool.QueryRowContext(ctx, stmt, table).Scan(&rows)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+
+	if err != nil {
+		return 0, false, fmt.Errorf("estimate rows of %s: %w", table, err)
+	}
+
+	// PostgreSQL reports -1 rows for the tables never vacuumed nor analyzed.
+	if !rows.Valid || rows.Float64 < 0 {
+		return 0, false, nil
+	}
+
+	return int64(rows.Float64), true, nil
+}
+
+// explainRows returns the number of rows the plan of the query filtering by the params estimates.
+// It returns false when the plan has no estimate.
+func (s *Store[Entity, DTO, ID]) explainRows(
+	ctx context.Context,
+	db *gorm.DB,
+	params query.Params,
+) (int64, bool, error) {
+	filters := make([]query.Param, 0, params.Len())
+
+	for _, param := range params.Params() {
+		switch param.(type) {
+		case query.FilterParam, query.ORParam, query.GroupParam, query.NotParam:
+			filters = append(filters, param)
+		}
+	}
+
+	tx := db.Session(&gorm.Session{DryRun: true}).
+		Scopes(s.ScopeBuilder.Build(query.NewParams(filters...))...).
+		Find(&[]DTO{})
+	if tx.Error != nil {
+		return 0, false, tx.Error
+	}
+
+	if db.Dialector.Name() == "postgres" {
+		return explainJSONRows(ctx, db, "EXPLAIN (FORMAT JSON) "+tx.Statement.SQL.String(), tx.Statement.Vars)
+	}
+
+	return explainTableRows(ctx, db, "EXPLAIN "+tx.Statement.SQL.String(), tx.Statement.Vars)
+}
+
+// explainJSONRows returns the "Plan Rows" of the root node of a PostgreSQL plan in the JSON format.
+func explainJSONRows(ctx context.Context, db *gorm.DB, stmt string, vars []any) (int64, bool, error) {
+	var raw []byte
+	if err := db.Statement.ConnPool.QueryRowContext(ctx, stmt, vars...).Scan(&raw); err != nil {
+		return 0, false, err
+	}
+
+	var plans []struct {
+		Plan struct {
+			Rows *float64 `json:"Plan Rows"`
+		}
+	}
+
+	if err := json.Unmarshal(raw, &plans); err != nil {
+		return 0, false, fmt.Errorf("parse plan: %w", err)
+	}
+
+	if len(plans) == 0 || plans[0].Plan.Rows == nil {
+		return 0, false, nil
+	}
+
+	return int64(*plans[0].Plan.Rows), true, nil
+}
+
+// explainTableRows returns the rows of a MySQL plan, weighted by the percentage of rows filtered by the
+// conditions of the query.
+func explainTableRows(ctx context.Context, db *gorm.DB, stmt string, vars []any) (int64, bool, error) {
+	rows, err := db.Statement.ConnPool.QueryContext(ctx, stmt, vars...)
+	if err != nil {
+		return 0, false, err
+	}
+
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, false, err
+	}
+
+	if !rows.Next() {
+		return 0, false, rows.Err()
+	}
+
+	var (
+		values = make([]sql.RawBytes, len(columns))
+		ptrs   = make([]any, len(columns))
+	)
+
+	for i := range values {
+		ptrs[i] = &values[i]
+	}
+
+	if err := rows.Scan(ptrs...); err != nil {
+		return 0, false, err
+	}
+
+	estimate, filtered := -1.0, 100.0
+
+	for i, column := range columns {
+		value, err := strconv.ParseFloat(string(values[i]), 64)
+		if err != nil {
+			continue
+		}
+
+		switch column {
+		case "rows":
+			estimate = value
+		case "filtered":
+			filtered = value
+		}
+	}
+
+	if estimate < 0 {
+		return 0, false, nil
+	}
+
+	return int64(estimate * filtered / 100), true, nil
+}
@@ -0,0 +1,119 @@
+package gormstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/infevocorp/goflexstore/query"
+)
+
+// ErrAnalyzeUnsupported is returned by ExplainAnalyze when the dialect cannot analyze the plan of a query.
+var ErrAnalyzeUnsupported = errors.New("explain analyze is not supported by the dialect")
+
+// Explain returns the execution plan of the query List runs with the params, as reported by EXPLAIN, so that
+// performance investigations do not require reconstructing the SQL by hand. The query is not executed.
+//
+// The format of the plan depends on the dialect: PostgreSQL reports one line per plan node, while MySQL and
+// SQLite report rows of columns, returned as tab-separated lines preceded by the column names.
+func (s *Store[Entity, DTO, ID]) Explain(ctx context.Context, params ...query.Param) (string, error) {
+	return s.explain(ctx, false, params)
+}
+
+// ExplainAnalyze is Explain running EXPLAIN ANALYZE, which executes the query and reports its actual row
+// counts and timings. It is supported by PostgreSQL and MySQL 8.0.18+, and returns ErrAnalyzeUnsupported
+// on other dialects.
+func (s *Store[Entity, DTO, ID]) ExplainAnalyze(ctx context.Context, params ...query.Param) (string, error) {
+	return s.explain(ctx, true, params)
+}
+
+func (s *Store[Entity, DTO, ID]) explain(ctx context.Context, analyze bool, params []query.Param) (string, error) {
+	db := s.OpScope.Tx(ctx).WithContext(ctx)
+
+	var explain string
+
+	switch name := db.Dialector.Name(); {
+	case name == "sqlite" && analyze:
+		return "", fmt.Errorf("%w: %s", ErrAnalyzeUnsupported, name)
+	case name == "sqlite":
+		explain = "EXPLAIN QUERY PLAN "
+	case analyze:
+		explain = "EXPLAIN ANALYZE "
+	default:
+		explain = "EXPLAIN "
+	}
+
+	// The statement of List is built by a dry run, then explained with its values bound by the driver,
+	// since it uses the placeholders of the dialect.
+	dryRunCtx, recorder := DryRun(ctx)
+	if _, err := s.List(dryRunCtx, params...); err != nil {
+		return "", err
+	}
+
+	statements := recorder.Statements()
+	if len(statements) == 0 {
+		return "", errors.New("no statement to explain")
+	}
+
+	stmt := statements[0]
+
+	rows, err := db.Statement.ConnPool.QueryContext(ctx, explain+stmt.SQL, stmt.Vars...)
+	if err != nil {
+		return "", err
+	}
+
+	defer rows.Close()
+
+	return formatPlan(rows)
+}
+
+// formatPlan formats the rows of an EXPLAIN statement: the values of single-column rows are returned one per
+// line, and the other rows as tab-separated lines preceded by the column names.
+func formatPlan(rows *sql.Rows) (string, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return "", err
+	}
+
+	var (
+		lines  []string
+		values = make([]any, len(columns))
+		ptrs   = make([]any, len(columns))
+		fields = make([]string, len(columns))
+	)
+
+	if len(columns) > 1 {
+		lines = append(lines, strings.Join(columns, "\t"))
+	}
+
+	for i := range values {
+		ptrs[i] = &values[i]
+	}
+
+	for rows.Next() {
+		if err := rows.Scan(ptrs...); err != nil {
+			return "", err
+		}
+
+		for i, value := range values {
+			switch v := value.(type) {
+			case nil:
+				fields[i] = "NULL"
+			case []byte:
+				fields[i] = string(v)
+			default:
+				fields[i] = fmt.Sprint(v)
+			}
+		}
+
+		lines = append(lines, strings.Join(fields, "\t"))
+	}
+
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
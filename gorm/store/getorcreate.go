@@ -0,0 +1,165 @@
+package gormstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	gormquery "github.com/infevocorp/goflexstore/gorm/query"
+	"github.com/infevocorp/goflexstore/query"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// GetOrCreate returns the entity matching the params, or creates the given entity if there is none.
+// It reports whether the entity was created, in which case the returned entity is the created one, with the ID and
+// the other values filled by the database.
+//
+// The lookup and the creation run within a transaction of the operation scope, the matching row being locked with
+// query.WithLock until the end of the transaction. The params should match the entity to create, and a unique index
+// on their columns is needed to prevent concurrent transactions from creating the entity twice: when the creation
+// violates it and ctx carries no transaction, the transaction is rolled back and the entity created concurrently
+// is returned instead. Within the transaction of the caller, the store.ErrDuplicateKey error is returned, since the
+// transaction cannot continue on every database.
+//
+// Example:
+//
+//	tag, created, err := tagStore.GetOrCreate(ctx, &model.Tag{Slug: slug}, query.Filter("Slug", slug))
+func (s *Store[Entity, DTO, ID]) GetOrCreate(
+	ctx context.Context,
+	entity Entity,
+	params ...query.Param,
+) (Entity, bool, error) {
+	return s.firstOrCreate(ctx, entity, params, func(_ context.Context, found Entity) (Entity, error) {
+		return found, nil
+	})
+}
+
+// UpdateOrCreate replaces the entity matching the params with the given entity, like Update does, or creates the
+// given entity if there is none. It reports whether the entity was created, and returns the updated or created
+// entity, with the ID of the replaced entity or the values filled by the database.
+//
+// The lookup and the write run within a transaction of the operation scope and are protected against concurrent
+// transactions like with GetOrCreate.
+//
+// Example:
+//
+//	setting, created, err := settingStore.UpdateOrCreate(ctx,
+//		&model.Setting{UserID: userID, Key: "theme", Value: "dark"},
+//		query.Filter("UserID", userID), query.Filter("Key", "theme"),
+//	)
+func (s *Store[Entity, DTO, ID]) UpdateOrCreate(
+	ctx context.Context,
+	entity Entity,
+	params ...query.Param,
+) (Entity, bool, error) {
+	return s.firstOrCreate(ctx, entity, params, func(ctx context.Context, found Entity) (Entity, error) {
+		updated, byKey, err := s.withPrimaryKey(ctx, entity, found)
+		if err != nil {
+			return *new(Entity), err
+		}
+
+		// the filters of the params keep the partition key of partitioned stores.
+		for _, param := range params {
+			if filter, ok := param.(query.FilterParam); ok {
+				byKey = append(byKey, filter)
+			}
+		}
+
+		return updated, s.Update(ctx, updated, byKey...)
+	})
+}
+
+// firstOrCreate calls onFound with the entity matching the params, locked for update, or creates the entity if
+// there is none. It retries once when the entity was created concurrently, unless ctx carries a transaction.
+func (s *Store[Entity, DTO, ID]) firstOrCreate(
+	ctx context.Context,
+	entity Entity,
+	params []query.Param,
+	onFound func(ctx context.Context, found Entity) (Entity, error),
+) (_ Entity, created bool, err error) {
+	defer recoverConversionError(&err)
+
+	if len(params) == 0 {
+		return *new(Entity), false, fmt.Errorf("%w: no param matching the entity", gormquery.ErrInvalidParam)
+	}
+
+	result, created, err := s.findOrCreate(ctx, entity, params, onFound)
+	if errors.Is(err, store.ErrDuplicateKey) && !s.OpScope.InTransaction(ctx) {
+		// the entity was created by a concurrent transaction after the lookup, which now finds it.
+		return s.findOrCreate(ctx, entity, params, onFound)
+	}
+
+	return result, created, err
+}
+
+// findOrCreate runs a single attempt of firstOrCreate within a transaction of the operation scope.
+func (s *Store[Entity, DTO, ID]) findOrCreate(
+	ctx context.Context,
+	entity Entity,
+	params []query.Param,
+	onFound func(ctx context.Context, found Entity) (Entity, error),
+) (_ Entity, created bool, err error) {
+	ctx, err = s.OpScope.Begin(ctx)
+	if err != nil {
+		return *new(Entity), false, err
+	}
+
+	defer s.OpScope.EndWithRecover(ctx, &err)
+
+	locked := make([]query.Param, 0, len(params)+1)
+	locked = append(locked, params...)
+	locked = append(locked, query.WithLock(query.LockTypeForUpdate))
+
+	found, err := s.Get(ctx, locked...)
+
+	switch {
+	case err == nil:
+		result, err := onFound(ctx, found)
+
+		return result, false, err
+	case !errors.Is(err, store.ErrNotFound):
+		return *new(Entity), false, err
+	}
+
+	dto, err := s.create(ctx, entity)
+	if err != nil {
+		return *new(Entity), false, err
+	}
+
+	return s.Converter.ToEntity(dto), true, nil
+}
+
+// withPrimaryKey returns a copy of the entity having the primary key of from, and the filters matching it.
+func (s *Store[Entity, DTO, ID]) withPrimaryKey(
+	ctx context.Context,
+	entity, from Entity,
+) (Entity, []query.Param, error) {
+	db := s.getTx(ctx)
+	if err := db.Statement.Parse(new(DTO)); err != nil {
+		return *new(Entity), nil, err
+	}
+
+	var (
+		dto = s.Converter.ToDTO(entity)
+		src = reflect.ValueOf(s.Converter.ToDTO(from))
+		dst = reflect.ValueOf(&dto).Elem()
+	)
+
+	if dst.Kind() == reflect.Pointer {
+		dst = dst.Elem()
+	}
+
+	byKey := make([]query.Param, 0, len(db.Statement.Schema.PrimaryFields))
+
+	for _, field := range db.Statement.Schema.PrimaryFields {
+		value, _ := field.ValueOf(ctx, src)
+		if err := field.Set(ctx, dst, value); err != nil {
+			return *new(Entity), nil, err
+		}
+
+		byKey = append(byKey, query.Filter(field.DBName, value))
+	}
+
+	return s.Converter.ToEntity(dto), byKey, nil
+}
@@ -0,0 +1,62 @@
+package gormstore
+
+import (
+	"context"
+
+	"github.com/infevocorp/goflexstore/query"
+)
+
+// Hooks are the functions run by a Store before and after its writes, see WithHooks, e.g. to validate or enrich
+// the entities, or to publish events. Every hook is optional.
+//
+// The before hooks run before the validation of the entities and may modify them. An error returned by a
+// before hook cancels the write, and an error returned by an after hook is returned by the write, which is
+// only rolled back if it runs within a transaction of the operation scope.
+//
+// Fields:
+//   - BeforeCreate, AfterCreate: Run with each entity written by Create, CreateMany and Upsert. The after hook
+//     gets the entities read back from the created rows, with their generated IDs.
+//   - BeforeUpdate, AfterUpdate: Run with the entity of Update and PartialUpdate. UpdateMany has no entity and
+//     runs no hook.
+//   - BeforeDelete, AfterDelete: Run with the params of Delete, DeleteReturning and SoftDelete.
+type Hooks[Entity any] struct {
+	BeforeCreate func(ctx context.Context, entity Entity) error
+	AfterCreate  func(ctx context.Context, entity Entity) error
+	BeforeUpdate func(ctx context.Context, entity Entity) error
+	AfterUpdate  func(ctx context.Context, entity Entity) error
+	BeforeDelete func(ctx context.Context, params query.Params) error
+	AfterDelete  func(ctx context.Context, params query.Params) error
+}
+
+// runHook runs the hook with each entity, stopping at the first error. A nil hook does nothing.
+func runHook[Entity any](ctx context.Context, hook func(context.Context, Entity) error, entities ...Entity) error {
+	if hook == nil {
+		return nil
+	}
+
+	for _, entity := range entities {
+		if err := hook(ctx, entity); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// afterCreate runs the AfterCreate hook with the entities of the created DTOs.
+func (s *Store[Entity, DTO, ID]) afterCreate(ctx context.Context, dtos []DTO) error {
+	if s.Hooks.AfterCreate == nil {
+		return nil
+	}
+
+	return runHook(ctx, s.Hooks.AfterCreate, s.toEntities(dtos)...)
+}
+
+// runDeleteHook runs the hook with the params of a delete. A nil hook does nothing.
+func runDeleteHook(ctx context.Context, hook func(context.Context, query.Params) error, params query.Params) error {
+	if hook == nil {
+		return nil
+	}
+
+	return hook(ctx, params)
+}
@@ -0,0 +1,78 @@
+package gormstore
+
+import (
+	"context"
+	"reflect"
+
+	"gorm.io/gorm"
+)
+
+// generateIDs sets an ID returned by the IDGenerator of the store to the DTOs whose ID is zero, unless the
+// primary key has a default value in the database.
+func (s *Store[Entity, DTO, ID]) generateIDs(ctx context.Context, dtos []DTO) error {
+	if s.IDGenerator == nil {
+		return nil
+	}
+
+	stmt := &gorm.Statement{DB: s.OpScope.Tx(ctx)}
+	if err := stmt.Parse(new(DTO)); err != nil {
+		return err
+	}
+
+	field := stmt.Schema.PrioritizedPrimaryField
+	if field == nil || (field.HasDefaultValue && field.DefaultValueInterface == nil) {
+		return nil
+	}
+
+	for i := range dtos {
+		if dtos[i].GetID() != *new(ID) {
+			continue
+		}
+
+		id, err := s.IDGenerator(ctx)
+		if err != nil {
+			return err
+		}
+
+		if err := field.Set(ctx, reflect.ValueOf(&dtos[i]).Elem(), id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setIDs sets the IDs of the created DTOs, such as the ones generated by the database, to the entities whose ID is
+// zero, in place for pointer entities. The ID is set to the entity field named like the primary key of the DTO.
+func (s *Store[Entity, DTO, ID]) setIDs(ctx context.Context, entities []Entity, dtos []DTO) error {
+	stmt := &gorm.Statement{DB: s.OpScope.Tx(ctx)}
+	if err := stmt.Parse(new(DTO)); err != nil {
+		return err
+	}
+
+	field := stmt.Schema.PrioritizedPrimaryField
+	if field == nil {
+		return nil
+	}
+
+	values := reflect.ValueOf(entities)
+
+	for i, entity := range entities {
+		id := dtos[i].GetID()
+		if entity.GetID() != *new(ID) || id == *new(ID) {
+			continue
+		}
+
+		v := reflect.Indirect(values.Index(i))
+		if v.Kind() != reflect.Struct {
+			continue
+		}
+
+		idValue := reflect.ValueOf(id)
+		if f := v.FieldByName(field.Name); f.CanSet() && idValue.Type().AssignableTo(f.Type()) {
+			f.Set(idValue)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package gormstore
+
+import (
+	"context"
+
+	"github.com/infevocorp/goflexstore/query"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// ListAs lists the rows of the query of the params into values of a result type R instead of the entities of
+// the store, e.g. the results of a grouped query with aggregates, which List cannot hydrate. The columns of the
+// rows are scanned into the fields of R by name, following the gorm naming strategy and column tags.
+//
+// The params are applied as with List, including the pagination policy of the store.
+//
+// Example:
+// Counting the articles of each category:
+//
+//	type CategoryCount struct {
+//		Category string
+//		Total    int64
+//	}
+//
+//	counts, err := gormstore.ListAs[CategoryCount](ctx, articleStore,
+//		query.Select("Category", "COUNT(*) AS total"),
+//		query.GroupBy("Category"),
+//		query.OrderBy("Category", false),
+//	)
+func ListAs[R any, Entity store.Entity[ID], DTO store.Entity[ID], ID comparable](
+	ctx context.Context,
+	s *Store[Entity, DTO, ID],
+	params ...query.Param,
+) ([]R, error) {
+	queryParams := query.NewParams(params...)
+	if err := s.requirePartitionKey(queryParams); err != nil {
+		return nil, err
+	}
+
+	// results are limited by the pagination policy like the lists.
+	if s.ScopeBuilder.Pagination.Limited() && len(queryParams.Get(query.TypePaginate)) == 0 {
+		queryParams = queryParams.Append(query.Paginate(0, 0))
+	}
+
+	tx := s.getReadTx(ctx, queryParams).Scopes(s.ScopeBuilder.Build(queryParams)...)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	results := make([]R, 0, listCapacity(queryParams))
+	if err := tx.Scan(&results).Error; err != nil {
+		return nil, err
+	}
+
+	if len(results) == 0 && !s.EmptySlices {
+		return nil, nil
+	}
+
+	return results, nil
+}
@@ -0,0 +1,67 @@
+package gormstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/infevocorp/goflexstore/flexlog"
+)
+
+// gormLogger logs the statements of a store to a flexlog.Logger, see WithLogger.
+// Statements are logged with their placeholders instead of their values, which may be sensitive.
+type gormLogger struct {
+	logger flexlog.Logger
+	entity string
+}
+
+func (l gormLogger) LogMode(logger.LogLevel) logger.Interface {
+	return l
+}
+
+func (l gormLogger) Info(ctx context.Context, msg string, data ...any) {
+	l.log(ctx, flexlog.LevelInfo, msg, data)
+}
+
+func (l gormLogger) Warn(ctx context.Context, msg string, data ...any) {
+	l.log(ctx, flexlog.LevelWarn, msg, data)
+}
+
+func (l gormLogger) Error(ctx context.Context, msg string, data ...any) {
+	l.log(ctx, flexlog.LevelError, msg, data)
+}
+
+func (l gormLogger) log(ctx context.Context, level flexlog.Level, msg string, data []any) {
+	l.logger.Log(ctx, level, fmt.Sprintf(msg, data...), flexlog.KeyEntity, l.entity)
+}
+
+func (l gormLogger) ParamsFilter(_ context.Context, sql string, _ ...any) (string, []any) {
+	return sql, nil
+}
+
+func (l gormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	sql, rows := fc()
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = nil
+	}
+
+	var operation string
+	if fields := strings.Fields(sql); len(fields) > 0 {
+		operation = strings.ToUpper(fields[0])
+	}
+
+	flexlog.Log(ctx, l.logger, "sql statement", flexlog.Record{
+		Entity:    l.entity,
+		Operation: operation,
+		Start:     begin,
+		Rows:      rows,
+		SQL:       sql,
+		Err:       err,
+	})
+}
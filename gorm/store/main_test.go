@@ -4,11 +4,9 @@ import (
 	"database/sql"
 	"testing"
 
-	"github.com/DATA-DOG/go-sqlmock"
-	"gorm.io/driver/mysql"
-	"gorm.io/gorm"
-
 	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
 )
 
 type UserDTO struct {
@@ -35,21 +33,29 @@ func (e User) GetID() int {
 	return e.ID
 }
 
-func newTestDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
-	db, sqlMock, err := sqlmock.New()
+type Document struct {
+	ID      int    `gorm:"column:id;primaryKey"`
+	Title   string `gorm:"column:title"`
+	Version int64  `gorm:"column:version"`
+}
+
+func (d Document) GetID() int {
+	return d.ID
+}
+
+// newSQLiteDB opens an in-memory SQLite database with the documents table, for the features
+// the MySQL dialect used with sqlmock does not support.
+func newSQLiteDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
 	require.NoError(t, err)
 
-	sqlMock.ExpectQuery("SELECT VERSION()").WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow("8.0.23"))
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
 
-	gormDB, err := gorm.Open(mysql.New(mysql.Config{
-		Conn: db,
-	}), &gorm.Config{
-		DisableAutomaticPing: true,
-	})
+	// Every connection to ":memory:" opens a distinct database.
+	sqlDB.SetMaxOpenConns(1)
 
-	t.Cleanup(func() {
-		require.NoError(t, sqlMock.ExpectationsWereMet())
-	})
+	require.NoError(t, db.AutoMigrate(&Document{}))
 
-	return gormDB, sqlMock
+	return db
 }
@@ -2,7 +2,10 @@ package gormstore
 
 import (
 	"github.com/infevocorp/goflexstore/converter"
+	"github.com/infevocorp/goflexstore/flexlog"
+	gormopscope "github.com/infevocorp/goflexstore/gorm/opscope"
 	gormquery "github.com/infevocorp/goflexstore/gorm/query"
+	"github.com/infevocorp/goflexstore/idgen"
 	"github.com/infevocorp/goflexstore/store"
 )
 
@@ -51,3 +54,290 @@ func WithScopeBuilderOption[
 		s.ScopeBuilder = gormquery.NewBuilder(options...)
 	}
 }
+
+// WithEmptySlices makes List return an empty, non-nil slice instead of nil when no entity matches.
+// It is useful when the result is encoded to JSON directly, where a nil slice is encoded as `null`.
+func WithEmptySlices[
+	Entity store.Entity[ID],
+	DTO store.Entity[ID],
+	ID comparable,
+](
+	enabled bool,
+) Option[Entity, DTO, ID] {
+	return func(s *Store[Entity, DTO, ID]) {
+		s.EmptySlices = enabled
+	}
+}
+
+// WithCascade sets the associations deleted together with the entities by Delete and DeleteReturning,
+// in addition to the ones given per call with query.Cascade. Use clause.Associations to delete all of them.
+//
+// The matched entities are loaded before being deleted, since GORM needs their primary keys to delete the
+// associations. Associations supporting soft delete are soft deleted, and many to many associations only have
+// their join table rows deleted.
+func WithCascade[
+	Entity store.Entity[ID],
+	DTO store.Entity[ID],
+	ID comparable,
+](
+	associations ...string,
+) Option[Entity, DTO, ID] {
+	return func(s *Store[Entity, DTO, ID]) {
+		s.Cascade = append(s.Cascade, associations...)
+	}
+}
+
+// WithValidator sets the validator of the entities written by Create, CreateMany, Update and Upsert.
+// Invalid entities are rejected with a store.ValidationError before reaching the database.
+// PartialUpdate is not validated since its entity only holds the fields to update.
+//
+// Example:
+//
+//	gormstore.WithValidator[*model.Article, *dto.Article, int64](validator.New())
+//	gormstore.WithValidator[*model.Article, *dto.Article, int64](gormstore.MethodValidator)
+func WithValidator[
+	Entity store.Entity[ID],
+	DTO store.Entity[ID],
+	ID comparable,
+](
+	validator Validator,
+) Option[Entity, DTO, ID] {
+	return func(s *Store[Entity, DTO, ID]) {
+		s.Validator = validator
+	}
+}
+
+// WithHooks sets the hooks run by the store before and after its creates, updates and deletes, e.g. to enrich
+// the entities or publish events without decorating the whole store. See Hooks for when each hook runs.
+//
+// Example:
+//
+//	gormstore.WithHooks[*model.Article, *dto.Article, int64](gormstore.Hooks[*model.Article]{
+//		BeforeCreate: func(ctx context.Context, article *model.Article) error {
+//			article.Slug = slug.Make(article.Title)
+//			return nil
+//		},
+//		AfterUpdate: func(ctx context.Context, article *model.Article) error {
+//			return publisher.Publish(ctx, ArticleUpdated{ID: article.ID})
+//		},
+//	})
+func WithHooks[
+	Entity store.Entity[ID],
+	DTO store.Entity[ID],
+	ID comparable,
+](
+	hooks Hooks[Entity],
+) Option[Entity, DTO, ID] {
+	return func(s *Store[Entity, DTO, ID]) {
+		s.Hooks = hooks
+	}
+}
+
+// WithPartitionKey makes the store refuse the queries that do not filter on the given field, the partition key
+// of a partitioned table. Such queries would scan every partition instead of the ones holding the matching rows.
+//
+// Get, List, Count, Exists, Update, PartialUpdate, Delete and DeleteReturning return ErrMissingPartitionKey
+// when their params have no filter on the field. Update by ID alone is refused as well, since the ID does not
+// tell which partition holds the row. gormpartition.Range builds a filter on a range of the key that
+// the database can use to prune partitions.
+//
+// Example:
+//
+//	gormstore.WithPartitionKey[*model.Event, *dto.Event, int64]("CreatedAt")
+func WithPartitionKey[
+	Entity store.Entity[ID],
+	DTO store.Entity[ID],
+	ID comparable,
+](
+	field string,
+) Option[Entity, DTO, ID] {
+	return func(s *Store[Entity, DTO, ID]) {
+		s.PartitionKey = field
+	}
+}
+
+// WithIDGenerator sets the generator of the IDs of the entities created with a zero ID by Create, CreateMany and
+// Upsert. Stores whose ID type is a UUID, i.e. its underlying type is [16]byte, generate version 7 UUIDs with
+// idgen.UUIDv7 by default.
+//
+// IDs are not generated when the primary key has a default value in the database, such as an auto increment
+// or `default:gen_random_uuid()`: the database generates the ID, which is read back with a RETURNING clause
+// on PostgreSQL and SQLite.
+//
+// Example:
+//
+//	gormstore.WithIDGenerator[*model.Article, *dto.Article, string](idgen.UUIDv7[string])
+func WithIDGenerator[
+	Entity store.Entity[ID],
+	DTO store.Entity[ID],
+	ID comparable,
+](
+	generator idgen.Generator[ID],
+) Option[Entity, DTO, ID] {
+	return func(s *Store[Entity, DTO, ID]) {
+		s.IDGenerator = generator
+	}
+}
+
+// WithLogger logs the SQL statements of the store to the logger, with the entity, duration, rows affected and
+// transaction of each statement, instead of the logger of the GORM database. Statements are logged with their
+// placeholders, so the values they bind are not logged.
+func WithLogger[
+	Entity store.Entity[ID],
+	DTO store.Entity[ID],
+	ID comparable,
+](
+	logger flexlog.Logger,
+) Option[Entity, DTO, ID] {
+	return func(s *Store[Entity, DTO, ID]) {
+		s.Logger = logger
+	}
+}
+
+// WithPagination sets the pagination policy of the queries of the store, see gormquery.Pagination. Lists are
+// limited to its default or maximum limit even when no paginate param is given.
+//
+// The policy is set on the scope builder of the store, so it must be given after WithScopeBuilderOption.
+//
+// Example:
+//
+//	gormstore.WithPagination[*model.Article, *dto.Article, int64](gormquery.Pagination{
+//		DefaultLimit: 20,
+//		MaxLimit:     100,
+//		MaxOffset:    10000,
+//	})
+func WithPagination[
+	Entity store.Entity[ID],
+	DTO store.Entity[ID],
+	ID comparable,
+](
+	pagination gormquery.Pagination,
+) Option[Entity, DTO, ID] {
+	return func(s *Store[Entity, DTO, ID]) {
+		if s.ScopeBuilder == nil {
+			s.ScopeBuilder = newScopeBuilder[DTO]()
+		}
+
+		s.ScopeBuilder.Pagination = pagination
+	}
+}
+
+// WithTemporal sets the temporal table configuration of the queries of the store, see gormquery.Temporal. It
+// enables the query.AsOf params, reading the past states of the entities.
+//
+// The configuration is set on the scope builder of the store, so it must be given after WithScopeBuilderOption.
+//
+// Example:
+//
+//	gormstore.WithTemporal[*model.Article, *dto.Article, int64](gormquery.Temporal{
+//		HistoryTable: "articles_history",
+//	})
+func WithTemporal[
+	Entity store.Entity[ID],
+	DTO store.Entity[ID],
+	ID comparable,
+](
+	temporal gormquery.Temporal,
+) Option[Entity, DTO, ID] {
+	return func(s *Store[Entity, DTO, ID]) {
+		if s.ScopeBuilder == nil {
+			s.ScopeBuilder = newScopeBuilder[DTO]()
+		}
+
+		s.ScopeBuilder.Temporal = temporal
+	}
+}
+
+// WithReadScope sets the scope of the reads of the store, typically of a replica, e.g. created by
+// gormopscope.NewReadTransactionScope on its connection.
+//
+// Get, List, ListWithCount, Count, Exists, AggregateMany, ListPage, ListCursor, Stream, Pluck and ListAs then run
+// on the read scope, within its transaction if the context carries one, unless the context carries a transaction
+// of the write scope, so that a transaction reads its own writes, or their params lock the rows with
+// query.WithLock. The other operations run on the write scope.
+//
+// Example:
+//
+//	articleStore := gormstore.New[*model.Article, *dto.Article, int64](primaryScope,
+//		gormstore.WithReadScope[*model.Article, *dto.Article, int64](
+//			gormopscope.NewReadTransactionScope("replica", replicaDB),
+//		),
+//	)
+func WithReadScope[
+	Entity store.Entity[ID],
+	DTO store.Entity[ID],
+	ID comparable,
+](
+	scope *gormopscope.TransactionScope,
+) Option[Entity, DTO, ID] {
+	return func(s *Store[Entity, DTO, ID]) {
+		s.ReadScope = scope
+	}
+}
+
+// WithWriteScope sets the scope of the writes of the store, of the primary, replacing the operation scope given to
+// New. It is meant to be used with WithReadScope, to state both scopes explicitly.
+func WithWriteScope[
+	Entity store.Entity[ID],
+	DTO store.Entity[ID],
+	ID comparable,
+](
+	scope *gormopscope.TransactionScope,
+) Option[Entity, DTO, ID] {
+	return func(s *Store[Entity, DTO, ID]) {
+		s.OpScope = scope
+	}
+}
+
+// WithErrorTranslator sets the function translating the errors of the writes, TranslateError by default, e.g. to
+// map the violations of a constraint to a domain error, or to return the errors of the driver unchanged.
+//
+// Example:
+//
+//	userStore := gormstore.New[*model.User, *dto.User, int64](opScope,
+//		gormstore.WithErrorTranslator[*model.User, *dto.User, int64](func(err error) error {
+//			err = gormstore.TranslateError(err)
+//
+//			var constraintErr *store.ConstraintError
+//			if errors.As(err, &constraintErr) && constraintErr.Constraint == "users_email_key" {
+//				return ErrEmailTaken
+//			}
+//
+//			return err
+//		}),
+//	)
+func WithErrorTranslator[
+	Entity store.Entity[ID],
+	DTO store.Entity[ID],
+	ID comparable,
+](
+	translate func(err error) error,
+) Option[Entity, DTO, ID] {
+	return func(s *Store[Entity, DTO, ID]) {
+		s.ErrorTranslator = translate
+	}
+}
+
+// WithRequireRowsAffected makes Update, PartialUpdate and Delete, and their Count variants, return
+// store.ErrNoRowsAffected when no row is affected, so that a write targeting a missing entity fails instead of
+// silently doing nothing.
+//
+// Example:
+//
+//	articleStore := gormstore.New[*model.Article, *dto.Article, int64](opScope,
+//		gormstore.WithRequireRowsAffected[*model.Article, *dto.Article, int64](),
+//	)
+//
+//	err := articleStore.Delete(ctx, query.Filter("ID", id))
+//	if errors.Is(err, store.ErrNoRowsAffected) {
+//		return http.StatusNotFound
+//	}
+func WithRequireRowsAffected[
+	Entity store.Entity[ID],
+	DTO store.Entity[ID],
+	ID comparable,
+]() Option[Entity, DTO, ID] {
+	return func(s *Store[Entity, DTO, ID]) {
+		s.RequireRowsAffected = true
+	}
+}
@@ -0,0 +1,143 @@
+package gormstore
+
+import (
+	"context"
+	"fmt"
+
+	gormquery "github.com/infevocorp/goflexstore/gorm/query"
+	"github.com/infevocorp/goflexstore/query"
+)
+
+// ErrInvalidCursor is returned by ListPage and ListCursor when the cursor is malformed or was issued for another
+// ordering.
+var ErrInvalidCursor = gormquery.ErrInvalidCursor
+
+// ListPage returns a page of at most limit entities matching the params, starting after the cursor, with the
+// cursor of the next page, or an empty cursor on the last page. The first page is listed with an empty cursor.
+//
+// The entities are ordered by the OrderBy params followed by the primary key, which breaks the ties so that no
+// entity is skipped nor repeated across pages, and each page is read by keyset pagination: it filters the rows
+// after the values of the last entity of the previous page, which the database reads from the index of the
+// ordering however deep the page, unlike an offset. The ordered columns must therefore not be NULL. The Paginate
+// params are ignored, and the limit is lowered to the maximum limit of the pagination policy, if any.
+//
+// The cursors are opaque URL-safe strings holding the values of the ordered columns of the last entity: they are
+// not encrypted, and are bound to the ordering of the params, ListPage returning ErrInvalidCursor for the cursors
+// issued for another ordering.
+//
+// Example:
+//
+//	articles, next, err := articleStore.ListPage(ctx, r.URL.Query().Get("cursor"), 20,
+//		query.Filter("Status", "published"),
+//		query.OrderBy("PublishedAt", true),
+//	)
+func (s *Store[Entity, DTO, ID]) ListPage(
+	ctx context.Context,
+	cursor string,
+	limit int,
+	params ...query.Param,
+) ([]Entity, string, error) {
+	var orderBy []query.OrderByParam
+
+	for _, param := range query.NewParams(params...).Get(query.TypeOrderBy) {
+		orderBy = append(orderBy, param.(query.OrderByParam))
+	}
+
+	return s.ListCursor(ctx, append(params, query.Cursor(cursor, limit, orderBy...))...)
+}
+
+// ListCursor returns the page of the entities matching the params read by their query.Cursor param, with the
+// cursor of the next page, or an empty cursor on the last page. The params must hold exactly one Cursor param,
+// and their OrderBy and Paginate params are ignored in favor of it. See ListPage for the ordering of the pages
+// and the cursors.
+//
+// Example:
+//
+//	articles, next, err := articleStore.ListCursor(ctx,
+//		query.Filter("Status", "published"),
+//		query.Cursor(r.URL.Query().Get("cursor"), 20, query.OrderBy("PublishedAt", true)),
+//	)
+func (s *Store[Entity, DTO, ID]) ListCursor(
+	ctx context.Context,
+	params ...query.Param,
+) (_ []Entity, _ string, err error) {
+	defer recoverConversionError(&err)
+
+	queryParams := query.NewParams(params...)
+	if err := s.requirePartitionKey(queryParams); err != nil {
+		return nil, "", err
+	}
+
+	cursors := queryParams.Get(query.TypeCursor)
+	if len(cursors) != 1 {
+		return nil, "", fmt.Errorf("%w: expected one cursor param, got %d", gormquery.ErrInvalidParam, len(cursors))
+	}
+
+	cursor := cursors[0].(query.CursorParam)
+
+	limit := cursor.Size
+	if limit < 1 {
+		return nil, "", fmt.Errorf("%w: page limit %d", gormquery.ErrInvalidParam, limit)
+	}
+
+	if maxLimit := s.ScopeBuilder.Pagination.MaxLimit; maxLimit > 0 && limit > maxLimit {
+		limit = maxLimit
+	}
+
+	db := s.getReadTx(ctx, queryParams)
+	if err := db.Statement.Parse(new(DTO)); err != nil {
+		return nil, "", err
+	}
+
+	keyset, err := s.ScopeBuilder.Keyset(db.Statement.Schema, cursor.OrderBy)
+	if err != nil {
+		return nil, "", err
+	}
+
+	pageParams := make([]query.Param, 0, queryParams.Len())
+	for _, param := range queryParams.Params() {
+		switch param.(type) {
+		case query.OrderByParam, query.PaginateParam, query.CursorParam:
+		default:
+			pageParams = append(pageParams, param)
+		}
+	}
+
+	tx := keyset.Order(db.Scopes(s.ScopeBuilder.Build(query.NewParams(pageParams...))...))
+
+	if cursor.After != "" {
+		after, err := keyset.After(cursor.After)
+		if err != nil {
+			return nil, "", err
+		}
+
+		tx = tx.Where(after)
+	}
+
+	if tx.Error != nil {
+		return nil, "", tx.Error
+	}
+
+	dtos := make([]DTO, 0, limit+1)
+
+	// One more entity is read to know whether there is a next page.
+	if err := tx.Limit(limit + 1).Find(&dtos).Error; err != nil {
+		return nil, "", err
+	}
+
+	var next string
+
+	if len(dtos) > limit {
+		dtos = dtos[:limit]
+
+		if next, err = keyset.Next(ctx, dtos[limit-1]); err != nil {
+			return nil, "", err
+		}
+	}
+
+	if entities := s.toEntities(dtos); entities != nil || !s.EmptySlices {
+		return entities, next, nil
+	}
+
+	return []Entity{}, next, nil
+}
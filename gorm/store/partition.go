@@ -0,0 +1,43 @@
+package gormstore
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/infevocorp/goflexstore/query"
+)
+
+// ErrMissingPartitionKey is returned when a query of a store configured with WithPartitionKey
+// has no filter on the partition key.
+var ErrMissingPartitionKey = errors.New("missing partition key filter")
+
+// requirePartitionKey returns ErrMissingPartitionKey if the store has a partition key and the params
+// do not filter on it. An OR filter is accepted when each of its conditions filters on the partition key.
+func (s *Store[Entity, DTO, ID]) requirePartitionKey(params query.Params) error {
+	if s.PartitionKey == "" {
+		return nil
+	}
+
+	if _, ok := params.GetFilter(s.PartitionKey); ok {
+		return nil
+	}
+
+	for _, param := range params.Get(query.TypeOR) {
+		if orParam, ok := param.(query.ORParam); ok && filtersOn(orParam.Params, s.PartitionKey) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s", ErrMissingPartitionKey, s.PartitionKey)
+}
+
+// filtersOn returns true if every filter is on the given field.
+func filtersOn(filters []query.FilterParam, field string) bool {
+	for _, filter := range filters {
+		if filter.Name != field {
+			return false
+		}
+	}
+
+	return len(filters) > 0
+}
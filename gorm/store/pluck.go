@@ -0,0 +1,66 @@
+package gormstore
+
+import (
+	"context"
+
+	"github.com/infevocorp/goflexstore/query"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// Pluck lists the values of a single field of the entities matching the params, e.g. the IDs of the entities to
+// process, scanning the column of the field into values of type V without loading the DTOs nor converting them.
+//
+// The params are applied as with List, including the pagination policy of the store, except the Select and
+// Preload params, which are ignored.
+//
+// Example:
+// Getting the IDs of the published articles:
+//
+//	ids, err := gormstore.Pluck[int64](ctx, articleStore, "ID", query.Filter("Status", "published"))
+func Pluck[V any, Entity store.Entity[ID], DTO store.Entity[ID], ID comparable](
+	ctx context.Context,
+	s *Store[Entity, DTO, ID],
+	field string,
+	params ...query.Param,
+) ([]V, error) {
+	var pluckParams []query.Param
+
+	for _, param := range params {
+		switch param.(type) {
+		case query.SelectParam, query.PreloadParam, query.PreloadAllParam:
+		default:
+			pluckParams = append(pluckParams, param)
+		}
+	}
+
+	queryParams := query.NewParams(pluckParams...)
+	if err := s.requirePartitionKey(queryParams); err != nil {
+		return nil, err
+	}
+
+	// values are limited by the pagination policy like the lists.
+	if s.ScopeBuilder.Pagination.Limited() && len(queryParams.Get(query.TypePaginate)) == 0 {
+		queryParams = queryParams.Append(query.Paginate(0, 0))
+	}
+
+	col := field
+	if c, ok := s.ScopeBuilder.FieldToColMap[field]; ok {
+		col = c
+	}
+
+	tx := s.getReadTx(ctx, queryParams).Scopes(s.ScopeBuilder.Build(queryParams)...)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	values := make([]V, 0, listCapacity(queryParams))
+	if err := tx.Pluck(col, &values).Error; err != nil {
+		return nil, err
+	}
+
+	if len(values) == 0 && !s.EmptySlices {
+		return nil, nil
+	}
+
+	return values, nil
+}
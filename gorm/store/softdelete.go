@@ -0,0 +1,89 @@
+package gormstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"github.com/infevocorp/goflexstore/query"
+)
+
+// ErrSoftDeleteUnsupported is returned by SoftDelete and Restore when the DTO has no gorm.DeletedAt field.
+var ErrSoftDeleteUnsupported = errors.New("soft delete is not supported by the model")
+
+var deletedAtType = reflect.TypeOf(gorm.DeletedAt{})
+
+// SoftDelete marks the entities matching the params as deleted by setting their gorm.DeletedAt field, so that
+// Get, List, Count and the other reads exclude them unless a query.Unscoped param is given, and Restore can
+// bring them back. It returns ErrSoftDeleteUnsupported if the DTO has no gorm.DeletedAt field, instead of
+// deleting the rows like Delete does for such DTOs.
+//
+// The entities are soft deleted like Delete does, associations in cascade included, except that the
+// query.Unscoped params are ignored: the rows are never removed.
+//
+// Example:
+//
+//	err := articleStore.SoftDelete(ctx, query.Filter("ID", id))
+func (s *Store[Entity, DTO, ID]) SoftDelete(ctx context.Context, params ...query.Param) error {
+	if _, err := s.deletedAtField(ctx); err != nil {
+		return err
+	}
+
+	scoped := make([]query.Param, 0, len(params))
+
+	for _, param := range params {
+		if _, ok := param.(query.UnscopedParam); !ok {
+			scoped = append(scoped, param)
+		}
+	}
+
+	return s.Delete(ctx, scoped...)
+}
+
+// Restore brings back the soft-deleted entities matching the params by clearing their gorm.DeletedAt field. The
+// entities which are not deleted are left untouched, and restoring without params restores every deleted entity.
+// It returns ErrSoftDeleteUnsupported if the DTO has no gorm.DeletedAt field.
+//
+// Example:
+//
+//	err := articleStore.Restore(ctx, query.Filter("ID", id))
+func (s *Store[Entity, DTO, ID]) Restore(ctx context.Context, params ...query.Param) error {
+	queryParams := query.NewParams(params...)
+	if err := s.requirePartitionKey(queryParams); err != nil {
+		return err
+	}
+
+	field, err := s.deletedAtField(ctx)
+	if err != nil {
+		return err
+	}
+
+	tx := s.getTx(ctx).Unscoped().Scopes(s.ScopeBuilder.Build(queryParams)...)
+
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	return tx.Where(clause.Neq{Column: clause.Column{Name: field.DBName}, Value: nil}).Update(field.DBName, nil).Error
+}
+
+// deletedAtField returns the gorm.DeletedAt field of the DTO, or ErrSoftDeleteUnsupported.
+func (s *Store[Entity, DTO, ID]) deletedAtField(ctx context.Context) (*schema.Field, error) {
+	db := s.getTx(ctx)
+	if err := db.Statement.Parse(new(DTO)); err != nil {
+		return nil, err
+	}
+
+	for _, field := range db.Statement.Schema.Fields {
+		if field.FieldType == deletedAtType {
+			return field, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrSoftDeleteUnsupported, db.Statement.Schema.Name)
+}
@@ -3,14 +3,18 @@ package gormstore
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sort"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
 	"github.com/infevocorp/goflexstore/converter"
+	"github.com/infevocorp/goflexstore/flexlog"
 	gormopscope "github.com/infevocorp/goflexstore/gorm/opscope"
 	gormquery "github.com/infevocorp/goflexstore/gorm/query"
 	gormutils "github.com/infevocorp/goflexstore/gorm/utils"
+	"github.com/infevocorp/goflexstore/idgen"
 	"github.com/infevocorp/goflexstore/query"
 	"github.com/infevocorp/goflexstore/store"
 )
@@ -35,20 +39,50 @@ func New[Entity store.Entity[ID], DTO store.Entity[ID], ID comparable](
 	}
 
 	if s.Converter == nil {
-		s.Converter = converter.NewReflect[Entity, DTO, ID](nil)
+		if converter.SameType[Entity, DTO]() {
+			s.Converter = converter.NewIdentity[Entity, DTO, ID]()
+		} else {
+			s.Converter = converter.NewReflect[Entity, DTO, ID](nil)
+		}
+	}
+
+	if s.IDGenerator == nil && idgen.IsUUID[ID]() {
+		s.IDGenerator = idgen.UUIDv7[ID]
 	}
 
 	if s.ScopeBuilder == nil {
-		s.ScopeBuilder = gormquery.NewBuilder(
-			gormquery.WithFieldToColMap(
-				gormutils.FieldToColMap(*new(DTO)),
-			),
-		)
+		s.ScopeBuilder = newScopeBuilder[DTO]()
+	}
+
+	if s.ErrorTranslator == nil {
+		s.ErrorTranslator = TranslateError
 	}
 
 	return s
 }
 
+// NewSimple initializes a new Store for entities which are also the DTOs, mapped directly to their table.
+// The entities are read and written without conversion, see converter.Identity.
+//
+// Example:
+//
+//	articleStore := gormstore.NewSimple[*model.Article, int64](opScope)
+func NewSimple[T store.Entity[ID], ID comparable](
+	opScope *gormopscope.TransactionScope,
+	options ...Option[T, T, ID],
+) *Store[T, T, ID] {
+	return New[T, T, ID](opScope, options...)
+}
+
+// newScopeBuilder returns the default scope builder of the stores of DTO.
+func newScopeBuilder[DTO any](options ...gormquery.Option) *gormquery.ScopeBuilder {
+	return gormquery.NewBuilder(append([]gormquery.Option{
+		gormquery.WithFieldToColMap(
+			gormutils.FieldToColMap(*new(DTO)),
+		),
+	}, options...)...)
+}
+
 // Store represents a storage mechanism using GORM for database operations.
 // It supports CRUD operations and is designed to be generic for any Entity and DTO types.
 //
@@ -57,20 +91,40 @@ func New[Entity store.Entity[ID], DTO store.Entity[ID], ID comparable](
 // ID: The type of the unique identifier for the entity.
 type Store[Entity store.Entity[ID], DTO store.Entity[ID], ID comparable] struct {
 	OpScope      *gormopscope.TransactionScope
+	ReadScope    *gormopscope.TransactionScope
 	Converter    converter.Converter[Entity, DTO, ID]
 	ScopeBuilder *gormquery.ScopeBuilder
 	BatchSize    int
+	EmptySlices  bool
+	Cascade      []string
+	Validator    Validator
+	PartitionKey string
+	IDGenerator  idgen.Generator[ID]
+	Logger       flexlog.Logger
+	Hooks        Hooks[Entity]
+	// RequireRowsAffected makes Update, PartialUpdate and Delete return store.ErrNoRowsAffected when no row is
+	// affected.
+	RequireRowsAffected bool
+	// ErrorTranslator translates the errors of the writes, TranslateError by default.
+	ErrorTranslator func(err error) error
 }
 
 // Get retrieves a single entity based on provided query parameters.
-// It returns the entity if found, otherwise an error.
-func (s *Store[Entity, DTO, ID]) Get(ctx context.Context, params ...query.Param) (Entity, error) {
+// It returns the entity if found, otherwise store.ErrNotFound, which gorm.ErrRecordNotFound is translated to.
+func (s *Store[Entity, DTO, ID]) Get(ctx context.Context, params ...query.Param) (_ Entity, err error) {
+	defer recoverConversionError(&err)
+
+	queryParams := query.NewParams(params...)
+	if err := s.requirePartitionKey(queryParams); err != nil {
+		return *new(Entity), err
+	}
+
 	var (
 		dto    DTO
-		scopes = s.ScopeBuilder.Build(query.NewParams(params...))
+		scopes = s.ScopeBuilder.Build(queryParams)
 	)
 
-	tx := s.getTx(ctx).Scopes(scopes...)
+	tx := s.getReadTx(ctx, queryParams).Scopes(scopes...)
 
 	if tx.Error != nil {
 		return *new(Entity), tx.Error
@@ -80,7 +134,7 @@ func (s *Store[Entity, DTO, ID]) Get(ctx context.Context, params ...query.Param)
 		First(&dto).Error; err != nil {
 
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return *new(Entity), store.ErrorNotFound
+			return *new(Entity), store.ErrNotFound
 		}
 
 		return *new(Entity), err
@@ -91,13 +145,25 @@ func (s *Store[Entity, DTO, ID]) Get(ctx context.Context, params ...query.Param)
 
 // List retrieves a list of entities matching the provided query parameters.
 // Returns a slice of entities and an error if the operation fails.
-func (s *Store[Entity, DTO, ID]) List(ctx context.Context, params ...query.Param) ([]Entity, error) {
+func (s *Store[Entity, DTO, ID]) List(ctx context.Context, params ...query.Param) (_ []Entity, err error) {
+	defer recoverConversionError(&err)
+
+	queryParams := query.NewParams(params...)
+	if err := s.requirePartitionKey(queryParams); err != nil {
+		return nil, err
+	}
+
+	// lists are limited by the pagination policy even when no paginate param is given.
+	if s.ScopeBuilder.Pagination.Limited() && len(queryParams.Get(query.TypePaginate)) == 0 {
+		queryParams = queryParams.Append(query.Paginate(0, 0))
+	}
+
 	var (
-		dtos   []DTO
-		scopes = s.ScopeBuilder.Build(query.NewParams(params...))
+		dtos   = make([]DTO, 0, listCapacity(queryParams))
+		scopes = s.ScopeBuilder.Build(queryParams)
 	)
 
-	tx := s.getTx(ctx).Scopes(scopes...)
+	tx := s.getReadTx(ctx, queryParams).Scopes(scopes...)
 
 	if tx.Error != nil {
 		return nil, tx.Error
@@ -107,18 +173,88 @@ func (s *Store[Entity, DTO, ID]) List(ctx context.Context, params ...query.Param
 		return nil, err
 	}
 
-	return converter.ToMany(dtos, s.Converter.ToEntity), nil
+	if entities := s.toEntities(dtos); entities != nil || !s.EmptySlices {
+		return entities, nil
+	}
+
+	return []Entity{}, nil
+}
+
+// ListWithCount retrieves the page of entities matching the provided query parameters, like List, together with
+// the number of matching entities, counted without the Paginate and Cursor params.
+// The scopes of the params are built once and shared by both queries, and the list is not queried when no
+// entity matches.
+func (s *Store[Entity, DTO, ID]) ListWithCount(
+	ctx context.Context,
+	params ...query.Param,
+) (_ []Entity, _ int64, err error) {
+	defer recoverConversionError(&err)
+
+	queryParams := query.NewParams(params...)
+	if err := s.requirePartitionKey(queryParams); err != nil {
+		return nil, 0, err
+	}
+
+	var countParams, pageParams []query.Param
+
+	for _, param := range queryParams.Params() {
+		switch param.(type) {
+		case query.PaginateParam, query.CursorParam:
+			pageParams = append(pageParams, param)
+		default:
+			countParams = append(countParams, param)
+		}
+	}
+
+	// lists are limited by the pagination policy even when no paginate param is given.
+	if s.ScopeBuilder.Pagination.Limited() && len(pageParams) == 0 {
+		pageParams = append(pageParams, query.Paginate(0, 0))
+	}
+
+	// the session lets the count and the list run on the same scopes.
+	tx := s.getReadTx(ctx, queryParams).
+		Scopes(s.ScopeBuilder.Build(query.NewParams(countParams...))...).
+		Session(&gorm.Session{})
+
+	if tx.Error != nil {
+		return nil, 0, tx.Error
+	}
+
+	var count int64
+
+	if err := tx.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	dtos := make([]DTO, 0, listCapacity(query.NewParams(pageParams...)))
+
+	if count > 0 {
+		if err := tx.Scopes(s.ScopeBuilder.Build(query.NewParams(pageParams...))...).Find(&dtos).Error; err != nil {
+			return nil, 0, err
+		}
+	}
+
+	if entities := s.toEntities(dtos); entities != nil || !s.EmptySlices {
+		return entities, count, nil
+	}
+
+	return []Entity{}, count, nil
 }
 
 // Count returns the number of entities that satisfy the provided query parameters.
 // The count is returned along with an error if the operation fails.
 func (s *Store[Entity, DTO, ID]) Count(ctx context.Context, params ...query.Param) (int64, error) {
+	queryParams := query.NewParams(params...)
+	if err := s.requirePartitionKey(queryParams); err != nil {
+		return 0, err
+	}
+
 	var (
 		count  int64
-		scopes = s.ScopeBuilder.Build(query.NewParams(params...))
+		scopes = s.ScopeBuilder.Build(queryParams)
 	)
 
-	tx := s.getTx(ctx).Scopes(scopes...)
+	tx := s.getReadTx(ctx, queryParams).Scopes(scopes...)
 
 	if tx.Error != nil {
 		return 0, tx.Error
@@ -134,12 +270,17 @@ func (s *Store[Entity, DTO, ID]) Count(ctx context.Context, params ...query.Para
 // Exists checks for the existence of at least one entity that matches the query parameters.
 // Returns true if such an entity exists, false otherwise.
 func (s *Store[Entity, DTO, ID]) Exists(ctx context.Context, params ...query.Param) (bool, error) {
+	queryParams := query.NewParams(params...)
+	if err := s.requirePartitionKey(queryParams); err != nil {
+		return false, err
+	}
+
 	var (
 		count  int64
-		scopes = s.ScopeBuilder.Build(query.NewParams(params...))
+		scopes = s.ScopeBuilder.Build(queryParams)
 	)
 
-	tx := s.getTx(ctx).Scopes(scopes...)
+	tx := s.getReadTx(ctx, queryParams).Scopes(scopes...)
 
 	if tx.Error != nil {
 		return false, tx.Error
@@ -156,90 +297,435 @@ func (s *Store[Entity, DTO, ID]) Exists(ctx context.Context, params ...query.Par
 
 // Create adds a new entity to the store and returns its ID.
 // Returns an error if the creation fails.
-func (s *Store[Entity, DTO, ID]) Create(ctx context.Context, entity Entity) (ID, error) {
-	dto := s.Converter.ToDTO(entity)
-	if err := s.getTx(ctx).Create(&dto).Error; err != nil {
+func (s *Store[Entity, DTO, ID]) Create(ctx context.Context, entity Entity) (_ ID, err error) {
+	defer recoverConversionError(&err)
+
+	dto, err := s.create(ctx, entity)
+	if err != nil {
 		return *new(ID), err
 	}
 
 	return dto.GetID(), nil
 }
 
+// create adds the entity to the store and returns its DTO, as filled by the database.
+func (s *Store[Entity, DTO, ID]) create(ctx context.Context, entity Entity) (DTO, error) {
+	if err := runHook(ctx, s.Hooks.BeforeCreate, entity); err != nil {
+		return *new(DTO), err
+	}
+
+	if err := s.validate(ctx, entity); err != nil {
+		return *new(DTO), err
+	}
+
+	dtos := []DTO{s.Converter.ToDTO(entity)}
+	if err := s.generateIDs(ctx, dtos); err != nil {
+		return *new(DTO), err
+	}
+
+	if err := s.getTx(ctx).Create(&dtos[0]).Error; err != nil {
+		return *new(DTO), s.ErrorTranslator(err)
+	}
+
+	return dtos[0], s.afterCreate(ctx, dtos)
+}
+
 // CreateMany performs batch creation of entities.
 // The BatchSize field of the store determines the number of entities in each batch.
+// The IDs filled by the database, e.g. auto-increment ones, are set to the entities whose ID is zero.
 // Returns an error if the operation fails.
-func (s *Store[Entity, DTO, ID]) CreateMany(ctx context.Context, entities []Entity) error {
+func (s *Store[Entity, DTO, ID]) CreateMany(ctx context.Context, entities []Entity) (err error) {
+	defer recoverConversionError(&err)
+
+	if err := runHook(ctx, s.Hooks.BeforeCreate, entities...); err != nil {
+		return err
+	}
+
+	if err := s.validate(ctx, entities...); err != nil {
+		return err
+	}
+
 	dtos := converter.ToMany(entities, s.Converter.ToDTO)
+	if err := s.generateIDs(ctx, dtos); err != nil {
+		return err
+	}
+
 	batchSize := defaultValue(s.BatchSize, 50)
 
-	return s.getTx(ctx).CreateInBatches(dtos, batchSize).Error
+	if err := s.getTx(ctx).CreateInBatches(dtos, batchSize).Error; err != nil {
+		return s.ErrorTranslator(err)
+	}
+
+	if err := s.setIDs(ctx, entities, dtos); err != nil {
+		return err
+	}
+
+	return s.afterCreate(ctx, dtos)
 }
 
 // Update modifies an existing entity in the store, including fields with zero values.
-// Returns an error if the update operation fails.
+// Returns an error if the update operation fails, see UpdateCount.
 func (s *Store[Entity, DTO, ID]) Update(ctx context.Context, entity Entity, params ...query.Param) error {
+	_, err := s.UpdateCount(ctx, entity, params...)
+
+	return err
+}
+
+// UpdateCount modifies an existing entity in the store like Update, and returns the number of rows affected.
+// With WithRequireRowsAffected, it returns store.ErrNoRowsAffected when no row is affected.
+//
+// Note: MySQL only counts the rows whose values changed, unless the clientFoundRows parameter of the DSN is set.
+func (s *Store[Entity, DTO, ID]) UpdateCount(
+	ctx context.Context,
+	entity Entity,
+	params ...query.Param,
+) (_ int64, err error) {
+	defer recoverConversionError(&err)
+
+	if err := runHook(ctx, s.Hooks.BeforeUpdate, entity); err != nil {
+		return 0, err
+	}
+
+	if err := s.validate(ctx, entity); err != nil {
+		return 0, err
+	}
+
+	queryParams := query.NewParams(params...)
+	if err := s.requirePartitionKey(queryParams); err != nil {
+		return 0, err
+	}
+
 	dto := s.Converter.ToDTO(entity)
 	id := dto.GetID()
 
 	if id == *new(ID) && len(params) == 0 {
-		return errors.New("id is required")
+		return 0, errors.New("id is required")
 	}
 
 	tx := s.getTx(ctx)
 
 	if len(params) > 0 {
-		scopes := s.ScopeBuilder.Build(query.NewParams(params...))
+		scopes := s.ScopeBuilder.Build(queryParams)
 		tx = tx.Scopes(scopes...)
 
 		if tx.Error != nil {
-			return tx.Error
+			return 0, tx.Error
 		}
+	} else {
+		// the DTO is the model of the statement, so that it is updated by its primary key.
+		tx = tx.Model(&dto)
+	}
+
+	tx = tx.Select("*").Updates(&dto)
+	if tx.Error != nil {
+		return 0, s.ErrorTranslator(tx.Error)
+	}
+
+	if err := s.requireRowsAffected(tx.RowsAffected); err != nil {
+		return 0, err
 	}
 
-	return tx.Select("*").Updates(&dto).Error
+	return tx.RowsAffected, runHook(ctx, s.Hooks.AfterUpdate, entity)
 }
 
 // PartialUpdate updates specific fields of an existing entity in the store.
 // Only non-zero fields of the entity are updated.
-// Returns an error if the operation fails.
+// Returns an error if the operation fails, see PartialUpdateCount.
 func (s *Store[Entity, DTO, ID]) PartialUpdate(ctx context.Context, entity Entity, params ...query.Param) error {
+	_, err := s.PartialUpdateCount(ctx, entity, params...)
+
+	return err
+}
+
+// PartialUpdateCount updates the non-zero fields of an existing entity like PartialUpdate, and returns the number
+// of rows affected. With WithRequireRowsAffected, it returns store.ErrNoRowsAffected when no row is affected.
+func (s *Store[Entity, DTO, ID]) PartialUpdateCount(
+	ctx context.Context,
+	entity Entity,
+	params ...query.Param,
+) (_ int64, err error) {
+	defer recoverConversionError(&err)
+
+	if err := runHook(ctx, s.Hooks.BeforeUpdate, entity); err != nil {
+		return 0, err
+	}
+
+	queryParams := query.NewParams(params...)
+	if err := s.requirePartitionKey(queryParams); err != nil {
+		return 0, err
+	}
+
 	dto := s.Converter.ToDTO(entity)
-	scopes := s.ScopeBuilder.Build(query.NewParams(params...))
+	scopes := s.ScopeBuilder.Build(queryParams)
 
 	tx := s.getTx(ctx).Scopes(scopes...)
 
 	if tx.Error != nil {
-		return tx.Error
+		return 0, tx.Error
+	}
+
+	tx = tx.Updates(dto)
+	if tx.Error != nil {
+		return 0, s.ErrorTranslator(tx.Error)
+	}
+
+	if err := s.requireRowsAffected(tx.RowsAffected); err != nil {
+		return 0, err
+	}
+
+	return tx.RowsAffected, runHook(ctx, s.Hooks.AfterUpdate, entity)
+}
+
+// UpdateMany sets the fields of updates, keyed by field name, on every entity matching the query parameters in
+// a single UPDATE statement, and returns the number of rows affected. The field names are mapped to their columns
+// like the filters. GORM refuses to update without conditions, so updating every row requires a condition such
+// as query.Raw("1 = 1").
+//
+// Example:
+//
+//	archived, err := articleStore.UpdateMany(ctx, map[string]any{"Status": "archived"},
+//		query.Filter("PublishedAt", cutoff).WithOP(query.LT),
+//	)
+func (s *Store[Entity, DTO, ID]) UpdateMany(
+	ctx context.Context,
+	updates map[string]any,
+	params ...query.Param,
+) (int64, error) {
+	queryParams := query.NewParams(params...)
+	if err := s.requirePartitionKey(queryParams); err != nil {
+		return 0, err
+	}
+
+	if len(updates) == 0 {
+		return 0, fmt.Errorf("%w: no field to update", gormquery.ErrInvalidParam)
+	}
+
+	cols := make(map[string]any, len(updates))
+
+	for name, value := range updates {
+		if col, ok := s.ScopeBuilder.FieldToColMap[name]; ok {
+			name = col
+		}
+
+		cols[name] = value
+	}
+
+	tx := s.getTx(ctx).Scopes(s.ScopeBuilder.Build(queryParams)...)
+
+	if tx.Error != nil {
+		return 0, tx.Error
 	}
 
-	return tx.Updates(dto).Error
+	tx = tx.Updates(cols)
+
+	return tx.RowsAffected, s.ErrorTranslator(tx.Error)
 }
 
 // Delete removes entities from the store based on the provided query parameters.
-// Returns an error if the deletion operation fails.
+// Returns an error if the deletion operation fails, see DeleteCount.
+//
+// When associations are deleted in cascade, see WithCascade and query.Cascade, the entities are selected for
+// update and deleted by primary key along with the associations, within a transaction of the operation scope.
 func (s *Store[Entity, DTO, ID]) Delete(ctx context.Context, params ...query.Param) error {
+	_, err := s.DeleteCount(ctx, params...)
+
+	return err
+}
+
+// DeleteCount removes the entities matching the query parameters like Delete, and returns their number, the
+// associations deleted in cascade excluded. With WithRequireRowsAffected, it returns store.ErrNoRowsAffected when
+// no entity is deleted.
+func (s *Store[Entity, DTO, ID]) DeleteCount(ctx context.Context, params ...query.Param) (int64, error) {
+	queryParams := query.NewParams(params...)
+	if err := s.requirePartitionKey(queryParams); err != nil {
+		return 0, err
+	}
+
+	if err := runDeleteHook(ctx, s.Hooks.BeforeDelete, queryParams); err != nil {
+		return 0, err
+	}
+
 	var (
-		dto    DTO
-		scopes = s.ScopeBuilder.Build(query.NewParams(params...))
+		dto      DTO
+		scopes   = s.ScopeBuilder.Build(queryParams)
+		affected int64
 	)
 
+	if cascade := s.cascade(queryParams); len(cascade) > 0 {
+		dtos, err := s.deleteSelected(ctx, scopes, cascade, isUnscoped(queryParams))
+		if err != nil {
+			return 0, err
+		}
+
+		affected = int64(len(dtos))
+	} else {
+		tx := s.getTx(ctx).Scopes(scopes...)
+
+		if tx.Error != nil {
+			return 0, tx.Error
+		}
+
+		tx = tx.Delete(&dto)
+		if tx.Error != nil {
+			return 0, s.ErrorTranslator(tx.Error)
+		}
+
+		affected = tx.RowsAffected
+	}
+
+	if err := s.requireRowsAffected(affected); err != nil {
+		return 0, err
+	}
+
+	return affected, runDeleteHook(ctx, s.Hooks.AfterDelete, queryParams)
+}
+
+// requireRowsAffected returns store.ErrNoRowsAffected if no row is affected and the store requires rows affected,
+// see WithRequireRowsAffected.
+func (s *Store[Entity, DTO, ID]) requireRowsAffected(affected int64) error {
+	if affected == 0 && s.RequireRowsAffected {
+		return fmt.Errorf("%w: %s", store.ErrNoRowsAffected, store.EntityName[Entity]())
+	}
+
+	return nil
+}
+
+// DeleteReturning deletes the entities that satisfy the provided query parameters and returns them.
+//
+// The entities are returned by a RETURNING clause when the dialect supports it (e.g. PostgreSQL and SQLite)
+// and no association is deleted in cascade. Otherwise they are selected for update and deleted by primary key
+// within a transaction of the operation scope.
+func (s *Store[Entity, DTO, ID]) DeleteReturning(ctx context.Context, params ...query.Param) (_ []Entity, err error) {
+	defer recoverConversionError(&err)
+
+	queryParams := query.NewParams(params...)
+	if err := s.requirePartitionKey(queryParams); err != nil {
+		return nil, err
+	}
+
+	if err := runDeleteHook(ctx, s.Hooks.BeforeDelete, queryParams); err != nil {
+		return nil, err
+	}
+
+	var (
+		dtos    []DTO
+		scopes  = s.ScopeBuilder.Build(queryParams)
+		cascade = s.cascade(queryParams)
+	)
+
+	if len(cascade) == 0 && supportsReturning(s.getTx(ctx)) {
+		tx := s.getTx(ctx).Scopes(scopes...)
+
+		if tx.Error != nil {
+			return nil, tx.Error
+		}
+
+		if err := tx.Clauses(clause.Returning{}).Delete(&dtos).Error; err != nil {
+			return nil, s.ErrorTranslator(err)
+		}
+	} else if dtos, err = s.deleteSelected(ctx, scopes, cascade, isUnscoped(queryParams)); err != nil {
+		return nil, err
+	}
+
+	if err := runDeleteHook(ctx, s.Hooks.AfterDelete, queryParams); err != nil {
+		return nil, err
+	}
+
+	return s.toEntities(dtos), nil
+}
+
+// deleteSelected selects for update the DTOs matched by the scopes and deletes them by primary key, along with
+// the given associations, within a transaction of the operation scope. It returns the deleted DTOs.
+// Unscoped deletes remove the soft-deleted DTOs matched by the scopes too.
+func (s *Store[Entity, DTO, ID]) deleteSelected(
+	ctx context.Context,
+	scopes []gormquery.ScopeFunc,
+	cascade []string,
+	unscoped bool,
+) (_ []DTO, err error) {
+	ctx, err = s.OpScope.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer s.OpScope.EndWithRecover(ctx, &err)
+
+	var dtos []DTO
+
 	tx := s.getTx(ctx).Scopes(scopes...)
 
 	if tx.Error != nil {
-		return tx.Error
+		return nil, tx.Error
 	}
 
-	if err := tx.Delete(&dto).Error; err != nil {
-		return err
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Find(&dtos).Error; err != nil {
+		return nil, err
 	}
 
-	return nil
+	if len(dtos) == 0 {
+		return nil, nil
+	}
+
+	tx = s.getTx(ctx)
+
+	if unscoped {
+		tx = tx.Unscoped()
+	}
+
+	if len(cascade) > 0 {
+		tx = tx.Select(cascade)
+	}
+
+	if err := tx.Delete(&dtos).Error; err != nil {
+		return nil, s.ErrorTranslator(err)
+	}
+
+	return dtos, nil
+}
+
+// cascade returns the associations to delete with the entities: the ones of the store followed by
+// the ones of the query.Cascade params.
+func (s *Store[Entity, DTO, ID]) cascade(params query.Params) []string {
+	cascadeParams := params.Get(query.TypeCascade)
+	if len(cascadeParams) == 0 {
+		return s.Cascade
+	}
+
+	associations := append([]string{}, s.Cascade...)
+
+	for _, p := range cascadeParams {
+		associations = append(associations, p.(query.CascadeParam).Associations...)
+	}
+
+	return associations
 }
 
 // Upsert either creates a new entity or updates an existing one based on the provided conflict resolution strategy.
 // Returns the ID of the affected entity and an error if the operation fails.
-func (s *Store[Entity, DTO, ID]) Upsert(ctx context.Context, entity Entity, onConflict store.OnConflict) (ID, error) {
-	dto := s.Converter.ToDTO(entity)
+// The filters of onConflict.UpdateWhere are rendered as the WHERE condition of the DO UPDATE clause, their columns
+// being qualified with the table name. It returns an error wrapping gormquery.ErrInvalidParam if one is invalid.
+// The onConflict.UpdateExprs are assigned after the updates of onConflict.Updates or onConflict.UpdateColumns, and
+// onConflict.TargetWhere is rendered as the WHERE predicate of the conflict target.
+func (s *Store[Entity, DTO, ID]) Upsert(
+	ctx context.Context,
+	entity Entity,
+	onConflict store.OnConflict,
+) (_ ID, err error) {
+	defer recoverConversionError(&err)
+
+	if err := runHook(ctx, s.Hooks.BeforeCreate, entity); err != nil {
+		return *new(ID), err
+	}
+
+	if err := s.validate(ctx, entity); err != nil {
+		return *new(ID), err
+	}
+
+	dtos := []DTO{s.Converter.ToDTO(entity)}
+	if err := s.generateIDs(ctx, dtos); err != nil {
+		return *new(ID), err
+	}
+
 	c := clause.OnConflict{
 		Columns:      []clause.Column{},
 		OnConstraint: onConflict.OnConstraint,
@@ -257,13 +743,114 @@ func (s *Store[Entity, DTO, ID]) Upsert(ctx context.Context, entity Entity, onCo
 		c.DoUpdates = clause.AssignmentColumns(onConflict.UpdateColumns)
 	}
 
-	if err := s.getTx(ctx).Clauses(c).Create(&dto).Error; err != nil {
-		return *new(ID), err
+	c.DoUpdates = append(c.DoUpdates, updateExprs(onConflict.UpdateExprs)...)
+
+	if onConflict.TargetWhere != "" {
+		c.TargetWhere.Exprs = []clause.Expression{clause.Expr{SQL: onConflict.TargetWhere}}
 	}
 
-	return dto.GetID(), nil
+	for _, filter := range onConflict.UpdateWhere {
+		if excluded, ok := filter.Value.(store.ExcludedColumn); ok {
+			filter.Value = clause.Column{Table: "excluded", Name: string(excluded)}
+		}
+
+		expr, err := s.ScopeBuilder.Condition(filter, clause.CurrentTable)
+		if err != nil {
+			return *new(ID), err
+		}
+
+		c.Where.Exprs = append(c.Where.Exprs, expr)
+	}
+
+	if err := s.getTx(ctx).Clauses(c).Create(&dtos[0]).Error; err != nil {
+		return *new(ID), s.ErrorTranslator(err)
+	}
+
+	return dtos[0].GetID(), s.afterCreate(ctx, dtos)
+}
+
+// updateExprs returns the assignments of the OnConflict.UpdateExprs, sorted by column for a stable statement.
+func updateExprs(exprs map[string]string) []clause.Assignment {
+	cols := make([]string, 0, len(exprs))
+	for col := range exprs {
+		cols = append(cols, col)
+	}
+
+	sort.Strings(cols)
+
+	assignments := make([]clause.Assignment, len(cols))
+	for i, col := range cols {
+		assignments[i] = clause.Assignment{Column: clause.Column{Name: col}, Value: gorm.Expr(exprs[col])}
+	}
+
+	return assignments
+}
+
+// supportsReturning reports whether the dialect of the database supports RETURNING clauses on DELETE statements.
+func supportsReturning(db *gorm.DB) bool {
+	for _, name := range db.Callback().Delete().Clauses {
+		if name == "RETURNING" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Ping checks that the database is reachable by running `SELECT 1`, within the transaction carried by
+// the context if any.
+func (s *Store[Entity, DTO, ID]) Ping(ctx context.Context) error {
+	return s.OpScope.Tx(ctx).WithContext(ctx).Exec("SELECT 1").Error
+}
+
+// Model returns a new DTO, the GORM model of the table of the store, e.g. to migrate it with gormmigrate.
+func (s *Store[Entity, DTO, ID]) Model() any {
+	return new(DTO)
 }
 
 func (s *Store[Entity, DTO, ID]) getTx(ctx context.Context) *gorm.DB {
-	return s.OpScope.Tx(ctx).WithContext(ctx).Model(new(DTO))
+	return s.session(ctx, s.OpScope.Tx(ctx))
+}
+
+// getReadTx returns the database the reads with the params run on: the read scope, see WithReadScope, unless
+// ctx carries a transaction of the operation scope or the params lock the rows, which require the primary.
+func (s *Store[Entity, DTO, ID]) getReadTx(ctx context.Context, params query.Params) *gorm.DB {
+	if s.ReadScope == nil || s.OpScope.InTransaction(ctx) || len(params.Get(query.TypeWithLock)) > 0 {
+		return s.getTx(ctx)
+	}
+
+	return s.session(ctx, s.ReadScope.Tx(ctx))
+}
+
+// session returns the session of db for the operations of the store with ctx.
+func (s *Store[Entity, DTO, ID]) session(ctx context.Context, db *gorm.DB) *gorm.DB {
+	db = db.WithContext(ctx)
+
+	if s.Logger != nil {
+		db = db.Session(&gorm.Session{
+			Logger: gormLogger{logger: s.Logger, entity: store.EntityName[Entity]()},
+		})
+	}
+
+	return dryRunSession(ctx, db).Model(new(DTO))
+}
+
+// recoverConversionError returns the converter.ConversionError the operation panicked with as its error
+// when gormquery runs in lenient mode. Other panics, and all panics in strict mode, are propagated.
+func recoverConversionError(errPtr *error) {
+	if gormquery.CurrentMode() != gormquery.ModeLenient {
+		return
+	}
+
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	if convErr, ok := r.(*converter.ConversionError); ok {
+		*errPtr = convErr
+		return
+	}
+
+	panic(r)
 }
@@ -3,16 +3,29 @@ package gormstore_test
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
+	"github.com/infevocorp/goflexstore/converter"
 	"github.com/infevocorp/goflexstore/filters"
+	"github.com/infevocorp/goflexstore/flexlog"
 	gormopscope "github.com/infevocorp/goflexstore/gorm/opscope"
+	gormquery "github.com/infevocorp/goflexstore/gorm/query"
 	gormstore "github.com/infevocorp/goflexstore/gorm/store"
+	gormtest "github.com/infevocorp/goflexstore/gorm/test"
+	"github.com/infevocorp/goflexstore/idgen"
 	"github.com/infevocorp/goflexstore/query"
+	"github.com/infevocorp/goflexstore/store"
 )
 
 func Test_Store_Get(t *testing.T) {
@@ -81,7 +94,7 @@ func Test_Store_Get(t *testing.T) {
 	for i := range tests {
 		tt := tests[i]
 		t.Run(tt.name, func(t *testing.T) {
-			db, sqlMock := newTestDB(t)
+			db, sqlMock := gormtest.NewDB(t)
 
 			d := deps{
 				sqlMock: sqlMock,
@@ -102,3 +115,2293 @@ func Test_Store_Get(t *testing.T) {
 		})
 	}
 }
+
+type MismatchedUser struct {
+	ID   int
+	Name int
+}
+
+func (e MismatchedUser) GetID() int {
+	return e.ID
+}
+
+func Test_Store_LenientMode(t *testing.T) {
+	newStore := func(db *gorm.DB) *gormstore.Store[MismatchedUser, UserDTO, int] {
+		return gormstore.New[MismatchedUser, UserDTO, int](gormopscope.NewWriteTransactionScope("test", db))
+	}
+
+	expectGet := func(sqlMock sqlmock.Sqlmock) {
+		gormtest.ExpectQuery(sqlMock, "SELECT * FROM `user_dtos` WHERE id = ? ORDER BY `user_dtos`.`id` LIMIT 1", 1).
+			WillReturnRows(gormtest.Rows([]string{"id", "name", "age"}, []driver.Value{1, "user_name", 42}))
+	}
+
+	t.Run("should-panic-on-conversion-error-in-strict-mode", func(t *testing.T) {
+		// GIVEN
+		db, sqlMock := gormtest.NewDB(t)
+		expectGet(sqlMock)
+
+		s := newStore(db)
+
+		// WHEN
+		get := func() {
+			_, _ = s.Get(context.Background(), filters.IDs(1))
+		}
+
+		// THEN
+		assert.PanicsWithError(t, "cannot assign src.Name(string) to dst.Name(int)", get)
+	})
+
+	t.Run("should-return-conversion-error-in-lenient-mode", func(t *testing.T) {
+		// GIVEN
+		gormquery.SetMode(gormquery.ModeLenient)
+		t.Cleanup(func() { gormquery.SetMode(gormquery.ModeStrict) })
+
+		db, sqlMock := gormtest.NewDB(t)
+		expectGet(sqlMock)
+
+		s := newStore(db)
+
+		// WHEN
+		_, err := s.Get(context.Background(), filters.IDs(1))
+
+		// THEN
+		var convErr *converter.ConversionError
+
+		require.ErrorAs(t, err, &convErr)
+		assert.EqualError(t, err, "cannot assign src.Name(string) to dst.Name(int)")
+	})
+
+	t.Run("should-return-invalid-param-error-in-lenient-mode", func(t *testing.T) {
+		// GIVEN
+		gormquery.SetMode(gormquery.ModeLenient)
+		t.Cleanup(func() { gormquery.SetMode(gormquery.ModeStrict) })
+
+		db, _ := gormtest.NewDB(t)
+		s := gormstore.New[User, UserDTO, int](gormopscope.NewWriteTransactionScope("test", db))
+
+		// WHEN
+		_, err := s.List(context.Background(), query.Filter("Name", nil))
+
+		// THEN
+		assert.ErrorIs(t, err, gormquery.ErrInvalidParam)
+	})
+}
+
+func Test_Store_List(t *testing.T) {
+	expectList := func(sqlMock sqlmock.Sqlmock, rows *sqlmock.Rows) {
+		gormtest.ExpectQuery(sqlMock, "SELECT * FROM `user_dtos` LIMIT 10").WillReturnRows(rows)
+	}
+
+	t.Run("should-list-entities", func(t *testing.T) {
+		// GIVEN
+		db, sqlMock := gormtest.NewDB(t)
+		expectList(sqlMock, sqlmock.NewRows([]string{"id", "name", "age"}).
+			AddRow(1, "user1", 21).
+			AddRow(2, "user2", 42))
+
+		s := gormstore.New[User, UserDTO, int](gormopscope.NewWriteTransactionScope("test", db))
+
+		// WHEN
+		users, err := s.List(context.Background(), query.Paginate(0, 10))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, []User{
+			{ID: 1, Name: "user1", Age: 21},
+			{ID: 2, Name: "user2", Age: 42},
+		}, users)
+	})
+
+	t.Run("should-return-nil-when-empty", func(t *testing.T) {
+		// GIVEN
+		db, sqlMock := gormtest.NewDB(t)
+		expectList(sqlMock, sqlmock.NewRows([]string{"id", "name", "age"}))
+
+		s := gormstore.New[User, UserDTO, int](gormopscope.NewWriteTransactionScope("test", db))
+
+		// WHEN
+		users, err := s.List(context.Background(), query.Paginate(0, 10))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Nil(t, users)
+	})
+
+	t.Run("should-return-empty-slice-when-empty-slices-enabled", func(t *testing.T) {
+		// GIVEN
+		db, sqlMock := gormtest.NewDB(t)
+		expectList(sqlMock, sqlmock.NewRows([]string{"id", "name", "age"}))
+
+		s := gormstore.New[User, UserDTO, int](
+			gormopscope.NewWriteTransactionScope("test", db),
+			gormstore.WithEmptySlices[User, UserDTO, int](true),
+		)
+
+		// WHEN
+		users, err := s.List(context.Background(), query.Paginate(0, 10))
+
+		// THEN
+		require.NoError(t, err)
+		assert.NotNil(t, users)
+		assert.Empty(t, users)
+	})
+}
+
+func Test_Store_Upsert_UpdateWhere(t *testing.T) {
+	newStore := func(t *testing.T) *gormstore.Store[Document, Document, int] {
+		db := newSQLiteDB(t)
+		require.NoError(t, db.Create(&Document{ID: 1, Title: "stored", Version: 10}).Error)
+
+		return gormstore.New[Document, Document, int](gormopscope.NewWriteTransactionScope("test", db))
+	}
+
+	lastWriteWins := store.OnConflict{
+		Columns:   []string{"id"},
+		UpdateAll: true,
+		UpdateWhere: []query.FilterParam{
+			query.Filter("Version", store.Excluded("Version")).WithOP(query.LT),
+		},
+	}
+
+	tests := []struct {
+		name   string
+		entity Document
+		want   Document
+	}{
+		{
+			name:   "should-update-with-newer-row",
+			entity: Document{ID: 1, Title: "newer", Version: 20},
+			want:   Document{ID: 1, Title: "newer", Version: 20},
+		},
+		{
+			name:   "should-not-update-with-older-row",
+			entity: Document{ID: 1, Title: "older", Version: 5},
+			want:   Document{ID: 1, Title: "stored", Version: 10},
+		},
+	}
+
+	for i := range tests {
+		tt := tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			s := newStore(t)
+			ctx := context.Background()
+
+			// WHEN
+			_, err := s.Upsert(ctx, tt.entity, lastWriteWins)
+
+			// THEN
+			require.NoError(t, err)
+
+			got, err := s.Get(ctx, filters.IDs(1))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("should-return-invalid-param-error", func(t *testing.T) {
+		// GIVEN
+		s := newStore(t)
+
+		// WHEN
+		_, err := s.Upsert(context.Background(), Document{ID: 1}, store.OnConflict{
+			Columns:     []string{"id"},
+			UpdateAll:   true,
+			UpdateWhere: []query.FilterParam{query.Filter("Version", nil)},
+		})
+
+		// THEN
+		assert.ErrorIs(t, err, gormquery.ErrInvalidParam)
+	})
+}
+
+type Membership struct {
+	ID     int    `gorm:"column:id;primaryKey"`
+	Email  string `gorm:"column:email"`
+	Active bool   `gorm:"column:active"`
+	Logins int    `gorm:"column:logins"`
+}
+
+func (m Membership) GetID() int {
+	return m.ID
+}
+
+func Test_Store_Upsert_UpdateExprs(t *testing.T) {
+	t.Run("should-update-with-expressions", func(t *testing.T) {
+		// GIVEN
+		db := newSQLiteDB(t)
+		require.NoError(t, db.Create(&Document{ID: 1, Title: "stored", Version: 10}).Error)
+
+		s := gormstore.New[Document, Document, int](gormopscope.NewWriteTransactionScope("test", db))
+
+		// WHEN
+		_, err := s.Upsert(context.Background(), Document{ID: 1, Title: "upserted", Version: 5}, store.OnConflict{
+			Columns:       []string{"id"},
+			UpdateColumns: []string{"title"},
+			UpdateExprs:   map[string]string{"version": "version + excluded.version"},
+		})
+
+		// THEN
+		require.NoError(t, err)
+
+		got, err := s.Get(context.Background(), filters.IDs(1))
+		require.NoError(t, err)
+		assert.Equal(t, Document{ID: 1, Title: "upserted", Version: 15}, got)
+	})
+
+	t.Run("should-target-partial-unique-index", func(t *testing.T) {
+		// GIVEN
+		db := newSQLiteDB(t)
+		require.NoError(t, db.AutoMigrate(&Membership{}))
+		require.NoError(t, db.Exec("CREATE UNIQUE INDEX idx_active_email ON memberships (email) WHERE active = 1").Error)
+		require.NoError(t, db.Create([]Membership{
+			{ID: 1, Email: "a@example.com", Active: false, Logins: 1},
+			{ID: 2, Email: "a@example.com", Active: true, Logins: 1},
+		}).Error)
+
+		s := gormstore.New[Membership, Membership, int](gormopscope.NewWriteTransactionScope("test", db))
+
+		// WHEN
+		_, err := s.Upsert(context.Background(), Membership{ID: 3, Email: "a@example.com", Active: true, Logins: 1},
+			store.OnConflict{
+				Columns:     []string{"email"},
+				TargetWhere: "active = 1",
+				UpdateExprs: map[string]string{"logins": "logins + 1"},
+			})
+
+		// THEN
+		require.NoError(t, err)
+
+		memberships, err := s.List(context.Background(), query.OrderBy("ID", false))
+		require.NoError(t, err)
+		assert.Equal(t, []Membership{
+			{ID: 1, Email: "a@example.com", Active: false, Logins: 1},
+			{ID: 2, Email: "a@example.com", Active: true, Logins: 2},
+		}, memberships)
+	})
+}
+
+func Test_Store_DeleteReturning(t *testing.T) {
+	t.Run("should-delete-returning-with-returning-clause", func(t *testing.T) {
+		// GIVEN
+		db := newSQLiteDB(t)
+		require.NoError(t, db.Create([]Document{
+			{ID: 1, Title: "first", Version: 1},
+			{ID: 2, Title: "second", Version: 2},
+			{ID: 3, Title: "third", Version: 3},
+		}).Error)
+
+		s := gormstore.New[Document, Document, int](gormopscope.NewWriteTransactionScope("test", db))
+
+		// WHEN
+		deleted, err := s.DeleteReturning(context.Background(), query.Filter("Version", 2).WithOP(query.GTE))
+
+		// THEN
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []Document{
+			{ID: 2, Title: "second", Version: 2},
+			{ID: 3, Title: "third", Version: 3},
+		}, deleted)
+
+		remaining, err := s.List(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []Document{{ID: 1, Title: "first", Version: 1}}, remaining)
+	})
+
+	t.Run("should-select-and-delete-without-returning-clause", func(t *testing.T) {
+		// GIVEN
+		db, sqlMock := gormtest.NewDB(t)
+		s := gormstore.New[User, UserDTO, int](gormopscope.NewWriteTransactionScope("test", db))
+
+		sqlMock.ExpectBegin()
+		gormtest.ExpectQuery(sqlMock, "SELECT * FROM `user_dtos` WHERE age > ? FOR UPDATE", 40).
+			WillReturnRows(gormtest.Rows([]string{"id", "name", "age"},
+				[]driver.Value{1, "user1", 42},
+				[]driver.Value{2, "user2", 43},
+			))
+		gormtest.ExpectExec(sqlMock, "DELETE FROM `user_dtos` WHERE `user_dtos`.`id` IN (?,?)", 1, 2).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		sqlMock.ExpectCommit()
+
+		// WHEN
+		deleted, err := s.DeleteReturning(context.Background(), query.Filter("age", 40).WithOP(query.GT))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, []User{
+			{ID: 1, Name: "user1", Age: 42},
+			{ID: 2, Name: "user2", Age: 43},
+		}, deleted)
+	})
+
+	t.Run("should-not-delete-when-nothing-matches", func(t *testing.T) {
+		// GIVEN
+		db, sqlMock := gormtest.NewDB(t)
+		s := gormstore.New[User, UserDTO, int](gormopscope.NewWriteTransactionScope("test", db))
+
+		sqlMock.ExpectBegin()
+		gormtest.ExpectQuery(sqlMock, "SELECT * FROM `user_dtos` WHERE age > ? FOR UPDATE", 40).
+			WillReturnRows(gormtest.Rows([]string{"id", "name", "age"}))
+		sqlMock.ExpectCommit()
+
+		// WHEN
+		deleted, err := s.DeleteReturning(context.Background(), query.Filter("age", 40).WithOP(query.GT))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Nil(t, deleted)
+	})
+}
+
+type Post struct {
+	ID       int        `gorm:"column:id;primaryKey"`
+	Title    string     `gorm:"column:title"`
+	Comments []*Comment `gorm:"foreignKey:PostID"`
+	Labels   []*Label   `gorm:"many2many:post_labels"`
+}
+
+func (p *Post) GetID() int {
+	return p.ID
+}
+
+type Comment struct {
+	ID        int            `gorm:"column:id;primaryKey"`
+	PostID    int            `gorm:"column:post_id"`
+	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at"`
+}
+
+func (c *Comment) GetID() int {
+	return c.ID
+}
+
+type Label struct {
+	ID   int    `gorm:"column:id;primaryKey"`
+	Name string `gorm:"column:name"`
+}
+
+func Test_Store_Delete_Cascade(t *testing.T) {
+	newDB := func(t *testing.T) *gorm.DB {
+		db := newSQLiteDB(t)
+
+		require.NoError(t, db.AutoMigrate(&Post{}, &Comment{}, &Label{}))
+		require.NoError(t, db.Create([]*Post{
+			{ID: 1, Comments: []*Comment{{ID: 1}, {ID: 2}}, Labels: []*Label{{ID: 1, Name: "go"}}},
+			{ID: 2, Comments: []*Comment{{ID: 3}}, Labels: []*Label{{ID: 2, Name: "sql"}}},
+		}).Error)
+
+		return db
+	}
+
+	count := func(t *testing.T, db *gorm.DB, table string) int64 {
+		var n int64
+
+		require.NoError(t, db.Table(table).Count(&n).Error)
+
+		return n
+	}
+
+	t.Run("should-delete-associations-of-call", func(t *testing.T) {
+		// GIVEN
+		db := newDB(t)
+		s := gormstore.New[*Post, *Post, int](gormopscope.NewWriteTransactionScope("test", db))
+
+		// WHEN
+		err := s.Delete(context.Background(), filters.IDs(1), query.Cascade("Comments", "Labels"))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count(t, db, "posts"))
+		assert.Equal(t, int64(1), count(t, db, "post_labels"))
+		assert.Equal(t, int64(2), count(t, db, "labels"))
+
+		var comments []Comment
+		require.NoError(t, db.Unscoped().Order("id").Find(&comments).Error)
+		require.Len(t, comments, 3)
+		assert.True(t, comments[0].DeletedAt.Valid)
+		assert.True(t, comments[1].DeletedAt.Valid)
+		assert.False(t, comments[2].DeletedAt.Valid)
+	})
+
+	t.Run("should-delete-associations-of-store", func(t *testing.T) {
+		// GIVEN
+		db := newDB(t)
+		s := gormstore.New[*Post, *Post, int](
+			gormopscope.NewWriteTransactionScope("test", db),
+			gormstore.WithCascade[*Post, *Post, int](clause.Associations),
+		)
+
+		// WHEN
+		deleted, err := s.DeleteReturning(context.Background(), filters.IDs(2))
+
+		// THEN
+		require.NoError(t, err)
+		require.Len(t, deleted, 1)
+		assert.Equal(t, 2, deleted[0].ID)
+		assert.Equal(t, int64(1), count(t, db, "posts"))
+		assert.Equal(t, int64(1), count(t, db, "post_labels"))
+
+		var comments int64
+		require.NoError(t, db.Model(&Comment{}).Count(&comments).Error)
+		assert.Equal(t, int64(2), comments)
+	})
+
+	t.Run("should-not-delete-associations-by-default", func(t *testing.T) {
+		// GIVEN
+		db := newDB(t)
+		s := gormstore.New[*Post, *Post, int](gormopscope.NewWriteTransactionScope("test", db))
+
+		// WHEN
+		err := s.Delete(context.Background(), filters.IDs(1))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count(t, db, "posts"))
+		assert.Equal(t, int64(2), count(t, db, "post_labels"))
+	})
+}
+
+type Note struct {
+	ID   int    `gorm:"column:id;primaryKey"`
+	Text string `gorm:"column:text"`
+}
+
+func (n *Note) GetID() int {
+	return n.ID
+}
+
+func (n *Note) Validate() error {
+	if n.Text == "" {
+		return store.FieldError{Field: "Text", Rule: "required", Message: "is required"}
+	}
+
+	return nil
+}
+
+func Test_Store_Validator(t *testing.T) {
+	newStore := func(t *testing.T, validator gormstore.Validator) (*gormstore.Store[*Note, *Note, int], *gorm.DB) {
+		db := newSQLiteDB(t)
+		require.NoError(t, db.AutoMigrate(&Note{}))
+
+		return gormstore.New[*Note, *Note, int](
+			gormopscope.NewWriteTransactionScope("test", db),
+			gormstore.WithValidator[*Note, *Note, int](validator),
+		), db
+	}
+
+	t.Run("should-reject-invalid-entities", func(t *testing.T) {
+		// GIVEN
+		s, db := newStore(t, gormstore.MethodValidator)
+		ctx := context.Background()
+
+		// WHEN
+		_, createErr := s.Create(ctx, &Note{})
+		createManyErr := s.CreateMany(ctx, []*Note{{Text: "valid"}, {}})
+		updateErr := s.Update(ctx, &Note{ID: 1})
+		_, upsertErr := s.Upsert(ctx, &Note{ID: 1}, store.OnConflict{UpdateAll: true})
+
+		// THEN
+		for _, err := range []error{createErr, createManyErr, updateErr, upsertErr} {
+			var validationErr *store.ValidationError
+
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, "Note", validationErr.Entity)
+			assert.Equal(t, []store.FieldError{{Field: "Text", Rule: "required", Message: "is required"}},
+				validationErr.Fields)
+		}
+
+		var count int64
+		require.NoError(t, db.Model(&Note{}).Count(&count).Error)
+		assert.Zero(t, count)
+	})
+
+	t.Run("should-write-valid-entities", func(t *testing.T) {
+		// GIVEN
+		var validated []any
+
+		s, _ := newStore(t, gormstore.ValidatorFunc(func(_ context.Context, entity any) error {
+			validated = append(validated, entity)
+			return nil
+		}))
+		note := &Note{Text: "valid"}
+
+		// WHEN
+		id, err := s.Create(context.Background(), note)
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, 1, id)
+		assert.Equal(t, []any{note}, validated)
+	})
+
+	t.Run("should-not-validate-partial-updates", func(t *testing.T) {
+		// GIVEN
+		s, _ := newStore(t, gormstore.MethodValidator)
+		ctx := context.Background()
+
+		_, err := s.Create(ctx, &Note{Text: "valid"})
+		require.NoError(t, err)
+
+		// WHEN
+		err = s.PartialUpdate(ctx, &Note{ID: 1}, filters.IDs(1))
+
+		// THEN
+		require.NoError(t, err)
+	})
+}
+
+func Test_Store_Hooks(t *testing.T) {
+	newStore := func(t *testing.T, hooks gormstore.Hooks[*Note]) *gormstore.Store[*Note, *Note, int] {
+		db := newSQLiteDB(t)
+		require.NoError(t, db.AutoMigrate(&Note{}))
+
+		return gormstore.New[*Note, *Note, int](
+			gormopscope.NewWriteTransactionScope("test", db),
+			gormstore.WithValidator[*Note, *Note, int](gormstore.MethodValidator),
+			gormstore.WithHooks[*Note, *Note, int](hooks),
+		)
+	}
+
+	t.Run("should-run-hooks-around-writes", func(t *testing.T) {
+		// GIVEN
+		var calls []string
+
+		record := func(name string) func(context.Context, *Note) error {
+			return func(_ context.Context, note *Note) error {
+				calls = append(calls, fmt.Sprintf("%s %d %s", name, note.ID, note.Text))
+				return nil
+			}
+		}
+
+		s := newStore(t, gormstore.Hooks[*Note]{
+			BeforeCreate: func(_ context.Context, note *Note) error {
+				// enriches the entity before its validation.
+				note.Text = "created"
+				return nil
+			},
+			AfterCreate:  record("AfterCreate"),
+			BeforeUpdate: record("BeforeUpdate"),
+			AfterUpdate:  record("AfterUpdate"),
+			BeforeDelete: func(_ context.Context, params query.Params) error {
+				calls = append(calls, fmt.Sprintf("BeforeDelete %d", params.Len()))
+				return nil
+			},
+			AfterDelete: func(_ context.Context, params query.Params) error {
+				calls = append(calls, fmt.Sprintf("AfterDelete %d", params.Len()))
+				return nil
+			},
+		})
+		ctx := context.Background()
+
+		// WHEN
+		id, createErr := s.Create(ctx, &Note{})
+		updateErr := s.Update(ctx, &Note{ID: id, Text: "updated"}, filters.IDs(id))
+		deleteErr := s.Delete(ctx, filters.IDs(id))
+
+		// THEN
+		require.NoError(t, createErr)
+		require.NoError(t, updateErr)
+		require.NoError(t, deleteErr)
+		assert.Equal(t, []string{
+			"AfterCreate 1 created",
+			"BeforeUpdate 1 updated",
+			"AfterUpdate 1 updated",
+			"BeforeDelete 1",
+			"AfterDelete 1",
+		}, calls)
+	})
+
+	t.Run("should-cancel-write-when-before-hook-fails", func(t *testing.T) {
+		// GIVEN
+		errHook := errors.New("rejected")
+		s := newStore(t, gormstore.Hooks[*Note]{
+			BeforeCreate: func(context.Context, *Note) error { return errHook },
+			AfterCreate: func(context.Context, *Note) error {
+				t.Fatal("after hook of a canceled write")
+				return nil
+			},
+		})
+
+		// WHEN
+		_, err := s.Create(context.Background(), &Note{Text: "valid"})
+
+		// THEN
+		assert.ErrorIs(t, err, errHook)
+
+		count, err := s.Count(context.Background())
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+}
+
+type Event struct {
+	ID     int    `gorm:"column:id;primaryKey"`
+	Region string `gorm:"column:region"`
+	Name   string `gorm:"column:name"`
+}
+
+func (e *Event) GetID() int {
+	return e.ID
+}
+
+func Test_Store_PartitionKey(t *testing.T) {
+	newStore := func(t *testing.T) *gormstore.Store[*Event, *Event, int] {
+		db := newSQLiteDB(t)
+		require.NoError(t, db.AutoMigrate(&Event{}))
+
+		s := gormstore.New[*Event, *Event, int](
+			gormopscope.NewWriteTransactionScope("test", db),
+			gormstore.WithPartitionKey[*Event, *Event, int]("Region"),
+		)
+
+		require.NoError(t, s.CreateMany(context.Background(), []*Event{
+			{Region: "eu", Name: "a"},
+			{Region: "us", Name: "b"},
+		}))
+
+		return s
+	}
+
+	t.Run("should-refuse-queries-without-partition-key", func(t *testing.T) {
+		// GIVEN
+		s := newStore(t)
+		ctx := context.Background()
+
+		// WHEN
+		_, getErr := s.Get(ctx, filters.IDs(1))
+		_, listErr := s.List(ctx)
+		_, countErr := s.Count(ctx, query.Filter("Name", "a"))
+		_, existsErr := s.Exists(ctx)
+		updateErr := s.Update(ctx, &Event{ID: 1, Region: "eu", Name: "c"})
+		partialUpdateErr := s.PartialUpdate(ctx, &Event{Name: "c"}, filters.IDs(1))
+		deleteErr := s.Delete(ctx, filters.IDs(1))
+		_, deleteReturningErr := s.DeleteReturning(ctx, query.OR(
+			query.Filter("Region", "eu"),
+			query.Filter("Name", "b"),
+		))
+
+		// THEN
+		for _, err := range []error{
+			getErr, listErr, countErr, existsErr, updateErr, partialUpdateErr, deleteErr, deleteReturningErr,
+		} {
+			assert.ErrorIs(t, err, gormstore.ErrMissingPartitionKey)
+		}
+
+		count, err := s.Count(ctx, query.Filter("Region", []string{"eu", "us"}))
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+	})
+
+	t.Run("should-accept-queries-with-partition-key", func(t *testing.T) {
+		// GIVEN
+		s := newStore(t)
+		ctx := context.Background()
+
+		// WHEN
+		event, getErr := s.Get(ctx, filters.IDs(1), query.Filter("Region", "eu"))
+		events, listErr := s.List(ctx, query.OR(
+			query.Filter("Region", "eu"),
+			query.Filter("Region", "us"),
+		))
+		deleteErr := s.Delete(ctx, query.Filter("Region", "us"))
+
+		// THEN
+		require.NoError(t, getErr)
+		require.NoError(t, listErr)
+		require.NoError(t, deleteErr)
+		assert.Equal(t, &Event{ID: 1, Region: "eu", Name: "a"}, event)
+		assert.Len(t, events, 2)
+	})
+}
+
+func Test_Store_Ping(t *testing.T) {
+	t.Run("should-ping-database", func(t *testing.T) {
+		// GIVEN
+		db, sqlMock := gormtest.NewDB(t)
+		s := gormstore.New[User, UserDTO, int](gormopscope.NewWriteTransactionScope("test", db))
+
+		gormtest.ExpectExec(sqlMock, "SELECT 1").WillReturnResult(sqlmock.NewResult(0, 0))
+
+		// WHEN
+		err := s.Ping(context.Background())
+
+		// THEN
+		assert.NoError(t, err)
+	})
+
+	t.Run("should-return-database-error", func(t *testing.T) {
+		// GIVEN
+		db, sqlMock := gormtest.NewDB(t)
+		s := gormstore.New[User, UserDTO, int](gormopscope.NewWriteTransactionScope("test", db))
+
+		gormtest.ExpectExec(sqlMock, "SELECT 1").WillReturnError(sql.ErrConnDone)
+
+		// WHEN
+		err := s.Ping(context.Background())
+
+		// THEN
+		assert.ErrorIs(t, err, sql.ErrConnDone)
+	})
+}
+
+type Category struct {
+	ID       int    `gorm:"column:id;primaryKey"`
+	ParentID *int   `gorm:"column:parent_id"`
+	Name     string `gorm:"column:name"`
+}
+
+func (c Category) GetID() int {
+	return c.ID
+}
+
+func Test_Store_List_With(t *testing.T) {
+	// GIVEN
+	var (
+		db      = newSQLiteDB(t)
+		root    = 1
+		clothes = 2
+		garden  = 3
+		tree    = []Category{
+			{ID: 1, Name: "root"},
+			{ID: 2, ParentID: &root, Name: "clothes"},
+			{ID: 3, ParentID: &root, Name: "garden"},
+			{ID: 4, ParentID: &clothes, Name: "shirts"},
+			{ID: 5, ParentID: &clothes, Name: "pants"},
+			{ID: 6, ParentID: &garden, Name: "tools"},
+		}
+	)
+
+	require.NoError(t, db.AutoMigrate(&Category{}))
+	require.NoError(t, db.Create(tree).Error)
+
+	s := gormstore.New[Category, Category, int](gormopscope.NewWriteTransactionScope("test", db))
+
+	t.Run("should-list-subtree", func(t *testing.T) {
+		// WHEN
+		categories, err := s.List(context.Background(),
+			query.With("subtree", query.Filter("ID", clothes)).WithRecursive("ParentID", "ID"),
+			query.OrderBy("Name", false),
+		)
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, []string{"clothes", "pants", "shirts"}, categoryNames(categories))
+	})
+
+	t.Run("should-list-ancestors", func(t *testing.T) {
+		// WHEN
+		categories, err := s.List(context.Background(),
+			query.With("ancestors", query.Filter("Name", "tools")).WithRecursive("ID", "ParentID"),
+			query.OrderBy("ID", false),
+		)
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, []string{"root", "garden", "tools"}, categoryNames(categories))
+	})
+
+	t.Run("should-count-subtree", func(t *testing.T) {
+		// WHEN
+		count, err := s.Count(context.Background(),
+			query.With("subtree", query.Filter("ID", root)).WithRecursive("ParentID", "ID"),
+			query.Filter("ParentID", garden),
+		)
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+}
+
+func categoryNames(categories []Category) []string {
+	names := make([]string, len(categories))
+	for i, category := range categories {
+		names[i] = category.Name
+	}
+
+	return names
+}
+
+func Test_Store_DryRun(t *testing.T) {
+	t.Run("should-record-statements-without-executing-them", func(t *testing.T) {
+		// GIVEN
+		db, _ := gormtest.NewDB(t)
+		s := gormstore.New[User, UserDTO, int](gormopscope.NewWriteTransactionScope("test", db))
+
+		ctx, recorder := gormstore.DryRun(context.Background())
+
+		// WHEN
+		users, listErr := s.List(ctx, query.Filter("Name", "john"), query.Paginate(0, 10))
+		id, createErr := s.Create(ctx, User{Name: "jane", Age: 20})
+		deleteErr := s.Delete(ctx, query.Filter("Age", 30))
+
+		// THEN
+		require.NoError(t, listErr)
+		require.NoError(t, createErr)
+		require.NoError(t, deleteErr)
+		assert.Empty(t, users)
+		assert.Zero(t, id)
+
+		statements := recorder.Statements()
+		require.Len(t, statements, 3)
+		assert.Equal(t, gormstore.Statement{
+			SQL:  "SELECT * FROM `user_dtos` WHERE name = ? LIMIT 10",
+			Vars: []any{"john"},
+		}, statements[0])
+		assert.Equal(t, "INSERT INTO `user_dtos` (`name`,`age`,`is_admin`,`disabled`) VALUES (?,?,?,?)",
+			statements[1].SQL)
+		assert.Equal(t, []any{"jane", 20}, statements[1].Vars[:2])
+		assert.Equal(t, gormstore.Statement{SQL: "DELETE FROM `user_dtos` WHERE age = ?", Vars: []any{30}}, statements[2])
+	})
+
+	t.Run("should-execute-statements-outside-dry-run", func(t *testing.T) {
+		// GIVEN
+		db, sqlMock := gormtest.NewDB(t)
+		s := gormstore.New[User, UserDTO, int](gormopscope.NewWriteTransactionScope("test", db))
+
+		_, recorder := gormstore.DryRun(context.Background())
+
+		gormtest.ExpectQuery(sqlMock, "SELECT count(*) FROM `user_dtos`").
+			WillReturnRows(gormtest.Rows([]string{"count"}, []driver.Value{2}))
+
+		// WHEN
+		count, err := s.Count(context.Background())
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+		assert.Empty(t, recorder.Statements())
+	})
+}
+
+func Test_Store_Explain(t *testing.T) {
+	t.Run("should-explain-list-query", func(t *testing.T) {
+		// GIVEN
+		db, sqlMock := gormtest.NewDB(t)
+		s := gormstore.New[User, UserDTO, int](gormopscope.NewWriteTransactionScope("test", db))
+
+		gormtest.ExpectQuery(sqlMock, "EXPLAIN SELECT * FROM `user_dtos` WHERE name = ? LIMIT 10").
+			WithArgs("john").
+			WillReturnRows(gormtest.Rows([]string{"id", "table", "type", "key"},
+				[]driver.Value{1, "user_dtos", "ref", "idx_name"},
+			))
+
+		// WHEN
+		plan, err := s.Explain(context.Background(), query.Filter("Name", "john"), query.Paginate(0, 10))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, "id\ttable\ttype\tkey\n1\tuser_dtos\tref\tidx_name", plan)
+	})
+
+	t.Run("should-explain-analyze-list-query", func(t *testing.T) {
+		// GIVEN
+		db, sqlMock := gormtest.NewDB(t)
+		s := gormstore.New[User, UserDTO, int](gormopscope.NewWriteTransactionScope("test", db))
+
+		gormtest.ExpectQuery(sqlMock, "EXPLAIN ANALYZE SELECT * FROM `user_dtos` WHERE age > ?").
+			WithArgs(20).
+			WillReturnRows(gormtest.Rows([]string{"EXPLAIN"},
+				[]driver.Value{"-> Filter: (user_dtos.age > 20)"},
+				[]driver.Value{"    -> Table scan on user_dtos"},
+			))
+
+		// WHEN
+		plan, err := s.ExplainAnalyze(context.Background(), query.Filter("Age", 20).WithOP(query.GT))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, "-> Filter: (user_dtos.age > 20)\n    -> Table scan on user_dtos", plan)
+	})
+
+	t.Run("should-explain-query-plan-on-sqlite", func(t *testing.T) {
+		// GIVEN
+		s := gormstore.New[Document, Document, int](gormopscope.NewWriteTransactionScope("test", newSQLiteDB(t)))
+
+		// WHEN
+		plan, err := s.Explain(context.Background(), query.Filter("Title", "draft"))
+		_, analyzeErr := s.ExplainAnalyze(context.Background())
+
+		// THEN
+		require.NoError(t, err)
+		assert.Contains(t, plan, "SCAN documents")
+		assert.ErrorIs(t, analyzeErr, gormstore.ErrAnalyzeUnsupported)
+	})
+
+	t.Run("should-fail-with-invalid-params", func(t *testing.T) {
+		// GIVEN
+		db, _ := gormtest.NewDB(t)
+		s := gormstore.New[User, UserDTO, int](
+			gormopscope.NewWriteTransactionScope("test", db),
+			gormstore.WithPartitionKey[User, UserDTO, int]("Age"),
+		)
+
+		// WHEN
+		_, err := s.Explain(context.Background(), query.Filter("Name", "john"))
+
+		// THEN
+		assert.ErrorIs(t, err, gormstore.ErrMissingPartitionKey)
+	})
+}
+
+// postgresDialector reports the postgres dialect name while quoting as the wrapped MySQL dialector.
+type postgresDialector struct {
+	gorm.Dialector
+}
+
+func (postgresDialector) Name() string {
+	return "postgres"
+}
+
+func Test_Store_CountEstimate(t *testing.T) {
+	t.Run("should-estimate-table-rows-without-filter", func(t *testing.T) {
+		// GIVEN
+		db, sqlMock := gormtest.NewDB(t)
+		s := gormstore.New[User, UserDTO, int](gormopscope.NewWriteTransactionScope("test", db))
+
+		gormtest.ExpectQuery(sqlMock, "SELECT TABLE_ROWS FROM information_schema.TABLES "+
+			"WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?", "user_dtos").
+			WillReturnRows(gormtest.Rows([]string{"TABLE_ROWS"}, []driver.Value{1200000}))
+
+		// WHEN
+		count, err := s.CountEstimate(context.Background(), query.OrderBy("ID", true), query.Paginate(0, 20))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, int64(1200000), count)
+	})
+
+	t.Run("should-estimate-rows-of-plan-with-indexed-filters", func(t *testing.T) {
+		// GIVEN
+		db, sqlMock := gormtest.NewDB(t)
+		s := gormstore.New[User, UserDTO, int](gormopscope.NewWriteTransactionScope("test", db))
+
+		gormtest.ExpectQuery(sqlMock, "EXPLAIN SELECT * FROM `user_dtos` WHERE id > ?", 100).
+			WillReturnRows(gormtest.Rows([]string{"id", "table", "type", "key", "rows", "filtered"},
+				[]driver.Value{1, "user_dtos", "range", "PRIMARY", 5000, 50.0},
+			))
+
+		// WHEN
+		count, err := s.CountEstimate(context.Background(), query.Filter("ID", 100).WithOP(query.GT))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, int64(2500), count)
+	})
+
+	t.Run("should-count-rows-with-unindexed-filters", func(t *testing.T) {
+		// GIVEN
+		db, sqlMock := gormtest.NewDB(t)
+		s := gormstore.New[User, UserDTO, int](gormopscope.NewWriteTransactionScope("test", db))
+
+		gormtest.ExpectQuery(sqlMock, "SELECT count(*) FROM `user_dtos` WHERE id > ? AND name = ?", 100, "john").
+			WillReturnRows(gormtest.Rows([]string{"count"}, []driver.Value{3}))
+
+		// WHEN
+		count, err := s.CountEstimate(context.Background(),
+			query.Filter("ID", 100).WithOP(query.GT),
+			query.Filter("Name", "john"),
+		)
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), count)
+	})
+
+	t.Run("should-count-rows-of-tables-never-analyzed", func(t *testing.T) {
+		// GIVEN
+		db, sqlMock := gormtest.NewDB(t)
+		db.Dialector = postgresDialector{db.Dialector}
+		s := gormstore.New[User, UserDTO, int](gormopscope.NewWriteTransactionScope("test", db))
+
+		gormtest.ExpectQuery(sqlMock, "SELECT reltuples FROM pg_class WHERE oid = to_regclass(?)", "user_dtos").
+			WillReturnRows(gormtest.Rows([]string{"reltuples"}, []driver.Value{-1.0}))
+		gormtest.ExpectQuery(sqlMock, "SELECT count(*) FROM `user_dtos`").
+			WillReturnRows(gormtest.Rows([]string{"count"}, []driver.Value{12}))
+
+		// WHEN
+		count, err := s.CountEstimate(context.Background())
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, int64(12), count)
+	})
+
+	t.Run("should-estimate-rows-of-postgres-plan", func(t *testing.T) {
+		// GIVEN
+		db, sqlMock := gormtest.NewDB(t)
+		db.Dialector = postgresDialector{db.Dialector}
+		s := gormstore.New[User, UserDTO, int](gormopscope.NewWriteTransactionScope("test", db))
+
+		gormtest.ExpectQuery(sqlMock, "EXPLAIN (FORMAT JSON) SELECT * FROM `user_dtos` WHERE id < ?", 10).
+			WillReturnRows(gormtest.Rows([]string{"QUERY PLAN"},
+				[]driver.Value{`[{"Plan": {"Node Type": "Index Scan", "Plan Rows": 9}}]`},
+			))
+
+		// WHEN
+		count, err := s.CountEstimate(context.Background(), query.Filter("ID", 10).WithOP(query.LT))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, int64(9), count)
+	})
+
+	t.Run("should-count-rows-on-sqlite", func(t *testing.T) {
+		// GIVEN
+		db := newSQLiteDB(t)
+		require.NoError(t, db.Create([]Document{{ID: 1}, {ID: 2}}).Error)
+
+		s := gormstore.New[Document, Document, int](gormopscope.NewWriteTransactionScope("test", db))
+
+		// WHEN
+		count, err := s.CountEstimate(context.Background())
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+	})
+}
+
+type UUID [16]byte
+
+func (u UUID) Value() (driver.Value, error) {
+	return u[:], nil
+}
+
+func (u *UUID) Scan(src any) error {
+	copy(u[:], src.([]byte))
+
+	return nil
+}
+
+type Tag struct {
+	ID   UUID   `gorm:"column:id;primaryKey"`
+	Name string `gorm:"column:name"`
+}
+
+func (t Tag) GetID() UUID {
+	return t.ID
+}
+
+type Badge struct {
+	ID   string `gorm:"column:id;primaryKey"`
+	Name string `gorm:"column:name"`
+}
+
+func (b Badge) GetID() string {
+	return b.ID
+}
+
+type Token struct {
+	ID   string `gorm:"column:id;primaryKey;default:(lower(hex(randomblob(16))))"`
+	Name string `gorm:"column:name"`
+}
+
+func (t Token) GetID() string {
+	return t.ID
+}
+
+func Test_Store_IDGenerator(t *testing.T) {
+	uuidV7 := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+	t.Run("should-generate-uuidv7-by-default", func(t *testing.T) {
+		// GIVEN
+		db := newSQLiteDB(t)
+		require.NoError(t, db.AutoMigrate(&Tag{}))
+
+		s := gormstore.New[Tag, Tag, UUID](gormopscope.NewWriteTransactionScope("test", db))
+		existing := UUID{1}
+
+		// WHEN
+		id, err := s.Create(context.Background(), Tag{Name: "go"})
+		require.NoError(t, err)
+
+		manyErr := s.CreateMany(context.Background(), []Tag{{Name: "sql"}, {ID: existing, Name: "orm"}})
+		require.NoError(t, manyErr)
+
+		// THEN
+		assert.Regexp(t, uuidV7, idgen.FormatUUID(id))
+
+		tag, err := s.Get(context.Background(), query.Filter("ID", id))
+		require.NoError(t, err)
+		assert.Equal(t, "go", tag.Name)
+
+		tags, err := s.List(context.Background(), query.OrderBy("Name", false))
+		require.NoError(t, err)
+		require.Len(t, tags, 3)
+		assert.Equal(t, existing, tags[1].ID)
+		assert.Regexp(t, uuidV7, idgen.FormatUUID(tags[2].ID))
+	})
+
+	t.Run("should-generate-ids-with-generator", func(t *testing.T) {
+		// GIVEN
+		db := newSQLiteDB(t)
+		require.NoError(t, db.AutoMigrate(&Badge{}))
+
+		s := gormstore.New[Badge, Badge, string](
+			gormopscope.NewWriteTransactionScope("test", db),
+			gormstore.WithIDGenerator[Badge, Badge, string](idgen.UUIDv7[string]),
+		)
+
+		// WHEN
+		id, err := s.Upsert(context.Background(), Badge{Name: "urgent"}, store.OnConflict{DoNothing: true})
+
+		// THEN
+		require.NoError(t, err)
+		assert.Regexp(t, uuidV7, id)
+
+		count, err := s.Count(context.Background(), query.Filter("ID", id))
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("should-return-id-generated-by-database", func(t *testing.T) {
+		// GIVEN
+		db := newSQLiteDB(t)
+		require.NoError(t, db.AutoMigrate(&Token{}))
+
+		s := gormstore.New[Token, Token, string](
+			gormopscope.NewWriteTransactionScope("test", db),
+			gormstore.WithIDGenerator[Token, Token, string](idgen.UUIDv7[string]),
+		)
+
+		// WHEN
+		id, err := s.Create(context.Background(), Token{Name: "api"})
+
+		// THEN
+		require.NoError(t, err)
+		assert.Regexp(t, `^[0-9a-f]{32}$`, id)
+	})
+
+	t.Run("should-return-generator-error", func(t *testing.T) {
+		// GIVEN
+		db := newSQLiteDB(t)
+		require.NoError(t, db.AutoMigrate(&Badge{}))
+
+		s := gormstore.New[Badge, Badge, string](
+			gormopscope.NewWriteTransactionScope("test", db),
+			gormstore.WithIDGenerator[Badge, Badge, string](func(context.Context) (string, error) {
+				return "", assert.AnError
+			}),
+		)
+
+		// WHEN
+		err := s.CreateMany(context.Background(), []Badge{{Name: "urgent"}})
+
+		// THEN
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+type Reply struct {
+	ID   int64
+	Body string
+}
+
+func (r *Reply) GetID() int64 {
+	return r.ID
+}
+
+type ReplyDTO struct {
+	ID   int64  `gorm:"column:id;primaryKey;autoIncrement"`
+	Body string `gorm:"column:body"`
+}
+
+func (r *ReplyDTO) GetID() int64 {
+	return r.ID
+}
+
+func Test_Store_CreateMany(t *testing.T) {
+	t.Run("should-set-ids-generated-by-database", func(t *testing.T) {
+		// GIVEN
+		db := newSQLiteDB(t)
+		require.NoError(t, db.AutoMigrate(&ReplyDTO{}))
+
+		s := gormstore.New[*Reply, *ReplyDTO, int64](gormopscope.NewWriteTransactionScope("test", db))
+		replies := []*Reply{{Body: "first"}, {Body: "second"}, {ID: 10, Body: "third"}}
+
+		// WHEN
+		err := s.CreateMany(context.Background(), replies)
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, []*Reply{{ID: 1, Body: "first"}, {ID: 2, Body: "second"}, {ID: 10, Body: "third"}}, replies)
+
+		stored, err := s.List(context.Background(), query.OrderBy("ID", false))
+		require.NoError(t, err)
+		assert.Equal(t, replies, stored)
+	})
+}
+
+func Test_Store_ErrorTranslator(t *testing.T) {
+	t.Run("should-translate-duplicate-key", func(t *testing.T) {
+		// GIVEN
+		db := newSQLiteDB(t)
+		require.NoError(t, db.AutoMigrate(&Badge{}))
+
+		s := gormstore.New[Badge, Badge, string](gormopscope.NewWriteTransactionScope("test", db))
+
+		_, err := s.Create(context.Background(), Badge{ID: "b1", Name: "urgent"})
+		require.NoError(t, err)
+
+		// WHEN
+		_, err = s.Create(context.Background(), Badge{ID: "b1", Name: "urgent"})
+
+		// THEN
+		assert.ErrorIs(t, err, store.ErrDuplicateKey)
+		assert.ErrorIs(t, err, store.ErrDuplicate)
+
+		var constraintErr *store.ConstraintError
+		require.ErrorAs(t, err, &constraintErr)
+		assert.Equal(t, "badges.id", constraintErr.Constraint)
+	})
+
+	t.Run("should-translate-gorm-duplicated-key", func(t *testing.T) {
+		// WHEN
+		err := gormstore.TranslateError(gorm.ErrDuplicatedKey)
+
+		// THEN
+		assert.ErrorIs(t, err, store.ErrDuplicateKey)
+		assert.ErrorIs(t, err, gorm.ErrDuplicatedKey)
+	})
+
+	t.Run("should-use-custom-translator", func(t *testing.T) {
+		// GIVEN
+		db := newSQLiteDB(t)
+		require.NoError(t, db.AutoMigrate(&Badge{}))
+
+		errTaken := errors.New("badge taken")
+		s := gormstore.New[Badge, Badge, string](
+			gormopscope.NewWriteTransactionScope("test", db),
+			gormstore.WithErrorTranslator[Badge, Badge, string](func(err error) error {
+				if errors.Is(gormstore.TranslateError(err), store.ErrDuplicateKey) {
+					return errTaken
+				}
+
+				return err
+			}),
+		)
+
+		require.NoError(t, s.CreateMany(context.Background(), []Badge{{ID: "b1", Name: "urgent"}}))
+
+		// WHEN
+		err := s.CreateMany(context.Background(), []Badge{{ID: "b1", Name: "urgent"}})
+
+		// THEN
+		assert.ErrorIs(t, err, errTaken)
+	})
+}
+
+func Test_Store_Logger(t *testing.T) {
+	t.Run("should-log-statements-with-placeholders", func(t *testing.T) {
+		// GIVEN
+		var (
+			db      = newSQLiteDB(t)
+			scope   = gormopscope.NewWriteTransactionScope("writeTx", db)
+			entries []map[string]any
+			levels  []flexlog.Level
+		)
+
+		logger := flexlog.Func(func(_ context.Context, level flexlog.Level, _ string, args ...any) {
+			attrs := map[string]any{}
+			for i := 0; i+1 < len(args); i += 2 {
+				attrs[args[i].(string)] = args[i+1]
+			}
+
+			entries = append(entries, attrs)
+			levels = append(levels, level)
+		})
+
+		s := gormstore.New[Document, Document, int](scope, gormstore.WithLogger[Document, Document, int](logger))
+
+		ctx, err := scope.Begin(context.Background())
+		require.NoError(t, err)
+
+		// WHEN
+		_, err = s.Create(ctx, Document{ID: 1, Title: "secret"})
+		require.NoError(t, err)
+
+		_, err = s.Get(ctx, query.Filter("ID", 2))
+
+		// THEN
+		assert.ErrorIs(t, err, store.ErrNotFound)
+		require.NoError(t, scope.End(ctx, nil))
+		require.Len(t, entries, 2)
+
+		assert.Equal(t, "Document", entries[0][flexlog.KeyEntity])
+		assert.Equal(t, "INSERT", entries[0][flexlog.KeyOperation])
+		assert.Equal(t, "writeTx", entries[0][flexlog.KeyTx])
+		assert.Equal(t, int64(1), entries[0][flexlog.KeyRows])
+		assert.Contains(t, entries[0][flexlog.KeySQL], "VALUES (?,?,?)")
+		assert.NotContains(t, entries[0][flexlog.KeySQL], "secret")
+
+		assert.Equal(t, "SELECT", entries[1][flexlog.KeyOperation])
+		assert.Equal(t, []flexlog.Level{flexlog.LevelDebug, flexlog.LevelDebug}, levels)
+	})
+}
+
+func Test_Store_Pagination(t *testing.T) {
+	// GIVEN
+	db := newSQLiteDB(t)
+	require.NoError(t, db.Create([]Document{{ID: 1}, {ID: 2}, {ID: 3}}).Error)
+
+	s := gormstore.New[Document, Document, int](gormopscope.NewWriteTransactionScope("test", db),
+		gormstore.WithPagination[Document, Document, int](gormquery.Pagination{
+			DefaultLimit: 2,
+			MaxOffset:    10,
+		}),
+	)
+
+	t.Run("should-apply-default-limit-without-paginate", func(t *testing.T) {
+		// WHEN
+		documents, err := s.List(context.Background(), query.OrderBy("ID", false))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Len(t, documents, 2)
+	})
+
+	t.Run("should-not-limit-count", func(t *testing.T) {
+		// WHEN
+		count, err := s.Count(context.Background())
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), count)
+	})
+
+	t.Run("should-fail-beyond-max-offset", func(t *testing.T) {
+		// WHEN
+		_, err := s.List(context.Background(), query.Paginate(11, 2))
+
+		// THEN
+		assert.ErrorIs(t, err, gormquery.ErrMaxOffsetExceeded)
+	})
+}
+
+// Price is a versioned entity whose past versions are in the price_history table.
+type Price struct {
+	ID        int        `gorm:"column:id;primaryKey"`
+	Amount    int        `gorm:"column:amount"`
+	ValidFrom time.Time  `gorm:"column:valid_from"`
+	ValidTo   *time.Time `gorm:"column:valid_to"`
+}
+
+func (p Price) GetID() int {
+	return p.ID
+}
+
+func Test_Store_AsOf(t *testing.T) {
+	// GIVEN
+	var (
+		db    = newSQLiteDB(t)
+		day   = func(d int) time.Time { return time.Date(2024, 3, d, 0, 0, 0, 0, time.UTC) }
+		until = func(d int) *time.Time { end := day(d); return &end }
+	)
+
+	require.NoError(t, db.AutoMigrate(&Price{}))
+	require.NoError(t, db.Exec(
+		"CREATE TABLE price_history (id integer, amount integer, valid_from datetime, valid_to datetime)",
+	).Error)
+	require.NoError(t, db.Create(&Price{ID: 1, Amount: 30, ValidFrom: day(10)}).Error)
+	require.NoError(t, db.Table("price_history").Create([]Price{
+		{ID: 1, Amount: 10, ValidFrom: day(1), ValidTo: until(5)},
+		{ID: 1, Amount: 20, ValidFrom: day(5), ValidTo: until(10)},
+	}).Error)
+
+	s := gormstore.New[Price, Price, int](gormopscope.NewWriteTransactionScope("test", db),
+		gormstore.WithTemporal[Price, Price, int](gormquery.Temporal{HistoryTable: "price_history"}),
+	)
+
+	for _, tt := range []struct {
+		at   time.Time
+		want int
+	}{
+		{at: day(2), want: 10},
+		{at: day(5), want: 20},
+		{at: day(12), want: 30},
+	} {
+		// WHEN
+		price, err := s.Get(context.Background(), query.Filter("ID", 1), query.AsOf(tt.at))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, price.Amount, "as of %s", tt.at)
+	}
+
+	// WHEN
+	_, err := s.Get(context.Background(), query.Filter("ID", 1), query.AsOf(day(1).Add(-time.Hour)))
+
+	// THEN
+	assert.ErrorIs(t, err, store.ErrNotFound)
+}
+
+func Test_Store_ListPage(t *testing.T) {
+	// GIVEN
+	db := newSQLiteDB(t)
+	require.NoError(t, db.Create([]Document{
+		{ID: 1, Title: "b", Version: 2},
+		{ID: 2, Title: "a", Version: 1},
+		{ID: 3, Title: "b", Version: 1},
+		{ID: 4, Title: "c", Version: 1},
+		{ID: 5, Title: "b", Version: 1},
+	}).Error)
+
+	s := gormstore.New[Document, Document, int](gormopscope.NewWriteTransactionScope("test", db))
+
+	t.Run("should-list-every-entity-once-across-pages", func(t *testing.T) {
+		// GIVEN
+		var (
+			ids    []int
+			cursor string
+			pages  int
+		)
+
+		for {
+			// WHEN
+			documents, next, err := s.ListPage(context.Background(), cursor, 2,
+				query.Filter("Version", 0).WithOP(query.GT),
+				query.OrderBy("Title", true),
+			)
+
+			// THEN
+			require.NoError(t, err)
+
+			for _, d := range documents {
+				ids = append(ids, d.ID)
+			}
+
+			pages++
+
+			if next == "" {
+				break
+			}
+
+			cursor = next
+		}
+
+		assert.Equal(t, []int{4, 1, 3, 5, 2}, ids)
+		assert.Equal(t, 3, pages)
+	})
+
+	t.Run("should-reject-cursor-of-another-ordering", func(t *testing.T) {
+		// GIVEN
+		_, next, err := s.ListPage(context.Background(), "", 1, query.OrderBy("Title", false))
+		require.NoError(t, err)
+
+		// WHEN
+		_, _, err = s.ListPage(context.Background(), next, 1, query.OrderBy("Version", false))
+		_, _, malformedErr := s.ListPage(context.Background(), "not a cursor", 1)
+
+		// THEN
+		assert.ErrorIs(t, err, gormstore.ErrInvalidCursor)
+		assert.ErrorIs(t, malformedErr, gormstore.ErrInvalidCursor)
+	})
+}
+
+func Test_Store_ListWithCount(t *testing.T) {
+	// GIVEN
+	db := newSQLiteDB(t)
+	require.NoError(t, db.Create([]Document{
+		{ID: 1, Title: "a", Version: 1},
+		{ID: 2, Title: "b", Version: 2},
+		{ID: 3, Title: "c", Version: 2},
+		{ID: 4, Title: "d", Version: 2},
+	}).Error)
+
+	s := gormstore.New[Document, Document, int](gormopscope.NewWriteTransactionScope("test", db))
+
+	t.Run("should-list-page-and-count-every-matching-entity", func(t *testing.T) {
+		// WHEN
+		documents, count, err := s.ListWithCount(context.Background(),
+			query.Filter("Version", 2),
+			query.OrderBy("Title", true),
+			query.Paginate(1, 2),
+		)
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, []Document{{ID: 3, Title: "c", Version: 2}, {ID: 2, Title: "b", Version: 2}}, documents)
+		assert.Equal(t, int64(3), count)
+	})
+
+	t.Run("should-return-no-entity-when-none-matches", func(t *testing.T) {
+		// WHEN
+		documents, count, err := s.ListWithCount(context.Background(), query.Filter("Version", 3))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Empty(t, documents)
+		assert.Zero(t, count)
+	})
+}
+
+func Test_Store_UpdateMany(t *testing.T) {
+	// GIVEN
+	db := newSQLiteDB(t)
+	require.NoError(t, db.Create([]Document{
+		{ID: 1, Title: "a", Version: 1},
+		{ID: 2, Title: "b", Version: 1},
+		{ID: 3, Title: "c", Version: 2},
+	}).Error)
+
+	s := gormstore.New[Document, Document, int](gormopscope.NewWriteTransactionScope("test", db))
+
+	t.Run("should-update-matching-entities", func(t *testing.T) {
+		// WHEN
+		updated, err := s.UpdateMany(context.Background(), map[string]any{"Title": "z", "version": 3},
+			query.Filter("Version", 1),
+		)
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), updated)
+
+		documents, err := s.List(context.Background(), query.OrderBy("ID", false))
+		require.NoError(t, err)
+		assert.Equal(t, []Document{
+			{ID: 1, Title: "z", Version: 3},
+			{ID: 2, Title: "z", Version: 3},
+			{ID: 3, Title: "c", Version: 2},
+		}, documents)
+	})
+
+	t.Run("should-refuse-update-without-condition", func(t *testing.T) {
+		// WHEN
+		_, err := s.UpdateMany(context.Background(), map[string]any{"Title": "z"})
+
+		// THEN
+		assert.ErrorIs(t, err, gorm.ErrMissingWhereClause)
+	})
+
+	t.Run("should-refuse-empty-updates", func(t *testing.T) {
+		// WHEN
+		_, err := s.UpdateMany(context.Background(), nil, query.Filter("ID", 1))
+
+		// THEN
+		assert.ErrorIs(t, err, gormquery.ErrInvalidParam)
+	})
+}
+
+func Test_Store_Increment(t *testing.T) {
+	// GIVEN
+	db := newSQLiteDB(t)
+	require.NoError(t, db.Create([]Document{
+		{ID: 1, Title: "a", Version: 1},
+		{ID: 2, Title: "b", Version: 5},
+	}).Error)
+
+	s := gormstore.New[Document, Document, int](gormopscope.NewWriteTransactionScope("test", db))
+
+	t.Run("should-increment-and-decrement-matching-entities", func(t *testing.T) {
+		// WHEN
+		incErr := s.Increment(context.Background(), "Version", 2, query.Filter("ID", 1))
+		decErr := s.Decrement(context.Background(), "version", 3, query.Filter("ID", 2))
+
+		// THEN
+		require.NoError(t, incErr)
+		require.NoError(t, decErr)
+
+		documents, err := s.List(context.Background(), query.OrderBy("ID", false))
+		require.NoError(t, err)
+		assert.Equal(t, []Document{
+			{ID: 1, Title: "a", Version: 3},
+			{ID: 2, Title: "b", Version: 2},
+		}, documents)
+	})
+
+	t.Run("should-emit-atomic-update", func(t *testing.T) {
+		// GIVEN
+		mockDB, sqlMock := gormtest.NewDB(t)
+		mockStore := gormstore.New[User, UserDTO, int](gormopscope.NewWriteTransactionScope("test", mockDB))
+
+		gormtest.ExpectExec(sqlMock, "UPDATE `user_dtos` SET `age`=`age` + ? WHERE id = ?", int64(1), 7).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		// WHEN
+		err := mockStore.Increment(context.Background(), "Age", 1, query.Filter("ID", 7))
+
+		// THEN
+		require.NoError(t, err)
+		require.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("should-refuse-increment-without-condition", func(t *testing.T) {
+		// WHEN
+		err := s.Increment(context.Background(), "Version", 1)
+
+		// THEN
+		assert.ErrorIs(t, err, gorm.ErrMissingWhereClause)
+	})
+}
+
+func Test_Store_RowsAffected(t *testing.T) {
+	newStore := func(t *testing.T, opts ...gormstore.Option[Document, Document, int]) *gormstore.Store[Document, Document, int] {
+		db := newSQLiteDB(t)
+		require.NoError(t, db.Create([]Document{
+			{ID: 1, Title: "a", Version: 1},
+			{ID: 2, Title: "b", Version: 1},
+		}).Error)
+
+		return gormstore.New[Document, Document, int](gormopscope.NewWriteTransactionScope("test", db), opts...)
+	}
+
+	t.Run("should-return-rows-affected", func(t *testing.T) {
+		// GIVEN
+		s := newStore(t)
+		ctx := context.Background()
+
+		// WHEN
+		updated, updateErr := s.UpdateCount(ctx, Document{ID: 1, Title: "z", Version: 2})
+		partiallyUpdated, partialUpdateErr := s.PartialUpdateCount(ctx, Document{Title: "y"},
+			query.Filter("Version", []int{1, 2}))
+		deleted, deleteErr := s.DeleteCount(ctx, query.Filter("ID", []int{2, 3}))
+
+		// THEN
+		require.NoError(t, updateErr)
+		require.NoError(t, partialUpdateErr)
+		require.NoError(t, deleteErr)
+		assert.Equal(t, int64(1), updated)
+		assert.Equal(t, int64(2), partiallyUpdated)
+		assert.Equal(t, int64(1), deleted)
+	})
+
+	t.Run("should-ignore-no-rows-affected-by-default", func(t *testing.T) {
+		// GIVEN
+		s := newStore(t)
+
+		// WHEN
+		deleted, err := s.DeleteCount(context.Background(), query.Filter("ID", 3))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Zero(t, deleted)
+	})
+
+	t.Run("should-return-error-when-no-rows-affected", func(t *testing.T) {
+		// GIVEN
+		s := newStore(t, gormstore.WithRequireRowsAffected[Document, Document, int]())
+		ctx := context.Background()
+
+		// WHEN
+		updateErr := s.Update(ctx, Document{ID: 3, Title: "z"})
+		partialUpdateErr := s.PartialUpdate(ctx, Document{Title: "z"}, query.Filter("ID", 3))
+		deleteErr := s.Delete(ctx, query.Filter("ID", 3))
+		matchingErr := s.Delete(ctx, query.Filter("ID", 1))
+
+		// THEN
+		assert.ErrorIs(t, updateErr, store.ErrNoRowsAffected)
+		assert.ErrorIs(t, partialUpdateErr, store.ErrNoRowsAffected)
+		assert.ErrorIs(t, deleteErr, store.ErrNoRowsAffected)
+		assert.NoError(t, matchingErr)
+	})
+}
+
+func Test_Store_AggregateMany(t *testing.T) {
+	// GIVEN
+	db := newSQLiteDB(t)
+	require.NoError(t, db.Create([]Document{
+		{ID: 1, Title: "a", Version: 1},
+		{ID: 2, Title: "b", Version: 2},
+		{ID: 3, Title: "c", Version: 6},
+		{ID: 4, Title: "d", Version: 9},
+	}).Error)
+
+	s := gormstore.New[Document, Document, int](gormopscope.NewWriteTransactionScope("test", db))
+
+	t.Run("should-compute-aggregates-of-matching-entities", func(t *testing.T) {
+		// WHEN
+		results, err := s.AggregateMany(context.Background(), []store.Aggregate{
+			{Aggregation: store.Sum, Field: "Version"},
+			{Aggregation: store.Avg, Field: "Version"},
+			{Aggregation: store.Min, Field: "Version"},
+			{Aggregation: store.Max, Field: "Version"},
+			{Aggregation: store.CountOf},
+		}, query.Filter("ID", 3).WithOP(query.LTE), query.OrderBy("ID", true), query.Paginate(0, 1))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, []float64{9, 3, 1, 6, 3}, results)
+	})
+
+	t.Run("should-return-zero-without-matching-entity", func(t *testing.T) {
+		// WHEN
+		avg, err := s.Aggregate(context.Background(), store.Avg, "Version", query.Filter("ID", 5))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Zero(t, avg)
+	})
+
+	t.Run("should-refuse-unsupported-aggregation", func(t *testing.T) {
+		// WHEN
+		_, err := s.Aggregate(context.Background(), "MEDIAN", "Version")
+
+		// THEN
+		assert.ErrorIs(t, err, gormquery.ErrInvalidParam)
+	})
+}
+
+func Test_Store_SoftDelete(t *testing.T) {
+	newStore := func(t *testing.T) (*gorm.DB, *gormstore.Store[*Comment, *Comment, int]) {
+		db := newSQLiteDB(t)
+
+		require.NoError(t, db.AutoMigrate(&Comment{}))
+		require.NoError(t, db.Create([]*Comment{{ID: 1, PostID: 1}, {ID: 2, PostID: 1}, {ID: 3, PostID: 2}}).Error)
+
+		return db, gormstore.New[*Comment, *Comment, int](gormopscope.NewWriteTransactionScope("test", db))
+	}
+
+	t.Run("should-exclude-soft-deleted-entities-unless-unscoped", func(t *testing.T) {
+		// GIVEN
+		_, s := newStore(t)
+
+		// WHEN
+		err := s.SoftDelete(context.Background(), query.Filter("PostID", 1), query.Unscoped())
+
+		// THEN
+		require.NoError(t, err)
+
+		count, err := s.Count(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+
+		_, err = s.Get(context.Background(), filters.IDs(1))
+		assert.ErrorIs(t, err, store.ErrNotFound)
+
+		comments, err := s.List(context.Background(), query.Unscoped(), query.OrderBy("ID", false))
+		require.NoError(t, err)
+		require.Len(t, comments, 3)
+		assert.True(t, comments[0].DeletedAt.Valid)
+		assert.True(t, comments[1].DeletedAt.Valid)
+		assert.False(t, comments[2].DeletedAt.Valid)
+	})
+
+	t.Run("should-restore-soft-deleted-entities", func(t *testing.T) {
+		// GIVEN
+		_, s := newStore(t)
+		require.NoError(t, s.SoftDelete(context.Background(), query.Filter("PostID", 1)))
+
+		// WHEN
+		err := s.Restore(context.Background(), filters.IDs(2))
+
+		// THEN
+		require.NoError(t, err)
+
+		comments, err := s.List(context.Background(), query.OrderBy("ID", false))
+		require.NoError(t, err)
+		require.Len(t, comments, 2)
+		assert.Equal(t, 2, comments[0].ID)
+		assert.Equal(t, 3, comments[1].ID)
+	})
+
+	t.Run("should-delete-permanently-when-unscoped", func(t *testing.T) {
+		// GIVEN
+		db, s := newStore(t)
+		require.NoError(t, s.SoftDelete(context.Background(), filters.IDs(1)))
+
+		// WHEN
+		err := s.Delete(context.Background(), query.Filter("PostID", 1), query.Unscoped())
+
+		// THEN
+		require.NoError(t, err)
+
+		var count int64
+		require.NoError(t, db.Table("comments").Count(&count).Error)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("should-refuse-models-without-deleted-at", func(t *testing.T) {
+		// GIVEN
+		s := gormstore.New[Document, Document, int](gormopscope.NewWriteTransactionScope("test", newSQLiteDB(t)))
+
+		// WHEN
+		deleteErr := s.SoftDelete(context.Background(), filters.IDs(1))
+		restoreErr := s.Restore(context.Background(), filters.IDs(1))
+
+		// THEN
+		assert.ErrorIs(t, deleteErr, gormstore.ErrSoftDeleteUnsupported)
+		assert.ErrorIs(t, restoreErr, gormstore.ErrSoftDeleteUnsupported)
+	})
+}
+
+func Test_Store_Stream(t *testing.T) {
+	// GIVEN
+	db := newSQLiteDB(t)
+	require.NoError(t, db.Create([]Document{
+		{ID: 1, Title: "a", Version: 1},
+		{ID: 2, Title: "b", Version: 2},
+		{ID: 3, Title: "c", Version: 2},
+	}).Error)
+
+	s := gormstore.New[Document, Document, int](gormopscope.NewWriteTransactionScope("test", db))
+
+	t.Run("should-call-fn-with-each-matching-entity", func(t *testing.T) {
+		// GIVEN
+		var documents []Document
+
+		// WHEN
+		err := s.Stream(context.Background(), func(d Document) error {
+			documents = append(documents, d)
+			return nil
+		}, query.Filter("Version", 2), query.OrderBy("ID", true))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, []Document{{ID: 3, Title: "c", Version: 2}, {ID: 2, Title: "b", Version: 2}}, documents)
+	})
+
+	t.Run("should-stop-at-first-error", func(t *testing.T) {
+		// GIVEN
+		var (
+			errStop = errors.New("stop")
+			calls   int
+		)
+
+		// WHEN
+		err := s.Stream(context.Background(), func(Document) error {
+			calls++
+			return errStop
+		})
+
+		// THEN
+		assert.ErrorIs(t, err, errStop)
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func Test_Store_ListCursor(t *testing.T) {
+	// GIVEN
+	db := newSQLiteDB(t)
+	require.NoError(t, db.Create([]Document{
+		{ID: 1, Title: "a", Version: 3},
+		{ID: 2, Title: "b", Version: 1},
+		{ID: 3, Title: "c", Version: 3},
+		{ID: 4, Title: "d", Version: 2},
+	}).Error)
+
+	s := gormstore.New[Document, Document, int](gormopscope.NewWriteTransactionScope("test", db))
+
+	t.Run("should-list-every-entity-once-across-pages", func(t *testing.T) {
+		// GIVEN
+		var (
+			ids    []int
+			cursor string
+		)
+
+		for {
+			// WHEN
+			documents, next, err := s.ListCursor(context.Background(),
+				query.Cursor(cursor, 3, query.OrderBy("Version", false)),
+			)
+
+			// THEN
+			require.NoError(t, err)
+
+			for _, d := range documents {
+				ids = append(ids, d.ID)
+			}
+
+			if next == "" {
+				break
+			}
+
+			cursor = next
+		}
+
+		assert.Equal(t, []int{2, 4, 1, 3}, ids)
+	})
+
+	t.Run("should-list-page-of-cursor-param", func(t *testing.T) {
+		// GIVEN
+		_, next, err := s.ListCursor(context.Background(), query.Cursor("", 2, query.OrderBy("Version", true)))
+		require.NoError(t, err)
+
+		// WHEN
+		documents, err := s.List(context.Background(), query.Cursor(next, 2, query.OrderBy("Version", true)))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, []Document{{ID: 4, Title: "d", Version: 2}, {ID: 2, Title: "b", Version: 1}}, documents)
+	})
+
+	t.Run("should-require-cursor-param", func(t *testing.T) {
+		// WHEN
+		_, _, err := s.ListCursor(context.Background(), query.OrderBy("Version", false))
+
+		// THEN
+		assert.ErrorIs(t, err, gormquery.ErrInvalidParam)
+	})
+}
+
+func Test_NewSimple(t *testing.T) {
+	// GIVEN
+	s := gormstore.NewSimple[Document, int](gormopscope.NewWriteTransactionScope("test", newSQLiteDB(t)))
+
+	// WHEN
+	_, err := s.Create(context.Background(), Document{ID: 1, Title: "draft"})
+	require.NoError(t, err)
+
+	documents, err := s.List(context.Background())
+
+	// THEN
+	require.NoError(t, err)
+	assert.IsType(t, &converter.Identity[Document, Document, int]{}, s.Converter)
+	assert.Equal(t, []Document{{ID: 1, Title: "draft"}}, documents)
+}
+
+func Test_Store_EnsureUnique(t *testing.T) {
+	t.Run("should-report-duplicates-of-other-entities", func(t *testing.T) {
+		// GIVEN
+		db := newSQLiteDB(t)
+		require.NoError(t, db.Create([]Document{{ID: 1, Title: "draft", Version: 1}}).Error)
+
+		s := gormstore.New[Document, Document, int](gormopscope.NewWriteTransactionScope("test", db))
+
+		var (
+			ctx    = context.Background()
+			fields = []string{"Title", "Version"}
+		)
+
+		// WHEN
+		duplicateErr := s.EnsureUnique(ctx, fields, Document{Title: "draft", Version: 1})
+		selfErr := s.EnsureUnique(ctx, []string{"Title"}, Document{ID: 1, Title: "draft"})
+		uniqueErr := s.EnsureUnique(ctx, fields, Document{Title: "draft", Version: 2})
+
+		// THEN
+		var duplicate *store.DuplicateError
+		require.ErrorAs(t, duplicateErr, &duplicate)
+		assert.ErrorIs(t, duplicateErr, store.ErrDuplicate)
+		assert.Equal(t, []string{"Title", "Version"}, duplicate.Fields)
+		assert.NoError(t, selfErr)
+		assert.NoError(t, uniqueErr)
+	})
+
+	t.Run("should-lock-conflicting-entity", func(t *testing.T) {
+		// GIVEN
+		db, sqlMock := gormtest.NewDB(t)
+		s := gormstore.New[User, UserDTO, int](gormopscope.NewWriteTransactionScope("test", db))
+
+		gormtest.ExpectQuery(sqlMock, "SELECT * FROM `user_dtos` WHERE name = ? AND id <> ? LIMIT 1 FOR UPDATE",
+			"john", 3).
+			WillReturnRows(gormtest.Rows([]string{"id"}))
+
+		// WHEN
+		err := s.EnsureUnique(context.Background(), []string{"Name"}, User{ID: 3, Name: "john"},
+			query.WithLock(query.LockTypeForUpdate),
+		)
+
+		// THEN
+		require.NoError(t, err)
+	})
+}
+
+type Setting struct {
+	ID    int    `gorm:"column:id;primaryKey"`
+	Key   string `gorm:"column:key;uniqueIndex"`
+	Value string `gorm:"column:value"`
+}
+
+func (s Setting) GetID() int {
+	return s.ID
+}
+
+func Test_Store_GetOrCreate(t *testing.T) {
+	newStore := func(t *testing.T, opts ...gormstore.Option[*Setting, *Setting, int]) *gormstore.Store[*Setting, *Setting, int] {
+		db := newSQLiteDB(t)
+		require.NoError(t, db.AutoMigrate(&Setting{}))
+
+		return gormstore.New[*Setting, *Setting, int](gormopscope.NewWriteTransactionScope("test", db), opts...)
+	}
+
+	t.Run("should-create-missing-entity", func(t *testing.T) {
+		// GIVEN
+		s := newStore(t)
+
+		// WHEN
+		setting, created, err := s.GetOrCreate(context.Background(), &Setting{Key: "theme", Value: "dark"},
+			query.Filter("Key", "theme"))
+
+		// THEN
+		require.NoError(t, err)
+		assert.True(t, created)
+		assert.NotZero(t, setting.ID)
+		assert.Equal(t, "dark", setting.Value)
+	})
+
+	t.Run("should-return-existing-entity", func(t *testing.T) {
+		// GIVEN
+		s := newStore(t)
+		id, err := s.Create(context.Background(), &Setting{Key: "theme", Value: "light"})
+		require.NoError(t, err)
+
+		// WHEN
+		setting, created, err := s.GetOrCreate(context.Background(), &Setting{Key: "theme", Value: "dark"},
+			query.Filter("Key", "theme"))
+
+		// THEN
+		require.NoError(t, err)
+		assert.False(t, created)
+		assert.Equal(t, &Setting{ID: id, Key: "theme", Value: "light"}, setting)
+	})
+
+	t.Run("should-retry-on-duplicate-key", func(t *testing.T) {
+		// GIVEN
+		var s *gormstore.Store[*Setting, *Setting, int]
+
+		conflicts := 1
+		s = newStore(t, gormstore.WithHooks[*Setting, *Setting, int](gormstore.Hooks[*Setting]{
+			BeforeCreate: func(ctx context.Context, setting *Setting) error {
+				if conflicts == 0 {
+					return nil
+				}
+
+				conflicts--
+
+				return s.OpScope.Tx(ctx).Create(&Setting{Key: setting.Key, Value: "concurrent"}).Error
+			},
+		}))
+
+		// WHEN
+		setting, created, err := s.GetOrCreate(context.Background(), &Setting{Key: "theme", Value: "dark"},
+			query.Filter("Key", "theme"))
+
+		// THEN
+		require.NoError(t, err)
+		assert.True(t, created)
+		assert.Equal(t, "dark", setting.Value)
+
+		count, err := s.Count(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("should-return-duplicate-key-within-transaction", func(t *testing.T) {
+		// GIVEN
+		var s *gormstore.Store[*Setting, *Setting, int]
+
+		s = newStore(t, gormstore.WithHooks[*Setting, *Setting, int](gormstore.Hooks[*Setting]{
+			BeforeCreate: func(ctx context.Context, setting *Setting) error {
+				return s.OpScope.Tx(ctx).Create(&Setting{Key: setting.Key, Value: "concurrent"}).Error
+			},
+		}))
+
+		ctx, err := s.OpScope.Begin(context.Background())
+		require.NoError(t, err)
+
+		// WHEN
+		_, _, err = s.GetOrCreate(ctx, &Setting{Key: "theme", Value: "dark"}, query.Filter("Key", "theme"))
+
+		// THEN
+		assert.ErrorIs(t, err, store.ErrDuplicateKey)
+		require.Error(t, s.OpScope.End(ctx, err))
+	})
+
+	t.Run("should-require-params", func(t *testing.T) {
+		// GIVEN
+		s := newStore(t)
+
+		// WHEN
+		_, _, err := s.GetOrCreate(context.Background(), &Setting{Key: "theme"})
+
+		// THEN
+		assert.ErrorIs(t, err, gormquery.ErrInvalidParam)
+	})
+}
+
+func Test_Store_UpdateOrCreate(t *testing.T) {
+	t.Run("should-update-existing-entity", func(t *testing.T) {
+		// GIVEN
+		db := newSQLiteDB(t)
+		require.NoError(t, db.AutoMigrate(&Setting{}))
+
+		s := gormstore.New[*Setting, *Setting, int](gormopscope.NewWriteTransactionScope("test", db))
+		id, err := s.Create(context.Background(), &Setting{Key: "theme", Value: "light"})
+		require.NoError(t, err)
+
+		// WHEN
+		setting, created, err := s.UpdateOrCreate(context.Background(), &Setting{Key: "theme", Value: "dark"},
+			query.Filter("Key", "theme"))
+
+		// THEN
+		require.NoError(t, err)
+		assert.False(t, created)
+		assert.Equal(t, &Setting{ID: id, Key: "theme", Value: "dark"}, setting)
+
+		stored, err := s.List(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []*Setting{setting}, stored)
+	})
+
+	t.Run("should-create-missing-entity", func(t *testing.T) {
+		// GIVEN
+		db := newSQLiteDB(t)
+		require.NoError(t, db.AutoMigrate(&Setting{}))
+
+		s := gormstore.New[*Setting, *Setting, int](gormopscope.NewWriteTransactionScope("test", db))
+
+		// WHEN
+		setting, created, err := s.UpdateOrCreate(context.Background(), &Setting{Key: "theme", Value: "dark"},
+			query.Filter("Key", "theme"))
+
+		// THEN
+		require.NoError(t, err)
+		assert.True(t, created)
+		assert.NotZero(t, setting.ID)
+	})
+}
+
+func Test_ListAs(t *testing.T) {
+	type TitleCount struct {
+		Title string
+		Total int64
+	}
+
+	// GIVEN
+	db := newSQLiteDB(t)
+	require.NoError(t, db.Create([]Document{
+		{ID: 1, Title: "a"},
+		{ID: 2, Title: "b"},
+		{ID: 3, Title: "a"},
+	}).Error)
+
+	s := gormstore.New[Document, Document, int](gormopscope.NewWriteTransactionScope("test", db))
+
+	// WHEN
+	counts, err := gormstore.ListAs[TitleCount](context.Background(), s,
+		query.Select("Title", "COUNT(*) AS total"),
+		query.GroupBy("Title"),
+		query.OrderBy("Title", false),
+	)
+	none, noneErr := gormstore.ListAs[TitleCount](context.Background(), s, query.Filter("Title", "c"))
+
+	// THEN
+	require.NoError(t, err)
+	assert.Equal(t, []TitleCount{{Title: "a", Total: 2}, {Title: "b", Total: 1}}, counts)
+	require.NoError(t, noneErr)
+	assert.Nil(t, none)
+}
+
+func Test_Pluck(t *testing.T) {
+	// GIVEN
+	db := newSQLiteDB(t)
+	require.NoError(t, db.Create([]Document{
+		{ID: 1, Title: "a", Version: 1},
+		{ID: 2, Title: "b", Version: 2},
+		{ID: 3, Title: "c", Version: 2},
+	}).Error)
+
+	s := gormstore.New[Document, Document, int](gormopscope.NewWriteTransactionScope("test", db))
+
+	// WHEN
+	ids, err := gormstore.Pluck[int](context.Background(), s, "ID",
+		query.Filter("Version", 2),
+		query.OrderBy("ID", true),
+		query.Select("Title"),
+	)
+	titles, titlesErr := gormstore.Pluck[string](context.Background(), s, "Title", query.Paginate(1, 1))
+	none, noneErr := gormstore.Pluck[int](context.Background(), s, "ID", query.Filter("Version", 3))
+
+	// THEN
+	require.NoError(t, err)
+	assert.Equal(t, []int{3, 2}, ids)
+	require.NoError(t, titlesErr)
+	assert.Equal(t, []string{"b"}, titles)
+	require.NoError(t, noneErr)
+	assert.Nil(t, none)
+}
+
+func Test_Store_Raw(t *testing.T) {
+	// GIVEN
+	db := newSQLiteDB(t)
+	require.NoError(t, db.Create([]Document{
+		{ID: 1, Title: "a", Version: 1},
+		{ID: 2, Title: "b", Version: 2},
+		{ID: 3, Title: "c", Version: 3},
+	}).Error)
+
+	s := gormstore.New[Document, Document, int](gormopscope.NewWriteTransactionScope("test", db))
+
+	// WHEN
+	docs, err := s.List(context.Background(), query.Raw("version % ? = 1 AND title IN ?", 2, []string{"a", "c"}))
+	count, countErr := s.Count(context.Background(), query.Raw("version > ?", 1))
+
+	// THEN
+	require.NoError(t, err)
+	assert.Equal(t, []Document{{ID: 1, Title: "a", Version: 1}, {ID: 3, Title: "c", Version: 3}}, docs)
+	require.NoError(t, countErr)
+	assert.Equal(t, int64(2), count)
+}
+
+func Test_Store_ReadScope(t *testing.T) {
+	newStore := func(t *testing.T) (*gormstore.Store[User, UserDTO, int], sqlmock.Sqlmock, sqlmock.Sqlmock) {
+		primary, primaryMock := gormtest.NewDB(t)
+		replica, replicaMock := gormtest.NewDB(t)
+
+		s := gormstore.New[User, UserDTO, int](nil,
+			gormstore.WithWriteScope[User, UserDTO, int](gormopscope.NewWriteTransactionScope("primary", primary)),
+			gormstore.WithReadScope[User, UserDTO, int](gormopscope.NewReadTransactionScope("replica", replica)),
+		)
+
+		return s, primaryMock, replicaMock
+	}
+
+	t.Run("should-read-from-read-scope-and-write-to-write-scope", func(t *testing.T) {
+		// GIVEN
+		s, primaryMock, replicaMock := newStore(t)
+
+		gormtest.ExpectQuery(replicaMock, "SELECT count(*) FROM `user_dtos` WHERE age > ?", 40).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+		gormtest.ExpectExec(primaryMock, "INSERT INTO `user_dtos` (`name`,`age`,`is_admin`,`disabled`) VALUES (?,?,?,?)").
+			WillReturnResult(sqlmock.NewResult(4, 1))
+
+		// WHEN
+		count, err := s.Count(context.Background(), query.Filter("age", 40).WithOP(query.GT))
+		require.NoError(t, err)
+
+		id, err := s.Create(context.Background(), User{Name: "jane", Age: 20})
+		require.NoError(t, err)
+
+		// THEN
+		assert.Equal(t, int64(3), count)
+		assert.Equal(t, 4, id)
+	})
+
+	t.Run("should-read-own-writes-within-write-transaction", func(t *testing.T) {
+		// GIVEN
+		s, primaryMock, _ := newStore(t)
+
+		primaryMock.ExpectBegin()
+		gormtest.ExpectQuery(primaryMock, "SELECT count(*) FROM `user_dtos` WHERE age > ?", 40).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+		primaryMock.ExpectCommit()
+
+		ctx, err := s.OpScope.Begin(context.Background())
+		require.NoError(t, err)
+
+		// WHEN
+		count, err := s.Count(ctx, query.Filter("age", 40).WithOP(query.GT))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), count)
+		require.NoError(t, s.OpScope.End(ctx, nil))
+	})
+
+	t.Run("should-read-locked-rows-from-write-scope", func(t *testing.T) {
+		// GIVEN
+		s, primaryMock, _ := newStore(t)
+
+		gormtest.ExpectQuery(primaryMock, "SELECT * FROM `user_dtos` WHERE age > ? FOR UPDATE", 40).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "age"}).AddRow(1, "john", 41))
+
+		// WHEN
+		users, err := s.List(context.Background(),
+			query.Filter("age", 40).WithOP(query.GT),
+			query.WithLock(query.LockTypeForUpdate),
+		)
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, []User{{ID: 1, Name: "john", Age: 41}}, users)
+	})
+}
@@ -0,0 +1,64 @@
+package gormstore
+
+import (
+	"context"
+	"errors"
+
+	"github.com/infevocorp/goflexstore/query"
+)
+
+// Stream calls fn with each entity matching the params, in the order of the OrderBy params, reading the rows one
+// by one from the database instead of loading them all in memory, so that millions of rows can be processed.
+// The rows are converted like the entities returned by List, and read with the transaction of the operation
+// scope of the context, if any, or on the read scope, see WithReadScope.
+//
+// Streaming stops at the first error returned by fn, which Stream returns. The Paginate params are applied, but
+// not the pagination policy of the store, and Preload params are ignored since the associations of the rows are
+// not loaded. The connection reading the rows is busy until Stream returns: fn must not query a store through
+// the same transaction, e.g. on MySQL, whose connections cannot run a query while reading the rows of another.
+//
+// Example:
+//
+//	err := articleStore.Stream(ctx, func(article *model.Article) error {
+//		return encoder.Encode(article)
+//	}, query.Filter("Status", "published"), query.OrderBy("ID", false))
+func (s *Store[Entity, DTO, ID]) Stream(
+	ctx context.Context,
+	fn func(Entity) error,
+	params ...query.Param,
+) (err error) {
+	defer recoverConversionError(&err)
+
+	queryParams := query.NewParams(params...)
+	if err := s.requirePartitionKey(queryParams); err != nil {
+		return err
+	}
+
+	tx := s.getReadTx(ctx, queryParams).Scopes(s.ScopeBuilder.Build(queryParams)...)
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	rows, err := tx.Rows()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		err = errors.Join(err, rows.Close())
+	}()
+
+	for rows.Next() {
+		var dto DTO
+
+		if err := tx.ScanRows(rows, &dto); err != nil {
+			return err
+		}
+
+		if err := fn(s.Converter.ToEntity(dto)); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
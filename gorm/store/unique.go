@@ -0,0 +1,97 @@
+package gormstore
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	gormquery "github.com/infevocorp/goflexstore/gorm/query"
+	"github.com/infevocorp/goflexstore/query"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// EnsureUnique returns a *store.DuplicateError, matching store.ErrDuplicate, if another entity has the values
+// of the entity for all the fields, so that a duplicate is reported with the conflicting fields before the write
+// instead of as the raw constraint violation of the database. The entity itself, identified by a non-zero ID,
+// is not a duplicate, so that it can be checked before an update. The fields are checked together, like the
+// columns of a composite unique index; an entity with a NULL value for one of them has no duplicate.
+//
+// The params are added to the query of the conflicting entity, e.g. a tenant filter or query.WithLock to lock
+// it until the end of the transaction. EnsureUnique should run within the transaction of the write, and the
+// unique index remains needed to reject the entities inserted concurrently.
+//
+// Example:
+//
+//	err := userStore.EnsureUnique(ctx, []string{"TenantID", "Email"}, user)
+//	if errors.Is(err, store.ErrDuplicate) {
+//		return http.StatusConflict
+//	}
+func (s *Store[Entity, DTO, ID]) EnsureUnique(
+	ctx context.Context,
+	fields []string,
+	entity Entity,
+	params ...query.Param,
+) (err error) {
+	defer recoverConversionError(&err)
+
+	if len(fields) == 0 {
+		return fmt.Errorf("%w: no unique field", gormquery.ErrInvalidParam)
+	}
+
+	db := s.getTx(ctx)
+	if err := db.Statement.Parse(new(DTO)); err != nil {
+		return err
+	}
+
+	var (
+		sch     = db.Statement.Schema
+		dto     = reflect.Indirect(reflect.ValueOf(s.Converter.ToDTO(entity)))
+		filters = make([]query.Param, 0, len(fields)+len(sch.PrimaryFields)+len(params))
+	)
+
+	for _, name := range fields {
+		col := name
+		if mapped, ok := s.ScopeBuilder.FieldToColMap[name]; ok {
+			col = mapped
+		}
+
+		field := sch.LookUpField(col)
+		if field == nil {
+			return fmt.Errorf("%w: unknown unique field %s", gormquery.ErrInvalidParam, name)
+		}
+
+		value := reflect.Indirect(field.ReflectValueOf(ctx, dto))
+		if !value.IsValid() {
+			return nil
+		}
+
+		filters = append(filters, query.Filter(field.DBName, value.Interface()))
+	}
+
+	for _, field := range sch.PrimaryFields {
+		if id, zero := field.ValueOf(ctx, dto); !zero {
+			filters = append(filters, query.Filter(field.DBName, id).WithOP(query.NEQ))
+		}
+	}
+
+	filters = append(filters, params...)
+
+	tx := db.Scopes(s.ScopeBuilder.Build(query.NewParams(filters...))...)
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	var duplicates []DTO
+	if err := tx.Limit(1).Find(&duplicates).Error; err != nil {
+		return err
+	}
+
+	if len(duplicates) > 0 {
+		return &store.DuplicateError{
+			Entity: store.EntityName[Entity](),
+			Fields: fields,
+		}
+	}
+
+	return nil
+}
@@ -1,5 +1,45 @@
 package gormstore
 
+import (
+	"github.com/infevocorp/goflexstore/converter"
+	"github.com/infevocorp/goflexstore/query"
+)
+
+// maxListCapacity bounds the capacity preallocated by List, so a large page limit does not
+// allocate more memory than the rows actually returned would need.
+const maxListCapacity = 1000
+
+// listCapacity returns the capacity to preallocate for the results of a List query,
+// derived from its pagination limit.
+func listCapacity(params query.Params) int {
+	paginates := params.Get(query.TypePaginate)
+	if len(paginates) == 0 {
+		return 0
+	}
+
+	limit := paginates[len(paginates)-1].(query.PaginateParam).Limit
+	if limit <= 0 {
+		return 0
+	}
+
+	return min(limit, maxListCapacity)
+}
+
+// isUnscoped reports whether params include the soft-deleted entities, see query.Unscoped.
+func isUnscoped(params query.Params) bool {
+	return len(params.Get(query.TypeUnscoped)) > 0
+}
+
+// toEntities converts the DTOs read by a query. With converter.Identity, the entities share the slice of the
+// DTOs instead of being copied one by one.
+func (s *Store[Entity, DTO, ID]) toEntities(dtos []DTO) []Entity {
+	if identity, ok := s.Converter.(*converter.Identity[Entity, DTO, ID]); ok {
+		return identity.ToEntities(dtos)
+	}
+
+	return converter.ToMany(dtos, s.Converter.ToEntity)
+}
+
 func defaultValue[T comparable](val T, defaultVal T) T {
 	if val == (*new(T)) {
 		return defaultVal
@@ -0,0 +1,48 @@
+package gormstore
+
+import (
+	"context"
+
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// Validator validates the entities written by a Store, see WithValidator.
+// The *validator.Validate of github.com/go-playground/validator satisfies this interface.
+type Validator interface {
+	// StructCtx returns an error if the entity is invalid.
+	StructCtx(ctx context.Context, entity any) error
+}
+
+// ValidatorFunc is a function implementing Validator.
+type ValidatorFunc func(ctx context.Context, entity any) error
+
+// StructCtx calls f(ctx, entity).
+func (f ValidatorFunc) StructCtx(ctx context.Context, entity any) error {
+	return f(ctx, entity)
+}
+
+// MethodValidator is a Validator calling the Validate method of the entities implementing it,
+// and accepting the other entities.
+var MethodValidator Validator = ValidatorFunc(func(_ context.Context, entity any) error {
+	if v, ok := entity.(interface{ Validate() error }); ok {
+		return v.Validate()
+	}
+
+	return nil
+})
+
+// validate validates the entities with the validator of the store, if any.
+// It returns a store.ValidationError for the first invalid entity.
+func (s *Store[Entity, DTO, ID]) validate(ctx context.Context, entities ...Entity) error {
+	if s.Validator == nil {
+		return nil
+	}
+
+	for _, entity := range entities {
+		if err := s.Validator.StructCtx(ctx, entity); err != nil {
+			return store.NewValidationError(store.EntityName[Entity](), err)
+		}
+	}
+
+	return nil
+}
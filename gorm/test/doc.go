@@ -0,0 +1,20 @@
+// Package gormtest provides helpers to test code generating SQL through GORM with go-sqlmock.
+//
+// NewDB replaces the newTestDB function copied in every test package: it opens a MySQL flavored GORM
+// database backed by sqlmock and verifies the expectations when the test ends. ExpectQuery and ExpectExec
+// register expectations from plain SQL, without regexp.QuoteMeta and insensitive to whitespace, so that
+// long statements can be written on several lines. AssertSQL checks the statement generated by scopes,
+// e.g. the ones built by gormquery from query params, without executing it.
+//
+// Example:
+//
+//	db, mock := gormtest.NewDB(t)
+//
+//	gormtest.ExpectQuery(mock, `
+//		SELECT * FROM users
+//		WHERE age > ?`, 18).
+//		WillReturnRows(gormtest.Rows([]string{"id", "name"}, []driver.Value{1, "john"}))
+//
+//	gormtest.AssertSQL(t, db, &User{}, builder.Build(query.NewParams(query.Filter("Age", 18).WithOP(query.GT))),
+//		"SELECT * FROM `users` WHERE age > ?", 18)
+package gormtest
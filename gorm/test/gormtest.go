@@ -0,0 +1,200 @@
+package gormtest
+
+import (
+	"database/sql/driver"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// Option is a function that configures the database opened by NewDB.
+type Option func(*options)
+
+type options struct {
+	config       *gorm.Config
+	queryMatcher sqlmock.QueryMatcher
+	version      string
+}
+
+// WithConfig sets the GORM config of the database. DisableAutomaticPing is always enabled.
+func WithConfig(config *gorm.Config) Option {
+	return func(o *options) {
+		o.config = config
+	}
+}
+
+// WithQueryMatcher sets the matcher used by sqlmock to compare the expected and actual SQL.
+// It defaults to sqlmock.QueryMatcherRegexp, which ExpectQuery and ExpectExec rely on.
+func WithQueryMatcher(matcher sqlmock.QueryMatcher) Option {
+	return func(o *options) {
+		o.queryMatcher = matcher
+	}
+}
+
+// WithVersion sets the MySQL version returned to GORM when opening the database. It defaults to "8.0.23".
+func WithVersion(version string) Option {
+	return func(o *options) {
+		o.version = version
+	}
+}
+
+// NewDB opens a GORM database using the MySQL dialect over a sqlmock connection.
+// The expectations of the mock are verified when the test and its subtests complete.
+//
+// Parameters:
+//   - t: The test owning the database.
+//   - opts: Options customizing the database, see WithConfig, WithQueryMatcher and WithVersion.
+//
+// Returns:
+// The GORM database and the mock to register the expected statements on.
+func NewDB(t testing.TB, opts ...Option) (*gorm.DB, sqlmock.Sqlmock) {
+	t.Helper()
+
+	o := options{
+		config:       &gorm.Config{},
+		queryMatcher: sqlmock.QueryMatcherRegexp,
+		version:      "8.0.23",
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(o.queryMatcher))
+	require.NoError(t, err)
+
+	mock.ExpectQuery("SELECT VERSION()").WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(o.version))
+
+	config := *o.config
+	config.DisableAutomaticPing = true
+
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{
+		Conn: db,
+	}), &config)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	return gormDB, mock
+}
+
+var whitespaces = regexp.MustCompile(`\s+`)
+
+// Normalize collapses the whitespaces of a SQL statement into single spaces and trims it.
+func Normalize(sql string) string {
+	return strings.TrimSpace(whitespaces.ReplaceAllString(sql, " "))
+}
+
+// QuoteSQL returns a regular expression matching exactly the SQL statement, whatever its whitespaces.
+// It is meant for the default regexp query matcher of sqlmock, in place of regexp.QuoteMeta.
+func QuoteSQL(sql string) string {
+	words := strings.Fields(sql)
+	for i, word := range words {
+		words[i] = regexp.QuoteMeta(word)
+	}
+
+	return `^\s*` + strings.Join(words, `\s+`) + `\s*$`
+}
+
+// ExpectQuery expects the SQL query to be executed with the given arguments.
+// The SQL is matched literally, ignoring whitespaces. Without arguments, any arguments are accepted.
+func ExpectQuery(mock sqlmock.Sqlmock, sql string, args ...driver.Value) *sqlmock.ExpectedQuery {
+	expected := mock.ExpectQuery(QuoteSQL(sql))
+	if len(args) > 0 {
+		expected = expected.WithArgs(args...)
+	}
+
+	return expected
+}
+
+// ExpectExec expects the SQL statement to be executed with the given arguments.
+// The SQL is matched literally, ignoring whitespaces. Without arguments, any arguments are accepted.
+func ExpectExec(mock sqlmock.Sqlmock, sql string, args ...driver.Value) *sqlmock.ExpectedExec {
+	expected := mock.ExpectExec(QuoteSQL(sql))
+	if len(args) > 0 {
+		expected = expected.WithArgs(args...)
+	}
+
+	return expected
+}
+
+// ExpectQueryRegexp expects a SQL query matching the regular expression to be executed with the given arguments.
+func ExpectQueryRegexp(mock sqlmock.Sqlmock, pattern string, args ...driver.Value) *sqlmock.ExpectedQuery {
+	expected := mock.ExpectQuery(pattern)
+	if len(args) > 0 {
+		expected = expected.WithArgs(args...)
+	}
+
+	return expected
+}
+
+// AnyArgs returns n sqlmock.AnyArg matchers, for statements whose arguments are irrelevant to the test.
+func AnyArgs(n int) []driver.Value {
+	args := make([]driver.Value, n)
+	for i := range args {
+		args[i] = sqlmock.AnyArg()
+	}
+
+	return args
+}
+
+// Rows creates the rows returned by a query from the column names and the values of each row.
+func Rows(columns []string, values ...[]driver.Value) *sqlmock.Rows {
+	rows := sqlmock.NewRows(columns)
+	for _, row := range values {
+		rows.AddRow(row...)
+	}
+
+	return rows
+}
+
+// DryRun returns the SELECT statement and its arguments generated for model by the scopes,
+// without executing it.
+func DryRun(db *gorm.DB, model any, scopes ...func(*gorm.DB) *gorm.DB) (string, []any) {
+	stmt := db.Session(&gorm.Session{DryRun: true}).
+		Model(model).
+		Scopes(scopes...).
+		Find(model).
+		Statement
+
+	return stmt.SQL.String(), stmt.Vars
+}
+
+// TestingT is the subset of testing.TB used by AssertSQL.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+// AssertSQL asserts that the scopes generate the expected SELECT statement and arguments for model.
+// Whitespaces are normalized before comparing the statements.
+func AssertSQL(
+	t TestingT,
+	db *gorm.DB,
+	model any,
+	scopes []func(*gorm.DB) *gorm.DB,
+	wantSQL string,
+	wantArgs ...any,
+) bool {
+	t.Helper()
+
+	sql, args := DryRun(db, model, scopes...)
+
+	if len(wantArgs) == 0 {
+		wantArgs = nil
+	}
+
+	if len(args) == 0 {
+		args = nil
+	}
+
+	return assert.Equal(t, Normalize(wantSQL), Normalize(sql)) && assert.Equal(t, wantArgs, args)
+}
@@ -0,0 +1,138 @@
+package gormtest_test
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gormquery "github.com/infevocorp/goflexstore/gorm/query"
+	gormtest "github.com/infevocorp/goflexstore/gorm/test"
+	gormutils "github.com/infevocorp/goflexstore/gorm/utils"
+	"github.com/infevocorp/goflexstore/query"
+)
+
+type User struct {
+	ID   int    `gorm:"column:id;primaryKey"`
+	Name string `gorm:"column:name"`
+	Age  int    `gorm:"column:age"`
+}
+
+func Test_Normalize(t *testing.T) {
+	t.Run("should-collapse-whitespaces", func(t *testing.T) {
+		assert.Equal(t,
+			"SELECT * FROM users WHERE age > ?",
+			gormtest.Normalize("\n\tSELECT *\n\tFROM users\n\tWHERE  age > ?\n"),
+		)
+	})
+}
+
+func Test_QuoteSQL(t *testing.T) {
+	tests := []struct {
+		name string
+		sql  string
+		want bool
+	}{
+		{name: "same-sql", sql: "SELECT * FROM `users` WHERE id IN (?,?)", want: true},
+		{name: "other-whitespaces", sql: "SELECT *\n FROM `users`\n WHERE id IN (?,?)", want: true},
+		{name: "longer-sql", sql: "SELECT * FROM `users` WHERE id IN (?,?) LIMIT 1", want: false},
+		{name: "other-sql", sql: "SELECT * FROM `users` WHERE id IN (??)", want: false},
+	}
+
+	pattern := gormtest.QuoteSQL("SELECT * FROM `users`\nWHERE id IN (?,?)")
+
+	for i := range tests {
+		tt := tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, regexp.MustCompile(pattern).MatchString(tt.sql))
+		})
+	}
+}
+
+func Test_NewDB(t *testing.T) {
+	t.Run("should-match-expected-query", func(t *testing.T) {
+		// GIVEN
+		db, mock := gormtest.NewDB(t)
+
+		gormtest.ExpectQuery(mock, `
+			SELECT * FROM `+"`users`"+`
+			WHERE age > ?`, 18).
+			WillReturnRows(gormtest.Rows([]string{"id", "name", "age"},
+				[]driver.Value{1, "john", 20},
+				[]driver.Value{2, "jane", 30},
+			))
+
+		// WHEN
+		var users []User
+		err := db.Where("age > ?", 18).Find(&users).Error
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, []User{
+			{ID: 1, Name: "john", Age: 20},
+			{ID: 2, Name: "jane", Age: 30},
+		}, users)
+	})
+
+	t.Run("should-match-expected-exec", func(t *testing.T) {
+		// GIVEN
+		db, mock := gormtest.NewDB(t)
+
+		mock.ExpectBegin()
+		gormtest.ExpectExec(mock, "DELETE FROM `users` WHERE `users`.`id` = ?", gormtest.AnyArgs(1)...).
+			WillReturnResult(driver.RowsAffected(1))
+		mock.ExpectCommit()
+
+		// WHEN
+		err := db.Delete(&User{ID: 1}).Error
+
+		// THEN
+		require.NoError(t, err)
+	})
+}
+
+func Test_AssertSQL(t *testing.T) {
+	t.Run("should-assert-sql-of-params", func(t *testing.T) {
+		// GIVEN
+		db, _ := gormtest.NewDB(t)
+		builder := gormquery.NewBuilder(gormquery.WithFieldToColMap(gormutils.FieldToColMap(User{})))
+
+		// WHEN
+		scopes := builder.Build(query.NewParams(
+			query.Filter("Age", 18).WithOP(query.GT),
+			query.OrderBy("Name", false),
+			query.Paginate(0, 10),
+		))
+
+		// THEN
+		gormtest.AssertSQL(t, db, &User{}, scopes, "SELECT * FROM `users` WHERE age > ? ORDER BY `name` LIMIT 10", 18)
+	})
+
+	t.Run("should-fail-on-different-sql", func(t *testing.T) {
+		// GIVEN
+		db, _ := gormtest.NewDB(t)
+		builder := gormquery.NewBuilder(gormquery.WithFieldToColMap(gormutils.FieldToColMap(User{})))
+		scopes := builder.Build(query.NewParams(query.Filter("Age", 18)))
+
+		// WHEN
+		rt := &recorderT{}
+		ok := gormtest.AssertSQL(rt, db, &User{}, scopes, "SELECT * FROM `users` WHERE age > ?", 18)
+
+		// THEN
+		assert.False(t, ok)
+		assert.Len(t, rt.errors, 1)
+	})
+}
+
+type recorderT struct {
+	errors []string
+}
+
+func (r *recorderT) Helper() {}
+
+func (r *recorderT) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
@@ -2,10 +2,14 @@ package gormutils
 
 import (
 	"reflect"
+	"sync"
 
 	"gorm.io/gorm/schema"
 )
 
+// fieldToColMaps caches the field to column maps by struct type.
+var fieldToColMaps sync.Map
+
 // FieldToColMap creates a map of struct field names to their corresponding database column names.
 // This function is particularly useful for translating struct field names to database columns
 // when working with GORM, especially when struct fields are tagged with GORM tags defining the column names.
@@ -42,11 +46,53 @@ import (
 //
 // In this example, the User struct has fields ID, FirstName, and LastName. The `FieldToColMap` function
 // creates a map where 'ID' maps to 'id', 'FirstName' maps to 'first_name', and 'LastName' maps to 'last_name'.
+//
+// The map is only built once per struct type, subsequent calls return a copy of the cached map
+// that can be modified freely.
 func FieldToColMap(dto any) map[string]string {
+	cached := cachedFieldToColMap(getStructType(dto))
+	index := make(map[string]string, len(cached))
+
+	for field, col := range cached {
+		index[field] = col
+	}
+
+	return index
+}
+
+// FieldToColMaps returns the field to column maps of the given structs, in the same order.
+// It is a convenience for building the maps of several DTOs at once, e.g. when constructing the stores.
+//
+// Example:
+//
+//	maps := gormutils.FieldToColMaps(dto.User{}, dto.Article{})
+//	userCols, articleCols := maps[0], maps[1]
+func FieldToColMaps(dtos ...any) []map[string]string {
+	maps := make([]map[string]string, len(dtos))
+
+	for i, dto := range dtos {
+		maps[i] = FieldToColMap(dto)
+	}
+
+	return maps
+}
+
+// cachedFieldToColMap returns the cached field to column map of the struct type, building it on first use.
+// The returned map is shared and must not be modified.
+func cachedFieldToColMap(dtoTypeOf reflect.Type) map[string]string {
+	if index, ok := fieldToColMaps.Load(dtoTypeOf); ok {
+		return index.(map[string]string)
+	}
+
+	index, _ := fieldToColMaps.LoadOrStore(dtoTypeOf, buildFieldToColMap(dtoTypeOf))
+
+	return index.(map[string]string)
+}
+
+func buildFieldToColMap(dtoTypeOf reflect.Type) map[string]string {
 	var (
-		dtoTypeOf = getStructType(dto)
-		index     = map[string]string{}
-		numField  = dtoTypeOf.NumField()
+		index    = map[string]string{}
+		numField = dtoTypeOf.NumField()
 	)
 
 	for i := 0; i < numField; i++ {
@@ -0,0 +1,70 @@
+package gormutils_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	gormutils "github.com/infevocorp/goflexstore/gorm/utils"
+)
+
+type UserDTO struct {
+	ID        int64  `gorm:"column:id;primaryKey"`
+	FirstName string `gorm:"column:first_name"`
+	Age       int
+	secret    string //nolint:unused
+}
+
+type ArticleDTO struct {
+	ID    int64  `gorm:"column:id"`
+	Title string `gorm:"column:title"`
+}
+
+func Test_FieldToColMap(t *testing.T) {
+	t.Run("should-map-fields-to-columns", func(t *testing.T) {
+		index := gormutils.FieldToColMap(UserDTO{})
+
+		assert.Equal(t, map[string]string{
+			"ID":        "id",
+			"FirstName": "first_name",
+			"Age":       "Age",
+		}, index)
+	})
+
+	t.Run("should-accept-pointers", func(t *testing.T) {
+		assert.Equal(t, gormutils.FieldToColMap(UserDTO{}), gormutils.FieldToColMap(&UserDTO{}))
+	})
+
+	t.Run("should-return-copies-of-cached-map", func(t *testing.T) {
+		index := gormutils.FieldToColMap(UserDTO{})
+		index["ID"] = "user_id"
+
+		assert.Equal(t, "id", gormutils.FieldToColMap(UserDTO{})["ID"])
+	})
+}
+
+func Test_FieldToColMaps(t *testing.T) {
+	t.Run("should-return-maps-in-order", func(t *testing.T) {
+		maps := gormutils.FieldToColMaps(ArticleDTO{}, &UserDTO{})
+
+		assert.Equal(t, []map[string]string{
+			{
+				"ID":    "id",
+				"Title": "title",
+			},
+			{
+				"ID":        "id",
+				"FirstName": "first_name",
+				"Age":       "Age",
+			},
+		}, maps)
+	})
+}
+
+func Benchmark_FieldToColMap(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_ = gormutils.FieldToColMap(UserDTO{})
+	}
+}
@@ -0,0 +1,16 @@
+// Package health checks the persistence layer of a service, to back its health and readiness endpoints.
+//
+// A Checker runs named checks concurrently, each bounded by a timeout, and reports the status of each of them.
+// WithStores adds a check pinging every store of a registry, so services do not need the raw database handles,
+// and other checks, such as the replica lag check of gormhealth, are added with WithCheck.
+// The Checker is an http.Handler responding 200 when every check passes and 503 otherwise.
+//
+// Example:
+//
+//	checker := health.NewChecker(
+//		health.WithStores(stores),
+//		health.WithCheck("replica-lag", gormhealth.ReplicaLag(replicaDB, 10*time.Second)),
+//	)
+//
+//	http.Handle("/healthz", checker)
+package health
@@ -0,0 +1,161 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/infevocorp/goflexstore/opscope"
+	"github.com/infevocorp/goflexstore/registry"
+)
+
+// Status is the status of a check or of a whole report.
+type Status string
+
+const (
+	// StatusUp means that the check passed.
+	StatusUp Status = "up"
+	// StatusDown means that the check failed.
+	StatusDown Status = "down"
+)
+
+// Check returns an error if the checked dependency is unhealthy.
+type Check func(ctx context.Context) error
+
+// Result is the outcome of a check.
+type Result struct {
+	Name     string        `json:"name"`
+	Status   Status        `json:"status"`
+	Error    string        `json:"error,omitempty"`
+	Duration time.Duration `json:"duration"`
+}
+
+// Report is the outcome of all the checks of a Checker. Its status is down as soon as one check failed.
+type Report struct {
+	Status Status   `json:"status"`
+	Checks []Result `json:"checks"`
+}
+
+// Option is a function that configures the Checker.
+type Option func(*Checker)
+
+// WithTimeout sets the maximum duration of each check. It defaults to 5 seconds.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Checker) {
+		c.timeout = timeout
+	}
+}
+
+// WithCheck adds a named check.
+func WithCheck(name string, check Check) Option {
+	return func(c *Checker) {
+		c.checks = append(c.checks, namedCheck{name: name, check: check})
+	}
+}
+
+// WithStores adds a check per store registered in the registry when the option is applied, named after
+// the entity type of the store, e.g. "store:Article", and calling its Ping method.
+func WithStores[S opscope.Scope](stores *registry.Registry[S]) Option {
+	return func(c *Checker) {
+		for _, entry := range stores.Entries() {
+			c.checks = append(c.checks, namedCheck{name: "store:" + entry.Entity, check: entry.Ping})
+		}
+	}
+}
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Checker runs health checks, see NewChecker.
+type Checker struct {
+	checks  []namedCheck
+	timeout time.Duration
+}
+
+// NewChecker creates a Checker running the checks given as options.
+func NewChecker(opts ...Option) *Checker {
+	c := &Checker{
+		timeout: 5 * time.Second,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Check runs all the checks concurrently and returns their results, in the order the checks were added.
+func (c *Checker) Check(ctx context.Context) Report {
+	var (
+		wg     sync.WaitGroup
+		report = Report{
+			Status: StatusUp,
+			Checks: make([]Result, len(c.checks)),
+		}
+	)
+
+	for i, check := range c.checks {
+		wg.Add(1)
+
+		go func(i int, check namedCheck) {
+			defer wg.Done()
+
+			report.Checks[i] = c.run(ctx, check)
+		}(i, check)
+	}
+
+	wg.Wait()
+
+	for _, result := range report.Checks {
+		if result.Status == StatusDown {
+			report.Status = StatusDown
+		}
+	}
+
+	return report
+}
+
+// ServeHTTP runs the checks and writes the report as JSON, with the status 200 when every check passed
+// and 503 otherwise.
+func (c *Checker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	report := c.Check(r.Context())
+
+	status := http.StatusOK
+	if report.Status == StatusDown {
+		status = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(report)
+}
+
+// run runs a check bounded by the timeout of the checker.
+func (c *Checker) run(ctx context.Context, check namedCheck) Result {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var (
+		start = time.Now()
+		err   = check.check(ctx)
+	)
+
+	result := Result{
+		Name:     check.name,
+		Status:   StatusUp,
+		Duration: time.Since(start),
+	}
+
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+
+	return result
+}
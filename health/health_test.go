@@ -0,0 +1,129 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infevocorp/goflexstore/health"
+	mockopscope "github.com/infevocorp/goflexstore/mocks/opscope"
+	"github.com/infevocorp/goflexstore/registry"
+	"github.com/infevocorp/goflexstore/storetest"
+)
+
+type User struct {
+	ID int64
+}
+
+func (u *User) GetID() int64 {
+	return u.ID
+}
+
+type Article struct {
+	ID int64
+}
+
+func (a *Article) GetID() int64 {
+	return a.ID
+}
+
+func Test_Checker_Check(t *testing.T) {
+	t.Run("should-ping-registered-stores", func(t *testing.T) {
+		// GIVEN
+		var (
+			stores   = registry.New(mockopscope.NewScope(t))
+			users    = storetest.NewFake[*User, int64]()
+			articles = storetest.NewFake[*Article, int64]()
+		)
+
+		registry.Register[*User, int64](stores, users)
+		registry.Register[*Article, int64](stores, articles)
+		articles.FailWith("Ping", errors.New("connection refused"))
+
+		checker := health.NewChecker(health.WithStores(stores))
+
+		// WHEN
+		report := checker.Check(context.Background())
+
+		// THEN
+		assert.Equal(t, health.StatusDown, report.Status)
+		require.Len(t, report.Checks, 2)
+		assert.Equal(t, "store:User", report.Checks[0].Name)
+		assert.Equal(t, health.StatusUp, report.Checks[0].Status)
+		assert.Equal(t, "store:Article", report.Checks[1].Name)
+		assert.Equal(t, health.StatusDown, report.Checks[1].Status)
+		assert.Equal(t, "connection refused", report.Checks[1].Error)
+		assert.Len(t, users.Calls("Ping"), 1)
+	})
+
+	t.Run("should-bound-checks-with-timeout", func(t *testing.T) {
+		// GIVEN
+		checker := health.NewChecker(
+			health.WithTimeout(10*time.Millisecond),
+			health.WithCheck("slow", func(ctx context.Context) error {
+				<-ctx.Done()
+
+				return ctx.Err()
+			}),
+		)
+
+		// WHEN
+		report := checker.Check(context.Background())
+
+		// THEN
+		assert.Equal(t, health.StatusDown, report.Status)
+		assert.Equal(t, context.DeadlineExceeded.Error(), report.Checks[0].Error)
+	})
+
+	t.Run("should-be-up-without-checks", func(t *testing.T) {
+		report := health.NewChecker().Check(context.Background())
+
+		assert.Equal(t, health.StatusUp, report.Status)
+		assert.Empty(t, report.Checks)
+	})
+}
+
+func Test_Checker_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantCode   int
+		wantStatus health.Status
+	}{
+		{name: "healthy", wantCode: http.StatusOK, wantStatus: health.StatusUp},
+		{
+			name:       "unhealthy",
+			err:        errors.New("down"),
+			wantCode:   http.StatusServiceUnavailable,
+			wantStatus: health.StatusDown,
+		},
+	}
+
+	for i := range tests {
+		tt := tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			checker := health.NewChecker(health.WithCheck("db", func(context.Context) error {
+				return tt.err
+			}))
+			rec := httptest.NewRecorder()
+
+			// WHEN
+			checker.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+			// THEN
+			var report health.Report
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+			assert.Equal(t, tt.wantCode, rec.Code)
+			assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+			assert.Equal(t, tt.wantStatus, report.Status)
+		})
+	}
+}
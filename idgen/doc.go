@@ -0,0 +1,12 @@
+// Package idgen generates the IDs of entities on the client side, before they are created.
+//
+// UUIDv7 generates version 7 UUIDs, which start with their creation time in milliseconds: unlike random
+// version 4 UUIDs, consecutive IDs are close in primary key indexes, which keeps inserts fast. It fills
+// any ID type whose underlying type is [16]byte, such as github.com/google/uuid.UUID, or string.
+//
+// Example:
+//
+//	articleStore := gormstore.New[*model.Article, *dto.Article, string](scope,
+//		gormstore.WithIDGenerator[*model.Article, *dto.Article, string](idgen.UUIDv7[string]),
+//	)
+package idgen
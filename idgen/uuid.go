@@ -0,0 +1,88 @@
+package idgen
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// ErrUnsupportedType is returned by UUIDv7 when the ID type is neither a [16]byte nor a string.
+var ErrUnsupportedType = errors.New("unsupported UUID type")
+
+// Generator returns a new ID, e.g. UUIDv7.
+type Generator[ID any] func(ctx context.Context) (ID, error)
+
+// IsUUID reports whether the underlying type of ID is [16]byte, the one of the UUID types.
+func IsUUID[ID any]() bool {
+	t := reflect.TypeOf((*ID)(nil)).Elem()
+
+	return t.Kind() == reflect.Array && t.Len() == 16 && t.Elem().Kind() == reflect.Uint8
+}
+
+// NewUUIDv7 returns a new version 7 UUID, as defined by RFC 9562: a 48-bit Unix timestamp in milliseconds
+// followed by 74 random bits.
+func NewUUIDv7() ([16]byte, error) {
+	var u [16]byte
+
+	if _, err := rand.Read(u[6:]); err != nil {
+		return u, err
+	}
+
+	ms := uint64(time.Now().UnixMilli())
+	for i := 0; i < 6; i++ {
+		u[i] = byte(ms >> (40 - 8*i))
+	}
+
+	u[6] = u[6]&0x0f | 0x70 // version 7
+	u[8] = u[8]&0x3f | 0x80 // variant 10
+
+	return u, nil
+}
+
+// FormatUUID returns the canonical text form of the UUID, e.g. "01890a5d-ac96-774b-bcce-b302099a8057".
+func FormatUUID(u [16]byte) string {
+	var b [36]byte
+
+	hex.Encode(b[0:8], u[0:4])
+	b[8] = '-'
+	hex.Encode(b[9:13], u[4:6])
+	b[13] = '-'
+	hex.Encode(b[14:18], u[6:8])
+	b[18] = '-'
+	hex.Encode(b[19:23], u[8:10])
+	b[23] = '-'
+	hex.Encode(b[24:], u[10:])
+
+	return string(b[:])
+}
+
+// UUIDv7 is a Generator of version 7 UUIDs, see NewUUIDv7. IDs whose underlying type is [16]byte receive
+// the bytes of the UUID, and strings receive its canonical text form.
+// It returns an error wrapping ErrUnsupportedType for the other ID types.
+func UUIDv7[ID any](context.Context) (ID, error) {
+	var (
+		id ID
+		v  = reflect.ValueOf(&id).Elem()
+	)
+
+	if v.Kind() != reflect.String && !IsUUID[ID]() {
+		return id, fmt.Errorf("%w: %T", ErrUnsupportedType, id)
+	}
+
+	u, err := NewUUIDv7()
+	if err != nil {
+		return id, err
+	}
+
+	if v.Kind() == reflect.String {
+		v.SetString(FormatUUID(u))
+	} else {
+		reflect.Copy(v, reflect.ValueOf(u))
+	}
+
+	return id, nil
+}
@@ -0,0 +1,66 @@
+package idgen_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infevocorp/goflexstore/idgen"
+)
+
+type UUID [16]byte
+
+var uuidV7Pattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+func Test_UUIDv7(t *testing.T) {
+	t.Run("should-generate-uuid", func(t *testing.T) {
+		// WHEN
+		id, err := idgen.UUIDv7[UUID](context.Background())
+
+		// THEN
+		require.NoError(t, err)
+		assert.Regexp(t, uuidV7Pattern, idgen.FormatUUID(id))
+	})
+
+	t.Run("should-generate-string", func(t *testing.T) {
+		// WHEN
+		id, err := idgen.UUIDv7[string](context.Background())
+
+		// THEN
+		require.NoError(t, err)
+		assert.Regexp(t, uuidV7Pattern, id)
+	})
+
+	t.Run("should-generate-ordered-uuids", func(t *testing.T) {
+		// GIVEN
+		first, err := idgen.NewUUIDv7()
+		require.NoError(t, err)
+
+		// WHEN
+		second, err := idgen.NewUUIDv7()
+
+		// THEN
+		require.NoError(t, err)
+		assert.NotEqual(t, first, second)
+		assert.LessOrEqual(t, idgen.FormatUUID(first)[:13], idgen.FormatUUID(second)[:13])
+	})
+
+	t.Run("should-fail-with-unsupported-type", func(t *testing.T) {
+		// WHEN
+		_, err := idgen.UUIDv7[int64](context.Background())
+
+		// THEN
+		assert.ErrorIs(t, err, idgen.ErrUnsupportedType)
+		assert.EqualError(t, err, "unsupported UUID type: int64")
+	})
+}
+
+func Test_IsUUID(t *testing.T) {
+	assert.True(t, idgen.IsUUID[UUID]())
+	assert.True(t, idgen.IsUUID[[16]byte]())
+	assert.False(t, idgen.IsUUID[[8]byte]())
+	assert.False(t, idgen.IsUUID[string]())
+}
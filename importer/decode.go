@@ -0,0 +1,164 @@
+package importer
+
+import (
+	"encoding"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// rowReader reads the rows of a stream. next returns a function decoding the next row into the value pointed by
+// its argument, or io.EOF at the end of the stream. Decoding errors are the errors of the row, whereas the errors
+// returned by next abort the import.
+type rowReader interface {
+	next() (decode func(v any) error, err error)
+}
+
+func newRowReader(r io.Reader, format Format) (rowReader, error) {
+	switch format {
+	case CSV:
+		return newCSVReader(r)
+	case NDJSON:
+		return &ndjsonReader{decoder: json.NewDecoder(r)}, nil
+	default:
+		return nil, fmt.Errorf("importer: unknown format %d", format)
+	}
+}
+
+// csvReader reads the records of a CSV stream whose first record is the header.
+type csvReader struct {
+	reader *csv.Reader
+	header []string
+}
+
+func newCSVReader(r io.Reader) (*csvReader, error) {
+	reader := csv.NewReader(r)
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return &csvReader{reader: reader}, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("importer: read csv header: %w", err)
+	}
+
+	normalized := make([]string, len(header))
+	for i, name := range header {
+		normalized[i] = normalize(name)
+	}
+
+	return &csvReader{reader: reader, header: normalized}, nil
+}
+
+func (r *csvReader) next() (func(v any) error, error) {
+	record, err := r.reader.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	return func(v any) error {
+		return decodeCSVRecord(v, r.header, record)
+	}, nil
+}
+
+// decodeCSVRecord sets the fields of the value pointed by target from the cells of the record, allocating it
+// when it is a nil pointer. Empty cells leave their field unchanged.
+func decodeCSVRecord(target any, header, record []string) error {
+	v := reflect.ValueOf(target).Elem()
+	if v.Kind() == reflect.Pointer && v.IsNil() {
+		v.Set(reflect.New(v.Type().Elem()))
+	}
+
+	for v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return fmt.Errorf("row %s is not a struct", v.Type())
+	}
+
+	for i, cell := range record {
+		if i >= len(header) || cell == "" {
+			continue
+		}
+
+		field, ok := fieldByColumn(v, header[i])
+		if !ok {
+			return fmt.Errorf("row %s has no field matching column %s", v.Type(), header[i])
+		}
+
+		if err := setCell(field, cell); err != nil {
+			return fmt.Errorf("%s: %w", header[i], err)
+		}
+	}
+
+	return nil
+}
+
+// fieldByColumn returns the settable field of the struct whose name or JSON name matches the normalized column.
+func fieldByColumn(v reflect.Value, column string) (reflect.Value, bool) {
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		jsonName, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if normalize(f.Name) == column || (jsonName != "" && normalize(jsonName) == column) {
+			return v.Field(i), true
+		}
+	}
+
+	return reflect.Value{}, false
+}
+
+// setCell sets the field from the text of a cell, with its UnmarshalText method if it has one,
+// as is for strings, and as a JSON value otherwise, e.g. for numbers and booleans.
+func setCell(field reflect.Value, cell string) error {
+	if field.Kind() == reflect.Pointer {
+		if field.IsNil() {
+			field.Set(reflect.New(field.Type().Elem()))
+		}
+
+		field = field.Elem()
+	}
+
+	if u, ok := field.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(cell))
+	}
+
+	if field.Kind() == reflect.String {
+		field.SetString(cell)
+		return nil
+	}
+
+	return json.Unmarshal([]byte(cell), field.Addr().Interface())
+}
+
+func normalize(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+}
+
+// ndjsonReader reads the objects of an ND-JSON stream.
+type ndjsonReader struct {
+	decoder *json.Decoder
+}
+
+func (r *ndjsonReader) next() (func(v any) error, error) {
+	var raw json.RawMessage
+	if err := r.decoder.Decode(&raw); err != nil {
+		return nil, err
+	}
+
+	return func(v any) error {
+		return json.Unmarshal(raw, v)
+	}, nil
+}
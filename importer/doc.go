@@ -0,0 +1,23 @@
+// Package importer imports entities in bulk from CSV or ND-JSON streams through a store, e.g. to migrate data or
+// seed a database.
+//
+// An Importer reads the rows one at a time, decodes them into entities, or into rows converted to entities by a
+// converter, validates them and writes them by batches with CreateMany, or Upsert when an OnConflict strategy is
+// set. Batches are written within transactions of the operation scope of the store, each transaction writing a
+// chunk of batches, so that the memory used and the duration of the transactions stay bounded whatever the size
+// of the stream.
+//
+// CSV columns are mapped to the fields of the rows by their header, case insensitively and ignoring underscores,
+// and ND-JSON objects are decoded like JSON. The rows which cannot be decoded or are invalid are reported in the
+// Report, and skipped up to the maximum number of errors set by WithMaxErrors.
+//
+// Example:
+//
+//	imp := importer.NewWithConverter[*model.Article, *dto.ArticleRow, int64](scope, articleStore, rowConverter,
+//		importer.WithBatchSize(500),
+//		importer.WithMaxErrors(100),
+//		importer.WithProgress(func(r importer.Report) { log.Printf("%d rows imported", r.Imported) }),
+//	)
+//
+//	report, err := imp.Import(ctx, file, importer.CSV)
+package importer
@@ -0,0 +1,286 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/infevocorp/goflexstore/converter"
+	"github.com/infevocorp/goflexstore/opscope"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// Format is the format of an imported stream.
+type Format int
+
+const (
+	// CSV is a CSV stream whose first record is the header naming the fields of the columns.
+	CSV Format = iota
+	// NDJSON is a stream of JSON objects separated by newlines.
+	NDJSON
+)
+
+// ErrTooManyErrors is returned by Import when more rows than the maximum set by WithMaxErrors are invalid.
+var ErrTooManyErrors = errors.New("importer: too many invalid rows")
+
+// RowError is the error of a row which cannot be decoded or is invalid.
+//
+// Fields:
+//   - Row: The number of the row in the stream, starting at 1, the header of CSV streams excluded.
+//   - Err: The decoding error, or a store.ValidationError.
+type RowError struct {
+	Row int
+	Err error
+}
+
+// Error returns the number of the row and its error.
+func (e *RowError) Error() string {
+	return fmt.Sprintf("row %d: %s", e.Row, e.Err)
+}
+
+// Unwrap returns the error of the row.
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+// Report is the outcome of an import.
+//
+// Fields:
+//   - Rows: The number of rows read.
+//   - Imported: The number of entities written by the committed transactions.
+//   - Errors: The rows skipped because they cannot be decoded or are invalid.
+type Report struct {
+	Rows     int
+	Imported int
+	Errors   []*RowError
+}
+
+// Importer imports entities in bulk through a store, see New and NewWithConverter.
+type Importer[T store.Entity[ID], ID comparable] struct {
+	options
+	scope  opscope.Scope
+	store  store.Store[T, ID]
+	decode func(decode func(v any) error) (T, error)
+}
+
+// New creates an Importer decoding the rows directly into entities of type T.
+//
+// Parameters:
+//   - scope: The operation scope of the store, beginning the transactions of the import.
+//   - s: The store creating or upserting the entities.
+//   - opts: The options of the import.
+func New[T store.Entity[ID], ID comparable](
+	scope opscope.Scope,
+	s store.Store[T, ID],
+	opts ...Option,
+) *Importer[T, ID] {
+	return newImporter(scope, s, func(decode func(v any) error) (T, error) {
+		var entity T
+		err := decode(&entity)
+
+		return entity, err
+	}, opts)
+}
+
+// NewWithConverter creates an Importer decoding the rows into values of type Row, converted to entities with
+// ToEntity, e.g. to import a file whose columns differ from the fields of the entities.
+func NewWithConverter[T store.Entity[ID], Row store.Entity[ID], ID comparable](
+	scope opscope.Scope,
+	s store.Store[T, ID],
+	conv converter.Converter[T, Row, ID],
+	opts ...Option,
+) *Importer[T, ID] {
+	return newImporter(scope, s, func(decode func(v any) error) (T, error) {
+		var row Row
+		if err := decode(&row); err != nil {
+			return *new(T), err
+		}
+
+		return conv.ToEntity(row), nil
+	}, opts)
+}
+
+func newImporter[T store.Entity[ID], ID comparable](
+	scope opscope.Scope,
+	s store.Store[T, ID],
+	decode func(decode func(v any) error) (T, error),
+	opts []Option,
+) *Importer[T, ID] {
+	o := options{
+		batchSize:    100,
+		batchesPerTx: 1,
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.batchSize < 1 {
+		o.batchSize = 1
+	}
+
+	if o.batchesPerTx < 1 {
+		o.batchesPerTx = 1
+	}
+
+	return &Importer[T, ID]{
+		options: o,
+		scope:   scope,
+		store:   s,
+		decode:  decode,
+	}
+}
+
+// Import reads the rows of r in the given format and writes their entities through the store.
+//
+// It returns the report of the import, with an error if the stream cannot be read, too many rows are invalid
+// or a write fails. The transaction of the failed write is rolled back, but the entities written by the
+// transactions committed before it are kept and counted in Report.Imported.
+func (im *Importer[T, ID]) Import(ctx context.Context, r io.Reader, format Format) (Report, error) {
+	rows, err := newRowReader(r, format)
+	if err != nil {
+		return Report{}, err
+	}
+
+	imp := &run[T, ID]{Importer: im}
+
+	for {
+		decode, err := rows.next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return imp.fail(fmt.Errorf("importer: read row %d: %w", imp.report.Rows+1, err))
+		}
+
+		imp.report.Rows++
+
+		entity, err := im.decode(decode)
+		if err == nil && im.validator != nil {
+			if verr := im.validator.StructCtx(ctx, entity); verr != nil {
+				err = store.NewValidationError(store.EntityName[T](), verr)
+			}
+		}
+
+		if err != nil {
+			imp.report.Errors = append(imp.report.Errors, &RowError{Row: imp.report.Rows, Err: err})
+
+			if len(imp.report.Errors) > im.maxErrors {
+				return imp.fail(fmt.Errorf("%w: %w", ErrTooManyErrors, imp.report.Errors[len(imp.report.Errors)-1]))
+			}
+
+			continue
+		}
+
+		imp.batch = append(imp.batch, entity)
+
+		if len(imp.batch) >= im.batchSize {
+			if err := imp.flush(ctx); err != nil {
+				return imp.fail(err)
+			}
+		}
+	}
+
+	if err := imp.flush(ctx); err != nil {
+		return imp.fail(err)
+	}
+
+	if err := imp.commit(); err != nil {
+		return imp.report, err
+	}
+
+	return imp.report, nil
+}
+
+// run is the state of an import.
+type run[T store.Entity[ID], ID comparable] struct {
+	*Importer[T, ID]
+
+	report  Report
+	batch   []T
+	txCtx   context.Context
+	batches int
+	pending int
+}
+
+// flush writes the batch within the current transaction, beginning it if needed, and commits the transaction
+// once it wrote its chunk of batches.
+func (r *run[T, ID]) flush(ctx context.Context) error {
+	if len(r.batch) == 0 {
+		return nil
+	}
+
+	if r.txCtx == nil {
+		txCtx, err := r.scope.Begin(ctx)
+		if err != nil {
+			return fmt.Errorf("importer: begin transaction: %w", err)
+		}
+
+		r.txCtx = txCtx
+	}
+
+	if err := r.write(r.txCtx, r.batch); err != nil {
+		return fmt.Errorf("importer: write rows: %w", err)
+	}
+
+	r.pending += len(r.batch)
+	r.batch = r.batch[:0]
+	r.batches++
+
+	if r.batches >= r.batchesPerTx {
+		return r.commit()
+	}
+
+	return nil
+}
+
+// write creates or upserts the entities.
+func (r *run[T, ID]) write(ctx context.Context, entities []T) error {
+	if r.onConflict == nil {
+		return r.store.CreateMany(ctx, entities)
+	}
+
+	for _, entity := range entities {
+		if _, err := r.store.Upsert(ctx, entity, *r.onConflict); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// commit ends the current transaction, if any, and reports the progress.
+func (r *run[T, ID]) commit() error {
+	if r.txCtx == nil {
+		return nil
+	}
+
+	err := r.scope.End(r.txCtx, nil)
+	r.txCtx, r.batches = nil, 0
+
+	if err != nil {
+		r.pending = 0
+		return fmt.Errorf("importer: commit transaction: %w", err)
+	}
+
+	r.report.Imported += r.pending
+	r.pending = 0
+
+	if r.progress != nil {
+		r.progress(r.report)
+	}
+
+	return nil
+}
+
+// fail rolls back the current transaction, if any, and returns the report with err.
+func (r *run[T, ID]) fail(err error) (Report, error) {
+	if r.txCtx != nil {
+		_ = r.scope.End(r.txCtx, err)
+		r.txCtx, r.pending = nil, 0
+	}
+
+	return r.report, err
+}
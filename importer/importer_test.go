@@ -0,0 +1,188 @@
+package importer_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infevocorp/goflexstore/importer"
+	mockopscope "github.com/infevocorp/goflexstore/mocks/opscope"
+	"github.com/infevocorp/goflexstore/store"
+	"github.com/infevocorp/goflexstore/storetest"
+)
+
+type Article struct {
+	ID          int64
+	Title       string
+	Views       int
+	PublishedAt *time.Time
+}
+
+func (a *Article) GetID() int64 {
+	return a.ID
+}
+
+// ArticleRow is an article as exported by a legacy system.
+type ArticleRow struct {
+	ID       int64  `json:"id"`
+	Headline string `json:"headline"`
+}
+
+func (r *ArticleRow) GetID() int64 {
+	return r.ID
+}
+
+type rowConverter struct{}
+
+func (rowConverter) ToEntity(row *ArticleRow) *Article {
+	return &Article{ID: row.ID, Title: row.Headline}
+}
+
+func (rowConverter) ToDTO(article *Article) *ArticleRow {
+	return &ArticleRow{ID: article.ID, Headline: article.Title}
+}
+
+// newScope returns a scope whose transactions are counted by ends.
+func newScope(t *testing.T, ends *[]error) *mockopscope.Scope {
+	scope := mockopscope.NewScope(t)
+
+	scope.EXPECT().Begin(mock.Anything).RunAndReturn(func(ctx context.Context) (context.Context, error) {
+		return ctx, nil
+	}).Maybe()
+	scope.EXPECT().End(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, err error) error {
+		*ends = append(*ends, err)
+		return err
+	}).Maybe()
+
+	return scope
+}
+
+func Test_Importer_Import(t *testing.T) {
+	t.Run("should-import-csv-by-batches-within-chunked-transactions", func(t *testing.T) {
+		// GIVEN
+		var (
+			ends     []error
+			progress []int
+			articles = storetest.NewFake[*Article, int64]()
+			csv      = "id,title,views,published_at\n" +
+				"1,Hello,10,2024-01-02T15:04:05Z\n" +
+				"2,World,,\n" +
+				"3,\"Hello, again\",3,\n"
+		)
+
+		imp := importer.New[*Article, int64](newScope(t, &ends), articles,
+			importer.WithBatchSize(1),
+			importer.WithBatchesPerTransaction(2),
+			importer.WithProgress(func(r importer.Report) { progress = append(progress, r.Imported) }),
+		)
+
+		// WHEN
+		report, err := imp.Import(context.Background(), strings.NewReader(csv), importer.CSV)
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, importer.Report{Rows: 3, Imported: 3}, report)
+		assert.Equal(t, []int{2, 3}, progress)
+		assert.Equal(t, []error{nil, nil}, ends)
+		assert.Len(t, articles.Calls(store.MethodCreateMany), 3)
+
+		stored := articles.Entities()
+		require.Len(t, stored, 3)
+		assert.Equal(t, "Hello", stored[0].Title)
+		assert.Equal(t, 10, stored[0].Views)
+		assert.Equal(t, time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC), *stored[0].PublishedAt)
+		assert.Nil(t, stored[1].PublishedAt)
+		assert.Equal(t, "Hello, again", stored[2].Title)
+	})
+
+	t.Run("should-convert-ndjson-rows-and-skip-invalid-ones", func(t *testing.T) {
+		// GIVEN
+		var (
+			ends     []error
+			articles = storetest.NewFake[*Article, int64]()
+			ndjson   = `{"id": 1, "headline": "Hello"}` + "\n" +
+				`{"id": "two", "headline": "World"}` + "\n" +
+				`{"id": 3, "headline": ""}` + "\n"
+		)
+
+		imp := importer.NewWithConverter[*Article, *ArticleRow, int64](newScope(t, &ends), articles, rowConverter{},
+			importer.WithMaxErrors(2),
+			importer.WithValidator(validatorFunc(func(_ context.Context, entity any) error {
+				if entity.(*Article).Title == "" {
+					return store.FieldError{Field: "Title", Rule: "required", Message: "is required"}
+				}
+
+				return nil
+			})),
+		)
+
+		// WHEN
+		report, err := imp.Import(context.Background(), strings.NewReader(ndjson), importer.NDJSON)
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, 3, report.Rows)
+		assert.Equal(t, 1, report.Imported)
+		require.Len(t, report.Errors, 2)
+		assert.Equal(t, 2, report.Errors[0].Row)
+		assert.Equal(t, 3, report.Errors[1].Row)
+
+		var validationErr *store.ValidationError
+		assert.ErrorAs(t, report.Errors[1], &validationErr)
+		assert.EqualError(t, report.Errors[1], "row 3: invalid Article: Title: is required")
+	})
+
+	t.Run("should-roll-back-failed-transaction", func(t *testing.T) {
+		// GIVEN
+		var (
+			ends     []error
+			articles = storetest.NewFake[*Article, int64]()
+			dbErr    = errors.New("duplicate key")
+			csv      = "id,title\n1,Hello\n2,World\n"
+		)
+
+		articles.FailWith(store.MethodCreateMany, dbErr)
+
+		imp := importer.New[*Article, int64](newScope(t, &ends), articles)
+
+		// WHEN
+		report, err := imp.Import(context.Background(), strings.NewReader(csv), importer.CSV)
+
+		// THEN
+		assert.ErrorIs(t, err, dbErr)
+		assert.Equal(t, 0, report.Imported)
+		require.Len(t, ends, 1)
+		assert.ErrorIs(t, ends[0], dbErr)
+	})
+
+	t.Run("should-fail-at-first-invalid-row-by-default", func(t *testing.T) {
+		// GIVEN
+		var (
+			ends     []error
+			articles = storetest.NewFake[*Article, int64]()
+			csv      = "id,title,views\n1,Hello,many\n"
+		)
+
+		imp := importer.New[*Article, int64](newScope(t, &ends), articles)
+
+		// WHEN
+		_, err := imp.Import(context.Background(), strings.NewReader(csv), importer.CSV)
+
+		// THEN
+		assert.ErrorIs(t, err, importer.ErrTooManyErrors)
+		assert.Empty(t, articles.Entities())
+		assert.Empty(t, ends)
+	})
+}
+
+type validatorFunc func(ctx context.Context, entity any) error
+
+func (f validatorFunc) StructCtx(ctx context.Context, entity any) error {
+	return f(ctx, entity)
+}
@@ -0,0 +1,71 @@
+package importer
+
+import (
+	"context"
+
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// Option is a function that configures the Importer.
+type Option func(*options)
+
+// Validator validates the imported entities, see WithValidator.
+// The *validator.Validate of github.com/go-playground/validator satisfies this interface.
+type Validator interface {
+	// StructCtx returns an error if the entity is invalid.
+	StructCtx(ctx context.Context, entity any) error
+}
+
+type options struct {
+	batchSize    int
+	batchesPerTx int
+	maxErrors    int
+	validator    Validator
+	onConflict   *store.OnConflict
+	progress     func(Report)
+}
+
+// WithBatchSize sets the number of entities written at once. It defaults to 100.
+func WithBatchSize(size int) Option {
+	return func(o *options) {
+		o.batchSize = size
+	}
+}
+
+// WithBatchesPerTransaction sets the number of batches written within each transaction. It defaults to 1.
+func WithBatchesPerTransaction(n int) Option {
+	return func(o *options) {
+		o.batchesPerTx = n
+	}
+}
+
+// WithMaxErrors sets the number of rows which cannot be decoded or are invalid skipped before the import fails.
+// It defaults to 0: the import fails at the first invalid row.
+func WithMaxErrors(n int) Option {
+	return func(o *options) {
+		o.maxErrors = n
+	}
+}
+
+// WithValidator sets the validator of the entities. Invalid entities are reported as RowError wrapping a
+// store.ValidationError.
+func WithValidator(validator Validator) Option {
+	return func(o *options) {
+		o.validator = validator
+	}
+}
+
+// WithOnConflict upserts the entities with the conflict resolution strategy instead of creating them with
+// CreateMany. The entities of a batch are upserted one by one, within the transaction of the batch.
+func WithOnConflict(onConflict store.OnConflict) Option {
+	return func(o *options) {
+		o.onConflict = &onConflict
+	}
+}
+
+// WithProgress sets a function called with the report of the import after each committed transaction.
+func WithProgress(fn func(Report)) Option {
+	return func(o *options) {
+		o.progress = fn
+	}
+}
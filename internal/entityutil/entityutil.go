@@ -0,0 +1,170 @@
+// Package entityutil provides the reflection helpers shared by the stores evaluating the query params in memory:
+// the lookup of the fields of the entities, their ordering and their pagination.
+package entityutil
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/infevocorp/goflexstore/query"
+)
+
+// Sort sorts the entities by the fields of the OrderBy params, nil values first. The sort is stable.
+func Sort[T any](entities []T, orderBys []query.Param) error {
+	if len(orderBys) == 0 || len(entities) < 2 {
+		return nil
+	}
+
+	var sortErr error
+
+	sort.SliceStable(entities, func(i, j int) bool {
+		a, b := Indirect(reflect.ValueOf(entities[i])), Indirect(reflect.ValueOf(entities[j]))
+
+		for _, p := range orderBys {
+			orderBy := p.(query.OrderByParam)
+
+			cmp, err := Compare(FieldByName(a, orderBy.Name), FieldByName(b, orderBy.Name))
+			if err != nil {
+				sortErr = fmt.Errorf("order by %s: %w", orderBy.Name, err)
+				return false
+			}
+
+			if cmp != 0 {
+				return (cmp < 0) != orderBy.Desc
+			}
+		}
+
+		return false
+	})
+
+	return sortErr
+}
+
+// Paginate returns the entities of the page of the pagination, none when the offset is past the entities.
+func Paginate[T any](entities []T, p query.PaginateParam) []T {
+	if p.Offset >= len(entities) {
+		if p.Offset > 0 {
+			return nil
+		}
+
+		return entities
+	}
+
+	entities = entities[p.Offset:]
+
+	if p.Limit > 0 && p.Limit < len(entities) {
+		entities = entities[:p.Limit]
+	}
+
+	return entities
+}
+
+// Compare compares two values of an ordered field of the same type, nil values first.
+func Compare(a, b reflect.Value) (int, error) {
+	if !a.IsValid() || !b.IsValid() {
+		return 0, fmt.Errorf("no such field")
+	}
+
+	aNil, bNil := a.Kind() == reflect.Pointer && a.IsNil(), b.Kind() == reflect.Pointer && b.IsNil()
+	if aNil || bNil {
+		return boolToInt(bNil) - boolToInt(aNil), nil
+	}
+
+	a, b = Indirect(a), Indirect(b)
+
+	if at, ok := a.Interface().(time.Time); ok {
+		return at.Compare(b.Interface().(time.Time)), nil
+	}
+
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return ordered(a.Int(), b.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return ordered(a.Uint(), b.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return ordered(a.Float(), b.Float()), nil
+	case reflect.String:
+		return strings.Compare(a.String(), b.String()), nil
+	case reflect.Bool:
+		return boolToInt(a.Bool()) - boolToInt(b.Bool()), nil
+	default:
+		return 0, fmt.Errorf("cannot order values of type %s", a.Type())
+	}
+}
+
+// FieldByName returns the field of the struct matching the name, ignoring case and underscores, e.g. the AuthorID
+// field for author_id. It returns the zero Value when v is not a struct or no field matches.
+func FieldByName(v reflect.Value, name string) reflect.Value {
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}
+	}
+
+	key := NormalizeName(name)
+
+	return v.FieldByNameFunc(func(fieldName string) bool {
+		return NormalizeName(fieldName) == key
+	})
+}
+
+// ConflictFilters returns the filters on the fields of the entity matching the conflict columns of an upsert,
+// ignoring case and underscores, i.e. the filters selecting the stored entity the upsert conflicts with.
+// It returns false when there are no columns or one of them matches no exported field.
+func ConflictFilters(entity any, columns []string) ([]query.Param, bool) {
+	v := Indirect(reflect.ValueOf(entity))
+	if len(columns) == 0 || v.Kind() != reflect.Struct {
+		return nil, false
+	}
+
+	filters := make([]query.Param, 0, len(columns))
+
+	for _, column := range columns {
+		key := NormalizeName(column)
+
+		field, ok := v.Type().FieldByNameFunc(func(name string) bool {
+			return NormalizeName(name) == key
+		})
+		if !ok || !field.IsExported() {
+			return nil, false
+		}
+
+		filters = append(filters, query.Filter(field.Name, v.FieldByIndex(field.Index).Interface()))
+	}
+
+	return filters, true
+}
+
+// NormalizeName returns the name of a field without case nor underscores.
+func NormalizeName(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+}
+
+// Indirect dereferences non-nil pointers.
+func Indirect(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+
+	return v
+}
+
+func ordered[V int64 | uint64 | float64](a, b V) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,114 @@
+package entityutil_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infevocorp/goflexstore/internal/entityutil"
+	"github.com/infevocorp/goflexstore/query"
+)
+
+type Article struct {
+	ID          int64
+	AuthorID    int64
+	Rating      *float64
+	PublishedAt time.Time
+	Tags        []string
+}
+
+func rating(r float64) *float64 {
+	return &r
+}
+
+func Test_Sort(t *testing.T) {
+	t.Run("should-sort-by-fields-nil-first", func(t *testing.T) {
+		// GIVEN
+		articles := []*Article{
+			{ID: 1, AuthorID: 1, Rating: rating(4)},
+			{ID: 2, AuthorID: 2, Rating: rating(3)},
+			{ID: 3, AuthorID: 1},
+			{ID: 4, AuthorID: 1, Rating: rating(5)},
+		}
+
+		// WHEN
+		err := entityutil.Sort(articles, []query.Param{query.OrderBy("author_id", false), query.OrderBy("Rating", true)})
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, []int64{4, 1, 3, 2}, ids(articles))
+	})
+
+	t.Run("should-sort-by-time", func(t *testing.T) {
+		// GIVEN
+		now := time.Now()
+		articles := []Article{{ID: 1, PublishedAt: now}, {ID: 2, PublishedAt: now.Add(-time.Hour)}}
+
+		// WHEN
+		err := entityutil.Sort(articles, []query.Param{query.OrderBy("PublishedAt", false)})
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), articles[0].ID)
+	})
+
+	t.Run("should-fail-on-unordered-or-unknown-field", func(t *testing.T) {
+		// GIVEN
+		articles := []*Article{{ID: 1}, {ID: 2}}
+
+		// WHEN
+		errUnordered := entityutil.Sort(articles, []query.Param{query.OrderBy("Tags", false)})
+		errUnknown := entityutil.Sort(articles, []query.Param{query.OrderBy("Title", false)})
+
+		// THEN
+		assert.EqualError(t, errUnordered, "order by Tags: cannot order values of type []string")
+		assert.EqualError(t, errUnknown, "order by Title: no such field")
+	})
+}
+
+func Test_Paginate(t *testing.T) {
+	entities := []int{1, 2, 3, 4, 5}
+
+	tests := []struct {
+		name     string
+		paginate query.PaginateParam
+		expected []int
+	}{
+		{name: "should-return-all-without-pagination", expected: entities},
+		{name: "should-apply-offset-and-limit", paginate: query.PaginateParam{Offset: 1, Limit: 2}, expected: []int{2, 3}},
+		{name: "should-apply-limit-past-entities", paginate: query.PaginateParam{Offset: 3, Limit: 10}, expected: []int{4, 5}},
+		{name: "should-return-none-past-entities", paginate: query.PaginateParam{Offset: 5, Limit: 2}, expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, entityutil.Paginate(entities, tt.paginate))
+		})
+	}
+}
+
+func Test_FieldByName(t *testing.T) {
+	// GIVEN
+	v := entityutil.Indirect(reflect.ValueOf(&Article{AuthorID: 2}))
+
+	// WHEN
+	field := entityutil.FieldByName(v, "author_id")
+	missing := entityutil.FieldByName(v, "Title")
+
+	// THEN
+	require.True(t, field.IsValid())
+	assert.Equal(t, int64(2), field.Int())
+	assert.False(t, missing.IsValid())
+}
+
+func ids(articles []*Article) []int64 {
+	result := make([]int64, len(articles))
+	for i, a := range articles {
+		result[i] = a.ID
+	}
+
+	return result
+}
@@ -0,0 +1,56 @@
+package memo
+
+import (
+	"context"
+	"sync"
+)
+
+type contextKey struct{}
+
+// memo holds the memoized results of a request by entity and key.
+type memo struct {
+	mu      sync.Mutex
+	results map[string]map[string]any
+}
+
+// NewContext returns a copy of ctx carrying an empty memo, replacing the memo of ctx if any.
+func NewContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, contextKey{}, &memo{results: map[string]map[string]any{}})
+}
+
+// fromContext returns the memo carried by ctx, if any.
+func fromContext(ctx context.Context) (*memo, bool) {
+	m, ok := ctx.Value(contextKey{}).(*memo)
+
+	return m, ok
+}
+
+// get returns the memoized result of the key of the entity.
+func (m *memo) get(entity, key string) (any, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	result, ok := m.results[entity][key]
+
+	return result, ok
+}
+
+// set memoizes the result of the key of the entity.
+func (m *memo) set(entity, key string, result any) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.results[entity] == nil {
+		m.results[entity] = map[string]any{}
+	}
+
+	m.results[entity][key] = result
+}
+
+// forget deletes the memoized results of the entity.
+func (m *memo) forget(entity string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.results, entity)
+}
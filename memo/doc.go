@@ -0,0 +1,22 @@
+// Package memo memoizes the reads of stores within a request, so that the services called by the same request
+// do not read the same entities again.
+//
+// NewContext attaches a memo to the context of a request, typically in an HTTP or gRPC middleware, and the
+// Middleware decorating stores composed with store.Chain returns the results of the Get, List, Count, Exists and
+// Aggregate operations already done with the same context, entity, aggregates and query params. The memo lives as
+// long as the request: there is no expiration, and the writes through the Middleware simply forget the memoized
+// reads of their entity.
+// Operations with a context without memo, or running in a transaction, are not memoized.
+//
+// The memoized results are shared as is: the callers must not modify the entities they get, or must copy them first.
+//
+// Example:
+//
+//	articleStore := store.Chain[*model.Article, int64](inner, memo.Middleware())
+//
+//	func Memoize(next http.Handler) http.Handler {
+//		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+//			next.ServeHTTP(w, r.WithContext(memo.NewContext(r.Context())))
+//		})
+//	}
+package memo
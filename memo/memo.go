@@ -0,0 +1,57 @@
+package memo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/infevocorp/goflexstore/opscope"
+	"github.com/infevocorp/goflexstore/query"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// Middleware returns a store.Middleware memoizing the successful reads in the memo of the context, see NewContext.
+// The other operations, except Ping, forget the memoized reads of their entity once they are done, whether they
+// succeeded or not.
+//
+// Example:
+//
+//	articleStore := store.Chain[*model.Article, int64](inner, memo.Middleware())
+func Middleware() store.Middleware {
+	return func(next store.OperationFunc) store.OperationFunc {
+		return func(ctx context.Context, op *store.Operation) (any, error) {
+			m, ok := fromContext(ctx)
+			if !ok || op.Method == store.MethodPing {
+				return next(ctx, op)
+			}
+
+			switch op.Method {
+			case store.MethodGet, store.MethodList, store.MethodListWithCount, store.MethodCount, store.MethodExists,
+				store.MethodAggregate, store.MethodAggregateMany:
+			default:
+				defer m.forget(op.Entity)
+
+				return next(ctx, op)
+			}
+
+			if opscope.InTransaction(ctx) {
+				return next(ctx, op)
+			}
+
+			key := op.Method + ":" + query.NewParams(op.Params...).Hash()
+			if op.Input != nil {
+				key += fmt.Sprintf(":%v", op.Input)
+			}
+
+			if result, ok := m.get(op.Entity, key); ok {
+				return result, nil
+			}
+
+			result, err := next(ctx, op)
+			if err == nil {
+				m.set(op.Entity, key, result)
+			}
+
+			return result, err
+		}
+	}
+}
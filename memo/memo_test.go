@@ -0,0 +1,101 @@
+package memo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infevocorp/goflexstore/memo"
+	mockstore "github.com/infevocorp/goflexstore/mocks/store"
+	"github.com/infevocorp/goflexstore/query"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+type Article struct {
+	ID int
+}
+
+func (a *Article) GetID() int {
+	return a.ID
+}
+
+func Test_Middleware(t *testing.T) {
+	t.Run("should-memoize-reads-of-request", func(t *testing.T) {
+		// GIVEN
+		var (
+			ctx   = memo.NewContext(context.Background())
+			inner = mockstore.NewStore[*Article, int](t)
+		)
+
+		inner.EXPECT().Get(ctx, query.Filter("ID", 1)).Return(&Article{ID: 1}, nil).Once()
+		inner.EXPECT().Get(ctx, query.Filter("ID", 2)).Return(&Article{ID: 2}, nil).Once()
+
+		s := store.Chain[*Article, int](inner, memo.Middleware())
+
+		_, err := s.Get(ctx, query.Filter("ID", 1))
+		require.NoError(t, err)
+
+		// WHEN
+		article1, err1 := s.Get(ctx, query.Filter("ID", 1))
+		article2, err2 := s.Get(ctx, query.Filter("ID", 2))
+
+		// THEN
+		assert.NoError(t, err1)
+		assert.NoError(t, err2)
+		assert.Equal(t, 1, article1.ID)
+		assert.Equal(t, 2, article2.ID)
+	})
+
+	t.Run("should-forget-reads-after-write", func(t *testing.T) {
+		// GIVEN
+		var (
+			ctx     = memo.NewContext(context.Background())
+			inner   = mockstore.NewStore[*Article, int](t)
+			article = &Article{ID: 1}
+		)
+
+		inner.EXPECT().Count(ctx).Return(0, nil).Once()
+		inner.EXPECT().Create(ctx, article).Return(1, nil).Once()
+		inner.EXPECT().Count(ctx).Return(1, nil).Once()
+
+		s := store.Chain[*Article, int](inner, memo.Middleware())
+
+		_, err := s.Count(ctx)
+		require.NoError(t, err)
+		_, err = s.Create(ctx, article)
+		require.NoError(t, err)
+
+		// WHEN
+		count, err := s.Count(ctx)
+
+		// THEN
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("should-not-memoize-without-memo-or-on-error", func(t *testing.T) {
+		// GIVEN
+		var (
+			ctx     = context.Background()
+			memoCtx = memo.NewContext(ctx)
+			inner   = mockstore.NewStore[*Article, int](t)
+		)
+
+		inner.EXPECT().List(ctx).Return(nil, nil).Twice()
+		inner.EXPECT().Exists(memoCtx).Return(false, store.ErrNotFound).Twice()
+
+		s := store.Chain[*Article, int](inner, memo.Middleware())
+
+		for i := 0; i < 2; i++ {
+			// WHEN
+			_, listErr := s.List(ctx)
+			_, existsErr := s.Exists(memoCtx)
+
+			// THEN
+			assert.NoError(t, listErr)
+			assert.ErrorIs(t, existsErr, store.ErrNotFound)
+		}
+	})
+}
@@ -0,0 +1,27 @@
+// Package memstore provides a store.Store keeping its entities in memory, for fast unit tests and prototyping
+// without a database.
+//
+// The query params are interpreted against the fields of the entities by reflection:
+//   - Filters, including OR and IN filters through slice values, and AND, OR and Not groups.
+//   - Full-text params, matching the entities whose searched fields contain every word of the text, ignoring
+//     relevance.
+//   - Group by params, keeping the first entity of each group of entities having the same values of the grouped
+//     fields. Having conditions cannot be evaluated and make the calls fail.
+//   - Ordering, nil values first, and pagination.
+//   - Select params, returning the entities with only the selected fields set.
+//
+// Raw params cannot be evaluated and make the calls fail. Other params (preload, locks...) are ignored.
+// Field names are resolved to entity fields case insensitively, ignoring underscores, so both "FirstName"
+// and "first_name" match the FirstName field.
+//
+// Example:
+//
+//	articles := memstore.New[*model.Article, int64](
+//		&model.Article{ID: 1, Status: "draft"},
+//		&model.Article{ID: 2, Status: "active"},
+//	)
+//
+//	active, err := articles.List(ctx, query.Filter("status", "active"), query.OrderBy("id", true))
+//
+// storetest.Fake builds on the store to also record the calls and inject failures in unit tests.
+package memstore
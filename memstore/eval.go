@@ -0,0 +1,624 @@
+package memstore
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/infevocorp/goflexstore/internal/entityutil"
+	"github.com/infevocorp/goflexstore/query"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// matchAll reports whether the entity satisfies every filter, OR, group, not and full-text param of params.
+// It returns an error for raw params, whose SQL cannot be evaluated.
+func matchAll(entity any, params query.Params) (bool, error) {
+	for i := 0; i < params.Len(); i++ {
+		var (
+			ok  = true
+			err error
+		)
+
+		switch p := params.At(i).(type) {
+		case query.FilterParam:
+			ok, err = matchFilter(entity, p)
+		case query.ORParam:
+			ok, err = matchAny(entity, p.Params)
+		case query.GroupParam, query.NotParam, query.RawParam:
+			ok, err = matchCondition(entity, p)
+		case query.FullTextParam:
+			ok, err = matchFullText(entity, p)
+		}
+
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+func matchAny(entity any, filters []query.FilterParam) (bool, error) {
+	for _, filter := range filters {
+		ok, err := matchFilter(entity, filter)
+		if err != nil || ok {
+			return ok, err
+		}
+	}
+
+	return false, nil
+}
+
+// matchCondition evaluates a condition of a group: a filter, an OR, a group or a not param.
+// Raw params cannot be evaluated and return an error.
+func matchCondition(entity any, param query.Param) (bool, error) {
+	switch c := param.(type) {
+	case query.FilterParam:
+		return matchFilter(entity, c)
+	case query.ORParam:
+		return matchAny(entity, c.Params)
+	case query.GroupParam:
+		return matchGroup(entity, c)
+	case query.NotParam:
+		ok, err := matchCondition(entity, c.Param)
+
+		return !ok, err
+	case query.RawParam:
+		return false, fmt.Errorf("memstore: raw params cannot be evaluated: %s", c.SQL)
+	default:
+		return false, fmt.Errorf("memstore: %s param cannot be a condition of a group", param.ParamType())
+	}
+}
+
+// matchGroup evaluates the conditions of a group, nested groups included. An empty group matches.
+func matchGroup(entity any, group query.GroupParam) (bool, error) {
+	for _, param := range group.Params {
+		ok, err := matchCondition(entity, param)
+		if err != nil {
+			return false, err
+		}
+
+		if ok == group.OR {
+			return ok, nil
+		}
+	}
+
+	return !group.OR || len(group.Params) == 0, nil
+}
+
+// matchFullText approximates a full-text search: the entity matches if every word of the text is contained,
+// case insensitively, in one of the searched fields.
+func matchFullText(entity any, p query.FullTextParam) (bool, error) {
+	var text strings.Builder
+
+	for _, name := range p.Names {
+		field, err := fieldValue(entity, name)
+		if err != nil {
+			return false, err
+		}
+
+		if field.Kind() == reflect.String {
+			text.WriteString(strings.ToLower(field.String()))
+			text.WriteByte(' ')
+		}
+	}
+
+	for _, word := range strings.Fields(strings.ToLower(p.Text)) {
+		if !strings.Contains(text.String(), word) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// matchUpdateWhere reports whether the stored entity satisfies the OnConflict.UpdateWhere filters,
+// store.ExcludedColumn values being resolved from the upserted entity.
+func matchUpdateWhere(stored, upserted any, filters []query.FilterParam) (bool, error) {
+	for _, filter := range filters {
+		if excluded, ok := filter.Value.(store.ExcludedColumn); ok {
+			value, err := fieldValue(upserted, string(excluded))
+			if err != nil {
+				return false, err
+			}
+
+			filter.Value = value.Interface()
+		}
+
+		ok, err := matchFilter(stored, filter)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// matchFilter evaluates a filter against the entity field it names.
+// Slice values are evaluated as IN (EQ, IN) and NOT IN (NEQ, NOTIN) lists, and nil values as IS (NOT) NULL.
+func matchFilter(entity any, filter query.FilterParam) (bool, error) {
+	field, err := fieldValue(entity, filter.Name)
+	if err != nil {
+		return false, err
+	}
+
+	if filter.Value == nil {
+		return matchOperator(filter, isNil(field))
+	}
+
+	value := reflect.ValueOf(filter.Value)
+
+	if (filter.Operator == query.IN || filter.Operator == query.NOTIN) && !isList(value) {
+		return false, fmt.Errorf("memstore: value of %s must be a collection with %s", filter.Name, filter.Operator)
+	}
+
+	if isList(value) {
+		found := false
+
+		for i := 0; i < value.Len() && !found; i++ {
+			found = equal(field, value.Index(i))
+		}
+
+		return matchOperator(filter, found)
+	}
+
+	if filter.Operator == query.EQ || filter.Operator == query.NEQ {
+		return matchOperator(filter, equal(field, value))
+	}
+
+	if filter.Operator == query.LIKE || filter.Operator == query.ILIKE {
+		return matchLike(field, filter)
+	}
+
+	cmp, ok := compare(field, value)
+	if !ok {
+		return false, fmt.Errorf("memstore: cannot compare %s with %T using %s", filter.Name, filter.Value, filter.Operator)
+	}
+
+	switch filter.Operator {
+	case query.GT:
+		return cmp > 0, nil
+	case query.GTE:
+		return cmp >= 0, nil
+	case query.LT:
+		return cmp < 0, nil
+	case query.LTE:
+		return cmp <= 0, nil
+	default:
+		return false, fmt.Errorf("memstore: unsupported operator %s", filter.Operator)
+	}
+}
+
+// matchLike evaluates a LIKE or ILIKE filter, where % matches any sequence of characters and _ any single one.
+func matchLike(field reflect.Value, filter query.FilterParam) (bool, error) {
+	pattern, ok := filter.Value.(string)
+	if !ok || field.Kind() != reflect.String {
+		return false, fmt.Errorf("memstore: cannot match %s with %T using %s", filter.Name, filter.Value, filter.Operator)
+	}
+
+	var expr strings.Builder
+
+	expr.WriteString("(?s)^")
+
+	if filter.Operator == query.ILIKE {
+		expr.WriteString("(?i)")
+	}
+
+	for _, r := range pattern {
+		switch r {
+		case '%':
+			expr.WriteString(".*")
+		case '_':
+			expr.WriteString(".")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+
+	expr.WriteString("$")
+
+	return regexp.MustCompile(expr.String()).MatchString(field.String()), nil
+}
+
+// matchOperator applies an EQ, NEQ, IN or NOTIN operator to the result of an equality check.
+func matchOperator(filter query.FilterParam, equal bool) (bool, error) {
+	switch filter.Operator {
+	case query.EQ, query.IN:
+		return equal, nil
+	case query.NEQ, query.NOTIN:
+		return !equal, nil
+	default:
+		return false, fmt.Errorf("memstore: operator %s is not supported with value %v of %s",
+			filter.Operator, filter.Value, filter.Name)
+	}
+}
+
+// fieldValue returns the entity field matching name, ignoring case and underscores.
+// Pointer fields are dereferenced unless nil.
+func fieldValue(entity any, name string) (reflect.Value, error) {
+	v := entityutil.Indirect(reflect.ValueOf(entity))
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("memstore: entity %T is not a struct", entity)
+	}
+
+	field := entityutil.FieldByName(v, name)
+	if !field.IsValid() {
+		return reflect.Value{}, fmt.Errorf("memstore: entity %T has no field matching %s", entity, name)
+	}
+
+	return entityutil.Indirect(field), nil
+}
+
+func isNil(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
+
+func isList(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice:
+		return v.Type().Elem().Kind() != reflect.Uint8
+	case reflect.Array:
+		return true
+	default:
+		return false
+	}
+}
+
+// Equal reports whether two values are equal the way the filters of the store compare them: numbers of different
+// types by value, pointers by the values they point to, and other values deeply.
+func Equal(a, b any) bool {
+	return equal(reflect.ValueOf(a), reflect.ValueOf(b))
+}
+
+func equal(a, b reflect.Value) bool {
+	a, b = entityutil.Indirect(a), entityutil.Indirect(b)
+
+	if cmp, ok := compare(a, b); ok {
+		return cmp == 0
+	}
+
+	if !a.IsValid() || !b.IsValid() || !a.CanInterface() || !b.CanInterface() {
+		return false
+	}
+
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
+
+// compare orders two numbers, strings, booleans or times. It returns false when the values are not comparable.
+func compare(a, b reflect.Value) (int, bool) {
+	a, b = entityutil.Indirect(a), entityutil.Indirect(b)
+
+	if !a.IsValid() || !b.IsValid() {
+		return 0, false
+	}
+
+	if a.Type() == timeType && b.Type() == timeType {
+		return a.Interface().(time.Time).Compare(b.Interface().(time.Time)), true
+	}
+
+	switch {
+	case isInt(a) && isInt(b):
+		return order(a.Int(), b.Int()), true
+	case isUint(a) && isUint(b):
+		return order(a.Uint(), b.Uint()), true
+	case isNumber(a) && isNumber(b):
+		return order(toFloat(a), toFloat(b)), true
+	case a.Kind() == reflect.String && b.Kind() == reflect.String:
+		return strings.Compare(a.String(), b.String()), true
+	case a.Kind() == reflect.Bool && b.Kind() == reflect.Bool:
+		return order(toFloat(a), toFloat(b)), true
+	default:
+		return 0, false
+	}
+}
+
+func order[N int64 | uint64 | float64](a, b N) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func isInt(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	default:
+		return false
+	}
+}
+
+func isUint(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	default:
+		return false
+	}
+}
+
+func isNumber(v reflect.Value) bool {
+	return isInt(v) || isUint(v) || v.Kind() == reflect.Float32 || v.Kind() == reflect.Float64
+}
+
+func toFloat(v reflect.Value) float64 {
+	switch {
+	case isInt(v):
+		return float64(v.Int())
+	case isUint(v):
+		return float64(v.Uint())
+	case v.Kind() == reflect.Bool:
+		if v.Bool() {
+			return 1
+		}
+
+		return 0
+	default:
+		return v.Float()
+	}
+}
+
+// numbers returns the values of the field of the entities, skipping the nil pointers like SQL skips NULL, or a
+// zero per entity when the field is empty. The values counted by CountOf may be of any type.
+func numbers[T any](entities []T, agg store.Aggregate) ([]float64, error) {
+	field := agg.Field
+
+	values := make([]float64, 0, len(entities))
+
+	for _, entity := range entities {
+		if field == "" {
+			values = append(values, 0)
+			continue
+		}
+
+		v, err := fieldValue(entity, field)
+		if err != nil {
+			return nil, err
+		}
+
+		if isNil(v) {
+			continue
+		}
+
+		if agg.Aggregation == store.CountOf {
+			values = append(values, 0)
+			continue
+		}
+
+		if !isNumber(v) {
+			return nil, fmt.Errorf("memstore: field %s of %T is not a number", field, entity)
+		}
+
+		values = append(values, toFloat(v))
+	}
+
+	return values, nil
+}
+
+// aggregateValues computes the aggregation of the values, 0 when there is none.
+func aggregateValues(agg store.Aggregation, values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	if agg == store.CountOf {
+		return float64(len(values))
+	}
+
+	result := values[0]
+
+	for _, v := range values[1:] {
+		switch agg {
+		case store.Min:
+			result = math.Min(result, v)
+		case store.Max:
+			result = math.Max(result, v)
+		default:
+			result += v
+		}
+	}
+
+	if agg == store.Avg {
+		result /= float64(len(values))
+	}
+
+	return result
+}
+
+// clone returns a shallow copy of the struct pointed to by pointer entities, and the entity itself otherwise.
+func clone[T any](entity T) T {
+	v := reflect.ValueOf(entity)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return entity
+	}
+
+	c := reflect.New(v.Elem().Type())
+	c.Elem().Set(v.Elem())
+
+	return c.Interface().(T)
+}
+
+func cloneAll[T any](entities []T) []T {
+	if len(entities) == 0 {
+		return nil
+	}
+
+	cloned := make([]T, len(entities))
+	for i, entity := range entities {
+		cloned[i] = clone(entity)
+	}
+
+	return cloned
+}
+
+// addressable returns an addressable struct value of the entity: the pointed struct of pointer entities,
+// or a copy of struct entities. The second value returns the entity holding the changes.
+func addressable[T any](entity T) (reflect.Value, func() T) {
+	v := reflect.ValueOf(entity)
+	if v.Kind() == reflect.Pointer {
+		return v.Elem(), func() T { return entity }
+	}
+
+	c := reflect.New(v.Type()).Elem()
+	c.Set(v)
+
+	return c, func() T { return c.Interface().(T) }
+}
+
+// withID sets the ID field of the entity, in place for pointer entities.
+func withID[T any, ID any](entity T, id ID) T {
+	v, result := addressable(entity)
+	if v.Kind() != reflect.Struct {
+		return entity
+	}
+
+	field := v.FieldByName("ID")
+	idValue := reflect.ValueOf(id)
+
+	if !field.IsValid() || !field.CanSet() || !idValue.Type().AssignableTo(field.Type()) {
+		return entity
+	}
+
+	field.Set(idValue)
+
+	return result()
+}
+
+// withUpdates returns a copy of entity with the fields of updates, matched by name ignoring case and underscores.
+func withUpdates[T any](entity T, updates map[string]any) (T, error) {
+	v, result := addressable(clone(entity))
+	if v.Kind() != reflect.Struct {
+		return entity, fmt.Errorf("memstore: entity %T is not a struct", entity)
+	}
+
+	for name, value := range updates {
+		field := entityutil.FieldByName(v, name)
+		if !field.IsValid() || !field.CanSet() {
+			return entity, fmt.Errorf("memstore: entity %T has no field matching %s", entity, name)
+		}
+
+		if value == nil {
+			field.Set(reflect.Zero(field.Type()))
+			continue
+		}
+
+		// numbers are not converted to strings, which Go would make runes of.
+		val := reflect.ValueOf(value)
+		if !val.Type().ConvertibleTo(field.Type()) ||
+			field.Kind() == reflect.String && val.Kind() != reflect.String {
+			return entity, fmt.Errorf("memstore: cannot set %s of %T to %T", name, entity, value)
+		}
+
+		field.Set(val.Convert(field.Type()))
+	}
+
+	return result(), nil
+}
+
+// mergeNonZero returns a copy of dst with the non-zero fields of src.
+func mergeNonZero[T any](dst, src T) T {
+	v, result := addressable(clone(dst))
+	s := entityutil.Indirect(reflect.ValueOf(src))
+
+	if v.Kind() != reflect.Struct || s.Type() != v.Type() {
+		return dst
+	}
+
+	for i := 0; i < s.NumField(); i++ {
+		if field := v.Field(i); field.CanSet() && !s.Field(i).IsZero() {
+			field.Set(s.Field(i))
+		}
+	}
+
+	return result()
+}
+
+// groupEntities keeps the first entity of each group of entities having the same values of the fields of the
+// group by params, in order.
+func groupEntities[T any](entities []T, groupBys []query.Param) ([]T, error) {
+	for _, p := range groupBys {
+		groupBy := p.(query.GroupByParam)
+
+		if len(groupBy.Having) > 0 {
+			return nil, fmt.Errorf("memstore: having conditions cannot be evaluated")
+		}
+
+		var (
+			seen    = make(map[string]bool, len(entities))
+			grouped = make([]T, 0, len(entities))
+		)
+
+		for _, entity := range entities {
+			var key strings.Builder
+
+			for _, name := range groupBy.Names {
+				field, err := fieldValue(entity, name)
+				if err != nil {
+					return nil, err
+				}
+
+				fmt.Fprintf(&key, "%#v|", field.Interface())
+			}
+
+			if !seen[key.String()] {
+				seen[key.String()] = true
+				grouped = append(grouped, entity)
+			}
+		}
+
+		entities = grouped
+	}
+
+	return entities, nil
+}
+
+// selectFields returns a copy of the entity with only the fields of the select params, the others being zero.
+func selectFields[T any](entity T, selects []query.Param) (T, error) {
+	if len(selects) == 0 {
+		return clone(entity), nil
+	}
+
+	src := entityutil.Indirect(reflect.ValueOf(entity))
+	if src.Kind() != reflect.Struct {
+		return entity, fmt.Errorf("memstore: entity %T is not a struct", entity)
+	}
+
+	dst, result := addressable(zero(entity))
+
+	for _, p := range selects {
+		for _, name := range p.(query.SelectParam).Names {
+			field := entityutil.FieldByName(dst, name)
+			if !field.IsValid() || !field.CanSet() {
+				return entity, fmt.Errorf("memstore: entity %T has no field matching %s", entity, name)
+			}
+
+			field.Set(entityutil.FieldByName(src, name))
+		}
+	}
+
+	return result(), nil
+}
+
+// zero returns a zero entity of the type of entity, pointing to a zero struct for pointer entities.
+func zero[T any](entity T) T {
+	t := reflect.TypeOf(entity)
+	if t.Kind() != reflect.Pointer {
+		return *new(T)
+	}
+
+	return reflect.New(t.Elem()).Interface().(T)
+}
@@ -0,0 +1,473 @@
+package memstore
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/infevocorp/goflexstore/internal/entityutil"
+	"github.com/infevocorp/goflexstore/query"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// New creates a Store seeded with the given entities.
+//
+// Type parameters:
+//   - T: The entity type, either a struct or a pointer to a struct.
+//   - ID: The type of the entity's identifier.
+//
+// Parameters:
+//   - seed: The entities initially stored.
+//
+// Returns:
+// A Store implementing store.Store[T, ID].
+func New[T store.Entity[ID], ID comparable](seed ...T) *Store[T, ID] {
+	s := &Store[T, ID]{}
+
+	s.Seed(seed...)
+
+	return s
+}
+
+// Store is a store.Store keeping its entities in memory and evaluating the query params against them by
+// reflection.
+//
+// Entities are copied when they are stored and returned, so changes made by the caller are only visible
+// through Update, PartialUpdate and the other store methods, like with a database. Upsert detects conflicts
+// by ID only. The store is safe for concurrent use.
+type Store[T store.Entity[ID], ID comparable] struct {
+	mu       sync.RWMutex
+	entities []T
+}
+
+// Seed adds entities to the store.
+func (s *Store[T, ID]) Seed(entities ...T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, entity := range entities {
+		s.entities = append(s.entities, clone(entity))
+	}
+}
+
+// Entities returns a copy of the stored entities, in insertion order.
+func (s *Store[T, ID]) Entities() []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return cloneAll(s.entities)
+}
+
+// Get returns the first entity matching params, or store.ErrNotFound.
+func (s *Store[T, ID]) Get(_ context.Context, params ...query.Param) (T, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entities, err := s.list(query.NewParams(params...), true)
+	if err != nil {
+		return *new(T), err
+	}
+
+	if len(entities) == 0 {
+		return *new(T), store.ErrNotFound
+	}
+
+	return entities[0], nil
+}
+
+// List returns the entities matching params, grouped, ordered, paginated and selected accordingly.
+func (s *Store[T, ID]) List(_ context.Context, params ...query.Param) ([]T, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.list(query.NewParams(params...), true)
+}
+
+// ListWithCount returns the entities matching params, like List, and the number of entities matching the filters
+// of params.
+func (s *Store[T, ID]) ListWithCount(_ context.Context, params ...query.Param) ([]T, int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	p := query.NewParams(params...)
+
+	matching, err := s.list(p, false)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	entities, err := s.list(p, true)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return entities, int64(len(matching)), nil
+}
+
+// Count returns the number of entities, or of groups with a group by param, matching the filters of params.
+func (s *Store[T, ID]) Count(_ context.Context, params ...query.Param) (int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entities, err := s.list(query.NewParams(params...), false)
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(len(entities)), nil
+}
+
+// Aggregate computes the aggregate of the field over the entities matching the filters of params.
+func (s *Store[T, ID]) Aggregate(
+	ctx context.Context,
+	agg store.Aggregation,
+	field string,
+	params ...query.Param,
+) (float64, error) {
+	results, err := s.AggregateMany(ctx, []store.Aggregate{{Aggregation: agg, Field: field}}, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return results[0], nil
+}
+
+// AggregateMany computes the aggregates over the entities matching the filters of params.
+func (s *Store[T, ID]) AggregateMany(
+	_ context.Context,
+	aggs []store.Aggregate,
+	params ...query.Param,
+) ([]float64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entities, err := s.match(query.NewParams(params...))
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]float64, len(aggs))
+
+	for i, agg := range aggs {
+		if !agg.Aggregation.Valid() {
+			return nil, fmt.Errorf("memstore: unsupported aggregation %s", agg.Aggregation)
+		}
+
+		values, err := numbers(entities, agg)
+		if err != nil {
+			return nil, err
+		}
+
+		results[i] = aggregateValues(agg.Aggregation, values)
+	}
+
+	return results, nil
+}
+
+// Exists reports whether an entity matches the filters of params.
+func (s *Store[T, ID]) Exists(_ context.Context, params ...query.Param) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entities, err := s.match(query.NewParams(params...))
+	if err != nil {
+		return false, err
+	}
+
+	return len(entities) > 0, nil
+}
+
+// Create stores the entity and returns its ID.
+// When the ID is the zero value of an integer type, the next ID is generated and, for pointer entities,
+// set on the given entity. It returns a *store.DuplicateError if an entity with the same ID is stored.
+func (s *Store[T, ID]) Create(_ context.Context, entity T) (ID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.create(entity)
+}
+
+// CreateMany stores the entities, generating their IDs like Create. If one of their IDs is already stored, or
+// given twice, none of the entities is stored and a *store.DuplicateError is returned.
+func (s *Store[T, ID]) CreateMany(_ context.Context, entities []T) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := len(s.entities)
+
+	for _, entity := range entities {
+		if _, err := s.create(entity); err != nil {
+			s.entities = s.entities[:stored]
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Upsert replaces the stored entity having the same ID, or creates it when there is none.
+// With onConflict.DoNothing, or when it does not satisfy onConflict.UpdateWhere, an existing entity is left unchanged.
+func (s *Store[T, ID]) Upsert(_ context.Context, entity T, onConflict store.OnConflict) (ID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := entity.GetID()
+
+	for i, stored := range s.entities {
+		if id == *new(ID) || stored.GetID() != id {
+			continue
+		}
+
+		if onConflict.DoNothing {
+			return id, nil
+		}
+
+		ok, err := matchUpdateWhere(stored, entity, onConflict.UpdateWhere)
+		if err != nil {
+			return *new(ID), err
+		}
+
+		if ok {
+			s.entities[i] = clone(entity)
+		}
+
+		return id, nil
+	}
+
+	return s.create(entity)
+}
+
+// Update replaces the entities matching the filters of params with entity, each keeping its ID.
+// Without filters, the stored entity having the same ID is replaced.
+func (s *Store[T, ID]) Update(_ context.Context, entity T, params ...query.Param) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.update(entity, query.NewParams(params...), func(T) T {
+		return clone(entity)
+	})
+}
+
+// PartialUpdate copies the non-zero fields of entity to the entities matching the filters of params.
+// Without filters, the stored entity having the same ID is updated.
+func (s *Store[T, ID]) PartialUpdate(_ context.Context, entity T, params ...query.Param) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.update(entity, query.NewParams(params...), func(stored T) T {
+		return mergeNonZero(stored, entity)
+	})
+}
+
+// UpdateMany sets the fields of updates, matched by name like the filters, on the entities matching the filters of
+// params, and returns their number.
+func (s *Store[T, ID]) UpdateMany(_ context.Context, updates map[string]any, params ...query.Param) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := query.NewParams(params...)
+
+	var updated int64
+
+	for i, stored := range s.entities {
+		ok, err := matchAll(stored, p)
+		if err != nil {
+			return 0, err
+		}
+
+		if !ok {
+			continue
+		}
+
+		if s.entities[i], err = withUpdates(stored, updates); err != nil {
+			return 0, err
+		}
+
+		updated++
+	}
+
+	return updated, nil
+}
+
+// Delete removes the entities matching the filters of params.
+func (s *Store[T, ID]) Delete(ctx context.Context, params ...query.Param) error {
+	_, err := s.DeleteReturning(ctx, params...)
+
+	return err
+}
+
+// DeleteReturning removes the entities matching the filters of params and returns them.
+func (s *Store[T, ID]) DeleteReturning(_ context.Context, params ...query.Param) ([]T, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := query.NewParams(params...)
+
+	var (
+		kept    = make([]T, 0, len(s.entities))
+		deleted []T
+	)
+
+	for _, entity := range s.entities {
+		ok, err := matchAll(entity, p)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			deleted = append(deleted, entity)
+		} else {
+			kept = append(kept, entity)
+		}
+	}
+
+	s.entities = kept
+
+	return deleted, nil
+}
+
+// Ping always succeeds, the store having no connection.
+func (s *Store[T, ID]) Ping(_ context.Context) error {
+	return nil
+}
+
+// match returns the stored entities matching the filters of params.
+func (s *Store[T, ID]) match(params query.Params) ([]T, error) {
+	var matched []T
+
+	for _, entity := range s.entities {
+		ok, err := matchAll(entity, params)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			matched = append(matched, entity)
+		}
+	}
+
+	return matched, nil
+}
+
+// list returns the stored entities matching the filters of params, grouped by the group by params.
+// When paginate is true, the ordering, pagination and select params are applied too, and the entities are copied.
+func (s *Store[T, ID]) list(params query.Params, paginate bool) ([]T, error) {
+	matched, err := s.match(params)
+	if err != nil {
+		return nil, err
+	}
+
+	if matched, err = groupEntities(matched, params.Get(query.TypeGroupBy)); err != nil {
+		return nil, err
+	}
+
+	if !paginate {
+		return matched, nil
+	}
+
+	if err := entityutil.Sort(matched, params.Get(query.TypeOrderBy)); err != nil {
+		return nil, fmt.Errorf("memstore: %w", err)
+	}
+
+	for _, p := range params.Get(query.TypePaginate) {
+		matched = entityutil.Paginate(matched, p.(query.PaginateParam))
+	}
+
+	if len(matched) == 0 {
+		return nil, nil
+	}
+
+	selected := make([]T, len(matched))
+
+	for i, entity := range matched {
+		if selected[i], err = selectFields(entity, params.Get(query.TypeSelect)); err != nil {
+			return nil, err
+		}
+	}
+
+	return selected, nil
+}
+
+// create stores the entity, generating its ID if it is zero, unless an entity with the same ID is stored.
+func (s *Store[T, ID]) create(entity T) (ID, error) {
+	id := entity.GetID()
+
+	if id == *new(ID) {
+		if next, ok := s.nextID(); ok {
+			id = next
+			entity = withID(entity, id)
+		}
+	} else {
+		for _, stored := range s.entities {
+			if stored.GetID() == id {
+				return *new(ID), &store.DuplicateError{Entity: store.EntityName[T](), Fields: []string{"ID"}}
+			}
+		}
+	}
+
+	s.entities = append(s.entities, clone(entity))
+
+	return id, nil
+}
+
+// nextID returns the successor of the greatest stored ID, if ID is an integer type.
+func (s *Store[T, ID]) nextID() (ID, bool) {
+	next := reflect.New(reflect.TypeOf((*ID)(nil)).Elem()).Elem()
+
+	switch next.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var maxID int64
+
+		for _, entity := range s.entities {
+			if id := reflect.ValueOf(entity.GetID()).Int(); id > maxID {
+				maxID = id
+			}
+		}
+
+		next.SetInt(maxID + 1)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var maxID uint64
+
+		for _, entity := range s.entities {
+			if id := reflect.ValueOf(entity.GetID()).Uint(); id > maxID {
+				maxID = id
+			}
+		}
+
+		next.SetUint(maxID + 1)
+	default:
+		return *new(ID), false
+	}
+
+	return next.Interface().(ID), true
+}
+
+func (s *Store[T, ID]) update(entity T, params query.Params, apply func(T) T) error {
+	hasFilters := len(params.Get(query.TypeFilter)) > 0 || len(params.Get(query.TypeOR)) > 0 ||
+		len(params.Get(query.TypeGroup)) > 0 || len(params.Get(query.TypeNot)) > 0 || len(params.Get(query.TypeRaw)) > 0
+
+	for i, stored := range s.entities {
+		var (
+			ok  bool
+			err error
+		)
+
+		if hasFilters {
+			ok, err = matchAll(stored, params)
+		} else {
+			ok = stored.GetID() == entity.GetID()
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			// the updated entities keep their ID, the one of entity only selecting the entity to update.
+			s.entities[i] = withID(apply(stored), stored.GetID())
+		}
+	}
+
+	return nil
+}
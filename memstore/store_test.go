@@ -0,0 +1,246 @@
+package memstore_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infevocorp/goflexstore/filters"
+	"github.com/infevocorp/goflexstore/memstore"
+	"github.com/infevocorp/goflexstore/query"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+type Article struct {
+	ID       int64
+	Title    string
+	Status   string
+	AuthorID int64
+	Views    int
+}
+
+func (a *Article) GetID() int64 {
+	return a.ID
+}
+
+// Comment is stored by value, to check the stores of struct entities.
+type Comment struct {
+	ID   int
+	Body string
+}
+
+func (c Comment) GetID() int {
+	return c.ID
+}
+
+var _ store.Store[*Article, int64] = (*memstore.Store[*Article, int64])(nil)
+
+func newArticles() *memstore.Store[*Article, int64] {
+	return memstore.New[*Article, int64](
+		&Article{ID: 1, Title: "first", Status: "draft", AuthorID: 1, Views: 10},
+		&Article{ID: 2, Title: "second", Status: "active", AuthorID: 1, Views: 30},
+		&Article{ID: 3, Title: "third", Status: "active", AuthorID: 2, Views: 20},
+	)
+}
+
+func Test_Store_List(t *testing.T) {
+	t.Run("should-filter-order-and-paginate", func(t *testing.T) {
+		// GIVEN
+		articles := newArticles()
+
+		// WHEN
+		list, err := articles.List(context.Background(),
+			query.OR(query.Filter("status", "active"), query.Filter("views", 10)),
+			query.OrderBy("views", true),
+			query.Paginate(1, 2),
+		)
+
+		// THEN
+		require.NoError(t, err)
+		require.Len(t, list, 2)
+		assert.Equal(t, []int64{3, 1}, []int64{list[0].ID, list[1].ID})
+	})
+
+	t.Run("should-group-by-fields", func(t *testing.T) {
+		// GIVEN
+		articles := newArticles()
+
+		// WHEN
+		list, err := articles.List(context.Background(), query.GroupBy("author_id"))
+		count, countErr := articles.Count(context.Background(), query.GroupBy("author_id"))
+
+		// THEN
+		require.NoError(t, err)
+		require.NoError(t, countErr)
+		require.Len(t, list, 2)
+		assert.Equal(t, []int64{1, 3}, []int64{list[0].ID, list[1].ID})
+		assert.Equal(t, int64(2), count)
+	})
+
+	t.Run("should-return-error-of-having", func(t *testing.T) {
+		// GIVEN
+		articles := newArticles()
+
+		// WHEN
+		_, err := articles.List(context.Background(), query.GroupBy("status").WithHaving(query.Filter("views", 10)))
+
+		// THEN
+		assert.EqualError(t, err, "memstore: having conditions cannot be evaluated")
+	})
+
+	t.Run("should-select-fields", func(t *testing.T) {
+		// GIVEN
+		articles := newArticles()
+
+		// WHEN
+		article, err := articles.Get(context.Background(), filters.IDs[int64](2), query.Select("title", "views"))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, &Article{Title: "second", Views: 30}, article)
+	})
+
+	t.Run("should-return-error-of-unknown-selected-field", func(t *testing.T) {
+		// GIVEN
+		articles := newArticles()
+
+		// WHEN
+		_, err := articles.List(context.Background(), query.Select("summary"))
+
+		// THEN
+		assert.EqualError(t, err, "memstore: entity *memstore_test.Article has no field matching summary")
+	})
+}
+
+func Test_Store_Write(t *testing.T) {
+	t.Run("should-create-update-and-delete", func(t *testing.T) {
+		// GIVEN
+		ctx := context.Background()
+		articles := newArticles()
+
+		// WHEN
+		id, err := articles.Create(ctx, &Article{Title: "fourth", Status: "draft"})
+		require.NoError(t, err)
+
+		updated, err := articles.UpdateMany(ctx, map[string]any{"status": "archived"}, query.Filter("status", "draft"))
+		require.NoError(t, err)
+
+		deleted, err := articles.DeleteReturning(ctx, query.Filter("status", "active"))
+		require.NoError(t, err)
+
+		// THEN
+		assert.Equal(t, int64(4), id)
+		assert.Equal(t, int64(2), updated)
+		assert.Len(t, deleted, 2)
+
+		remaining := articles.Entities()
+		require.Len(t, remaining, 2)
+		assert.Equal(t, "archived", remaining[1].Status)
+	})
+
+	t.Run("should-keep-ids-of-entities-updated-by-filters", func(t *testing.T) {
+		// GIVEN
+		ctx := context.Background()
+		articles := newArticles()
+
+		// WHEN
+		err := articles.Update(ctx, &Article{Title: "updated", Status: "archived"}, query.Filter("status", "active"))
+		require.NoError(t, err)
+
+		err = articles.PartialUpdate(ctx, &Article{ID: 9, Views: 50}, query.Filter("status", "archived"))
+		require.NoError(t, err)
+
+		// THEN
+		assert.Equal(t, []*Article{
+			{ID: 1, Title: "first", Status: "draft", AuthorID: 1, Views: 10},
+			{ID: 2, Title: "updated", Status: "archived", Views: 50},
+			{ID: 3, Title: "updated", Status: "archived", Views: 50},
+		}, articles.Entities())
+	})
+
+	t.Run("should-reject-duplicate-id", func(t *testing.T) {
+		// GIVEN
+		ctx := context.Background()
+		articles := newArticles()
+
+		// WHEN
+		_, err := articles.Create(ctx, &Article{ID: 1, Title: "duplicate"})
+
+		// THEN
+		assert.ErrorIs(t, err, store.ErrDuplicate)
+		assert.EqualError(t, err, "duplicate Article: ID already exists")
+		assert.Len(t, articles.Entities(), 3)
+	})
+
+	t.Run("should-reject-batch-with-duplicate-id", func(t *testing.T) {
+		for name, batch := range map[string][]*Article{
+			"stored":  {{Title: "fourth"}, {ID: 2, Title: "duplicate"}},
+			"batched": {{ID: 5, Title: "fifth"}, {ID: 5, Title: "duplicate"}},
+		} {
+			// GIVEN
+			ctx := context.Background()
+			articles := newArticles()
+
+			// WHEN
+			err := articles.CreateMany(ctx, batch)
+
+			// THEN
+			assert.ErrorIs(t, err, store.ErrDuplicate, name)
+			assert.Len(t, articles.Entities(), 3, name)
+		}
+	})
+
+	t.Run("should-store-struct-entities", func(t *testing.T) {
+		// GIVEN
+		ctx := context.Background()
+		comments := memstore.New[Comment, int]()
+
+		// WHEN
+		id, err := comments.Create(ctx, Comment{Body: "hello"})
+		require.NoError(t, err)
+
+		err = comments.PartialUpdate(ctx, Comment{ID: id, Body: "hi"})
+		require.NoError(t, err)
+
+		comment, err := comments.Get(ctx, filters.IDs(id))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, Comment{ID: 1, Body: "hi"}, comment)
+	})
+
+	t.Run("should-be-safe-for-concurrent-use", func(t *testing.T) {
+		// GIVEN
+		ctx := context.Background()
+		articles := newArticles()
+
+		var wg sync.WaitGroup
+
+		// WHEN
+		for i := 0; i < 10; i++ {
+			wg.Add(2)
+
+			go func() {
+				defer wg.Done()
+
+				_, _ = articles.Create(ctx, &Article{Status: "draft"})
+			}()
+
+			go func() {
+				defer wg.Done()
+
+				_, _ = articles.List(ctx, query.OrderBy("id", false))
+			}()
+		}
+
+		wg.Wait()
+
+		// THEN
+		count, err := articles.Count(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(13), count)
+	})
+}
@@ -24,8 +24,155 @@ func (_m *Store[T, ID]) EXPECT() *Store_Expecter[T, ID] {
 	return &Store_Expecter[T, ID]{mock: &_m.Mock}
 }
 
+// Aggregate provides a mock function with given fields: ctx, agg, field, params
+func (_m *Store[T, ID]) Aggregate(ctx context.Context, agg store.Aggregation, field string, params ...query.Param) (float64, error) {
+	_va := make([]interface{}, len(params))
+	for _i := range params {
+		_va[_i] = params[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, agg, field)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Aggregate")
+	}
+
+	var r0 float64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, store.Aggregation, string, ...query.Param) (float64, error)); ok {
+		return rf(ctx, agg, field, params...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, store.Aggregation, string, ...query.Param) float64); ok {
+		r0 = rf(ctx, agg, field, params...)
+	} else {
+		r0 = ret.Get(0).(float64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, store.Aggregation, string, ...query.Param) error); ok {
+		r1 = rf(ctx, agg, field, params...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store_Aggregate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Aggregate'
+type Store_Aggregate_Call[T store.Entity[ID], ID comparable] struct {
+	*mock.Call
+}
+
+// Aggregate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - agg store.Aggregation
+//   - field string
+//   - params ...query.Param
+func (_e *Store_Expecter[T, ID]) Aggregate(ctx interface{}, agg interface{}, field interface{}, params ...interface{}) *Store_Aggregate_Call[T, ID] {
+	return &Store_Aggregate_Call[T, ID]{Call: _e.mock.On("Aggregate",
+		append([]interface{}{ctx, agg, field}, params...)...)}
+}
+
+func (_c *Store_Aggregate_Call[T, ID]) Run(run func(ctx context.Context, agg store.Aggregation, field string, params ...query.Param)) *Store_Aggregate_Call[T, ID] {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]query.Param, len(args)-3)
+		for i, a := range args[3:] {
+			if a != nil {
+				variadicArgs[i] = a.(query.Param)
+			}
+		}
+		run(args[0].(context.Context), args[1].(store.Aggregation), args[2].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *Store_Aggregate_Call[T, ID]) Return(_a0 float64, _a1 error) *Store_Aggregate_Call[T, ID] {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Store_Aggregate_Call[T, ID]) RunAndReturn(run func(context.Context, store.Aggregation, string, ...query.Param) (float64, error)) *Store_Aggregate_Call[T, ID] {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AggregateMany provides a mock function with given fields: ctx, aggs, params
+func (_m *Store[T, ID]) AggregateMany(ctx context.Context, aggs []store.Aggregate, params ...query.Param) ([]float64, error) {
+	_va := make([]interface{}, len(params))
+	for _i := range params {
+		_va[_i] = params[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, aggs)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AggregateMany")
+	}
+
+	var r0 []float64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []store.Aggregate, ...query.Param) ([]float64, error)); ok {
+		return rf(ctx, aggs, params...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []store.Aggregate, ...query.Param) []float64); ok {
+		r0 = rf(ctx, aggs, params...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]float64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []store.Aggregate, ...query.Param) error); ok {
+		r1 = rf(ctx, aggs, params...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store_AggregateMany_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AggregateMany'
+type Store_AggregateMany_Call[T store.Entity[ID], ID comparable] struct {
+	*mock.Call
+}
+
+// AggregateMany is a helper method to define mock.On call
+//   - ctx context.Context
+//   - aggs []store.Aggregate
+//   - params ...query.Param
+func (_e *Store_Expecter[T, ID]) AggregateMany(ctx interface{}, aggs interface{}, params ...interface{}) *Store_AggregateMany_Call[T, ID] {
+	return &Store_AggregateMany_Call[T, ID]{Call: _e.mock.On("AggregateMany",
+		append([]interface{}{ctx, aggs}, params...)...)}
+}
+
+func (_c *Store_AggregateMany_Call[T, ID]) Run(run func(ctx context.Context, aggs []store.Aggregate, params ...query.Param)) *Store_AggregateMany_Call[T, ID] {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]query.Param, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(query.Param)
+			}
+		}
+		run(args[0].(context.Context), args[1].([]store.Aggregate), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *Store_AggregateMany_Call[T, ID]) Return(_a0 []float64, _a1 error) *Store_AggregateMany_Call[T, ID] {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Store_AggregateMany_Call[T, ID]) RunAndReturn(run func(context.Context, []store.Aggregate, ...query.Param) ([]float64, error)) *Store_AggregateMany_Call[T, ID] {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Count provides a mock function with given fields: ctx, params
-func (_m *Store[T, ID]) Count(ctx context.Context, params ...query.Param) (int, error) {
+func (_m *Store[T, ID]) Count(ctx context.Context, params ...query.Param) (int64, error) {
 	_va := make([]interface{}, len(params))
 	for _i := range params {
 		_va[_i] = params[_i]
@@ -39,15 +186,15 @@ func (_m *Store[T, ID]) Count(ctx context.Context, params ...query.Param) (int,
 		panic("no return value specified for Count")
 	}
 
-	var r0 int
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, ...query.Param) (int, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, ...query.Param) (int64, error)); ok {
 		return rf(ctx, params...)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, ...query.Param) int); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, ...query.Param) int64); ok {
 		r0 = rf(ctx, params...)
 	} else {
-		r0 = ret.Get(0).(int)
+		r0 = ret.Get(0).(int64)
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, ...query.Param) error); ok {
@@ -85,12 +232,12 @@ func (_c *Store_Count_Call[T, ID]) Run(run func(ctx context.Context, params ...q
 	return _c
 }
 
-func (_c *Store_Count_Call[T, ID]) Return(_a0 int, _a1 error) *Store_Count_Call[T, ID] {
+func (_c *Store_Count_Call[T, ID]) Return(_a0 int64, _a1 error) *Store_Count_Call[T, ID] {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Store_Count_Call[T, ID]) RunAndReturn(run func(context.Context, ...query.Param) (int, error)) *Store_Count_Call[T, ID] {
+func (_c *Store_Count_Call[T, ID]) RunAndReturn(run func(context.Context, ...query.Param) (int64, error)) *Store_Count_Call[T, ID] {
 	_c.Call.Return(run)
 	return _c
 }
@@ -200,7 +347,7 @@ func (_c *Store_CreateMany_Call[T, ID]) RunAndReturn(run func(context.Context, [
 }
 
 // Delete provides a mock function with given fields: ctx, params
-func (_m *Store[T, ID]) Delete(ctx context.Context, params ...query.Params) (T, error) {
+func (_m *Store[T, ID]) Delete(ctx context.Context, params ...query.Param) error {
 	_va := make([]interface{}, len(params))
 	for _i := range params {
 		_va[_i] = params[_i]
@@ -214,18 +361,81 @@ func (_m *Store[T, ID]) Delete(ctx context.Context, params ...query.Params) (T,
 		panic("no return value specified for Delete")
 	}
 
-	var r0 T
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...query.Param) error); ok {
+		r0 = rf(ctx, params...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Store_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type Store_Delete_Call[T store.Entity[ID], ID comparable] struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params ...query.Param
+func (_e *Store_Expecter[T, ID]) Delete(ctx interface{}, params ...interface{}) *Store_Delete_Call[T, ID] {
+	return &Store_Delete_Call[T, ID]{Call: _e.mock.On("Delete",
+		append([]interface{}{ctx}, params...)...)}
+}
+
+func (_c *Store_Delete_Call[T, ID]) Run(run func(ctx context.Context, params ...query.Param)) *Store_Delete_Call[T, ID] {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]query.Param, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(query.Param)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *Store_Delete_Call[T, ID]) Return(_a0 error) *Store_Delete_Call[T, ID] {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Store_Delete_Call[T, ID]) RunAndReturn(run func(context.Context, ...query.Param) error) *Store_Delete_Call[T, ID] {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteReturning provides a mock function with given fields: ctx, params
+func (_m *Store[T, ID]) DeleteReturning(ctx context.Context, params ...query.Param) ([]T, error) {
+	_va := make([]interface{}, len(params))
+	for _i := range params {
+		_va[_i] = params[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteReturning")
+	}
+
+	var r0 []T
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, ...query.Params) (T, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, ...query.Param) ([]T, error)); ok {
 		return rf(ctx, params...)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, ...query.Params) T); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, ...query.Param) []T); ok {
 		r0 = rf(ctx, params...)
 	} else {
-		r0 = ret.Get(0).(T)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]T)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, ...query.Params) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, ...query.Param) error); ok {
 		r1 = rf(ctx, params...)
 	} else {
 		r1 = ret.Error(1)
@@ -234,25 +444,25 @@ func (_m *Store[T, ID]) Delete(ctx context.Context, params ...query.Params) (T,
 	return r0, r1
 }
 
-// Store_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
-type Store_Delete_Call[T store.Entity[ID], ID comparable] struct {
+// Store_DeleteReturning_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteReturning'
+type Store_DeleteReturning_Call[T store.Entity[ID], ID comparable] struct {
 	*mock.Call
 }
 
-// Delete is a helper method to define mock.On call
+// DeleteReturning is a helper method to define mock.On call
 //   - ctx context.Context
-//   - params ...query.Params
-func (_e *Store_Expecter[T, ID]) Delete(ctx interface{}, params ...interface{}) *Store_Delete_Call[T, ID] {
-	return &Store_Delete_Call[T, ID]{Call: _e.mock.On("Delete",
+//   - params ...query.Param
+func (_e *Store_Expecter[T, ID]) DeleteReturning(ctx interface{}, params ...interface{}) *Store_DeleteReturning_Call[T, ID] {
+	return &Store_DeleteReturning_Call[T, ID]{Call: _e.mock.On("DeleteReturning",
 		append([]interface{}{ctx}, params...)...)}
 }
 
-func (_c *Store_Delete_Call[T, ID]) Run(run func(ctx context.Context, params ...query.Params)) *Store_Delete_Call[T, ID] {
+func (_c *Store_DeleteReturning_Call[T, ID]) Run(run func(ctx context.Context, params ...query.Param)) *Store_DeleteReturning_Call[T, ID] {
 	_c.Call.Run(func(args mock.Arguments) {
-		variadicArgs := make([]query.Params, len(args)-1)
+		variadicArgs := make([]query.Param, len(args)-1)
 		for i, a := range args[1:] {
 			if a != nil {
-				variadicArgs[i] = a.(query.Params)
+				variadicArgs[i] = a.(query.Param)
 			}
 		}
 		run(args[0].(context.Context), variadicArgs...)
@@ -260,18 +470,18 @@ func (_c *Store_Delete_Call[T, ID]) Run(run func(ctx context.Context, params ...
 	return _c
 }
 
-func (_c *Store_Delete_Call[T, ID]) Return(_a0 T, _a1 error) *Store_Delete_Call[T, ID] {
+func (_c *Store_DeleteReturning_Call[T, ID]) Return(_a0 []T, _a1 error) *Store_DeleteReturning_Call[T, ID] {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Store_Delete_Call[T, ID]) RunAndReturn(run func(context.Context, ...query.Params) (T, error)) *Store_Delete_Call[T, ID] {
+func (_c *Store_DeleteReturning_Call[T, ID]) RunAndReturn(run func(context.Context, ...query.Param) ([]T, error)) *Store_DeleteReturning_Call[T, ID] {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Exist provides a mock function with given fields: ctx, params
-func (_m *Store[T, ID]) Exist(ctx context.Context, params ...query.Param) (bool, error) {
+// Exists provides a mock function with given fields: ctx, params
+func (_m *Store[T, ID]) Exists(ctx context.Context, params ...query.Param) (bool, error) {
 	_va := make([]interface{}, len(params))
 	for _i := range params {
 		_va[_i] = params[_i]
@@ -282,7 +492,7 @@ func (_m *Store[T, ID]) Exist(ctx context.Context, params ...query.Param) (bool,
 	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Exist")
+		panic("no return value specified for Exists")
 	}
 
 	var r0 bool
@@ -305,20 +515,20 @@ func (_m *Store[T, ID]) Exist(ctx context.Context, params ...query.Param) (bool,
 	return r0, r1
 }
 
-// Store_Exist_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exist'
-type Store_Exist_Call[T store.Entity[ID], ID comparable] struct {
+// Store_Exists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exists'
+type Store_Exists_Call[T store.Entity[ID], ID comparable] struct {
 	*mock.Call
 }
 
-// Exist is a helper method to define mock.On call
+// Exists is a helper method to define mock.On call
 //   - ctx context.Context
 //   - params ...query.Param
-func (_e *Store_Expecter[T, ID]) Exist(ctx interface{}, params ...interface{}) *Store_Exist_Call[T, ID] {
-	return &Store_Exist_Call[T, ID]{Call: _e.mock.On("Exist",
+func (_e *Store_Expecter[T, ID]) Exists(ctx interface{}, params ...interface{}) *Store_Exists_Call[T, ID] {
+	return &Store_Exists_Call[T, ID]{Call: _e.mock.On("Exists",
 		append([]interface{}{ctx}, params...)...)}
 }
 
-func (_c *Store_Exist_Call[T, ID]) Run(run func(ctx context.Context, params ...query.Param)) *Store_Exist_Call[T, ID] {
+func (_c *Store_Exists_Call[T, ID]) Run(run func(ctx context.Context, params ...query.Param)) *Store_Exists_Call[T, ID] {
 	_c.Call.Run(func(args mock.Arguments) {
 		variadicArgs := make([]query.Param, len(args)-1)
 		for i, a := range args[1:] {
@@ -331,12 +541,12 @@ func (_c *Store_Exist_Call[T, ID]) Run(run func(ctx context.Context, params ...q
 	return _c
 }
 
-func (_c *Store_Exist_Call[T, ID]) Return(_a0 bool, _a1 error) *Store_Exist_Call[T, ID] {
+func (_c *Store_Exists_Call[T, ID]) Return(_a0 bool, _a1 error) *Store_Exists_Call[T, ID] {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Store_Exist_Call[T, ID]) RunAndReturn(run func(context.Context, ...query.Param) (bool, error)) *Store_Exist_Call[T, ID] {
+func (_c *Store_Exists_Call[T, ID]) RunAndReturn(run func(context.Context, ...query.Param) (bool, error)) *Store_Exists_Call[T, ID] {
 	_c.Call.Return(run)
 	return _c
 }
@@ -485,6 +695,86 @@ func (_c *Store_List_Call[T, ID]) RunAndReturn(run func(context.Context, ...quer
 	return _c
 }
 
+// ListWithCount provides a mock function with given fields: ctx, params
+func (_m *Store[T, ID]) ListWithCount(ctx context.Context, params ...query.Param) ([]T, int64, error) {
+	_va := make([]interface{}, len(params))
+	for _i := range params {
+		_va[_i] = params[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWithCount")
+	}
+
+	var r0 []T
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...query.Param) ([]T, int64, error)); ok {
+		return rf(ctx, params...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...query.Param) []T); ok {
+		r0 = rf(ctx, params...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]T)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...query.Param) int64); ok {
+		r1 = rf(ctx, params...)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, ...query.Param) error); ok {
+		r2 = rf(ctx, params...)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Store_ListWithCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWithCount'
+type Store_ListWithCount_Call[T store.Entity[ID], ID comparable] struct {
+	*mock.Call
+}
+
+// ListWithCount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params ...query.Param
+func (_e *Store_Expecter[T, ID]) ListWithCount(ctx interface{}, params ...interface{}) *Store_ListWithCount_Call[T, ID] {
+	return &Store_ListWithCount_Call[T, ID]{Call: _e.mock.On("ListWithCount",
+		append([]interface{}{ctx}, params...)...)}
+}
+
+func (_c *Store_ListWithCount_Call[T, ID]) Run(run func(ctx context.Context, params ...query.Param)) *Store_ListWithCount_Call[T, ID] {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]query.Param, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(query.Param)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *Store_ListWithCount_Call[T, ID]) Return(_a0 []T, _a1 int64, _a2 error) *Store_ListWithCount_Call[T, ID] {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *Store_ListWithCount_Call[T, ID]) RunAndReturn(run func(context.Context, ...query.Param) ([]T, int64, error)) *Store_ListWithCount_Call[T, ID] {
+	_c.Call.Return(run)
+	return _c
+}
+
 // PartialUpdate provides a mock function with given fields: ctx, entity, params
 func (_m *Store[T, ID]) PartialUpdate(ctx context.Context, entity T, params ...query.Param) error {
 	_va := make([]interface{}, len(params))
@@ -547,6 +837,52 @@ func (_c *Store_PartialUpdate_Call[T, ID]) RunAndReturn(run func(context.Context
 	return _c
 }
 
+// Ping provides a mock function with given fields: ctx
+func (_m *Store[T, ID]) Ping(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Ping")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Store_Ping_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Ping'
+type Store_Ping_Call[T store.Entity[ID], ID comparable] struct {
+	*mock.Call
+}
+
+// Ping is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Store_Expecter[T, ID]) Ping(ctx interface{}) *Store_Ping_Call[T, ID] {
+	return &Store_Ping_Call[T, ID]{Call: _e.mock.On("Ping", ctx)}
+}
+
+func (_c *Store_Ping_Call[T, ID]) Run(run func(ctx context.Context)) *Store_Ping_Call[T, ID] {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Store_Ping_Call[T, ID]) Return(_a0 error) *Store_Ping_Call[T, ID] {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Store_Ping_Call[T, ID]) RunAndReturn(run func(context.Context) error) *Store_Ping_Call[T, ID] {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Update provides a mock function with given fields: ctx, entity, params
 func (_m *Store[T, ID]) Update(ctx context.Context, entity T, params ...query.Param) error {
 	_va := make([]interface{}, len(params))
@@ -609,46 +945,56 @@ func (_c *Store_Update_Call[T, ID]) RunAndReturn(run func(context.Context, T, ..
 	return _c
 }
 
-// Upsert provides a mock function with given fields: ctx, entity, params
-func (_m *Store[T, ID]) Upsert(ctx context.Context, entity T, params ...query.Param) error {
+// UpdateMany provides a mock function with given fields: ctx, updates, params
+func (_m *Store[T, ID]) UpdateMany(ctx context.Context, updates map[string]interface{}, params ...query.Param) (int64, error) {
 	_va := make([]interface{}, len(params))
 	for _i := range params {
 		_va[_i] = params[_i]
 	}
 	var _ca []interface{}
-	_ca = append(_ca, ctx, entity)
+	_ca = append(_ca, ctx, updates)
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Upsert")
+		panic("no return value specified for UpdateMany")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, T, ...query.Param) error); ok {
-		r0 = rf(ctx, entity, params...)
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, map[string]interface{}, ...query.Param) (int64, error)); ok {
+		return rf(ctx, updates, params...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, map[string]interface{}, ...query.Param) int64); ok {
+		r0 = rf(ctx, updates, params...)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int64)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, map[string]interface{}, ...query.Param) error); ok {
+		r1 = rf(ctx, updates, params...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Store_Upsert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Upsert'
-type Store_Upsert_Call[T store.Entity[ID], ID comparable] struct {
+// Store_UpdateMany_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateMany'
+type Store_UpdateMany_Call[T store.Entity[ID], ID comparable] struct {
 	*mock.Call
 }
 
-// Upsert is a helper method to define mock.On call
+// UpdateMany is a helper method to define mock.On call
 //   - ctx context.Context
-//   - entity T
+//   - updates map[string]interface{}
 //   - params ...query.Param
-func (_e *Store_Expecter[T, ID]) Upsert(ctx interface{}, entity interface{}, params ...interface{}) *Store_Upsert_Call[T, ID] {
-	return &Store_Upsert_Call[T, ID]{Call: _e.mock.On("Upsert",
-		append([]interface{}{ctx, entity}, params...)...)}
+func (_e *Store_Expecter[T, ID]) UpdateMany(ctx interface{}, updates interface{}, params ...interface{}) *Store_UpdateMany_Call[T, ID] {
+	return &Store_UpdateMany_Call[T, ID]{Call: _e.mock.On("UpdateMany",
+		append([]interface{}{ctx, updates}, params...)...)}
 }
 
-func (_c *Store_Upsert_Call[T, ID]) Run(run func(ctx context.Context, entity T, params ...query.Param)) *Store_Upsert_Call[T, ID] {
+func (_c *Store_UpdateMany_Call[T, ID]) Run(run func(ctx context.Context, updates map[string]interface{}, params ...query.Param)) *Store_UpdateMany_Call[T, ID] {
 	_c.Call.Run(func(args mock.Arguments) {
 		variadicArgs := make([]query.Param, len(args)-2)
 		for i, a := range args[2:] {
@@ -656,17 +1002,75 @@ func (_c *Store_Upsert_Call[T, ID]) Run(run func(ctx context.Context, entity T,
 				variadicArgs[i] = a.(query.Param)
 			}
 		}
-		run(args[0].(context.Context), args[1].(T), variadicArgs...)
+		run(args[0].(context.Context), args[1].(map[string]interface{}), variadicArgs...)
 	})
 	return _c
 }
 
-func (_c *Store_Upsert_Call[T, ID]) Return(_a0 error) *Store_Upsert_Call[T, ID] {
-	_c.Call.Return(_a0)
+func (_c *Store_UpdateMany_Call[T, ID]) Return(_a0 int64, _a1 error) *Store_UpdateMany_Call[T, ID] {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Store_UpdateMany_Call[T, ID]) RunAndReturn(run func(context.Context, map[string]interface{}, ...query.Param) (int64, error)) *Store_UpdateMany_Call[T, ID] {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Upsert provides a mock function with given fields: ctx, entity, onConflict
+func (_m *Store[T, ID]) Upsert(ctx context.Context, entity T, onConflict store.OnConflict) (ID, error) {
+	ret := _m.Called(ctx, entity, onConflict)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Upsert")
+	}
+
+	var r0 ID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, T, store.OnConflict) (ID, error)); ok {
+		return rf(ctx, entity, onConflict)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, T, store.OnConflict) ID); ok {
+		r0 = rf(ctx, entity, onConflict)
+	} else {
+		r0 = ret.Get(0).(ID)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, T, store.OnConflict) error); ok {
+		r1 = rf(ctx, entity, onConflict)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store_Upsert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Upsert'
+type Store_Upsert_Call[T store.Entity[ID], ID comparable] struct {
+	*mock.Call
+}
+
+// Upsert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entity T
+//   - onConflict store.OnConflict
+func (_e *Store_Expecter[T, ID]) Upsert(ctx interface{}, entity interface{}, onConflict interface{}) *Store_Upsert_Call[T, ID] {
+	return &Store_Upsert_Call[T, ID]{Call: _e.mock.On("Upsert", ctx, entity, onConflict)}
+}
+
+func (_c *Store_Upsert_Call[T, ID]) Run(run func(ctx context.Context, entity T, onConflict store.OnConflict)) *Store_Upsert_Call[T, ID] {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(T), args[2].(store.OnConflict))
+	})
+	return _c
+}
+
+func (_c *Store_Upsert_Call[T, ID]) Return(_a0 ID, _a1 error) *Store_Upsert_Call[T, ID] {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Store_Upsert_Call[T, ID]) RunAndReturn(run func(context.Context, T, ...query.Param) error) *Store_Upsert_Call[T, ID] {
+func (_c *Store_Upsert_Call[T, ID]) RunAndReturn(run func(context.Context, T, store.OnConflict) (ID, error)) *Store_Upsert_Call[T, ID] {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,21 @@
+// Package mongoopscope provides an opscope.Scope running the operations of the MongoDB stores in the transactions
+// of client sessions.
+//
+// TransactionScope.Begin starts a session and a transaction, and returns a context carrying the session, which
+// the driver uses for every operation performed with it, including those of the mongostore stores. Nested
+// scopes join the outermost transaction, which End commits, or aborts on error, before ending the session.
+//
+// MongoDB only supports transactions on replica sets and sharded clusters.
+//
+// Example:
+//
+//	txScope := mongoopscope.NewTransactionScope("mongo", client)
+//
+//	ctx, err := txScope.Begin(ctx)
+//	if err != nil {
+//		return err
+//	}
+//	defer txScope.EndWithRecover(ctx, &err)
+//
+//	_, err = articleStore.Create(ctx, article)
+package mongoopscope
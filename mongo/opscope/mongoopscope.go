@@ -0,0 +1,248 @@
+package mongoopscope
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/infevocorp/goflexstore/opscope"
+)
+
+var errBeginTx = errors.New("failed to begin transaction")
+
+type (
+	// contextKey is the type of the context key of the scope values, the name of the scope.
+	contextKey string
+
+	// scopeValue holds the session of the transaction and its nesting level in the context.
+	scopeValue struct {
+		session    mongo.Session
+		level      int
+		onCommit   []func(ctx context.Context)
+		onRollback []func(ctx context.Context)
+		finished   bool
+
+		// name and startedAt are reported by TxInfo.
+		name      string
+		startedAt time.Time
+	}
+)
+
+var (
+	_ opscope.Scope            = (*TransactionScope)(nil)
+	_ opscope.CommitNotifier   = (*TransactionScope)(nil)
+	_ opscope.RollbackNotifier = (*TransactionScope)(nil)
+	_ opscope.TxInspector      = (*scopeValue)(nil)
+)
+
+// NewTransactionScope creates a TransactionScope starting the sessions of the client.
+//
+// Parameters:
+//   - name: The name of the scope, used as the context key of its transactions.
+//   - client: The client starting the sessions.
+//   - txOptions: The options of the transactions, e.g. their read and write concerns. They are optional.
+//
+// Example:
+//
+//	txScope := mongoopscope.NewTransactionScope("mongo", client,
+//		options.Transaction().SetWriteConcern(writeconcern.Majority()))
+func NewTransactionScope(
+	name string,
+	client *mongo.Client,
+	txOptions ...*options.TransactionOptions,
+) *TransactionScope {
+	return &TransactionScope{
+		Name:      name,
+		Client:    client,
+		TxOptions: options.MergeTransactionOptions(txOptions...),
+	}
+}
+
+// TransactionScope runs the operations performed with the contexts it begins in the transaction of a session.
+//
+// Fields:
+//   - Name: The name of the scope, used as the context key of its transactions.
+//   - Client: The client starting the sessions.
+//   - TxOptions: The options of the transactions.
+type TransactionScope struct {
+	Name      string
+	Client    *mongo.Client
+	TxOptions *options.TransactionOptions
+}
+
+// Begin starts a session and its transaction, unless the context already carries a transaction of the scope,
+// whose level is increased instead.
+//
+// The returned context carries the session, used by the driver for the operations performed with it, and reports
+// the transaction to opscope.InTransaction and opscope.TxInfo.
+func (s *TransactionScope) Begin(ctx context.Context) (context.Context, error) {
+	if scopeVal := s.getScopeValue(ctx); scopeVal != nil {
+		scopeVal.level++
+		return ctx, nil
+	}
+
+	session, err := s.Client.StartSession()
+	if err != nil {
+		return ctx, errors.Join(errBeginTx, err)
+	}
+
+	if err := session.StartTransaction(s.TxOptions); err != nil {
+		session.EndSession(ctx)
+		return ctx, errors.Join(errBeginTx, err)
+	}
+
+	scopeVal := &scopeValue{
+		session:   session,
+		level:     1,
+		name:      s.Name,
+		startedAt: time.Now(),
+	}
+
+	ctx = mongo.NewSessionContext(s.setScopeValue(ctx, scopeVal), session)
+
+	return opscope.WithTxInspector(ctx, scopeVal), nil
+}
+
+// End decreases the level of the transaction of the context and, for the outermost scope, commits it, or aborts
+// it if err is not nil, and ends its session. The callbacks registered by OnCommit are called once the
+// transaction is committed, and those registered by OnRollback once it is aborted or fails to commit.
+func (s *TransactionScope) End(ctx context.Context, err error) error {
+	if errors.Is(err, errBeginTx) {
+		return nil
+	}
+
+	scopeVal := s.getScopeValue(ctx)
+	if scopeVal == nil {
+		return nil
+	}
+
+	if scopeVal.level > 1 {
+		scopeVal.level--
+		return nil
+	}
+
+	scopeVal.finished = true
+
+	err = scopeVal.end(ctx, err)
+
+	// run the callbacks outside of the finished transaction so that
+	// any store operation they perform does not reuse its session.
+	callbackCtx := mongo.NewSessionContext(s.setScopeValue(ctx, nil), nil)
+
+	if err != nil {
+		for _, fn := range scopeVal.onRollback {
+			fn(callbackCtx)
+		}
+
+		return err
+	}
+
+	for _, fn := range scopeVal.onCommit {
+		fn(callbackCtx)
+	}
+
+	return nil
+}
+
+// EndWithRecover ends the scope like End, with the error pointed to by errPtr joined with the recovered panic,
+// if any. The error of End is joined to the error pointed to by errPtr.
+//
+// It is important to pass a non-nil errPtr, as a nil pointer will result in a panic.
+func (s *TransactionScope) EndWithRecover(ctx context.Context, errPtr *error) {
+	if errPtr == nil {
+		panic("err pointer cannot be nil")
+	}
+
+	err := *errPtr
+
+	if r := recover(); r != nil {
+		if ferr, ok := r.(error); ok {
+			err = errors.Join(err, ferr)
+		} else {
+			err = errors.Join(err, fmt.Errorf("panic: %v", r))
+		}
+
+		*errPtr = err
+	}
+
+	if err2 := s.End(ctx, err); err2 != nil {
+		*errPtr = errors.Join(err, err2)
+	}
+}
+
+// OnCommit registers a callback to be called after the outermost transaction in the context is committed.
+// If the transaction is aborted, the callbacks are discarded. If the context does not carry a transaction of
+// the scope, fn is called immediately.
+func (s *TransactionScope) OnCommit(ctx context.Context, fn func(ctx context.Context)) {
+	scopeVal := s.getScopeValue(ctx)
+	if scopeVal == nil {
+		fn(ctx)
+		return
+	}
+
+	scopeVal.onCommit = append(scopeVal.onCommit, fn)
+}
+
+// OnRollback registers a callback to be called after the outermost transaction in the context is aborted or
+// fails to commit. If the transaction is committed, the callbacks are discarded. If the context does not carry a
+// transaction of the scope, fn is discarded.
+func (s *TransactionScope) OnRollback(ctx context.Context, fn func(ctx context.Context)) {
+	if scopeVal := s.getScopeValue(ctx); scopeVal != nil {
+		scopeVal.onRollback = append(scopeVal.onRollback, fn)
+	}
+}
+
+// Session returns the session of the transaction of the context, or nil if the context does not carry a
+// transaction of the scope.
+func (s *TransactionScope) Session(ctx context.Context) mongo.Session {
+	if scopeVal := s.getScopeValue(ctx); scopeVal != nil {
+		return scopeVal.session
+	}
+
+	return nil
+}
+
+func (s *TransactionScope) getScopeValue(ctx context.Context) *scopeValue {
+	// the value of a finished transaction is left in the contexts derived from its scope.
+	if val, ok := ctx.Value(contextKey(s.Name)).(*scopeValue); ok && val != nil && !val.finished {
+		return val
+	}
+
+	return nil
+}
+
+func (s *TransactionScope) setScopeValue(ctx context.Context, scopeVal *scopeValue) context.Context {
+	return context.WithValue(ctx, contextKey(s.Name), scopeVal)
+}
+
+// end commits the transaction, or aborts it if err is not nil, and ends the session.
+func (v *scopeValue) end(ctx context.Context, err error) error {
+	defer v.session.EndSession(ctx)
+
+	if err != nil {
+		if err2 := v.session.AbortTransaction(ctx); err2 != nil {
+			return errors.Join(err, fmt.Errorf("cannot abort transaction: %w", err2))
+		}
+
+		return err
+	}
+
+	if err := v.session.CommitTransaction(ctx); err != nil {
+		return fmt.Errorf("cannot commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// TxInfo implements opscope.TxInspector. It reports no transaction once the transaction is finished.
+func (v *scopeValue) TxInfo() (opscope.Info, bool) {
+	if v.finished {
+		return opscope.Info{}, false
+	}
+
+	return opscope.Info{Name: v.name, Level: v.level, StartedAt: v.startedAt}, true
+}
@@ -0,0 +1,94 @@
+package mongoopscope_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+
+	mongoopscope "github.com/infevocorp/goflexstore/mongo/opscope"
+	"github.com/infevocorp/goflexstore/opscope"
+)
+
+func Test_TransactionScope(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("should-commit-transaction-and-call-callbacks", func(mt *mtest.T) {
+		// GIVEN
+		var committed bool
+
+		txScope := mongoopscope.NewTransactionScope("test", mt.Client)
+		mt.AddMockResponses(mtest.CreateSuccessResponse(), mtest.CreateSuccessResponse())
+
+		// WHEN
+		ctx, err := txScope.Begin(context.Background())
+		require.NoError(t, err)
+
+		nestedCtx, err := txScope.Begin(ctx)
+		require.NoError(t, err)
+
+		info, inTx := opscope.TxInfo(nestedCtx)
+
+		_, err = mt.Coll.InsertOne(nestedCtx, bson.D{{Key: "title", Value: "go"}})
+		require.NoError(t, err)
+
+		txScope.OnCommit(nestedCtx, func(ctx context.Context) {
+			committed = !opscope.InTransaction(ctx)
+		})
+
+		require.NoError(t, txScope.End(nestedCtx, nil))
+		assert.False(t, committed)
+
+		err = txScope.End(ctx, nil)
+
+		// THEN
+		require.NoError(t, err)
+		assert.True(t, inTx)
+		assert.Equal(t, opscope.Info{Name: "test", Level: 2, StartedAt: info.StartedAt}, info)
+		assert.True(t, committed)
+		assert.False(t, opscope.InTransaction(ctx))
+
+		insert := mt.GetStartedEvent()
+		assert.Equal(t, "insert", insert.CommandName)
+		assert.True(t, insert.Command.Lookup("startTransaction").Boolean())
+		assert.Equal(t, "commitTransaction", mt.GetStartedEvent().CommandName)
+	})
+
+	mt.Run("should-abort-transaction-on-error", func(mt *mtest.T) {
+		// GIVEN
+		var committed, aborted bool
+
+		errFailed := errors.New("failed")
+		txScope := mongoopscope.NewTransactionScope("test", mt.Client)
+		mt.AddMockResponses(mtest.CreateSuccessResponse(), mtest.CreateSuccessResponse())
+
+		// WHEN
+		ctx, err := txScope.Begin(context.Background())
+		require.NoError(t, err)
+
+		_, err = mt.Coll.InsertOne(ctx, bson.D{{Key: "title", Value: "go"}})
+		require.NoError(t, err)
+
+		txScope.OnCommit(ctx, func(context.Context) {
+			committed = true
+		})
+		txScope.OnRollback(ctx, func(ctx context.Context) {
+			aborted = !opscope.InTransaction(ctx)
+		})
+
+		err = errFailed
+		txScope.EndWithRecover(ctx, &err)
+
+		// THEN
+		require.ErrorIs(t, err, errFailed)
+		assert.False(t, committed)
+		assert.True(t, aborted)
+
+		assert.Equal(t, "insert", mt.GetStartedEvent().CommandName)
+		assert.Equal(t, "abortTransaction", mt.GetStartedEvent().CommandName)
+	})
+}
@@ -0,0 +1,302 @@
+package mongoquery
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/infevocorp/goflexstore/query"
+)
+
+// ErrInvalidParam is returned by Build for the params that cannot be translated, wrapped with the reason.
+var ErrInvalidParam = errors.New("invalid query param")
+
+// Query is the translation of query params into the parts of a find command.
+//
+// Fields:
+//   - Filter: The filter document. It is empty when no param filters the documents.
+//   - Sort: The sort document of the OrderBy params, nil without them.
+//   - Projection: The projection document of the Select params, nil without them.
+//   - Skip: The number of documents to skip, 0 without Paginate param.
+//   - Limit: The maximum number of documents to return, 0 for no limit.
+type Query struct {
+	Filter     bson.D
+	Sort       bson.D
+	Projection bson.D
+	Skip       int64
+	Limit      int64
+}
+
+// Option configures a Builder.
+type Option func(*Builder)
+
+// WithFieldToKeyMap sets the map of the field names to the keys of the documents, see FieldToKeyMap.
+func WithFieldToKeyMap(fieldToKeyMap map[string]string) Option {
+	return func(b *Builder) {
+		b.FieldToKeyMap = fieldToKeyMap
+	}
+}
+
+// NewBuilder creates a Builder configured by the options.
+func NewBuilder(opts ...Option) *Builder {
+	b := &Builder{
+		FieldToKeyMap: map[string]string{},
+	}
+
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	return b
+}
+
+// Builder translates query params into Query, see the package documentation.
+//
+// Fields:
+//   - FieldToKeyMap: The map of the field names to the keys of the documents.
+type Builder struct {
+	FieldToKeyMap map[string]string
+}
+
+// Build translates the params into a Query. It returns an error wrapping ErrInvalidParam for the params that
+// cannot be translated.
+//
+// Example:
+//
+//	q, err := builder.Build(query.NewParams(
+//		query.Filter("status", "active"),
+//		query.OrderBy("views", true),
+//		query.Paginate(0, 10),
+//	))
+//	// q.Filter: {status: "active"}, q.Sort: {views: -1}, q.Limit: 10
+func (b *Builder) Build(params query.Params) (Query, error) {
+	var (
+		q          Query
+		conditions []bson.D
+	)
+
+	for _, param := range params.Params() {
+		switch p := param.(type) {
+		case query.FilterParam, query.ORParam, query.GroupParam, query.NotParam, query.RawParam:
+			cond, ok, err := b.Condition(p)
+			if err != nil {
+				return Query{}, err
+			}
+
+			if ok {
+				conditions = append(conditions, cond)
+			}
+		case query.FullTextParam:
+			conditions = append(conditions, bson.D{{Key: "$text", Value: bson.D{{Key: "$search", Value: p.Text}}}})
+		case query.OrderByParam:
+			direction := 1
+			if p.Desc {
+				direction = -1
+			}
+
+			q.Sort = append(q.Sort, bson.E{Key: b.key(p.Name), Value: direction})
+		case query.PaginateParam:
+			q.Skip, q.Limit = int64(p.Offset), int64(p.Limit)
+		case query.SelectParam:
+			for _, name := range p.Names {
+				q.Projection = append(q.Projection, bson.E{Key: b.key(name), Value: 1})
+			}
+		}
+	}
+
+	q.Filter = and(conditions)
+
+	return q, nil
+}
+
+// Condition translates a filter, OR, group or not param into a filter document. It returns false for the params
+// without condition, such as empty groups.
+func (b *Builder) Condition(param query.Param) (bson.D, bool, error) {
+	switch p := param.(type) {
+	case query.FilterParam:
+		cond, err := b.filter(p)
+
+		return cond, err == nil, err
+	case query.ORParam:
+		params := make([]query.Param, len(p.Params))
+		for i := range p.Params {
+			params[i] = p.Params[i]
+		}
+
+		return b.group(query.GroupParam{OR: true, Params: params})
+	case query.GroupParam:
+		return b.group(p)
+	case query.NotParam:
+		cond, ok, err := b.Condition(p.Param)
+		if err != nil || !ok {
+			return nil, false, err
+		}
+
+		return bson.D{{Key: "$nor", Value: bson.A{cond}}}, true, nil
+	case query.RawParam:
+		return nil, false, fmt.Errorf("%w: raw params cannot be translated to MongoDB: %s", ErrInvalidParam, p.SQL)
+	default:
+		return nil, false, fmt.Errorf("%w: %s param cannot be a condition", ErrInvalidParam, param.ParamType())
+	}
+}
+
+// group combines the conditions of the group with $and or $or.
+func (b *Builder) group(p query.GroupParam) (bson.D, bool, error) {
+	var conditions []bson.D
+
+	for _, param := range p.Params {
+		cond, ok, err := b.Condition(param)
+		if err != nil {
+			return nil, false, err
+		}
+
+		if ok {
+			conditions = append(conditions, cond)
+		}
+	}
+
+	switch {
+	case len(conditions) == 0:
+		return nil, false, nil
+	case !p.OR:
+		return and(conditions), true, nil
+	case len(conditions) == 1:
+		return conditions[0], true, nil
+	default:
+		return bson.D{{Key: "$or", Value: toArray(conditions)}}, true, nil
+	}
+}
+
+// filter translates a filter into a condition on its key.
+func (b *Builder) filter(p query.FilterParam) (bson.D, error) {
+	var (
+		key    = b.key(p.Name)
+		value  = p.Value
+		isList = isCollection(value)
+	)
+
+	if (p.Operator == query.IN || p.Operator == query.NOTIN) && !isList {
+		return nil, fmt.Errorf("%w: value of %s must be a collection with %s", ErrInvalidParam, p.Name, p.Operator)
+	}
+
+	if isList && reflect.ValueOf(value).Len() == 0 && (p.Operator == query.EQ || p.Operator == query.NEQ) {
+		return nil, fmt.Errorf("%w: empty collection of %s, use IN or NOTIN", ErrInvalidParam, p.Name)
+	}
+
+	var op string
+
+	switch p.Operator {
+	case query.EQ, query.IN:
+		if !isList {
+			return bson.D{{Key: key, Value: value}}, nil
+		}
+
+		op = "$in"
+	case query.NEQ, query.NOTIN:
+		op = "$ne"
+		if isList {
+			op = "$nin"
+		}
+	case query.GT:
+		op = "$gt"
+	case query.GTE:
+		op = "$gte"
+	case query.LT:
+		op = "$lt"
+	case query.LTE:
+		op = "$lte"
+	case query.LIKE, query.ILIKE:
+		pattern, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("%w: value of %s must be a string with %s", ErrInvalidParam, p.Name, p.Operator)
+		}
+
+		return bson.D{{Key: key, Value: likeRegex(pattern, p.Operator == query.ILIKE)}}, nil
+	default:
+		return nil, fmt.Errorf("%w: unsupported operator %s", ErrInvalidParam, p.Operator)
+	}
+
+	if isList && op != "$in" && op != "$nin" {
+		return nil, fmt.Errorf("%w: operator %s cannot compare %s with a collection", ErrInvalidParam, p.Operator, p.Name)
+	}
+
+	return bson.D{{Key: key, Value: bson.D{{Key: op, Value: value}}}}, nil
+}
+
+// key returns the document key of the field name.
+func (b *Builder) key(name string) string {
+	if key, ok := b.FieldToKeyMap[name]; ok {
+		return key
+	}
+
+	return name
+}
+
+// likeRegex translates a LIKE pattern, where % matches any sequence of characters and _ any single one, into an
+// anchored regular expression.
+func likeRegex(pattern string, insensitive bool) primitive.Regex {
+	var expr strings.Builder
+
+	expr.WriteString("^")
+
+	for _, r := range pattern {
+		switch r {
+		case '%':
+			expr.WriteString(".*")
+		case '_':
+			expr.WriteString(".")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+
+	expr.WriteString("$")
+
+	options := "s"
+	if insensitive {
+		options = "is"
+	}
+
+	return primitive.Regex{Pattern: expr.String(), Options: options}
+}
+
+// and combines the conditions with $and, unless there is a single one. It returns an empty document without
+// condition, matching every document.
+func and(conditions []bson.D) bson.D {
+	switch len(conditions) {
+	case 0:
+		return bson.D{}
+	case 1:
+		return conditions[0]
+	default:
+		return bson.D{{Key: "$and", Value: toArray(conditions)}}
+	}
+}
+
+func toArray(conditions []bson.D) bson.A {
+	array := make(bson.A, len(conditions))
+	for i, cond := range conditions {
+		array[i] = cond
+	}
+
+	return array
+}
+
+// isCollection reports whether the value is a slice, other than bytes, or an array.
+func isCollection(value any) bool {
+	v := reflect.ValueOf(value)
+
+	switch v.Kind() {
+	case reflect.Slice:
+		return v.Type().Elem().Kind() != reflect.Uint8
+	case reflect.Array:
+		return v.Type() != reflect.TypeOf(primitive.ObjectID{})
+	default:
+		return false
+	}
+}
@@ -0,0 +1,166 @@
+package mongoquery_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/infevocorp/goflexstore/filters"
+	mongoquery "github.com/infevocorp/goflexstore/mongo/query"
+	"github.com/infevocorp/goflexstore/query"
+)
+
+type Article struct {
+	ID       primitive.ObjectID `bson:"_id,omitempty"`
+	Title    string             `bson:"title"`
+	AuthorID int64              `bson:"author_id"`
+	Views    int
+	Secret   string `bson:"-"`
+}
+
+func newBuilder() *mongoquery.Builder {
+	return mongoquery.NewBuilder(mongoquery.WithFieldToKeyMap(mongoquery.FieldToKeyMap(&Article{})))
+}
+
+func Test_FieldToKeyMap(t *testing.T) {
+	assert.Equal(t, map[string]string{
+		"ID":       "_id",
+		"Title":    "title",
+		"AuthorID": "author_id",
+		"Views":    "views",
+	}, mongoquery.FieldToKeyMap(&Article{}))
+}
+
+func Test_Builder_Build(t *testing.T) {
+	tests := []struct {
+		name   string
+		params []query.Param
+		want   mongoquery.Query
+	}{
+		{
+			name: "no-param",
+			want: mongoquery.Query{Filter: bson.D{}},
+		},
+		{
+			name:   "equal",
+			params: []query.Param{query.Filter("Title", "go")},
+			want:   mongoquery.Query{Filter: bson.D{{Key: "title", Value: "go"}}},
+		},
+		{
+			name:   "ids",
+			params: []query.Param{filters.IDs(1, 2)},
+			want: mongoquery.Query{Filter: bson.D{
+				{Key: "_id", Value: bson.D{{Key: "$in", Value: []int{1, 2}}}},
+			}},
+		},
+		{
+			name:   "not-in",
+			params: []query.Param{query.Filter("Views", []int{1}).WithOP(query.NEQ)},
+			want: mongoquery.Query{Filter: bson.D{
+				{Key: "views", Value: bson.D{{Key: "$nin", Value: []int{1}}}},
+			}},
+		},
+		{
+			name:   "null",
+			params: []query.Param{query.Filter("Title", nil).WithOP(query.NEQ)},
+			want: mongoquery.Query{Filter: bson.D{
+				{Key: "title", Value: bson.D{{Key: "$ne", Value: nil}}},
+			}},
+		},
+		{
+			name:   "ilike",
+			params: []query.Param{query.Filter("Title", "go_%.").WithOP(query.ILIKE)},
+			want: mongoquery.Query{Filter: bson.D{
+				{Key: "title", Value: primitive.Regex{Pattern: `^go..*\.$`, Options: "is"}},
+			}},
+		},
+		{
+			name: "and-of-range",
+			params: []query.Param{
+				query.Filter("Views", 10).WithOP(query.GTE),
+				query.Filter("Views", 20).WithOP(query.LT),
+			},
+			want: mongoquery.Query{Filter: bson.D{{Key: "$and", Value: bson.A{
+				bson.D{{Key: "views", Value: bson.D{{Key: "$gte", Value: 10}}}},
+				bson.D{{Key: "views", Value: bson.D{{Key: "$lt", Value: 20}}}},
+			}}}},
+		},
+		{
+			name: "or",
+			params: []query.Param{query.OR(
+				query.Filter("Title", "go"),
+				query.Filter("AuthorID", int64(1)),
+			)},
+			want: mongoquery.Query{Filter: bson.D{{Key: "$or", Value: bson.A{
+				bson.D{{Key: "title", Value: "go"}},
+				bson.D{{Key: "author_id", Value: int64(1)}},
+			}}}},
+		},
+		{
+			name: "not-and-empty-group",
+			params: []query.Param{
+				query.Not(query.Filter("Title", "go")),
+				query.AND(),
+			},
+			want: mongoquery.Query{Filter: bson.D{{Key: "$nor", Value: bson.A{
+				bson.D{{Key: "title", Value: "go"}},
+			}}}},
+		},
+		{
+			name:   "full-text",
+			params: []query.Param{query.FullText("mongo driver", "Title")},
+			want: mongoquery.Query{Filter: bson.D{
+				{Key: "$text", Value: bson.D{{Key: "$search", Value: "mongo driver"}}},
+			}},
+		},
+		{
+			name: "sort-page-and-projection",
+			params: []query.Param{
+				query.OrderBy("Views", true),
+				query.OrderBy("ID", false),
+				query.Paginate(20, 10),
+				query.Select("Title", "Views"),
+			},
+			want: mongoquery.Query{
+				Filter:     bson.D{},
+				Sort:       bson.D{{Key: "views", Value: -1}, {Key: "_id", Value: 1}},
+				Projection: bson.D{{Key: "title", Value: 1}, {Key: "views", Value: 1}},
+				Skip:       20,
+				Limit:      10,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newBuilder().Build(query.NewParams(tt.params...))
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_Builder_Build_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		param query.Param
+	}{
+		{name: "raw", param: query.Raw("views > ?", 10)},
+		{name: "in-without-collection", param: query.Filter("Views", 1).WithOP(query.IN)},
+		{name: "empty-collection", param: query.Filter("Views", []int{})},
+		{name: "greater-than-collection", param: query.Filter("Views", []int{1}).WithOP(query.GT)},
+		{name: "like-number", param: query.Filter("Views", 1).WithOP(query.LIKE)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newBuilder().Build(query.NewParams(tt.param))
+
+			assert.ErrorIs(t, err, mongoquery.ErrInvalidParam)
+		})
+	}
+}
@@ -0,0 +1,16 @@
+// Package mongoquery translates the query params of goflexstore into the BSON documents of the MongoDB driver.
+//
+// The Builder turns query.Params into a Query holding the filter, sort, projection, skip and limit of a find
+// command:
+//   - Filters become field conditions: EQ and NEQ with a collection value become $in and $nin, nil values match
+//     null or missing fields, and LIKE and ILIKE patterns become anchored regular expressions.
+//   - OR params become $or, groups $and or $or, and not params $nor.
+//   - Full-text params become a $text search, which requires a text index on the collection.
+//   - OrderBy params become the sort, Paginate params the skip and limit, and Select params the projection.
+//
+// Raw params hold SQL and cannot be translated: they make Build fail. Other params (preload, group by, locks...)
+// are ignored.
+//
+// Field names are mapped to the keys of the documents through FieldToKeyMap, which is built from the bson tags
+// of the DTO by FieldToKeyMap, so that filters.IDs matches the _id key. Unmapped names are used as keys.
+package mongoquery
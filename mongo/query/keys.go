@@ -0,0 +1,76 @@
+package mongoquery
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// fieldToKeyMaps caches the field to key maps by struct type.
+var fieldToKeyMaps sync.Map
+
+// FieldToKeyMap creates a map of the struct field names of the DTO to the keys of their BSON documents.
+//
+// The key of a field is the name of its bson tag, or the lowercased field name like the driver does when the
+// tag has no name. The fields tagged with bson:"-" are skipped.
+//
+// Example:
+//
+//	type Article struct {
+//		ID    primitive.ObjectID `bson:"_id,omitempty"`
+//		Title string             `bson:"title"`
+//		Views int
+//	}
+//
+//	index := FieldToKeyMap(Article{})
+//	// map[ID:_id Title:title Views:views]
+//
+// The map is only built once per struct type, subsequent calls return a copy of the cached map
+// that can be modified freely.
+func FieldToKeyMap(dto any) map[string]string {
+	t := reflect.TypeOf(dto)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == nil || t.Kind() != reflect.Struct {
+		return map[string]string{}
+	}
+
+	cached, ok := fieldToKeyMaps.Load(t)
+	if !ok {
+		cached, _ = fieldToKeyMaps.LoadOrStore(t, buildFieldToKeyMap(t))
+	}
+
+	index := make(map[string]string, len(cached.(map[string]string)))
+
+	for field, key := range cached.(map[string]string) {
+		index[field] = key
+	}
+
+	return index
+}
+
+func buildFieldToKeyMap(t reflect.Type) map[string]string {
+	index := make(map[string]string, t.NumField())
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("bson"), ",")
+
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = strings.ToLower(field.Name)
+		}
+
+		index[field.Name] = name
+	}
+
+	return index
+}
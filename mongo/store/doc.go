@@ -0,0 +1,24 @@
+// Package mongostore provides a MongoDB implementation of the store interface, on top of the official driver.
+//
+// The Store reads and writes the DTOs of the entities as the documents of a collection. The query params are
+// translated into BSON documents by a mongoquery.Builder, whose field names are mapped to the keys of the
+// documents through the bson tags of the DTO, see mongoquery.FieldToKeyMap.
+//
+// The ID of the DTO must be its _id key. Tagged `bson:"_id,omitempty"`, a zero ID is left to the driver, which
+// generates an ObjectID, returned by Create as the ID when ID is primitive.ObjectID or string.
+//
+// The operations are run with the session carried by their context, so that they join the transaction begun
+// by a mongoopscope.TransactionScope.
+//
+// Example:
+//
+//	type Article struct {
+//		ID     primitive.ObjectID `bson:"_id,omitempty"`
+//		Title  string             `bson:"title"`
+//		Status string             `bson:"status"`
+//	}
+//
+//	articleStore := mongostore.NewSimple[*Article, primitive.ObjectID](db.Collection("articles"))
+//
+//	active, err := articleStore.List(ctx, query.Filter("Status", "active"), query.OrderBy("Title", false))
+package mongostore
@@ -0,0 +1,175 @@
+package mongostore
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	mongoquery "github.com/infevocorp/goflexstore/mongo/query"
+)
+
+// idKey is the key of the IDs of the documents.
+const idKey = "_id"
+
+// document encodes the DTO into an ordered document.
+func document(dto any) (bson.D, error) {
+	data, err := bson.Marshal(dto)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc bson.D
+	if err := bson.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+// lookup returns the value of the key of the document.
+func lookup(doc bson.D, key string) (any, bool) {
+	for _, e := range doc {
+		if e.Key == key {
+			return e.Value, true
+		}
+	}
+
+	return nil, false
+}
+
+// without returns the elements of the document whose key is not one of keys.
+func without(doc bson.D, keys ...string) bson.D {
+	kept := make(bson.D, 0, len(doc))
+
+	for _, e := range doc {
+		excluded := false
+
+		for _, key := range keys {
+			excluded = excluded || e.Key == key
+		}
+
+		if !excluded {
+			kept = append(kept, e)
+		}
+	}
+
+	return kept
+}
+
+// toID converts the ID of a document, as returned by the driver, to ID: ObjectIDs to their hexadecimal string and
+// numbers to the numeric ID types.
+func toID[ID any](value any) (ID, error) {
+	if id, ok := value.(ID); ok {
+		return id, nil
+	}
+
+	idType := reflect.TypeOf((*ID)(nil)).Elem()
+
+	if oid, ok := value.(primitive.ObjectID); ok && idType.Kind() == reflect.String {
+		return reflect.ValueOf(oid.Hex()).Convert(idType).Interface().(ID), nil
+	}
+
+	v := reflect.ValueOf(value)
+	if v.IsValid() && isNumber(v.Kind()) && isNumber(idType.Kind()) {
+		return v.Convert(idType).Interface().(ID), nil
+	}
+
+	return *new(ID), fmt.Errorf("mongostore: cannot convert ID %v of type %T to %s", value, value, idType)
+}
+
+// setID sets the field of the DTO encoded as the _id key to id.
+func setID[DTO, ID any](dto *DTO, id ID) error {
+	v := reflect.ValueOf(dto).Elem()
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return fmt.Errorf("mongostore: cannot set the ID of a nil %T", *dto)
+		}
+
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return fmt.Errorf("mongostore: DTO %T is not a struct", *dto)
+	}
+
+	for i := 0; i < v.NumField(); i++ {
+		if name, _, _ := strings.Cut(v.Type().Field(i).Tag.Get("bson"), ","); name != idKey {
+			continue
+		}
+
+		field := v.Field(i)
+		if !reflect.TypeOf(id).AssignableTo(field.Type()) {
+			return fmt.Errorf("mongostore: cannot set %T ID to field %s", id, v.Type().Field(i).Name)
+		}
+
+		field.Set(reflect.ValueOf(id))
+
+		return nil
+	}
+
+	return fmt.Errorf("mongostore: DTO %T has no field tagged bson:\"_id\"", *dto)
+}
+
+// toFloat converts a number of an aggregation result to float64, 0 for null.
+func toFloat(value any) float64 {
+	if d, ok := value.(primitive.Decimal128); ok {
+		if f, ok := new(big.Float).SetString(d.String()); ok {
+			result, _ := f.Float64()
+			return result
+		}
+
+		return 0
+	}
+
+	v := reflect.ValueOf(value)
+
+	switch {
+	case !v.IsValid():
+		return 0
+	case v.CanInt():
+		return float64(v.Int())
+	case v.CanUint():
+		return float64(v.Uint())
+	case v.CanFloat():
+		return v.Float()
+	default:
+		return 0
+	}
+}
+
+func isNumber(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// zeroKeys returns the keys of the zero fields of the DTO.
+func zeroKeys(dto any) []string {
+	v := reflect.ValueOf(dto)
+	for v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var keys []string
+
+	for field, key := range mongoquery.FieldToKeyMap(dto) {
+		if v.FieldByName(field).IsZero() {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
@@ -0,0 +1,56 @@
+package mongostore
+
+import (
+	"github.com/infevocorp/goflexstore/converter"
+	"github.com/infevocorp/goflexstore/idgen"
+	mongoquery "github.com/infevocorp/goflexstore/mongo/query"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// Option is a function that modifies the store.
+// It is used to set various configuration options for the Store at the time of its creation.
+type Option[Entity store.Entity[ID], DTO store.Entity[ID], ID comparable] func(*Store[Entity, DTO, ID])
+
+// WithConverter sets the converter used for transforming between entity and DTO types.
+func WithConverter[
+	Entity store.Entity[ID],
+	DTO store.Entity[ID],
+	ID comparable,
+](
+	converter converter.Converter[Entity, DTO, ID],
+) Option[Entity, DTO, ID] {
+	return func(s *Store[Entity, DTO, ID]) {
+		s.Converter = converter
+	}
+}
+
+// WithQueryBuilderOption sets the options of the query builder of the store, e.g. to map more field names.
+func WithQueryBuilderOption[
+	Entity store.Entity[ID],
+	DTO store.Entity[ID],
+	ID comparable,
+](
+	options ...mongoquery.Option,
+) Option[Entity, DTO, ID] {
+	return func(s *Store[Entity, DTO, ID]) {
+		s.QueryBuilder = newQueryBuilder[DTO](options...)
+	}
+}
+
+// WithIDGenerator sets the generator of the IDs of the entities created with a zero ID by Create, CreateMany and
+// Upsert. Without generator, the driver generates an ObjectID for the DTOs whose _id is omitted when empty.
+//
+// Example:
+//
+//	mongostore.WithIDGenerator[*model.Article, *dto.Article, string](idgen.UUIDv7[string])
+func WithIDGenerator[
+	Entity store.Entity[ID],
+	DTO store.Entity[ID],
+	ID comparable,
+](
+	generator idgen.Generator[ID],
+) Option[Entity, DTO, ID] {
+	return func(s *Store[Entity, DTO, ID]) {
+		s.IDGenerator = generator
+	}
+}
@@ -0,0 +1,626 @@
+package mongostore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/infevocorp/goflexstore/converter"
+	"github.com/infevocorp/goflexstore/idgen"
+	mongoquery "github.com/infevocorp/goflexstore/mongo/query"
+	"github.com/infevocorp/goflexstore/query"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// New initializes a new Store reading and writing the entities as the documents of the collection.
+//
+// Entity and DTO are types that must implement the store.Entity interface.
+// ID is the type of the identifier for the entities.
+func New[Entity store.Entity[ID], DTO store.Entity[ID], ID comparable](
+	collection *mongo.Collection,
+	options ...Option[Entity, DTO, ID],
+) *Store[Entity, DTO, ID] {
+	s := &Store[Entity, DTO, ID]{
+		Collection: collection,
+	}
+
+	for _, option := range options {
+		option(s)
+	}
+
+	if s.Converter == nil {
+		if converter.SameType[Entity, DTO]() {
+			s.Converter = converter.NewIdentity[Entity, DTO, ID]()
+		} else {
+			s.Converter = converter.NewReflect[Entity, DTO, ID](nil)
+		}
+	}
+
+	if s.IDGenerator == nil && idgen.IsUUID[ID]() {
+		s.IDGenerator = idgen.UUIDv7[ID]
+	}
+
+	if s.QueryBuilder == nil {
+		s.QueryBuilder = newQueryBuilder[DTO]()
+	}
+
+	return s
+}
+
+// NewSimple initializes a new Store for entities which are also the DTOs, stored as they are.
+//
+// Example:
+//
+//	articleStore := mongostore.NewSimple[*model.Article, primitive.ObjectID](db.Collection("articles"))
+func NewSimple[T store.Entity[ID], ID comparable](
+	collection *mongo.Collection,
+	options ...Option[T, T, ID],
+) *Store[T, T, ID] {
+	return New[T, T, ID](collection, options...)
+}
+
+// newQueryBuilder returns the default query builder of the stores of DTO.
+func newQueryBuilder[DTO any](options ...mongoquery.Option) *mongoquery.Builder {
+	return mongoquery.NewBuilder(append([]mongoquery.Option{
+		mongoquery.WithFieldToKeyMap(mongoquery.FieldToKeyMap(*new(DTO))),
+	}, options...)...)
+}
+
+// Store represents a storage mechanism using a MongoDB collection.
+// It supports CRUD operations and is designed to be generic for any Entity and DTO types.
+//
+// Entity: The domain model type.
+// DTO: The data transfer object type, representing the documents of the collection.
+// ID: The type of the unique identifier for the entity.
+type Store[Entity store.Entity[ID], DTO store.Entity[ID], ID comparable] struct {
+	Collection   *mongo.Collection
+	Converter    converter.Converter[Entity, DTO, ID]
+	QueryBuilder *mongoquery.Builder
+	IDGenerator  idgen.Generator[ID]
+}
+
+// Get retrieves the first document matching the params, or store.ErrNotFound, which mongo.ErrNoDocuments is
+// translated to.
+func (s *Store[Entity, DTO, ID]) Get(ctx context.Context, params ...query.Param) (Entity, error) {
+	q, err := s.QueryBuilder.Build(query.NewParams(params...))
+	if err != nil {
+		return *new(Entity), err
+	}
+
+	var dto DTO
+
+	err = s.Collection.FindOne(ctx, q.Filter, options.FindOne().
+		SetSort(sortOf(q)).
+		SetProjection(projectionOf(q)).
+		SetSkip(q.Skip),
+	).Decode(&dto)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return *new(Entity), store.ErrNotFound
+	}
+
+	if err != nil {
+		return *new(Entity), err
+	}
+
+	return s.Converter.ToEntity(dto), nil
+}
+
+// List retrieves the documents matching the params, sorted, paginated and projected accordingly.
+func (s *Store[Entity, DTO, ID]) List(ctx context.Context, params ...query.Param) ([]Entity, error) {
+	q, err := s.QueryBuilder.Build(query.NewParams(params...))
+	if err != nil {
+		return nil, err
+	}
+
+	return s.find(ctx, q)
+}
+
+// ListWithCount retrieves the documents matching the params, like List, together with the number of matching
+// documents, counted without the Paginate params.
+func (s *Store[Entity, DTO, ID]) ListWithCount(ctx context.Context, params ...query.Param) ([]Entity, int64, error) {
+	q, err := s.QueryBuilder.Build(query.NewParams(params...))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	count, err := s.Collection.CountDocuments(ctx, q.Filter)
+	if err != nil || count == 0 {
+		return nil, count, err
+	}
+
+	entities, err := s.find(ctx, q)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return entities, count, nil
+}
+
+// Count returns the number of documents matching the filters of the params.
+func (s *Store[Entity, DTO, ID]) Count(ctx context.Context, params ...query.Param) (int64, error) {
+	q, err := s.QueryBuilder.Build(query.NewParams(params...))
+	if err != nil {
+		return 0, err
+	}
+
+	return s.Collection.CountDocuments(ctx, q.Filter)
+}
+
+// Aggregate computes the aggregate function over the key of the field of the documents matching the params,
+// see AggregateMany.
+func (s *Store[Entity, DTO, ID]) Aggregate(
+	ctx context.Context,
+	agg store.Aggregation,
+	field string,
+	params ...query.Param,
+) (float64, error) {
+	results, err := s.AggregateMany(ctx, []store.Aggregate{{Aggregation: agg, Field: field}}, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return results[0], nil
+}
+
+// AggregateMany computes the aggregates of the documents matching the filters of the params in a single
+// $match and $group pipeline. A null aggregate, e.g. the sum of no document, is returned as 0. An empty field
+// counts the documents.
+func (s *Store[Entity, DTO, ID]) AggregateMany(
+	ctx context.Context,
+	aggs []store.Aggregate,
+	params ...query.Param,
+) ([]float64, error) {
+	if len(aggs) == 0 {
+		return nil, fmt.Errorf("%w: no aggregate", mongoquery.ErrInvalidParam)
+	}
+
+	q, err := s.QueryBuilder.Build(query.NewParams(params...))
+	if err != nil {
+		return nil, err
+	}
+
+	group := bson.D{{Key: idKey, Value: nil}}
+
+	for i, agg := range aggs {
+		expr, err := s.accumulator(agg)
+		if err != nil {
+			return nil, err
+		}
+
+		group = append(group, bson.E{Key: fmt.Sprintf("a%d", i), Value: expr})
+	}
+
+	cursor, err := s.Collection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: q.Filter}},
+		{{Key: "$group", Value: group}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []bson.M
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, err
+	}
+
+	results := make([]float64, len(aggs))
+
+	if len(rows) > 0 {
+		for i := range aggs {
+			results[i] = toFloat(rows[0][fmt.Sprintf("a%d", i)])
+		}
+	}
+
+	return results, nil
+}
+
+// accumulator returns the $group accumulator of the aggregate.
+func (s *Store[Entity, DTO, ID]) accumulator(agg store.Aggregate) (bson.D, error) {
+	field := "$" + s.key(agg.Field)
+
+	switch agg.Aggregation {
+	case store.Sum:
+		return bson.D{{Key: "$sum", Value: field}}, nil
+	case store.Avg:
+		return bson.D{{Key: "$avg", Value: field}}, nil
+	case store.Min:
+		return bson.D{{Key: "$min", Value: field}}, nil
+	case store.Max:
+		return bson.D{{Key: "$max", Value: field}}, nil
+	case store.CountOf:
+		if agg.Field == "" {
+			return bson.D{{Key: "$sum", Value: 1}}, nil
+		}
+
+		// null and missing values sort before any other value.
+		return bson.D{{Key: "$sum", Value: bson.D{{Key: "$cond", Value: bson.A{
+			bson.D{{Key: "$gt", Value: bson.A{field, nil}}}, 1, 0,
+		}}}}}, nil
+	default:
+		return nil, fmt.Errorf("%w: unsupported aggregation %s", mongoquery.ErrInvalidParam, agg.Aggregation)
+	}
+}
+
+// Exists reports whether a document matches the filters of the params.
+func (s *Store[Entity, DTO, ID]) Exists(ctx context.Context, params ...query.Param) (bool, error) {
+	q, err := s.QueryBuilder.Build(query.NewParams(params...))
+	if err != nil {
+		return false, err
+	}
+
+	count, err := s.Collection.CountDocuments(ctx, q.Filter, options.Count().SetLimit(1))
+
+	return count > 0, err
+}
+
+// Create inserts the entity and returns its ID, generated by the IDGenerator of the store or the driver when it
+// is zero.
+func (s *Store[Entity, DTO, ID]) Create(ctx context.Context, entity Entity) (ID, error) {
+	dtos := []DTO{s.Converter.ToDTO(entity)}
+	if err := s.generateIDs(ctx, dtos); err != nil {
+		return *new(ID), err
+	}
+
+	result, err := s.Collection.InsertOne(ctx, dtos[0])
+	if err != nil {
+		return *new(ID), err
+	}
+
+	return toID[ID](result.InsertedID)
+}
+
+// CreateMany inserts the entities in a single command.
+func (s *Store[Entity, DTO, ID]) CreateMany(ctx context.Context, entities []Entity) error {
+	if len(entities) == 0 {
+		return nil
+	}
+
+	dtos := converter.ToMany(entities, s.Converter.ToDTO)
+	if err := s.generateIDs(ctx, dtos); err != nil {
+		return err
+	}
+
+	documents := make([]any, len(dtos))
+	for i := range dtos {
+		documents[i] = dtos[i]
+	}
+
+	_, err := s.Collection.InsertMany(ctx, documents)
+
+	return err
+}
+
+// Upsert inserts the entity, or updates the document having the same values of the keys of onConflict.Columns,
+// the _id by default.
+//
+// With onConflict.DoNothing, an existing document is left unchanged. onConflict.Updates and
+// onConflict.UpdateColumns restrict the update to the given keys, the whole document being updated otherwise.
+// The filters of onConflict.UpdateWhere are added to the filter of the existing document, an Excluded value
+// referring to the key of the upserted document: when the existing document does not satisfy them, the insertion
+// fails with a duplicate key error on the unique index of the keys, which is ignored. onConflict.OnConstraint is
+// ignored.
+func (s *Store[Entity, DTO, ID]) Upsert(ctx context.Context, entity Entity, onConflict store.OnConflict) (ID, error) {
+	dtos := []DTO{s.Converter.ToDTO(entity)}
+	if err := s.generateIDs(ctx, dtos); err != nil {
+		return *new(ID), err
+	}
+
+	doc, err := document(dtos[0])
+	if err != nil {
+		return *new(ID), err
+	}
+
+	conflict, ok := s.conflictFilter(doc, onConflict)
+
+	// without the values of the keys, the document cannot conflict with an existing one.
+	if !ok {
+		result, err := s.Collection.InsertOne(ctx, dtos[0])
+		if err != nil {
+			return *new(ID), err
+		}
+
+		return toID[ID](result.InsertedID)
+	}
+
+	where, err := s.updateWhere(doc, onConflict.UpdateWhere)
+	if err != nil {
+		return *new(ID), err
+	}
+
+	filter := append(conflict, where...)
+	update := s.upsertUpdate(doc, conflict, onConflict)
+
+	result, err := s.Collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if len(onConflict.UpdateWhere) > 0 && mongo.IsDuplicateKeyError(err) {
+		return dtos[0].GetID(), nil
+	}
+
+	if err != nil {
+		return *new(ID), err
+	}
+
+	if result.UpsertedID != nil {
+		return toID[ID](result.UpsertedID)
+	}
+
+	return dtos[0].GetID(), nil
+}
+
+// conflictFilter returns the filter of the document having the values of the keys of the conflict of the upserted
+// one. It returns false when the upserted document has no value for one of the keys.
+func (s *Store[Entity, DTO, ID]) conflictFilter(doc bson.D, onConflict store.OnConflict) (bson.D, bool) {
+	keys := []string{idKey}
+	if len(onConflict.Columns) > 0 {
+		keys = make([]string, len(onConflict.Columns))
+		for i, col := range onConflict.Columns {
+			keys[i] = s.key(col)
+		}
+	}
+
+	filter := make(bson.D, 0, len(keys))
+
+	for _, key := range keys {
+		value, ok := lookup(doc, key)
+		if !ok {
+			return nil, false
+		}
+
+		filter = append(filter, bson.E{Key: key, Value: value})
+	}
+
+	return filter, true
+}
+
+// updateWhere returns the conditions of the UpdateWhere filters, an Excluded value referring to the key of the
+// upserted document.
+func (s *Store[Entity, DTO, ID]) updateWhere(doc bson.D, filters []query.FilterParam) (bson.D, error) {
+	var where bson.D
+
+	for _, filter := range filters {
+		if excluded, ok := filter.Value.(store.ExcludedColumn); ok {
+			filter.Value, _ = lookup(doc, s.key(string(excluded)))
+		}
+
+		cond, _, err := s.QueryBuilder.Condition(filter)
+		if err != nil {
+			return nil, err
+		}
+
+		where = append(where, cond...)
+	}
+
+	return where, nil
+}
+
+// upsertUpdate returns the update setting the keys to update on conflict, and the others on insert only, but the
+// keys of the conflict, which the inserted document gets from the equality conditions of the conflict filter.
+func (s *Store[Entity, DTO, ID]) upsertUpdate(doc, conflict bson.D, onConflict store.OnConflict) bson.D {
+	var set bson.D
+
+	switch {
+	case onConflict.DoNothing:
+	case len(onConflict.Updates) > 0:
+		for name, value := range onConflict.Updates {
+			set = append(set, bson.E{Key: s.key(name), Value: value})
+		}
+	case len(onConflict.UpdateColumns) > 0:
+		for _, col := range onConflict.UpdateColumns {
+			value, _ := lookup(doc, s.key(col))
+			set = append(set, bson.E{Key: s.key(col), Value: value})
+		}
+	default:
+		set = without(doc, idKey)
+	}
+
+	setKeys := make([]string, 0, len(set)+len(conflict))
+	for _, e := range append(set, conflict...) {
+		setKeys = append(setKeys, e.Key)
+	}
+
+	var update bson.D
+
+	if len(set) > 0 {
+		update = append(update, bson.E{Key: "$set", Value: set})
+	}
+
+	if onInsert := without(doc, setKeys...); len(onInsert) > 0 {
+		update = append(update, bson.E{Key: "$setOnInsert", Value: onInsert})
+	}
+
+	return update
+}
+
+// Update replaces the fields of the documents matching the filters of the params with the ones of the entity,
+// its _id excepted. Without filters, the document having the ID of the entity is replaced.
+func (s *Store[Entity, DTO, ID]) Update(ctx context.Context, entity Entity, params ...query.Param) error {
+	doc, err := document(s.Converter.ToDTO(entity))
+	if err != nil {
+		return err
+	}
+
+	return s.update(ctx, entity, without(doc, idKey), params)
+}
+
+// PartialUpdate sets the non-zero fields of the entity on the documents matching the filters of the params.
+// Without filters, the document having the ID of the entity is updated.
+func (s *Store[Entity, DTO, ID]) PartialUpdate(ctx context.Context, entity Entity, params ...query.Param) error {
+	dto := s.Converter.ToDTO(entity)
+
+	doc, err := document(dto)
+	if err != nil {
+		return err
+	}
+
+	return s.update(ctx, entity, without(doc, append(zeroKeys(dto), idKey)...), params)
+}
+
+// update sets the fields on the documents matching the filters of the params, or on the document having the ID
+// of the entity without filters.
+func (s *Store[Entity, DTO, ID]) update(ctx context.Context, entity Entity, set bson.D, params []query.Param) error {
+	if len(set) == 0 {
+		return nil
+	}
+
+	q, err := s.QueryBuilder.Build(query.NewParams(params...))
+	if err != nil {
+		return err
+	}
+
+	if len(q.Filter) == 0 {
+		q.Filter = bson.D{{Key: idKey, Value: entity.GetID()}}
+	}
+
+	_, err = s.Collection.UpdateMany(ctx, q.Filter, bson.D{{Key: "$set", Value: set}})
+
+	return err
+}
+
+// UpdateMany sets the fields of updates, whose names are mapped to keys like the filters, on the documents
+// matching the filters of the params, and returns the number of matching documents.
+func (s *Store[Entity, DTO, ID]) UpdateMany(
+	ctx context.Context,
+	updates map[string]any,
+	params ...query.Param,
+) (int64, error) {
+	q, err := s.QueryBuilder.Build(query.NewParams(params...))
+	if err != nil {
+		return 0, err
+	}
+
+	if len(updates) == 0 {
+		return 0, nil
+	}
+
+	set := make(bson.D, 0, len(updates))
+	for name, value := range updates {
+		set = append(set, bson.E{Key: s.key(name), Value: value})
+	}
+
+	result, err := s.Collection.UpdateMany(ctx, q.Filter, bson.D{{Key: "$set", Value: set}})
+	if err != nil {
+		return 0, err
+	}
+
+	return result.MatchedCount, nil
+}
+
+// Delete deletes the documents matching the filters of the params.
+func (s *Store[Entity, DTO, ID]) Delete(ctx context.Context, params ...query.Param) error {
+	q, err := s.QueryBuilder.Build(query.NewParams(params...))
+	if err != nil {
+		return err
+	}
+
+	_, err = s.Collection.DeleteMany(ctx, q.Filter)
+
+	return err
+}
+
+// DeleteReturning deletes the documents matching the filters of the params and returns them.
+// The documents are found, then deleted by _id: run it in a transaction for the documents changed in between
+// not to be deleted without being returned.
+func (s *Store[Entity, DTO, ID]) DeleteReturning(ctx context.Context, params ...query.Param) ([]Entity, error) {
+	q, err := s.QueryBuilder.Build(query.NewParams(params...))
+	if err != nil {
+		return nil, err
+	}
+
+	q.Projection = nil
+
+	entities, err := s.find(ctx, q)
+	if err != nil || len(entities) == 0 {
+		return nil, err
+	}
+
+	ids := make(bson.A, len(entities))
+	for i, entity := range entities {
+		ids[i] = s.Converter.ToDTO(entity).GetID()
+	}
+
+	if _, err := s.Collection.DeleteMany(ctx, bson.D{{Key: idKey, Value: bson.D{{Key: "$in", Value: ids}}}}); err != nil {
+		return nil, err
+	}
+
+	return entities, nil
+}
+
+// Ping checks that the deployment of the collection is reachable.
+func (s *Store[Entity, DTO, ID]) Ping(ctx context.Context) error {
+	return s.Collection.Database().Client().Ping(ctx, nil)
+}
+
+// find returns the entities of the documents matching the query.
+func (s *Store[Entity, DTO, ID]) find(ctx context.Context, q mongoquery.Query) ([]Entity, error) {
+	opts := options.Find().
+		SetSort(sortOf(q)).
+		SetProjection(projectionOf(q)).
+		SetSkip(q.Skip).
+		SetLimit(q.Limit)
+
+	cursor, err := s.Collection.Find(ctx, q.Filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var dtos []DTO
+	if err := cursor.All(ctx, &dtos); err != nil {
+		return nil, err
+	}
+
+	return converter.ToMany(dtos, s.Converter.ToEntity), nil
+}
+
+// generateIDs sets an ID returned by the IDGenerator of the store to the DTOs whose ID is zero.
+func (s *Store[Entity, DTO, ID]) generateIDs(ctx context.Context, dtos []DTO) error {
+	if s.IDGenerator == nil {
+		return nil
+	}
+
+	for i := range dtos {
+		if dtos[i].GetID() != *new(ID) {
+			continue
+		}
+
+		id, err := s.IDGenerator(ctx)
+		if err != nil {
+			return err
+		}
+
+		if err := setID(&dtos[i], id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// key returns the document key of the field name.
+func (s *Store[Entity, DTO, ID]) key(name string) string {
+	if key, ok := s.QueryBuilder.FieldToKeyMap[name]; ok {
+		return key
+	}
+
+	return name
+}
+
+// sortOf returns the sort of the query, nil without sort so that the driver omits it.
+func sortOf(q mongoquery.Query) any {
+	if len(q.Sort) == 0 {
+		return nil
+	}
+
+	return q.Sort
+}
+
+// projectionOf returns the projection of the query, nil without projection so that the driver omits it.
+func projectionOf(q mongoquery.Query) any {
+	if len(q.Projection) == 0 {
+		return nil
+	}
+
+	return q.Projection
+}
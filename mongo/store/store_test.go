@@ -0,0 +1,235 @@
+package mongostore_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+
+	"github.com/infevocorp/goflexstore/filters"
+	mongostore "github.com/infevocorp/goflexstore/mongo/store"
+	"github.com/infevocorp/goflexstore/query"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+type Article struct {
+	ID     primitive.ObjectID `bson:"_id,omitempty"`
+	Title  string             `bson:"title"`
+	Status string             `bson:"status"`
+	Views  int                `bson:"views"`
+}
+
+func (a *Article) GetID() primitive.ObjectID {
+	return a.ID
+}
+
+// newStore returns a store of the collection of the mocked deployment.
+func newStore(mt *mtest.T) *mongostore.Store[*Article, *Article, primitive.ObjectID] {
+	return mongostore.NewSimple[*Article, primitive.ObjectID](mt.Coll)
+}
+
+// command returns the first command sent to the mocked deployment not returned yet.
+func command(mt *mtest.T) bson.Raw {
+	event := mt.GetStartedEvent()
+	require.NotNil(mt, event)
+
+	return event.Command
+}
+
+func Test_Store_Read(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	ns := "db.articles"
+	id := primitive.NewObjectID()
+
+	mt.Run("should-get-and-translate-not-found", func(mt *mtest.T) {
+		// GIVEN
+		s := newStore(mt)
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, ns, mtest.FirstBatch, bson.D{{Key: "_id", Value: id}, {Key: "title", Value: "go"}}),
+			mtest.CreateCursorResponse(0, ns, mtest.FirstBatch),
+		)
+
+		// WHEN
+		article, err := s.Get(context.Background(), filters.IDs(id))
+		_, notFoundErr := s.Get(context.Background(), query.Filter("Title", "unknown"))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, &Article{ID: id, Title: "go"}, article)
+		assert.ErrorIs(t, notFoundErr, store.ErrNotFound)
+	})
+
+	mt.Run("should-list-with-translated-params", func(mt *mtest.T) {
+		// GIVEN
+		s := newStore(mt)
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, ns, mtest.FirstBatch,
+			bson.D{{Key: "_id", Value: id}, {Key: "title", Value: "go"}, {Key: "views", Value: 10}},
+		))
+
+		// WHEN
+		articles, err := s.List(context.Background(),
+			query.Filter("Status", "active"),
+			query.OrderBy("Views", true),
+			query.Paginate(10, 5),
+			query.Select("Title", "Views"),
+		)
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, []*Article{{ID: id, Title: "go", Views: 10}}, articles)
+
+		cmd := command(mt)
+		assert.Equal(t, "active", cmd.Lookup("filter", "status").StringValue())
+		assert.Equal(t, int32(-1), cmd.Lookup("sort", "views").Int32())
+		assert.Equal(t, int32(1), cmd.Lookup("projection", "title").Int32())
+		assert.Equal(t, int64(10), cmd.Lookup("skip").Int64())
+		assert.Equal(t, int64(5), cmd.Lookup("limit").Int64())
+	})
+
+	mt.Run("should-count", func(mt *mtest.T) {
+		// GIVEN
+		s := newStore(mt)
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, ns, mtest.FirstBatch, bson.D{{Key: "n", Value: int32(3)}}))
+
+		// WHEN
+		count, err := s.Count(context.Background(), query.Filter("Views", 10).WithOP(query.GT))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), count)
+	})
+
+	mt.Run("should-aggregate", func(mt *mtest.T) {
+		// GIVEN
+		s := newStore(mt)
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, ns, mtest.FirstBatch,
+			bson.D{{Key: "_id", Value: nil}, {Key: "a0", Value: int64(60)}, {Key: "a1", Value: 20.5}, {Key: "a2", Value: nil}},
+		))
+
+		// WHEN
+		results, err := s.AggregateMany(context.Background(), []store.Aggregate{
+			{Aggregation: store.Sum, Field: "Views"},
+			{Aggregation: store.Avg, Field: "Views"},
+			{Aggregation: store.Max, Field: "Views"},
+		}, query.Filter("Status", "active"))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, []float64{60, 20.5, 0}, results)
+
+		group := command(mt).Lookup("pipeline").Array().Index(1).Value().Document().Lookup("$group")
+		assert.Equal(t, "$views", group.Document().Lookup("a0", "$sum").StringValue())
+	})
+
+	mt.Run("should-return-error-of-raw-param", func(mt *mtest.T) {
+		// GIVEN
+		s := newStore(mt)
+
+		// WHEN
+		_, err := s.List(context.Background(), query.Raw("views > ?", 10))
+
+		// THEN
+		assert.ErrorContains(t, err, "raw params cannot be translated")
+	})
+}
+
+func Test_Store_Write(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	ns := "db.articles"
+	id := primitive.NewObjectID()
+
+	mt.Run("should-create-with-generated-id", func(mt *mtest.T) {
+		// GIVEN
+		s := newStore(mt)
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+		// WHEN
+		createdID, err := s.Create(context.Background(), &Article{Title: "go"})
+
+		// THEN
+		require.NoError(t, err)
+		assert.False(t, createdID.IsZero())
+
+		doc := command(mt).Lookup("documents").Array().Index(0).Value().Document()
+		assert.Equal(t, createdID, doc.Lookup("_id").ObjectID())
+	})
+
+	mt.Run("should-upsert-by-id", func(mt *mtest.T) {
+		// GIVEN
+		s := newStore(mt)
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}, bson.E{Key: "nModified", Value: 1}))
+
+		// WHEN
+		upsertedID, err := s.Upsert(context.Background(), &Article{ID: id, Title: "go"}, store.OnConflict{
+			UpdateColumns: []string{"Title"},
+		})
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, id, upsertedID)
+
+		update := command(mt).Lookup("updates").Array().Index(0).Value().Document()
+		assert.Equal(t, id, update.Lookup("q", "_id").ObjectID())
+		assert.Equal(t, "go", update.Lookup("u", "$set", "title").StringValue())
+		assert.Equal(t, "", update.Lookup("u", "$setOnInsert", "status").StringValue())
+		assert.True(t, update.Lookup("upsert").Boolean())
+	})
+
+	mt.Run("should-partially-update-non-zero-fields", func(mt *mtest.T) {
+		// GIVEN
+		s := newStore(mt)
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}))
+
+		// WHEN
+		err := s.PartialUpdate(context.Background(), &Article{ID: id, Status: "active"})
+
+		// THEN
+		require.NoError(t, err)
+
+		update := command(mt).Lookup("updates").Array().Index(0).Value().Document()
+		assert.Equal(t, id, update.Lookup("q", "_id").ObjectID())
+		set, err := update.Lookup("u", "$set").Document().Elements()
+		require.NoError(t, err)
+		require.Len(t, set, 1)
+		assert.Equal(t, "active", set[0].Value().StringValue())
+	})
+
+	mt.Run("should-update-many", func(mt *mtest.T) {
+		// GIVEN
+		s := newStore(mt)
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 2}, bson.E{Key: "nModified", Value: 1}))
+
+		// WHEN
+		updated, err := s.UpdateMany(context.Background(), map[string]any{"Status": "archived"},
+			query.Filter("Status", "draft"))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), updated)
+	})
+
+	mt.Run("should-delete-returning-deleted-entities", func(mt *mtest.T) {
+		// GIVEN
+		s := newStore(mt)
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, ns, mtest.FirstBatch, bson.D{{Key: "_id", Value: id}, {Key: "status", Value: "draft"}}),
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}),
+		)
+
+		// WHEN
+		deleted, err := s.DeleteReturning(context.Background(), query.Filter("Status", "draft"))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, []*Article{{ID: id, Status: "draft"}}, deleted)
+
+		assert.Equal(t, "find", command(mt).Index(0).Key())
+
+		del := command(mt).Lookup("deletes").Array().Index(0).Value().Document()
+		assert.Equal(t, id, del.Lookup("q", "_id", "$in").Array().Index(0).Value().ObjectID())
+	})
+}
@@ -0,0 +1,28 @@
+// Package namedquery centralizes the complex queries reused across an application under names.
+//
+// Queries holds the named query definitions, registered once at startup: a Query function building the query
+// params from arguments, or a query.Template precompiled by query.Compile whose filter values are bound from the
+// arguments. Store decorates a store with the Named and NamedCount methods running the registered queries by name,
+// so that the services share a single definition of, e.g., the active articles of an author, and the definitions
+// are tested in one place.
+//
+// Example:
+//
+//	queries := namedquery.New()
+//	queries.RegisterTemplate("activeByAuthor", query.Compile(
+//		query.Filter("AuthorID", 0),
+//		query.Filter("Status", ""),
+//		query.OrderBy("CreatedAt", true),
+//	))
+//	queries.Register("recent", func(args ...any) (query.Params, error) {
+//		since, ok := args[0].(time.Time)
+//		if !ok {
+//			return query.Params{}, errors.New("recent expects a time.Time")
+//		}
+//
+//		return query.NewParams(query.Filter("CreatedAt", since).WithOP(query.GTE)), nil
+//	})
+//
+//	articles := namedquery.NewStore[*model.Article, int64](articleStore, queries)
+//	active, err := articles.Named(ctx, "activeByAuthor", authorID, "active")
+package namedquery
@@ -0,0 +1,93 @@
+package namedquery
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/infevocorp/goflexstore/query"
+)
+
+// ErrUnknownQuery is returned when no query is registered under the requested name.
+var ErrUnknownQuery = errors.New("unknown named query")
+
+// Query builds the query params of a named query from its arguments.
+// It returns an error if the arguments are invalid, e.g. of an unexpected number or type.
+type Query func(args ...any) (query.Params, error)
+
+// Template returns a Query binding its arguments to the filter values of the template, see query.Template.Bind.
+func Template(t *query.Template) Query {
+	return func(args ...any) (query.Params, error) {
+		return t.Bind(args...)
+	}
+}
+
+// Queries holds named query definitions.
+// Queries are expected to be registered at startup. A Queries is safe for concurrent use.
+type Queries struct {
+	mu      sync.RWMutex
+	queries map[string]Query
+}
+
+// New creates an empty set of named queries.
+func New() *Queries {
+	return &Queries{
+		queries: make(map[string]Query),
+	}
+}
+
+// Register adds the query under the given name.
+// It panics if a query is already registered under the name, as names are expected to be unique.
+func (q *Queries) Register(name string, query Query) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if _, ok := q.queries[name]; ok {
+		panic(fmt.Sprintf("namedquery: query %q already registered", name))
+	}
+
+	q.queries[name] = query
+}
+
+// RegisterTemplate adds the precompiled template under the given name, see Register and Template.
+func (q *Queries) RegisterTemplate(name string, t *query.Template) {
+	q.Register(name, Template(t))
+}
+
+// Names returns the names of the registered queries, in lexical order.
+func (q *Queries) Names() []string {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	names := make([]string, 0, len(q.queries))
+	for name := range q.queries {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Params returns the query params of the named query built from the given arguments.
+// The params can be extended, e.g. with pagination, before running them with any store method.
+//
+// Returns:
+// The query params, or an error wrapping ErrUnknownQuery if no query is registered under the name.
+func (q *Queries) Params(name string, args ...any) (query.Params, error) {
+	q.mu.RLock()
+	build, ok := q.queries[name]
+	q.mu.RUnlock()
+
+	if !ok {
+		return query.Params{}, fmt.Errorf("%w: %s", ErrUnknownQuery, name)
+	}
+
+	params, err := build(args...)
+	if err != nil {
+		return query.Params{}, fmt.Errorf("namedquery %s: %w", name, err)
+	}
+
+	return params, nil
+}
@@ -0,0 +1,166 @@
+package namedquery_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infevocorp/goflexstore/namedquery"
+	"github.com/infevocorp/goflexstore/query"
+	"github.com/infevocorp/goflexstore/store"
+	"github.com/infevocorp/goflexstore/storetest"
+)
+
+type Article struct {
+	ID       int64
+	AuthorID int64
+	Status   string
+}
+
+func (a *Article) GetID() int64 {
+	return a.ID
+}
+
+func newQueries() *namedquery.Queries {
+	queries := namedquery.New()
+	queries.RegisterTemplate("activeByAuthor", query.Compile(
+		query.Filter("AuthorID", int64(0)),
+		query.Filter("Status", "active"),
+	))
+	queries.Register("byStatus", func(args ...any) (query.Params, error) {
+		if len(args) != 1 {
+			return query.Params{}, errors.New("byStatus expects a status")
+		}
+
+		return query.NewParams(query.Filter("Status", args[0])), nil
+	})
+
+	return queries
+}
+
+func Test_Queries(t *testing.T) {
+	t.Run("should-build-params-of-named-query", func(t *testing.T) {
+		// GIVEN
+		queries := newQueries()
+
+		// WHEN
+		params, err := queries.Params("byStatus", "draft")
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, query.NewParams(query.Filter("Status", "draft")), params)
+	})
+
+	t.Run("should-bind-arguments-of-template", func(t *testing.T) {
+		// GIVEN
+		queries := newQueries()
+
+		// WHEN
+		params, err := queries.Params("activeByAuthor", int64(7), "active")
+
+		// THEN
+		require.NoError(t, err)
+		assert.True(t, storetest.HasParam(query.Filter("AuthorID", int64(7))).Match(params))
+		assert.True(t, storetest.HasParam(query.Filter("Status", "active")).Match(params))
+
+		_, ok := params.Template()
+		assert.True(t, ok)
+	})
+
+	t.Run("should-return-error-for-unknown-query", func(t *testing.T) {
+		// GIVEN
+		queries := newQueries()
+
+		// WHEN
+		_, err := queries.Params("missing")
+
+		// THEN
+		assert.ErrorIs(t, err, namedquery.ErrUnknownQuery)
+	})
+
+	t.Run("should-return-error-for-invalid-arguments", func(t *testing.T) {
+		// GIVEN
+		queries := newQueries()
+
+		// WHEN
+		_, err := queries.Params("activeByAuthor", int64(7))
+
+		// THEN
+		assert.ErrorContains(t, err, "namedquery activeByAuthor: template expects 2 values, got 1")
+	})
+
+	t.Run("should-panic-on-duplicate-name", func(t *testing.T) {
+		// GIVEN
+		queries := newQueries()
+
+		// WHEN
+		register := func() {
+			queries.RegisterTemplate("byStatus", query.Compile(query.Filter("Status", "")))
+		}
+
+		// THEN
+		assert.PanicsWithValue(t, `namedquery: query "byStatus" already registered`, register)
+	})
+
+	t.Run("should-list-names-in-order", func(t *testing.T) {
+		// GIVEN
+		queries := newQueries()
+
+		// WHEN
+		names := queries.Names()
+
+		// THEN
+		assert.Equal(t, []string{"activeByAuthor", "byStatus"}, names)
+	})
+}
+
+func Test_Store(t *testing.T) {
+	seed := []*Article{
+		{ID: 1, AuthorID: 7, Status: "active"},
+		{ID: 2, AuthorID: 7, Status: "draft"},
+		{ID: 3, AuthorID: 8, Status: "active"},
+	}
+
+	t.Run("should-list-entities-of-named-query", func(t *testing.T) {
+		// GIVEN
+		fake := storetest.NewFake[*Article, int64](seed...)
+		articles := namedquery.NewStore[*Article, int64](fake, newQueries())
+
+		// WHEN
+		result, err := articles.Named(context.Background(), "activeByAuthor", int64(7), "active")
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, []*Article{seed[0]}, result)
+		fake.AssertCalled(t, store.MethodList, storetest.HasFilter("AuthorID", int64(7)))
+	})
+
+	t.Run("should-count-entities-of-named-query", func(t *testing.T) {
+		// GIVEN
+		fake := storetest.NewFake[*Article, int64](seed...)
+		articles := namedquery.NewStore[*Article, int64](fake, newQueries())
+
+		// WHEN
+		count, err := articles.NamedCount(context.Background(), "byStatus", "active")
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+	})
+
+	t.Run("should-not-call-store-for-unknown-query", func(t *testing.T) {
+		// GIVEN
+		fake := storetest.NewFake[*Article, int64](seed...)
+		articles := namedquery.NewStore[*Article, int64](fake, newQueries())
+
+		// WHEN
+		_, err := articles.Named(context.Background(), "missing")
+
+		// THEN
+		assert.ErrorIs(t, err, namedquery.ErrUnknownQuery)
+		assert.Empty(t, fake.Calls())
+	})
+}
@@ -0,0 +1,56 @@
+package namedquery
+
+import (
+	"context"
+
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// Store decorates a store with methods running the named queries of a Queries.
+// All the methods of the decorated store remain available.
+type Store[T store.Entity[ID], ID comparable] struct {
+	store.Store[T, ID]
+
+	queries *Queries
+}
+
+// NewStore creates a Store running the named queries of queries against inner.
+//
+// Example:
+//
+//	articles := namedquery.NewStore[*model.Article, int64](articleStore, queries)
+func NewStore[T store.Entity[ID], ID comparable](inner store.Store[T, ID], queries *Queries) *Store[T, ID] {
+	return &Store[T, ID]{
+		Store:   inner,
+		queries: queries,
+	}
+}
+
+// Queries returns the named queries run by the store.
+func (s *Store[T, ID]) Queries() *Queries {
+	return s.queries
+}
+
+// Named lists the entities matching the named query built from the given arguments.
+//
+// Example:
+//
+//	active, err := articles.Named(ctx, "activeByAuthor", authorID, "active")
+func (s *Store[T, ID]) Named(ctx context.Context, name string, args ...any) ([]T, error) {
+	params, err := s.queries.Params(name, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Store.List(ctx, params)
+}
+
+// NamedCount counts the entities matching the named query built from the given arguments.
+func (s *Store[T, ID]) NamedCount(ctx context.Context, name string, args ...any) (int64, error) {
+	params, err := s.queries.Params(name, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return s.Store.Count(ctx, params)
+}
@@ -31,3 +31,25 @@ type Scope interface {
 	// error, ensuring that panic-induced errors are properly handled and reported.
 	EndWithRecover(ctx context.Context, err *error)
 }
+
+// CommitNotifier is an optional interface implemented by scopes that can defer work until the
+// outermost operation scope has been committed successfully.
+//
+// It is typically used to trigger side effects, such as publishing events or invalidating caches,
+// that must not happen if the surrounding operation is rolled back.
+type CommitNotifier interface {
+	// OnCommit registers fn to be called once the outermost scope stored in ctx has been committed.
+	// If ctx does not carry an active scope, fn is called immediately.
+	OnCommit(ctx context.Context, fn func(ctx context.Context))
+}
+
+// RollbackNotifier is an optional interface implemented by scopes that can defer work until the
+// outermost operation scope has been rolled back.
+//
+// It is typically used to undo side effects performed outside of the scope, such as deleting an uploaded file,
+// when the surrounding operation fails.
+type RollbackNotifier interface {
+	// OnRollback registers fn to be called once the outermost scope stored in ctx has been rolled back,
+	// or has failed to commit. If ctx does not carry an active scope, fn is discarded.
+	OnRollback(ctx context.Context, fn func(ctx context.Context))
+}
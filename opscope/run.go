@@ -0,0 +1,61 @@
+package opscope
+
+import "context"
+
+// Run runs fn within the scope: it begins the scope, calls fn with the context of the scope and ends the scope
+// with the outcome of fn, recovering from a panic of fn to end the scope with it as error, see
+// Scope.EndWithRecover.
+//
+// Parameters:
+//   - ctx: The context the scope is begun from.
+//   - scope: The operation scope, e.g. a transaction scope.
+//   - fn: The operation, given the context of the scope.
+//
+// Returns:
+// The error of Begin, or the error of fn joined with the error of ending the scope.
+//
+// Example:
+// Creating an article and its first revision in a transaction:
+//
+//	err := opscope.Run(ctx, txScope, func(ctx context.Context) error {
+//		id, err := articleStore.Create(ctx, article)
+//		if err != nil {
+//			return err
+//		}
+//
+//		_, err = revisionStore.Create(ctx, &model.Revision{ArticleID: id, Body: article.Body})
+//
+//		return err
+//	})
+func Run(ctx context.Context, scope Scope, fn func(ctx context.Context) error) (err error) {
+	ctx, err = scope.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer scope.EndWithRecover(ctx, &err)
+
+	return fn(ctx)
+}
+
+// RunValue runs fn within the scope like Run, and returns its value, or the zero value if the operation or the
+// end of the scope failed.
+//
+// Example:
+//
+//	id, err := opscope.RunValue(ctx, txScope, func(ctx context.Context) (int64, error) {
+//		return articleStore.Create(ctx, article)
+//	})
+func RunValue[T any](ctx context.Context, scope Scope, fn func(ctx context.Context) (T, error)) (T, error) {
+	var value T
+
+	err := Run(ctx, scope, func(ctx context.Context) (err error) {
+		value, err = fn(ctx)
+		return err
+	})
+	if err != nil {
+		return *new(T), err
+	}
+
+	return value, nil
+}
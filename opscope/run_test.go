@@ -0,0 +1,137 @@
+package opscope_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infevocorp/goflexstore/opscope"
+)
+
+type scopeKey struct{}
+
+// fakeScope records the outcome the scope was ended with.
+type fakeScope struct {
+	beginErr error
+	endErr   error
+	ended    bool
+	outcome  error
+}
+
+func (s *fakeScope) Begin(ctx context.Context) (context.Context, error) {
+	if s.beginErr != nil {
+		return ctx, s.beginErr
+	}
+
+	return context.WithValue(ctx, scopeKey{}, s), nil
+}
+
+func (s *fakeScope) End(_ context.Context, err error) error {
+	s.ended, s.outcome = true, err
+
+	if err != nil {
+		return err
+	}
+
+	return s.endErr
+}
+
+func (s *fakeScope) EndWithRecover(ctx context.Context, errPtr *error) {
+	err := *errPtr
+
+	if r := recover(); r != nil {
+		err = errors.Join(err, errors.New("panic"))
+		*errPtr = err
+	}
+
+	if err2 := s.End(ctx, err); err2 != nil && err2 != err {
+		*errPtr = errors.Join(err, err2)
+	}
+}
+
+func Test_Run(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should-run-within-scope", func(t *testing.T) {
+		// GIVEN
+		scope := &fakeScope{}
+
+		// WHEN
+		err := opscope.Run(ctx, scope, func(ctx context.Context) error {
+			assert.Equal(t, scope, ctx.Value(scopeKey{}))
+			return nil
+		})
+
+		// THEN
+		assert.NoError(t, err)
+		assert.True(t, scope.ended)
+		assert.NoError(t, scope.outcome)
+	})
+
+	t.Run("should-end-scope-with-error-of-operation", func(t *testing.T) {
+		// GIVEN
+		scope := &fakeScope{}
+		errFailed := errors.New("failed")
+
+		// WHEN
+		err := opscope.Run(ctx, scope, func(context.Context) error { return errFailed })
+
+		// THEN
+		assert.ErrorIs(t, err, errFailed)
+		assert.ErrorIs(t, scope.outcome, errFailed)
+	})
+
+	t.Run("should-end-scope-with-panic", func(t *testing.T) {
+		// GIVEN
+		scope := &fakeScope{}
+
+		// WHEN
+		err := opscope.Run(ctx, scope, func(context.Context) error { panic("boom") })
+
+		// THEN
+		assert.EqualError(t, err, "panic")
+		assert.EqualError(t, scope.outcome, "panic")
+	})
+
+	t.Run("should-return-error-of-begin", func(t *testing.T) {
+		// GIVEN
+		scope := &fakeScope{beginErr: errors.New("no connection")}
+
+		// WHEN
+		err := opscope.Run(ctx, scope, func(context.Context) error {
+			t.Fatal("unexpected call")
+			return nil
+		})
+
+		// THEN
+		assert.EqualError(t, err, "no connection")
+		assert.False(t, scope.ended)
+	})
+}
+
+func Test_RunValue(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should-return-value", func(t *testing.T) {
+		// WHEN
+		value, err := opscope.RunValue(ctx, &fakeScope{}, func(context.Context) (int, error) { return 42, nil })
+
+		// THEN
+		assert.NoError(t, err)
+		assert.Equal(t, 42, value)
+	})
+
+	t.Run("should-return-zero-value-if-end-fails", func(t *testing.T) {
+		// GIVEN
+		scope := &fakeScope{endErr: errors.New("commit failed")}
+
+		// WHEN
+		value, err := opscope.RunValue(ctx, scope, func(context.Context) (int, error) { return 42, nil })
+
+		// THEN
+		assert.EqualError(t, err, "commit failed")
+		assert.Zero(t, value)
+	})
+}
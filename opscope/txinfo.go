@@ -0,0 +1,57 @@
+package opscope
+
+import (
+	"context"
+	"time"
+)
+
+// Info describes the transaction of an operation scope, see TxInfo.
+//
+// Fields:
+//   - Name: The name of the scope the transaction was begun by, e.g. "writeTx".
+//   - Level: The nesting level of the scope, 1 for the outermost one and incremented by every nested Begin.
+//   - StartedAt: When the outermost scope began the transaction.
+type Info struct {
+	Name      string
+	Level     int
+	StartedAt time.Time
+}
+
+// TxInspector is implemented by the operation scopes to report the state of the transaction of a context,
+// see WithTxInspector.
+type TxInspector interface {
+	// TxInfo returns the transaction, or false if it is finished.
+	TxInfo() (Info, bool)
+}
+
+type inspectorKey struct{}
+
+// WithTxInspector returns a context whose transaction is reported by the inspector to InTransaction and TxInfo.
+// It is called by the implementations of Scope when they begin a transaction.
+func WithTxInspector(ctx context.Context, inspector TxInspector) context.Context {
+	return context.WithValue(ctx, inspectorKey{}, inspector)
+}
+
+// InTransaction reports whether the context carries an active transaction of an operation scope, e.g. to assert
+// in tests that a service runs its writes within a transaction.
+func InTransaction(ctx context.Context) bool {
+	_, ok := TxInfo(ctx)
+	return ok
+}
+
+// TxInfo returns the innermost active transaction of the context, or false if there is none.
+//
+// Example:
+// Logging the transaction of an operation:
+//
+//	if info, ok := opscope.TxInfo(ctx); ok {
+//		logger.Info("query", "tx", info.Name, "level", info.Level, "tx_age", time.Since(info.StartedAt))
+//	}
+func TxInfo(ctx context.Context) (Info, bool) {
+	inspector, ok := ctx.Value(inspectorKey{}).(TxInspector)
+	if !ok {
+		return Info{}, false
+	}
+
+	return inspector.TxInfo()
+}
@@ -0,0 +1,24 @@
+// Package otelstore instruments stores with OpenTelemetry, the cross-cutting tracing and metrics every service
+// using goflexstore needs.
+//
+// New decorates a store, and Middleware decorates stores composed with store.Chain along with other
+// middlewares. Every operation gets a client span named after its entity and method, such as "Article.List",
+// with the entity, method, a summary of the query params and the number of rows as attributes. The summary
+// holds the types of the params and the fields of the filters, never their values. The latency of the
+// operations is recorded by the goflexstore.operation.duration histogram, and their failures counted by the
+// goflexstore.operation.errors counter, so that the error rate is their ratio. store.ErrNotFound is not a
+// failure.
+//
+// The spans and metrics go to the global providers of the otel package by default, see WithTracerProvider and
+// WithMeterProvider. The metrics are exported to Prometheus by configuring the meter provider with the
+// Prometheus exporter of OpenTelemetry.
+//
+// Example:
+//
+//	articleStore := otelstore.New[*model.Article, int64](inner)
+//
+//	articleStore := store.Chain[*model.Article, int64](inner,
+//		otelstore.Middleware(otelstore.WithTracerProvider(tp), otelstore.WithMeterProvider(mp)),
+//		flexlog.Middleware(logger),
+//	)
+package otelstore
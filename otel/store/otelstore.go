@@ -0,0 +1,133 @@
+package otelstore
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/infevocorp/goflexstore/flexlog"
+	"github.com/infevocorp/goflexstore/query"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// instrumentationName is the name of the tracer and meter of the Middleware.
+const instrumentationName = "github.com/infevocorp/goflexstore/otel/store"
+
+// The attributes of the spans and metrics of the operations.
+const (
+	// EntityKey is the name of the entity of the store, see store.EntityName.
+	EntityKey = attribute.Key("goflexstore.entity")
+	// MethodKey is the method of the operation, such as store.MethodList.
+	MethodKey = attribute.Key("goflexstore.method")
+	// ParamsKey is the summary of the query params of the operation, e.g. "filter:Status,orderby,paginate".
+	ParamsKey = attribute.Key("goflexstore.params")
+	// RowsKey is the number of entities returned or written by the operation, when it is known.
+	RowsKey = attribute.Key("goflexstore.rows")
+	// ErrorKey tells whether the operation failed, on the duration metric.
+	ErrorKey = attribute.Key("goflexstore.error")
+)
+
+// New decorates the inner store with the Middleware, tracing and measuring its operations.
+func New[T store.Entity[ID], ID comparable](inner store.Store[T, ID], opts ...Option) store.Store[T, ID] {
+	return store.Chain[T, ID](inner, Middleware(opts...))
+}
+
+// Middleware returns a store middleware tracing every operation in a span and recording its duration and
+// failure in metrics, see the package documentation.
+func Middleware(opts ...Option) store.Middleware {
+	o := options{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	var (
+		tracer = o.tracerProvider.Tracer(instrumentationName)
+		meter  = o.meterProvider.Meter(instrumentationName)
+	)
+
+	// the instruments failing to be created are no-op ones, and the error is reported to the otel error handler.
+	duration, err := meter.Float64Histogram("goflexstore.operation.duration",
+		metric.WithUnit("s"),
+		metric.WithDescription("Duration of the store operations."),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	failures, err := meter.Int64Counter("goflexstore.operation.errors",
+		metric.WithUnit("{operation}"),
+		metric.WithDescription("Number of failed store operations."),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return func(next store.OperationFunc) store.OperationFunc {
+		return func(ctx context.Context, op *store.Operation) (any, error) {
+			attrs := []attribute.KeyValue{EntityKey.String(op.Entity), MethodKey.String(op.Method)}
+
+			ctx, span := tracer.Start(ctx, op.Entity+"."+op.Method,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(attrs...),
+				trace.WithAttributes(ParamsKey.String(summarize(op.Params))),
+			)
+			defer span.End()
+
+			start := time.Now()
+
+			result, err := next(ctx, op)
+
+			elapsed := time.Since(start).Seconds()
+
+			if rows := flexlog.RowsOf(op, result, err); rows >= 0 {
+				span.SetAttributes(RowsKey.Int64(rows))
+			}
+
+			failed := err != nil && !errors.Is(err, store.ErrNotFound)
+			if failed {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+				failures.Add(ctx, 1, metric.WithAttributes(attrs...))
+			}
+
+			duration.Record(ctx, elapsed, metric.WithAttributes(append(attrs, ErrorKey.Bool(failed))...))
+
+			return result, err
+		}
+	}
+}
+
+// summarize returns the types of the params, with the fields of the filters, separated by commas.
+func summarize(params []query.Param) string {
+	if len(params) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+
+	for i, param := range query.NewParams(params...).Params() {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+
+		b.WriteString(param.ParamType())
+
+		if filter, ok := param.(query.FilterParam); ok {
+			b.WriteByte(':')
+			b.WriteString(filter.Name)
+		}
+	}
+
+	return b.String()
+}
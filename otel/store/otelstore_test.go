@@ -0,0 +1,121 @@
+package otelstore_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	otelstore "github.com/infevocorp/goflexstore/otel/store"
+	"github.com/infevocorp/goflexstore/query"
+	"github.com/infevocorp/goflexstore/store"
+	"github.com/infevocorp/goflexstore/storetest"
+)
+
+type Article struct {
+	ID     int
+	Status string
+}
+
+func (a *Article) GetID() int {
+	return a.ID
+}
+
+// newStore returns an instrumented store of articles, with the recorder of its spans and the reader of its metrics.
+func newStore() (store.Store[*Article, int], *storetest.Fake[*Article, int], *tracetest.SpanRecorder, *sdkmetric.ManualReader) {
+	var (
+		recorder = tracetest.NewSpanRecorder()
+		reader   = sdkmetric.NewManualReader()
+		inner    = storetest.NewFake[*Article, int](
+			&Article{ID: 1, Status: "active"},
+			&Article{ID: 2, Status: "active"},
+			&Article{ID: 3, Status: "draft"},
+		)
+	)
+
+	s := otelstore.New[*Article, int](inner,
+		otelstore.WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))),
+		otelstore.WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+	)
+
+	return s, inner, recorder, reader
+}
+
+func Test_Middleware(t *testing.T) {
+	t.Run("should-trace-operation", func(t *testing.T) {
+		// GIVEN
+		s, _, recorder, _ := newStore()
+
+		// WHEN
+		articles, err := s.List(context.Background(), query.Filter("Status", "active"), query.Paginate(0, 10))
+
+		// THEN
+		require.NoError(t, err)
+		require.Len(t, articles, 2)
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 1)
+		assert.Equal(t, "Article.List", spans[0].Name())
+		assert.Equal(t, trace.SpanKindClient, spans[0].SpanKind())
+		assert.ElementsMatch(t, []attribute.KeyValue{
+			otelstore.EntityKey.String("Article"),
+			otelstore.MethodKey.String(store.MethodList),
+			otelstore.ParamsKey.String("filter:Status,paginate"),
+			otelstore.RowsKey.Int64(2),
+		}, spans[0].Attributes())
+		assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	})
+
+	t.Run("should-record-failures", func(t *testing.T) {
+		// GIVEN
+		var (
+			s, inner, recorder, reader = newStore()
+			dbErr                      = errors.New("connection refused")
+		)
+
+		inner.FailWith("Count", dbErr)
+
+		// WHEN
+		_, err := s.Count(context.Background())
+		_, notFoundErr := s.Get(context.Background(), query.Filter("ID", 9))
+
+		// THEN
+		assert.ErrorIs(t, err, dbErr)
+		assert.ErrorIs(t, notFoundErr, store.ErrNotFound)
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 2)
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+		assert.Equal(t, "connection refused", spans[0].Status().Description)
+		assert.Equal(t, codes.Unset, spans[1].Status().Code)
+
+		var metrics metricdata.ResourceMetrics
+		require.NoError(t, reader.Collect(context.Background(), &metrics))
+		require.Len(t, metrics.ScopeMetrics, 1)
+
+		byName := map[string]metricdata.Aggregation{}
+		for _, m := range metrics.ScopeMetrics[0].Metrics {
+			byName[m.Name] = m.Data
+		}
+
+		failures, ok := byName["goflexstore.operation.errors"].(metricdata.Sum[int64])
+		require.True(t, ok)
+		require.Len(t, failures.DataPoints, 1)
+		assert.Equal(t, int64(1), failures.DataPoints[0].Value)
+		method, _ := failures.DataPoints[0].Attributes.Value(otelstore.MethodKey)
+		assert.Equal(t, store.MethodCount, method.AsString())
+
+		durations, ok := byName["goflexstore.operation.duration"].(metricdata.Histogram[float64])
+		require.True(t, ok)
+		assert.Len(t, durations.DataPoints, 2)
+	})
+}
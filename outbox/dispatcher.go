@@ -0,0 +1,151 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/infevocorp/goflexstore/opscope"
+	"github.com/infevocorp/goflexstore/query"
+)
+
+// Report is the outcome of a batch.
+//
+// Fields:
+//   - Published: The number of published messages, deleted from the outbox.
+//   - Failed: The number of messages which failed to publish, to be retried.
+type Report struct {
+	Published int
+	Failed    int
+}
+
+// Dispatcher publishes the due messages of an outbox, see NewDispatcher.
+type Dispatcher struct {
+	options
+	store     *Store
+	publisher Publisher
+}
+
+// NewDispatcher creates a Dispatcher publishing the messages of the outbox store with the publisher.
+//
+// Parameters:
+//   - s: The outbox store the messages are enqueued to.
+//   - publisher: The publisher of the messages, e.g. SenderPublisher.
+//   - opts: The options of the dispatcher.
+func NewDispatcher(s *Store, publisher Publisher, opts ...Option) *Dispatcher {
+	o := options{
+		batchSize:   100,
+		maxAttempts: 10,
+		backoff:     ExponentialBackoff(time.Second, time.Hour),
+		now:         time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.batchSize < 1 {
+		o.batchSize = 1
+	}
+
+	return &Dispatcher{
+		options:   o,
+		store:     s,
+		publisher: publisher,
+	}
+}
+
+// Dispatch publishes the first batch of the due messages within a transaction: the published messages are
+// deleted, and the failed ones are scheduled for a later attempt. A message failing to publish does not fail
+// the batch, and is passed to the function set by WithOnError.
+//
+// It returns the report of the batch, or the error of the outbox store, in which case the batch is rolled back
+// and its messages are published again by the next batch.
+func (d *Dispatcher) Dispatch(ctx context.Context) (Report, error) {
+	return opscope.RunValue(ctx, d.store.Scope, func(ctx context.Context) (Report, error) {
+		var (
+			report Report
+			now    = d.now()
+		)
+
+		params := []query.Param{
+			query.Filter("DueAt", now).WithOP(query.LTE),
+			query.OrderBy("ID", false),
+			query.Paginate(0, d.batchSize),
+			query.WithLock(query.LockTypeForUpdate),
+		}
+
+		if d.maxAttempts > 0 {
+			params = append(params, query.Filter("Attempts", d.maxAttempts).WithOP(query.LT))
+		}
+
+		messages, err := d.store.Messages.List(ctx, params...)
+		if err != nil {
+			return report, fmt.Errorf("outbox: list messages: %w", err)
+		}
+
+		published := make([]int64, 0, len(messages))
+
+		for _, message := range messages {
+			if err := d.publisher.Publish(ctx, message); err != nil {
+				report.Failed++
+
+				if err := d.retry(ctx, message, err, now); err != nil {
+					return report, err
+				}
+
+				continue
+			}
+
+			published = append(published, message.ID)
+		}
+
+		if len(published) > 0 {
+			if err := d.store.Messages.Delete(ctx, query.Filter("ID", published)); err != nil {
+				return report, fmt.Errorf("outbox: delete published messages: %w", err)
+			}
+		}
+
+		report.Published = len(published)
+
+		return report, nil
+	})
+}
+
+// Run dispatches the due messages every interval, batch after batch until none is left, until the context is
+// cancelled, and returns the error of the context. A failed batch is retried at the next interval.
+func (d *Dispatcher) Run(ctx context.Context, every time.Duration) error {
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			for {
+				report, err := d.Dispatch(ctx)
+				if err != nil || report.Published+report.Failed < d.batchSize {
+					break
+				}
+			}
+		}
+	}
+}
+
+// retry schedules the next attempt to publish the message which failed with err.
+func (d *Dispatcher) retry(ctx context.Context, message *Message, err error, now time.Time) error {
+	message.Attempts++
+	message.LastError = err.Error()
+	message.DueAt = now.Add(d.backoff(message.Attempts))
+
+	if d.onError != nil {
+		d.onError(message, err)
+	}
+
+	if err := d.store.Messages.Update(ctx, message); err != nil {
+		return fmt.Errorf("outbox: update message %d: %w", message.ID, err)
+	}
+
+	return nil
+}
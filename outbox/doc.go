@@ -0,0 +1,38 @@
+// Package outbox implements the transactional outbox pattern: the messages to publish to a broker are written to
+// a message table within the transaction of the changes they announce, and published by a Dispatcher once the
+// transaction is committed, so that a message is published if and only if its changes are persisted.
+//
+// A Store enqueues the messages through a regular store.Store, e.g. the GORM store of the message table of the
+// gorm/outbox package, within the operation scope shared with the stores of the changes. It also implements
+// broker.Sender, so that the events of an events.Store are enqueued rather than sent: events.WithScope must then
+// be left out, for the events to be enqueued before the commit instead of after.
+//
+// A Dispatcher polls the due messages by batches in the order of their ID, each batch within its own
+// transaction locking its messages, hands them to a Publisher and deletes the published ones. A message
+// failing to publish is retried later with an exponential backoff, after the messages following it, until
+// its maximum number of attempts is reached: it is then left in the table for inspection. Messages are thus
+// published at least once, and consumers must be idempotent.
+//
+// Example:
+//
+//	messages := outbox.NewStore(gormoutbox.NewStore(scope), scope)
+//
+//	err := opscope.Run(ctx, scope, func(ctx context.Context) error {
+//		if _, err := orderStore.Create(ctx, order); err != nil {
+//			return err
+//		}
+//
+//		message, err := outbox.NewJSON("orders", strconv.FormatInt(order.ID, 10), order)
+//		if err != nil {
+//			return err
+//		}
+//
+//		return messages.Enqueue(ctx, message)
+//	})
+//
+//	dispatcher := outbox.NewDispatcher(messages, outbox.SenderPublisher(kafkaSender),
+//		outbox.WithBatchSize(100),
+//	)
+//
+//	go dispatcher.Run(ctx, time.Second)
+package outbox
@@ -0,0 +1,63 @@
+package outbox
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Message is a message of the outbox.
+//
+// Fields:
+//   - ID: The identifier of the message, ordering the messages.
+//   - Topic: The topic, or subject, the message is published to.
+//   - Key: The partitioning key of the message, e.g. the ID of the entity it is about. It is optional.
+//   - Payload: The encoded message.
+//   - Headers: The headers of the message. They are optional.
+//   - CreatedAt: When the message was enqueued.
+//   - DueAt: When the message is due to be published, after CreatedAt once it failed to publish.
+//   - Attempts: The number of failed attempts to publish the message.
+//   - LastError: The error of the last failed attempt.
+type Message struct {
+	ID        int64
+	Topic     string
+	Key       []byte
+	Payload   []byte
+	Headers   map[string]string
+	CreatedAt time.Time
+	DueAt     time.Time
+	Attempts  int
+	LastError string
+}
+
+// GetID returns the identifier of the message.
+func (m *Message) GetID() int64 {
+	return m.ID
+}
+
+// NewJSON returns a message of the topic whose payload is the JSON encoding of v.
+//
+// Parameters:
+//   - topic: The topic of the message.
+//   - key: The partitioning key of the message, or an empty string.
+//   - v: The value encoded as payload.
+//
+// Example:
+//
+//	message, err := outbox.NewJSON("orders", strconv.FormatInt(order.ID, 10), order)
+func NewJSON(topic, key string, v any) (*Message, error) {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	message := &Message{
+		Topic:   topic,
+		Payload: payload,
+	}
+
+	if key != "" {
+		message.Key = []byte(key)
+	}
+
+	return message, nil
+}
@@ -0,0 +1,70 @@
+package outbox
+
+import "time"
+
+// Option is a function that configures the Dispatcher.
+type Option func(*options)
+
+type options struct {
+	batchSize   int
+	maxAttempts int
+	backoff     func(attempts int) time.Duration
+	now         func() time.Time
+	onError     func(message *Message, err error)
+}
+
+// WithBatchSize sets the number of messages published within each transaction. It defaults to 100.
+func WithBatchSize(size int) Option {
+	return func(o *options) {
+		o.batchSize = size
+	}
+}
+
+// WithMaxAttempts sets the number of attempts after which a message failing to publish is no longer retried.
+// It defaults to 10, and 0 retries the messages forever.
+func WithMaxAttempts(attempts int) Option {
+	return func(o *options) {
+		o.maxAttempts = attempts
+	}
+}
+
+// WithBackoff sets the function returning the delay before the next attempt to publish a message, given its
+// number of failed attempts. It defaults to ExponentialBackoff(time.Second, time.Hour).
+func WithBackoff(backoff func(attempts int) time.Duration) Option {
+	return func(o *options) {
+		o.backoff = backoff
+	}
+}
+
+// WithClock sets the function returning the current time, deciding which messages are due. It defaults to
+// time.Now.
+func WithClock(now func() time.Time) Option {
+	return func(o *options) {
+		o.now = now
+	}
+}
+
+// WithOnError sets a function called with the messages failing to publish and their error, e.g. to log them.
+func WithOnError(onError func(message *Message, err error)) Option {
+	return func(o *options) {
+		o.onError = onError
+	}
+}
+
+// ExponentialBackoff returns a backoff doubling the delay after each failed attempt, starting from base and
+// capped at ceiling.
+func ExponentialBackoff(base, ceiling time.Duration) func(attempts int) time.Duration {
+	return func(attempts int) time.Duration {
+		delay := base
+
+		for i := 1; i < attempts && delay < ceiling; i++ {
+			delay *= 2
+		}
+
+		if delay > ceiling {
+			return ceiling
+		}
+
+		return delay
+	}
+}
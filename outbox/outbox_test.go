@@ -0,0 +1,206 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infevocorp/goflexstore/events/broker"
+	mockopscope "github.com/infevocorp/goflexstore/mocks/opscope"
+	"github.com/infevocorp/goflexstore/outbox"
+	"github.com/infevocorp/goflexstore/storetest"
+)
+
+var now = time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+// newScope returns a scope beginning and ending transactions with the given context.
+func newScope(t *testing.T) *mockopscope.Scope {
+	scope := mockopscope.NewScope(t)
+	scope.EXPECT().Begin(mock.Anything).RunAndReturn(func(ctx context.Context) (context.Context, error) {
+		return ctx, nil
+	})
+	scope.EXPECT().EndWithRecover(mock.Anything, mock.Anything).Return()
+
+	return scope
+}
+
+func Test_Store_Enqueue(t *testing.T) {
+	t.Run("should-create-messages-due-now", func(t *testing.T) {
+		// GIVEN
+		var (
+			messages = storetest.NewFake[*outbox.Message, int64]()
+			s        = outbox.NewStore(messages, newScope(t))
+		)
+
+		message, err := outbox.NewJSON("orders", "1", map[string]int{"id": 1})
+		require.NoError(t, err)
+
+		// WHEN
+		err = s.Enqueue(context.Background(), message)
+
+		// THEN
+		require.NoError(t, err)
+
+		enqueued := messages.Entities()
+		require.Len(t, enqueued, 1)
+		assert.Equal(t, "orders", enqueued[0].Topic)
+		assert.Equal(t, []byte("1"), enqueued[0].Key)
+		assert.JSONEq(t, `{"id":1}`, string(enqueued[0].Payload))
+		assert.False(t, enqueued[0].CreatedAt.IsZero())
+		assert.Equal(t, enqueued[0].CreatedAt, enqueued[0].DueAt)
+	})
+
+	t.Run("should-enqueue-broker-messages", func(t *testing.T) {
+		// GIVEN
+		var (
+			messages = storetest.NewFake[*outbox.Message, int64]()
+			s        = outbox.NewStore(messages, newScope(t))
+		)
+
+		// WHEN
+		err := s.Send(context.Background(), broker.Message{
+			Topic:   "article",
+			Key:     []byte("7"),
+			Value:   []byte(`{}`),
+			Headers: map[string]string{broker.HeaderEventType: "created"},
+		})
+
+		// THEN
+		require.NoError(t, err)
+
+		enqueued := messages.Entities()
+		require.Len(t, enqueued, 1)
+		assert.Equal(t, "article", enqueued[0].Topic)
+		assert.Equal(t, []byte("7"), enqueued[0].Key)
+		assert.Equal(t, []byte(`{}`), enqueued[0].Payload)
+		assert.Equal(t, map[string]string{broker.HeaderEventType: "created"}, enqueued[0].Headers)
+	})
+}
+
+func Test_Dispatcher_Dispatch(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should-publish-due-messages-in-order", func(t *testing.T) {
+		// GIVEN
+		var (
+			messages  = storetest.NewFake[*outbox.Message, int64]()
+			published []int64
+		)
+
+		messages.Seed(
+			&outbox.Message{ID: 1, Topic: "orders", DueAt: now.Add(-time.Minute)},
+			&outbox.Message{ID: 2, Topic: "orders", DueAt: now},
+			&outbox.Message{ID: 3, Topic: "orders", DueAt: now.Add(time.Minute)},
+			&outbox.Message{ID: 4, Topic: "orders", DueAt: now, Attempts: 10},
+		)
+
+		dispatcher := outbox.NewDispatcher(outbox.NewStore(messages, newScope(t)),
+			outbox.PublisherFunc(func(_ context.Context, message *outbox.Message) error {
+				published = append(published, message.ID)
+				return nil
+			}),
+			outbox.WithClock(func() time.Time { return now }),
+		)
+
+		// WHEN
+		report, err := dispatcher.Dispatch(ctx)
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, outbox.Report{Published: 2}, report)
+		assert.Equal(t, []int64{1, 2}, published)
+
+		left := messages.Entities()
+		require.Len(t, left, 2)
+		assert.Equal(t, int64(3), left[0].ID)
+		assert.Equal(t, int64(4), left[1].ID)
+	})
+
+	t.Run("should-retry-failed-messages-later", func(t *testing.T) {
+		// GIVEN
+		var (
+			messages  = storetest.NewFake[*outbox.Message, int64]()
+			errBroker = errors.New("broker unavailable")
+			failed    []int64
+		)
+
+		messages.Seed(
+			&outbox.Message{ID: 1, Topic: "orders", DueAt: now, Attempts: 1},
+			&outbox.Message{ID: 2, Topic: "invoices", DueAt: now},
+		)
+
+		dispatcher := outbox.NewDispatcher(outbox.NewStore(messages, newScope(t)),
+			outbox.PublisherFunc(func(_ context.Context, message *outbox.Message) error {
+				if message.Topic == "orders" {
+					return errBroker
+				}
+
+				return nil
+			}),
+			outbox.WithClock(func() time.Time { return now }),
+			outbox.WithBackoff(outbox.ExponentialBackoff(time.Second, time.Minute)),
+			outbox.WithOnError(func(message *outbox.Message, err error) {
+				assert.ErrorIs(t, err, errBroker)
+				failed = append(failed, message.ID)
+			}),
+		)
+
+		// WHEN
+		report, err := dispatcher.Dispatch(ctx)
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, outbox.Report{Published: 1, Failed: 1}, report)
+		assert.Equal(t, []int64{1}, failed)
+
+		left := messages.Entities()
+		require.Len(t, left, 1)
+		assert.Equal(t, int64(1), left[0].ID)
+		assert.Equal(t, 2, left[0].Attempts)
+		assert.Equal(t, "broker unavailable", left[0].LastError)
+		assert.Equal(t, now.Add(2*time.Second), left[0].DueAt)
+	})
+
+	t.Run("should-fail-if-messages-cannot-be-listed", func(t *testing.T) {
+		// GIVEN
+		var (
+			messages = storetest.NewFake[*outbox.Message, int64]()
+			errDB    = errors.New("connection lost")
+		)
+
+		messages.FailWith("List", errDB)
+
+		dispatcher := outbox.NewDispatcher(outbox.NewStore(messages, newScope(t)),
+			outbox.PublisherFunc(func(context.Context, *outbox.Message) error {
+				t.Fatal("unexpected publish")
+				return nil
+			}),
+		)
+
+		// WHEN
+		_, err := dispatcher.Dispatch(ctx)
+
+		// THEN
+		assert.ErrorIs(t, err, errDB)
+	})
+}
+
+func Test_ExponentialBackoff(t *testing.T) {
+	t.Run("should-double-delay-up-to-ceiling", func(t *testing.T) {
+		// GIVEN
+		backoff := outbox.ExponentialBackoff(time.Second, 5*time.Second)
+
+		// WHEN
+		delays := []time.Duration{backoff(1), backoff(2), backoff(3), backoff(4), backoff(100)}
+
+		// THEN
+		assert.Equal(t, []time.Duration{
+			time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second,
+		}, delays)
+	})
+}
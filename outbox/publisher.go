@@ -0,0 +1,34 @@
+package outbox
+
+import (
+	"context"
+
+	"github.com/infevocorp/goflexstore/events/broker"
+)
+
+// Publisher publishes the messages of the outbox, typically to a message broker.
+type Publisher interface {
+	// Publish publishes the message, and returns an error if it could not be published.
+	Publish(ctx context.Context, message *Message) error
+}
+
+// PublisherFunc is an adapter allowing the use of an ordinary function as a Publisher.
+type PublisherFunc func(ctx context.Context, message *Message) error
+
+// Publish calls f(ctx, message).
+func (f PublisherFunc) Publish(ctx context.Context, message *Message) error {
+	return f(ctx, message)
+}
+
+// SenderPublisher returns a Publisher sending the messages to a broker through the sender, e.g. the adapter of
+// a Kafka or NATS client also used with broker.NewKafka or broker.NewNATS.
+func SenderPublisher(sender broker.Sender) Publisher {
+	return PublisherFunc(func(ctx context.Context, message *Message) error {
+		return sender.Send(ctx, broker.Message{
+			Topic:   message.Topic,
+			Key:     message.Key,
+			Value:   message.Payload,
+			Headers: message.Headers,
+		})
+	})
+}
@@ -0,0 +1,78 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/infevocorp/goflexstore/events/broker"
+	"github.com/infevocorp/goflexstore/opscope"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+var _ broker.Sender = (*Store)(nil)
+
+// NewStore creates a Store enqueuing the messages in the message store.
+//
+// Parameters:
+//   - messages: The store of the messages, e.g. created by gormoutbox.NewStore.
+//   - scope: The operation scope shared by the message store and the stores of the changes.
+//
+// Example:
+//
+//	messages := outbox.NewStore(gormoutbox.NewStore(scope), scope)
+func NewStore(messages store.Store[*Message, int64], scope opscope.Scope) *Store {
+	return &Store{
+		Messages: messages,
+		Scope:    scope,
+	}
+}
+
+// Store enqueues messages in the outbox within the transactions of the operation scope.
+type Store struct {
+	Messages store.Store[*Message, int64]
+	Scope    opscope.Scope
+}
+
+// Enqueue writes the messages to the outbox within the scope of ctx, so that they are only published if the
+// transaction of ctx is committed. Their CreatedAt and DueAt default to the current time.
+//
+// Example:
+//
+//	err := messages.Enqueue(ctx, &outbox.Message{Topic: "orders", Payload: payload})
+func (s *Store) Enqueue(ctx context.Context, messages ...*Message) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+
+	for _, message := range messages {
+		if message.CreatedAt.IsZero() {
+			message.CreatedAt = now
+		}
+
+		if message.DueAt.IsZero() {
+			message.DueAt = message.CreatedAt
+		}
+	}
+
+	return opscope.Run(ctx, s.Scope, func(ctx context.Context) error {
+		return s.Messages.CreateMany(ctx, messages)
+	})
+}
+
+// Send implements broker.Sender by enqueuing the broker messages, e.g. the events encoded by broker.NewKafka.
+func (s *Store) Send(ctx context.Context, messages ...broker.Message) error {
+	enqueued := make([]*Message, len(messages))
+
+	for i, m := range messages {
+		enqueued[i] = &Message{
+			Topic:   m.Topic,
+			Key:     m.Key,
+			Payload: m.Value,
+			Headers: m.Headers,
+		}
+	}
+
+	return s.Enqueue(ctx, enqueued...)
+}
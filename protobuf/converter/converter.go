@@ -0,0 +1,235 @@
+package protoconverter
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/infevocorp/goflexstore/converter"
+)
+
+// New creates a Converter between the Entity and the DTO, a message generated by protoc-gen-go.
+//
+// The Converter implements converter.Converter when the Entity and the DTO implement store.Entity, the GetID method
+// of the message being declared in a separate file of its package, e.g.
+//
+//	func (x *Article) GetID() int64 { return x.GetId() }
+//
+// Type parameters:
+//   - Entity: The Entity type, a struct or a pointer to a struct.
+//   - DTO: The pointer to the struct of the generated message, e.g. *cmsv1.Article.
+//
+// Parameters:
+//   - opts: Options configuring the converter, e.g. MapField or Ignore.
+//
+// It panics if the DTO is not a pointer to a struct.
+func New[Entity any, DTO proto.Message](opts ...Option) *Converter[Entity, DTO] {
+	if t := reflect.TypeOf((*DTO)(nil)).Elem(); t.Kind() != reflect.Pointer || t.Elem().Kind() != reflect.Struct {
+		panic(fmt.Sprintf("protoconverter: DTO must be a pointer to a generated message, got %s", t))
+	}
+
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return &Converter[Entity, DTO]{options: o}
+}
+
+// Converter converts between an Entity and a message generated by protoc-gen-go, see the package documentation.
+// The matching fields of each pair of struct and message are resolved once and cached by the converter.
+// A converter is safe for concurrent use.
+type Converter[Entity any, DTO proto.Message] struct {
+	options options
+	// fields caches the []fieldPair of the pairs of struct and message, by fieldsKey.
+	fields sync.Map
+}
+
+// ToEntity converts the message into an Entity. A nil message is converted to the zero Entity.
+func (c *Converter[Entity, DTO]) ToEntity(dto DTO) Entity {
+	var entity Entity
+
+	m := dto.ProtoReflect()
+	if !m.IsValid() {
+		return entity
+	}
+
+	dst := reflect.ValueOf(&entity).Elem()
+	if dst.Kind() == reflect.Pointer {
+		dst.Set(reflect.New(dst.Type().Elem()))
+		dst = dst.Elem()
+	}
+
+	if err := c.toStruct(m, dst, true); err != nil {
+		panic(&converter.ConversionError{Err: err})
+	}
+
+	return entity
+}
+
+// ToDTO converts the Entity into a new message. A nil Entity is converted to a nil message.
+func (c *Converter[Entity, DTO]) ToDTO(entity Entity) DTO {
+	var dto DTO
+
+	src := reflect.ValueOf(&entity).Elem()
+	if src.Kind() == reflect.Pointer {
+		if src.IsNil() {
+			return dto
+		}
+
+		src = src.Elem()
+	}
+
+	dto = reflect.New(reflect.TypeOf(dto).Elem()).Interface().(DTO)
+
+	if err := c.toMessage(src, dto.ProtoReflect(), true); err != nil {
+		panic(&converter.ConversionError{Err: err})
+	}
+
+	return dto
+}
+
+// fieldPair is a field of a struct and the field of the message it is converted to and from.
+type fieldPair struct {
+	index int
+	fd    protoreflect.FieldDescriptor
+}
+
+// fieldsKey identifies the fields of a pair of struct and message. The options only apply to the top level pair.
+type fieldsKey struct {
+	typ  reflect.Type
+	desc protoreflect.FullName
+	top  bool
+}
+
+// fieldPairs returns the matching fields of the struct type and the message, resolving them on first use.
+func (c *Converter[Entity, DTO]) fieldPairs(
+	typ reflect.Type,
+	desc protoreflect.MessageDescriptor,
+	top bool,
+) ([]fieldPair, error) {
+	key := fieldsKey{typ: typ, desc: desc.FullName(), top: top}
+
+	if pairs, ok := c.fields.Load(key); ok {
+		return pairs.([]fieldPair), nil
+	}
+
+	pairs, err := matchFields(typ, desc, top, c.options)
+	if err != nil {
+		return nil, err
+	}
+
+	c.fields.Store(key, pairs)
+
+	return pairs, nil
+}
+
+// matchFields matches the exported fields of the struct type with the fields of the message by name, ignoring the
+// case and the underscores, or through the mapping of the options for the top level pair.
+func matchFields(
+	typ reflect.Type,
+	desc protoreflect.MessageDescriptor,
+	top bool,
+	o options,
+) ([]fieldPair, error) {
+	var (
+		fields = desc.Fields()
+		byName = make(map[string]protoreflect.FieldDescriptor, fields.Len())
+		pairs  []fieldPair
+	)
+
+	for i := 0; i < fields.Len(); i++ {
+		byName[normalizeName(string(fields.Get(i).Name()))] = fields.Get(i)
+	}
+
+	// a field of the message mapped to a field of the entity is not matched by its own name.
+	if top {
+		for _, protoField := range o.mapping {
+			delete(byName, normalizeName(protoField))
+		}
+	}
+
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if !f.IsExported() || f.Anonymous || top && o.ignore[f.Name] {
+			continue
+		}
+
+		if protoField, mapped := o.mapping[f.Name]; top && mapped {
+			fd := fields.ByName(protoreflect.Name(protoField))
+			if fd == nil {
+				return nil, fmt.Errorf("field %s of %s is mapped to unknown field %s of %s",
+					f.Name, typ, protoField, desc.FullName())
+			}
+
+			pairs = append(pairs, fieldPair{index: i, fd: fd})
+
+			continue
+		}
+
+		if fd, ok := byName[normalizeName(f.Name)]; ok {
+			pairs = append(pairs, fieldPair{index: i, fd: fd})
+		}
+	}
+
+	return pairs, nil
+}
+
+// normalizeName returns the name of a field without case nor underscores, e.g. "authorid" for both AuthorID and
+// author_id.
+func normalizeName(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+}
+
+// toStruct sets the fields of the dst struct with the fields of the message which are set.
+func (c *Converter[Entity, DTO]) toStruct(m protoreflect.Message, dst reflect.Value, top bool) error {
+	pairs, err := c.fieldPairs(dst.Type(), m.Descriptor(), top)
+	if err != nil {
+		return err
+	}
+
+	for _, pair := range pairs {
+		if !m.Has(pair.fd) {
+			continue
+		}
+
+		if err := c.fromProtoField(pair.fd, m.Get(pair.fd), dst.Field(pair.index)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// toMessage sets the fields of the message with the fields of the src struct which are not zero.
+func (c *Converter[Entity, DTO]) toMessage(src reflect.Value, m protoreflect.Message, top bool) error {
+	pairs, err := c.fieldPairs(src.Type(), m.Descriptor(), top)
+	if err != nil {
+		return err
+	}
+
+	for _, pair := range pairs {
+		v := src.Field(pair.index)
+		if v.IsZero() {
+			continue
+		}
+
+		if od := pair.fd.ContainingOneof(); od != nil && !od.IsSynthetic() && m.WhichOneof(od) != nil {
+			return fmt.Errorf("cannot set both %s and %s of oneof %s",
+				m.WhichOneof(od).Name(), pair.fd.Name(), od.FullName())
+		}
+
+		value, err := c.toProtoField(m, pair.fd, v)
+		if err != nil {
+			return err
+		}
+
+		m.Set(pair.fd, value)
+	}
+
+	return nil
+}
@@ -0,0 +1,292 @@
+package protoconverter_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/infevocorp/goflexstore/converter"
+	protoconverter "github.com/infevocorp/goflexstore/protobuf/converter"
+	"github.com/infevocorp/goflexstore/protobuf/internal/testpb"
+)
+
+type Article struct {
+	ID        int64
+	Title     string
+	Status    string
+	CreatedAt time.Time
+	ReadTime  time.Duration
+	Subtitle  *string
+	ViewCount int64
+	Rating    *int32
+	Author    *Author
+	Tags      []Tag
+	Keywords  []string
+	Labels    map[string]string
+	URL       string
+	Upload    *Upload
+	Secret    string
+}
+
+func (a *Article) GetID() int64 {
+	return a.ID
+}
+
+type Author struct {
+	ID   int64
+	Name string
+}
+
+type Tag struct {
+	ID   int64
+	Slug string
+}
+
+type Upload struct {
+	FileName string
+	Size     int64
+}
+
+func newConverter() *protoconverter.Converter[*Article, *testpb.Article] {
+	return protoconverter.New[*Article, *testpb.Article](
+		protoconverter.MapField("CreatedAt", "create_time"),
+		protoconverter.Ignore("Secret"),
+	)
+}
+
+func Test_Converter(t *testing.T) {
+	t.Run("should-implement-converter", func(t *testing.T) {
+		var c converter.Converter[*Article, *testpb.Article, int64] = newConverter()
+
+		assert.NotNil(t, c)
+	})
+
+	t.Run("should-convert-entity-to-dto", func(t *testing.T) {
+		// GIVEN
+		var (
+			c         = newConverter()
+			createdAt = time.Date(2024, 3, 1, 10, 30, 0, 500, time.UTC)
+			subtitle  = "subtitle"
+			rating    = int32(0)
+		)
+
+		// WHEN
+		dto := c.ToDTO(&Article{
+			ID:        1,
+			Title:     "title",
+			Status:    "draft",
+			CreatedAt: createdAt,
+			ReadTime:  90 * time.Second,
+			Subtitle:  &subtitle,
+			ViewCount: 42,
+			Rating:    &rating,
+			Author:    &Author{ID: 2, Name: "author"},
+			Tags:      []Tag{{ID: 3, Slug: "go"}, {ID: 4, Slug: "grpc"}},
+			Keywords:  []string{"protobuf"},
+			Labels:    map[string]string{"lang": "en"},
+			URL:       "https://example.com",
+			Secret:    "secret",
+		})
+
+		// THEN
+		assert.True(t, proto.Equal(&testpb.Article{
+			Id:         1,
+			Title:      "title",
+			Status:     testpb.Status_STATUS_DRAFT,
+			CreateTime: timestamppb.New(createdAt),
+			ReadTime:   durationpb.New(90 * time.Second),
+			Subtitle:   wrapperspb.String("subtitle"),
+			ViewCount:  wrapperspb.Int64(42),
+			Rating:     proto.Int32(0),
+			Author:     &testpb.Author{Id: 2, Name: "author"},
+			Tags:       []*testpb.Tag{{Id: 3, Slug: "go"}, {Id: 4, Slug: "grpc"}},
+			Keywords:   []string{"protobuf"},
+			Labels:     map[string]string{"lang": "en"},
+			Source:     &testpb.Article_Url{Url: "https://example.com"},
+		}, dto), dto.String())
+	})
+
+	t.Run("should-convert-dto-to-entity", func(t *testing.T) {
+		// GIVEN
+		var (
+			c         = newConverter()
+			createdAt = time.Date(2024, 3, 1, 10, 30, 0, 500, time.UTC)
+			subtitle  = "subtitle"
+			rating    = int32(0)
+		)
+
+		// WHEN
+		entity := c.ToEntity(&testpb.Article{
+			Id:         1,
+			Title:      "title",
+			Status:     testpb.Status_STATUS_PUBLISHED,
+			CreateTime: timestamppb.New(createdAt),
+			ReadTime:   durationpb.New(90 * time.Second),
+			Subtitle:   wrapperspb.String("subtitle"),
+			ViewCount:  wrapperspb.Int64(42),
+			Rating:     proto.Int32(0),
+			Author:     &testpb.Author{Id: 2, Name: "author"},
+			Tags:       []*testpb.Tag{{Id: 3, Slug: "go"}, {Id: 4, Slug: "grpc"}},
+			Keywords:   []string{"protobuf"},
+			Labels:     map[string]string{"lang": "en"},
+			Source:     &testpb.Article_Upload{Upload: &testpb.Upload{FileName: "article.md", Size: 1024}},
+		})
+
+		// THEN
+		assert.Equal(t, &Article{
+			ID:        1,
+			Title:     "title",
+			Status:    "published",
+			CreatedAt: createdAt,
+			ReadTime:  90 * time.Second,
+			Subtitle:  &subtitle,
+			ViewCount: 42,
+			Rating:    &rating,
+			Author:    &Author{ID: 2, Name: "author"},
+			Tags:      []Tag{{ID: 3, Slug: "go"}, {ID: 4, Slug: "grpc"}},
+			Keywords:  []string{"protobuf"},
+			Labels:    map[string]string{"lang": "en"},
+			Upload:    &Upload{FileName: "article.md", Size: 1024},
+		}, entity)
+	})
+
+	t.Run("should-leave-zero-values-unset", func(t *testing.T) {
+		// GIVEN
+		c := newConverter()
+
+		// WHEN
+		dto := c.ToDTO(&Article{ID: 1})
+		entity := c.ToEntity(&testpb.Article{Id: 1})
+
+		// THEN
+		assert.True(t, proto.Equal(&testpb.Article{Id: 1}, dto), dto.String())
+		assert.Nil(t, dto.CreateTime)
+		assert.Nil(t, dto.Subtitle)
+		assert.Nil(t, dto.ViewCount)
+		assert.Nil(t, dto.Rating)
+		assert.Nil(t, dto.Source)
+		assert.Equal(t, &Article{ID: 1}, entity)
+	})
+
+	t.Run("should-convert-nil", func(t *testing.T) {
+		// GIVEN
+		c := newConverter()
+
+		// WHEN
+		dto := c.ToDTO(nil)
+		entity := c.ToEntity(nil)
+
+		// THEN
+		assert.Nil(t, dto)
+		assert.Nil(t, entity)
+	})
+
+	t.Run("should-convert-enum-names", func(t *testing.T) {
+		// GIVEN
+		c := newConverter()
+
+		for status, expected := range map[string]testpb.Status{
+			"":                 testpb.Status_STATUS_UNSPECIFIED,
+			"draft":            testpb.Status_STATUS_DRAFT,
+			"PUBLISHED":        testpb.Status_STATUS_PUBLISHED,
+			"STATUS_PUBLISHED": testpb.Status_STATUS_PUBLISHED,
+		} {
+			// WHEN
+			dto := c.ToDTO(&Article{Status: status})
+
+			// THEN
+			assert.Equal(t, expected, dto.Status, status)
+		}
+	})
+
+	t.Run("should-convert-enum-numbers", func(t *testing.T) {
+		// GIVEN
+		type NumberedArticle struct {
+			Status int
+		}
+
+		c := protoconverter.New[NumberedArticle, *testpb.Article]()
+
+		// WHEN
+		dto := c.ToDTO(NumberedArticle{Status: 2})
+		entity := c.ToEntity(&testpb.Article{Status: testpb.Status_STATUS_DRAFT})
+
+		// THEN
+		assert.Equal(t, testpb.Status_STATUS_PUBLISHED, dto.Status)
+		assert.Equal(t, NumberedArticle{Status: 1}, entity)
+	})
+
+	t.Run("should-panic-on-unknown-enum-name", func(t *testing.T) {
+		// GIVEN
+		c := newConverter()
+
+		// WHEN
+		defer func() {
+			r := recover()
+
+			// THEN
+			require.IsType(t, &converter.ConversionError{}, r)
+			assert.EqualError(t, r.(error), `unknown value "archived" of enum goflexstore.protobuf.test.Status`)
+		}()
+
+		c.ToDTO(&Article{Status: "archived"})
+	})
+
+	t.Run("should-panic-on-several-oneof-fields", func(t *testing.T) {
+		// GIVEN
+		c := newConverter()
+
+		// WHEN
+		defer func() {
+			r := recover()
+
+			// THEN
+			require.IsType(t, &converter.ConversionError{}, r)
+			assert.EqualError(t, r.(error),
+				"cannot set both url and upload of oneof goflexstore.protobuf.test.Article.source")
+		}()
+
+		c.ToDTO(&Article{URL: "https://example.com", Upload: &Upload{FileName: "article.md"}})
+	})
+
+	t.Run("should-panic-on-mismatching-types", func(t *testing.T) {
+		// GIVEN
+		type InvalidArticle struct {
+			Title int
+		}
+
+		c := protoconverter.New[*InvalidArticle, *testpb.Article]()
+
+		// WHEN
+		defer func() {
+			r := recover()
+
+			// THEN
+			require.IsType(t, &converter.ConversionError{}, r)
+			assert.EqualError(t, r.(error), "cannot convert int to and from goflexstore.protobuf.test.Article.title")
+		}()
+
+		c.ToDTO(&InvalidArticle{Title: 1})
+	})
+
+	t.Run("should-panic-on-unknown-mapped-field", func(t *testing.T) {
+		// GIVEN
+		c := protoconverter.New[*Article, *testpb.Article](protoconverter.MapField("CreatedAt", "created_at"))
+
+		// WHEN
+		defer func() {
+			r := recover()
+
+			// THEN
+			require.IsType(t, &converter.ConversionError{}, r)
+		}()
+
+		c.ToEntity(&testpb.Article{Id: 1})
+	})
+}
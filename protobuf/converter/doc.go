@@ -0,0 +1,33 @@
+// Package protoconverter converts entities to and from the messages generated by protoc-gen-go, the DTOs of the
+// gRPC APIs, without the conversions written by hand for every message.
+//
+// The fields of the messages are read and written through protobuf reflection, and matched with the fields of the
+// entities by name, ignoring the case and the underscores, so that the author_id field of a message matches the
+// AuthorID field of an entity. MapField and Ignore change the matching of the fields of the entity itself.
+//
+// On top of the scalars, nested messages, repeated fields and maps, the converter handles:
+//   - google.protobuf.Timestamp and google.protobuf.Duration, converted to and from time.Time and time.Duration.
+//   - The wrappers of google/protobuf/wrappers.proto, such as google.protobuf.StringValue, converted to and from
+//     pointers, e.g. *string, a nil pointer being a nil wrapper, or to and from the wrapped value.
+//   - Enums, converted to and from strings holding the lower case name of their value without the prefix of the
+//     enum, e.g. "draft" for STATUS_DRAFT of the Status enum, or to and from integers holding their number.
+//     The zero value is the empty string.
+//   - The fields of a oneof, converted to and from a field of the entity per field of the oneof, only the one
+//     which is set in the message being set in the entity, and the other way around.
+//
+// Zero values of the entity are left unset in the messages, use pointers to set zero values of optional fields,
+// wrappers and oneofs.
+//
+// Like the converters of the converter package, the Converter panics with a converter.ConversionError when a field
+// cannot be converted.
+//
+// Example:
+//
+//	articleConverter := protoconverter.New[*model.Article, *cmsv1.Article](
+//		protoconverter.MapField("CreatedAt", "create_time"),
+//		protoconverter.Ignore("AuthorPasswordHash"),
+//	)
+//
+//	pb := articleConverter.ToDTO(article)
+//	article := articleConverter.ToEntity(pb)
+package protoconverter
@@ -0,0 +1,42 @@
+package protoconverter
+
+// Option is a function that configures a Converter, see New.
+type Option func(*options)
+
+type options struct {
+	// mapping key is Entity's field name. value is the message's field name.
+	mapping map[string]string
+	ignore  map[string]bool
+}
+
+// MapField maps the field of the Entity to the field of the message of the given name, as written in the .proto
+// file, e.g. "create_time", in both conversions. It only applies to the fields of the Entity itself, not to the
+// fields of its nested structs.
+//
+// Example:
+//
+//	protoconverter.New[*model.Article, *cmsv1.Article](protoconverter.MapField("CreatedAt", "create_time"))
+func MapField(entityField, protoField string) Option {
+	return func(o *options) {
+		if o.mapping == nil {
+			o.mapping = map[string]string{}
+		}
+
+		o.mapping[entityField] = protoField
+	}
+}
+
+// Ignore excludes the fields of the Entity of the given names from both conversions, e.g. sensitive fields that
+// must not leave the service. It only applies to the fields of the Entity itself, not to the fields of its nested
+// structs.
+func Ignore(fields ...string) Option {
+	return func(o *options) {
+		if o.ignore == nil {
+			o.ignore = make(map[string]bool, len(fields))
+		}
+
+		for _, field := range fields {
+			o.ignore[field] = true
+		}
+	}
+}
@@ -0,0 +1,437 @@
+package protoconverter
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	timestampName protoreflect.FullName = "google.protobuf.Timestamp"
+	durationName  protoreflect.FullName = "google.protobuf.Duration"
+)
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+
+	// wrapperNames are the messages of google/protobuf/wrappers.proto, wrapping the value of their field 1.
+	wrapperNames = map[protoreflect.FullName]bool{
+		"google.protobuf.DoubleValue": true,
+		"google.protobuf.FloatValue":  true,
+		"google.protobuf.Int64Value":  true,
+		"google.protobuf.UInt64Value": true,
+		"google.protobuf.Int32Value":  true,
+		"google.protobuf.UInt32Value": true,
+		"google.protobuf.BoolValue":   true,
+		"google.protobuf.StringValue": true,
+		"google.protobuf.BytesValue":  true,
+	}
+
+	// scalarTypes are the Go types of the values of the scalar kinds, see protoreflect.Value.
+	scalarTypes = map[protoreflect.Kind]reflect.Type{
+		protoreflect.BoolKind:     reflect.TypeOf(false),
+		protoreflect.Int32Kind:    reflect.TypeOf(int32(0)),
+		protoreflect.Sint32Kind:   reflect.TypeOf(int32(0)),
+		protoreflect.Sfixed32Kind: reflect.TypeOf(int32(0)),
+		protoreflect.Int64Kind:    reflect.TypeOf(int64(0)),
+		protoreflect.Sint64Kind:   reflect.TypeOf(int64(0)),
+		protoreflect.Sfixed64Kind: reflect.TypeOf(int64(0)),
+		protoreflect.Uint32Kind:   reflect.TypeOf(uint32(0)),
+		protoreflect.Fixed32Kind:  reflect.TypeOf(uint32(0)),
+		protoreflect.Uint64Kind:   reflect.TypeOf(uint64(0)),
+		protoreflect.Fixed64Kind:  reflect.TypeOf(uint64(0)),
+		protoreflect.FloatKind:    reflect.TypeOf(float32(0)),
+		protoreflect.DoubleKind:   reflect.TypeOf(float64(0)),
+		protoreflect.StringKind:   reflect.TypeOf(""),
+		protoreflect.BytesKind:    reflect.TypeOf([]byte(nil)),
+	}
+)
+
+// toProtoField returns the value of the field of the message m converted from v.
+func (c *Converter[Entity, DTO]) toProtoField(
+	m protoreflect.Message,
+	fd protoreflect.FieldDescriptor,
+	v reflect.Value,
+) (protoreflect.Value, error) {
+	switch {
+	case fd.IsList():
+		if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+			return protoreflect.Value{}, mismatchError(v.Type(), fd)
+		}
+
+		list := m.NewField(fd).List()
+
+		for i := 0; i < v.Len(); i++ {
+			elem, err := c.toProtoValue(fd, v.Index(i), list.NewElement)
+			if err != nil {
+				return protoreflect.Value{}, err
+			}
+
+			list.Append(elem)
+		}
+
+		return protoreflect.ValueOfList(list), nil
+	case fd.IsMap():
+		if v.Kind() != reflect.Map {
+			return protoreflect.Value{}, mismatchError(v.Type(), fd)
+		}
+
+		entries := m.NewField(fd).Map()
+
+		for iter := v.MapRange(); iter.Next(); {
+			key, err := c.toProtoValue(fd.MapKey(), iter.Key(), nil)
+			if err != nil {
+				return protoreflect.Value{}, err
+			}
+
+			value, err := c.toProtoValue(fd.MapValue(), iter.Value(), entries.NewValue)
+			if err != nil {
+				return protoreflect.Value{}, err
+			}
+
+			entries.Set(key.MapKey(), value)
+		}
+
+		return protoreflect.ValueOfMap(entries), nil
+	default:
+		return c.toProtoValue(fd, v, func() protoreflect.Value { return m.NewField(fd) })
+	}
+}
+
+// toProtoValue returns the singular value of the field fd converted from v. newValue returns a new message for the
+// message fields.
+func (c *Converter[Entity, DTO]) toProtoValue(
+	fd protoreflect.FieldDescriptor,
+	v reflect.Value,
+	newValue func() protoreflect.Value,
+) (protoreflect.Value, error) {
+	// a nil pointer in a slice or a map is converted like the zero value, a list holding no nil message.
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			v = reflect.Zero(v.Type().Elem())
+		} else {
+			v = v.Elem()
+		}
+	}
+
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		value := newValue()
+
+		return value, c.toMessageValue(v, value.Message())
+	case protoreflect.EnumKind:
+		number, err := toEnumNumber(fd, v)
+
+		return protoreflect.ValueOfEnum(number), err
+	default:
+		return toScalar(fd, v)
+	}
+}
+
+// toMessageValue sets the message m with v, a time.Time for a timestamp, a time.Duration for a duration, the
+// wrapped value for a wrapper and a struct for the other messages.
+func (c *Converter[Entity, DTO]) toMessageValue(v reflect.Value, m protoreflect.Message) error {
+	fields := m.Descriptor().Fields()
+
+	switch name := m.Descriptor().FullName(); {
+	case name == timestampName:
+		if v.Type() != timeType {
+			return mismatchError(v.Type(), m.Descriptor())
+		}
+
+		t := v.Interface().(time.Time)
+		m.Set(fields.ByNumber(1), protoreflect.ValueOfInt64(t.Unix()))
+		m.Set(fields.ByNumber(2), protoreflect.ValueOfInt32(int32(t.Nanosecond())))
+
+		return nil
+	case name == durationName:
+		if v.Type() != durationType {
+			return mismatchError(v.Type(), m.Descriptor())
+		}
+
+		d := time.Duration(v.Int())
+		m.Set(fields.ByNumber(1), protoreflect.ValueOfInt64(int64(d/time.Second)))
+		m.Set(fields.ByNumber(2), protoreflect.ValueOfInt32(int32(d%time.Second)))
+
+		return nil
+	case wrapperNames[name]:
+		value, err := toScalar(fields.ByNumber(1), v)
+		if err != nil {
+			return err
+		}
+
+		m.Set(fields.ByNumber(1), value)
+
+		return nil
+	case v.Kind() == reflect.Struct:
+		return c.toMessage(v, m, false)
+	default:
+		return mismatchError(v.Type(), m.Descriptor())
+	}
+}
+
+// toScalar returns the value of the scalar field fd converted from v.
+func toScalar(fd protoreflect.FieldDescriptor, v reflect.Value) (protoreflect.Value, error) {
+	typ := scalarTypes[fd.Kind()]
+	if typ == nil || !convertible(v.Type(), typ) {
+		return protoreflect.Value{}, mismatchError(v.Type(), fd)
+	}
+
+	return protoreflect.ValueOf(v.Convert(typ).Interface()), nil
+}
+
+// toEnumNumber returns the number of the value of the enum field fd named by the string v, with or without the
+// prefix of the enum and ignoring the case, or the number held by the integer v.
+func toEnumNumber(fd protoreflect.FieldDescriptor, v reflect.Value) (protoreflect.EnumNumber, error) {
+	switch kindOf(v.Type()) {
+	case reflect.String:
+		name := v.String()
+		if name == "" {
+			return 0, nil
+		}
+
+		var (
+			ed     = fd.Enum()
+			values = ed.Values()
+			upper  = strings.ToUpper(name)
+		)
+
+		for _, candidate := range []string{name, enumPrefix(ed) + upper, upper} {
+			if value := values.ByName(protoreflect.Name(candidate)); value != nil {
+				return value.Number(), nil
+			}
+		}
+
+		if number, err := strconv.ParseInt(name, 10, 32); err == nil {
+			return protoreflect.EnumNumber(number), nil
+		}
+
+		return 0, fmt.Errorf("unknown value %q of enum %s", name, ed.FullName())
+	case reflect.Int:
+		return protoreflect.EnumNumber(v.Convert(reflect.TypeOf(int32(0))).Int()), nil
+	default:
+		return 0, mismatchError(v.Type(), fd)
+	}
+}
+
+// fromProtoField sets dst with the value of the field fd.
+func (c *Converter[Entity, DTO]) fromProtoField(
+	fd protoreflect.FieldDescriptor,
+	value protoreflect.Value,
+	dst reflect.Value,
+) error {
+	switch {
+	case fd.IsList():
+		if dst.Kind() != reflect.Slice {
+			return mismatchError(dst.Type(), fd)
+		}
+
+		list := value.List()
+		elems := reflect.MakeSlice(dst.Type(), list.Len(), list.Len())
+
+		for i := 0; i < list.Len(); i++ {
+			if err := c.fromProtoValue(fd, list.Get(i), elems.Index(i)); err != nil {
+				return err
+			}
+		}
+
+		dst.Set(elems)
+
+		return nil
+	case fd.IsMap():
+		if dst.Kind() != reflect.Map {
+			return mismatchError(dst.Type(), fd)
+		}
+
+		var (
+			entries = value.Map()
+			result  = reflect.MakeMapWithSize(dst.Type(), entries.Len())
+			err     error
+		)
+
+		entries.Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+			key := reflect.New(dst.Type().Key()).Elem()
+			if err = c.fromProtoValue(fd.MapKey(), k.Value(), key); err != nil {
+				return false
+			}
+
+			elem := reflect.New(dst.Type().Elem()).Elem()
+			if err = c.fromProtoValue(fd.MapValue(), v, elem); err != nil {
+				return false
+			}
+
+			result.SetMapIndex(key, elem)
+
+			return true
+		})
+
+		if err != nil {
+			return err
+		}
+
+		dst.Set(result)
+
+		return nil
+	default:
+		return c.fromProtoValue(fd, value, dst)
+	}
+}
+
+// fromProtoValue sets dst with the singular value of the field fd, allocating dst if it is a pointer.
+func (c *Converter[Entity, DTO]) fromProtoValue(
+	fd protoreflect.FieldDescriptor,
+	value protoreflect.Value,
+	dst reflect.Value,
+) error {
+	if dst.Kind() == reflect.Pointer {
+		elem := reflect.New(dst.Type().Elem())
+		if err := c.fromProtoValue(fd, value, elem.Elem()); err != nil {
+			return err
+		}
+
+		dst.Set(elem)
+
+		return nil
+	}
+
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return c.fromMessageValue(value.Message(), dst)
+	case protoreflect.EnumKind:
+		return fromEnumNumber(fd, value.Enum(), dst)
+	default:
+		return fromScalar(fd, value, dst)
+	}
+}
+
+// fromMessageValue sets dst with the message m, see toMessageValue.
+func (c *Converter[Entity, DTO]) fromMessageValue(m protoreflect.Message, dst reflect.Value) error {
+	fields := m.Descriptor().Fields()
+
+	switch name := m.Descriptor().FullName(); {
+	case name == timestampName:
+		if dst.Type() != timeType {
+			return mismatchError(dst.Type(), m.Descriptor())
+		}
+
+		seconds, nanos := m.Get(fields.ByNumber(1)).Int(), m.Get(fields.ByNumber(2)).Int()
+		dst.Set(reflect.ValueOf(time.Unix(seconds, nanos).UTC()))
+
+		return nil
+	case name == durationName:
+		if dst.Type() != durationType {
+			return mismatchError(dst.Type(), m.Descriptor())
+		}
+
+		seconds, nanos := m.Get(fields.ByNumber(1)).Int(), m.Get(fields.ByNumber(2)).Int()
+		dst.SetInt(int64(time.Duration(seconds)*time.Second + time.Duration(nanos)))
+
+		return nil
+	case wrapperNames[name]:
+		return fromScalar(fields.ByNumber(1), m.Get(fields.ByNumber(1)), dst)
+	case dst.Kind() == reflect.Struct:
+		return c.toStruct(m, dst, false)
+	default:
+		return mismatchError(dst.Type(), m.Descriptor())
+	}
+}
+
+// fromScalar sets dst with the value of the scalar field fd.
+func fromScalar(fd protoreflect.FieldDescriptor, value protoreflect.Value, dst reflect.Value) error {
+	src := reflect.ValueOf(value.Interface())
+	if !convertible(src.Type(), dst.Type()) {
+		return mismatchError(dst.Type(), fd)
+	}
+
+	dst.Set(src.Convert(dst.Type()))
+
+	return nil
+}
+
+// fromEnumNumber sets dst with the lower case name of the value of the enum field fd without the prefix of the enum,
+// or with its number, see toEnumNumber.
+func fromEnumNumber(fd protoreflect.FieldDescriptor, number protoreflect.EnumNumber, dst reflect.Value) error {
+	switch kindOf(dst.Type()) {
+	case reflect.String:
+		var (
+			value = fd.Enum().Values().ByNumber(number)
+			name  string
+		)
+
+		switch {
+		case number == 0:
+		case value != nil:
+			name = strings.ToLower(strings.TrimPrefix(string(value.Name()), enumPrefix(fd.Enum())))
+		default:
+			// the unknown values of open enums keep their number.
+			name = strconv.Itoa(int(number))
+		}
+
+		dst.SetString(name)
+
+		return nil
+	case reflect.Int:
+		dst.Set(reflect.ValueOf(int64(number)).Convert(dst.Type()))
+
+		return nil
+	default:
+		return mismatchError(dst.Type(), fd)
+	}
+}
+
+// enumPrefix returns the prefix of the values of the enum recommended by the protobuf style guide, the name of the
+// enum in upper snake case followed by an underscore, e.g. "ARTICLE_STATUS_" for ArticleStatus.
+func enumPrefix(ed protoreflect.EnumDescriptor) string {
+	var (
+		name   = []rune(string(ed.Name()))
+		prefix strings.Builder
+	)
+
+	for i, r := range name {
+		if i > 0 && unicode.IsUpper(r) && (unicode.IsLower(name[i-1]) || unicode.IsDigit(name[i-1])) {
+			prefix.WriteByte('_')
+		}
+
+		prefix.WriteRune(unicode.ToUpper(r))
+	}
+
+	prefix.WriteByte('_')
+
+	return prefix.String()
+}
+
+// convertible reports whether the values of the src type are converted to the dst type: the booleans, the numbers,
+// the strings and the byte slices between themselves.
+func convertible(src, dst reflect.Type) bool {
+	return kindOf(src) == kindOf(dst) && kindOf(src) != reflect.Invalid && src.ConvertibleTo(dst)
+}
+
+// kindOf returns the kind of the values of the type for convertible: reflect.Int for all the numbers, reflect.Slice
+// for the byte slices, reflect.Bool and reflect.String, or reflect.Invalid.
+func kindOf(typ reflect.Type) reflect.Kind {
+	switch typ.Kind() {
+	case reflect.Bool, reflect.String:
+		return typ.Kind()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return reflect.Int
+	case reflect.Slice:
+		if typ.Elem().Kind() == reflect.Uint8 {
+			return reflect.Slice
+		}
+	}
+
+	return reflect.Invalid
+}
+
+// mismatchError returns the error of a Go type that cannot be converted to and from a protobuf descriptor.
+func mismatchError(typ reflect.Type, desc protoreflect.Descriptor) error {
+	return fmt.Errorf("cannot convert %s to and from %s", typ, desc.FullName())
+}
@@ -0,0 +1,18 @@
+module github.com/infevocorp/goflexstore/protobuf
+
+go 1.21.6
+
+require (
+	github.com/infevocorp/goflexstore v1.1.0
+	github.com/stretchr/testify v1.8.4
+	google.golang.org/protobuf v1.34.2
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pkg/errors v0.9.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/infevocorp/goflexstore => ../
@@ -0,0 +1,6 @@
+package testpb
+
+// GetID implements store.Entity, for Article to be the DTO of a converter.Converter.
+func (x *Article) GetID() int64 {
+	return x.GetId()
+}
@@ -0,0 +1,40 @@
+package query
+
+import "time"
+
+// AsOfParam makes a read return the state of the entities as of a past time, e.g. to audit an entity or to
+// reproduce a report. It requires the store to be configured for temporal tables.
+//
+// Fields:
+//   - Time: The time of the state read.
+type AsOfParam struct {
+	Time time.Time
+}
+
+// ParamType returns the type of this parameter, which is `asof`.
+// This method is used to distinguish AsOfParam from other types of query parameters.
+func (p AsOfParam) ParamType() string {
+	return TypeAsOf
+}
+
+// AsOf creates a new AsOfParam reading the state of the entities as of t.
+//
+// The other params apply to the past state: a filter on a field matches the value the field had at t. The
+// past states are read from the system-versioned tables of the database, or from history tables holding the
+// past versions of the rows with their validity period, depending on the configuration of the store.
+//
+// Parameters:
+//   - t: The time of the state read.
+//
+// Returns:
+// An AsOfParam.
+//
+// Example:
+// Reading an order as it was when it was invoiced:
+//
+//	order, err := orderStore.Get(ctx, query.Filter("ID", invoice.OrderID), query.AsOf(invoice.CreatedAt))
+func AsOf(t time.Time) AsOfParam {
+	return AsOfParam{
+		Time: t,
+	}
+}
@@ -0,0 +1,22 @@
+package query_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infevocorp/goflexstore/query"
+)
+
+func Test_AsOf(t *testing.T) {
+	t.Run("param-type-should-be-asof", func(t *testing.T) {
+		assert.Equal(t, query.TypeAsOf, query.AsOfParam{}.ParamType())
+	})
+
+	t.Run("should-create-asof-param", func(t *testing.T) {
+		at := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+		assert.Equal(t, query.AsOfParam{Time: at}, query.AsOf(at))
+	})
+}
@@ -0,0 +1,35 @@
+package query
+
+// CascadeParam specifies the associations to be deleted together with the entities matched by a delete.
+// It is ignored by the other operations.
+//
+// Fields:
+//   - Associations: The names of the associations to delete, as named in the entity's data model.
+type CascadeParam struct {
+	Associations []string
+}
+
+// ParamType returns the type of this parameter, which is `cascade`.
+// This method is used to distinguish CascadeParam from other types of query parameters.
+func (p CascadeParam) ParamType() string {
+	return TypeCascade
+}
+
+// Cascade creates a new CascadeParam deleting the given associations together with the deleted entities.
+// Stores soft delete the associations supporting it, like the entities themselves.
+//
+// Parameters:
+//   - associations: The names of the associations to delete.
+//
+// Returns:
+// A CascadeParam containing the provided association names.
+//
+// Example:
+// Deleting an article along with the rows linking it to its tags:
+//
+//	err := articleStore.Delete(ctx, filters.IDs(id), query.Cascade("Tags"))
+func Cascade(associations ...string) CascadeParam {
+	return CascadeParam{
+		Associations: associations,
+	}
+}
@@ -0,0 +1,23 @@
+package query_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infevocorp/goflexstore/query"
+)
+
+func Test_Cascade(t *testing.T) {
+	t.Run("param-type-should-be-cascade", func(t *testing.T) {
+		assert.Equal(t, query.TypeCascade, query.CascadeParam{}.ParamType())
+	})
+
+	t.Run("should-create-cascade-param", func(t *testing.T) {
+		c := query.Cascade("Tags", "Comments")
+
+		assert.Equal(t, query.CascadeParam{
+			Associations: []string{"Tags", "Comments"},
+		}, c)
+	})
+}
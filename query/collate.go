@@ -0,0 +1,44 @@
+package query
+
+// CollateParam forces the collation used by the comparisons of a field in a query.
+//
+// Fields:
+//   - Name: The name of the field whose comparisons use the collation.
+//   - Collation: The name of the collation, as known by the database.
+type CollateParam struct {
+	Name      string
+	Collation string
+}
+
+// ParamType returns the type of this parameter, which is `collate`.
+// This method is used to distinguish CollateParam from other types of query parameters.
+func (p CollateParam) ParamType() string {
+	return TypeCollate
+}
+
+// Collate creates a new CollateParam making the filters on the field, including the ones of OR params and
+// having conditions, compare the values with the given collation instead of the one of the column.
+// It does not change the sort order of OrderBy params.
+//
+// Collation names depend on the database, e.g. utf8mb4_bin on MySQL, "C" on PostgreSQL or BINARY on SQLite.
+//
+// Parameters:
+//   - fieldName: The name of the field whose comparisons use the collation.
+//   - collation: The name of the collation.
+//
+// Returns:
+// A CollateParam.
+//
+// Example:
+// Matching a login case-sensitively on a case-insensitive MySQL column:
+//
+//	user, err := userStore.Get(ctx,
+//		query.Filter("Login", login),
+//		query.Collate("Login", "utf8mb4_bin"),
+//	)
+func Collate(fieldName, collation string) CollateParam {
+	return CollateParam{
+		Name:      fieldName,
+		Collation: collation,
+	}
+}
@@ -0,0 +1,22 @@
+package query_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infevocorp/goflexstore/query"
+)
+
+func Test_Collate(t *testing.T) {
+	t.Run("param-type-should-be-collate", func(t *testing.T) {
+		assert.Equal(t, query.TypeCollate, query.CollateParam{}.ParamType())
+	})
+
+	t.Run("should-create-collate-param", func(t *testing.T) {
+		assert.Equal(t, query.CollateParam{
+			Name:      "Login",
+			Collation: "utf8mb4_bin",
+		}, query.Collate("Login", "utf8mb4_bin"))
+	})
+}
@@ -0,0 +1,49 @@
+package query
+
+// CursorParam specifies a page of a keyset, or cursor, pagination when querying a data store.
+// Unlike an offset, the cursor holds the values of the ordered fields of the last entity of the previous page,
+// and the page is read by filtering the entities after them, so reading a deep page costs as much as the first.
+//
+// Fields:
+//   - After: The opaque cursor returned with the previous page, or empty for the first page.
+//   - Size: The maximum number of items to return in the page.
+//   - OrderBy: The ordering of the pages, which the stores complete with the primary key to break the ties.
+type CursorParam struct {
+	After   string
+	Size    int
+	OrderBy []OrderByParam
+}
+
+// ParamType returns the type of this parameter, which is `cursor`.
+// This method helps to identify CursorParam as the parameter type for keyset pagination purposes.
+func (p CursorParam) ParamType() string {
+	return TypeCursor
+}
+
+// Cursor creates a new CursorParam reading the page of at most size items following the cursor after, in the
+// ordering of the orderBy fields followed by the primary key.
+// The cursors are issued by the stores with the pages, and bound to their ordering: the params of the next pages
+// must keep the same ordering. Cursor replaces the OrderBy and Paginate params of the query.
+//
+// Parameters:
+//   - after: The cursor of the previous page, or empty for the first page.
+//   - size: The maximum number of items of the page.
+//   - orderBy: The fields ordering the pages.
+//
+// Returns:
+// A CursorParam configured with the specified cursor, size and ordering.
+//
+// Example:
+// Reading the pages of the most recent articles:
+//
+//	params := query.NewParams(
+//		query.Filter("Status", "published"),
+//		query.Cursor(r.URL.Query().Get("cursor"), 20, query.OrderBy("CreatedAt", true)),
+//	)
+func Cursor(after string, size int, orderBy ...OrderByParam) Param {
+	return CursorParam{
+		After:   after,
+		Size:    size,
+		OrderBy: orderBy,
+	}
+}
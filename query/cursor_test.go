@@ -0,0 +1,25 @@
+package query_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infevocorp/goflexstore/query"
+)
+
+func Test_Cursor(t *testing.T) {
+	t.Run("param-type-should-be-cursor", func(t *testing.T) {
+		assert.Equal(t, query.TypeCursor, query.CursorParam{}.ParamType())
+	})
+
+	t.Run("should-create-cursor-param", func(t *testing.T) {
+		p := query.Cursor("abc", 20, query.OrderBy("CreatedAt", true))
+
+		assert.Equal(t, query.CursorParam{
+			After:   "abc",
+			Size:    20,
+			OrderBy: []query.OrderByParam{{Name: "CreatedAt", Desc: true}},
+		}, p)
+	})
+}
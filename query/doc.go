@@ -30,6 +30,35 @@
 // orders them by 'CreatedAt' in descending order, groups them by 'Category', and applies
 // pagination to retrieve the first 10 records.
 //
+// The filters of the params are combined with AND logic. OR and AND groups nest to express any other boolean
+// combination, and Not negates a filter or a whole group:
+//
+//	query.OR(
+//		query.AND(query.Filter("AuthorID", 1), query.Filter("Status", "Draft")),
+//		query.Filter("Status", "Published"),
+//	)
+//
+//	query.Not(query.AND(query.Filter("AuthorID", 1), query.Filter("Status", "Draft")))
+//
+// Queries executed repeatedly with different values can be compiled once into a Template,
+// whose Bind method produces the Params for each execution:
+//
+//	var byStatus = query.Compile(
+//		query.Filter("Status", ""),
+//		query.OrderBy("CreatedAt", true),
+//	)
+//
+//	params, err := byStatus.Bind("Active")
+//
+// Params are encoded in JSON with encoding/json, in an envelope holding the version of the encoding and the type of
+// each param, so that queries can be persisted, passed between services, or logged and replayed later. Custom param
+// types are registered with RegisterParamType to be decoded:
+//
+//	data, err := json.Marshal(query.NewParams(query.Filter("Status", "Active"), query.Paginate(0, 10)))
+//
+//	var params query.Params
+//	err = json.Unmarshal(data, &params)
+//
 // The query package is versatile and can be adapted to various data retrieval needs, making it
 // a valuable tool for developers working with data stores in Go.
 package query
@@ -0,0 +1,53 @@
+package query
+
+// FullTextParam filters the entities whose text fields match a full-text search, e.g. for search boxes.
+//
+// Fields:
+//   - Names: The names of the fields searched, covered by a full-text index of the database.
+//   - Text: The searched text, in natural language.
+//   - ByRelevance: Whether the entities are ordered by decreasing relevance to the text, before the orderings
+//     of the OrderBy params.
+type FullTextParam struct {
+	Names       []string
+	Text        string
+	ByRelevance bool
+}
+
+// ParamType returns the type of this parameter, which is `fulltext`.
+// This method is used to distinguish FullTextParam from other types of query parameters.
+func (p FullTextParam) ParamType() string {
+	return TypeFullText
+}
+
+// OrderByRelevance returns a copy of the param ordering the entities by decreasing relevance to the text.
+func (p FullTextParam) OrderByRelevance() FullTextParam {
+	p.ByRelevance = true
+
+	return p
+}
+
+// FullText creates a new FullTextParam filtering the entities whose fields match the text.
+//
+// The text is matched in natural language by the full-text search of the database, e.g. MATCH ... AGAINST on
+// MySQL, which requires a FULLTEXT index on exactly the searched columns, or text search vectors on PostgreSQL.
+//
+// Parameters:
+//   - text: The searched text.
+//   - fieldNames: The names of the fields searched.
+//
+// Returns:
+// A FullTextParam.
+//
+// Example:
+// Searching the published articles, the most relevant first:
+//
+//	articles, err := articleStore.List(ctx,
+//		query.FullText("golang generics", "Title", "Body").OrderByRelevance(),
+//		query.Filter("Status", "published"),
+//	)
+func FullText(text string, fieldNames ...string) FullTextParam {
+	return FullTextParam{
+		Names: fieldNames,
+		Text:  text,
+	}
+}
@@ -0,0 +1,29 @@
+package query_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infevocorp/goflexstore/query"
+)
+
+func Test_FullText(t *testing.T) {
+	t.Run("param-type-should-be-fulltext", func(t *testing.T) {
+		assert.Equal(t, query.TypeFullText, query.FullTextParam{}.ParamType())
+	})
+
+	t.Run("should-create-fulltext-param", func(t *testing.T) {
+		assert.Equal(t, query.FullTextParam{
+			Names: []string{"Title", "Body"},
+			Text:  "golang",
+		}, query.FullText("golang", "Title", "Body"))
+	})
+
+	t.Run("should-order-by-relevance-without-modifying-receiver", func(t *testing.T) {
+		p := query.FullText("golang", "Title")
+
+		assert.True(t, p.OrderByRelevance().ByRelevance)
+		assert.False(t, p.ByRelevance)
+	})
+}
@@ -0,0 +1,93 @@
+package query
+
+// WithinRadiusParam filters the entities whose location is within a distance of a point, e.g. for
+// "stores near me" queries.
+//
+// Fields:
+//   - Name: The name of the field holding the location of the entities.
+//   - Lat: The latitude of the point, in degrees.
+//   - Lng: The longitude of the point, in degrees.
+//   - Meters: The maximum distance to the point, in meters.
+type WithinRadiusParam struct {
+	Name   string
+	Lat    float64
+	Lng    float64
+	Meters float64
+}
+
+// ParamType returns the type of this parameter, which is `withinradius`.
+// This method is used to distinguish WithinRadiusParam from other types of query parameters.
+func (p WithinRadiusParam) ParamType() string {
+	return TypeWithinRadius
+}
+
+// WithinRadius creates a new WithinRadiusParam filtering the entities whose location is at most meters away
+// from the point at lat and lng.
+//
+// Parameters:
+//   - fieldName: The name of the field holding the location of the entities.
+//   - lat: The latitude of the point, in degrees.
+//   - lng: The longitude of the point, in degrees.
+//   - meters: The maximum distance to the point, in meters.
+//
+// Returns:
+// A WithinRadiusParam.
+//
+// Example:
+// Listing the stores within 2 km of the user:
+//
+//	stores, err := storeStore.List(ctx, query.WithinRadius("Location", 48.8566, 2.3522, 2000))
+func WithinRadius(fieldName string, lat, lng, meters float64) WithinRadiusParam {
+	return WithinRadiusParam{
+		Name:   fieldName,
+		Lat:    lat,
+		Lng:    lng,
+		Meters: meters,
+	}
+}
+
+// InBoundingBoxParam filters the entities whose location is within a box delimited by two latitudes and
+// two longitudes, e.g. the area displayed by a map.
+//
+// Fields:
+//   - Name: The name of the field holding the location of the entities.
+//   - MinLat, MinLng: The latitude and longitude of the south west corner of the box, in degrees.
+//   - MaxLat, MaxLng: The latitude and longitude of the north east corner of the box, in degrees.
+type InBoundingBoxParam struct {
+	Name   string
+	MinLat float64
+	MinLng float64
+	MaxLat float64
+	MaxLng float64
+}
+
+// ParamType returns the type of this parameter, which is `inboundingbox`.
+// This method is used to distinguish InBoundingBoxParam from other types of query parameters.
+func (p InBoundingBoxParam) ParamType() string {
+	return TypeInBoundingBox
+}
+
+// InBoundingBox creates a new InBoundingBoxParam filtering the entities whose location is within the box
+// delimited by the south west corner at minLat and minLng and the north east corner at maxLat and maxLng.
+//
+// Parameters:
+//   - fieldName: The name of the field holding the location of the entities.
+//   - minLat, minLng: The latitude and longitude of the south west corner of the box, in degrees.
+//   - maxLat, maxLng: The latitude and longitude of the north east corner of the box, in degrees.
+//
+// Returns:
+// An InBoundingBoxParam.
+//
+// Example:
+// Listing the stores displayed by a map:
+//
+//	stores, err := storeStore.List(ctx, query.InBoundingBox("Location", 48.80, 2.25, 48.90, 2.42))
+func InBoundingBox(fieldName string, minLat, minLng, maxLat, maxLng float64) InBoundingBoxParam {
+	return InBoundingBoxParam{
+		Name:   fieldName,
+		MinLat: minLat,
+		MinLng: minLng,
+		MaxLat: maxLat,
+		MaxLng: maxLng,
+	}
+}
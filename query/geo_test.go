@@ -0,0 +1,40 @@
+package query_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infevocorp/goflexstore/query"
+)
+
+func Test_WithinRadius(t *testing.T) {
+	t.Run("param-type-should-be-withinradius", func(t *testing.T) {
+		assert.Equal(t, query.TypeWithinRadius, query.WithinRadiusParam{}.ParamType())
+	})
+
+	t.Run("should-create-withinradius-param", func(t *testing.T) {
+		assert.Equal(t, query.WithinRadiusParam{
+			Name:   "Location",
+			Lat:    48.8566,
+			Lng:    2.3522,
+			Meters: 2000,
+		}, query.WithinRadius("Location", 48.8566, 2.3522, 2000))
+	})
+}
+
+func Test_InBoundingBox(t *testing.T) {
+	t.Run("param-type-should-be-inboundingbox", func(t *testing.T) {
+		assert.Equal(t, query.TypeInBoundingBox, query.InBoundingBoxParam{}.ParamType())
+	})
+
+	t.Run("should-create-inboundingbox-param", func(t *testing.T) {
+		assert.Equal(t, query.InBoundingBoxParam{
+			Name:   "Location",
+			MinLat: 48.80,
+			MinLng: 2.25,
+			MaxLat: 48.90,
+			MaxLng: 2.42,
+		}, query.InBoundingBox("Location", 48.80, 2.25, 48.90, 2.42))
+	})
+}
@@ -0,0 +1,130 @@
+package query
+
+import "fmt"
+
+// GroupParam represents a group of conditions combined with AND or OR logic.
+// Unlike ORParam, its conditions can themselves be groups, so that arbitrary boolean expressions are built by
+// nesting the groups created by AND and OR.
+//
+// Fields:
+//   - OR: Whether the conditions are combined with OR logic, rather than AND logic.
+//   - Params: The conditions of the group, each a FilterParam, an ORParam, a GroupParam, a NotParam or
+//     a RawParam.
+type GroupParam struct {
+	OR     bool
+	Params []Param
+}
+
+// ParamType returns the type of this parameter, which is `group`.
+// This method allows differentiating GroupParam from other types of query parameters.
+func (p GroupParam) ParamType() string {
+	return TypeGroup
+}
+
+// Filters returns the filters of the group and of its nested conditions, in order of appearance.
+func (p GroupParam) Filters() []FilterParam {
+	var filters []FilterParam
+
+	for _, param := range p.Params {
+		filters = appendFilters(filters, param)
+	}
+
+	return filters
+}
+
+// bind returns a copy of the group whose filter values, in the order of Filters, are taken from values.
+// It returns the values left.
+func (p GroupParam) bind(values []any) (GroupParam, []any) {
+	params := make([]Param, len(p.Params))
+
+	for i, param := range p.Params {
+		params[i], values = bindCondition(param, values)
+	}
+
+	return GroupParam{OR: p.OR, Params: params}, values
+}
+
+// appendFilters appends the filters of the condition, nested ones included, to filters.
+func appendFilters(filters []FilterParam, param Param) []FilterParam {
+	switch c := param.(type) {
+	case FilterParam:
+		return append(filters, c)
+	case ORParam:
+		return append(filters, c.Params...)
+	case GroupParam:
+		return append(filters, c.Filters()...)
+	case NotParam:
+		return appendFilters(filters, c.Param)
+	default:
+		return filters
+	}
+}
+
+// bindCondition returns a copy of the condition whose filter values, in order of appearance, are taken
+// from values. It returns the values left.
+func bindCondition(param Param, values []any) (Param, []any) {
+	switch c := param.(type) {
+	case FilterParam:
+		c.Value = values[0]
+
+		return c, values[1:]
+	case ORParam:
+		filters := make([]FilterParam, len(c.Params))
+
+		for j, filter := range c.Params {
+			filter.Value, values = values[0], values[1:]
+			filters[j] = filter
+		}
+
+		return ORParam{Params: filters}, values
+	case GroupParam:
+		return c.bind(values)
+	case NotParam:
+		c.Param, values = bindCondition(c.Param, values)
+
+		return c, values
+	default:
+		return param, values
+	}
+}
+
+// AND creates a new GroupParam, which is a logical AND combination of the provided conditions.
+//
+// Top level parameters are already combined with AND logic: AND is meant to be nested in OR groups.
+//
+// Parameters:
+//   - params: A variable number of Param, each of which should be a FilterParam, an ORParam, a GroupParam,
+//     a NotParam or a RawParam.
+//
+// Returns: A GroupParam that encapsulates the provided conditions in an AND logic.
+//
+// Example:
+// Matching the published articles of an author, or the drafts of any author created since yesterday:
+//
+//	query.NewParams(
+//	  query.OR(
+//	    query.AND(query.Filter("AuthorID", 1), query.Filter("Status", "published")),
+//	    query.AND(query.Filter("Status", "draft"), query.Filter("CreatedAt", yesterday).WithOP(query.GTE)),
+//	  ),
+//	)
+//
+// Note: The function panics if any parameter provided is not a condition.
+func AND(params ...Param) Param {
+	return GroupParam{
+		Params: conditions("AND", params),
+	}
+}
+
+// conditions checks that params are conditions of a group, and returns them.
+func conditions(op string, params []Param) []Param {
+	for _, p := range params {
+		switch p.(type) {
+		case FilterParam, ORParam, GroupParam, NotParam, RawParam:
+		default:
+			panic(fmt.Errorf("%s only accept FilterParam, ORParam, GroupParam, NotParam or RawParam but got %s",
+				op, p.ParamType()))
+		}
+	}
+
+	return params
+}
@@ -0,0 +1,64 @@
+package query_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infevocorp/goflexstore/query"
+)
+
+func Test_Group(t *testing.T) {
+	t.Run("param-type-should-be-group", func(t *testing.T) {
+		assert.Equal(t, query.TypeGroup, query.GroupParam{}.ParamType())
+	})
+
+	t.Run("should-create-and-group", func(t *testing.T) {
+		g := query.AND(
+			query.Filter("id", 1),
+			query.OR(query.Filter("name", "john"), query.Filter("name", "jane")),
+		)
+
+		assert.Equal(t, query.GroupParam{
+			Params: []query.Param{
+				query.Filter("id", 1),
+				query.ORParam{Params: []query.FilterParam{query.Filter("name", "john"), query.Filter("name", "jane")}},
+			},
+		}, g)
+	})
+
+	t.Run("or-should-create-group-of-groups", func(t *testing.T) {
+		g := query.OR(
+			query.AND(query.Filter("id", 1), query.Filter("name", "john")),
+			query.Filter("id", 2),
+		)
+
+		assert.Equal(t, query.GroupParam{
+			OR: true,
+			Params: []query.Param{
+				query.GroupParam{Params: []query.Param{query.Filter("id", 1), query.Filter("name", "john")}},
+				query.Filter("id", 2),
+			},
+		}, g)
+	})
+
+	t.Run("should-return-nested-filters-in-order", func(t *testing.T) {
+		g := query.OR(
+			query.AND(query.Filter("id", 1), query.OR(query.Filter("age", 2), query.Filter("age", 3))),
+			query.Filter("id", 4),
+		).(query.GroupParam)
+
+		assert.Equal(t, []query.FilterParam{
+			query.Filter("id", 1),
+			query.Filter("age", 2),
+			query.Filter("age", 3),
+			query.Filter("id", 4),
+		}, g.Filters())
+	})
+
+	t.Run("should-panic-if-param-is-not-condition", func(t *testing.T) {
+		assert.PanicsWithError(t, "AND only accept FilterParam, ORParam, GroupParam, NotParam or RawParam but got paginate", func() {
+			query.AND(query.Filter("id", 1), query.Paginate(0, 10))
+		})
+	})
+}
@@ -0,0 +1,480 @@
+package query
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// EncodingVersion is the version of the JSON encoding of the params written by Params.MarshalJSON.
+// Params.UnmarshalJSON decodes the params encoded with this version or an older one.
+const EncodingVersion = 1
+
+var (
+	// ErrUnknownParamType is returned when encoding or decoding a param whose type is neither a built-in one nor
+	// registered with RegisterParamType.
+	ErrUnknownParamType = errors.New("unknown param type")
+
+	// ErrUnsupportedEncoding is returned when decoding params encoded with a version this package does not support,
+	// e.g. by a newer version of the package.
+	ErrUnsupportedEncoding = errors.New("unsupported params encoding")
+)
+
+// paramsEnvelope is the JSON encoding of Params.
+type paramsEnvelope struct {
+	Version int            `json:"version"`
+	Params  []encodedParam `json:"params"`
+}
+
+// encodedParam is the JSON encoding of a param, along with its type to decode it.
+type encodedParam struct {
+	Type  string          `json:"type"`
+	Param json.RawMessage `json:"param"`
+}
+
+// paramDecoder decodes the JSON encoding of a param of a type.
+type paramDecoder func(data []byte) (Param, error)
+
+// paramTypes holds the decoders of the param types, by the names returned by their ParamType method.
+var paramTypes = struct {
+	sync.RWMutex
+	decoders map[string]paramDecoder
+}{
+	decoders: map[string]paramDecoder{
+		TypeFilter:        decodeParamAs[FilterParam],
+		TypeGroupBy:       decodeParamAs[GroupByParam],
+		TypeSelect:        decodeParamAs[SelectParam],
+		TypeOR:            decodeParamAs[ORParam],
+		TypeGroup:         decodeParamAs[GroupParam],
+		TypeNot:           decodeParamAs[NotParam],
+		TypeRaw:           decodeParamAs[RawParam],
+		TypeOrderBy:       decodeParamAs[OrderByParam],
+		TypePaginate:      decodeParamAs[PaginateParam],
+		TypeCursor:        decodeParamAs[CursorParam],
+		TypePreload:       decodeParamAs[PreloadParam],
+		TypePreloadAll:    decodeParamAs[PreloadAllParam],
+		TypeWithLock:      decodeParamAs[WithLockParam],
+		TypeCascade:       decodeParamAs[CascadeParam],
+		TypeSample:        decodeParamAs[SampleParam],
+		TypeWith:          decodeParamAs[WithParam],
+		TypeWithinRadius:  decodeParamAs[WithinRadiusParam],
+		TypeInBoundingBox: decodeParamAs[InBoundingBoxParam],
+		TypeCollate:       decodeParamAs[CollateParam],
+		TypeFullText:      decodeParamAs[FullTextParam],
+		TypeAsOf:          decodeParamAs[AsOfParam],
+		TypeUnscoped:      decodeParamAs[UnscopedParam],
+	},
+}
+
+// RegisterParamType registers the custom param type P, for the params of this type to be encoded and decoded
+// with Params. P is a value type, not a pointer, whose ParamType method returns the same name for its zero value,
+// and it is encoded and decoded by encoding/json. It replaces the type registered with the same name, if any.
+//
+// Example:
+//
+//	func init() {
+//		query.RegisterParamType[TenantParam]()
+//	}
+func RegisterParamType[P Param]() {
+	var zero P
+
+	paramTypes.Lock()
+	defer paramTypes.Unlock()
+
+	paramTypes.decoders[zero.ParamType()] = decodeParamAs[P]
+}
+
+// decodeParamAs decodes the JSON encoding of a param of type P.
+func decodeParamAs[P Param](data []byte) (Param, error) {
+	var param P
+	if err := json.Unmarshal(data, &param); err != nil {
+		return nil, err
+	}
+
+	return param, nil
+}
+
+// paramDecoderOf returns the decoder of the param type.
+func paramDecoderOf(paramType string) (paramDecoder, error) {
+	paramTypes.RLock()
+	defer paramTypes.RUnlock()
+
+	decode, ok := paramTypes.decoders[paramType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownParamType, paramType)
+	}
+
+	return decode, nil
+}
+
+// MarshalJSON encodes the params in JSON, within an envelope holding the EncodingVersion and the type of each
+// param, e.g. to persist a query, to pass it to another service, or to log it and replay it later:
+//
+//	{"version":1,"params":[{"type":"filter","param":{"Name":"Status","Operator":"EQ","Value":"published"}}]}
+//
+// The params must be of the built-in types or of the types registered with RegisterParamType, for them to be
+// decoded by UnmarshalJSON. The Template the params were bound from, if any, is not encoded.
+func (p Params) MarshalJSON() ([]byte, error) {
+	params, err := encodeParams(p.params)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(paramsEnvelope{Version: EncodingVersion, Params: params})
+}
+
+// UnmarshalJSON decodes params encoded by MarshalJSON. It returns ErrUnsupportedEncoding for an envelope of a newer
+// EncodingVersion, and ErrUnknownParamType for a param of a type not registered with RegisterParamType.
+//
+// The values of the filters and of the raw conditions are decoded from their JSON representation: the integers
+// are decoded as int64, the other numbers as float64, and the arrays as []any, while the types encoded as strings,
+// such as time.Time, are decoded as strings. Stores comparing the values in SQL are not affected, but values that
+// must keep their type should be converted by the caller, or carried by a param type registered with
+// RegisterParamType.
+//
+// Example:
+//
+//	var params query.Params
+//	if err := json.Unmarshal(data, &params); err != nil {
+//		return err
+//	}
+//
+//	articles, err := articleStore.List(ctx, params)
+func (p *Params) UnmarshalJSON(data []byte) error {
+	var envelope paramsEnvelope
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return err
+	}
+
+	if envelope.Version < 1 || envelope.Version > EncodingVersion {
+		return fmt.Errorf("%w: version %d, expected up to %d", ErrUnsupportedEncoding, envelope.Version, EncodingVersion)
+	}
+
+	params, err := decodeParams(envelope.Params)
+	if err != nil {
+		return err
+	}
+
+	*p = newParams(params)
+
+	return nil
+}
+
+// encodeParams encodes the params with their type, flattening the nested Params.
+func encodeParams(params []Param) ([]encodedParam, error) {
+	encoded := make([]encodedParam, 0, len(params))
+
+	for _, param := range params {
+		if nested, ok := param.(Params); ok {
+			params, err := encodeParams(nested.params)
+			if err != nil {
+				return nil, err
+			}
+
+			encoded = append(encoded, params...)
+
+			continue
+		}
+
+		e, err := encodeParam(param)
+		if err != nil {
+			return nil, err
+		}
+
+		encoded = append(encoded, e)
+	}
+
+	return encoded, nil
+}
+
+// encodeParam encodes the param with its type, which must be registered to be decoded.
+func encodeParam(param Param) (encodedParam, error) {
+	if param == nil {
+		return encodedParam{}, errors.New("cannot encode nil param")
+	}
+
+	if _, err := paramDecoderOf(param.ParamType()); err != nil {
+		return encodedParam{}, err
+	}
+
+	data, err := json.Marshal(param)
+	if err != nil {
+		return encodedParam{}, fmt.Errorf("cannot encode %s param: %w", param.ParamType(), err)
+	}
+
+	return encodedParam{Type: param.ParamType(), Param: data}, nil
+}
+
+// decodeParams decodes the params encoded by encodeParams.
+func decodeParams(encoded []encodedParam) ([]Param, error) {
+	if len(encoded) == 0 {
+		return nil, nil
+	}
+
+	params := make([]Param, len(encoded))
+
+	for i, e := range encoded {
+		param, err := decodeParam(e)
+		if err != nil {
+			return nil, err
+		}
+
+		params[i] = param
+	}
+
+	return params, nil
+}
+
+// decodeParam decodes the param encoded by encodeParam.
+func decodeParam(e encodedParam) (Param, error) {
+	decode, err := paramDecoderOf(e.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	param, err := decode(e.Param)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode %s param: %w", e.Type, err)
+	}
+
+	return param, nil
+}
+
+// decodeValue decodes a JSON value, holding the integers as int64 and the other numbers as float64.
+func decodeValue(data json.RawMessage) (any, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	return decodeNumbers(value), nil
+}
+
+// decodeNumbers replaces the json.Number of the decoded value, its arrays and objects included, by int64 or
+// float64.
+func decodeNumbers(value any) any {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+
+		f, _ := v.Float64()
+
+		return f
+	case []any:
+		for i := range v {
+			v[i] = decodeNumbers(v[i])
+		}
+	case map[string]any:
+		for k := range v {
+			v[k] = decodeNumbers(v[k])
+		}
+	}
+
+	return value
+}
+
+// UnmarshalJSON decodes the filter, its value as described by Params.UnmarshalJSON.
+func (p *FilterParam) UnmarshalJSON(data []byte) error {
+	var v struct {
+		Name     string
+		Operator Operator
+		Value    json.RawMessage
+	}
+
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	value, err := decodeValue(v.Value)
+	if err != nil {
+		return err
+	}
+
+	*p = FilterParam{Name: v.Name, Operator: v.Operator, Value: value}
+
+	return nil
+}
+
+// UnmarshalJSON decodes the raw condition, its args as described by Params.UnmarshalJSON.
+func (p *RawParam) UnmarshalJSON(data []byte) error {
+	var v struct {
+		SQL  string
+		Args []json.RawMessage
+	}
+
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	var args []any
+
+	for _, arg := range v.Args {
+		value, err := decodeValue(arg)
+		if err != nil {
+			return err
+		}
+
+		args = append(args, value)
+	}
+
+	*p = RawParam{SQL: v.SQL, Args: args}
+
+	return nil
+}
+
+// groupJSON is the JSON encoding of GroupParam.
+type groupJSON struct {
+	OR     bool
+	Params []encodedParam
+}
+
+// MarshalJSON encodes the group, its conditions with their type.
+func (p GroupParam) MarshalJSON() ([]byte, error) {
+	params, err := encodeParams(p.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(groupJSON{OR: p.OR, Params: params})
+}
+
+// UnmarshalJSON decodes the group encoded by MarshalJSON.
+func (p *GroupParam) UnmarshalJSON(data []byte) error {
+	var v groupJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	params, err := decodeParams(v.Params)
+	if err != nil {
+		return err
+	}
+
+	*p = GroupParam{OR: v.OR, Params: params}
+
+	return nil
+}
+
+// notJSON is the JSON encoding of NotParam.
+type notJSON struct {
+	Param encodedParam
+}
+
+// MarshalJSON encodes the negation, its condition with its type.
+func (p NotParam) MarshalJSON() ([]byte, error) {
+	param, err := encodeParam(p.Param)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(notJSON{Param: param})
+}
+
+// UnmarshalJSON decodes the negation encoded by MarshalJSON.
+func (p *NotParam) UnmarshalJSON(data []byte) error {
+	var v notJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	param, err := decodeParam(v.Param)
+	if err != nil {
+		return err
+	}
+
+	*p = NotParam{Param: param}
+
+	return nil
+}
+
+// preloadJSON is the JSON encoding of PreloadParam.
+type preloadJSON struct {
+	Name   string
+	Params []encodedParam
+}
+
+// MarshalJSON encodes the preload, its params with their type.
+func (p PreloadParam) MarshalJSON() ([]byte, error) {
+	params, err := encodeParams(p.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(preloadJSON{Name: p.Name, Params: params})
+}
+
+// UnmarshalJSON decodes the preload encoded by MarshalJSON.
+func (p *PreloadParam) UnmarshalJSON(data []byte) error {
+	var v preloadJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	params, err := decodeParams(v.Params)
+	if err != nil {
+		return err
+	}
+
+	*p = PreloadParam{Name: v.Name, Params: params}
+
+	return nil
+}
+
+// withJSON is the JSON encoding of WithParam.
+type withJSON struct {
+	Name        string
+	Params      []encodedParam
+	Recursive   bool
+	Field       string
+	ParentField string
+}
+
+// MarshalJSON encodes the common table expression, its params with their type.
+func (p WithParam) MarshalJSON() ([]byte, error) {
+	params, err := encodeParams(p.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(withJSON{
+		Name:        p.Name,
+		Params:      params,
+		Recursive:   p.Recursive,
+		Field:       p.Field,
+		ParentField: p.ParentField,
+	})
+}
+
+// UnmarshalJSON decodes the common table expression encoded by MarshalJSON.
+func (p *WithParam) UnmarshalJSON(data []byte) error {
+	var v withJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	params, err := decodeParams(v.Params)
+	if err != nil {
+		return err
+	}
+
+	*p = WithParam{
+		Name:        v.Name,
+		Params:      params,
+		Recursive:   v.Recursive,
+		Field:       v.Field,
+		ParentField: v.ParentField,
+	}
+
+	return nil
+}
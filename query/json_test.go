@@ -0,0 +1,193 @@
+package query_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infevocorp/goflexstore/query"
+)
+
+type TenantParam struct {
+	TenantID string
+}
+
+func (p TenantParam) ParamType() string {
+	return "tenant"
+}
+
+type UnregisteredParam struct{}
+
+func (p UnregisteredParam) ParamType() string {
+	return "unregistered"
+}
+
+func Test_Params_JSON(t *testing.T) {
+	t.Run("should-encode-params-in-envelope", func(t *testing.T) {
+		// GIVEN
+		params := query.NewParams(
+			query.Filter("Status", "published"),
+			query.Filter("ViewCount", 10).WithOP(query.GT),
+		)
+
+		// WHEN
+		data, err := json.Marshal(params)
+
+		// THEN
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"version":1,"params":[
+			{"type":"filter","param":{"Name":"Status","Operator":"EQ","Value":"published"}},
+			{"type":"filter","param":{"Name":"ViewCount","Operator":"GT","Value":10}}
+		]}`, string(data))
+	})
+
+	t.Run("should-decode-every-param-type", func(t *testing.T) {
+		// GIVEN
+		asOf := time.Date(2024, 3, 1, 10, 30, 0, 0, time.UTC)
+		params := query.NewParams(
+			query.Filter("Status", "published"),
+			query.Filter("ID", []int64{1, 2}).WithOP(query.IN),
+			query.GroupBy("AuthorID").WithHaving(query.Filter("Count", int64(2)).WithOP(query.GTE)),
+			query.Select("ID", "Title"),
+			query.OR(query.Filter("Status", "draft"), query.Filter("Status", "review")),
+			query.OR(query.AND(query.Filter("AuthorID", int64(1)), query.Filter("Rating", 4.5)), query.Filter("Pinned", true)),
+			query.Not(query.Filter("Title", "%test%").WithOP(query.LIKE)),
+			query.Raw("LENGTH(title) > ?", int64(10)),
+			query.OrderBy("CreatedAt", true),
+			query.Paginate(10, 20),
+			query.Cursor("cursor", 20, query.OrderBy("ID", false)),
+			query.Preload("Comments", query.Filter("Approved", true), query.Paginate(0, 5)),
+			query.PreloadAll().Excluding("Author"),
+			query.WithLock(query.LockTypeForUpdate),
+			query.Cascade("Comments"),
+			query.Sample(10).WithMethod(query.SampleBernoulli),
+			query.With("Thread", query.Filter("ID", int64(1))).WithRecursive("ParentID", "ID"),
+			query.WithinRadius("Location", 48.85, 2.35, 1000),
+			query.InBoundingBox("Location", 48.8, 2.3, 48.9, 2.4),
+			query.Collate("Title", "utf8mb4_bin"),
+			query.FullText("golang", "Title", "Content").OrderByRelevance(),
+			query.AsOf(asOf),
+			query.Unscoped(),
+		)
+
+		data, err := json.Marshal(params)
+		require.NoError(t, err)
+
+		// WHEN
+		var decoded query.Params
+		err = json.Unmarshal(data, &decoded)
+
+		// THEN
+		require.NoError(t, err)
+
+		expected := params.Params()
+		expected[1] = query.Filter("ID", []any{int64(1), int64(2)}).WithOP(query.IN)
+
+		assert.Equal(t, expected, decoded.Params())
+		assert.Equal(t, "published", decoded.Get(query.TypeFilter)[0].(query.FilterParam).Value)
+	})
+
+	t.Run("should-flatten-nested-params", func(t *testing.T) {
+		// GIVEN
+		params := query.NewParams(
+			query.Filter("Status", "published"),
+			query.NewParams(query.Paginate(0, 10), query.OrderBy("ID", false)),
+		)
+
+		data, err := json.Marshal(params)
+		require.NoError(t, err)
+
+		// WHEN
+		var decoded query.Params
+		err = json.Unmarshal(data, &decoded)
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, params.Params(), decoded.Params())
+		assert.Equal(t, params.Hash(), decoded.Hash())
+	})
+
+	t.Run("should-decode-values", func(t *testing.T) {
+		// GIVEN
+		data := `{"version":1,"params":[
+			{"type":"filter","param":{"Name":"ID","Operator":"EQ","Value":1}},
+			{"type":"filter","param":{"Name":"Rating","Operator":"GTE","Value":4.5}},
+			{"type":"filter","param":{"Name":"Tags","Operator":"IN","Value":["go",2]}},
+			{"type":"filter","param":{"Name":"DeletedAt","Operator":"EQ","Value":null}},
+			{"type":"raw","param":{"SQL":"a = ? AND b = ?","Args":[1,"b"]}}
+		]}`
+
+		// WHEN
+		var params query.Params
+		err := json.Unmarshal([]byte(data), &params)
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, []query.Param{
+			query.Filter("ID", int64(1)),
+			query.Filter("Rating", 4.5).WithOP(query.GTE),
+			query.Filter("Tags", []any{"go", int64(2)}).WithOP(query.IN),
+			query.Filter("DeletedAt", nil),
+			query.Raw("a = ? AND b = ?", int64(1), "b"),
+		}, params.Params())
+	})
+
+	t.Run("should-encode-registered-param-type", func(t *testing.T) {
+		// GIVEN
+		query.RegisterParamType[TenantParam]()
+
+		params := query.NewParams(TenantParam{TenantID: "acme"}, query.Filter("Status", "published"))
+
+		data, err := json.Marshal(params)
+		require.NoError(t, err)
+
+		// WHEN
+		var decoded query.Params
+		err = json.Unmarshal(data, &decoded)
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, params.Params(), decoded.Params())
+	})
+
+	t.Run("should-fail-on-unknown-param-type", func(t *testing.T) {
+		// GIVEN
+		params := query.NewParams(UnregisteredParam{})
+
+		// WHEN
+		_, err := json.Marshal(params)
+		errDecode := json.Unmarshal([]byte(`{"version":1,"params":[{"type":"unregistered","param":{}}]}`),
+			&query.Params{})
+
+		// THEN
+		assert.ErrorIs(t, err, query.ErrUnknownParamType)
+		assert.ErrorIs(t, errDecode, query.ErrUnknownParamType)
+	})
+
+	t.Run("should-fail-on-unsupported-version", func(t *testing.T) {
+		for _, data := range []string{
+			`{"version":2,"params":[]}`,
+			`{"params":[]}`,
+		} {
+			// WHEN
+			err := json.Unmarshal([]byte(data), &query.Params{})
+
+			// THEN
+			assert.ErrorIs(t, err, query.ErrUnsupportedEncoding, data)
+		}
+	})
+
+	t.Run("should-fail-on-unknown-operator", func(t *testing.T) {
+		// GIVEN
+		data := `{"version":1,"params":[{"type":"filter","param":{"Name":"ID","Operator":"BETWEEN","Value":1}}]}`
+
+		// WHEN
+		err := json.Unmarshal([]byte(data), &query.Params{})
+
+		// THEN
+		assert.EqualError(t, err, `cannot decode filter param: unknown operator "BETWEEN"`)
+	})
+}
@@ -0,0 +1,51 @@
+package query
+
+import "fmt"
+
+// NotParam represents the negation of a condition: a filter, or a whole OR or AND group.
+// It expresses the exclusions that cannot be expressed by inverting the operators of individual filters.
+//
+// Fields:
+//   - Param: The negated condition, a FilterParam, an ORParam, a GroupParam, a NotParam or a RawParam.
+type NotParam struct {
+	Param Param
+}
+
+// ParamType returns the type of this parameter, which is `not`.
+// This method allows differentiating NotParam from other types of query parameters.
+func (p NotParam) ParamType() string {
+	return TypeNot
+}
+
+// Filters returns the filters of the negated condition, in order of appearance.
+func (p NotParam) Filters() []FilterParam {
+	return appendFilters(nil, p.Param)
+}
+
+// Not creates a new NotParam negating the provided condition.
+//
+// Parameters:
+//   - param: The condition to negate, a FilterParam, an ORParam, a GroupParam, a NotParam or a RawParam.
+//
+// Returns: A NotParam matching the records which do not satisfy the condition.
+//
+// Example:
+// Excluding the drafts of a given author:
+//
+//	query.NewParams(
+//	  query.Not(query.AND(query.Filter("AuthorID", 1), query.Filter("Status", "draft"))),
+//	)
+//
+// Note: The function panics if the parameter provided is not a condition.
+func Not(param Param) Param {
+	switch param.(type) {
+	case FilterParam, ORParam, GroupParam, NotParam, RawParam:
+	default:
+		panic(fmt.Errorf("Not only accept FilterParam, ORParam, GroupParam, NotParam or RawParam but got %s",
+			param.ParamType()))
+	}
+
+	return NotParam{
+		Param: param,
+	}
+}
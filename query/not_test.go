@@ -0,0 +1,49 @@
+package query_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infevocorp/goflexstore/query"
+)
+
+func Test_Not(t *testing.T) {
+	t.Run("param-type-should-be-not", func(t *testing.T) {
+		assert.Equal(t, query.TypeNot, query.NotParam{}.ParamType())
+	})
+
+	t.Run("should-create-not-param", func(t *testing.T) {
+		n := query.Not(query.Filter("id", 1))
+
+		assert.Equal(t, query.NotParam{Param: query.Filter("id", 1)}, n)
+	})
+
+	t.Run("should-return-filters-of-negated-group", func(t *testing.T) {
+		n := query.Not(query.AND(query.Filter("id", 1), query.Not(query.Filter("age", 2)))).(query.NotParam)
+
+		assert.Equal(t, []query.FilterParam{query.Filter("id", 1), query.Filter("age", 2)}, n.Filters())
+	})
+
+	t.Run("should-bind-template-values", func(t *testing.T) {
+		tmpl := query.Compile(
+			query.Not(query.OR(query.Filter("id", 0), query.Filter("age", 0))),
+			query.AND(query.Not(query.Filter("name", ""))),
+		)
+
+		params, err := tmpl.Bind(1, 2, "john")
+
+		require.NoError(t, err)
+		assert.Equal(t, []query.Param{
+			query.Not(query.OR(query.Filter("id", 1), query.Filter("age", 2))),
+			query.AND(query.Not(query.Filter("name", "john"))),
+		}, params.Params())
+	})
+
+	t.Run("should-panic-if-param-is-not-condition", func(t *testing.T) {
+		assert.PanicsWithError(t, "Not only accept FilterParam, ORParam, GroupParam, NotParam or RawParam but got paginate", func() {
+			query.Not(query.Paginate(0, 10))
+		})
+	})
+}
@@ -24,6 +24,21 @@ const (
 
 	// LTE represents the 'Less Than or Equal' operator in a filter expression.
 	LTE
+
+	// LIKE represents the 'Like' operator in a filter expression, matching the value as a pattern
+	// where % matches any sequence of characters and _ any single character.
+	LIKE
+
+	// ILIKE represents the case-insensitive 'Like' operator in a filter expression, see LIKE.
+	ILIKE
+
+	// IN represents the 'In' operator in a filter expression, matching any of the values of a slice or array.
+	// Unlike EQ with a collection, an empty collection matches nothing rather than being rejected.
+	IN
+
+	// NOTIN represents the 'Not In' operator in a filter expression, matching none of the values of a slice or
+	// array. An empty collection matches everything.
+	NOTIN
 )
 
 // String returns the string representation of the Operator.
@@ -46,7 +61,37 @@ func (o Operator) String() string {
 		return "LT"
 	case LTE:
 		return "LTE"
+	case LIKE:
+		return "LIKE"
+	case ILIKE:
+		return "ILIKE"
+	case IN:
+		return "IN"
+	case NOTIN:
+		return "NOTIN"
 	default:
 		return fmt.Sprintf("UNKNOWN(%d)", o)
 	}
 }
+
+// MarshalText encodes the Operator by its name, e.g. "EQ", so that the encoded params remain readable and do not
+// depend on the order of the constants.
+func (o Operator) MarshalText() ([]byte, error) {
+	if o > NOTIN {
+		return nil, fmt.Errorf("unknown operator %d", o)
+	}
+
+	return []byte(o.String()), nil
+}
+
+// UnmarshalText decodes the name of an Operator, see MarshalText.
+func (o *Operator) UnmarshalText(text []byte) error {
+	for op := EQ; op <= NOTIN; op++ {
+		if op.String() == string(text) {
+			*o = op
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown operator %q", text)
+}
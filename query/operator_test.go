@@ -33,6 +33,22 @@ func Test_Operator_String(t *testing.T) {
 		assert.Equal(t, "LTE", query.LTE.String())
 	})
 
+	t.Run("LIKE", func(t *testing.T) {
+		assert.Equal(t, "LIKE", query.LIKE.String())
+	})
+
+	t.Run("ILIKE", func(t *testing.T) {
+		assert.Equal(t, "ILIKE", query.ILIKE.String())
+	})
+
+	t.Run("IN", func(t *testing.T) {
+		assert.Equal(t, "IN", query.IN.String())
+	})
+
+	t.Run("NOTIN", func(t *testing.T) {
+		assert.Equal(t, "NOTIN", query.NOTIN.String())
+	})
+
 	t.Run("UNKNOWN", func(t *testing.T) {
 		assert.Equal(t, "UNKNOWN(100)", query.Operator(100).String())
 	})
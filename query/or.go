@@ -1,9 +1,5 @@
 package query
 
-import (
-	"fmt"
-)
-
 // ORParam represents a logical OR combination of multiple filter parameters.
 // It is used in queries to combine multiple FilterParam instances such that
 // any of the conditions being true will result in a match.
@@ -23,12 +19,15 @@ func (p ORParam) ParamType() string {
 // OR creates a new ORParam, which is a logical OR combination of the provided filter parameters.
 //
 // This function is used to build queries where you want to match records that satisfy any one of the given filter
-// conditions.
+// conditions. When some of the conditions are groups, e.g. created by AND, it creates a GroupParam combining them
+// with OR logic instead.
 //
 // Parameters:
-//   - params: A variable number of Param, each of which should be a FilterParam.
+//   - params: A variable number of Param, each of which should be a FilterParam, an ORParam, a GroupParam,
+//     a NotParam or a RawParam.
 //
-// Returns: An ORParam that encapsulates the provided filter parameters in an OR logic.
+// Returns: An ORParam that encapsulates the provided filter parameters in an OR logic, or a GroupParam if some of
+// them are not filters.
 //
 // Example:
 // Using OR to combine filter conditions:
@@ -42,14 +41,17 @@ func (p ORParam) ParamType() string {
 //
 // This example creates query parameters that match records where 'id' is either 1 or 2.
 //
-// Note: The function panics if any parameter provided is not a FilterParam.
+// Note: The function panics if any parameter provided is not a condition.
 func OR(params ...Param) Param {
 	filterParams := []FilterParam{}
 
 	for _, p := range params {
 		f, ok := p.(FilterParam)
 		if !ok {
-			panic(fmt.Errorf("OR only accept FilterParam but got %s", p.ParamType()))
+			return GroupParam{
+				OR:     true,
+				Params: conditions("OR", params),
+			}
 		}
 
 		filterParams = append(filterParams, f)
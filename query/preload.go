@@ -54,3 +54,43 @@ func Preload(preload string, params ...Param) PreloadParam {
 		Params: params,
 	}
 }
+
+// PreloadAllParam is used to preload every first-level association of the entities, except the excluded ones.
+//
+// Fields:
+//   - Except: The names of the associations (reference fields) not to preload.
+type PreloadAllParam struct {
+	Except []string
+}
+
+// ParamType returns the type of this parameter, which is `preloadall`.
+// This method distinguishes PreloadAllParam from other types of query parameters.
+func (p PreloadAllParam) ParamType() string {
+	return TypePreloadAll
+}
+
+// Excluding returns a new PreloadAllParam not preloading the given associations, in addition to the ones
+// already excluded.
+func (p PreloadAllParam) Excluding(names ...string) PreloadAllParam {
+	return PreloadAllParam{
+		Except: append(append([]string(nil), p.Except...), names...),
+	}
+}
+
+// PreloadAll creates a new PreloadAllParam preloading every first-level association of the entities,
+// without enumerating their names. Nested associations are not preloaded.
+//
+// A Preload param of the same query customizes the preloading of its association, e.g. with filters.
+//
+// Returns:
+// A new PreloadAllParam excluding no association. Use Excluding to exclude some of them.
+//
+// Example:
+// Preloading every association of 'Article' but its 'Comments':
+//
+//	articles, err := articleStore.List(ctx,
+//		query.PreloadAll().Excluding("Comments"),
+//	)
+func PreloadAll() PreloadAllParam {
+	return PreloadAllParam{}
+}
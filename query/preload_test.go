@@ -41,4 +41,16 @@ func Test_Preload_Test(t *testing.T) {
 			},
 		}, a)
 	})
+
+	t.Run("preload-all-param-type-should-be-preloadall", func(t *testing.T) {
+		assert.Equal(t, query.TypePreloadAll, query.PreloadAllParam{}.ParamType())
+	})
+
+	t.Run("preload-all-excluding", func(t *testing.T) {
+		a := query.PreloadAll()
+		b := a.Excluding("Comments").Excluding("Tags", "Author")
+
+		assert.Equal(t, query.PreloadAllParam{}, a)
+		assert.Equal(t, query.PreloadAllParam{Except: []string{"Comments", "Tags", "Author"}}, b)
+	})
 }
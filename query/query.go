@@ -1,5 +1,13 @@
 package query
 
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sync"
+)
+
 // Param is an interface representing a query parameter.
 // It provides a common method to identify the type of the parameter.
 type Param interface {
@@ -7,16 +15,55 @@ type Param interface {
 	ParamType() string
 }
 
-// Params is a struct that aggregates multiple query parameters.
-// It also provides methods to retrieve specific types of parameters and a caching mechanism for efficient retrieval.
+// Params is an immutable collection of query parameters.
+// It provides methods to retrieve specific types of parameters, backed by lazily built caches.
+//
+// Params is safe for concurrent use and cheap to copy: copies share the same underlying parameters and caches.
+// Append and With never modify the receiver, they return a new Params instead.
 type Params struct {
-	params       []Param
-	cachedFilter map[string]int
+	params []Param
+	cache  *paramsCache
+	// template is the template the params were bound from, if any.
+	template *Template
+}
+
+// paramsCache holds the indexes of the parameters, built on first use.
+type paramsCache struct {
+	once    sync.Once
+	filters map[string]int
+	types   map[string][]int
+}
+
+// ParamType returns the type of Params used as a query parameter, which is `params`.
+// It allows passing Params, such as the ones bound from a Template, where a Param is expected.
+func (p Params) ParamType() string {
+	return TypeParams
+}
+
+// Template returns the Template the params were bound from, if any.
+// Store implementations can use it to reuse the work done for previous bindings of the same template.
+func (p Params) Template() (*Template, bool) {
+	return p.template, p.template != nil
 }
 
 // Params returns the list of all query parameters.
+// The returned slice is a copy and can be modified freely.
 func (p Params) Params() []Param {
-	return p.params
+	params := make([]Param, len(p.params))
+	copy(params, p.params)
+
+	return params
+}
+
+// Len returns the number of query parameters.
+func (p Params) Len() int {
+	return len(p.params)
+}
+
+// At returns the i-th query parameter. It panics if i is out of range.
+// Together with Len, it allows iterating over the parameters without copying them.
+func (p Params) At(i int) Param {
+	return p.params[i]
 }
 
 // Get returns all query parameters of a specific type.
@@ -27,18 +74,18 @@ func (p Params) Params() []Param {
 // Returns:
 // A slice of Param that match the specified paramType.
 func (p Params) Get(paramType string) []Param {
-	params := make([]Param, 0)
+	indexes := p.index().types[paramType]
+	params := make([]Param, len(indexes))
 
-	for _, param := range p.params {
-		if param.ParamType() == paramType {
-			params = append(params, param)
-		}
+	for i, index := range indexes {
+		params[i] = p.params[index]
 	}
 
 	return params
 }
 
 // GetFilter returns the FilterParam with the given name, if it exists.
+// If several filters have the same name, the last one is returned.
 //
 // Parameters:
 //   - name: The name of the filter parameter to retrieve.
@@ -46,7 +93,7 @@ func (p Params) Get(paramType string) []Param {
 // Returns:
 // A FilterParam and a boolean indicating whether it was found.
 func (p Params) GetFilter(name string) (FilterParam, bool) {
-	i, ok := p.cachedFilter[name]
+	i, ok := p.index().filters[name]
 	if ok {
 		return p.params[i].(FilterParam), true
 	}
@@ -54,8 +101,109 @@ func (p Params) GetFilter(name string) (FilterParam, bool) {
 	return FilterParam{}, false
 }
 
+// Append returns a new Params with the given parameters added after the existing ones.
+// The receiver is left unchanged.
+//
+// Example:
+//
+//	scoped := params.Append(query.Filter("TenantID", tenantID))
+func (p Params) Append(params ...Param) Params {
+	merged := make([]Param, 0, len(p.params)+len(params))
+	merged = append(merged, p.params...)
+	merged = appendFlattened(merged, params)
+
+	return newParams(merged)
+}
+
+// With returns a new Params with the given parameters set. A FilterParam replaces the existing filters
+// having the same name, while the other parameters are appended. The receiver is left unchanged.
+//
+// Example:
+//
+//	// overrides any "Status" filter provided by the caller
+//	params = params.With(query.Filter("Status", "published"))
+func (p Params) With(params ...Param) Params {
+	params = appendFlattened(nil, params)
+	replaced := make(map[string]bool)
+
+	for _, param := range params {
+		if filter, ok := param.(FilterParam); ok {
+			replaced[filter.Name] = true
+		}
+	}
+
+	merged := make([]Param, 0, len(p.params)+len(params))
+
+	for _, param := range p.params {
+		if filter, ok := param.(FilterParam); ok && replaced[filter.Name] {
+			continue
+		}
+
+		merged = append(merged, param)
+	}
+
+	merged = append(merged, params...)
+
+	return newParams(merged)
+}
+
+// Hash returns a digest of the query parameters, equal for the Params holding equal parameters in the same order,
+// e.g. to key caches or to de-duplicate identical queries. Values are compared by their Go syntax representation,
+// so pointers are only equal to themselves, and Filter("ID", 1) and Filter("ID", "1") have different hashes.
+func (p Params) Hash() string {
+	h := sha256.New()
+	hashParams(h, p.params)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// hashParams writes the representation of the parameters to w. Nested Params are written by their parameters,
+// as their caches are not part of their value.
+func hashParams(w io.Writer, params []Param) {
+	for _, param := range params {
+		switch param := param.(type) {
+		case Params:
+			hashParams(w, param.params)
+		case PreloadParam:
+			fmt.Fprintf(w, "%T{%q;", param, param.Name)
+			hashParams(w, param.Params)
+			fmt.Fprint(w, "};")
+		default:
+			fmt.Fprintf(w, "%#v;", param)
+		}
+	}
+}
+
+// index returns the caches of the parameters, building them on first use.
+func (p Params) index() *paramsCache {
+	cache := p.cache
+	if cache == nil {
+		// zero value Params, nothing to cache.
+		return &paramsCache{}
+	}
+
+	cache.once.Do(func() {
+		cache.filters = make(map[string]int)
+		cache.types = make(map[string][]int)
+
+		for i, param := range p.params {
+			paramType := param.ParamType()
+			cache.types[paramType] = append(cache.types[paramType], i)
+
+			if filter, ok := param.(FilterParam); ok {
+				cache.filters[filter.Name] = i
+			}
+		}
+	})
+
+	return cache
+}
+
 // NewParams creates a new Params object with the given query parameters.
-// It initializes a cache for filter parameters for efficient retrieval.
+// The parameters are copied, so the caller can reuse the slice passed as argument.
+//
+// Params given as parameters are flattened. When the only parameter is a Params, it is returned as is,
+// preserving the Template it was bound from.
 //
 // Parameters:
 //   - params: A variable number of Param to include in the Params object.
@@ -73,17 +221,33 @@ func (p Params) GetFilter(name string) (FilterParam, bool) {
 //		query.Filter("Name", "test"),
 //	)
 func NewParams(params ...Param) Params {
-	cachedFilter := map[string]int{}
+	if len(params) == 1 {
+		if p, ok := params[0].(Params); ok {
+			return p
+		}
+	}
 
-	for i, param := range params {
-		if param.ParamType() == "filter" {
-			cachedFilter[param.(FilterParam).Name] = i
+	return newParams(appendFlattened(make([]Param, 0, len(params)), params))
+}
+
+// appendFlattened appends params to dst, replacing Params by the parameters they contain.
+func appendFlattened(dst []Param, params []Param) []Param {
+	for _, param := range params {
+		if p, ok := param.(Params); ok {
+			dst = append(dst, p.params...)
+		} else {
+			dst = append(dst, param)
 		}
 	}
 
+	return dst
+}
+
+// newParams creates a Params owning the given slice.
+func newParams(params []Param) Params {
 	return Params{
-		params:       params,
-		cachedFilter: cachedFilter,
+		params: params,
+		cache:  &paramsCache{},
 	}
 }
 
@@ -1,6 +1,7 @@
 package query_test
 
 import (
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -123,3 +124,153 @@ func Test_Params_FilterGetter(t *testing.T) {
 		assert.Equal(t, query.Filter("name", "john"), filterParam)
 	})
 }
+
+func Test_Params_Append(t *testing.T) {
+	t.Run("should-append-params-without-modifying-receiver", func(t *testing.T) {
+		params := query.NewParams(
+			query.Filter("name", "john"),
+		)
+
+		appended := params.Append(query.Filter("age", 20), query.Paginate(0, 10))
+
+		assert.Equal(t, []query.Param{
+			query.Filter("name", "john"),
+		}, params.Params())
+		assert.Equal(t, []query.Param{
+			query.Filter("name", "john"),
+			query.Filter("age", 20),
+			query.Paginate(0, 10),
+		}, appended.Params())
+
+		_, ok := params.GetFilter("age")
+		assert.False(t, ok)
+
+		filterParam, ok := appended.GetFilter("age")
+		assert.True(t, ok)
+		assert.Equal(t, query.Filter("age", 20), filterParam)
+	})
+
+	t.Run("should-not-share-backing-array", func(t *testing.T) {
+		params := query.NewParams(
+			query.Filter("name", "john"),
+		)
+
+		a := params.Append(query.Filter("age", 20))
+		b := params.Append(query.Filter("age", 30))
+
+		filterA, _ := a.GetFilter("age")
+		filterB, _ := b.GetFilter("age")
+
+		assert.Equal(t, 20, filterA.Value)
+		assert.Equal(t, 30, filterB.Value)
+	})
+}
+
+func Test_Params_With(t *testing.T) {
+	t.Run("should-replace-filters-with-same-name", func(t *testing.T) {
+		params := query.NewParams(
+			query.Filter("name", "john"),
+			query.Paginate(0, 10),
+			query.Filter("name", "jane"),
+		)
+
+		replaced := params.With(query.Filter("name", "bob"), query.OrderBy("name", false))
+
+		assert.Equal(t, []query.Param{
+			query.Paginate(0, 10),
+			query.Filter("name", "bob"),
+			query.OrderBy("name", false),
+		}, replaced.Params())
+		assert.Equal(t, 3, params.Len())
+	})
+}
+
+func Test_NewParams(t *testing.T) {
+	t.Run("should-copy-params", func(t *testing.T) {
+		params := []query.Param{
+			query.Filter("name", "john"),
+		}
+
+		p := query.NewParams(params...)
+		params[0] = query.Filter("name", "jane")
+
+		filterParam, ok := p.GetFilter("name")
+		assert.True(t, ok)
+		assert.Equal(t, query.Filter("name", "john"), filterParam)
+	})
+
+	t.Run("params-should-return-copy", func(t *testing.T) {
+		p := query.NewParams(
+			query.Filter("name", "john"),
+		)
+
+		p.Params()[0] = query.Filter("name", "jane")
+
+		assert.Equal(t, []query.Param{
+			query.Filter("name", "john"),
+		}, p.Params())
+	})
+
+	t.Run("zero-value-should-be-usable", func(t *testing.T) {
+		var p query.Params
+
+		_, ok := p.GetFilter("name")
+
+		assert.False(t, ok)
+		assert.Equal(t, []query.Param{}, p.Get("filter"))
+		assert.Equal(t, 1, p.Append(query.Filter("name", "john")).Len())
+	})
+
+	t.Run("should-be-safe-for-concurrent-use", func(t *testing.T) {
+		p := query.NewParams(
+			query.Filter("name", "john"),
+			query.Paginate(0, 10),
+		)
+
+		var wg sync.WaitGroup
+
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+
+			go func(i int) {
+				defer wg.Done()
+
+				_, _ = p.GetFilter("name")
+				_ = p.Get("paginate")
+				_ = p.Append(query.Filter("age", i)).With(query.Filter("name", "jane"))
+			}(i)
+		}
+
+		wg.Wait()
+
+		assert.Equal(t, 2, p.Len())
+	})
+}
+
+func Test_Params_Hash(t *testing.T) {
+	t.Run("should-be-equal-for-equal-params", func(t *testing.T) {
+		a := query.NewParams(query.Filter("ID", 1), query.Preload("Author", query.Filter("Active", true)))
+		b := query.NewParams(query.Filter("ID", 1)).Append(query.Preload("Author", query.Filter("Active", true)))
+
+		assert.Equal(t, a.Hash(), b.Hash())
+	})
+
+	t.Run("should-differ-for-different-params", func(t *testing.T) {
+		hashes := map[string]bool{}
+
+		for _, params := range []query.Params{
+			query.NewParams(),
+			query.NewParams(query.Filter("ID", 1)),
+			query.NewParams(query.Filter("ID", "1")),
+			query.NewParams(query.Filter("ID", 1).WithOP(query.GT)),
+			query.NewParams(query.Filter("ID", 1), query.OrderBy("ID", true)),
+			query.NewParams(query.OrderBy("ID", true), query.Filter("ID", 1)),
+			query.NewParams(query.Preload("Author", query.Filter("Active", true))),
+			query.NewParams(query.Preload("Author", query.Filter("Active", false))),
+		} {
+			hashes[params.Hash()] = true
+		}
+
+		assert.Len(t, hashes, 8)
+	})
+}
@@ -0,0 +1,42 @@
+package query
+
+// RawParam represents a condition written in the SQL of the store, with its bind arguments.
+// It is an escape hatch for the complex one-off conditions which cannot be expressed with the other parameters,
+// and do not deserve a custom filter. It is only supported by the SQL stores.
+//
+// The SQL is passed through as is: it must never include user input, which must be given as arguments.
+//
+// Fields:
+//   - SQL: The condition, with a ? placeholder for each argument.
+//   - Args: The arguments bound to the placeholders. A slice argument expands to a list of values.
+type RawParam struct {
+	SQL  string
+	Args []any
+}
+
+// ParamType returns the type of this parameter, which is `raw`.
+// This method allows differentiating RawParam from other types of query parameters.
+func (p RawParam) ParamType() string {
+	return TypeRaw
+}
+
+// Raw creates a new RawParam with the given condition and arguments.
+// Raw params can be conditions of AND, OR and Not params. Their arguments are not bound by templates.
+//
+// Parameters:
+//   - sql: The condition, with a ? placeholder for each argument, written in the SQL of the store.
+//   - args: The arguments bound to the placeholders.
+//
+// Returns: A RawParam holding the condition and its arguments.
+//
+// Example:
+//
+//	query.NewParams(
+//	  query.Raw("age > ? AND status IN ?", 18, []string{"active", "pending"}),
+//	)
+func Raw(sql string, args ...any) Param {
+	return RawParam{
+		SQL:  sql,
+		Args: args,
+	}
+}
@@ -0,0 +1,30 @@
+package query_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infevocorp/goflexstore/query"
+)
+
+func Test_Raw(t *testing.T) {
+	t.Run("param-type-should-be-raw", func(t *testing.T) {
+		assert.Equal(t, query.TypeRaw, query.RawParam{}.ParamType())
+	})
+
+	t.Run("should-create-raw-param", func(t *testing.T) {
+		r := query.Raw("age > ? AND status IN ?", 18, []string{"a", "b"})
+
+		assert.Equal(t, query.RawParam{
+			SQL:  "age > ? AND status IN ?",
+			Args: []any{18, []string{"a", "b"}},
+		}, r)
+	})
+
+	t.Run("should-be-condition-of-groups", func(t *testing.T) {
+		assert.NotPanics(t, func() {
+			query.OR(query.Raw("age > ?", 18), query.Not(query.Raw("status = ?", "a")))
+		})
+	})
+}
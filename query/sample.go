@@ -0,0 +1,62 @@
+package query
+
+// SampleMethod is the sampling method of a SampleParam.
+type SampleMethod uint8
+
+const (
+	// SampleSystem samples whole storage blocks. It is the fastest method, but rows stored together are
+	// sampled together, so the sample is less random.
+	SampleSystem SampleMethod = iota
+
+	// SampleBernoulli samples each row independently. It reads the whole table but is more accurate.
+	SampleBernoulli
+)
+
+// SampleParam restricts a read to a random sample of the rows of the table, for approximate analytics
+// over large tables.
+//
+// Fields:
+//   - Percent: The percentage of the rows to sample, between 0 and 100.
+//   - Method: The sampling method, SampleSystem by default.
+type SampleParam struct {
+	Percent float64
+	Method  SampleMethod
+}
+
+// ParamType returns the type of this parameter, which is `sample`.
+// This method is used to distinguish SampleParam from other types of query parameters.
+func (p SampleParam) ParamType() string {
+	return TypeSample
+}
+
+// WithMethod returns a new SampleParam with the specified sampling method, keeping the percentage unchanged.
+func (p SampleParam) WithMethod(method SampleMethod) SampleParam {
+	return SampleParam{
+		Percent: p.Percent,
+		Method:  method,
+	}
+}
+
+// Sample creates a new SampleParam reading about percent % of the rows of the table.
+//
+// Sampling is meant for reads whose result can be extrapolated, such as Count or aggregations in dashboards:
+// a count over a 1% sample multiplied by 100 approximates the total count. Stores whose database cannot
+// sample a table ignore the param and read every row, and it must not be given to writes.
+//
+// Parameters:
+//   - percent: The percentage of the rows to sample, between 0 and 100.
+//
+// Returns:
+// A SampleParam using the SampleSystem method. Use WithMethod to change it.
+//
+// Example:
+// Approximating the number of page views of the day:
+//
+//	count, err := viewStore.Count(ctx, query.Sample(1), query.Filter("Day", today))
+//	approx := count * 100
+func Sample(percent float64) SampleParam {
+	return SampleParam{
+		Percent: percent,
+		Method:  SampleSystem,
+	}
+}
@@ -0,0 +1,29 @@
+package query_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infevocorp/goflexstore/query"
+)
+
+func Test_Sample(t *testing.T) {
+	t.Run("param-type-should-be-sample", func(t *testing.T) {
+		assert.Equal(t, query.TypeSample, query.SampleParam{}.ParamType())
+	})
+
+	t.Run("should-create-sample-param", func(t *testing.T) {
+		assert.Equal(t, query.SampleParam{
+			Percent: 2.5,
+			Method:  query.SampleSystem,
+		}, query.Sample(2.5))
+	})
+
+	t.Run("should-change-method", func(t *testing.T) {
+		assert.Equal(t, query.SampleParam{
+			Percent: 10,
+			Method:  query.SampleBernoulli,
+		}, query.Sample(10).WithMethod(query.SampleBernoulli))
+	})
+}
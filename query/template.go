@@ -0,0 +1,146 @@
+package query
+
+import "fmt"
+
+// Template is a precompiled set of query parameters whose filter values are bound at execution time.
+//
+// Endpoints running the same query with different values can compile it once and bind the values on
+// every request. Store implementations recognize the Params bound from a template (see Params.Template)
+// and reuse the work that does not depend on the values, such as the parts of the query built from
+// Select, OrderBy, Paginate or Preload parameters.
+//
+// The values are the ones of the FilterParams, in order of appearance, including the filters of OR, group
+// and not parameters and the having conditions of GroupBy parameters. The filters of Preload and With parameters
+// are not bound. The values given to Compile are placeholders and are not used.
+//
+// A Template is immutable and safe for concurrent use.
+type Template struct {
+	params Params
+	slots  []templateSlot
+}
+
+// templateSlot locates a bound value: the index of the parameter and, for OR, group, not and GroupBy parameters,
+// the index of the filter in the parameter. sub is -1 for top level filters.
+type templateSlot struct {
+	param int
+	sub   int
+}
+
+// Compile creates a Template from the given query parameters.
+//
+// Parameters:
+//   - params: The query parameters. The values of their filters are placeholders.
+//
+// Returns:
+// A Template whose Bind method produces Params with the given filter values.
+//
+// Example:
+//
+//	var articlesByAuthor = query.Compile(
+//		query.Filter("AuthorID", 0),
+//		query.OrderBy("CreatedAt", true),
+//		query.Paginate(0, 20),
+//	)
+//
+//	params, err := articlesByAuthor.Bind(authorID)
+//	if err != nil {
+//		return err
+//	}
+//
+//	articles, err := articleStore.List(ctx, params)
+func Compile(params ...Param) *Template {
+	t := &Template{
+		params: NewParams(params...),
+	}
+
+	for i, param := range t.params.params {
+		switch p := param.(type) {
+		case FilterParam:
+			t.slots = append(t.slots, templateSlot{param: i, sub: -1})
+		case ORParam:
+			for j := range p.Params {
+				t.slots = append(t.slots, templateSlot{param: i, sub: j})
+			}
+		case GroupByParam:
+			for j := range p.Having {
+				t.slots = append(t.slots, templateSlot{param: i, sub: j})
+			}
+		case GroupParam, NotParam:
+			for j := range appendFilters(nil, p) {
+				t.slots = append(t.slots, templateSlot{param: i, sub: j})
+			}
+		}
+	}
+
+	return t
+}
+
+// NumValues returns the number of values expected by Bind.
+func (t *Template) NumValues() int {
+	return len(t.slots)
+}
+
+// Params returns the parameters the template was compiled from.
+func (t *Template) Params() Params {
+	return t.params
+}
+
+// IsStatic reports whether the i-th parameter does not depend on the bound values.
+// The parts of the query built from static parameters can be reused across bindings.
+func (t *Template) IsStatic(i int) bool {
+	switch t.params.params[i].(type) {
+	case FilterParam, ORParam, GroupParam, NotParam:
+		return false
+	case GroupByParam:
+		return len(t.params.params[i].(GroupByParam).Having) == 0
+	default:
+		return true
+	}
+}
+
+// Bind returns the template parameters with the given filter values.
+//
+// Parameters:
+//   - values: The filter values, in order of appearance. There must be exactly NumValues values.
+//
+// Returns:
+// The bound Params, or an error if the number of values does not match.
+func (t *Template) Bind(values ...any) (Params, error) {
+	if len(values) != len(t.slots) {
+		return Params{}, fmt.Errorf("template expects %d values, got %d", len(t.slots), len(values))
+	}
+
+	bound := make([]Param, len(t.params.params))
+	copy(bound, t.params.params)
+
+	for k, slot := range t.slots {
+		switch p := bound[slot.param].(type) {
+		case FilterParam:
+			p.Value = values[k]
+			bound[slot.param] = p
+		case ORParam:
+			if slot.sub == 0 {
+				p.Params = append([]FilterParam(nil), p.Params...)
+			}
+
+			p.Params[slot.sub].Value = values[k]
+			bound[slot.param] = p
+		case GroupByParam:
+			if slot.sub == 0 {
+				p.Having = append([]FilterParam(nil), p.Having...)
+			}
+
+			p.Having[slot.sub].Value = values[k]
+			bound[slot.param] = p
+		case GroupParam, NotParam:
+			if slot.sub == 0 {
+				bound[slot.param], _ = bindCondition(p, values[k:])
+			}
+		}
+	}
+
+	params := newParams(bound)
+	params.template = t
+
+	return params, nil
+}
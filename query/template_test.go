@@ -0,0 +1,169 @@
+package query_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infevocorp/goflexstore/query"
+)
+
+func Test_Compile(t *testing.T) {
+	t.Run("should-count-filter-values", func(t *testing.T) {
+		tmpl := query.Compile(
+			query.Filter("name", ""),
+			query.OR(query.Filter("age", 0), query.Filter("age", 0)),
+			query.GroupBy("name").WithHaving(query.Filter("count", 0)),
+			query.Paginate(0, 10),
+		)
+
+		assert.Equal(t, 4, tmpl.NumValues())
+	})
+
+	t.Run("should-report-static-params", func(t *testing.T) {
+		tmpl := query.Compile(
+			query.Filter("name", ""),
+			query.OR(query.Filter("age", 0)),
+			query.GroupBy("name"),
+			query.GroupBy("name").WithHaving(query.Filter("count", 0)),
+			query.Select("name"),
+			query.Paginate(0, 10),
+		)
+
+		assert.False(t, tmpl.IsStatic(0))
+		assert.False(t, tmpl.IsStatic(1))
+		assert.True(t, tmpl.IsStatic(2))
+		assert.False(t, tmpl.IsStatic(3))
+		assert.True(t, tmpl.IsStatic(4))
+		assert.True(t, tmpl.IsStatic(5))
+	})
+}
+
+func Test_Template_Bind(t *testing.T) {
+	t.Run("should-bind-values-in-order", func(t *testing.T) {
+		tmpl := query.Compile(
+			query.Filter("name", ""),
+			query.OR(query.Filter("age", 0), query.Filter("age", 0).WithOP(query.GT)),
+			query.GroupBy("name").WithHaving(query.Filter("count", 0)),
+			query.Paginate(0, 10),
+		)
+
+		params, err := tmpl.Bind("john", 20, 30, 2)
+
+		require.NoError(t, err)
+		assert.Equal(t, []query.Param{
+			query.Filter("name", "john"),
+			query.OR(query.Filter("age", 20), query.Filter("age", 30).WithOP(query.GT)),
+			query.GroupBy("name").WithHaving(query.Filter("count", 2)),
+			query.Paginate(0, 10),
+		}, params.Params())
+
+		filter, ok := params.GetFilter("name")
+		assert.True(t, ok)
+		assert.Equal(t, "john", filter.Value)
+
+		bound, ok := params.Template()
+		assert.True(t, ok)
+		assert.Same(t, tmpl, bound)
+	})
+
+	t.Run("should-not-modify-template", func(t *testing.T) {
+		tmpl := query.Compile(
+			query.OR(query.Filter("age", 0), query.Filter("age", 0)),
+		)
+
+		first, err := tmpl.Bind(1, 2)
+		require.NoError(t, err)
+
+		second, err := tmpl.Bind(3, 4)
+		require.NoError(t, err)
+
+		assert.Equal(t, []query.Param{
+			query.OR(query.Filter("age", 1), query.Filter("age", 2)),
+		}, first.Params())
+		assert.Equal(t, []query.Param{
+			query.OR(query.Filter("age", 3), query.Filter("age", 4)),
+		}, second.Params())
+		assert.Equal(t, []query.Param{
+			query.OR(query.Filter("age", 0), query.Filter("age", 0)),
+		}, tmpl.Params().Params())
+	})
+
+	t.Run("should-bind-values-of-nested-groups", func(t *testing.T) {
+		tmpl := query.Compile(
+			query.OR(
+				query.AND(query.Filter("name", ""), query.OR(query.Filter("age", 0), query.Filter("age", 0))),
+				query.Filter("status", ""),
+			),
+			query.Filter("id", 0),
+		)
+
+		params, err := tmpl.Bind("john", 20, 30, "active", 1)
+
+		require.NoError(t, err)
+		assert.False(t, tmpl.IsStatic(0))
+		assert.Equal(t, []query.Param{
+			query.OR(
+				query.AND(query.Filter("name", "john"), query.OR(query.Filter("age", 20), query.Filter("age", 30))),
+				query.Filter("status", "active"),
+			),
+			query.Filter("id", 1),
+		}, params.Params())
+		assert.Equal(t, []query.Param{
+			query.OR(
+				query.AND(query.Filter("name", ""), query.OR(query.Filter("age", 0), query.Filter("age", 0))),
+				query.Filter("status", ""),
+			),
+			query.Filter("id", 0),
+		}, tmpl.Params().Params())
+	})
+
+	t.Run("should-fail-when-values-do-not-match", func(t *testing.T) {
+		tmpl := query.Compile(
+			query.Filter("name", ""),
+		)
+
+		_, err := tmpl.Bind("john", 20)
+
+		assert.EqualError(t, err, "template expects 1 values, got 2")
+	})
+}
+
+func Test_Params_AsParam(t *testing.T) {
+	t.Run("new-params-should-keep-single-params", func(t *testing.T) {
+		tmpl := query.Compile(query.Filter("name", ""))
+		bound, err := tmpl.Bind("john")
+		require.NoError(t, err)
+
+		params := query.NewParams(bound)
+
+		_, ok := params.Template()
+		assert.True(t, ok)
+	})
+
+	t.Run("new-params-should-flatten-params", func(t *testing.T) {
+		tmpl := query.Compile(query.Filter("name", ""))
+		bound, err := tmpl.Bind("john")
+		require.NoError(t, err)
+
+		params := query.NewParams(bound, query.Paginate(0, 10))
+
+		_, ok := params.Template()
+		assert.False(t, ok)
+		assert.Equal(t, []query.Param{
+			query.Filter("name", "john"),
+			query.Paginate(0, 10),
+		}, params.Params())
+	})
+
+	t.Run("append-should-flatten-params", func(t *testing.T) {
+		params := query.NewParams(query.Filter("name", "john")).
+			Append(query.NewParams(query.Paginate(0, 10)))
+
+		assert.Equal(t, []query.Param{
+			query.Filter("name", "john"),
+			query.Paginate(0, 10),
+		}, params.Params())
+	})
+}
@@ -18,6 +18,18 @@ const (
 	// result in a match.
 	TypeOR = "or"
 
+	// TypeGroup represents the type name for nested boolean group parameters in a query.
+	// These parameters combine conditions, possibly groups themselves, with AND or OR logic.
+	TypeGroup = "group"
+
+	// TypeNot represents the type name for negation parameters in a query.
+	// These parameters match the entities which do not satisfy a condition, such as a filter or a group.
+	TypeNot = "not"
+
+	// TypeRaw represents the type name for raw SQL condition parameters in a query.
+	// These parameters filter the entities with a condition written in the SQL of the store.
+	TypeRaw = "raw"
+
 	// TypeOrderBy represents the type name for order-by parameters in a query.
 	// These parameters define the sorting order of the result set based on specified fields.
 	TypeOrderBy = "orderby"
@@ -26,11 +38,59 @@ const (
 	// These parameters control the slicing of the result set into manageable segments, defining the offset and limit.
 	TypePaginate = "paginate"
 
+	// TypeCursor represents the type name for keyset pagination parameters in a query.
+	// These parameters read the page of the result set following a cursor, in the order of specified fields.
+	TypeCursor = "cursor"
+
 	// TypePreload represents the type name for preload parameters in a query.
 	// These parameters specify related entities or fields that should be loaded along with the primary query results.
 	TypePreload = "preload"
 
+	// TypePreloadAll represents the type name for preload all parameters in a query.
+	// These parameters load every first-level association of the entities, except the excluded ones.
+	TypePreloadAll = "preloadall"
+
 	// TypeWithLock represents the type name for the lock-for-update clause parameters in a query.
 	// These parameters specify the lock mode to be used: "FOR UPDATE".
 	TypeWithLock = "withlock"
+
+	// TypeCascade represents the type name for cascade parameters in a query.
+	// These parameters specify the associations to be deleted together with the deleted entities.
+	TypeCascade = "cascade"
+
+	// TypeSample represents the type name for sample parameters in a query.
+	// These parameters restrict a read to a random sample of the rows of the table.
+	TypeSample = "sample"
+
+	// TypeWith represents the type name for common table expression parameters in a query.
+	// These parameters make a read select the rows of a, possibly recursive, common table expression.
+	TypeWith = "with"
+
+	// TypeWithinRadius represents the type name for geospatial radius parameters in a query.
+	// These parameters filter the entities whose location is within a distance of a point.
+	TypeWithinRadius = "withinradius"
+
+	// TypeInBoundingBox represents the type name for geospatial bounding box parameters in a query.
+	// These parameters filter the entities whose location is within a box of latitudes and longitudes.
+	TypeInBoundingBox = "inboundingbox"
+
+	// TypeCollate represents the type name for collation parameters in a query.
+	// These parameters force the collation used by the comparisons of a field.
+	TypeCollate = "collate"
+
+	// TypeFullText represents the type name for full-text search parameters in a query.
+	// These parameters filter the entities whose text fields match a text, possibly ordering them by relevance.
+	TypeFullText = "fulltext"
+
+	// TypeAsOf represents the type name for temporal parameters in a query.
+	// These parameters make a read return the state of the entities as of a past time.
+	TypeAsOf = "asof"
+
+	// TypeUnscoped represents the type name for unscoped parameters in a query.
+	// These parameters include the soft-deleted entities in reads and make the deletes permanent.
+	TypeUnscoped = "unscoped"
+
+	// TypeParams represents the type name of Params used as a single query parameter.
+	// Params passed among other parameters are flattened, so stores never receive it.
+	TypeParams = "params"
 )
@@ -0,0 +1,25 @@
+package query
+
+// UnscopedParam makes an operation include the soft-deleted entities, which stores exclude by default, and makes
+// the deletes permanent.
+type UnscopedParam struct{}
+
+// ParamType returns the type of this parameter, which is `unscoped`.
+// This method is used to distinguish UnscopedParam from other types of query parameters.
+func (p UnscopedParam) ParamType() string {
+	return TypeUnscoped
+}
+
+// Unscoped creates a new UnscopedParam including the soft-deleted entities in reads, e.g. to list the trash or
+// restore an entity, and deleting the entities permanently instead of soft deleting them.
+//
+// Returns:
+// An UnscopedParam.
+//
+// Example:
+// Getting an article, even if it was soft deleted:
+//
+//	article, err := articleStore.Get(ctx, query.Unscoped(), query.Filter("ID", id))
+func Unscoped() UnscopedParam {
+	return UnscopedParam{}
+}
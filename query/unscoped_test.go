@@ -0,0 +1,19 @@
+package query_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infevocorp/goflexstore/query"
+)
+
+func Test_Unscoped(t *testing.T) {
+	t.Run("param-type-should-be-unscoped", func(t *testing.T) {
+		assert.Equal(t, query.TypeUnscoped, query.UnscopedParam{}.ParamType())
+	})
+
+	t.Run("should-create-unscoped-param", func(t *testing.T) {
+		assert.Equal(t, query.UnscopedParam{}, query.Unscoped())
+	})
+}
@@ -0,0 +1,77 @@
+package query
+
+// WithParam reads the rows of a common table expression (CTE) instead of the whole table.
+//
+// The CTE selects the rows of the table matched by Params. When it is recursive, it also includes, until
+// no new row is found, the rows whose Field equals the ParentField of a row already included, which walks
+// hierarchies such as category trees or org charts. The other params of the query then apply to the rows
+// of the CTE.
+//
+// Fields:
+//   - Name: The name of the CTE.
+//   - Params: The params selecting the initial rows of the CTE.
+//   - Recursive: Whether the CTE is recursive.
+//   - Field: The field of the rows added by the recursion, e.g. "ParentID".
+//   - ParentField: The field of the rows already included that Field references, e.g. "ID".
+type WithParam struct {
+	Name        string
+	Params      []Param
+	Recursive   bool
+	Field       string
+	ParentField string
+}
+
+// ParamType returns the type of this parameter, which is `with`.
+// This method is used to distinguish WithParam from other types of query parameters.
+func (p WithParam) ParamType() string {
+	return TypeWith
+}
+
+// WithRecursive returns a new recursive WithParam, adding the rows whose field equals the parentField of
+// a row already included, keeping the name and params unchanged.
+//
+// Parameters:
+//   - field: The field of the rows added by the recursion.
+//   - parentField: The field of the rows already included that field references.
+//
+// Returns:
+// A new recursive WithParam.
+func (p WithParam) WithRecursive(field, parentField string) WithParam {
+	return WithParam{
+		Name:        p.Name,
+		Params:      p.Params,
+		Recursive:   true,
+		Field:       field,
+		ParentField: parentField,
+	}
+}
+
+// With creates a new WithParam reading the rows matched by params through a common table expression.
+// Use WithRecursive to walk a hierarchy from these rows. It only applies to reads.
+//
+// Parameters:
+//   - name: The name of the common table expression. It must not be the name of a table.
+//   - params: The params selecting the initial rows, typically filters.
+//
+// Returns:
+// A non recursive WithParam.
+//
+// Example:
+// Listing a category and all its descendants, by name:
+//
+//	categories, err := categoryStore.List(ctx,
+//		query.With("subtree", query.Filter("ID", rootID)).WithRecursive("ParentID", "ID"),
+//		query.OrderBy("Name", false),
+//	)
+//
+// Listing the chain of managers of an employee, the employee included:
+//
+//	managers, err := employeeStore.List(ctx,
+//		query.With("chain", query.Filter("ID", employeeID)).WithRecursive("ID", "ManagerID"),
+//	)
+func With(name string, params ...Param) WithParam {
+	return WithParam{
+		Name:   name,
+		Params: params,
+	}
+}
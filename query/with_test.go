@@ -0,0 +1,32 @@
+package query_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infevocorp/goflexstore/query"
+)
+
+func Test_With(t *testing.T) {
+	t.Run("param-type-should-be-with", func(t *testing.T) {
+		assert.Equal(t, query.TypeWith, query.WithParam{}.ParamType())
+	})
+
+	t.Run("should-create-with-param", func(t *testing.T) {
+		assert.Equal(t, query.WithParam{
+			Name:   "subtree",
+			Params: []query.Param{query.Filter("ID", 1)},
+		}, query.With("subtree", query.Filter("ID", 1)))
+	})
+
+	t.Run("should-make-with-param-recursive", func(t *testing.T) {
+		assert.Equal(t, query.WithParam{
+			Name:        "subtree",
+			Params:      []query.Param{query.Filter("ID", 1)},
+			Recursive:   true,
+			Field:       "ParentID",
+			ParentField: "ID",
+		}, query.With("subtree", query.Filter("ID", 1)).WithRecursive("ParentID", "ID"))
+	})
+}
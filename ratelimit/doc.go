@@ -0,0 +1,23 @@
+// Package ratelimit limits the rate of the operations of stores, e.g. to protect the expensive List and Count
+// queries of the endpoints exposed to untrusted callers.
+//
+// Middleware decorates stores composed with store.Chain. Operations are limited by token buckets, keyed by
+// the entity of the store, the method when it has its own limit, and the key returned by the Keyer for the
+// context, such as the ID of the authenticated user. Limited operations fail with an *Error wrapping
+// ErrRateLimited, which tells when to retry.
+//
+// Example:
+// Allowing each user 5 searches per second, with bursts of 10:
+//
+//	articleStore := store.Chain[*model.Article, int64](inner, ratelimit.Middleware(
+//		ratelimit.WithMethodLimit(store.MethodList, ratelimit.Limit{Rate: 5, Burst: 10}),
+//		ratelimit.WithMethodLimit(store.MethodCount, ratelimit.Limit{Rate: 5, Burst: 10}),
+//		ratelimit.WithKeyer(func(ctx context.Context) string { return auth.UserID(ctx) }),
+//	))
+//
+//	articles, err := articleStore.List(ctx, params...)
+//	if rateErr := (*ratelimit.Error)(nil); errors.As(err, &rateErr) {
+//		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(rateErr.RetryAfter.Seconds()))))
+//		w.WriteHeader(http.StatusTooManyRequests)
+//	}
+package ratelimit
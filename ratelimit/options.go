@@ -0,0 +1,52 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Option is a function that configures the Middleware.
+type Option func(*options)
+
+// Keyer returns the key of the caller of an operation, e.g. the ID of the authenticated user, so that each
+// caller has its own buckets. An empty key shares the buckets between the callers.
+type Keyer func(ctx context.Context) string
+
+type options struct {
+	limit   Limit
+	methods map[string]Limit
+	keyer   Keyer
+	now     func() time.Time
+}
+
+// WithLimit sets the limit of the operations of the methods without their own limit.
+// By default, only the methods with their own limit are limited.
+func WithLimit(limit Limit) Option {
+	return func(o *options) {
+		o.limit = limit
+	}
+}
+
+// WithMethodLimit sets the limit of the operations of a method, one of the store.Method constants.
+// The operations of the method have their own buckets, separate from the ones of the other methods.
+func WithMethodLimit(method string, limit Limit) Option {
+	return func(o *options) {
+		o.methods[method] = limit
+	}
+}
+
+// WithKeyer sets the function returning the key of the caller of an operation. By default, every caller
+// shares the same buckets.
+func WithKeyer(keyer Keyer) Option {
+	return func(o *options) {
+		o.keyer = keyer
+	}
+}
+
+// WithClock sets the function returning the current time, e.g. to control the refill of the buckets in tests.
+// It defaults to time.Now.
+func WithClock(now func() time.Time) Option {
+	return func(o *options) {
+		o.now = now
+	}
+}
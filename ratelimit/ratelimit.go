@@ -0,0 +1,171 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// ErrRateLimited is wrapped by the errors of the operations rejected by Middleware, see Error.
+var ErrRateLimited = errors.New("rate limited")
+
+// Error is the error of an operation rejected by Middleware. It wraps ErrRateLimited.
+//
+// Fields:
+//   - Key: The key of the bucket, made of the entity, the method if it has its own limit, and the key of
+//     the caller, e.g. "Article:List:42".
+//   - RetryAfter: The time until the bucket allows an operation again.
+type Error struct {
+	Key        string
+	RetryAfter time.Duration
+}
+
+// Error describes the rejected operation.
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s, retry after %s", ErrRateLimited, e.Key, e.RetryAfter)
+}
+
+// Unwrap returns ErrRateLimited.
+func (e *Error) Unwrap() error {
+	return ErrRateLimited
+}
+
+// Limit is the limit of a token bucket: Burst operations are allowed at once, then Rate operations
+// per second. A limit with a zero Rate does not limit the operations, and Burst is at least 1.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// maxIdleBuckets is the number of buckets above which the full buckets are released,
+// so that the buckets of the callers gone idle do not accumulate.
+const maxIdleBuckets = 10000
+
+// Middleware returns a store.Middleware rejecting the operations exceeding their limit with an *Error,
+// without calling the inner store. Ping is never limited, so that health checks are not.
+//
+// Example:
+//
+//	articleStore := store.Chain[*model.Article, int64](inner, ratelimit.Middleware(
+//		ratelimit.WithMethodLimit(store.MethodList, ratelimit.Limit{Rate: 5, Burst: 10}),
+//	))
+func Middleware(opts ...Option) store.Middleware {
+	o := options{
+		methods: map[string]Limit{},
+		now:     time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	l := &limiter{options: o, buckets: map[string]*bucket{}}
+
+	return func(next store.OperationFunc) store.OperationFunc {
+		return func(ctx context.Context, op *store.Operation) (any, error) {
+			if op.Method == store.MethodPing {
+				return next(ctx, op)
+			}
+
+			if err := l.allow(ctx, op); err != nil {
+				return nil, err
+			}
+
+			return next(ctx, op)
+		}
+	}
+}
+
+// limiter holds the buckets of a Middleware.
+type limiter struct {
+	options
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+// allow takes a token from the bucket of the operation, or returns an *Error if it is empty.
+func (l *limiter) allow(ctx context.Context, op *store.Operation) error {
+	key := op.Entity
+
+	limit, ok := l.methods[op.Method]
+	if ok {
+		key += ":" + op.Method
+	} else {
+		limit = l.limit
+	}
+
+	if limit.Rate <= 0 {
+		return nil
+	}
+
+	if limit.Burst < 1 {
+		limit.Burst = 1
+	}
+
+	if l.keyer != nil {
+		key += ":" + l.keyer(ctx)
+	}
+
+	now := l.now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.buckets[key]
+	if !ok {
+		if len(l.buckets) >= maxIdleBuckets {
+			l.release(now)
+		}
+
+		b = &bucket{limit: limit, tokens: float64(limit.Burst), last: now}
+		l.buckets[key] = b
+	}
+
+	if retryAfter := b.take(now); retryAfter > 0 {
+		return &Error{Key: key, RetryAfter: retryAfter}
+	}
+
+	return nil
+}
+
+// release deletes the buckets refilled to their burst, which are the same as new ones.
+func (l *limiter) release(now time.Time) {
+	for key, b := range l.buckets {
+		if b.refill(now); b.tokens >= float64(b.limit.Burst) {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// bucket is a token bucket.
+type bucket struct {
+	limit  Limit
+	tokens float64
+	last   time.Time
+}
+
+// refill adds the tokens accumulated since the last refill, up to the burst.
+func (b *bucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = math.Min(float64(b.limit.Burst), b.tokens+elapsed.Seconds()*b.limit.Rate)
+		b.last = now
+	}
+}
+
+// take takes a token, or returns the time until a token is available if there is none.
+func (b *bucket) take(now time.Time) time.Duration {
+	b.refill(now)
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+
+	return time.Duration((1 - b.tokens) / b.limit.Rate * float64(time.Second))
+}
@@ -0,0 +1,123 @@
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mockstore "github.com/infevocorp/goflexstore/mocks/store"
+	"github.com/infevocorp/goflexstore/ratelimit"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+type Article struct {
+	ID int
+}
+
+func (a *Article) GetID() int {
+	return a.ID
+}
+
+type userKey struct{}
+
+// clock returns a clock starting at a fixed time, and a function advancing it.
+func clock() (func() time.Time, func(time.Duration)) {
+	now := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	return func() time.Time { return now }, func(d time.Duration) { now = now.Add(d) }
+}
+
+func Test_Middleware(t *testing.T) {
+	t.Run("should-reject-operations-beyond-burst-until-refilled", func(t *testing.T) {
+		// GIVEN
+		var (
+			ctx          = context.Background()
+			inner        = mockstore.NewStore[*Article, int](t)
+			now, advance = clock()
+		)
+
+		inner.EXPECT().List(ctx).Return(nil, nil).Times(3)
+
+		s := store.Chain[*Article, int](inner, ratelimit.Middleware(
+			ratelimit.WithMethodLimit(store.MethodList, ratelimit.Limit{Rate: 2, Burst: 2}),
+			ratelimit.WithClock(now),
+		))
+
+		_, err := s.List(ctx)
+		require.NoError(t, err)
+		_, err = s.List(ctx)
+		require.NoError(t, err)
+
+		// WHEN
+		_, err = s.List(ctx)
+
+		// THEN
+		var rateErr *ratelimit.Error
+		require.ErrorAs(t, err, &rateErr)
+		assert.ErrorIs(t, err, ratelimit.ErrRateLimited)
+		assert.Equal(t, "Article:List", rateErr.Key)
+		assert.Equal(t, 500*time.Millisecond, rateErr.RetryAfter)
+		assert.EqualError(t, err, "rate limited: Article:List, retry after 500ms")
+
+		advance(500 * time.Millisecond)
+
+		_, err = s.List(ctx)
+		assert.NoError(t, err)
+	})
+
+	t.Run("should-limit-callers-separately", func(t *testing.T) {
+		// GIVEN
+		var (
+			john  = context.WithValue(context.Background(), userKey{}, "john")
+			jane  = context.WithValue(context.Background(), userKey{}, "jane")
+			inner = mockstore.NewStore[*Article, int](t)
+		)
+
+		inner.EXPECT().Count(john).Return(1, nil).Once()
+		inner.EXPECT().Count(jane).Return(2, nil).Once()
+
+		s := store.Chain[*Article, int](inner, ratelimit.Middleware(
+			ratelimit.WithLimit(ratelimit.Limit{Rate: 1}),
+			ratelimit.WithKeyer(func(ctx context.Context) string { return ctx.Value(userKey{}).(string) }),
+		))
+
+		// WHEN
+		_, johnErr := s.Count(john)
+		_, janeErr := s.Count(jane)
+		_, johnErr2 := s.Count(john)
+
+		// THEN
+		assert.NoError(t, johnErr)
+		assert.NoError(t, janeErr)
+		assert.ErrorIs(t, johnErr2, ratelimit.ErrRateLimited)
+	})
+
+	t.Run("should-not-limit-methods-without-limit", func(t *testing.T) {
+		// GIVEN
+		var (
+			ctx   = context.Background()
+			inner = mockstore.NewStore[*Article, int](t)
+		)
+
+		inner.EXPECT().Get(ctx).Return(&Article{ID: 1}, nil).Times(3)
+		inner.EXPECT().Ping(ctx).Return(nil).Times(3)
+
+		s := store.Chain[*Article, int](inner, ratelimit.Middleware(
+			ratelimit.WithMethodLimit(store.MethodList, ratelimit.Limit{Rate: 1}),
+			ratelimit.WithLimit(ratelimit.Limit{}),
+		))
+
+		for i := 0; i < 3; i++ {
+			// WHEN
+			_, getErr := s.Get(ctx)
+			pingErr := s.Ping(ctx)
+
+			// THEN
+			assert.NoError(t, getErr)
+			assert.NoError(t, pingErr)
+		}
+	})
+}
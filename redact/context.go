@@ -0,0 +1,33 @@
+package redact
+
+import "context"
+
+type contextKey struct{}
+
+// WithCapabilities returns a copy of ctx carrying the given capabilities in addition to the ones of ctx.
+func WithCapabilities(ctx context.Context, capabilities ...string) context.Context {
+	inherited := capabilitiesOf(ctx)
+
+	all := make([]string, 0, len(inherited)+len(capabilities))
+	all = append(all, inherited...)
+	all = append(all, capabilities...)
+
+	return context.WithValue(ctx, contextKey{}, all)
+}
+
+// HasCapability reports whether ctx carries the capability.
+func HasCapability(ctx context.Context, capability string) bool {
+	for _, c := range capabilitiesOf(ctx) {
+		if c == capability {
+			return true
+		}
+	}
+
+	return false
+}
+
+func capabilitiesOf(ctx context.Context) []string {
+	capabilities, _ := ctx.Value(contextKey{}).([]string)
+
+	return capabilities
+}
@@ -0,0 +1,24 @@
+// Package redact masks the sensitive fields of the entities read from stores, such as personal data, unless the
+// context carries the capability to see them, so that one store serves both privileged and unprivileged callers.
+//
+// The capabilities are attached to the context with WithCapabilities, typically by an authorization middleware.
+// Stores decorated with Middleware return copies of the entities read by Get, List, ListWithCount and
+// DeleteReturning whose configured fields are blanked, i.e. set to their zero value, or replaced by a hash of their
+// value, which keeps them comparable, e.g. to group by email, without revealing them. The fields of nested structs
+// and of structs pointed to are configured by their path, e.g. "Address.Street". The entities held by the inner
+// store and its caches, and the structs they point to, are never modified.
+//
+// Example:
+//
+//	customerStore := store.Chain[*model.Customer, int64](inner, redact.Middleware(
+//		redact.Blank("Phone", "BirthDate"),
+//		redact.Hash("Email"),
+//		redact.WithCapability("customers:pii"),
+//	))
+//
+//	// customer.Email is the hex SHA-256 hash of the email
+//	customer, err := customerStore.Get(ctx, filters.IDs(42))
+//
+//	// customer.Email is the email
+//	customer, err = customerStore.Get(redact.WithCapabilities(ctx, "customers:pii"), filters.IDs(42))
+package redact
@@ -0,0 +1,42 @@
+package redact
+
+// Option is a function that configures the Middleware.
+type Option func(*options)
+
+type options struct {
+	capability string
+	blank      []string
+	hash       []string
+	hashKey    []byte
+}
+
+// Blank masks the fields by setting them to their zero value. The fields of nested structs, or of structs pointed
+// to, are named by their path, e.g. "Address.Street".
+func Blank(fields ...string) Option {
+	return func(o *options) {
+		o.blank = append(o.blank, fields...)
+	}
+}
+
+// Hash masks the fields by replacing them with the hex SHA-256 hash of their value. The fields must be strings
+// or pointers to strings; nil pointers are kept nil. Nested fields are named by their path, as with Blank.
+func Hash(fields ...string) Option {
+	return func(o *options) {
+		o.hash = append(o.hash, fields...)
+	}
+}
+
+// WithHashKey makes Hash use the HMAC-SHA256 of the values with the key, so that the hashes of guessable values,
+// such as phone numbers, cannot be reversed by hashing every candidate.
+func WithHashKey(key []byte) Option {
+	return func(o *options) {
+		o.hashKey = key
+	}
+}
+
+// WithCapability sets the capability unmasking the fields, see WithCapabilities. It defaults to "pii".
+func WithCapability(capability string) Option {
+	return func(o *options) {
+		o.capability = capability
+	}
+}
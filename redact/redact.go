@@ -0,0 +1,206 @@
+package redact
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"reflect"
+	"strings"
+
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// Middleware returns a store.Middleware masking the configured fields of the entities returned by Get, List,
+// ListWithCount and DeleteReturning, unless the context carries the capability set by WithCapability.
+//
+// It fails the reads of entities missing a configured field, or whose hashed field is not a string, so that a
+// misspelled field name cannot leak the field it was meant to mask.
+func Middleware(opts ...Option) store.Middleware {
+	o := options{
+		capability: "pii",
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return func(next store.OperationFunc) store.OperationFunc {
+		return func(ctx context.Context, op *store.Operation) (any, error) {
+			result, err := next(ctx, op)
+			if err != nil || HasCapability(ctx, o.capability) {
+				return result, err
+			}
+
+			switch op.Method {
+			case store.MethodGet, store.MethodList, store.MethodDeleteReturning:
+			case store.MethodListWithCount:
+				masked, err := o.maskEntities(reflect.ValueOf(result))
+				if err != nil {
+					return nil, fmt.Errorf("redact %s: %w", op.Entity, err)
+				}
+
+				return masked.Interface(), nil
+			default:
+				return result, nil
+			}
+
+			masked, err := o.mask(reflect.ValueOf(result))
+			if err != nil {
+				return nil, fmt.Errorf("redact %s: %w", op.Entity, err)
+			}
+
+			return masked.Interface(), nil
+		}
+	}
+}
+
+// maskEntities returns a copy of a store.ListWithCountResult whose entities are masked.
+func (o *options) maskEntities(result reflect.Value) (reflect.Value, error) {
+	masked := reflect.New(result.Type()).Elem()
+	masked.Set(result)
+
+	entities := masked.FieldByName("Entities")
+
+	maskedEntities, err := o.mask(entities)
+	if err != nil {
+		return result, err
+	}
+
+	entities.Set(maskedEntities)
+
+	return masked, nil
+}
+
+// mask returns a masked copy of an entity, a pointer to an entity or a slice of them.
+func (o *options) mask(v reflect.Value) (reflect.Value, error) {
+	switch {
+	case !v.IsValid():
+		return v, nil
+	case v.Kind() == reflect.Slice:
+		if v.IsNil() {
+			return v, nil
+		}
+
+		masked := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+
+		for i := 0; i < v.Len(); i++ {
+			entity, err := o.mask(v.Index(i))
+			if err != nil {
+				return v, err
+			}
+
+			masked.Index(i).Set(entity)
+		}
+
+		return masked, nil
+	case v.Kind() == reflect.Pointer:
+		if v.IsNil() {
+			return v, nil
+		}
+
+		entity, err := o.mask(v.Elem())
+		if err != nil {
+			return v, err
+		}
+
+		masked := reflect.New(entity.Type())
+		masked.Elem().Set(entity)
+
+		return masked, nil
+	case v.Kind() == reflect.Struct:
+		return o.maskStruct(v)
+	default:
+		return v, fmt.Errorf("cannot mask fields of %s", v.Type())
+	}
+}
+
+// maskStruct returns a copy of the struct with its configured fields masked.
+func (o *options) maskStruct(v reflect.Value) (reflect.Value, error) {
+	masked := reflect.New(v.Type()).Elem()
+	masked.Set(v)
+
+	for _, name := range o.blank {
+		field, err := fieldByPath(masked, name)
+		if err != nil {
+			return v, err
+		}
+
+		if field.IsValid() {
+			field.SetZero()
+		}
+	}
+
+	for _, name := range o.hash {
+		field, err := fieldByPath(masked, name)
+		if err != nil {
+			return v, err
+		}
+
+		switch {
+		case !field.IsValid():
+		case field.Kind() == reflect.String:
+			field.SetString(o.digest(field.String()))
+		case field.Kind() == reflect.Pointer && field.Type().Elem().Kind() == reflect.String:
+			if field.IsNil() {
+				continue
+			}
+
+			digest := reflect.New(field.Type().Elem())
+			digest.Elem().SetString(o.digest(field.Elem().String()))
+			field.Set(digest)
+		default:
+			return v, fmt.Errorf("cannot hash field %s of type %s", name, field.Type())
+		}
+	}
+
+	return masked, nil
+}
+
+// fieldByPath returns the settable field of the struct at the path, the names of the nested fields separated by
+// dots. The structs pointed to along the path are copied, so that masking the field does not modify them, and the
+// zero Value is returned when one of the pointers is nil.
+func fieldByPath(v reflect.Value, path string) (reflect.Value, error) {
+	t := v.Type()
+
+	for i, name := range strings.Split(path, ".") {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, nil
+			}
+
+			elem := reflect.New(v.Type().Elem())
+			elem.Elem().Set(v.Elem())
+			v.Set(elem)
+			v = elem.Elem()
+		}
+
+		if v.Kind() == reflect.Struct {
+			v = v.FieldByName(name)
+		} else {
+			v = reflect.Value{}
+		}
+
+		if !v.IsValid() || !v.CanSet() {
+			return reflect.Value{}, fmt.Errorf("%s has no field %s", t, path)
+		}
+	}
+
+	return v, nil
+}
+
+// digest returns the hex hash of the value.
+func (o *options) digest(value string) string {
+	var h hash.Hash
+	if o.hashKey != nil {
+		h = hmac.New(sha256.New, o.hashKey)
+	} else {
+		h = sha256.New()
+	}
+
+	h.Write([]byte(value))
+
+	return hex.EncodeToString(h.Sum(nil))
+}
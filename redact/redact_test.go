@@ -0,0 +1,167 @@
+package redact_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infevocorp/goflexstore/query"
+	"github.com/infevocorp/goflexstore/redact"
+	"github.com/infevocorp/goflexstore/store"
+	"github.com/infevocorp/goflexstore/storetest"
+)
+
+type Customer struct {
+	ID    int64
+	Name  string
+	Email string
+	Phone *string
+}
+
+func (c *Customer) GetID() int64 {
+	return c.ID
+}
+
+type Address struct {
+	Street string
+	City   string
+}
+
+type Order struct {
+	ID       int64
+	Billing  Address
+	Shipping *Address
+}
+
+func (o *Order) GetID() int64 {
+	return o.ID
+}
+
+func sha(value string) string {
+	sum := sha256.Sum256([]byte(value))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func newCustomers(opts ...redact.Option) (*storetest.Fake[*Customer, int64], store.Store[*Customer, int64]) {
+	phone := "+33 6 12 34 56 78"
+	inner := storetest.NewFake[*Customer, int64](
+		&Customer{ID: 1, Name: "Ada", Email: "ada@example.com", Phone: &phone},
+		&Customer{ID: 2, Name: "Bob", Email: "bob@example.com"},
+	)
+
+	return inner, store.Chain[*Customer, int64](inner, redact.Middleware(opts...))
+}
+
+func Test_Middleware(t *testing.T) {
+	t.Run("should-mask-fields-without-capability", func(t *testing.T) {
+		// GIVEN
+		inner, customers := newCustomers(redact.Blank("Phone"), redact.Hash("Email"))
+
+		// WHEN
+		list, err := customers.List(context.Background())
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, []*Customer{
+			{ID: 1, Name: "Ada", Email: sha("ada@example.com")},
+			{ID: 2, Name: "Bob", Email: sha("bob@example.com")},
+		}, list)
+		assert.Equal(t, "ada@example.com", inner.Entities()[0].Email)
+	})
+
+	t.Run("should-not-mask-fields-with-capability", func(t *testing.T) {
+		// GIVEN
+		_, customers := newCustomers(redact.Hash("Email"), redact.WithCapability("customers:pii"))
+		ctx := redact.WithCapabilities(context.Background(), "customers:read", "customers:pii")
+
+		// WHEN
+		customer, err := customers.Get(ctx, query.Filter("ID", int64(1)))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, "ada@example.com", customer.Email)
+	})
+
+	t.Run("should-hash-pointers-with-key", func(t *testing.T) {
+		// GIVEN
+		_, customers := newCustomers(redact.Hash("Phone"), redact.WithHashKey([]byte("secret")))
+
+		// WHEN
+		list, err := customers.List(context.Background())
+
+		// THEN
+		require.NoError(t, err)
+		require.NotNil(t, list[0].Phone)
+		assert.Len(t, *list[0].Phone, 64)
+		assert.NotEqual(t, sha("+33 6 12 34 56 78"), *list[0].Phone)
+		assert.Nil(t, list[1].Phone)
+	})
+
+	t.Run("should-fail-on-unknown-field", func(t *testing.T) {
+		// GIVEN
+		_, customers := newCustomers(redact.Blank("Emial"))
+
+		// WHEN
+		_, err := customers.Get(context.Background(), query.Filter("ID", int64(1)))
+
+		// THEN
+		assert.EqualError(t, err, "redact Customer: redact_test.Customer has no field Emial")
+	})
+
+	t.Run("should-mask-nested-fields", func(t *testing.T) {
+		// GIVEN
+		inner := storetest.NewFake[*Order, int64](
+			&Order{
+				ID:       1,
+				Billing:  Address{Street: "1 Main St", City: "Paris"},
+				Shipping: &Address{Street: "2 Side St", City: "Lyon"},
+			},
+			&Order{ID: 2, Billing: Address{Street: "3 High St", City: "Nice"}},
+		)
+		orders := store.Chain[*Order, int64](inner, redact.Middleware(
+			redact.Blank("Billing.Street", "Shipping.Street"),
+			redact.Hash("Shipping.City"),
+		))
+
+		// WHEN
+		list, err := orders.List(context.Background())
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, []*Order{
+			{ID: 1, Billing: Address{City: "Paris"}, Shipping: &Address{City: sha("Lyon")}},
+			{ID: 2, Billing: Address{City: "Nice"}},
+		}, list)
+		assert.Equal(t, Address{Street: "2 Side St", City: "Lyon"}, *inner.Entities()[0].Shipping)
+		assert.Equal(t, "1 Main St", inner.Entities()[0].Billing.Street)
+	})
+
+	t.Run("should-fail-on-unknown-nested-field", func(t *testing.T) {
+		// GIVEN
+		inner := storetest.NewFake[*Order, int64](&Order{ID: 1, Shipping: &Address{}})
+		orders := store.Chain[*Order, int64](inner, redact.Middleware(redact.Blank("Shipping.Zip")))
+
+		// WHEN
+		_, err := orders.List(context.Background())
+
+		// THEN
+		assert.EqualError(t, err, "redact Order: redact_test.Order has no field Shipping.Zip")
+	})
+}
+
+func Test_HasCapability(t *testing.T) {
+	// GIVEN
+	ctx := redact.WithCapabilities(context.Background(), "a")
+	child := redact.WithCapabilities(ctx, "b")
+
+	// THEN
+	assert.True(t, redact.HasCapability(child, "a"))
+	assert.True(t, redact.HasCapability(child, "b"))
+	assert.False(t, redact.HasCapability(ctx, "b"))
+	assert.False(t, redact.HasCapability(context.Background(), "a"))
+}
@@ -0,0 +1,54 @@
+// Package rediscache is the Redis backend of the cachestore package, sharing the cached reads of the stores
+// between the instances of a service.
+//
+// Example:
+//
+//	client := redis.NewClient(&redis.Options{Addr: "localhost:6379"})
+//	articleStore := cachestore.New[*model.Article, int64](inner, rediscache.New(client))
+package rediscache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/infevocorp/goflexstore/cachestore"
+)
+
+// Cache is a cachestore.Cache holding the values in Redis.
+type Cache struct {
+	client redis.UniversalClient
+}
+
+var _ cachestore.Cache = (*Cache)(nil)
+
+// New creates a Cache holding the values in the Redis server, cluster or sentinel of the client.
+func New(client redis.UniversalClient) *Cache {
+	return &Cache{client: client}
+}
+
+// Get returns the value of the key, and false if the key is missing or expired.
+func (c *Cache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := c.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	return value, true, nil
+}
+
+// Set sets the value of the key, expiring after ttl, or never if ttl is 0.
+func (c *Cache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return c.client.Set(ctx, key, value, ttl).Err()
+}
+
+// Delete deletes the key, if any.
+func (c *Cache) Delete(ctx context.Context, key string) error {
+	return c.client.Del(ctx, key).Err()
+}
@@ -0,0 +1,69 @@
+package rediscache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	rediscache "github.com/infevocorp/goflexstore/redis/cache"
+)
+
+func Test_Cache(t *testing.T) {
+	newCache := func(t *testing.T) (*miniredis.Miniredis, *rediscache.Cache) {
+		server := miniredis.RunT(t)
+
+		return server, rediscache.New(redis.NewClient(&redis.Options{Addr: server.Addr()}))
+	}
+
+	t.Run("should-set-get-and-delete-values", func(t *testing.T) {
+		// GIVEN
+		ctx := context.Background()
+		_, cache := newCache(t)
+
+		// WHEN
+		require.NoError(t, cache.Set(ctx, "a", []byte("1"), 0))
+		value, ok, err := cache.Get(ctx, "a")
+		require.NoError(t, err)
+
+		require.NoError(t, cache.Delete(ctx, "a"))
+		_, deleted, deletedErr := cache.Get(ctx, "a")
+
+		// THEN
+		assert.True(t, ok)
+		assert.Equal(t, []byte("1"), value)
+		require.NoError(t, deletedErr)
+		assert.False(t, deleted)
+	})
+
+	t.Run("should-expire-values", func(t *testing.T) {
+		// GIVEN
+		ctx := context.Background()
+		server, cache := newCache(t)
+		require.NoError(t, cache.Set(ctx, "a", []byte("1"), time.Minute))
+
+		// WHEN
+		server.FastForward(2 * time.Minute)
+		_, ok, err := cache.Get(ctx, "a")
+
+		// THEN
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("should-return-connection-errors", func(t *testing.T) {
+		// GIVEN
+		server, cache := newCache(t)
+		server.Close()
+
+		// WHEN
+		_, _, err := cache.Get(context.Background(), "a")
+
+		// THEN
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,34 @@
+// Package redisstore provides a Redis implementation of the store interface, for the entities mostly accessed by
+// ID, such as sessions, carts or feature settings.
+//
+// The Store encodes each DTO, in JSON or MessagePack, as the value of the key prefix:ID, optionally expiring after
+// a TTL, and keeps the IDs of the entities in the set prefix:ids. The query params are evaluated by a
+// memstore.Store against the entities which may match them: the ones of the top level equality and IN filters of
+// the ID and of the fields indexed with WithIndex, or all the entities of the store otherwise. Every filter, group,
+// ordering, pagination and select supported by memstore can then be used, but only the filters of the ID and the
+// indexed fields avoid loading every entity.
+//
+// The index of a field is a set prefix:idx:Field:value of the IDs of the entities having the value. The sets are
+// only used to select the entities to evaluate the params against, so that the entries left by the expired
+// entities never make them match.
+//
+// The writes read the entities they change in a transaction watching their keys and write them, with their index
+// entries, in a single MULTI/EXEC: they fail with redis.TxFailedErr if one of the entities is changed by another
+// client in between. With Redis Cluster, use a prefix with a hash tag, e.g. "{sessions}", for all the keys of the
+// store to belong to the same slot.
+//
+// Example:
+//
+//	type Session struct {
+//		ID     string `json:"id"`
+//		UserID int64  `json:"user_id"`
+//		Device string `json:"device"`
+//	}
+//
+//	sessionStore := redisstore.NewSimple[*Session, string](client, "sessions",
+//		redisstore.WithIndex[*Session, *Session, string]("UserID"),
+//		redisstore.WithTTL[*Session, *Session, string](24*time.Hour),
+//	)
+//
+//	sessions, err := sessionStore.List(ctx, query.Filter("UserID", userID))
+package redisstore
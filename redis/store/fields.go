@@ -0,0 +1,100 @@
+package redisstore
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// fieldOf returns the name of the field of the struct, or pointer to struct, type matching the name, ignoring case
+// and underscores like the filters.
+func fieldOf(t reflect.Type, name string) (string, bool) {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == nil || t.Kind() != reflect.Struct {
+		return "", false
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		if field := t.Field(i); field.IsExported() && normalize(field.Name) == normalize(name) {
+			return field.Name, true
+		}
+	}
+
+	return "", false
+}
+
+// fieldValue returns the string of the value of the field of the DTO, as stored in the index keys.
+func fieldValue(dto any, field string) string {
+	v := reflect.Indirect(reflect.ValueOf(dto))
+	if v.Kind() != reflect.Struct {
+		return ""
+	}
+
+	return valueString(v.FieldByName(field))
+}
+
+// valueString returns the string of a value of an index key, the empty string for nil pointers.
+func valueString(v reflect.Value) string {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return ""
+		}
+
+		v = v.Elem()
+	}
+
+	if !v.IsValid() {
+		return ""
+	}
+
+	return fmt.Sprint(v.Interface())
+}
+
+// collection returns the values of a slice, other than bytes, or array value, and the value itself otherwise.
+func collection(value any) []any {
+	v := reflect.ValueOf(value)
+
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8 || v.Kind() == reflect.Array {
+		values := make([]any, v.Len())
+		for i := range values {
+			values[i] = v.Index(i).Interface()
+		}
+
+		return values
+	}
+
+	return []any{value}
+}
+
+// withID returns a copy of the DTO whose ID field is set to id.
+func withID[DTO, ID any](dto DTO, id ID) DTO {
+	v := reflect.ValueOf(&dto).Elem()
+
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return dto
+		}
+
+		c := reflect.New(v.Type().Elem())
+		c.Elem().Set(v.Elem())
+		v.Set(c)
+		v = c.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return dto
+	}
+
+	if field := v.FieldByName("ID"); field.IsValid() && field.CanSet() && reflect.TypeOf(id).AssignableTo(field.Type()) {
+		field.Set(reflect.ValueOf(id))
+	}
+
+	return dto
+}
+
+func normalize(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+}
@@ -0,0 +1,88 @@
+package redisstore
+
+import (
+	"time"
+
+	"github.com/infevocorp/goflexstore/converter"
+	"github.com/infevocorp/goflexstore/idgen"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// Option is a function that modifies the store.
+// It is used to set various configuration options for the Store at the time of its creation.
+type Option[Entity store.Entity[ID], DTO store.Entity[ID], ID comparable] func(*Store[Entity, DTO, ID])
+
+// WithConverter sets the converter used for transforming between entity and DTO types.
+func WithConverter[
+	Entity store.Entity[ID],
+	DTO store.Entity[ID],
+	ID comparable,
+](
+	converter converter.Converter[Entity, DTO, ID],
+) Option[Entity, DTO, ID] {
+	return func(s *Store[Entity, DTO, ID]) {
+		s.Converter = converter
+	}
+}
+
+// WithIDGenerator sets the generator of the IDs of the entities created with a zero ID by Create, CreateMany and
+// Upsert. Without generator, the zero integer IDs are taken from a counter incremented in Redis.
+func WithIDGenerator[
+	Entity store.Entity[ID],
+	DTO store.Entity[ID],
+	ID comparable,
+](
+	generator idgen.Generator[ID],
+) Option[Entity, DTO, ID] {
+	return func(s *Store[Entity, DTO, ID]) {
+		s.IDGenerator = generator
+	}
+}
+
+// WithCodec sets the codec of the DTOs, JSON by default.
+//
+// Example:
+//
+//	redisstore.WithCodec[*model.Session, *model.Session, string](redisstore.Msgpack)
+func WithCodec[
+	Entity store.Entity[ID],
+	DTO store.Entity[ID],
+	ID comparable,
+](
+	codec Codec,
+) Option[Entity, DTO, ID] {
+	return func(s *Store[Entity, DTO, ID]) {
+		s.Codec = codec
+	}
+}
+
+// WithIndex maintains secondary indexes of the DTO fields, so that the equality filters of the fields only load
+// the matching entities instead of all of them.
+//
+// Example:
+//
+//	redisstore.WithIndex[*model.Session, *model.Session, string]("UserID", "Device")
+func WithIndex[
+	Entity store.Entity[ID],
+	DTO store.Entity[ID],
+	ID comparable,
+](
+	fields ...string,
+) Option[Entity, DTO, ID] {
+	return func(s *Store[Entity, DTO, ID]) {
+		s.Indexes = append(s.Indexes, fields...)
+	}
+}
+
+// WithTTL makes the entities expire ttl after they were last written.
+func WithTTL[
+	Entity store.Entity[ID],
+	DTO store.Entity[ID],
+	ID comparable,
+](
+	ttl time.Duration,
+) Option[Entity, DTO, ID] {
+	return func(s *Store[Entity, DTO, ID]) {
+		s.TTL = ttl
+	}
+}
@@ -0,0 +1,725 @@
+package redisstore
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/infevocorp/goflexstore/converter"
+	"github.com/infevocorp/goflexstore/idgen"
+	"github.com/infevocorp/goflexstore/memstore"
+	"github.com/infevocorp/goflexstore/query"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// New initializes a new Store keeping the entities in Redis under keys starting with the prefix.
+//
+// Entity and DTO are types that must implement the store.Entity interface, the DTO having an ID field.
+// ID is the type of the identifier for the entities.
+//
+// It panics if an indexed field, see WithIndex, is not a field of the DTO.
+func New[Entity store.Entity[ID], DTO store.Entity[ID], ID comparable](
+	client redis.UniversalClient,
+	prefix string,
+	options ...Option[Entity, DTO, ID],
+) *Store[Entity, DTO, ID] {
+	s := &Store[Entity, DTO, ID]{
+		Client: client,
+		Prefix: prefix,
+		Codec:  JSON,
+	}
+
+	for _, option := range options {
+		option(s)
+	}
+
+	if s.Converter == nil {
+		if converter.SameType[Entity, DTO]() {
+			s.Converter = converter.NewIdentity[Entity, DTO, ID]()
+		} else {
+			s.Converter = converter.NewReflect[Entity, DTO, ID](nil)
+		}
+	}
+
+	if s.IDGenerator == nil && idgen.IsUUID[ID]() {
+		s.IDGenerator = idgen.UUIDv7[ID]
+	}
+
+	for i, index := range s.Indexes {
+		field, ok := fieldOf(reflect.TypeOf(*new(DTO)), index)
+		if !ok {
+			panic(fmt.Sprintf("redisstore: DTO %T has no field %s to index", *new(DTO), index))
+		}
+
+		s.Indexes[i] = field
+	}
+
+	return s
+}
+
+// NewSimple initializes a new Store for entities which are also the DTOs, encoded as they are.
+//
+// Example:
+//
+//	sessionStore := redisstore.NewSimple[*model.Session, string](client, "sessions",
+//		redisstore.WithIndex[*model.Session, *model.Session, string]("UserID"),
+//		redisstore.WithTTL[*model.Session, *model.Session, string](24*time.Hour),
+//	)
+func NewSimple[T store.Entity[ID], ID comparable](
+	client redis.UniversalClient,
+	prefix string,
+	options ...Option[T, T, ID],
+) *Store[T, T, ID] {
+	return New[T, T, ID](client, prefix, options...)
+}
+
+// Store represents a storage mechanism keeping the entities in Redis, for the entities mostly accessed by ID.
+// It supports CRUD operations and is designed to be generic for any Entity and DTO types.
+//
+// Each DTO is encoded by the Codec as the value of the key Prefix:ID. The set Prefix:ids holds the IDs of the
+// entities, and the sets Prefix:idx:Field:value the IDs of the entities of each value of the indexed fields.
+//
+// Entity: The domain model type.
+// DTO: The data transfer object type, encoded as the values of the keys.
+// ID: The type of the unique identifier for the entity.
+type Store[Entity store.Entity[ID], DTO store.Entity[ID], ID comparable] struct {
+	Client      redis.UniversalClient
+	Prefix      string
+	Codec       Codec
+	Converter   converter.Converter[Entity, DTO, ID]
+	IDGenerator idgen.Generator[ID]
+	Indexes     []string
+	TTL         time.Duration
+}
+
+// change is a DTO written by a transaction, before and after the write, nil when it is created or deleted.
+type change[DTO any] struct {
+	before *DTO
+	after  *DTO
+}
+
+// Get retrieves the first entity matching the params, or store.ErrNotFound.
+func (s *Store[Entity, DTO, ID]) Get(ctx context.Context, params ...query.Param) (Entity, error) {
+	mem, err := s.load(ctx, params)
+	if err != nil {
+		return *new(Entity), err
+	}
+
+	dto, err := mem.Get(ctx, params...)
+	if err != nil {
+		return *new(Entity), err
+	}
+
+	return s.Converter.ToEntity(dto), nil
+}
+
+// List retrieves the entities matching the params, ordered and paginated accordingly.
+func (s *Store[Entity, DTO, ID]) List(ctx context.Context, params ...query.Param) ([]Entity, error) {
+	mem, err := s.load(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos, err := mem.List(ctx, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	return converter.ToMany(dtos, s.Converter.ToEntity), nil
+}
+
+// ListWithCount retrieves the entities matching the params, like List, together with the number of matching
+// entities, counted without the Paginate params.
+func (s *Store[Entity, DTO, ID]) ListWithCount(ctx context.Context, params ...query.Param) ([]Entity, int64, error) {
+	mem, err := s.load(ctx, params)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	dtos, count, err := mem.ListWithCount(ctx, params...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return converter.ToMany(dtos, s.Converter.ToEntity), count, nil
+}
+
+// Count returns the number of entities matching the filters of the params.
+func (s *Store[Entity, DTO, ID]) Count(ctx context.Context, params ...query.Param) (int64, error) {
+	mem, err := s.load(ctx, params)
+	if err != nil {
+		return 0, err
+	}
+
+	return mem.Count(ctx, params...)
+}
+
+// Aggregate computes the aggregate function over the field of the entities matching the filters of the params.
+func (s *Store[Entity, DTO, ID]) Aggregate(
+	ctx context.Context,
+	agg store.Aggregation,
+	field string,
+	params ...query.Param,
+) (float64, error) {
+	mem, err := s.load(ctx, params)
+	if err != nil {
+		return 0, err
+	}
+
+	return mem.Aggregate(ctx, agg, field, params...)
+}
+
+// AggregateMany computes the aggregates over the entities matching the filters of the params.
+func (s *Store[Entity, DTO, ID]) AggregateMany(
+	ctx context.Context,
+	aggs []store.Aggregate,
+	params ...query.Param,
+) ([]float64, error) {
+	mem, err := s.load(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return mem.AggregateMany(ctx, aggs, params...)
+}
+
+// Exists reports whether an entity matches the filters of the params.
+func (s *Store[Entity, DTO, ID]) Exists(ctx context.Context, params ...query.Param) (bool, error) {
+	mem, err := s.load(ctx, params)
+	if err != nil {
+		return false, err
+	}
+
+	return mem.Exists(ctx, params...)
+}
+
+// Create stores the entity and returns its ID, generated when it is zero. It returns a store.DuplicateError if an
+// entity has the same ID.
+func (s *Store[Entity, DTO, ID]) Create(ctx context.Context, entity Entity) (ID, error) {
+	dtos := []DTO{s.Converter.ToDTO(entity)}
+
+	if err := s.create(ctx, dtos); err != nil {
+		return *new(ID), err
+	}
+
+	return dtos[0].GetID(), nil
+}
+
+// CreateMany stores the entities in a single pipelined transaction, generating their IDs like Create.
+func (s *Store[Entity, DTO, ID]) CreateMany(ctx context.Context, entities []Entity) error {
+	if len(entities) == 0 {
+		return nil
+	}
+
+	return s.create(ctx, converter.ToMany(entities, s.Converter.ToDTO))
+}
+
+// Upsert replaces the entity having the same ID, or creates it when there is none. The conflicts are detected by
+// ID only, onConflict.Columns being ignored.
+// With onConflict.DoNothing, or when it does not satisfy onConflict.UpdateWhere, an existing entity is left unchanged.
+func (s *Store[Entity, DTO, ID]) Upsert(ctx context.Context, entity Entity, onConflict store.OnConflict) (ID, error) {
+	dto := s.Converter.ToDTO(entity)
+
+	if err := s.generateID(ctx, &dto); err != nil {
+		return *new(ID), err
+	}
+
+	err := s.write(ctx, []string{s.id(dto.GetID())}, func(mem *memstore.Store[DTO, ID]) ([]change[DTO], error) {
+		before := mem.Entities()
+
+		if _, err := mem.Upsert(ctx, dto, onConflict); err != nil {
+			return nil, err
+		}
+
+		return changes(before, mem.Entities()), nil
+	})
+	if err != nil {
+		return *new(ID), err
+	}
+
+	return dto.GetID(), nil
+}
+
+// Update replaces the entities matching the filters of the params with the entity, keeping their IDs.
+// Without filters, the entity having the same ID is replaced.
+func (s *Store[Entity, DTO, ID]) Update(ctx context.Context, entity Entity, params ...query.Param) error {
+	dto := s.Converter.ToDTO(entity)
+
+	return s.update(ctx, dto, params, func(mem *memstore.Store[DTO, ID]) error {
+		return mem.Update(ctx, dto, params...)
+	})
+}
+
+// PartialUpdate sets the non-zero fields of the entity on the entities matching the filters of the params.
+// Without filters, the entity having the same ID is updated.
+func (s *Store[Entity, DTO, ID]) PartialUpdate(ctx context.Context, entity Entity, params ...query.Param) error {
+	dto := s.Converter.ToDTO(entity)
+
+	return s.update(ctx, dto, params, func(mem *memstore.Store[DTO, ID]) error {
+		return mem.PartialUpdate(ctx, dto, params...)
+	})
+}
+
+// UpdateMany sets the fields of updates, matched by name like the filters, on the entities matching the filters of
+// the params, and returns their number.
+func (s *Store[Entity, DTO, ID]) UpdateMany(
+	ctx context.Context,
+	updates map[string]any,
+	params ...query.Param,
+) (int64, error) {
+	ids, err := s.candidates(ctx, query.NewParams(params...))
+	if err != nil {
+		return 0, err
+	}
+
+	var updated int64
+
+	err = s.write(ctx, ids, func(mem *memstore.Store[DTO, ID]) ([]change[DTO], error) {
+		before := mem.Entities()
+
+		updated, err = mem.UpdateMany(ctx, updates, params...)
+		if err != nil {
+			return nil, err
+		}
+
+		return changes(before, mem.Entities()), nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return updated, nil
+}
+
+// Delete deletes the entities matching the filters of the params.
+func (s *Store[Entity, DTO, ID]) Delete(ctx context.Context, params ...query.Param) error {
+	_, err := s.DeleteReturning(ctx, params...)
+
+	return err
+}
+
+// DeleteReturning deletes the entities matching the filters of the params and returns them.
+func (s *Store[Entity, DTO, ID]) DeleteReturning(ctx context.Context, params ...query.Param) ([]Entity, error) {
+	ids, err := s.candidates(ctx, query.NewParams(params...))
+	if err != nil {
+		return nil, err
+	}
+
+	var deleted []DTO
+
+	err = s.write(ctx, ids, func(mem *memstore.Store[DTO, ID]) ([]change[DTO], error) {
+		deleted, err = mem.DeleteReturning(ctx, params...)
+		if err != nil {
+			return nil, err
+		}
+
+		deletes := make([]change[DTO], len(deleted))
+		for i := range deleted {
+			deletes[i] = change[DTO]{before: &deleted[i]}
+		}
+
+		return deletes, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return converter.ToMany(deleted, s.Converter.ToEntity), nil
+}
+
+// Ping checks that the Redis server is reachable.
+func (s *Store[Entity, DTO, ID]) Ping(ctx context.Context) error {
+	return s.Client.Ping(ctx).Err()
+}
+
+// create generates the zero IDs of the DTOs and stores them in a transaction failing if one of their keys exists.
+func (s *Store[Entity, DTO, ID]) create(ctx context.Context, dtos []DTO) error {
+	keys := make([]string, len(dtos))
+
+	for i := range dtos {
+		if err := s.generateID(ctx, &dtos[i]); err != nil {
+			return err
+		}
+
+		keys[i] = s.key(s.id(dtos[i].GetID()))
+	}
+
+	return s.Client.Watch(ctx, func(tx *redis.Tx) error {
+		existing, err := tx.Exists(ctx, keys...).Result()
+		if err != nil {
+			return err
+		}
+
+		if existing > 0 {
+			return &store.DuplicateError{Entity: store.EntityName[Entity](), Fields: []string{"ID"}}
+		}
+
+		creates := make([]change[DTO], len(dtos))
+		for i := range dtos {
+			creates[i] = change[DTO]{after: &dtos[i]}
+		}
+
+		return s.commit(ctx, tx, creates)
+	}, keys...)
+}
+
+// update runs the update of the store on the entities matching the filters of the params, or on the entity having
+// the ID of the DTO without filters.
+func (s *Store[Entity, DTO, ID]) update(
+	ctx context.Context,
+	dto DTO,
+	params []query.Param,
+	update func(mem *memstore.Store[DTO, ID]) error,
+) error {
+	queryParams := query.NewParams(params...)
+
+	ids := []string{s.id(dto.GetID())}
+
+	if hasFilters(queryParams) {
+		var err error
+
+		if ids, err = s.candidates(ctx, queryParams); err != nil {
+			return err
+		}
+	}
+
+	return s.write(ctx, ids, func(mem *memstore.Store[DTO, ID]) ([]change[DTO], error) {
+		before := mem.Entities()
+
+		if err := update(mem); err != nil {
+			return nil, err
+		}
+
+		return changes(before, mem.Entities()), nil
+	})
+}
+
+// write loads the entities of the IDs in a memstore.Store, within a transaction watching their keys, and commits
+// the changes made by op. The transaction fails with redis.TxFailedErr if one of the keys is changed in between.
+func (s *Store[Entity, DTO, ID]) write(
+	ctx context.Context,
+	ids []string,
+	op func(mem *memstore.Store[DTO, ID]) ([]change[DTO], error),
+) error {
+	if len(ids) == 0 {
+		_, err := op(memstore.New[DTO, ID]())
+		return err
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = s.key(id)
+	}
+
+	return s.Client.Watch(ctx, func(tx *redis.Tx) error {
+		dtos, _, err := s.get(ctx, tx, keys)
+		if err != nil {
+			return err
+		}
+
+		changes, err := op(memstore.New[DTO, ID](dtos...))
+		if err != nil {
+			return err
+		}
+
+		return s.commit(ctx, tx, changes)
+	}, keys...)
+}
+
+// commit writes the changes and their index entries in a MULTI/EXEC transaction.
+func (s *Store[Entity, DTO, ID]) commit(ctx context.Context, tx *redis.Tx, changes []change[DTO]) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	_, err := tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, c := range changes {
+			if c.after == nil {
+				id := s.id((*c.before).GetID())
+
+				pipe.Del(ctx, s.key(id))
+				pipe.SRem(ctx, s.idsKey(), id)
+
+				for _, index := range s.Indexes {
+					pipe.SRem(ctx, s.indexKey(index, fieldValue(*c.before, index)), id)
+				}
+
+				continue
+			}
+
+			id := s.id((*c.after).GetID())
+
+			value, err := s.Codec.Marshal(*c.after)
+			if err != nil {
+				return err
+			}
+
+			pipe.Set(ctx, s.key(id), value, s.TTL)
+			pipe.SAdd(ctx, s.idsKey(), id)
+
+			for _, index := range s.Indexes {
+				value := fieldValue(*c.after, index)
+
+				if c.before != nil {
+					if previous := fieldValue(*c.before, index); previous != value {
+						pipe.SRem(ctx, s.indexKey(index, previous), id)
+					}
+				}
+
+				pipe.SAdd(ctx, s.indexKey(index, value), id)
+			}
+		}
+
+		return nil
+	})
+
+	return err
+}
+
+// load returns a memstore.Store of the entities which may match the params, to evaluate the params against them.
+func (s *Store[Entity, DTO, ID]) load(ctx context.Context, params []query.Param) (*memstore.Store[DTO, ID], error) {
+	ids, err := s.candidates(ctx, query.NewParams(params...))
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = s.key(id)
+	}
+
+	dtos, expired, err := s.get(ctx, s.Client, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	// the IDs of the expired entities are left in the sets until they are read.
+	if len(expired) > 0 {
+		members := make([]any, len(expired))
+		for i, key := range expired {
+			members[i] = strings.TrimPrefix(key, s.Prefix+":")
+		}
+
+		_ = s.Client.SRem(ctx, s.idsKey(), members...).Err()
+	}
+
+	return memstore.New[DTO, ID](dtos...), nil
+}
+
+// candidates returns the sorted IDs of the entities which may match the params: the IDs of the top level equality
+// filters of the ID and the indexed fields, or all the IDs without such filter.
+func (s *Store[Entity, DTO, ID]) candidates(ctx context.Context, params query.Params) ([]string, error) {
+	var (
+		ids     map[string]bool
+		indexed bool
+	)
+
+	for _, param := range params.Get(query.TypeFilter) {
+		filter := param.(query.FilterParam)
+		if filter.Value == nil || filter.Operator != query.EQ && filter.Operator != query.IN {
+			continue
+		}
+
+		values := collection(filter.Value)
+
+		var matching []string
+
+		switch index, ok := s.index(filter.Name); {
+		case normalize(filter.Name) == "id":
+			for _, value := range values {
+				matching = append(matching, valueString(reflect.ValueOf(value)))
+			}
+		case ok:
+			keys := make([]string, len(values))
+			for i, value := range values {
+				keys[i] = s.indexKey(index, valueString(reflect.ValueOf(value)))
+			}
+
+			members, err := s.Client.SUnion(ctx, keys...).Result()
+			if err != nil {
+				return nil, err
+			}
+
+			matching = members
+		default:
+			continue
+		}
+
+		ids = intersect(ids, matching, indexed)
+		indexed = true
+	}
+
+	if !indexed {
+		members, err := s.Client.SMembers(ctx, s.idsKey()).Result()
+		if err != nil {
+			return nil, err
+		}
+
+		ids = intersect(nil, members, false)
+	}
+
+	sorted := make([]string, 0, len(ids))
+	for id := range ids {
+		sorted = append(sorted, id)
+	}
+
+	sort.Strings(sorted)
+
+	return sorted, nil
+}
+
+// get returns the decoded DTOs of the keys, and the keys missing because they expired or were deleted.
+func (s *Store[Entity, DTO, ID]) get(
+	ctx context.Context,
+	client redis.Cmdable,
+	keys []string,
+) ([]DTO, []string, error) {
+	if len(keys) == 0 {
+		return nil, nil, nil
+	}
+
+	values, err := client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		dtos    = make([]DTO, 0, len(values))
+		missing []string
+	)
+
+	for i, value := range values {
+		data, ok := value.(string)
+		if !ok {
+			missing = append(missing, keys[i])
+			continue
+		}
+
+		var dto DTO
+		if err := s.Codec.Unmarshal([]byte(data), &dto); err != nil {
+			return nil, nil, fmt.Errorf("redisstore: cannot decode %s: %w", keys[i], err)
+		}
+
+		dtos = append(dtos, dto)
+	}
+
+	return dtos, missing, nil
+}
+
+// generateID sets an ID to the DTO whose ID is zero: the one returned by the IDGenerator of the store or, for
+// integer IDs, the next value of the counter of the store.
+func (s *Store[Entity, DTO, ID]) generateID(ctx context.Context, dto *DTO) error {
+	if (*dto).GetID() != *new(ID) {
+		return nil
+	}
+
+	if s.IDGenerator != nil {
+		id, err := s.IDGenerator(ctx)
+		if err != nil {
+			return err
+		}
+
+		*dto = withID(*dto, id)
+
+		return nil
+	}
+
+	id := reflect.New(reflect.TypeOf((*ID)(nil)).Elem()).Elem()
+
+	switch id.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		next, err := s.Client.Incr(ctx, s.Prefix+":seq").Result()
+		if err != nil {
+			return err
+		}
+
+		id.SetInt(next)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		next, err := s.Client.Incr(ctx, s.Prefix+":seq").Result()
+		if err != nil {
+			return err
+		}
+
+		id.SetUint(uint64(next))
+	default:
+		return fmt.Errorf("redisstore: cannot generate the %s ID of %T without IDGenerator", id.Type(), *dto)
+	}
+
+	*dto = withID(*dto, id.Interface().(ID))
+
+	return nil
+}
+
+// index returns the indexed field matching the name, ignoring case and underscores like the filters.
+func (s *Store[Entity, DTO, ID]) index(name string) (string, bool) {
+	for _, index := range s.Indexes {
+		if normalize(index) == normalize(name) {
+			return index, true
+		}
+	}
+
+	return "", false
+}
+
+func (s *Store[Entity, DTO, ID]) id(id ID) string {
+	return fmt.Sprint(id)
+}
+
+func (s *Store[Entity, DTO, ID]) key(id string) string {
+	return s.Prefix + ":" + id
+}
+
+func (s *Store[Entity, DTO, ID]) idsKey() string {
+	return s.Prefix + ":ids"
+}
+
+func (s *Store[Entity, DTO, ID]) indexKey(field, value string) string {
+	return s.Prefix + ":idx:" + field + ":" + value
+}
+
+// changes returns the entities of after differing from the ones of before at the same position, the entities
+// after the ones of before being created.
+func changes[DTO any](before, after []DTO) []change[DTO] {
+	var changes []change[DTO]
+
+	for i := range after {
+		switch {
+		case i >= len(before):
+			changes = append(changes, change[DTO]{after: &after[i]})
+		case !reflect.DeepEqual(before[i], after[i]):
+			changes = append(changes, change[DTO]{before: &before[i], after: &after[i]})
+		}
+	}
+
+	return changes
+}
+
+// intersect returns the IDs of ids, or all of them if the set was not initialized, which are in values.
+func intersect(ids map[string]bool, values []string, initialized bool) map[string]bool {
+	result := make(map[string]bool, len(values))
+
+	for _, value := range values {
+		if !initialized || ids[value] {
+			result[value] = true
+		}
+	}
+
+	return result
+}
+
+// hasFilters reports whether the params have conditions.
+func hasFilters(params query.Params) bool {
+	for _, param := range params.Params() {
+		switch param.(type) {
+		case query.FilterParam, query.ORParam, query.GroupParam, query.NotParam, query.RawParam:
+			return true
+		}
+	}
+
+	return false
+}
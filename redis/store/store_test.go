@@ -0,0 +1,213 @@
+package redisstore_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infevocorp/goflexstore/filters"
+	"github.com/infevocorp/goflexstore/query"
+	redisstore "github.com/infevocorp/goflexstore/redis/store"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+type Session struct {
+	ID     int64  `json:"id" msgpack:"id"`
+	UserID int64  `json:"user_id" msgpack:"user_id"`
+	Device string `json:"device" msgpack:"device"`
+	Hits   int    `json:"hits" msgpack:"hits"`
+}
+
+func (s *Session) GetID() int64 {
+	return s.ID
+}
+
+var _ store.Store[*Session, int64] = (*redisstore.Store[*Session, *Session, int64])(nil)
+
+func newSessions(
+	t *testing.T,
+	options ...redisstore.Option[*Session, *Session, int64],
+) (*miniredis.Miniredis, *redisstore.Store[*Session, *Session, int64]) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+
+	options = append([]redisstore.Option[*Session, *Session, int64]{
+		redisstore.WithIndex[*Session, *Session, int64]("user_id"),
+	}, options...)
+
+	sessions := redisstore.NewSimple[*Session, int64](client, "sessions", options...)
+
+	require.NoError(t, sessions.CreateMany(context.Background(), []*Session{
+		{UserID: 1, Device: "phone", Hits: 10},
+		{UserID: 1, Device: "laptop", Hits: 30},
+		{UserID: 2, Device: "phone", Hits: 20},
+	}))
+
+	return server, sessions
+}
+
+func ids(sessions []*Session) []int64 {
+	ids := make([]int64, len(sessions))
+	for i, session := range sessions {
+		ids[i] = session.ID
+	}
+
+	return ids
+}
+
+func Test_Store_Read(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should-get-by-id", func(t *testing.T) {
+		// GIVEN
+		_, sessions := newSessions(t)
+
+		// WHEN
+		session, err := sessions.Get(ctx, filters.IDs[int64](2))
+		_, notFoundErr := sessions.Get(ctx, filters.IDs[int64](4))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, &Session{ID: 2, UserID: 1, Device: "laptop", Hits: 30}, session)
+		assert.ErrorIs(t, notFoundErr, store.ErrNotFound)
+	})
+
+	t.Run("should-list-through-index-and-evaluate-params", func(t *testing.T) {
+		// GIVEN
+		server, sessions := newSessions(t)
+
+		// WHEN
+		list, count, err := sessions.ListWithCount(ctx,
+			query.Filter("UserID", []int64{1, 2}),
+			query.Filter("Device", "phone"),
+			query.OrderBy("Hits", true),
+			query.Paginate(0, 1),
+		)
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, []int64{3}, ids(list))
+		assert.Equal(t, int64(2), count)
+
+		members, err := server.Members("sessions:idx:UserID:1")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"1", "2"}, members)
+	})
+
+	t.Run("should-scan-without-indexed-filter", func(t *testing.T) {
+		// GIVEN
+		_, sessions := newSessions(t)
+
+		// WHEN
+		total, err := sessions.Aggregate(ctx, store.Sum, "Hits", query.Filter("Device", "phone"))
+		exists, existsErr := sessions.Exists(ctx, query.Filter("Device", "tablet"))
+
+		// THEN
+		require.NoError(t, err)
+		require.NoError(t, existsErr)
+		assert.Equal(t, float64(30), total)
+		assert.False(t, exists)
+	})
+
+	t.Run("should-expire-entities", func(t *testing.T) {
+		// GIVEN
+		server, sessions := newSessions(t, redisstore.WithTTL[*Session, *Session, int64](time.Hour))
+
+		// WHEN
+		server.FastForward(2 * time.Hour)
+		count, err := sessions.Count(ctx)
+
+		// THEN
+		require.NoError(t, err)
+		assert.Zero(t, count)
+
+		members, err := server.Members("sessions:ids")
+		assert.ErrorIs(t, err, miniredis.ErrKeyNotFound)
+		assert.Empty(t, members)
+	})
+}
+
+func Test_Store_Write(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should-create-update-and-delete", func(t *testing.T) {
+		// GIVEN
+		server, sessions := newSessions(t, redisstore.WithCodec[*Session, *Session, int64](redisstore.Msgpack))
+
+		// WHEN
+		id, err := sessions.Create(ctx, &Session{UserID: 3, Device: "tv"})
+		require.NoError(t, err)
+
+		require.NoError(t, sessions.Update(ctx, &Session{ID: id, UserID: 2, Device: "tv"}))
+		require.NoError(t, sessions.PartialUpdate(ctx, &Session{Hits: 5}, query.Filter("UserID", 2)))
+
+		updated, err := sessions.UpdateMany(ctx, map[string]any{"device": "desktop"}, query.Filter("Device", "tv"))
+		require.NoError(t, err)
+
+		deleted, err := sessions.DeleteReturning(ctx, query.Filter("UserID", 1))
+		require.NoError(t, err)
+
+		// THEN
+		assert.Equal(t, int64(4), id)
+		assert.Equal(t, int64(1), updated)
+		assert.Equal(t, []int64{1, 2}, ids(deleted))
+
+		list, err := sessions.List(ctx, query.Filter("UserID", 2), query.OrderBy("ID", false))
+		require.NoError(t, err)
+		assert.Equal(t, []*Session{
+			{ID: 3, UserID: 2, Device: "phone", Hits: 5},
+			{ID: 4, UserID: 2, Device: "desktop", Hits: 5},
+		}, list)
+
+		assert.False(t, server.Exists("sessions:1"))
+		assert.False(t, server.Exists("sessions:idx:UserID:3"))
+	})
+
+	t.Run("should-reject-duplicate", func(t *testing.T) {
+		// GIVEN
+		_, sessions := newSessions(t)
+
+		// WHEN
+		_, err := sessions.Create(ctx, &Session{ID: 1, UserID: 3})
+
+		// THEN
+		assert.ErrorIs(t, err, store.ErrDuplicate)
+	})
+
+	t.Run("should-upsert", func(t *testing.T) {
+		// GIVEN
+		_, sessions := newSessions(t)
+
+		// WHEN
+		_, err := sessions.Upsert(ctx, &Session{ID: 1, UserID: 1, Device: "phone", Hits: 5}, store.OnConflict{
+			UpdateAll:   true,
+			UpdateWhere: []query.FilterParam{query.Filter("Hits", store.Excluded("Hits")).WithOP(query.LT)},
+		})
+		require.NoError(t, err)
+
+		_, err = sessions.Upsert(ctx, &Session{ID: 2, UserID: 1, Device: "laptop", Hits: 50}, store.OnConflict{
+			UpdateAll:   true,
+			UpdateWhere: []query.FilterParam{query.Filter("Hits", store.Excluded("Hits")).WithOP(query.LT)},
+		})
+		require.NoError(t, err)
+
+		id, err := sessions.Upsert(ctx, &Session{UserID: 3, Device: "tv"}, store.OnConflict{UpdateAll: true})
+		require.NoError(t, err)
+
+		// THEN
+		list, err := sessions.List(ctx, query.OrderBy("ID", false))
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), id)
+		assert.Equal(t, []*Session{
+			{ID: 1, UserID: 1, Device: "phone", Hits: 10},
+			{ID: 2, UserID: 1, Device: "laptop", Hits: 50},
+			{ID: 3, UserID: 2, Device: "phone", Hits: 20},
+			{ID: 4, UserID: 3, Device: "tv"},
+		}, list)
+	})
+}
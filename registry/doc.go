@@ -0,0 +1,18 @@
+// Package registry provides a container of the stores of an application, indexed by entity type.
+//
+// It replaces the hand-written structs listing every store: stores are registered once, at startup, from the
+// operation scope shared by the registry so that they all take part in the same transactions, and resolved
+// by entity type wherever they are needed. The middlewares given to New decorate every registered store with
+// store.Chain, e.g. to log or measure all the operations of the application in one place.
+//
+// Example:
+//
+//	stores := registry.New(gormopscope.NewWriteTransactionScope("write", db),
+//		registry.WithMiddleware(logging))
+//
+//	registry.Provide(stores, func(scope *gormopscope.TransactionScope) store.Store[*model.User, int64] {
+//		return gormstore.New[*model.User, *dto.User, int64](scope)
+//	})
+//
+//	users := registry.MustResolve[*model.User, int64](stores)
+package registry
@@ -0,0 +1,164 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/infevocorp/goflexstore/opscope"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// ErrNotRegistered is returned by Resolve when no store is registered for the entity type.
+var ErrNotRegistered = errors.New("store not registered")
+
+// Option is a function that configures the Registry.
+type Option func(*options)
+
+type options struct {
+	middlewares []store.Middleware
+}
+
+// WithMiddleware adds middlewares decorating every store registered afterwards, see store.Chain.
+func WithMiddleware(middlewares ...store.Middleware) Option {
+	return func(o *options) {
+		o.middlewares = append(o.middlewares, middlewares...)
+	}
+}
+
+// Entry describes a registered store, see Registry.Entries.
+type Entry struct {
+	// Entity is the name of the entity type of the store, see store.EntityName.
+	Entity string
+	// Store is the store.Store[T, ID] registered for the entity type.
+	Store any
+	// Inner is the store given to Register, before its decoration by the middlewares of the registry.
+	// It allows reaching the methods of the implementation, e.g. the Model method of gormstore.Store.
+	Inner any
+	// Ping calls the Ping method of the store.
+	Ping func(ctx context.Context) error
+}
+
+// Registry holds the stores of an application by entity type, along with the operation scope they share.
+//
+// S is the type of the operation scope, e.g. *gormopscope.TransactionScope, given to the factories of Provide.
+// A Registry is safe for concurrent use.
+type Registry[S opscope.Scope] struct {
+	scope   S
+	options options
+
+	mu      sync.RWMutex
+	stores  map[reflect.Type]any
+	entries []Entry
+}
+
+// New creates a registry sharing the given operation scope between its stores.
+func New[S opscope.Scope](scope S, opts ...Option) *Registry[S] {
+	r := &Registry[S]{
+		scope:  scope,
+		stores: make(map[reflect.Type]any),
+	}
+
+	for _, opt := range opts {
+		opt(&r.options)
+	}
+
+	return r
+}
+
+// Scope returns the operation scope shared by the stores of the registry.
+// It is the scope to begin and end the transactions spanning several stores.
+func (r *Registry[S]) Scope() S {
+	return r.scope
+}
+
+// Entries returns the registered stores, in registration order.
+// It allows applying the same treatment to every store, e.g. checking them from a health endpoint.
+func (r *Registry[S]) Entries() []Entry {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entries := make([]Entry, len(r.entries))
+	copy(entries, r.entries)
+
+	return entries
+}
+
+// Register adds the store of the entity type T, decorated with the middlewares of the registry,
+// and returns the decorated store.
+//
+// Stores are expected to be registered at startup, so Register panics if a store is already registered
+// for T and ID.
+//
+// Example:
+//
+//	registry.Register[*model.User, int64](stores, gormstore.New[*model.User, *dto.User, int64](scope))
+func Register[T store.Entity[ID], ID comparable, S opscope.Scope](
+	r *Registry[S],
+	s store.Store[T, ID],
+) store.Store[T, ID] {
+	key := keyOf[T, ID]()
+	inner := s
+	s = store.Chain(s, r.options.middlewares...)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.stores[key]; ok {
+		panic(fmt.Sprintf("registry: store of %s already registered", store.EntityName[T]()))
+	}
+
+	r.stores[key] = s
+	r.entries = append(r.entries, Entry{
+		Entity: store.EntityName[T](),
+		Store:  s,
+		Inner:  inner,
+		Ping:   s.Ping,
+	})
+
+	return s
+}
+
+// Provide registers the store built by factory from the operation scope of the registry, see Register.
+func Provide[T store.Entity[ID], ID comparable, S opscope.Scope](
+	r *Registry[S],
+	factory func(scope S) store.Store[T, ID],
+) store.Store[T, ID] {
+	return Register(r, factory(r.scope))
+}
+
+// Resolve returns the store registered for the entity type T, or an error wrapping ErrNotRegistered.
+//
+// Example:
+//
+//	users, err := registry.Resolve[*model.User, int64](stores)
+func Resolve[T store.Entity[ID], ID comparable, S opscope.Scope](r *Registry[S]) (store.Store[T, ID], error) {
+	r.mu.RLock()
+	s, ok := r.stores[keyOf[T, ID]()]
+	r.mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotRegistered, store.EntityName[T]())
+	}
+
+	return s.(store.Store[T, ID]), nil
+}
+
+// MustResolve is like Resolve but panics if no store is registered for the entity type T.
+// It simplifies the wiring of the application, where a missing store is a programming error.
+func MustResolve[T store.Entity[ID], ID comparable, S opscope.Scope](r *Registry[S]) store.Store[T, ID] {
+	s, err := Resolve[T, ID](r)
+	if err != nil {
+		panic(err)
+	}
+
+	return s
+}
+
+// keyOf returns the key of the stores of T and ID, so that a store is resolved only with the types
+// it was registered with.
+func keyOf[T store.Entity[ID], ID comparable]() reflect.Type {
+	return reflect.TypeOf((*store.Store[T, ID])(nil)).Elem()
+}
@@ -0,0 +1,128 @@
+package registry_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mockopscope "github.com/infevocorp/goflexstore/mocks/opscope"
+	"github.com/infevocorp/goflexstore/registry"
+	"github.com/infevocorp/goflexstore/store"
+	"github.com/infevocorp/goflexstore/storetest"
+)
+
+type User struct {
+	ID   int64
+	Name string
+}
+
+func (u *User) GetID() int64 {
+	return u.ID
+}
+
+type Article struct {
+	ID    int64
+	Title string
+}
+
+func (a *Article) GetID() int64 {
+	return a.ID
+}
+
+func Test_Registry(t *testing.T) {
+	t.Run("should-resolve-registered-stores", func(t *testing.T) {
+		// GIVEN
+		var (
+			scope    = mockopscope.NewScope(t)
+			r        = registry.New(scope)
+			users    = storetest.NewFake[*User, int64]()
+			articles = storetest.NewFake[*Article, int64]()
+		)
+
+		registry.Register[*User, int64](r, users)
+		registry.Provide(r, func(s *mockopscope.Scope) store.Store[*Article, int64] {
+			assert.Same(t, scope, s)
+
+			return articles
+		})
+
+		// WHEN
+		resolvedUsers, usersErr := registry.Resolve[*User, int64](r)
+		resolvedArticles, articlesErr := registry.Resolve[*Article, int64](r)
+
+		// THEN
+		require.NoError(t, usersErr)
+		require.NoError(t, articlesErr)
+		assert.Same(t, users, resolvedUsers)
+		assert.Same(t, articles, resolvedArticles)
+		assert.Same(t, scope, r.Scope())
+
+		entries := r.Entries()
+		require.Len(t, entries, 2)
+		assert.Equal(t, "User", entries[0].Entity)
+		assert.Same(t, users, entries[0].Store)
+		assert.Equal(t, "Article", entries[1].Entity)
+		assert.NoError(t, entries[1].Ping(context.Background()))
+	})
+
+	t.Run("should-fail-to-resolve-unregistered-store", func(t *testing.T) {
+		// GIVEN
+		r := registry.New(mockopscope.NewScope(t))
+		registry.Register[*User, int64](r, storetest.NewFake[*User, int64]())
+
+		// WHEN
+		_, err := registry.Resolve[*Article, int64](r)
+
+		// THEN
+		assert.ErrorIs(t, err, registry.ErrNotRegistered)
+		assert.EqualError(t, err, "store not registered: Article")
+		assert.Panics(t, func() {
+			registry.MustResolve[*Article, int64](r)
+		})
+	})
+
+	t.Run("should-panic-on-duplicate-registration", func(t *testing.T) {
+		// GIVEN
+		r := registry.New(mockopscope.NewScope(t))
+		registry.Register[*User, int64](r, storetest.NewFake[*User, int64]())
+
+		// WHEN / THEN
+		assert.PanicsWithValue(t, "registry: store of User already registered", func() {
+			registry.Register[*User, int64](r, storetest.NewFake[*User, int64]())
+		})
+	})
+
+	t.Run("should-apply-middlewares-to-registered-stores", func(t *testing.T) {
+		// GIVEN
+		var calls []string
+
+		record := func(next store.OperationFunc) store.OperationFunc {
+			return func(ctx context.Context, op *store.Operation) (any, error) {
+				calls = append(calls, op.Entity+"."+op.Method)
+
+				return next(ctx, op)
+			}
+		}
+
+		r := registry.New(mockopscope.NewScope(t), registry.WithMiddleware(record))
+		users := storetest.NewFake[*User, int64](&User{ID: 1, Name: "john"})
+		registry.Register[*User, int64](r, users)
+		registry.Register[*Article, int64](r, storetest.NewFake[*Article, int64]())
+
+		// WHEN
+		user, err := registry.MustResolve[*User, int64](r).Get(context.Background())
+		require.NoError(t, err)
+
+		count, err := registry.MustResolve[*Article, int64](r).Count(context.Background())
+		require.NoError(t, err)
+
+		// THEN
+		assert.Equal(t, "john", user.Name)
+		assert.Zero(t, count)
+		assert.Equal(t, []string{"User.Get", "Article.Count"}, calls)
+		assert.Same(t, users, r.Entries()[0].Inner)
+		assert.NotSame(t, users, r.Entries()[0].Store)
+	})
+}
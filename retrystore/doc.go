@@ -0,0 +1,28 @@
+// Package retrystore retries the operations of stores failing with transient database errors, such as
+// deadlocks, serialization failures and connection resets, with exponential backoff and jitter.
+//
+// New decorates a store, and Middleware decorates stores composed with store.Chain. An operation failing with
+// an error that the Classifier of the Policy reports as retryable is run again, up to the maximum number of
+// attempts, after a delay growing exponentially and randomized by the jitter, so that the conflicting callers
+// do not retry in lockstep. The error of the last attempt is returned.
+//
+// IsTransient, the default Classifier, recognizes the connection errors and the SQLSTATE codes of the errors of
+// PostgreSQL drivers. Other backends set their own Classifier, e.g. for the deadlocks of MySQL:
+//
+//	policy := retrystore.Policy{
+//		Classifier: func(err error) bool {
+//			var mysqlErr *mysql.MySQLError
+//			return retrystore.IsTransient(err) ||
+//				errors.As(err, &mysqlErr) && (mysqlErr.Number == 1213 || mysqlErr.Number == 1205)
+//		},
+//	}
+//
+// The operations running in a transaction are never retried, since the failed statement aborted the
+// transaction: the whole transaction must be retried by the caller. A write failing with a connection reset
+// may have been applied before the connection was lost, so the writes should be idempotent, e.g. Upsert, or
+// the Classifier should not retry them.
+//
+// Example:
+//
+//	articleStore := retrystore.New[*model.Article, int64](inner, retrystore.Policy{MaxAttempts: 5})
+package retrystore
@@ -0,0 +1,104 @@
+package retrystore
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"syscall"
+	"time"
+
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// Policy configures the retries of the operations. Its zero value retries the transient errors 3 times.
+//
+// Fields:
+//   - MaxAttempts: The maximum number of attempts of an operation, the first one included. It defaults to 3.
+//   - InitialBackoff: The delay before the first retry. It defaults to 50ms.
+//   - MaxBackoff: The maximum delay before a retry. It defaults to 2s.
+//   - Multiplier: The factor applied to the delay after each retry. It defaults to 2.
+//   - Jitter: The fraction of the delay that is randomized, between 0 and 1. It defaults to 0.5: the delay is
+//     between half and all of the exponential one.
+//   - Classifier: The function reporting whether an error is retryable. It defaults to IsTransient.
+//   - OnRetry: The function called before each retry, e.g. to log or count it. It is optional.
+type Policy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	Jitter         float64
+	Classifier     func(err error) bool
+	OnRetry        func(ctx context.Context, op *store.Operation, attempt int, err error)
+}
+
+// withDefaults returns the policy with the defaults of its zero fields.
+func (p Policy) withDefaults() Policy {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = 3
+	}
+
+	if p.InitialBackoff <= 0 {
+		p.InitialBackoff = 50 * time.Millisecond
+	}
+
+	if p.MaxBackoff <= 0 {
+		p.MaxBackoff = 2 * time.Second
+	}
+
+	if p.Multiplier < 1 {
+		p.Multiplier = 2
+	}
+
+	if p.Jitter <= 0 || p.Jitter > 1 {
+		p.Jitter = 0.5
+	}
+
+	if p.Classifier == nil {
+		p.Classifier = IsTransient
+	}
+
+	return p
+}
+
+// backoff returns the delay before the retry following the attempt, counted from 1.
+func (p Policy) backoff(attempt int) time.Duration {
+	delay := float64(p.InitialBackoff) * math.Pow(p.Multiplier, float64(attempt-1))
+	delay = math.Min(delay, float64(p.MaxBackoff))
+
+	return time.Duration(delay * (1 - p.Jitter*rand.Float64())) //nolint:gosec // jitter does not need crypto.
+}
+
+// transientSQLStates are the SQLSTATE codes of the transient errors: the serialization failures and deadlocks.
+var transientSQLStates = map[string]bool{
+	"40001": true, // serialization_failure
+	"40P01": true, // deadlock_detected
+}
+
+// IsTransient reports whether the error is a transient database error, worth retrying:
+//   - a connection error: driver.ErrBadConn, a connection reset or broken pipe, or an unexpected EOF;
+//   - an error with a SQLState method, like the errors of pgx and lib/pq, whose SQLSTATE is a serialization
+//     failure (40001), a deadlock (40P01) or a connection exception (class 08).
+//
+// The cancellation and deadline of the context are never transient.
+func IsTransient(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	var sqlErr interface{ SQLState() string }
+	if errors.As(err, &sqlErr) {
+		state := sqlErr.SQLState()
+
+		return transientSQLStates[state] || len(state) == 5 && state[:2] == "08"
+	}
+
+	return false
+}
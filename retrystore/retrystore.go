@@ -0,0 +1,46 @@
+package retrystore
+
+import (
+	"context"
+	"time"
+
+	"github.com/infevocorp/goflexstore/opscope"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// New decorates the inner store with the Middleware, retrying its operations failing with transient errors.
+func New[T store.Entity[ID], ID comparable](inner store.Store[T, ID], policy Policy) store.Store[T, ID] {
+	return store.Chain[T, ID](inner, Middleware(policy))
+}
+
+// Middleware returns a store middleware retrying the operations failing with the errors that the Classifier of
+// the policy reports as retryable, see the package documentation.
+func Middleware(policy Policy) store.Middleware {
+	policy = policy.withDefaults()
+
+	return func(next store.OperationFunc) store.OperationFunc {
+		return func(ctx context.Context, op *store.Operation) (any, error) {
+			for attempt := 1; ; attempt++ {
+				result, err := next(ctx, op)
+				if err == nil || attempt == policy.MaxAttempts || opscope.InTransaction(ctx) ||
+					!policy.Classifier(err) {
+					return result, err
+				}
+
+				if policy.OnRetry != nil {
+					policy.OnRetry(ctx, op, attempt, err)
+				}
+
+				timer := time.NewTimer(policy.backoff(attempt))
+
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+
+					return result, err
+				case <-timer.C:
+				}
+			}
+		}
+	}
+}
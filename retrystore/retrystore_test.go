@@ -0,0 +1,183 @@
+package retrystore_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infevocorp/goflexstore/opscope"
+	"github.com/infevocorp/goflexstore/retrystore"
+	"github.com/infevocorp/goflexstore/store"
+	"github.com/infevocorp/goflexstore/storetest"
+)
+
+type Article struct {
+	ID     int
+	Status string
+}
+
+func (a *Article) GetID() int {
+	return a.ID
+}
+
+// sqlStateError is an error with a SQLSTATE, like the errors of the PostgreSQL drivers.
+type sqlStateError struct {
+	state string
+}
+
+func (e sqlStateError) Error() string {
+	return "sqlstate " + e.state
+}
+
+func (e sqlStateError) SQLState() string {
+	return e.state
+}
+
+// txInspector reports an active transaction.
+type txInspector struct{}
+
+func (txInspector) TxInfo() (opscope.Info, bool) {
+	return opscope.Info{Name: "test", Level: 1}, true
+}
+
+func newStore(policy retrystore.Policy) (*storetest.Fake[*Article, int], store.Store[*Article, int]) {
+	inner := storetest.NewFake[*Article, int](&Article{ID: 1, Status: "active"})
+	policy.InitialBackoff = time.Millisecond
+
+	return inner, retrystore.New[*Article, int](inner, policy)
+}
+
+func Test_Store(t *testing.T) {
+	t.Run("should-retry-transient-error-until-success", func(t *testing.T) {
+		// GIVEN
+		var (
+			retries []int
+			inner   *storetest.Fake[*Article, int]
+		)
+
+		inner, s := newStore(retrystore.Policy{
+			OnRetry: func(_ context.Context, op *store.Operation, attempt int, err error) {
+				retries = append(retries, attempt)
+
+				if attempt == 2 {
+					inner.FailWith(op.Method, nil)
+				}
+			},
+		})
+		inner.FailWith(store.MethodGet, sqlStateError{state: "40P01"})
+
+		// WHEN
+		article, err := s.Get(context.Background())
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, 1, article.ID)
+		assert.Equal(t, []int{1, 2}, retries)
+		assert.Len(t, inner.Calls(store.MethodGet), 3)
+	})
+
+	t.Run("should-return-last-error-after-max-attempts", func(t *testing.T) {
+		// GIVEN
+		inner, s := newStore(retrystore.Policy{MaxAttempts: 4})
+		inner.FailWith(store.MethodCreate, driver.ErrBadConn)
+
+		// WHEN
+		_, err := s.Create(context.Background(), &Article{ID: 2})
+
+		// THEN
+		require.ErrorIs(t, err, driver.ErrBadConn)
+		assert.Len(t, inner.Calls(store.MethodCreate), 4)
+	})
+
+	t.Run("should-not-retry-permanent-error", func(t *testing.T) {
+		// GIVEN
+		inner, s := newStore(retrystore.Policy{})
+
+		inner.FailWith(store.MethodUpdate, errors.New("constraint violation"))
+
+		// WHEN
+		err := s.Update(context.Background(), &Article{ID: 1})
+
+		// THEN
+		require.EqualError(t, err, "constraint violation")
+		assert.Len(t, inner.Calls(store.MethodUpdate), 1)
+	})
+
+	t.Run("should-not-retry-in-transaction", func(t *testing.T) {
+		// GIVEN
+		inner, s := newStore(retrystore.Policy{})
+		inner.FailWith(store.MethodDelete, sqlStateError{state: "40001"})
+		ctx := opscope.WithTxInspector(context.Background(), txInspector{})
+
+		// WHEN
+		err := s.Delete(ctx)
+
+		// THEN
+		require.Error(t, err)
+		assert.Len(t, inner.Calls(store.MethodDelete), 1)
+	})
+
+	t.Run("should-use-classifier", func(t *testing.T) {
+		// GIVEN
+		errLocked := errors.New("locked")
+		inner, s := newStore(retrystore.Policy{
+			Classifier: func(err error) bool { return errors.Is(err, errLocked) },
+		})
+		inner.FailWith(store.MethodCount, errLocked)
+
+		// WHEN
+		_, err := s.Count(context.Background())
+
+		// THEN
+		require.ErrorIs(t, err, errLocked)
+		assert.Len(t, inner.Calls(store.MethodCount), 3)
+	})
+
+	t.Run("should-stop-waiting-when-context-is-done", func(t *testing.T) {
+		// GIVEN
+		inner := storetest.NewFake[*Article, int]()
+		inner.FailWith(store.MethodList, syscall.ECONNRESET)
+		s := retrystore.New[*Article, int](inner, retrystore.Policy{InitialBackoff: time.Hour, MaxBackoff: time.Hour})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		// WHEN
+		_, err := s.List(ctx)
+
+		// THEN
+		require.ErrorIs(t, err, syscall.ECONNRESET)
+		assert.Len(t, inner.Calls(store.MethodList), 1)
+	})
+}
+
+func Test_IsTransient(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "bad-conn", err: driver.ErrBadConn, want: true},
+		{name: "wrapped-connection-reset", err: fmt.Errorf("read: %w", syscall.ECONNRESET), want: true},
+		{name: "serialization-failure", err: sqlStateError{state: "40001"}, want: true},
+		{name: "deadlock", err: sqlStateError{state: "40P01"}, want: true},
+		{name: "connection-exception", err: sqlStateError{state: "08006"}, want: true},
+		{name: "unique-violation", err: sqlStateError{state: "23505"}, want: false},
+		{name: "canceled", err: context.Canceled, want: false},
+		{name: "not-found", err: store.ErrNotFound, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, retrystore.IsTransient(tt.err))
+		})
+	}
+}
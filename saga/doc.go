@@ -0,0 +1,29 @@
+// Package saga sequences the steps of a workflow which cannot run in a single database transaction, e.g. because
+// it spans several databases or services, undoing the completed steps when a later one fails.
+//
+// Each step of a Saga has an action and an optional compensation, the action undoing its effects. Run executes
+// the actions in order; when one fails, the compensations of the completed steps are executed in reverse order,
+// and Run returns an *Error describing the failed step and the compensations which failed too. The steps added
+// with StepInScope run their action and their compensation in a transaction of their operation scope, so that
+// each of them is atomic.
+//
+// Compensations run even when the context of Run is cancelled: they receive a context keeping its values but
+// detached from its cancellation and deadline.
+//
+// Example:
+//
+//	err := saga.New("checkout").
+//		StepInScope("reserve stock", inventoryScope,
+//			func(ctx context.Context) error { return stock.Reserve(ctx, order) },
+//			func(ctx context.Context) error { return stock.Release(ctx, order) },
+//		).
+//		Step("charge card",
+//			func(ctx context.Context) error { return payments.Charge(ctx, order) },
+//			func(ctx context.Context) error { return payments.Refund(ctx, order) },
+//		).
+//		StepInScope("confirm order", orderScope,
+//			func(ctx context.Context) error { return orders.Update(ctx, order.Confirm()) },
+//			nil,
+//		).
+//		Run(ctx)
+package saga
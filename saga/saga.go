@@ -0,0 +1,170 @@
+package saga
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/infevocorp/goflexstore/opscope"
+)
+
+// Func is the action or the compensation of a step.
+type Func func(ctx context.Context) error
+
+// Saga is a sequence of steps with compensations, see New.
+type Saga struct {
+	name  string
+	steps []step
+}
+
+// step is a step of a Saga.
+type step struct {
+	name       string
+	scope      opscope.Scope
+	action     Func
+	compensate Func
+}
+
+// New creates an empty Saga. The name identifies the saga in its errors.
+func New(name string) *Saga {
+	return &Saga{name: name}
+}
+
+// Step adds a step to the saga. The compensation, if not nil, is executed when a later step fails.
+func (s *Saga) Step(name string, action, compensate Func) *Saga {
+	s.steps = append(s.steps, step{name: name, action: action, compensate: compensate})
+
+	return s
+}
+
+// StepInScope adds a step whose action and compensation each run in a transaction of the scope: the transaction
+// is committed if they succeed and rolled back otherwise.
+func (s *Saga) StepInScope(name string, scope opscope.Scope, action, compensate Func) *Saga {
+	s.steps = append(s.steps, step{name: name, scope: scope, action: action, compensate: compensate})
+
+	return s
+}
+
+// Run executes the actions of the steps in order. When an action fails, Run executes the compensations of the
+// completed steps in reverse order, the failed step excluded, and returns an *Error.
+func (s *Saga) Run(ctx context.Context) error {
+	for i, st := range s.steps {
+		if err := st.run(ctx, st.action); err != nil {
+			return s.compensate(ctx, i, err)
+		}
+	}
+
+	return nil
+}
+
+// compensate executes the compensations of the steps before the failed one, in reverse order.
+func (s *Saga) compensate(ctx context.Context, failed int, err error) error {
+	sagaErr := &Error{
+		Saga: s.name,
+		Step: s.steps[failed].name,
+		Err:  err,
+	}
+
+	ctx = detached{ctx}
+
+	for i := failed - 1; i >= 0; i-- {
+		st := s.steps[i]
+		if st.compensate == nil {
+			continue
+		}
+
+		if cerr := st.run(ctx, st.compensate); cerr != nil {
+			sagaErr.Compensations = append(sagaErr.Compensations, &StepError{Step: st.name, Err: cerr})
+		}
+	}
+
+	return sagaErr
+}
+
+// run executes fn, in a transaction of the scope of the step if it has one.
+func (st step) run(ctx context.Context, fn Func) error {
+	if st.scope == nil {
+		return fn(ctx)
+	}
+
+	return opscope.Run(ctx, st.scope, fn)
+}
+
+// Error is returned by Run when a step failed.
+//
+// Fields:
+//   - Saga: The name of the saga.
+//   - Step: The name of the failed step.
+//   - Err: The error of the failed step.
+//   - Compensations: The errors of the compensations which failed, in their order of execution.
+//     When it is not empty, the effects of the saga are only partially undone.
+type Error struct {
+	Saga          string
+	Step          string
+	Err           error
+	Compensations []*StepError
+}
+
+// Error describes the failed step and compensations.
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("saga %s: step %s: %s", e.Saga, e.Step, e.Err)
+
+	if len(e.Compensations) > 0 {
+		failed := make([]string, len(e.Compensations))
+		for i, c := range e.Compensations {
+			failed[i] = c.Error()
+		}
+
+		msg += " (compensations failed: " + strings.Join(failed, "; ") + ")"
+	}
+
+	return msg
+}
+
+// Unwrap returns the error of the failed step.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Compensated reports whether every compensation succeeded.
+func (e *Error) Compensated() bool {
+	return len(e.Compensations) == 0
+}
+
+// StepError is the error of the compensation of a step.
+type StepError struct {
+	Step string
+	Err  error
+}
+
+// Error returns the name of the step and its error.
+func (e *StepError) Error() string {
+	return e.Step + ": " + e.Err.Error()
+}
+
+// Unwrap returns the error of the step.
+func (e *StepError) Unwrap() error {
+	return e.Err
+}
+
+// detached is a context keeping the values of its parent but not its cancellation and deadline.
+type detached struct {
+	parent context.Context
+}
+
+func (detached) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (detached) Done() <-chan struct{} {
+	return nil
+}
+
+func (detached) Err() error {
+	return nil
+}
+
+func (d detached) Value(key any) any {
+	return d.parent.Value(key)
+}
@@ -0,0 +1,119 @@
+package saga_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	mockopscope "github.com/infevocorp/goflexstore/mocks/opscope"
+	"github.com/infevocorp/goflexstore/saga"
+)
+
+// recorder returns a step function recording its name in calls and returning err.
+func recorder(calls *[]string, name string, err error) saga.Func {
+	return func(context.Context) error {
+		*calls = append(*calls, name)
+		return err
+	}
+}
+
+func Test_Saga_Run(t *testing.T) {
+	t.Run("should-run-steps-in-order", func(t *testing.T) {
+		// GIVEN
+		var calls []string
+
+		s := saga.New("checkout").
+			Step("reserve", recorder(&calls, "reserve", nil), recorder(&calls, "release", nil)).
+			Step("charge", recorder(&calls, "charge", nil), recorder(&calls, "refund", nil))
+
+		// WHEN
+		err := s.Run(context.Background())
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, []string{"reserve", "charge"}, calls)
+	})
+
+	t.Run("should-compensate-completed-steps-in-reverse-order", func(t *testing.T) {
+		// GIVEN
+		var (
+			calls     []string
+			chargeErr = errors.New("card declined")
+		)
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		s := saga.New("checkout").
+			Step("reserve", recorder(&calls, "reserve", nil), func(ctx context.Context) error {
+				calls = append(calls, "release")
+				return ctx.Err()
+			}).
+			Step("notify", recorder(&calls, "notify", nil), nil).
+			Step("charge", func(context.Context) error {
+				cancel()
+				calls = append(calls, "charge")
+
+				return chargeErr
+			}, recorder(&calls, "refund", nil))
+
+		// WHEN
+		err := s.Run(ctx)
+
+		// THEN
+		var sagaErr *saga.Error
+		require.ErrorAs(t, err, &sagaErr)
+		assert.ErrorIs(t, err, chargeErr)
+		assert.Equal(t, "charge", sagaErr.Step)
+		assert.True(t, sagaErr.Compensated())
+		assert.EqualError(t, err, "saga checkout: step charge: card declined")
+		assert.Equal(t, []string{"reserve", "notify", "charge", "release"}, calls)
+	})
+
+	t.Run("should-report-failed-compensations", func(t *testing.T) {
+		// GIVEN
+		var calls []string
+
+		s := saga.New("checkout").
+			Step("reserve", recorder(&calls, "reserve", nil), recorder(&calls, "release", errors.New("timeout"))).
+			Step("charge", recorder(&calls, "charge", errors.New("card declined")), nil)
+
+		// WHEN
+		err := s.Run(context.Background())
+
+		// THEN
+		var sagaErr *saga.Error
+		require.ErrorAs(t, err, &sagaErr)
+		assert.False(t, sagaErr.Compensated())
+		assert.EqualError(t, err, "saga checkout: step charge: card declined (compensations failed: reserve: timeout)")
+	})
+
+	t.Run("should-run-steps-in-transactions-of-their-scope", func(t *testing.T) {
+		// GIVEN
+		var (
+			calls    []string
+			scope    = mockopscope.NewScope(t)
+			ctx      = context.Background()
+			orderErr = errors.New("duplicate order")
+		)
+
+		scope.EXPECT().Begin(ctx).Return(ctx, nil).Once()
+		scope.EXPECT().EndWithRecover(ctx, mock.Anything).Run(func(_ context.Context, err *error) {
+			assert.Equal(t, orderErr, *err)
+		}).Once()
+
+		s := saga.New("checkout").
+			Step("charge", recorder(&calls, "charge", nil), recorder(&calls, "refund", nil)).
+			StepInScope("create order", scope, recorder(&calls, "create order", orderErr), nil)
+
+		// WHEN
+		err := s.Run(ctx)
+
+		// THEN
+		assert.ErrorIs(t, err, orderErr)
+		assert.Equal(t, []string{"charge", "create order", "refund"}, calls)
+	})
+}
@@ -1,6 +1,7 @@
 #!/bin/bash
 
-packages=$(go list ./... | grep -v "/mocks" | xargs)
+for dir in $(go list -m -f '{{.Dir}}'); do
+	packages=$(cd "$dir" && go list ./... | grep -v "/mocks" | xargs)
 
-
-go test $packages
\ No newline at end of file
+	(cd "$dir" && go test -race $packages) || exit 1
+done
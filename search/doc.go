@@ -0,0 +1,17 @@
+// Package search exposes the full-text search of entities, combined with structured filters, behind a single
+// interface, whether it is served by the database of their store or by a dedicated search engine.
+//
+// A Searcher adds a query.FullText param over its searched fields to the params of the caller, ordering the
+// entities by relevance first, and lists them through the store. When a Backend is configured, e.g. over an
+// Elasticsearch index kept in sync with the entity lifecycle events, the searches with a text are delegated to
+// it instead, so that the services do not depend on where the search runs.
+//
+// Example:
+//
+//	articles := search.New[*model.Article, int64](articleStore, []string{"Title", "Body"})
+//
+//	results, err := articles.Search(ctx, r.URL.Query().Get("q"),
+//		query.Filter("Status", "published"),
+//		query.Paginate(0, 20),
+//	)
+package search
@@ -0,0 +1,95 @@
+package search
+
+import (
+	"context"
+	"strings"
+
+	"github.com/infevocorp/goflexstore/query"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// Backend searches the entities outside of their store, e.g. in a search engine, see WithBackend.
+// It must apply the structured params, such as the filters and the pagination, as the store would.
+type Backend[T any] interface {
+	// Search returns the entities matching the text and the params, the most relevant first.
+	Search(ctx context.Context, text string, params query.Params) ([]T, error)
+	// Count returns the number of entities matching the text and the params.
+	Count(ctx context.Context, text string, params query.Params) (int64, error)
+}
+
+// Option is a function that configures the Searcher.
+type Option[T store.Entity[ID], ID comparable] func(*Searcher[T, ID])
+
+// WithBackend delegates the searches with a text to the backend. The searches without text are still served
+// by the store.
+func WithBackend[T store.Entity[ID], ID comparable](backend Backend[T]) Option[T, ID] {
+	return func(s *Searcher[T, ID]) {
+		s.backend = backend
+	}
+}
+
+// Searcher searches the entities of a store, see New.
+type Searcher[T store.Entity[ID], ID comparable] struct {
+	store   store.Store[T, ID]
+	fields  []string
+	backend Backend[T]
+}
+
+// New creates a Searcher matching the text against the given fields of the entities of the store, which must
+// be covered by a full-text index of the database, see query.FullText.
+func New[T store.Entity[ID], ID comparable](
+	s store.Store[T, ID],
+	fields []string,
+	opts ...Option[T, ID],
+) *Searcher[T, ID] {
+	searcher := &Searcher[T, ID]{
+		store:  s,
+		fields: fields,
+	}
+
+	for _, opt := range opts {
+		opt(searcher)
+	}
+
+	return searcher
+}
+
+// Search returns the entities matching the text and the params, the most relevant first, then in the order of
+// the OrderBy params. A blank text matches every entity: the entities matching the params are listed in the order
+// of the OrderBy params.
+func (s *Searcher[T, ID]) Search(ctx context.Context, text string, params ...query.Param) ([]T, error) {
+	text = strings.TrimSpace(text)
+
+	switch {
+	case text == "":
+		return s.store.List(ctx, params...)
+	case s.backend != nil:
+		return s.backend.Search(ctx, text, query.NewParams(params...))
+	default:
+		return s.store.List(ctx, s.withText(text, true, params)...)
+	}
+}
+
+// Count returns the number of entities matching the text and the params, e.g. to paginate the results of Search.
+func (s *Searcher[T, ID]) Count(ctx context.Context, text string, params ...query.Param) (int64, error) {
+	text = strings.TrimSpace(text)
+
+	switch {
+	case text == "":
+		return s.store.Count(ctx, params...)
+	case s.backend != nil:
+		return s.backend.Count(ctx, text, query.NewParams(params...))
+	default:
+		return s.store.Count(ctx, s.withText(text, false, params)...)
+	}
+}
+
+// withText returns the params with the full-text param of the text.
+func (s *Searcher[T, ID]) withText(text string, byRelevance bool, params []query.Param) []query.Param {
+	fullText := query.FullText(text, s.fields...)
+	if byRelevance {
+		fullText = fullText.OrderByRelevance()
+	}
+
+	return append([]query.Param{fullText}, params...)
+}
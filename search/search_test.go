@@ -0,0 +1,107 @@
+package search_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infevocorp/goflexstore/query"
+	"github.com/infevocorp/goflexstore/search"
+	"github.com/infevocorp/goflexstore/store"
+	"github.com/infevocorp/goflexstore/storetest"
+)
+
+type Article struct {
+	ID     int64
+	Title  string
+	Status string
+}
+
+func (a *Article) GetID() int64 {
+	return a.ID
+}
+
+func newArticles() *storetest.Fake[*Article, int64] {
+	return storetest.NewFake[*Article, int64](
+		&Article{ID: 1, Title: "Go generics", Status: "published"},
+		&Article{ID: 2, Title: "Go channels", Status: "draft"},
+		&Article{ID: 3, Title: "Rust traits", Status: "published"},
+	)
+}
+
+// backend is a Backend recording its searches.
+type backend struct {
+	texts []string
+}
+
+func (b *backend) Search(_ context.Context, text string, _ query.Params) ([]*Article, error) {
+	b.texts = append(b.texts, text)
+	return []*Article{{ID: 42}}, nil
+}
+
+func (b *backend) Count(_ context.Context, text string, _ query.Params) (int64, error) {
+	b.texts = append(b.texts, text)
+	return 1, nil
+}
+
+func Test_Searcher_Search(t *testing.T) {
+	t.Run("should-combine-text-and-filters-ordered-by-relevance", func(t *testing.T) {
+		// GIVEN
+		articles := newArticles()
+		searcher := search.New[*Article, int64](articles, []string{"Title"})
+
+		// WHEN
+		results, err := searcher.Search(context.Background(), " go ", query.Filter("Status", "published"))
+
+		// THEN
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, int64(1), results[0].ID)
+		articles.AssertCalled(t, store.MethodList,
+			storetest.HasParam(query.FullText("go", "Title").OrderByRelevance()),
+			storetest.HasFilter("Status", "published"),
+		)
+	})
+
+	t.Run("should-list-without-text", func(t *testing.T) {
+		// GIVEN
+		articles := newArticles()
+		searcher := search.New[*Article, int64](articles, []string{"Title"})
+
+		// WHEN
+		count, err := searcher.Count(context.Background(), "", query.Filter("Status", "published"))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+		articles.AssertNotCalled(t, store.MethodCount, storetest.HasParamType(query.TypeFullText))
+	})
+
+	t.Run("should-delegate-text-searches-to-backend", func(t *testing.T) {
+		// GIVEN
+		var (
+			articles = newArticles()
+			b        = &backend{}
+		)
+
+		searcher := search.New[*Article, int64](articles, []string{"Title"},
+			search.WithBackend[*Article, int64](b),
+		)
+
+		// WHEN
+		results, err := searcher.Search(context.Background(), "go")
+		count, countErr := searcher.Count(context.Background(), "go")
+		all, listErr := searcher.Search(context.Background(), "")
+
+		// THEN
+		require.NoError(t, err)
+		require.NoError(t, countErr)
+		require.NoError(t, listErr)
+		assert.Equal(t, []*Article{{ID: 42}}, results)
+		assert.Equal(t, int64(1), count)
+		assert.Len(t, all, 3)
+		assert.Equal(t, []string{"go", "go"}, b.texts)
+	})
+}
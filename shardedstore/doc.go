@@ -0,0 +1,21 @@
+// Package shardedstore spreads the entities of a type over several stores, one per shard, e.g. one per database.
+//
+// A Store owns the stores of the shards and routes each operation by the shard key of the entities, a field
+// such as the tenant ID: the writes are routed by the value of the field in their entities, and the reads and
+// deletes by the EQ filter on the field in their params. The operations without shard key are scattered to every
+// shard and their results gathered: List merges the entities of the shards, sorts them again by the OrderBy params
+// and applies the pagination to the merged list, Count sums the counts and Exists reports whether any shard has a
+// matching entity.
+//
+// Each shard keeps its own operation scope: a transaction only covers the operations of its shard.
+//
+// Example:
+//
+//	articles := shardedstore.New[*model.Article, int64](
+//		[]store.Store[*model.Article, int64]{shard0, shard1, shard2},
+//		shardedstore.WithShardKey("TenantID"),
+//	)
+//
+//	// routed to the shard of tenant 42
+//	list, err := articles.List(ctx, query.Filter("TenantID", 42), query.OrderBy("CreatedAt", true))
+package shardedstore
@@ -0,0 +1,77 @@
+package shardedstore
+
+import (
+	"reflect"
+
+	"github.com/infevocorp/goflexstore/query"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// withoutOffset returns the params listing, from each shard, the entities needed to paginate the merged list:
+// the first offset+limit ones. It also returns the pagination to apply to the merged list.
+func withoutOffset(params query.Params) ([]query.Param, query.PaginateParam) {
+	var (
+		shardParams = make([]query.Param, 0, params.Len())
+		paginate    query.PaginateParam
+	)
+
+	for i := 0; i < params.Len(); i++ {
+		if p, ok := params.At(i).(query.PaginateParam); ok {
+			paginate = p
+			continue
+		}
+
+		shardParams = append(shardParams, params.At(i))
+	}
+
+	if paginate.Limit > 0 {
+		shardParams = append(shardParams, query.Paginate(0, paginate.Offset+paginate.Limit))
+	}
+
+	return shardParams, paginate
+}
+
+func isNil(v any) bool {
+	rv := reflect.ValueOf(v)
+
+	return !rv.IsValid() || (rv.Kind() == reflect.Pointer && rv.IsNil())
+}
+
+// combineAggregates combines the aggregates of the shards, each followed by the count of the values of their field.
+func combineAggregates(aggs []store.Aggregate, results [][]float64) []float64 {
+	combined := make([]float64, len(aggs))
+
+	for i, agg := range aggs {
+		var total float64
+
+		for _, result := range results {
+			value, count := result[i], result[len(aggs)+i]
+			if count == 0 {
+				continue
+			}
+
+			switch agg.Aggregation {
+			case store.Min:
+				if total == 0 || value < combined[i] {
+					combined[i] = value
+				}
+			case store.Max:
+				if total == 0 || value > combined[i] {
+					combined[i] = value
+				}
+			case store.Avg:
+				combined[i] += value * count
+			default:
+				combined[i] += value
+			}
+
+			total += count
+		}
+
+		if agg.Aggregation == store.Avg && total > 0 {
+			combined[i] /= total
+		}
+	}
+
+	return combined
+}
@@ -0,0 +1,37 @@
+package shardedstore
+
+import (
+	"fmt"
+	"hash/fnv"
+)
+
+// Option is a function that configures the sharded Store.
+type Option func(*options)
+
+type options struct {
+	shardKey string
+	shardOf  func(key any, shards int) int
+}
+
+// WithShardKey sets the name of the field holding the shard key of the entities. It defaults to "ID".
+func WithShardKey(field string) Option {
+	return func(o *options) {
+		o.shardKey = field
+	}
+}
+
+// WithShardFunc sets the function returning the index of the shard of a shard key, between 0 and shards-1.
+// By default, the shard is the FNV-1a hash of the key formatted with fmt.Sprint, modulo the number of shards.
+func WithShardFunc(shardOf func(key any, shards int) int) Option {
+	return func(o *options) {
+		o.shardOf = shardOf
+	}
+}
+
+// hashShard is the default shard function, see WithShardFunc.
+func hashShard(key any, shards int) int {
+	h := fnv.New32a()
+	_, _ = fmt.Fprint(h, key)
+
+	return int(h.Sum32() % uint32(shards))
+}
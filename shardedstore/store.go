@@ -0,0 +1,442 @@
+package shardedstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/infevocorp/goflexstore/internal/entityutil"
+	"github.com/infevocorp/goflexstore/query"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// ErrNoShardKey is returned by the writes whose entity has no shard key field.
+var ErrNoShardKey = errors.New("shardedstore: entity has no shard key")
+
+// Store is a store.Store routing its operations to the stores of the shards, see New.
+type Store[T store.Entity[ID], ID comparable] struct {
+	options
+	shards []store.Store[T, ID]
+}
+
+var _ store.Store[store.Entity[int], int] = (*Store[store.Entity[int], int])(nil)
+
+// New creates a Store over the stores of the shards, in the order of their index. It panics if there is no shard.
+func New[T store.Entity[ID], ID comparable](shards []store.Store[T, ID], opts ...Option) *Store[T, ID] {
+	if len(shards) == 0 {
+		panic("shardedstore: no shard")
+	}
+
+	o := options{
+		shardKey: "ID",
+		shardOf:  hashShard,
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return &Store[T, ID]{
+		options: o,
+		shards:  shards,
+	}
+}
+
+// Shard returns the store of the shard of a shard key, e.g. to run a transaction on it.
+func (s *Store[T, ID]) Shard(key any) store.Store[T, ID] {
+	return s.shards[s.shardOf(key, len(s.shards))]
+}
+
+// Get returns the entity from the shard of the params, or the first one found among the shards.
+func (s *Store[T, ID]) Get(ctx context.Context, params ...query.Param) (T, error) {
+	if shard, ok := s.routeParams(params); ok {
+		return shard.Get(ctx, params...)
+	}
+
+	results, err := scatter(ctx, s.shards, func(ctx context.Context, shard store.Store[T, ID]) (T, error) {
+		entity, err := shard.Get(ctx, params...)
+		if errors.Is(err, store.ErrNotFound) {
+			return entity, nil
+		}
+
+		return entity, err
+	})
+	if err != nil {
+		return *new(T), err
+	}
+
+	for _, entity := range results {
+		if !isNil(entity) {
+			return entity, nil
+		}
+	}
+
+	return *new(T), store.ErrNotFound
+}
+
+// List returns the entities from the shard of the params, or the entities of every shard merged, sorted by the
+// OrderBy params and paginated.
+func (s *Store[T, ID]) List(ctx context.Context, params ...query.Param) ([]T, error) {
+	if shard, ok := s.routeParams(params); ok {
+		return shard.List(ctx, params...)
+	}
+
+	p := query.NewParams(params...)
+	shardParams, paginate := withoutOffset(p)
+
+	results, err := scatter(ctx, s.shards, func(ctx context.Context, shard store.Store[T, ID]) ([]T, error) {
+		return shard.List(ctx, shardParams...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var merged []T
+	for _, entities := range results {
+		merged = append(merged, entities...)
+	}
+
+	if err := entityutil.Sort(merged, p.Get(query.TypeOrderBy)); err != nil {
+		return nil, fmt.Errorf("shardedstore: %w", err)
+	}
+
+	return entityutil.Paginate(merged, paginate), nil
+}
+
+// ListWithCount returns the entities and the count from the shard of the params, or the entities of every shard
+// merged, sorted by the OrderBy params and paginated, with the sum of the counts of every shard.
+func (s *Store[T, ID]) ListWithCount(ctx context.Context, params ...query.Param) ([]T, int64, error) {
+	if shard, ok := s.routeParams(params); ok {
+		return shard.ListWithCount(ctx, params...)
+	}
+
+	p := query.NewParams(params...)
+	shardParams, paginate := withoutOffset(p)
+
+	results, err := scatter(ctx, s.shards, func(ctx context.Context, shard store.Store[T, ID]) (
+		store.ListWithCountResult[T],
+		error,
+	) {
+		entities, count, err := shard.ListWithCount(ctx, shardParams...)
+
+		return store.ListWithCountResult[T]{Entities: entities, Count: count}, err
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var (
+		merged []T
+		total  int64
+	)
+
+	for _, result := range results {
+		merged = append(merged, result.Entities...)
+		total += result.Count
+	}
+
+	if err := entityutil.Sort(merged, p.Get(query.TypeOrderBy)); err != nil {
+		return nil, 0, fmt.Errorf("shardedstore: %w", err)
+	}
+
+	return entityutil.Paginate(merged, paginate), total, nil
+}
+
+// Count returns the count of the shard of the params, or the sum of the counts of every shard.
+func (s *Store[T, ID]) Count(ctx context.Context, params ...query.Param) (int64, error) {
+	if shard, ok := s.routeParams(params); ok {
+		return shard.Count(ctx, params...)
+	}
+
+	counts, err := scatter(ctx, s.shards, func(ctx context.Context, shard store.Store[T, ID]) (int64, error) {
+		return shard.Count(ctx, params...)
+	})
+
+	var total int64
+	for _, count := range counts {
+		total += count
+	}
+
+	return total, err
+}
+
+// Exists reports whether the shard of the params, or any shard, has a matching entity.
+func (s *Store[T, ID]) Exists(ctx context.Context, params ...query.Param) (bool, error) {
+	if shard, ok := s.routeParams(params); ok {
+		return shard.Exists(ctx, params...)
+	}
+
+	results, err := scatter(ctx, s.shards, func(ctx context.Context, shard store.Store[T, ID]) (bool, error) {
+		return shard.Exists(ctx, params...)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	for _, exists := range results {
+		if exists {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// Aggregate computes the aggregate of the field in the shard of the params, or combines the aggregates of every
+// shard, see AggregateMany.
+func (s *Store[T, ID]) Aggregate(
+	ctx context.Context,
+	agg store.Aggregation,
+	field string,
+	params ...query.Param,
+) (float64, error) {
+	if shard, ok := s.routeParams(params); ok {
+		return shard.Aggregate(ctx, agg, field, params...)
+	}
+
+	results, err := s.AggregateMany(ctx, []store.Aggregate{{Aggregation: agg, Field: field}}, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return results[0], nil
+}
+
+// AggregateMany computes the aggregates in the shard of the params, or combines the aggregates of every shard:
+// the sums and counts are added, the minimums and maximums are compared and the averages are weighted by the count
+// of the values of each shard, which is queried along.
+func (s *Store[T, ID]) AggregateMany(
+	ctx context.Context,
+	aggs []store.Aggregate,
+	params ...query.Param,
+) ([]float64, error) {
+	if shard, ok := s.routeParams(params); ok {
+		return shard.AggregateMany(ctx, aggs, params...)
+	}
+
+	shardAggs := make([]store.Aggregate, 0, 2*len(aggs))
+	shardAggs = append(shardAggs, aggs...)
+
+	for _, agg := range aggs {
+		shardAggs = append(shardAggs, store.Aggregate{Aggregation: store.CountOf, Field: agg.Field})
+	}
+
+	results, err := scatter(ctx, s.shards, func(ctx context.Context, shard store.Store[T, ID]) ([]float64, error) {
+		return shard.AggregateMany(ctx, shardAggs, params...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return combineAggregates(aggs, results), nil
+}
+
+// Create creates the entity in the shard of its shard key.
+func (s *Store[T, ID]) Create(ctx context.Context, entity T) (ID, error) {
+	shard, err := s.routeEntity(entity)
+	if err != nil {
+		return *new(ID), err
+	}
+
+	return shard.Create(ctx, entity)
+}
+
+// Upsert upserts the entity in the shard of its shard key.
+func (s *Store[T, ID]) Upsert(ctx context.Context, entity T, onConflict store.OnConflict) (ID, error) {
+	shard, err := s.routeEntity(entity)
+	if err != nil {
+		return *new(ID), err
+	}
+
+	return shard.Upsert(ctx, entity, onConflict)
+}
+
+// CreateMany creates the entities in the shards of their shard keys, with one CreateMany per shard.
+// The shards are written one after the other: when one fails, the entities of the previous ones are kept.
+func (s *Store[T, ID]) CreateMany(ctx context.Context, entities []T) error {
+	byShard := make([][]T, len(s.shards))
+
+	for _, entity := range entities {
+		index, err := s.entityShard(entity)
+		if err != nil {
+			return err
+		}
+
+		byShard[index] = append(byShard[index], entity)
+	}
+
+	for i, shardEntities := range byShard {
+		if len(shardEntities) == 0 {
+			continue
+		}
+
+		if err := s.shards[i].CreateMany(ctx, shardEntities); err != nil {
+			return fmt.Errorf("shardedstore: shard %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// Update updates the entity in the shard of its shard key.
+func (s *Store[T, ID]) Update(ctx context.Context, entity T, params ...query.Param) error {
+	shard, err := s.routeEntity(entity)
+	if err != nil {
+		return err
+	}
+
+	return shard.Update(ctx, entity, params...)
+}
+
+// PartialUpdate partially updates the entity in the shard of its shard key.
+func (s *Store[T, ID]) PartialUpdate(ctx context.Context, entity T, params ...query.Param) error {
+	shard, err := s.routeEntity(entity)
+	if err != nil {
+		return err
+	}
+
+	return shard.PartialUpdate(ctx, entity, params...)
+}
+
+// UpdateMany updates the matching entities of the shard of the params, or of every shard, and returns the sum of
+// the updated entities. The shard key cannot be updated, since the entities would stay in the shard of its
+// former value.
+func (s *Store[T, ID]) UpdateMany(ctx context.Context, updates map[string]any, params ...query.Param) (int64, error) {
+	if _, ok := updates[s.shardKey]; ok {
+		return 0, fmt.Errorf("shardedstore: shard key %s cannot be updated", s.shardKey)
+	}
+
+	if shard, ok := s.routeParams(params); ok {
+		return shard.UpdateMany(ctx, updates, params...)
+	}
+
+	counts, err := scatter(ctx, s.shards, func(ctx context.Context, shard store.Store[T, ID]) (int64, error) {
+		return shard.UpdateMany(ctx, updates, params...)
+	})
+
+	var total int64
+	for _, count := range counts {
+		total += count
+	}
+
+	return total, err
+}
+
+// Delete deletes the matching entities from the shard of the params, or from every shard.
+func (s *Store[T, ID]) Delete(ctx context.Context, params ...query.Param) error {
+	if shard, ok := s.routeParams(params); ok {
+		return shard.Delete(ctx, params...)
+	}
+
+	_, err := scatter(ctx, s.shards, func(ctx context.Context, shard store.Store[T, ID]) (struct{}, error) {
+		return struct{}{}, shard.Delete(ctx, params...)
+	})
+
+	return err
+}
+
+// DeleteReturning deletes the matching entities from the shard of the params, or from every shard, and returns them.
+func (s *Store[T, ID]) DeleteReturning(ctx context.Context, params ...query.Param) ([]T, error) {
+	if shard, ok := s.routeParams(params); ok {
+		return shard.DeleteReturning(ctx, params...)
+	}
+
+	results, err := scatter(ctx, s.shards, func(ctx context.Context, shard store.Store[T, ID]) ([]T, error) {
+		return shard.DeleteReturning(ctx, params...)
+	})
+
+	var deleted []T
+	for _, entities := range results {
+		deleted = append(deleted, entities...)
+	}
+
+	return deleted, err
+}
+
+// Ping pings every shard.
+func (s *Store[T, ID]) Ping(ctx context.Context) error {
+	_, err := scatter(ctx, s.shards, func(ctx context.Context, shard store.Store[T, ID]) (struct{}, error) {
+		return struct{}{}, shard.Ping(ctx)
+	})
+
+	return err
+}
+
+// routeParams returns the shard of the EQ filter on the shard key with a single value, if any.
+func (s *Store[T, ID]) routeParams(params []query.Param) (store.Store[T, ID], bool) {
+	key := entityutil.NormalizeName(s.shardKey)
+
+	for _, p := range query.NewParams(params...).Get(query.TypeFilter) {
+		filter := p.(query.FilterParam)
+
+		if filter.Operator != query.EQ || entityutil.NormalizeName(filter.Name) != key || filter.Value == nil {
+			continue
+		}
+
+		if v := reflect.ValueOf(filter.Value); v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+			continue
+		}
+
+		return s.Shard(filter.Value), true
+	}
+
+	return nil, false
+}
+
+// routeEntity returns the shard of the shard key of the entity.
+func (s *Store[T, ID]) routeEntity(entity T) (store.Store[T, ID], error) {
+	index, err := s.entityShard(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.shards[index], nil
+}
+
+// entityShard returns the index of the shard of the shard key of the entity.
+func (s *Store[T, ID]) entityShard(entity T) (int, error) {
+	v := entityutil.Indirect(reflect.ValueOf(entity))
+	if v.Kind() != reflect.Struct {
+		return 0, fmt.Errorf("%w: %T is not a struct", ErrNoShardKey, entity)
+	}
+
+	field := entityutil.FieldByName(v, s.shardKey)
+	if !field.IsValid() {
+		return 0, fmt.Errorf("%w: %T has no field %s", ErrNoShardKey, entity, s.shardKey)
+	}
+
+	return s.shardOf(entityutil.Indirect(field).Interface(), len(s.shards)), nil
+}
+
+// scatter calls fn concurrently for every shard and returns their results in the order of the shards, with the
+// errors of the shards joined.
+func scatter[T store.Entity[ID], ID comparable, R any](
+	ctx context.Context,
+	shards []store.Store[T, ID],
+	fn func(ctx context.Context, shard store.Store[T, ID]) (R, error),
+) ([]R, error) {
+	var (
+		wg      sync.WaitGroup
+		results = make([]R, len(shards))
+		errs    = make([]error, len(shards))
+	)
+
+	for i, shard := range shards {
+		wg.Add(1)
+
+		go func(i int, shard store.Store[T, ID]) {
+			defer wg.Done()
+
+			results[i], errs[i] = fn(ctx, shard)
+			if errs[i] != nil {
+				errs[i] = fmt.Errorf("shardedstore: shard %d: %w", i, errs[i])
+			}
+		}(i, shard)
+	}
+
+	wg.Wait()
+
+	return results, errors.Join(errs...)
+}
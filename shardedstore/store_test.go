@@ -0,0 +1,213 @@
+package shardedstore_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infevocorp/goflexstore/query"
+	"github.com/infevocorp/goflexstore/shardedstore"
+	"github.com/infevocorp/goflexstore/store"
+	"github.com/infevocorp/goflexstore/storetest"
+)
+
+type Article struct {
+	ID       int64
+	TenantID int64
+	Title    string
+}
+
+func (a *Article) GetID() int64 {
+	return a.ID
+}
+
+// byTenant routes the tenant N to the shard N modulo the number of shards.
+func byTenant(key any, shards int) int {
+	return int(key.(int64)) % shards
+}
+
+func newShards() (*storetest.Fake[*Article, int64], *storetest.Fake[*Article, int64], *shardedstore.Store[*Article, int64]) {
+	shard0 := storetest.NewFake[*Article, int64](
+		&Article{ID: 1, TenantID: 2, Title: "b"},
+		&Article{ID: 3, TenantID: 4, Title: "d"},
+	)
+	shard1 := storetest.NewFake[*Article, int64](
+		&Article{ID: 2, TenantID: 1, Title: "a"},
+		&Article{ID: 4, TenantID: 3, Title: "c"},
+		&Article{ID: 5, TenantID: 3, Title: "e"},
+	)
+
+	sharded := shardedstore.New[*Article, int64](
+		[]store.Store[*Article, int64]{shard0, shard1},
+		shardedstore.WithShardKey("TenantID"),
+		shardedstore.WithShardFunc(byTenant),
+	)
+
+	return shard0, shard1, sharded
+}
+
+func titles(articles []*Article) []string {
+	result := make([]string, 0, len(articles))
+	for _, a := range articles {
+		result = append(result, a.Title)
+	}
+
+	return result
+}
+
+func Test_Store_Routing(t *testing.T) {
+	t.Run("should-route-reads-by-shard-key-filter", func(t *testing.T) {
+		// GIVEN
+		shard0, shard1, sharded := newShards()
+
+		// WHEN
+		list, err := sharded.List(context.Background(), query.Filter("TenantID", int64(3)))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, []string{"c", "e"}, titles(list))
+		assert.Empty(t, shard0.Calls())
+		assert.Len(t, shard1.Calls("List"), 1)
+	})
+
+	t.Run("should-route-writes-by-entity-shard-key", func(t *testing.T) {
+		// GIVEN
+		shard0, shard1, sharded := newShards()
+
+		// WHEN
+		_, err := sharded.Create(context.Background(), &Article{ID: 6, TenantID: 6, Title: "f"})
+		require.NoError(t, err)
+
+		err = sharded.CreateMany(context.Background(), []*Article{
+			{ID: 7, TenantID: 7, Title: "g"},
+			{ID: 8, TenantID: 8, Title: "h"},
+		})
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, []string{"b", "d", "f", "h"}, titles(shard0.Entities()))
+		assert.Equal(t, []string{"a", "c", "e", "g"}, titles(shard1.Entities()))
+	})
+
+	t.Run("should-fail-when-entity-has-no-shard-key", func(t *testing.T) {
+		// GIVEN
+		sharded := shardedstore.New[*Article, int64](
+			[]store.Store[*Article, int64]{storetest.NewFake[*Article, int64]()},
+			shardedstore.WithShardKey("Region"),
+		)
+
+		// WHEN
+		_, err := sharded.Create(context.Background(), &Article{ID: 1})
+
+		// THEN
+		assert.ErrorIs(t, err, shardedstore.ErrNoShardKey)
+	})
+}
+
+func Test_Store_ScatterGather(t *testing.T) {
+	t.Run("should-merge-sort-and-paginate-lists", func(t *testing.T) {
+		// GIVEN
+		shard0, shard1, sharded := newShards()
+
+		// WHEN
+		list, err := sharded.List(context.Background(),
+			query.OrderBy("Title", true),
+			query.Paginate(1, 3),
+		)
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, []string{"d", "c", "b"}, titles(list))
+
+		for _, shard := range []*storetest.Fake[*Article, int64]{shard0, shard1} {
+			calls := shard.Calls("List")
+			require.Len(t, calls, 1)
+			assert.True(t, storetest.HasParam(query.Paginate(0, 4)).Match(calls[0].Params))
+		}
+	})
+
+	t.Run("should-sum-counts", func(t *testing.T) {
+		// GIVEN
+		_, _, sharded := newShards()
+
+		// WHEN
+		count, err := sharded.Count(context.Background())
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, int64(5), count)
+	})
+
+	t.Run("should-merge-lists-and-sum-counts", func(t *testing.T) {
+		// GIVEN
+		_, _, sharded := newShards()
+
+		// WHEN
+		list, count, err := sharded.ListWithCount(context.Background(),
+			query.OrderBy("Title", false),
+			query.Paginate(1, 2),
+		)
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, []string{"b", "c"}, titles(list))
+		assert.Equal(t, int64(5), count)
+	})
+
+	t.Run("should-combine-aggregates", func(t *testing.T) {
+		// GIVEN
+		_, _, sharded := newShards()
+		aggs := []store.Aggregate{
+			{Aggregation: store.Sum, Field: "ID"},
+			{Aggregation: store.Avg, Field: "ID"},
+			{Aggregation: store.Min, Field: "ID"},
+			{Aggregation: store.Max, Field: "ID"},
+			{Aggregation: store.CountOf},
+		}
+
+		// WHEN
+		all, err := sharded.AggregateMany(context.Background(), aggs, query.Filter("ID", int64(2)).WithOP(query.GTE))
+		require.NoError(t, err)
+
+		oneShard, err := sharded.AggregateMany(context.Background(), aggs, query.Filter("ID", int64(4)).WithOP(query.GTE))
+		require.NoError(t, err)
+
+		// THEN
+		assert.Equal(t, []float64{14, 3.5, 2, 5, 4}, all)
+		assert.Equal(t, []float64{9, 4.5, 4, 5, 2}, oneShard)
+	})
+
+	t.Run("should-get-from-any-shard", func(t *testing.T) {
+		// GIVEN
+		_, _, sharded := newShards()
+
+		// WHEN
+		article, err := sharded.Get(context.Background(), query.Filter("ID", int64(4)))
+		_, notFoundErr := sharded.Get(context.Background(), query.Filter("ID", int64(9)))
+		exists, existsErr := sharded.Exists(context.Background(), query.Filter("Title", "e"))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, "c", article.Title)
+		assert.ErrorIs(t, notFoundErr, store.ErrNotFound)
+		require.NoError(t, existsErr)
+		assert.True(t, exists)
+	})
+
+	t.Run("should-return-shard-errors", func(t *testing.T) {
+		// GIVEN
+		shardErr := errors.New("connection refused")
+		_, shard1, sharded := newShards()
+		shard1.FailWith("List", shardErr)
+
+		// WHEN
+		_, err := sharded.List(context.Background())
+
+		// THEN
+		assert.ErrorIs(t, err, shardErr)
+		assert.ErrorContains(t, err, "shard 1")
+	})
+}
@@ -0,0 +1,16 @@
+// Package singleflight collapses the concurrent identical reads of stores into a single query, e.g. to protect
+// the database from the read storms of a hot key.
+//
+// Middleware decorates stores composed with store.Chain. While a Get, List, ListWithCount, Count, Exists, Aggregate
+// or AggregateMany operation is running, the identical operations, of the same entity, method, aggregates and
+// query params, wait for it and share its result instead of querying the database again. The operations running in
+// a transaction are never collapsed, so that they see their own writes.
+//
+// The result is shared as is: the callers must not modify the entities they get, or must copy them first.
+//
+// Example:
+//
+//	articleStore := store.Chain[*model.Article, int64](inner, singleflight.Middleware(
+//		singleflight.WithKeyer(func(ctx context.Context) string { return auth.TenantID(ctx) }),
+//	))
+package singleflight
@@ -0,0 +1,44 @@
+package singleflight
+
+import (
+	"context"
+
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// Option is a function that configures the Middleware.
+type Option func(*options)
+
+// Keyer returns a key identifying the callers allowed to share results, e.g. the ID of the tenant when it is not
+// part of the query params. Only the operations with the same key are collapsed.
+type Keyer func(ctx context.Context) string
+
+type options struct {
+	methods map[string]bool
+	keyer   Keyer
+}
+
+// WithMethods sets the methods whose operations are collapsed, among store.MethodGet, store.MethodList,
+// store.MethodListWithCount, store.MethodCount, store.MethodExists, store.MethodAggregate and
+// store.MethodAggregateMany, which are the default ones.
+func WithMethods(methods ...string) Option {
+	return func(o *options) {
+		o.methods = map[string]bool{}
+
+		for _, method := range methods {
+			switch method {
+			case store.MethodGet, store.MethodList, store.MethodListWithCount, store.MethodCount, store.MethodExists,
+				store.MethodAggregate, store.MethodAggregateMany:
+				o.methods[method] = true
+			}
+		}
+	}
+}
+
+// WithKeyer sets the function returning the key of the callers allowed to share results. By default, every
+// caller shares the results of the others.
+func WithKeyer(keyer Keyer) Option {
+	return func(o *options) {
+		o.keyer = keyer
+	}
+}
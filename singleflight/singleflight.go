@@ -0,0 +1,110 @@
+package singleflight
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/infevocorp/goflexstore/opscope"
+	"github.com/infevocorp/goflexstore/query"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// errPanicked is returned to the operations waiting for an operation that panicked.
+var errPanicked = errors.New("singleflight: collapsed store operation panicked")
+
+// Middleware returns a store.Middleware collapsing the concurrent identical reads into a single call of the
+// inner store.
+//
+// The operations waiting for a running one return when it does, or with the error of their context when it is
+// done first. As the running operation is executed with the context of its caller, its cancellation fails the
+// operations waiting for it too.
+//
+// Example:
+//
+//	articleStore := store.Chain[*model.Article, int64](inner, singleflight.Middleware())
+func Middleware(opts ...Option) store.Middleware {
+	o := options{
+		methods: map[string]bool{
+			store.MethodGet:           true,
+			store.MethodList:          true,
+			store.MethodListWithCount: true,
+			store.MethodCount:         true,
+			store.MethodExists:        true,
+			store.MethodAggregate:     true,
+			store.MethodAggregateMany: true,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	g := &group{calls: map[string]*call{}}
+
+	return func(next store.OperationFunc) store.OperationFunc {
+		return func(ctx context.Context, op *store.Operation) (any, error) {
+			if !o.methods[op.Method] || opscope.InTransaction(ctx) {
+				return next(ctx, op)
+			}
+
+			key := op.Entity + ":" + op.Method + ":" + query.NewParams(op.Params...).Hash()
+			if op.Input != nil {
+				key += fmt.Sprintf(":%v", op.Input)
+			}
+			if o.keyer != nil {
+				key += ":" + o.keyer(ctx)
+			}
+
+			return g.do(ctx, key, func() (any, error) {
+				return next(ctx, op)
+			})
+		}
+	}
+}
+
+// group holds the running operations of a Middleware by key.
+type group struct {
+	mu    sync.Mutex
+	calls map[string]*call
+}
+
+// call is a running operation.
+type call struct {
+	done   chan struct{}
+	result any
+	err    error
+}
+
+// do calls fn, unless an operation with the same key is running, in which case it waits for its result.
+func (g *group) do(ctx context.Context, key string, fn func() (any, error)) (any, error) {
+	g.mu.Lock()
+
+	if c, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+
+		select {
+		case <-c.done:
+			return c.result, c.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	c := &call{done: make(chan struct{}), err: errPanicked}
+	g.calls[key] = c
+	g.mu.Unlock()
+
+	defer func() {
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+
+		close(c.done)
+	}()
+
+	c.result, c.err = fn()
+
+	return c.result, c.err
+}
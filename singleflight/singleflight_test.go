@@ -0,0 +1,128 @@
+package singleflight_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	mockopscope "github.com/infevocorp/goflexstore/mocks/opscope"
+	mockstore "github.com/infevocorp/goflexstore/mocks/store"
+	"github.com/infevocorp/goflexstore/opscope"
+	"github.com/infevocorp/goflexstore/query"
+	"github.com/infevocorp/goflexstore/singleflight"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+type Article struct {
+	ID int
+}
+
+func (a *Article) GetID() int {
+	return a.ID
+}
+
+// listConcurrently calls List n times concurrently and returns the results once every call returned.
+func listConcurrently(ctx context.Context, s store.Store[*Article, int], n int, params ...query.Param) [][]*Article {
+	var (
+		wg      sync.WaitGroup
+		results = make([][]*Article, n)
+	)
+
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			results[i], _ = s.List(ctx, params...)
+		}(i)
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+func Test_Middleware(t *testing.T) {
+	t.Run("should-collapse-concurrent-identical-reads", func(t *testing.T) {
+		// GIVEN
+		var (
+			ctx      = context.Background()
+			inner    = mockstore.NewStore[*Article, int](t)
+			release  = make(chan struct{})
+			articles = []*Article{{ID: 1}}
+		)
+
+		inner.EXPECT().List(ctx, query.Filter("ID", 1)).
+			RunAndReturn(func(context.Context, ...query.Param) ([]*Article, error) {
+				<-release
+				return articles, nil
+			}).Once()
+
+		s := store.Chain[*Article, int](inner, singleflight.Middleware())
+
+		// release the query once the other calls had the time to wait for it.
+		time.AfterFunc(50*time.Millisecond, func() { close(release) })
+
+		// WHEN
+		results := listConcurrently(ctx, s, 5, query.Filter("ID", 1))
+
+		// THEN
+		for _, result := range results {
+			assert.Equal(t, articles, result)
+		}
+	})
+
+	t.Run("should-not-collapse-different-params", func(t *testing.T) {
+		// GIVEN
+		var (
+			ctx   = context.Background()
+			inner = mockstore.NewStore[*Article, int](t)
+		)
+
+		inner.EXPECT().Count(ctx, query.Filter("ID", 1)).Return(1, nil).Once()
+		inner.EXPECT().Count(ctx, query.Filter("ID", 2)).Return(0, nil).Once()
+
+		s := store.Chain[*Article, int](inner, singleflight.Middleware())
+
+		// WHEN
+		count1, err1 := s.Count(ctx, query.Filter("ID", 1))
+		count2, err2 := s.Count(ctx, query.Filter("ID", 2))
+
+		// THEN
+		assert.NoError(t, err1)
+		assert.NoError(t, err2)
+		assert.Equal(t, int64(1), count1)
+		assert.Equal(t, int64(0), count2)
+	})
+
+	t.Run("should-not-collapse-reads-in-transaction", func(t *testing.T) {
+		// GIVEN
+		var (
+			inspector = mockopscope.NewTxInspector(t)
+			ctx       = opscope.WithTxInspector(context.Background(), inspector)
+			inner     = mockstore.NewStore[*Article, int](t)
+			release   = make(chan struct{})
+		)
+
+		inspector.EXPECT().TxInfo().Return(opscope.Info{Name: "writeTx", Level: 1}, true)
+		inner.EXPECT().List(ctx).
+			RunAndReturn(func(context.Context, ...query.Param) ([]*Article, error) {
+				<-release
+				return nil, nil
+			}).Times(3)
+
+		s := store.Chain[*Article, int](inner, singleflight.Middleware())
+
+		time.AfterFunc(50*time.Millisecond, func() { close(release) })
+
+		// WHEN
+		results := listConcurrently(ctx, s, 3)
+
+		// THEN
+		assert.Len(t, results, 3)
+	})
+}
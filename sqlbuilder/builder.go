@@ -0,0 +1,431 @@
+package sqlbuilder
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/infevocorp/goflexstore/query"
+)
+
+// ErrInvalidParam is returned for the query params that cannot be rendered to SQL, wrapped with the reason.
+var ErrInvalidParam = errors.New("invalid query param")
+
+// operators holds the SQL operator of each comparison query.Operator.
+var operators = [...]string{
+	query.EQ:  "=",
+	query.NEQ: "<>",
+	query.GT:  ">",
+	query.GTE: ">=",
+	query.LT:  "<",
+	query.LTE: "<=",
+}
+
+// Clauses are the clauses of a SELECT statement rendered from query params, with ? placeholders.
+//
+// Fields:
+//   - Where: The conditions of the WHERE clause, to be joined with AND, see Where.
+//   - WhereArgs: The args of the placeholders of the conditions.
+//   - GroupBy: The grouped columns.
+//   - Having: The conditions of the HAVING clause, to be joined with AND.
+//   - HavingArgs: The args of the placeholders of the HAVING conditions.
+//   - OrderBy: The ordered columns, followed by DESC for a descending order.
+//   - Select: The selected columns, empty to select all of them.
+//   - Offset: The number of rows to skip.
+//   - Limit: The maximum number of rows, 0 for no limit.
+//   - Lock: Whether the rows are locked for update.
+type Clauses struct {
+	Where      []string
+	WhereArgs  []any
+	GroupBy    []string
+	Having     []string
+	HavingArgs []any
+	OrderBy    []string
+	Select     []string
+	Offset     int
+	Limit      int
+	Lock       bool
+}
+
+// Option configures a Builder.
+type Option func(*Builder)
+
+// WithFieldToColMap sets the columns of the field names of the params. The names missing from the map are
+// used as columns as they are.
+func WithFieldToColMap(fieldToColMap map[string]string) Option {
+	return func(b *Builder) {
+		b.FieldToColMap = fieldToColMap
+	}
+}
+
+// WithTable qualifies the columns with the table name, e.g. "articles"."status".
+func WithTable(table string) Option {
+	return func(b *Builder) {
+		b.Table = table
+	}
+}
+
+// NewBuilder creates a Builder rendering the params in the dialect.
+//
+// Example:
+//
+//	builder := sqlbuilder.NewBuilder(sqlbuilder.Postgres, sqlbuilder.WithFieldToColMap(map[string]string{
+//		"AuthorID": "author_id",
+//	}))
+func NewBuilder(dialect Dialect, options ...Option) *Builder {
+	b := &Builder{Dialect: dialect}
+
+	for _, option := range options {
+		option(b)
+	}
+
+	return b
+}
+
+// Builder renders query params to the SQL of a dialect, independently of any ORM.
+//
+// Filters with a nil value are rendered as IS (NOT) NULL conditions, and filters with a collection value as
+// (NOT) IN lists, the empty IN and NOTIN lists being rendered as the constant conditions 1 = 0 and 1 = 1. ILIKE is
+// rendered as LOWER(col) LIKE LOWER(?) for the dialects without ILIKE.
+//
+// Fields:
+//   - Dialect: The dialect of the rendered SQL.
+//   - FieldToColMap: The columns of the field names of the params.
+//   - Table: The table qualifying the columns, if any.
+type Builder struct {
+	Dialect       Dialect
+	FieldToColMap map[string]string
+	Table         string
+}
+
+// ToSQL renders the SELECT statement of the params on the table, with the placeholders of the dialect, e.g. to
+// debug the queries of a store.
+//
+// Example:
+//
+//	sql, args, err := builder.ToSQL("articles", query.NewParams(
+//		query.Filter("Status", "active"),
+//		query.OrderBy("CreatedAt", true),
+//		query.Paginate(0, 10),
+//	))
+//	// SELECT * FROM "articles" WHERE "status" = $1 ORDER BY "created_at" DESC LIMIT 10 [active]
+func (b *Builder) ToSQL(table string, params query.Params) (string, []any, error) {
+	c, err := b.Build(params)
+	if err != nil {
+		return "", nil, err
+	}
+
+	columns := c.Select
+	if len(columns) == 0 {
+		columns = []string{"*"}
+	}
+
+	sql, args := b.Select(table, columns, c)
+
+	return b.Dialect.Rebind(sql), args, nil
+}
+
+// Build renders the params to clauses. It returns an error wrapping ErrInvalidParam for the params that cannot be
+// rendered. The preload, cascade and unscoped params are ignored.
+func (b *Builder) Build(params query.Params) (Clauses, error) {
+	var c Clauses
+
+	for _, param := range params.Params() {
+		switch p := param.(type) {
+		case query.FilterParam, query.ORParam, query.GroupParam, query.NotParam, query.RawParam:
+			cond, args, err := b.Condition(p)
+			if err != nil {
+				return Clauses{}, err
+			}
+
+			if cond != "" {
+				c.Where = append(c.Where, cond)
+				c.WhereArgs = append(c.WhereArgs, args...)
+			}
+		case query.OrderByParam:
+			order := b.Column(p.Name)
+			if p.Desc {
+				order += " DESC"
+			}
+
+			c.OrderBy = append(c.OrderBy, order)
+		case query.PaginateParam:
+			c.Offset, c.Limit = p.Offset, p.Limit
+		case query.SelectParam:
+			for _, name := range p.Names {
+				c.Select = append(c.Select, b.Column(name))
+			}
+		case query.GroupByParam:
+			for _, name := range p.Names {
+				c.GroupBy = append(c.GroupBy, b.Column(name))
+			}
+
+			for _, filter := range p.Having {
+				cond, args, err := b.Filter(filter)
+				if err != nil {
+					return Clauses{}, err
+				}
+
+				c.Having = append(c.Having, cond)
+				c.HavingArgs = append(c.HavingArgs, args...)
+			}
+		case query.WithLockParam:
+			c.Lock = true
+		case query.PreloadParam, query.PreloadAllParam, query.CascadeParam, query.UnscopedParam:
+		default:
+			return Clauses{}, fmt.Errorf("%w: %s params are not supported", ErrInvalidParam, param.ParamType())
+		}
+	}
+
+	return c, nil
+}
+
+// Condition renders a filter, OR, group, not or raw param. It returns an empty condition for empty groups.
+func (b *Builder) Condition(param query.Param) (string, []any, error) {
+	switch p := param.(type) {
+	case query.FilterParam:
+		return b.Filter(p)
+	case query.ORParam:
+		params := make([]query.Param, len(p.Params))
+		for i := range p.Params {
+			params[i] = p.Params[i]
+		}
+
+		return b.group(query.GroupParam{OR: true, Params: params})
+	case query.GroupParam:
+		return b.group(p)
+	case query.NotParam:
+		cond, args, err := b.Condition(p.Param)
+		if err != nil || cond == "" {
+			return "", nil, err
+		}
+
+		return "NOT " + cond, args, nil
+	case query.RawParam:
+		return "(" + p.SQL + ")", p.Args, nil
+	default:
+		return "", nil, fmt.Errorf("%w: %s param cannot be a condition", ErrInvalidParam, param.ParamType())
+	}
+}
+
+// group renders the conditions of the group joined by AND or OR within parentheses.
+func (b *Builder) group(p query.GroupParam) (string, []any, error) {
+	var (
+		conds []string
+		args  []any
+	)
+
+	for _, param := range p.Params {
+		cond, condArgs, err := b.Condition(param)
+		if err != nil {
+			return "", nil, err
+		}
+
+		if cond != "" {
+			conds = append(conds, cond)
+			args = append(args, condArgs...)
+		}
+	}
+
+	if len(conds) == 0 {
+		return "", nil, nil
+	}
+
+	op := " AND "
+	if p.OR {
+		op = " OR "
+	}
+
+	return "(" + strings.Join(conds, op) + ")", args, nil
+}
+
+// Filter renders a filter: nil values as IS (NOT) NULL, collections as (NOT) IN lists, and ILIKE as a comparison
+// of the lowercased values for the dialects without ILIKE.
+func (b *Builder) Filter(p query.FilterParam) (string, []any, error) {
+	col := b.Column(p.Name)
+
+	if p.Value == nil {
+		switch p.Operator {
+		case query.EQ:
+			return col + " IS NULL", nil, nil
+		case query.NEQ:
+			return col + " IS NOT NULL", nil, nil
+		default:
+			return "", nil, fmt.Errorf("%w: nil value of %s with %s", ErrInvalidParam, p.Name, p.Operator)
+		}
+	}
+
+	if values, ok := collection(p.Value); ok {
+		return b.in(p, col, values)
+	}
+
+	switch p.Operator {
+	case query.EQ, query.NEQ, query.GT, query.GTE, query.LT, query.LTE:
+		return col + " " + operators[p.Operator] + " ?", []any{p.Value}, nil
+	case query.LIKE:
+		return col + " LIKE ?", []any{p.Value}, nil
+	case query.ILIKE:
+		if b.Dialect.Name() == Postgres.Name() {
+			return col + " ILIKE ?", []any{p.Value}, nil
+		}
+
+		return "LOWER(" + col + ") LIKE LOWER(?)", []any{p.Value}, nil
+	case query.IN, query.NOTIN:
+		return "", nil, fmt.Errorf("%w: value of %s must be a collection with %s", ErrInvalidParam, p.Name, p.Operator)
+	default:
+		return "", nil, fmt.Errorf("%w: unsupported operator %s", ErrInvalidParam, p.Operator)
+	}
+}
+
+// Comparison returns the SQL operator of a comparison operator, EQ to LTE, and false for the other operators.
+func Comparison(op query.Operator) (string, bool) {
+	if int(op) >= len(operators) || operators[op] == "" {
+		return "", false
+	}
+
+	return operators[op], true
+}
+
+// in renders a filter whose value is a collection as a (NOT) IN list. Empty IN and NOTIN lists are rendered as
+// constant conditions, and are rejected with EQ and NEQ.
+func (b *Builder) in(p query.FilterParam, col string, values []any) (string, []any, error) {
+	var not bool
+
+	switch p.Operator {
+	case query.EQ, query.IN:
+	case query.NEQ, query.NOTIN:
+		not = true
+	default:
+		return "", nil, fmt.Errorf("%w: operator %s cannot be used with a collection", ErrInvalidParam, p.Operator)
+	}
+
+	if len(values) == 0 {
+		switch {
+		case p.Operator == query.IN:
+			return "1 = 0", nil, nil
+		case p.Operator == query.NOTIN:
+			return "1 = 1", nil, nil
+		default:
+			return "", nil, fmt.Errorf("%w: empty collection of %s, use IN or NOTIN", ErrInvalidParam, p.Name)
+		}
+	}
+
+	op := " IN "
+	if not {
+		op = " NOT IN "
+	}
+
+	return col + op + Placeholders(len(values)), values, nil
+}
+
+// Column returns the quoted column of the field name, qualified by the table of the builder if any. The names
+// missing from the FieldToColMap of the builder are quoted as they are.
+func (b *Builder) Column(name string) string {
+	col := name
+	if c, ok := b.FieldToColMap[name]; ok {
+		col = c
+	}
+
+	if b.Table != "" {
+		col = b.Table + "." + col
+	}
+
+	return b.Dialect.Quote(col)
+}
+
+// Select renders the SELECT statement of the columns of the table and the clauses, with ? placeholders to be
+// rebound by the dialect once the statement is complete.
+func (b *Builder) Select(table string, columns []string, c Clauses) (string, []any) {
+	var (
+		sql  strings.Builder
+		args = append([]any{}, c.WhereArgs...)
+	)
+
+	sql.WriteString("SELECT ")
+	sql.WriteString(strings.Join(columns, ", "))
+	sql.WriteString(" FROM ")
+	sql.WriteString(b.Dialect.Quote(table))
+	sql.WriteString(Where(c.Where))
+
+	if len(c.GroupBy) > 0 {
+		sql.WriteString(" GROUP BY ")
+		sql.WriteString(strings.Join(c.GroupBy, ", "))
+	}
+
+	if len(c.Having) > 0 {
+		sql.WriteString(" HAVING ")
+		sql.WriteString(strings.Join(c.Having, " AND "))
+
+		args = append(args, c.HavingArgs...)
+	}
+
+	if len(c.OrderBy) > 0 {
+		sql.WriteString(" ORDER BY ")
+		sql.WriteString(strings.Join(c.OrderBy, ", "))
+	}
+
+	sql.WriteString(b.Limit(c.Offset, c.Limit))
+
+	// SQLite locks the whole database in write transactions.
+	if c.Lock && b.Dialect.Name() != SQLite.Name() {
+		sql.WriteString(" FOR UPDATE")
+	}
+
+	return sql.String(), args
+}
+
+// Limit renders the LIMIT and OFFSET clauses, empty without limit nor offset. MySQL and SQLite require a LIMIT
+// with an OFFSET.
+func (b *Builder) Limit(offset, limit int) string {
+	var sql string
+
+	switch {
+	case limit > 0:
+		sql = " LIMIT " + strconv.Itoa(limit)
+	case offset <= 0:
+		return ""
+	case b.Dialect.Name() == MySQL.Name():
+		sql = " LIMIT 18446744073709551615"
+	case b.Dialect.Name() == SQLite.Name():
+		sql = " LIMIT -1"
+	}
+
+	if offset > 0 {
+		sql += " OFFSET " + strconv.Itoa(offset)
+	}
+
+	return sql
+}
+
+// Where renders the WHERE clause of the conditions, empty without condition.
+func Where(conds []string) string {
+	if len(conds) == 0 {
+		return ""
+	}
+
+	return " WHERE " + strings.Join(conds, " AND ")
+}
+
+// Placeholders renders n ? placeholders within parentheses, e.g. the values of a row or an IN list.
+func Placeholders(n int) string {
+	return "(" + strings.TrimSuffix(strings.Repeat("?, ", n), ", ") + ")"
+}
+
+// collection returns the values of a slice, other than bytes, or array value.
+func collection(value any) ([]any, bool) {
+	v := reflect.ValueOf(value)
+
+	switch {
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8, v.Kind() == reflect.Array:
+		values := make([]any, v.Len())
+		for i := range values {
+			values[i] = v.Index(i).Interface()
+		}
+
+		return values, true
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,111 @@
+package sqlbuilder_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infevocorp/goflexstore/query"
+	"github.com/infevocorp/goflexstore/sqlbuilder"
+)
+
+func Test_Builder_ToSQL(t *testing.T) {
+	fieldToColMap := map[string]string{"AuthorID": "author_id", "Status": "status", "Views": "views"}
+
+	tests := []struct {
+		name    string
+		dialect sqlbuilder.Dialect
+		params  []query.Param
+		sql     string
+		args    []any
+	}{
+		{
+			name:    "should-render-filters-groups-and-pagination",
+			dialect: sqlbuilder.Postgres,
+			params: []query.Param{
+				query.Filter("Status", "active"),
+				query.OR(
+					query.AND(query.Filter("AuthorID", 1), query.Filter("Views", 10).WithOP(query.GTE)),
+					query.Not(query.Filter("AuthorID", []int{2, 3})),
+				),
+				query.OrderBy("Views", true),
+				query.Paginate(20, 10),
+			},
+			sql: `SELECT * FROM "articles" WHERE "status" = $1 AND (("author_id" = $2 AND "views" >= $3) OR ` +
+				`NOT "author_id" IN ($4, $5)) ORDER BY "views" DESC LIMIT 10 OFFSET 20`,
+			args: []any{"active", 1, 10, 2, 3},
+		},
+		{
+			name:    "should-render-null-empty-collections-and-ilike",
+			dialect: sqlbuilder.MySQL,
+			params: []query.Param{
+				query.Filter("deleted_at", nil),
+				query.Filter("AuthorID", []int{}).WithOP(query.NOTIN),
+				query.Filter("Status", "ACT%").WithOP(query.ILIKE),
+				query.Paginate(5, 0),
+			},
+			sql: "SELECT * FROM `articles` WHERE `deleted_at` IS NULL AND 1 = 1 AND LOWER(`status`) LIKE LOWER(?) " +
+				"LIMIT 18446744073709551615 OFFSET 5",
+			args: []any{"ACT%"},
+		},
+		{
+			name:    "should-render-select-group-by-having-and-raw",
+			dialect: sqlbuilder.SQLite,
+			params: []query.Param{
+				query.Select("AuthorID"),
+				query.Raw("views > ? * 2", 5),
+				query.GroupBy("AuthorID").WithHaving(query.Filter("AuthorID", 3).WithOP(query.NEQ)),
+				query.WithLock(query.LockTypeForUpdate),
+			},
+			sql: `SELECT "author_id" FROM "articles" WHERE (views > ? * 2) GROUP BY "author_id" ` +
+				`HAVING "author_id" <> ?`,
+			args: []any{5, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN
+			builder := sqlbuilder.NewBuilder(tt.dialect, sqlbuilder.WithFieldToColMap(fieldToColMap))
+
+			// WHEN
+			sql, args, err := builder.ToSQL("articles", query.NewParams(tt.params...))
+
+			// THEN
+			require.NoError(t, err)
+			assert.Equal(t, tt.sql, sql)
+			assert.Equal(t, tt.args, args)
+		})
+	}
+}
+
+func Test_Builder_Build(t *testing.T) {
+	t.Run("should-qualify-columns-with-table", func(t *testing.T) {
+		// GIVEN
+		builder := sqlbuilder.NewBuilder(sqlbuilder.Postgres, sqlbuilder.WithTable("articles"))
+
+		// WHEN
+		cond, args, err := builder.Condition(query.Filter("status", "active"))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, `"articles"."status" = ?`, cond)
+		assert.Equal(t, []any{"active"}, args)
+	})
+
+	t.Run("should-return-error-of-invalid-params", func(t *testing.T) {
+		builder := sqlbuilder.NewBuilder(sqlbuilder.Postgres)
+
+		for _, param := range []query.Param{
+			query.Filter("status", []string{}),
+			query.Filter("status", "active").WithOP(query.IN),
+			query.Filter("status", nil).WithOP(query.GT),
+			query.Sample(0.1),
+		} {
+			_, err := builder.Build(query.NewParams(param))
+
+			assert.ErrorIs(t, err, sqlbuilder.ErrInvalidParam, param)
+		}
+	})
+}
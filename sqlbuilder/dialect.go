@@ -0,0 +1,95 @@
+package sqlbuilder
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Dialect renders the identifiers and placeholders of the SQL of a database.
+type Dialect interface {
+	// Name returns the name of the dialect: "postgres", "mysql" or "sqlite" for the dialects of the package.
+	Name() string
+
+	// Quote quotes an identifier, each part of the dotted identifiers being quoted separately.
+	Quote(identifier string) string
+
+	// Rebind replaces the ? placeholders of the query, outside of quoted strings and identifiers, with the
+	// placeholders of the dialect.
+	Rebind(query string) string
+}
+
+var (
+	// Postgres is the dialect of PostgreSQL, with "quoted" identifiers and $1 placeholders.
+	Postgres Dialect = dialect{name: "postgres", quote: '"', numbered: true}
+
+	// MySQL is the dialect of MySQL and MariaDB, with `quoted` identifiers and ? placeholders.
+	MySQL Dialect = dialect{name: "mysql", quote: '`'}
+
+	// SQLite is the dialect of SQLite, with "quoted" identifiers and ? placeholders.
+	SQLite Dialect = dialect{name: "sqlite", quote: '"'}
+)
+
+type dialect struct {
+	name     string
+	quote    byte
+	numbered bool
+}
+
+func (d dialect) Name() string {
+	return d.name
+}
+
+func (d dialect) Quote(identifier string) string {
+	var b strings.Builder
+
+	for i, part := range strings.Split(identifier, ".") {
+		if i > 0 {
+			b.WriteByte('.')
+		}
+
+		if part == "*" {
+			b.WriteString(part)
+			continue
+		}
+
+		quote := string(d.quote)
+
+		b.WriteString(quote)
+		b.WriteString(strings.ReplaceAll(part, quote, quote+quote))
+		b.WriteString(quote)
+	}
+
+	return b.String()
+}
+
+func (d dialect) Rebind(query string) string {
+	if !d.numbered {
+		return query
+	}
+
+	var (
+		b     strings.Builder
+		n     int
+		quote rune
+	)
+
+	for _, r := range query {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"':
+			quote = r
+		case r == '?':
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+
+			continue
+		}
+
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,24 @@
+package sqlbuilder_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infevocorp/goflexstore/sqlbuilder"
+)
+
+func Test_Dialect(t *testing.T) {
+	t.Run("should-quote-identifiers", func(t *testing.T) {
+		assert.Equal(t, `"articles"."author_id"`, sqlbuilder.Postgres.Quote("articles.author_id"))
+		assert.Equal(t, "`articles`.*", sqlbuilder.MySQL.Quote("articles.*"))
+		assert.Equal(t, `"odd""name"`, sqlbuilder.SQLite.Quote(`odd"name`))
+	})
+
+	t.Run("should-rebind-placeholders-outside-of-quotes", func(t *testing.T) {
+		q := `SELECT * FROM "a?" WHERE x = ? AND y = '?' AND z IN (?, ?)`
+
+		assert.Equal(t, `SELECT * FROM "a?" WHERE x = $1 AND y = '?' AND z IN ($2, $3)`, sqlbuilder.Postgres.Rebind(q))
+		assert.Equal(t, q, sqlbuilder.MySQL.Rebind(q))
+	})
+}
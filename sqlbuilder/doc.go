@@ -0,0 +1,18 @@
+// Package sqlbuilder renders query params to the SQL of PostgreSQL, MySQL or SQLite with bind args, independently
+// of any ORM.
+//
+// A Builder renders the params to the Clauses of a SELECT statement with ? placeholders, which the store backends
+// compose into their statements before replacing the placeholders with the ones of the Dialect, e.g. $1 for
+// PostgreSQL. ToSQL renders the complete SELECT statement of the params, e.g. to log or debug the queries of a
+// store.
+//
+// Example:
+//
+//	builder := sqlbuilder.NewBuilder(sqlbuilder.Postgres)
+//
+//	sql, args, err := builder.ToSQL("articles", query.NewParams(
+//		query.Filter("status", "active"),
+//		query.OR(query.Filter("author_id", 1), query.Filter("views", 100).WithOP(query.GTE)),
+//	))
+//	// SELECT * FROM "articles" WHERE "status" = $1 AND ("author_id" = $2 OR "views" >= $3) [active 1 100]
+package sqlbuilder
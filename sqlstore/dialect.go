@@ -0,0 +1,20 @@
+package sqlstore
+
+import "github.com/infevocorp/goflexstore/sqlbuilder"
+
+// Dialect renders the identifiers and placeholders of the SQL of a database, see sqlbuilder.Dialect.
+type Dialect = sqlbuilder.Dialect
+
+var (
+	// Postgres is the dialect of PostgreSQL, see sqlbuilder.Postgres.
+	Postgres = sqlbuilder.Postgres
+
+	// MySQL is the dialect of MySQL and MariaDB, see sqlbuilder.MySQL.
+	MySQL = sqlbuilder.MySQL
+
+	// SQLite is the dialect of SQLite, see sqlbuilder.SQLite.
+	SQLite = sqlbuilder.SQLite
+)
+
+// ErrInvalidParam is returned for the query params that cannot be rendered to SQL, see sqlbuilder.ErrInvalidParam.
+var ErrInvalidParam = sqlbuilder.ErrInvalidParam
@@ -0,0 +1,157 @@
+package sqlstore_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infevocorp/goflexstore/query"
+	"github.com/infevocorp/goflexstore/sqlstore"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+func Test_FieldToColMap(t *testing.T) {
+	type Base struct {
+		ID int64 `db:"id,pk"`
+	}
+
+	type DTO struct {
+		Base
+		HTTPStatus int
+		AuthorID   int64
+		Title      string `db:"headline"`
+		Ignored    string `db:"-"`
+		internal   string
+	}
+
+	assert.Equal(t, map[string]string{
+		"ID":         "id",
+		"HTTPStatus": "http_status",
+		"AuthorID":   "author_id",
+		"Title":      "headline",
+	}, sqlstore.FieldToColMap(DTO{internal: ""}))
+}
+
+// newMockArticles returns a store of the articles of a mocked database in the dialect.
+func newMockArticles(
+	t *testing.T,
+	dialect sqlstore.Dialect,
+) (*sqlstore.Store[*Article, *Article, int64], sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		assert.NoError(t, mock.ExpectationsWereMet())
+		_ = db.Close()
+	})
+
+	opScope := sqlstore.NewTransactionScope("tx", db, dialect, nil)
+
+	return sqlstore.NewSimple[*Article, int64](opScope, "articles"), mock
+}
+
+func Test_Store_Dialects(t *testing.T) {
+	ctx := context.Background()
+	columns := []string{"id", "title", "status", "author_id", "views", "summary"}
+
+	t.Run("should-render-postgres-select", func(t *testing.T) {
+		// GIVEN
+		articles, mock := newMockArticles(t, sqlstore.Postgres)
+
+		mock.ExpectQuery(`SELECT * FROM "articles" WHERE "status" ILIKE $1 AND ("author_id" = $2 OR "views" > $3) `+
+			`ORDER BY "title" DESC LIMIT 10 OFFSET 20 FOR UPDATE`).
+			WithArgs("a%", 1, 5).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "first", "active", 1, 10, nil))
+
+		// WHEN
+		list, err := articles.List(ctx,
+			query.Filter("Status", "a%").WithOP(query.ILIKE),
+			query.OR(query.Filter("AuthorID", 1), query.Filter("Views", 5).WithOP(query.GT)),
+			query.OrderBy("Title", true),
+			query.Paginate(20, 10),
+			query.WithLock(query.LockTypeForUpdate),
+		)
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, []int64{1}, ids(list))
+	})
+
+	t.Run("should-render-postgres-upsert-on-constraint", func(t *testing.T) {
+		// GIVEN
+		articles, mock := newMockArticles(t, sqlstore.Postgres)
+
+		mock.ExpectQuery(`INSERT INTO "articles" ("title", "status", "author_id", "views", "summary") `+
+			`VALUES ($1, $2, $3, $4, $5) ON CONFLICT ON CONSTRAINT "articles_title_key" `+
+			`DO UPDATE SET "status" = EXCLUDED."status", "views" = $6 `+
+			`WHERE "articles"."status" <> EXCLUDED."status" RETURNING "id"`).
+			WithArgs("first", "active", int64(0), 0, nil, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+		// WHEN
+		id, err := articles.Upsert(ctx, &Article{Title: "first", Status: "active"}, store.OnConflict{
+			OnConstraint:  "articles_title_key",
+			UpdateColumns: []string{"Status"},
+			Updates:       map[string]any{"Views": 1},
+			UpdateWhere: []query.FilterParam{
+				query.Filter("Status", store.Excluded("Status")).WithOP(query.NEQ),
+			},
+		})
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), id)
+	})
+
+	t.Run("should-render-mysql-upsert", func(t *testing.T) {
+		// GIVEN
+		articles, mock := newMockArticles(t, sqlstore.MySQL)
+
+		mock.ExpectExec("INSERT INTO `articles` (`title`, `status`, `author_id`, `views`, `summary`) "+
+			"VALUES (?, ?, ?, ?, ?) ON DUPLICATE KEY UPDATE `title` = VALUES(`title`), `status` = VALUES(`status`), "+
+			"`author_id` = VALUES(`author_id`), `views` = VALUES(`views`), `summary` = VALUES(`summary`)").
+			WithArgs("first", "active", int64(0), 0, nil).
+			WillReturnResult(sqlmock.NewResult(7, 1))
+
+		mock.ExpectExec("INSERT IGNORE INTO `articles` (`id`, `title`, `status`, `author_id`, `views`, `summary`) "+
+			"VALUES (?, ?, ?, ?, ?, ?)").
+			WithArgs(int64(7), "first", "draft", int64(0), 0, nil).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		// WHEN
+		article := &Article{Title: "first", Status: "active"}
+		id, err := articles.Upsert(ctx, article, store.OnConflict{UpdateAll: true})
+		require.NoError(t, err)
+
+		_, ignoreErr := articles.Upsert(ctx, &Article{ID: 7, Title: "first", Status: "draft"}, store.OnConflict{
+			DoNothing: true,
+		})
+
+		// THEN
+		require.NoError(t, ignoreErr)
+		assert.Equal(t, int64(7), id)
+		assert.Equal(t, int64(7), article.ID)
+	})
+
+	t.Run("should-select-then-delete-with-mysql", func(t *testing.T) {
+		// GIVEN
+		articles, mock := newMockArticles(t, sqlstore.MySQL)
+
+		mock.ExpectQuery("SELECT * FROM `articles` WHERE `status` = ? LIMIT 18446744073709551615 OFFSET 1").
+			WithArgs("draft").
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(2, "second", "draft", 1, 10, nil))
+		mock.ExpectExec("DELETE FROM `articles` WHERE `id` IN (?)").
+			WithArgs(int64(2)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		// WHEN
+		deleted, err := articles.DeleteReturning(ctx, query.Filter("Status", "draft"), query.Paginate(1, 0))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, []int64{2}, ids(deleted))
+	})
+}
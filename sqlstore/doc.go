@@ -0,0 +1,33 @@
+// Package sqlstore provides a database/sql implementation of the store interface, without ORM.
+//
+// The Store reads and writes the DTOs of the entities as the rows of a table. The columns of the DTO fields are
+// given by their db tags, defaulting to the snake case of the field names, see FieldToColMap. The primary key is
+// the field tagged `db:"id,pk"`, or the id column without such tag. A zero integer primary key is omitted from
+// the inserts for the database to generate it.
+//
+// The query params are rendered to SQL by a sqlbuilder.Builder in the Dialect of the TransactionScope of the
+// store: Postgres, MySQL or SQLite. Filters, OR, AND and Not groups, raw conditions, ordering, pagination,
+// selects, group by, having and locks are supported, the preload, cascade and unscoped params are ignored and the
+// others make the calls fail with ErrInvalidParam. Upsert renders ON CONFLICT clauses with PostgreSQL and SQLite,
+// and ON DUPLICATE KEY UPDATE with MySQL.
+//
+// The columns are scanned into the fields of the same column name, the other ones being discarded: nullable
+// columns need fields such as sql.NullString or pointers.
+//
+// The statements are executed in the transaction begun by the TransactionScope in their context, if any, or on
+// its database otherwise.
+//
+// Example:
+//
+//	type Article struct {
+//		ID       int64  `db:"id,pk"`
+//		Title    string `db:"title"`
+//		Status   string `db:"status"`
+//		AuthorID int64  `db:"author_id"`
+//	}
+//
+//	opScope := sqlstore.NewTransactionScope("writeTx", db, sqlstore.Postgres, nil)
+//	articleStore := sqlstore.NewSimple[*Article, int64](opScope, "articles")
+//
+//	active, err := articleStore.List(ctx, query.Filter("Status", "active"), query.OrderBy("Title", false))
+package sqlstore
@@ -0,0 +1,165 @@
+package sqlstore
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// mappings caches the mappings of the DTO types.
+var mappings sync.Map
+
+// column is a field of a DTO mapped to a column.
+type column struct {
+	field string
+	name  string
+	index []int
+	pk    bool
+}
+
+// mapping is the columns of the fields of a DTO type.
+type mapping struct {
+	columns []column
+	// byName indexes the columns by field and column name.
+	byName map[string]*column
+	// fieldToCol maps the field names to the column names, see FieldToColMap.
+	fieldToCol map[string]string
+	pk         *column
+}
+
+// FieldToColMap creates a map of the struct field names of the DTO to their column names.
+//
+// The column of a field is the name of its db tag, or the snake case of the field name without tag, e.g.
+// "author_id" for AuthorID. The fields tagged with db:"-" and the unexported ones are skipped, and the fields of
+// embedded structs are promoted.
+//
+// Example:
+//
+//	type Article struct {
+//		ID       int64  `db:"id,pk"`
+//		Title    string `db:"title"`
+//		AuthorID int64
+//	}
+//
+//	index := FieldToColMap(Article{})
+//	// map[AuthorID:author_id ID:id Title:title]
+func FieldToColMap(dto any) map[string]string {
+	fieldToCol := mappingOf(reflect.TypeOf(dto)).fieldToCol
+	index := make(map[string]string, len(fieldToCol))
+
+	for field, col := range fieldToCol {
+		index[field] = col
+	}
+
+	return index
+}
+
+// mappingOf returns the cached mapping of the DTO type, a struct or a pointer to a struct.
+func mappingOf(t reflect.Type) *mapping {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if cached, ok := mappings.Load(t); ok {
+		return cached.(*mapping)
+	}
+
+	m := &mapping{byName: map[string]*column{}, fieldToCol: map[string]string{}}
+
+	if t != nil && t.Kind() == reflect.Struct {
+		m.columns = columnsOf(t, nil)
+	}
+
+	for i := range m.columns {
+		col := &m.columns[i]
+		m.byName[col.field] = col
+		m.byName[col.name] = col
+		m.fieldToCol[col.field] = col.name
+
+		if col.pk && m.pk == nil {
+			m.pk = col
+		}
+	}
+
+	// the primary key defaults to the id column.
+	if m.pk == nil {
+		if col, ok := m.byName["id"]; ok {
+			col.pk = true
+			m.pk = col
+		}
+	}
+
+	cached, _ := mappings.LoadOrStore(t, m)
+
+	return cached.(*mapping)
+}
+
+func columnsOf(t reflect.Type, index []int) []column {
+	var columns []column
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fieldIndex := append(append([]int{}, index...), i)
+
+		tag, options, _ := strings.Cut(field.Tag.Get("db"), ",")
+		if tag == "-" {
+			continue
+		}
+
+		if field.Anonymous && tag == "" && field.Type.Kind() == reflect.Struct {
+			columns = append(columns, columnsOf(field.Type, fieldIndex)...)
+			continue
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if tag == "" {
+			tag = snakeCase(field.Name)
+		}
+
+		columns = append(columns, column{
+			field: field.Name,
+			name:  tag,
+			index: fieldIndex,
+			pk:    options == "pk",
+		})
+	}
+
+	return columns
+}
+
+// column returns the column of the field or column name, and whether the DTO has it.
+func (m *mapping) column(name string) (string, bool) {
+	if col, ok := m.byName[name]; ok {
+		return col.name, true
+	}
+
+	return name, false
+}
+
+// snakeCase converts a field name to snake case, keeping the initialisms together: AuthorID becomes author_id and
+// HTTPStatus http_status.
+func snakeCase(name string) string {
+	var (
+		b     strings.Builder
+		runes = []rune(name)
+	)
+
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) ||
+				i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1])) {
+				b.WriteByte('_')
+			}
+
+			r = unicode.ToLower(r)
+		}
+
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
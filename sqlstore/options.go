@@ -0,0 +1,42 @@
+package sqlstore
+
+import (
+	"github.com/infevocorp/goflexstore/converter"
+	"github.com/infevocorp/goflexstore/idgen"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// Option is a function that modifies the store.
+// It is used to set various configuration options for the Store at the time of its creation.
+type Option[Entity store.Entity[ID], DTO store.Entity[ID], ID comparable] func(*Store[Entity, DTO, ID])
+
+// WithConverter sets the converter used for transforming between entity and DTO types.
+func WithConverter[
+	Entity store.Entity[ID],
+	DTO store.Entity[ID],
+	ID comparable,
+](
+	converter converter.Converter[Entity, DTO, ID],
+) Option[Entity, DTO, ID] {
+	return func(s *Store[Entity, DTO, ID]) {
+		s.Converter = converter
+	}
+}
+
+// WithIDGenerator sets the generator of the IDs of the entities created with a zero ID by Create, CreateMany and
+// Upsert. Without generator, the zero integer IDs are generated by the database, e.g. an auto-increment column.
+//
+// Example:
+//
+//	sqlstore.WithIDGenerator[*model.Article, *dto.Article, string](idgen.UUIDv7[string])
+func WithIDGenerator[
+	Entity store.Entity[ID],
+	DTO store.Entity[ID],
+	ID comparable,
+](
+	generator idgen.Generator[ID],
+) Option[Entity, DTO, ID] {
+	return func(s *Store[Entity, DTO, ID]) {
+		s.IDGenerator = generator
+	}
+}
@@ -0,0 +1,246 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/infevocorp/goflexstore/opscope"
+)
+
+var errBeginTx = errors.New("failed to begin transaction")
+
+// Querier executes the statements of the stores, either on the database or in a transaction.
+// It is implemented by *sql.DB, *sql.Conn and *sql.Tx.
+type Querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+type (
+	// contextKey is the type of the context key of the scope values, the name of the scope.
+	contextKey string
+
+	// scopeValue holds the transaction and its nesting level in the context.
+	scopeValue struct {
+		tx         *sql.Tx
+		level      int
+		onCommit   []func(ctx context.Context)
+		onRollback []func(ctx context.Context)
+		finished   bool
+
+		// name and startedAt are reported by TxInfo.
+		name      string
+		startedAt time.Time
+	}
+)
+
+var (
+	_ opscope.Scope            = (*TransactionScope)(nil)
+	_ opscope.CommitNotifier   = (*TransactionScope)(nil)
+	_ opscope.RollbackNotifier = (*TransactionScope)(nil)
+	_ opscope.TxInspector      = (*scopeValue)(nil)
+)
+
+// NewTransactionScope creates a transaction scope beginning the transactions of the stores on db.
+//
+// Parameters:
+//   - name: The name of the scope, used as the key of its transactions in the contexts.
+//   - db: The database the statements are executed on.
+//   - dialect: The SQL dialect of the database, e.g. sqlstore.Postgres.
+//   - txOptions: The isolation level and read-only mode of the transactions, nil for the driver defaults.
+//
+// Example:
+//
+//	txScope := sqlstore.NewTransactionScope("writeTx", db, sqlstore.Postgres, &sql.TxOptions{
+//		Isolation: sql.LevelSerializable,
+//	})
+func NewTransactionScope(name string, db *sql.DB, dialect Dialect, txOptions *sql.TxOptions) *TransactionScope {
+	return &TransactionScope{
+		Name:      name,
+		DB:        db,
+		Dialect:   dialect,
+		TxOptions: txOptions,
+	}
+}
+
+// TransactionScope is the operation scope of the stores of a database: Begin begins a transaction in which the
+// stores execute their statements until End commits or rolls it back. Nested scopes share the transaction of the
+// outermost one.
+//
+// Fields:
+//   - Name: The name of the scope, used as the key of its transactions in the contexts.
+//   - DB: The database the statements are executed on.
+//   - Dialect: The SQL dialect of the database.
+//   - TxOptions: The options of the transactions.
+type TransactionScope struct {
+	Name      string
+	DB        *sql.DB
+	Dialect   Dialect
+	TxOptions *sql.TxOptions
+}
+
+// Begin begins a transaction, or increments the nesting level of the transaction of ctx.
+// The returned context reports the transaction to opscope.InTransaction and opscope.TxInfo.
+func (s *TransactionScope) Begin(ctx context.Context) (context.Context, error) {
+	if scopeVal := s.getScopeValue(ctx); scopeVal != nil {
+		scopeVal.level++
+		return ctx, nil
+	}
+
+	tx, err := s.DB.BeginTx(ctx, s.TxOptions)
+	if err != nil {
+		return ctx, errors.Join(errBeginTx, err)
+	}
+
+	scopeVal := &scopeValue{
+		tx:        tx,
+		level:     1,
+		name:      s.Name,
+		startedAt: time.Now(),
+	}
+
+	return opscope.WithTxInspector(s.setScopeValue(ctx, scopeVal), scopeVal), nil
+}
+
+// End commits the transaction of ctx, or rolls it back if err is not nil, once the outermost scope ends.
+// Nested scopes only decrement the nesting level. The callbacks registered by OnCommit are then called if the
+// transaction was committed, and those registered by OnRollback otherwise.
+func (s *TransactionScope) End(ctx context.Context, err error) error {
+	if errors.Is(err, errBeginTx) {
+		return nil
+	}
+
+	scopeVal := s.getScopeValue(ctx)
+	if scopeVal == nil {
+		return nil
+	}
+
+	if scopeVal.level > 1 {
+		scopeVal.level--
+		return nil
+	}
+
+	scopeVal.finished = true
+
+	// run the callbacks outside of the finished transaction so that
+	// any store operation they perform does not reuse it.
+	callbackCtx := s.setScopeValue(ctx, nil)
+
+	if err := scopeVal.end(err); err != nil {
+		for _, fn := range scopeVal.onRollback {
+			fn(callbackCtx)
+		}
+
+		return err
+	}
+
+	for _, fn := range scopeVal.onCommit {
+		fn(callbackCtx)
+	}
+
+	return nil
+}
+
+// EndWithRecover ends the scope like End, recovering from a panic to roll the transaction back.
+// The recovered panic and the error of End are joined to *errPtr.
+//
+// Example:
+//
+//	ctx, err = txScope.Begin(ctx)
+//	if err != nil {
+//		return err
+//	}
+//	defer txScope.EndWithRecover(ctx, &err)
+func (s *TransactionScope) EndWithRecover(ctx context.Context, errPtr *error) {
+	if errPtr == nil {
+		panic("err pointer cannot be nil")
+	}
+
+	err := *errPtr
+
+	if r := recover(); r != nil {
+		if ferr, ok := r.(error); ok {
+			err = errors.Join(err, ferr)
+		} else {
+			err = errors.Join(err, fmt.Errorf("panic: %v", r))
+		}
+
+		*errPtr = err
+	}
+
+	if err2 := s.End(ctx, err); err2 != nil {
+		*errPtr = errors.Join(err, err2)
+	}
+}
+
+// OnCommit registers fn to be called once the outermost transaction of ctx is committed, or immediately
+// when ctx has no transaction. The callbacks of a rolled back transaction are discarded.
+func (s *TransactionScope) OnCommit(ctx context.Context, fn func(ctx context.Context)) {
+	scopeVal := s.getScopeValue(ctx)
+	if scopeVal == nil {
+		fn(ctx)
+		return
+	}
+
+	scopeVal.onCommit = append(scopeVal.onCommit, fn)
+}
+
+// OnRollback registers fn to be called once the outermost transaction of ctx is rolled back or fails to commit.
+// The callbacks of a committed transaction are discarded, and so is fn when ctx has no transaction.
+func (s *TransactionScope) OnRollback(ctx context.Context, fn func(ctx context.Context)) {
+	if scopeVal := s.getScopeValue(ctx); scopeVal != nil {
+		scopeVal.onRollback = append(scopeVal.onRollback, fn)
+	}
+}
+
+// Tx returns the transaction of ctx, or the database when ctx has no transaction.
+func (s *TransactionScope) Tx(ctx context.Context) Querier {
+	if scopeVal := s.getScopeValue(ctx); scopeVal != nil {
+		return scopeVal.tx
+	}
+
+	return s.DB
+}
+
+func (s *TransactionScope) getScopeValue(ctx context.Context) *scopeValue {
+	// the value of a finished transaction is left in the contexts derived from its scope.
+	if val, ok := ctx.Value(contextKey(s.Name)).(*scopeValue); ok && val != nil && !val.finished {
+		return val
+	}
+
+	return nil
+}
+
+func (s *TransactionScope) setScopeValue(ctx context.Context, scopeVal *scopeValue) context.Context {
+	return context.WithValue(ctx, contextKey(s.Name), scopeVal)
+}
+
+// end commits the transaction, or rolls it back if err is not nil.
+func (v *scopeValue) end(err error) error {
+	if err != nil {
+		if err2 := v.tx.Rollback(); err2 != nil {
+			return errors.Join(err, fmt.Errorf("cannot rollback transaction: %w", err2))
+		}
+
+		return err
+	}
+
+	if err := v.tx.Commit(); err != nil {
+		return fmt.Errorf("cannot commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// TxInfo implements opscope.TxInspector.
+func (v *scopeValue) TxInfo() (opscope.Info, bool) {
+	if v.finished {
+		return opscope.Info{}, false
+	}
+
+	return opscope.Info{Name: v.name, Level: v.level, StartedAt: v.startedAt}, true
+}
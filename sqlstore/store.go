@@ -0,0 +1,895 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/infevocorp/goflexstore/converter"
+	"github.com/infevocorp/goflexstore/idgen"
+	"github.com/infevocorp/goflexstore/query"
+	"github.com/infevocorp/goflexstore/sqlbuilder"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// ErrMissingWhereClause is returned by the statements which would otherwise affect every row of the table, e.g.
+// Delete without filter.
+var ErrMissingWhereClause = errors.New("WHERE conditions required")
+
+// New initializes a new Store reading and writing the entities as the rows of the table.
+//
+// Entity and DTO are types that must implement the store.Entity interface.
+// ID is the type of the identifier for the entities.
+func New[Entity store.Entity[ID], DTO store.Entity[ID], ID comparable](
+	opScope *TransactionScope,
+	table string,
+	options ...Option[Entity, DTO, ID],
+) *Store[Entity, DTO, ID] {
+	s := &Store[Entity, DTO, ID]{
+		OpScope: opScope,
+		Table:   table,
+	}
+
+	for _, option := range options {
+		option(s)
+	}
+
+	if s.Converter == nil {
+		if converter.SameType[Entity, DTO]() {
+			s.Converter = converter.NewIdentity[Entity, DTO, ID]()
+		} else {
+			s.Converter = converter.NewReflect[Entity, DTO, ID](nil)
+		}
+	}
+
+	if s.IDGenerator == nil && idgen.IsUUID[ID]() {
+		s.IDGenerator = idgen.UUIDv7[ID]
+	}
+
+	return s
+}
+
+// NewSimple initializes a new Store for entities which are also the DTOs, mapped directly to the table.
+//
+// Example:
+//
+//	articleStore := sqlstore.NewSimple[*model.Article, int64](opScope, "articles")
+func NewSimple[T store.Entity[ID], ID comparable](
+	opScope *TransactionScope,
+	table string,
+	options ...Option[T, T, ID],
+) *Store[T, T, ID] {
+	return New[T, T, ID](opScope, table, options...)
+}
+
+// Store represents a storage mechanism using database/sql, without ORM.
+// It supports CRUD operations and is designed to be generic for any Entity and DTO types.
+//
+// The columns of the DTO fields are given by their db tags, see FieldToColMap, and the statements are rendered in
+// the Dialect of the OpScope.
+//
+// Entity: The domain model type.
+// DTO: The data transfer object type, a struct or a pointer to a struct representing the rows of the table.
+// ID: The type of the unique identifier for the entity.
+type Store[Entity store.Entity[ID], DTO store.Entity[ID], ID comparable] struct {
+	OpScope     *TransactionScope
+	Table       string
+	Converter   converter.Converter[Entity, DTO, ID]
+	IDGenerator idgen.Generator[ID]
+}
+
+// Get retrieves the first row matching the params, or store.ErrNotFound.
+func (s *Store[Entity, DTO, ID]) Get(ctx context.Context, params ...query.Param) (Entity, error) {
+	c, err := s.builder().Build(query.NewParams(params...))
+	if err != nil {
+		return *new(Entity), err
+	}
+
+	c.Limit = 1
+
+	entities, err := s.list(ctx, c)
+	if err != nil {
+		return *new(Entity), err
+	}
+
+	if len(entities) == 0 {
+		return *new(Entity), store.ErrNotFound
+	}
+
+	return entities[0], nil
+}
+
+// List retrieves the rows matching the params, ordered, grouped and paginated accordingly.
+func (s *Store[Entity, DTO, ID]) List(ctx context.Context, params ...query.Param) ([]Entity, error) {
+	c, err := s.builder().Build(query.NewParams(params...))
+	if err != nil {
+		return nil, err
+	}
+
+	return s.list(ctx, c)
+}
+
+// ListWithCount retrieves the rows matching the params, like List, together with the number of matching rows,
+// counted without the Paginate params. The list is not queried when no row matches.
+func (s *Store[Entity, DTO, ID]) ListWithCount(ctx context.Context, params ...query.Param) ([]Entity, int64, error) {
+	c, err := s.builder().Build(query.NewParams(params...))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	count, err := s.count(ctx, c)
+	if err != nil || count == 0 {
+		return nil, count, err
+	}
+
+	entities, err := s.list(ctx, c)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return entities, count, nil
+}
+
+// Count returns the number of rows matching the filters of the params, or the number of groups with a GroupBy
+// param.
+func (s *Store[Entity, DTO, ID]) Count(ctx context.Context, params ...query.Param) (int64, error) {
+	c, err := s.builder().Build(query.NewParams(params...))
+	if err != nil {
+		return 0, err
+	}
+
+	return s.count(ctx, c)
+}
+
+// Aggregate computes the aggregate function over the column of the field of the rows matching the params,
+// see AggregateMany.
+func (s *Store[Entity, DTO, ID]) Aggregate(
+	ctx context.Context,
+	agg store.Aggregation,
+	field string,
+	params ...query.Param,
+) (float64, error) {
+	results, err := s.AggregateMany(ctx, []store.Aggregate{{Aggregation: agg, Field: field}}, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return results[0], nil
+}
+
+// AggregateMany computes the aggregates of the rows matching the params in a single
+// SELECT SUM(col) AS a0, MAX(col) AS a1... query. The OrderBy, Paginate and Select params are ignored, and
+// a NULL aggregate, e.g. the sum of no row, is returned as 0. An empty field counts the rows.
+func (s *Store[Entity, DTO, ID]) AggregateMany(
+	ctx context.Context,
+	aggs []store.Aggregate,
+	params ...query.Param,
+) ([]float64, error) {
+	if len(aggs) == 0 {
+		return nil, fmt.Errorf("%w: no aggregate", ErrInvalidParam)
+	}
+
+	b := s.builder()
+
+	c, err := b.Build(query.NewParams(params...))
+	if err != nil {
+		return nil, err
+	}
+
+	selects := make([]string, len(aggs))
+
+	for i, agg := range aggs {
+		if !agg.Aggregation.Valid() {
+			return nil, fmt.Errorf("%w: unsupported aggregation %s", ErrInvalidParam, agg.Aggregation)
+		}
+
+		col := "*"
+		if agg.Field != "" {
+			col = b.Column(agg.Field)
+		}
+
+		selects[i] = fmt.Sprintf("%s(%s) AS a%d", agg.Aggregation, col, i)
+	}
+
+	c.OrderBy, c.Offset, c.Limit = nil, 0, 0
+
+	values := make([]sql.NullFloat64, len(aggs))
+	dest := make([]any, len(aggs))
+
+	for i := range values {
+		dest[i] = &values[i]
+	}
+
+	if err := s.queryRow(ctx, b, selects, c).Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	results := make([]float64, len(aggs))
+	for i, value := range values {
+		results[i] = value.Float64
+	}
+
+	return results, nil
+}
+
+// Exists reports whether a row matches the filters of the params.
+func (s *Store[Entity, DTO, ID]) Exists(ctx context.Context, params ...query.Param) (bool, error) {
+	b := s.builder()
+
+	c, err := b.Build(query.NewParams(params...))
+	if err != nil {
+		return false, err
+	}
+
+	c.OrderBy, c.Offset, c.Limit = nil, 0, 1
+
+	var one int
+
+	err = s.queryRow(ctx, b, []string{"1"}, c).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// Create inserts the entity and returns its ID. A zero ID is generated by the IDGenerator of the store or, for
+// integer IDs, by the database, in which case it is set on the DTO.
+func (s *Store[Entity, DTO, ID]) Create(ctx context.Context, entity Entity) (ID, error) {
+	return s.insert(ctx, s.Converter.ToDTO(entity), "", "")
+}
+
+// CreateMany inserts the entities in a single statement. The IDs generated by the database are not set on the
+// DTOs.
+func (s *Store[Entity, DTO, ID]) CreateMany(ctx context.Context, entities []Entity) error {
+	if len(entities) == 0 {
+		return nil
+	}
+
+	dtos := converter.ToMany(entities, s.Converter.ToDTO)
+	for i := range dtos {
+		if err := s.generateID(ctx, &dtos[i]); err != nil {
+			return err
+		}
+	}
+
+	var (
+		m       = mappingOf(reflect.TypeOf(dtos[0]))
+		omitPK  = true
+		columns []column
+	)
+
+	for _, dto := range dtos {
+		omitPK = omitPK && autoIncrement(m, dto)
+	}
+
+	for _, col := range m.columns {
+		if !col.pk || !omitPK {
+			columns = append(columns, col)
+		}
+	}
+
+	var (
+		rows = make([]string, len(dtos))
+		args = make([]any, 0, len(dtos)*len(columns))
+	)
+
+	for i, dto := range dtos {
+		v := structOf(dto)
+		for _, col := range columns {
+			args = append(args, v.FieldByIndex(col.index).Interface())
+		}
+
+		rows[i] = sqlbuilder.Placeholders(len(columns))
+	}
+
+	stmt := s.insertSQL("", columns) + strings.Join(rows, ", ")
+
+	_, err := s.OpScope.Tx(ctx).ExecContext(ctx, s.dialect().Rebind(stmt), args...)
+
+	return err
+}
+
+// Upsert inserts the entity, or updates the row conflicting on the columns of onConflict.Columns, the primary key
+// by default, or on the constraint onConflict.OnConstraint with PostgreSQL.
+//
+// PostgreSQL and SQLite render an ON CONFLICT clause and MySQL an ON DUPLICATE KEY UPDATE clause, or
+// INSERT IGNORE with onConflict.DoNothing. The rows conflicting on other unique keys are updated too with MySQL,
+// which ignores onConflict.Columns and onConflict.UpdateWhere. Without update, the conflicting row is left unchanged.
+func (s *Store[Entity, DTO, ID]) Upsert(ctx context.Context, entity Entity, onConflict store.OnConflict) (ID, error) {
+	dto := s.Converter.ToDTO(entity)
+	m := mappingOf(reflect.TypeOf(dto))
+
+	updates, args, err := s.upsertUpdates(m, onConflict)
+	if err != nil {
+		return *new(ID), err
+	}
+
+	if s.dialect().Name() == MySQL.Name() {
+		if onConflict.DoNothing {
+			return s.insert(ctx, dto, "INSERT IGNORE", "")
+		}
+
+		if len(updates) == 0 && m.pk != nil {
+			pk := s.dialect().Quote(m.pk.name)
+			updates = []string{pk + " = " + pk}
+		}
+
+		return s.insert(ctx, dto, "", " ON DUPLICATE KEY UPDATE "+strings.Join(updates, ", "), args...)
+	}
+
+	var target string
+
+	switch {
+	case onConflict.OnConstraint != "" && s.dialect().Name() == Postgres.Name():
+		target = " ON CONSTRAINT " + s.dialect().Quote(onConflict.OnConstraint)
+	case len(onConflict.Columns) > 0:
+		b := s.builder()
+
+		columns := make([]string, len(onConflict.Columns))
+		for i, name := range onConflict.Columns {
+			columns[i] = b.Column(name)
+		}
+
+		target = " (" + strings.Join(columns, ", ") + ")"
+	case m.pk != nil:
+		target = " (" + s.dialect().Quote(m.pk.name) + ")"
+	}
+
+	if onConflict.DoNothing || len(updates) == 0 {
+		return s.insert(ctx, dto, "", " ON CONFLICT"+target+" DO NOTHING")
+	}
+
+	suffix := " ON CONFLICT" + target + " DO UPDATE SET " + strings.Join(updates, ", ")
+
+	if len(onConflict.UpdateWhere) > 0 {
+		conds, condArgs, err := s.updateWhere(onConflict.UpdateWhere)
+		if err != nil {
+			return *new(ID), err
+		}
+
+		suffix += " WHERE " + conds
+		args = append(args, condArgs...)
+	}
+
+	return s.insert(ctx, dto, "", suffix, args...)
+}
+
+// upsertUpdates returns the assignments of the conflicting row: the columns of onConflict.UpdateColumns, or every
+// column but the conflicting ones with onConflict.UpdateAll, set to their value in the proposed row, then the
+// values of onConflict.Updates in the order of their names.
+func (s *Store[Entity, DTO, ID]) upsertUpdates(m *mapping, onConflict store.OnConflict) ([]string, []any, error) {
+	var (
+		b       = s.builder()
+		columns []string
+		updates []string
+		args    []any
+	)
+
+	if onConflict.DoNothing {
+		return nil, nil, nil
+	}
+
+	switch {
+	case onConflict.UpdateAll:
+		conflicting := map[string]bool{}
+		for _, name := range onConflict.Columns {
+			col, _ := m.column(name)
+			conflicting[col] = true
+		}
+
+		for _, col := range m.columns {
+			if !col.pk && !conflicting[col.name] {
+				columns = append(columns, col.name)
+			}
+		}
+	default:
+		for _, name := range onConflict.UpdateColumns {
+			col, _ := m.column(name)
+			columns = append(columns, col)
+		}
+	}
+
+	for _, col := range columns {
+		updates = append(updates, s.dialect().Quote(col)+" = "+s.excluded(col))
+	}
+
+	names := make([]string, 0, len(onConflict.Updates))
+	for name := range onConflict.Updates {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		if excluded, ok := onConflict.Updates[name].(store.ExcludedColumn); ok {
+			col, _ := m.column(string(excluded))
+			updates = append(updates, b.Column(name)+" = "+s.excluded(col))
+
+			continue
+		}
+
+		updates = append(updates, b.Column(name)+" = ?")
+		args = append(args, onConflict.Updates[name])
+	}
+
+	return updates, args, nil
+}
+
+// updateWhere renders the conditions of the conflicting row to be updated by an upsert, whose columns are
+// qualified by the table name to tell them from the columns of the proposed row.
+func (s *Store[Entity, DTO, ID]) updateWhere(filters []query.FilterParam) (string, []any, error) {
+	var (
+		b     = s.builder()
+		conds = make([]string, len(filters))
+		args  []any
+	)
+
+	b.Table = s.Table
+
+	for i, filter := range filters {
+		excluded, ok := filter.Value.(store.ExcludedColumn)
+		if !ok {
+			cond, condArgs, err := b.Filter(filter)
+			if err != nil {
+				return "", nil, err
+			}
+
+			conds[i] = cond
+			args = append(args, condArgs...)
+
+			continue
+		}
+
+		op, ok := sqlbuilder.Comparison(filter.Operator)
+		if !ok {
+			return "", nil, fmt.Errorf("%w: operator %s cannot be used with an excluded column", ErrInvalidParam,
+				filter.Operator)
+		}
+
+		col, _ := s.mapping().column(string(excluded))
+		conds[i] = b.Column(filter.Name) + " " + op + " " + s.excluded(col)
+	}
+
+	return strings.Join(conds, " AND "), args, nil
+}
+
+// excluded returns the reference to the column of the row proposed for insertion by an upsert.
+func (s *Store[Entity, DTO, ID]) excluded(col string) string {
+	if s.dialect().Name() == MySQL.Name() {
+		return "VALUES(" + s.dialect().Quote(col) + ")"
+	}
+
+	return "EXCLUDED." + s.dialect().Quote(col)
+}
+
+// Update replaces the columns of the rows matching the filters of the params with the fields of the entity, its
+// primary key excepted. Without filters, the row having the ID of the entity is updated.
+func (s *Store[Entity, DTO, ID]) Update(ctx context.Context, entity Entity, params ...query.Param) error {
+	return s.update(ctx, s.Converter.ToDTO(entity), false, params)
+}
+
+// PartialUpdate sets the non-zero fields of the entity on the rows matching the filters of the params.
+// Without filters, the row having the ID of the entity is updated.
+func (s *Store[Entity, DTO, ID]) PartialUpdate(ctx context.Context, entity Entity, params ...query.Param) error {
+	return s.update(ctx, s.Converter.ToDTO(entity), true, params)
+}
+
+// update sets the fields of the DTO, or its non-zero ones, on the rows matching the filters of the params, or on
+// the row having the ID of the DTO without filters.
+func (s *Store[Entity, DTO, ID]) update(ctx context.Context, dto DTO, nonZero bool, params []query.Param) error {
+	b := s.builder()
+
+	c, err := b.Build(query.NewParams(params...))
+	if err != nil {
+		return err
+	}
+
+	var (
+		m    = s.mapping()
+		v    = structOf(dto)
+		sets []string
+		args []any
+	)
+
+	for _, col := range m.columns {
+		field := v.FieldByIndex(col.index)
+		if col.pk || nonZero && field.IsZero() {
+			continue
+		}
+
+		sets = append(sets, s.dialect().Quote(col.name)+" = ?")
+		args = append(args, field.Interface())
+	}
+
+	if len(sets) == 0 {
+		return nil
+	}
+
+	if len(c.Where) == 0 {
+		if m.pk == nil {
+			return ErrMissingWhereClause
+		}
+
+		c.Where = []string{s.dialect().Quote(m.pk.name) + " = ?"}
+		c.WhereArgs = []any{dto.GetID()}
+	}
+
+	stmt := "UPDATE " + s.dialect().Quote(s.Table) + " SET " + strings.Join(sets, ", ") + sqlbuilder.Where(c.Where)
+
+	_, err = s.OpScope.Tx(ctx).ExecContext(ctx, s.dialect().Rebind(stmt), append(args, c.WhereArgs...)...)
+
+	return err
+}
+
+// UpdateMany sets the fields of updates, whose names are mapped to columns like the filters, on the rows matching
+// the filters of the params, and returns the number of rows affected. It returns ErrMissingWhereClause without
+// filter.
+func (s *Store[Entity, DTO, ID]) UpdateMany(
+	ctx context.Context,
+	updates map[string]any,
+	params ...query.Param,
+) (int64, error) {
+	b := s.builder()
+
+	c, err := b.Build(query.NewParams(params...))
+	if err != nil {
+		return 0, err
+	}
+
+	if len(c.Where) == 0 {
+		return 0, ErrMissingWhereClause
+	}
+
+	if len(updates) == 0 {
+		return 0, nil
+	}
+
+	names := make([]string, 0, len(updates))
+	for name := range updates {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var (
+		sets = make([]string, len(names))
+		args = make([]any, 0, len(names)+len(c.WhereArgs))
+	)
+
+	for i, name := range names {
+		sets[i] = b.Column(name) + " = ?"
+		args = append(args, updates[name])
+	}
+
+	stmt := "UPDATE " + s.dialect().Quote(s.Table) + " SET " + strings.Join(sets, ", ") + sqlbuilder.Where(c.Where)
+
+	result, err := s.OpScope.Tx(ctx).ExecContext(ctx, s.dialect().Rebind(stmt), append(args, c.WhereArgs...)...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// Delete deletes the rows matching the filters of the params. It returns ErrMissingWhereClause without filter.
+func (s *Store[Entity, DTO, ID]) Delete(ctx context.Context, params ...query.Param) error {
+	c, err := s.builder().Build(query.NewParams(params...))
+	if err != nil {
+		return err
+	}
+
+	if len(c.Where) == 0 {
+		return ErrMissingWhereClause
+	}
+
+	stmt := "DELETE FROM " + s.dialect().Quote(s.Table) + sqlbuilder.Where(c.Where)
+
+	_, err = s.OpScope.Tx(ctx).ExecContext(ctx, s.dialect().Rebind(stmt), c.WhereArgs...)
+
+	return err
+}
+
+// DeleteReturning deletes the rows matching the filters of the params and returns them. It returns
+// ErrMissingWhereClause without filter.
+//
+// PostgreSQL and SQLite delete the rows with a RETURNING clause. With MySQL, the rows are selected, then deleted
+// by primary key: run it in a transaction for the rows changed in between not to be deleted without being returned.
+func (s *Store[Entity, DTO, ID]) DeleteReturning(ctx context.Context, params ...query.Param) ([]Entity, error) {
+	b := s.builder()
+
+	c, err := b.Build(query.NewParams(params...))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(c.Where) == 0 {
+		return nil, ErrMissingWhereClause
+	}
+
+	if s.dialect().Name() != MySQL.Name() {
+		stmt := "DELETE FROM " + s.dialect().Quote(s.Table) + sqlbuilder.Where(c.Where) + " RETURNING *"
+
+		return s.queryRows(ctx, stmt, c.WhereArgs)
+	}
+
+	pk := s.mapping().pk
+	if pk == nil {
+		return nil, fmt.Errorf("%w: the DTO has no primary key", ErrInvalidParam)
+	}
+
+	c.Select, c.GroupBy, c.Having, c.HavingArgs = nil, nil, nil, nil
+
+	entities, err := s.list(ctx, c)
+	if err != nil || len(entities) == 0 {
+		return nil, err
+	}
+
+	ids := make([]any, len(entities))
+	for i, entity := range entities {
+		ids[i] = s.Converter.ToDTO(entity).GetID()
+	}
+
+	stmt := "DELETE FROM " + s.dialect().Quote(s.Table) +
+		" WHERE " + s.dialect().Quote(pk.name) + " IN " + sqlbuilder.Placeholders(len(ids))
+
+	if _, err := s.OpScope.Tx(ctx).ExecContext(ctx, s.dialect().Rebind(stmt), ids...); err != nil {
+		return nil, err
+	}
+
+	return entities, nil
+}
+
+// Ping checks that the database is reachable.
+func (s *Store[Entity, DTO, ID]) Ping(ctx context.Context) error {
+	return s.OpScope.DB.PingContext(ctx)
+}
+
+// insert inserts the DTO with the verb, INSERT by default, and the suffix of the statement, e.g. an ON CONFLICT
+// clause with the args of its placeholders, and returns its ID.
+func (s *Store[Entity, DTO, ID]) insert(ctx context.Context, dto DTO, verb, suffix string, args ...any) (ID, error) {
+	if err := s.generateID(ctx, &dto); err != nil {
+		return *new(ID), err
+	}
+
+	var (
+		m       = mappingOf(reflect.TypeOf(dto))
+		v       = structOf(dto)
+		omitPK  = autoIncrement(m, dto)
+		columns []column
+		values  []any
+	)
+
+	for _, col := range m.columns {
+		if col.pk && omitPK {
+			continue
+		}
+
+		columns = append(columns, col)
+		values = append(values, v.FieldByIndex(col.index).Interface())
+	}
+
+	stmt := s.insertSQL(verb, columns) + sqlbuilder.Placeholders(len(columns)) + suffix
+	values = append(values, args...)
+
+	if !omitPK {
+		_, err := s.OpScope.Tx(ctx).ExecContext(ctx, s.dialect().Rebind(stmt), values...)
+		return dto.GetID(), err
+	}
+
+	var id ID
+
+	if s.dialect().Name() == MySQL.Name() {
+		result, err := s.OpScope.Tx(ctx).ExecContext(ctx, s.dialect().Rebind(stmt), values...)
+		if err != nil {
+			return id, err
+		}
+
+		lastID, err := result.LastInsertId()
+		if err != nil {
+			return id, err
+		}
+
+		reflect.ValueOf(&id).Elem().Set(reflect.ValueOf(lastID).Convert(reflect.TypeOf(id)))
+	} else {
+		stmt += " RETURNING " + s.dialect().Quote(m.pk.name)
+
+		// a conflicting row left unchanged is not returned.
+		err := s.OpScope.Tx(ctx).QueryRowContext(ctx, s.dialect().Rebind(stmt), values...).Scan(&id)
+		if errors.Is(err, sql.ErrNoRows) {
+			return id, nil
+		}
+
+		if err != nil {
+			return id, err
+		}
+	}
+
+	if reflect.TypeOf(dto).Kind() == reflect.Pointer {
+		pk := v.FieldByIndex(m.pk.index)
+		pk.Set(reflect.ValueOf(id).Convert(pk.Type()))
+	}
+
+	return id, nil
+}
+
+// insertSQL renders an INSERT statement of the columns up to VALUES.
+func (s *Store[Entity, DTO, ID]) insertSQL(verb string, columns []column) string {
+	if verb == "" {
+		verb = "INSERT"
+	}
+
+	names := make([]string, len(columns))
+	for i, col := range columns {
+		names[i] = s.dialect().Quote(col.name)
+	}
+
+	return verb + " INTO " + s.dialect().Quote(s.Table) + " (" + strings.Join(names, ", ") + ") VALUES "
+}
+
+// list returns the entities of the rows of the SELECT statement of the clauses.
+func (s *Store[Entity, DTO, ID]) list(ctx context.Context, c sqlbuilder.Clauses) ([]Entity, error) {
+	selects := c.Select
+	if len(selects) == 0 {
+		selects = []string{"*"}
+	}
+
+	stmt, args := s.builder().Select(s.Table, selects, c)
+
+	return s.queryRows(ctx, stmt, args)
+}
+
+// count returns the number of rows, or groups, of the SELECT statement of the clauses, without pagination.
+func (s *Store[Entity, DTO, ID]) count(ctx context.Context, c sqlbuilder.Clauses) (int64, error) {
+	b := s.builder()
+
+	c.OrderBy, c.Offset, c.Limit, c.Lock = nil, 0, 0, false
+
+	var (
+		count int64
+		stmt  string
+		args  []any
+	)
+
+	if len(c.GroupBy) == 0 {
+		stmt, args = b.Select(s.Table, []string{"COUNT(*)"}, c)
+	} else {
+		stmt, args = b.Select(s.Table, c.GroupBy, c)
+		stmt = "SELECT COUNT(*) FROM (" + stmt + ") AS " + s.dialect().Quote("groups")
+	}
+
+	err := s.OpScope.Tx(ctx).QueryRowContext(ctx, s.dialect().Rebind(stmt), args...).Scan(&count)
+
+	return count, err
+}
+
+// queryRow queries the single row of the SELECT statement of the columns and clauses.
+func (s *Store[Entity, DTO, ID]) queryRow(
+	ctx context.Context,
+	b *sqlbuilder.Builder,
+	columns []string,
+	c sqlbuilder.Clauses,
+) *sql.Row {
+	stmt, args := b.Select(s.Table, columns, c)
+
+	return s.OpScope.Tx(ctx).QueryRowContext(ctx, s.dialect().Rebind(stmt), args...)
+}
+
+// queryRows returns the entities of the rows of the statement, whose columns are scanned into the fields of the
+// DTOs mapped to them. The columns without field are discarded.
+func (s *Store[Entity, DTO, ID]) queryRows(ctx context.Context, stmt string, args []any) ([]Entity, error) {
+	rows, err := s.OpScope.Tx(ctx).QueryContext(ctx, s.dialect().Rebind(stmt), args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	names, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		m        = mappingOf(reflect.TypeOf(*new(DTO)))
+		entities []Entity
+	)
+
+	for rows.Next() {
+		dto, v := newDTO[DTO]()
+		dest := make([]any, len(names))
+
+		for i, name := range names {
+			if col, ok := m.byName[name]; ok && col.name == name {
+				dest[i] = v.FieldByIndex(col.index).Addr().Interface()
+			} else {
+				dest[i] = new(any)
+			}
+		}
+
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+
+		entities = append(entities, s.Converter.ToEntity(*dto))
+	}
+
+	return entities, rows.Err()
+}
+
+// generateID sets an ID returned by the IDGenerator of the store on the DTO whose ID is zero.
+func (s *Store[Entity, DTO, ID]) generateID(ctx context.Context, dto *DTO) error {
+	if s.IDGenerator == nil || (*dto).GetID() != *new(ID) {
+		return nil
+	}
+
+	m := mappingOf(reflect.TypeOf(*dto))
+	if m.pk == nil {
+		return fmt.Errorf("%w: the DTO has no primary key", ErrInvalidParam)
+	}
+
+	id, err := s.IDGenerator(ctx)
+	if err != nil {
+		return err
+	}
+
+	// the generated ID is set on a copy of the DTOs which are not pointers.
+	v := reflect.ValueOf(dto).Elem()
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+
+	v.FieldByIndex(m.pk.index).Set(reflect.ValueOf(id))
+
+	return nil
+}
+
+func (s *Store[Entity, DTO, ID]) builder() *sqlbuilder.Builder {
+	return sqlbuilder.NewBuilder(s.dialect(), sqlbuilder.WithFieldToColMap(s.mapping().fieldToCol))
+}
+
+func (s *Store[Entity, DTO, ID]) mapping() *mapping {
+	return mappingOf(reflect.TypeOf(*new(DTO)))
+}
+
+func (s *Store[Entity, DTO, ID]) dialect() Dialect {
+	return s.OpScope.Dialect
+}
+
+// autoIncrement reports whether the primary key of the DTO is a zero integer, to be generated by the database.
+func autoIncrement(m *mapping, dto any) bool {
+	if m.pk == nil {
+		return false
+	}
+
+	pk := structOf(dto).FieldByIndex(m.pk.index)
+
+	switch pk.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return pk.IsZero()
+	default:
+		return false
+	}
+}
+
+// structOf returns the struct value of the DTO, a struct or a pointer to a struct.
+func structOf(dto any) reflect.Value {
+	return reflect.Indirect(reflect.ValueOf(dto))
+}
+
+// newDTO returns a pointer to a new DTO, allocating the struct of pointer DTOs, and its addressable struct value.
+func newDTO[DTO any]() (*DTO, reflect.Value) {
+	dto := new(DTO)
+
+	v := reflect.ValueOf(dto).Elem()
+	if v.Kind() == reflect.Pointer {
+		v.Set(reflect.New(v.Type().Elem()))
+	}
+
+	return dto, reflect.Indirect(v)
+}
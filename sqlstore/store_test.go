@@ -0,0 +1,355 @@
+package sqlstore_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infevocorp/goflexstore/filters"
+	"github.com/infevocorp/goflexstore/opscope"
+	"github.com/infevocorp/goflexstore/query"
+	"github.com/infevocorp/goflexstore/sqlstore"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+type Article struct {
+	ID       int64          `db:"id,pk"`
+	Title    string         `db:"title"`
+	Status   string         `db:"status"`
+	AuthorID int64          // author_id
+	Views    int            `db:"views"`
+	Summary  sql.NullString `db:"summary"`
+}
+
+func (a *Article) GetID() int64 {
+	return a.ID
+}
+
+var _ store.Store[*Article, int64] = (*sqlstore.Store[*Article, *Article, int64])(nil)
+
+// newArticles opens an in-memory SQLite database with the articles table and three articles.
+func newArticles(t *testing.T) (*sqlstore.Store[*Article, *Article, int64], *sqlstore.TransactionScope) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+
+	// Every connection to ":memory:" opens a distinct database.
+	db.SetMaxOpenConns(1)
+
+	t.Cleanup(func() { _ = db.Close() })
+
+	_, err = db.Exec(`CREATE TABLE articles (
+		id INTEGER PRIMARY KEY,
+		title TEXT NOT NULL UNIQUE,
+		status TEXT NOT NULL,
+		author_id INTEGER NOT NULL,
+		views INTEGER NOT NULL,
+		summary TEXT
+	)`)
+	require.NoError(t, err)
+
+	opScope := sqlstore.NewTransactionScope("tx", db, sqlstore.SQLite, nil)
+	articles := sqlstore.NewSimple[*Article, int64](opScope, "articles")
+
+	require.NoError(t, articles.CreateMany(context.Background(), []*Article{
+		{Title: "first", Status: "draft", AuthorID: 1, Views: 10},
+		{Title: "second", Status: "active", AuthorID: 1, Views: 30},
+		{Title: "third", Status: "active", AuthorID: 2, Views: 20},
+	}))
+
+	return articles, opScope
+}
+
+func ids(articles []*Article) []int64 {
+	ids := make([]int64, len(articles))
+	for i, article := range articles {
+		ids[i] = article.ID
+	}
+
+	return ids
+}
+
+func Test_Store_Read(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should-filter-order-and-paginate", func(t *testing.T) {
+		// GIVEN
+		articles, _ := newArticles(t)
+
+		// WHEN
+		list, err := articles.List(ctx,
+			query.OR(query.Filter("Status", "active"), query.Filter("views", 10)),
+			query.OrderBy("Views", true),
+			query.Paginate(1, 2),
+		)
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, []int64{3, 1}, ids(list))
+	})
+
+	t.Run("should-evaluate-groups-not-and-collections", func(t *testing.T) {
+		// GIVEN
+		articles, _ := newArticles(t)
+
+		// WHEN
+		list, err := articles.List(ctx,
+			query.OR(
+				query.AND(query.Filter("AuthorID", 1), query.Filter("Status", "draft")),
+				query.Not(query.Filter("ID", []int64{1, 2})),
+			),
+			query.Filter("Summary", nil),
+			query.Filter("Title", "%IR%").WithOP(query.ILIKE),
+		)
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, []int64{1, 3}, ids(list))
+	})
+
+	t.Run("should-get-article", func(t *testing.T) {
+		// GIVEN
+		articles, _ := newArticles(t)
+
+		// WHEN
+		article, err := articles.Get(ctx, filters.IDs[int64](2))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, &Article{ID: 2, Title: "second", Status: "active", AuthorID: 1, Views: 30}, article)
+	})
+
+	t.Run("should-return-not-found", func(t *testing.T) {
+		// GIVEN
+		articles, _ := newArticles(t)
+
+		// WHEN
+		_, err := articles.Get(ctx, filters.IDs[int64](4))
+
+		// THEN
+		assert.ErrorIs(t, err, store.ErrNotFound)
+	})
+
+	t.Run("should-select-columns", func(t *testing.T) {
+		// GIVEN
+		articles, _ := newArticles(t)
+
+		// WHEN
+		article, err := articles.Get(ctx, filters.IDs[int64](2), query.Select("Title", "views"))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, &Article{Title: "second", Views: 30}, article)
+	})
+
+	t.Run("should-count-and-group", func(t *testing.T) {
+		// GIVEN
+		articles, _ := newArticles(t)
+
+		// WHEN
+		list, total, err := articles.ListWithCount(ctx, query.Filter("Status", "active"), query.Paginate(1, 1))
+		groups, groupsErr := articles.Count(ctx, query.GroupBy("AuthorID"))
+
+		// THEN
+		require.NoError(t, err)
+		require.NoError(t, groupsErr)
+		assert.Equal(t, []int64{3}, ids(list))
+		assert.Equal(t, int64(2), total)
+		assert.Equal(t, int64(2), groups)
+	})
+
+	t.Run("should-aggregate-and-check-existence", func(t *testing.T) {
+		// GIVEN
+		articles, _ := newArticles(t)
+
+		// WHEN
+		results, err := articles.AggregateMany(ctx, []store.Aggregate{
+			{Aggregation: store.Sum, Field: "Views"},
+			{Aggregation: store.CountOf},
+		}, query.Filter("Status", "active"))
+		none, noneErr := articles.Aggregate(ctx, store.Max, "views", query.Filter("Status", "archived"))
+		exists, existsErr := articles.Exists(ctx, query.Filter("AuthorID", 2))
+
+		// THEN
+		require.NoError(t, err)
+		require.NoError(t, noneErr)
+		require.NoError(t, existsErr)
+		assert.Equal(t, []float64{50, 2}, results)
+		assert.Zero(t, none)
+		assert.True(t, exists)
+	})
+
+	t.Run("should-return-error-of-unsupported-param", func(t *testing.T) {
+		// GIVEN
+		articles, _ := newArticles(t)
+
+		// WHEN
+		_, err := articles.List(ctx, query.Filter("Status", []string{}))
+
+		// THEN
+		assert.ErrorIs(t, err, sqlstore.ErrInvalidParam)
+	})
+}
+
+func Test_Store_Write(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should-create-update-and-delete", func(t *testing.T) {
+		// GIVEN
+		articles, _ := newArticles(t)
+		article := &Article{Title: "fourth", Status: "draft", AuthorID: 2}
+
+		// WHEN
+		id, err := articles.Create(ctx, article)
+		require.NoError(t, err)
+
+		article.Views = 5
+		require.NoError(t, articles.Update(ctx, article))
+		require.NoError(t, articles.PartialUpdate(ctx, &Article{Status: "active"}, query.Filter("AuthorID", 2)))
+
+		updated, updateErr := articles.UpdateMany(ctx, map[string]any{"Views": 0}, query.Filter("Status", "active"))
+		deleted, deleteErr := articles.DeleteReturning(ctx, query.Filter("Views", 0))
+
+		// THEN
+		require.NoError(t, updateErr)
+		require.NoError(t, deleteErr)
+		assert.Equal(t, int64(4), id)
+		assert.Equal(t, int64(4), article.ID)
+		assert.Equal(t, int64(3), updated)
+		assert.ElementsMatch(t, []int64{2, 3, 4}, ids(deleted))
+
+		list, err := articles.List(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{1}, ids(list))
+	})
+
+	t.Run("should-reject-updates-and-deletes-without-filter", func(t *testing.T) {
+		// GIVEN
+		articles, _ := newArticles(t)
+
+		// WHEN
+		_, updateErr := articles.UpdateMany(ctx, map[string]any{"Views": 0})
+		deleteErr := articles.Delete(ctx, query.OrderBy("ID", false))
+
+		// THEN
+		assert.ErrorIs(t, updateErr, sqlstore.ErrMissingWhereClause)
+		assert.ErrorIs(t, deleteErr, sqlstore.ErrMissingWhereClause)
+	})
+
+	t.Run("should-upsert", func(t *testing.T) {
+		// GIVEN
+		articles, _ := newArticles(t)
+
+		// WHEN
+		_, err := articles.Upsert(ctx, &Article{Title: "first", Status: "active", Views: 15}, store.OnConflict{
+			Columns:     []string{"Title"},
+			UpdateAll:   true,
+			UpdateWhere: []query.FilterParam{query.Filter("Views", store.Excluded("Views")).WithOP(query.LT)},
+		})
+		require.NoError(t, err)
+
+		_, err = articles.Upsert(ctx, &Article{Title: "second", Views: 15}, store.OnConflict{
+			Columns:     []string{"Title"},
+			UpdateAll:   true,
+			UpdateWhere: []query.FilterParam{query.Filter("Views", store.Excluded("Views")).WithOP(query.LT)},
+		})
+		require.NoError(t, err)
+
+		_, err = articles.Upsert(ctx, &Article{Title: "third", Status: "draft"}, store.OnConflict{
+			Columns: []string{"title"},
+			Updates: map[string]any{"Views": 25, "Summary": "updated"},
+		})
+		require.NoError(t, err)
+
+		_, err = articles.Upsert(ctx, &Article{Title: "third", Status: "draft"}, store.OnConflict{
+			Columns:   []string{"title"},
+			DoNothing: true,
+		})
+		require.NoError(t, err)
+
+		id, err := articles.Upsert(ctx, &Article{Title: "fourth", Status: "draft"}, store.OnConflict{
+			Columns:   []string{"Title"},
+			UpdateAll: true,
+		})
+		require.NoError(t, err)
+
+		// THEN
+		list, err := articles.List(ctx, query.OrderBy("ID", false))
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), id)
+		assert.Equal(t, []*Article{
+			{ID: 1, Title: "first", Status: "active", Views: 15},
+			{ID: 2, Title: "second", Status: "active", AuthorID: 1, Views: 30},
+			{ID: 3, Title: "third", Status: "active", AuthorID: 2, Views: 25,
+				Summary: sql.NullString{String: "updated", Valid: true}},
+			{ID: 4, Title: "fourth", Status: "draft"},
+		}, list)
+	})
+}
+
+func Test_TransactionScope(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should-commit-nested-scopes-and-call-callbacks", func(t *testing.T) {
+		// GIVEN
+		articles, opScope := newArticles(t)
+
+		var committed bool
+
+		// WHEN
+		txCtx, err := opScope.Begin(ctx)
+		require.NoError(t, err)
+
+		nestedCtx, err := opScope.Begin(txCtx)
+		require.NoError(t, err)
+
+		_, err = articles.Create(nestedCtx, &Article{Title: "fourth", Status: "draft"})
+		require.NoError(t, err)
+
+		opScope.OnCommit(nestedCtx, func(context.Context) { committed = true })
+
+		require.NoError(t, opScope.End(nestedCtx, nil))
+		info, inTx := opscope.TxInfo(txCtx)
+		require.NoError(t, opScope.End(txCtx, nil))
+
+		// THEN
+		assert.True(t, inTx)
+		assert.Equal(t, 1, info.Level)
+		assert.True(t, committed)
+		assert.False(t, opscope.InTransaction(txCtx))
+
+		count, err := articles.Count(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), count)
+	})
+
+	t.Run("should-rollback-on-error", func(t *testing.T) {
+		// GIVEN
+		articles, opScope := newArticles(t)
+		errFailed := errors.New("failed")
+
+		// WHEN
+		txCtx, err := opScope.Begin(ctx)
+		require.NoError(t, err)
+
+		require.NoError(t, articles.Delete(txCtx, query.Filter("Status", "active")))
+
+		var rolledBack bool
+
+		opScope.OnCommit(txCtx, func(context.Context) { t.Fatal("unexpected commit callback") })
+		opScope.OnRollback(txCtx, func(ctx context.Context) { rolledBack = !opscope.InTransaction(ctx) })
+		err = opScope.End(txCtx, errFailed)
+
+		// THEN
+		assert.ErrorIs(t, err, errFailed)
+		assert.True(t, rolledBack)
+
+		count, err := articles.Count(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), count)
+	})
+}
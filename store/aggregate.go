@@ -0,0 +1,41 @@
+package store
+
+// Aggregation is an aggregate function computed over a field of the entities, see Store.Aggregate.
+type Aggregation string
+
+const (
+	// Sum is the sum of the values of a field.
+	Sum Aggregation = "SUM"
+
+	// Avg is the average of the values of a field.
+	Avg Aggregation = "AVG"
+
+	// Min is the smallest value of a field.
+	Min Aggregation = "MIN"
+
+	// Max is the largest value of a field.
+	Max Aggregation = "MAX"
+
+	// CountOf is the number of non-null values of a field, or the number of entities without field.
+	CountOf Aggregation = "COUNT"
+)
+
+// Valid reports whether a is one of the Aggregation constants.
+func (a Aggregation) Valid() bool {
+	switch a {
+	case Sum, Avg, Min, Max, CountOf:
+		return true
+	default:
+		return false
+	}
+}
+
+// Aggregate is an aggregation of a field, computed with others by Store.AggregateMany.
+//
+// Fields:
+//   - Aggregation: The aggregate function.
+//   - Field: The name of the aggregated field, which must be numeric.
+type Aggregate struct {
+	Aggregation Aggregation
+	Field       string
+}
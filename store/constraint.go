@@ -0,0 +1,53 @@
+package store
+
+import "errors"
+
+// The kinds of constraint violations, matched by the ConstraintError of the statements violating them.
+var (
+	// ErrDuplicateKey is matched by the violations of unique constraints, primary keys included.
+	// Since it means the entity already exists, it is matched by ErrDuplicate too.
+	ErrDuplicateKey = errors.New("duplicate key")
+
+	// ErrForeignKeyViolation is matched by the violations of foreign keys, e.g. when a referenced entity does not
+	// exist, or when an entity still referenced is deleted.
+	ErrForeignKeyViolation = errors.New("foreign key violation")
+
+	// ErrCheckViolation is matched by the violations of check constraints.
+	ErrCheckViolation = errors.New("check constraint violation")
+
+	// ErrNotNullViolation is matched by the violations of not null constraints.
+	ErrNotNullViolation = errors.New("not null violation")
+)
+
+// ConstraintError is returned by stores whose database rejected a statement violating a constraint, see the
+// storeerrors package translating the errors of the database drivers.
+//
+// Fields:
+//   - Kind: The kind of violation, e.g. ErrDuplicateKey, matched by errors.Is.
+//   - Constraint: The name of the violated constraint, or of its column, when the driver reports it.
+//   - Err: The error of the driver.
+type ConstraintError struct {
+	Kind       error
+	Constraint string
+	Err        error
+}
+
+// Error returns the kind of violation, the constraint and the error of the driver.
+func (e *ConstraintError) Error() string {
+	msg := e.Kind.Error()
+	if e.Constraint != "" {
+		msg += " " + e.Constraint
+	}
+
+	return msg + ": " + e.Err.Error()
+}
+
+// Is reports whether target is the kind of violation, or ErrDuplicate for duplicate keys.
+func (e *ConstraintError) Is(target error) bool {
+	return target == e.Kind || e.Kind == ErrDuplicateKey && target == ErrDuplicate
+}
+
+// Unwrap returns the error of the driver.
+func (e *ConstraintError) Unwrap() error {
+	return e.Err
+}
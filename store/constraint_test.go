@@ -0,0 +1,41 @@
+package store_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infevocorp/goflexstore/store"
+)
+
+func Test_ConstraintError(t *testing.T) {
+	t.Run("should-match-kind-and-driver-error", func(t *testing.T) {
+		// GIVEN
+		driverErr := errors.New(`pq: insert or update on table "articles" violates foreign key constraint`)
+
+		// WHEN
+		err := fmt.Errorf("create article: %w", &store.ConstraintError{
+			Kind:       store.ErrForeignKeyViolation,
+			Constraint: "articles_author_id_fkey",
+			Err:        driverErr,
+		})
+
+		// THEN
+		assert.ErrorIs(t, err, store.ErrForeignKeyViolation)
+		assert.ErrorIs(t, err, driverErr)
+		assert.NotErrorIs(t, err, store.ErrDuplicate)
+		assert.EqualError(t, err, "create article: foreign key violation articles_author_id_fkey: "+driverErr.Error())
+	})
+
+	t.Run("duplicate-key-should-match-duplicate", func(t *testing.T) {
+		// WHEN
+		err := &store.ConstraintError{Kind: store.ErrDuplicateKey, Err: errors.New("duplicate entry")}
+
+		// THEN
+		assert.ErrorIs(t, err, store.ErrDuplicateKey)
+		assert.ErrorIs(t, err, store.ErrDuplicate)
+		assert.EqualError(t, err, "duplicate key: duplicate entry")
+	})
+}
@@ -0,0 +1,29 @@
+package store
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrDuplicate is matched by the DuplicateError of the entities conflicting with existing ones.
+var ErrDuplicate = errors.New("duplicate")
+
+// DuplicateError is returned by stores rejecting an entity whose values of unique fields already exist.
+//
+// Fields:
+//   - Entity: The name of the entity type, e.g. "Article".
+//   - Fields: The unique fields whose values already exist, e.g. "Email".
+type DuplicateError struct {
+	Entity string
+	Fields []string
+}
+
+// Error returns the conflicting fields of the entity.
+func (e *DuplicateError) Error() string {
+	return "duplicate " + e.Entity + ": " + strings.Join(e.Fields, ", ") + " already exists"
+}
+
+// Is reports whether target is ErrDuplicate, so that errors.Is(err, ErrDuplicate) matches any DuplicateError.
+func (e *DuplicateError) Is(target error) bool {
+	return target == ErrDuplicate
+}
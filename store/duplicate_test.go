@@ -0,0 +1,19 @@
+package store_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infevocorp/goflexstore/store"
+)
+
+func Test_DuplicateError(t *testing.T) {
+	// GIVEN
+	err := fmt.Errorf("create user: %w", &store.DuplicateError{Entity: "User", Fields: []string{"TenantID", "Email"}})
+
+	// THEN
+	assert.ErrorIs(t, err, store.ErrDuplicate)
+	assert.EqualError(t, err, "create user: duplicate User: TenantID, Email already exists")
+}
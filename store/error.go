@@ -2,4 +2,16 @@ package store
 
 import "errors"
 
-var ErrorNotFound = errors.New("not found")
+// ErrNotFound is returned by Store.Get when no entity matches the params. The stores translate the not-found
+// errors of their backend, such as gorm.ErrRecordNotFound, to it, so that callers test a missing entity with
+// errors.Is(err, store.ErrNotFound) whatever the implementation.
+var ErrNotFound = errors.New("not found")
+
+// ErrorNotFound is the former name of ErrNotFound.
+//
+// Deprecated: Use ErrNotFound.
+var ErrorNotFound = ErrNotFound
+
+// ErrNoRowsAffected is returned by the stores configured to require their targeted writes, such as Update,
+// PartialUpdate and Delete, to affect at least one row, when no entity matches the params of the write.
+var ErrNoRowsAffected = errors.New("no rows affected")
@@ -0,0 +1,309 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/infevocorp/goflexstore/query"
+)
+
+// Names of the Store methods, used as Operation.Method.
+const (
+	MethodGet             = "Get"
+	MethodList            = "List"
+	MethodListWithCount   = "ListWithCount"
+	MethodCount           = "Count"
+	MethodAggregate       = "Aggregate"
+	MethodAggregateMany   = "AggregateMany"
+	MethodExists          = "Exists"
+	MethodCreate          = "Create"
+	MethodCreateMany      = "CreateMany"
+	MethodUpsert          = "Upsert"
+	MethodUpdate          = "Update"
+	MethodPartialUpdate   = "PartialUpdate"
+	MethodUpdateMany      = "UpdateMany"
+	MethodDelete          = "Delete"
+	MethodDeleteReturning = "DeleteReturning"
+	MethodPing            = "Ping"
+)
+
+// Operation describes a call to a Store method going through the middlewares of Chain.
+//
+// Middlewares can modify the operation before calling the next OperationFunc, e.g. to append a filter to Params,
+// as long as Input keeps the type expected by the method.
+//
+// Fields:
+//   - Method: The name of the called method, one of the Method constants.
+//   - Entity: The name of the entity type of the store, see EntityName.
+//   - Params: The query params given to Get, List, ListWithCount, Count, Aggregate, AggregateMany, Exists,
+//     Update, PartialUpdate, UpdateMany, Delete and DeleteReturning.
+//   - Input: The entity given to Create, Upsert, Update and PartialUpdate, the slice of entities given to
+//     CreateMany, the map of updates given to UpdateMany, the Aggregate given to Aggregate, the slice of
+//     Aggregate given to AggregateMany, nil for the other methods.
+//   - OnConflict: The conflict resolution strategy given to Upsert.
+type Operation struct {
+	Method     string
+	Entity     string
+	Params     []query.Param
+	Input      any
+	OnConflict OnConflict
+}
+
+// ListWithCountResult is the result of the ListWithCount operations: the page of entities and the total count.
+type ListWithCountResult[T any] struct {
+	Entities []T
+	Count    int64
+}
+
+// OperationFunc executes an operation and returns the result of the method: the entity for Get, the slice of
+// entities for List and DeleteReturning, a ListWithCountResult for ListWithCount, an int64 for Count and
+// UpdateMany, a float64 for Aggregate, a []float64 for AggregateMany, a bool for Exists, the ID for Create and
+// Upsert, and nil for the other methods.
+type OperationFunc func(ctx context.Context, op *Operation) (any, error)
+
+// Middleware wraps the execution of the operations of a store, see Chain.
+//
+// A middleware is not tied to an entity type, so the same middleware can decorate every store of an application,
+// e.g. to log, measure, retry or cache their operations.
+//
+// Example:
+// Logging the duration of the operations:
+//
+//	func Logging(logger *slog.Logger) store.Middleware {
+//		return func(next store.OperationFunc) store.OperationFunc {
+//			return func(ctx context.Context, op *store.Operation) (any, error) {
+//				start := time.Now()
+//				result, err := next(ctx, op)
+//				logger.InfoContext(ctx, "store operation", "entity", op.Entity, "method", op.Method,
+//					"duration", time.Since(start), "error", err)
+//
+//				return result, err
+//			}
+//		}
+//	}
+type Middleware func(next OperationFunc) OperationFunc
+
+// Chain decorates a store with middlewares. The first middleware is the outermost one: it is the first to see
+// the operations and the last to see their results. Chain returns inner when no middleware is given.
+//
+// A middleware returning a result without calling next, e.g. a cache, must return a value of the type expected
+// by the method, otherwise the method fails with an error.
+//
+// Example:
+//
+//	articleStore := store.Chain[*model.Article, int64](inner, Logging(logger), Retry(3))
+func Chain[T Entity[ID], ID comparable](inner Store[T, ID], middlewares ...Middleware) Store[T, ID] {
+	if len(middlewares) == 0 {
+		return inner
+	}
+
+	c := &chain[T, ID]{
+		inner:  inner,
+		entity: EntityName[T](),
+	}
+
+	c.exec = c.call
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		c.exec = middlewares[i](c.exec)
+	}
+
+	return c
+}
+
+// chain is the Store returned by Chain.
+type chain[T Entity[ID], ID comparable] struct {
+	inner  Store[T, ID]
+	entity string
+	exec   OperationFunc
+}
+
+func (c *chain[T, ID]) Get(ctx context.Context, params ...query.Param) (T, error) {
+	return resultOf[T](c.run(ctx, &Operation{Method: MethodGet, Params: params}))
+}
+
+func (c *chain[T, ID]) List(ctx context.Context, params ...query.Param) ([]T, error) {
+	return resultOf[[]T](c.run(ctx, &Operation{Method: MethodList, Params: params}))
+}
+
+func (c *chain[T, ID]) ListWithCount(ctx context.Context, params ...query.Param) ([]T, int64, error) {
+	result, err := resultOf[ListWithCountResult[T]](c.run(ctx, &Operation{Method: MethodListWithCount, Params: params}))
+
+	return result.Entities, result.Count, err
+}
+
+func (c *chain[T, ID]) Count(ctx context.Context, params ...query.Param) (int64, error) {
+	return resultOf[int64](c.run(ctx, &Operation{Method: MethodCount, Params: params}))
+}
+
+func (c *chain[T, ID]) Aggregate(
+	ctx context.Context,
+	agg Aggregation,
+	field string,
+	params ...query.Param,
+) (float64, error) {
+	return resultOf[float64](c.run(ctx, &Operation{
+		Method: MethodAggregate,
+		Input:  Aggregate{Aggregation: agg, Field: field},
+		Params: params,
+	}))
+}
+
+func (c *chain[T, ID]) AggregateMany(ctx context.Context, aggs []Aggregate, params ...query.Param) ([]float64, error) {
+	return resultOf[[]float64](c.run(ctx, &Operation{Method: MethodAggregateMany, Input: aggs, Params: params}))
+}
+
+func (c *chain[T, ID]) Exists(ctx context.Context, params ...query.Param) (bool, error) {
+	return resultOf[bool](c.run(ctx, &Operation{Method: MethodExists, Params: params}))
+}
+
+func (c *chain[T, ID]) Create(ctx context.Context, entity T) (ID, error) {
+	return resultOf[ID](c.run(ctx, &Operation{Method: MethodCreate, Input: entity}))
+}
+
+func (c *chain[T, ID]) Upsert(ctx context.Context, entity T, onConflict OnConflict) (ID, error) {
+	return resultOf[ID](c.run(ctx, &Operation{Method: MethodUpsert, Input: entity, OnConflict: onConflict}))
+}
+
+func (c *chain[T, ID]) CreateMany(ctx context.Context, entities []T) error {
+	_, err := c.run(ctx, &Operation{Method: MethodCreateMany, Input: entities})
+
+	return err
+}
+
+func (c *chain[T, ID]) Update(ctx context.Context, entity T, params ...query.Param) error {
+	_, err := c.run(ctx, &Operation{Method: MethodUpdate, Input: entity, Params: params})
+
+	return err
+}
+
+func (c *chain[T, ID]) PartialUpdate(ctx context.Context, entity T, params ...query.Param) error {
+	_, err := c.run(ctx, &Operation{Method: MethodPartialUpdate, Input: entity, Params: params})
+
+	return err
+}
+
+func (c *chain[T, ID]) UpdateMany(ctx context.Context, updates map[string]any, params ...query.Param) (int64, error) {
+	return resultOf[int64](c.run(ctx, &Operation{Method: MethodUpdateMany, Input: updates, Params: params}))
+}
+
+func (c *chain[T, ID]) Delete(ctx context.Context, params ...query.Param) error {
+	_, err := c.run(ctx, &Operation{Method: MethodDelete, Params: params})
+
+	return err
+}
+
+func (c *chain[T, ID]) DeleteReturning(ctx context.Context, params ...query.Param) ([]T, error) {
+	return resultOf[[]T](c.run(ctx, &Operation{Method: MethodDeleteReturning, Params: params}))
+}
+
+func (c *chain[T, ID]) Ping(ctx context.Context) error {
+	_, err := c.run(ctx, &Operation{Method: MethodPing})
+
+	return err
+}
+
+// run executes the operation through the middlewares.
+func (c *chain[T, ID]) run(ctx context.Context, op *Operation) (any, error) {
+	op.Entity = c.entity
+
+	return c.exec(ctx, op)
+}
+
+// call executes the operation on the inner store, once it went through every middleware.
+func (c *chain[T, ID]) call(ctx context.Context, op *Operation) (any, error) {
+	switch op.Method {
+	case MethodGet:
+		return c.inner.Get(ctx, op.Params...)
+	case MethodList:
+		return c.inner.List(ctx, op.Params...)
+	case MethodListWithCount:
+		entities, count, err := c.inner.ListWithCount(ctx, op.Params...)
+		if err != nil {
+			return nil, err
+		}
+
+		return ListWithCountResult[T]{Entities: entities, Count: count}, nil
+	case MethodCount:
+		return c.inner.Count(ctx, op.Params...)
+	case MethodExists:
+		return c.inner.Exists(ctx, op.Params...)
+	case MethodDelete:
+		return nil, c.inner.Delete(ctx, op.Params...)
+	case MethodDeleteReturning:
+		return c.inner.DeleteReturning(ctx, op.Params...)
+	case MethodPing:
+		return nil, c.inner.Ping(ctx)
+	case MethodCreateMany:
+		entities, err := inputOf[[]T](op)
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, c.inner.CreateMany(ctx, entities)
+	case MethodAggregate:
+		agg, err := inputOf[Aggregate](op)
+		if err != nil {
+			return nil, err
+		}
+
+		return c.inner.Aggregate(ctx, agg.Aggregation, agg.Field, op.Params...)
+	case MethodAggregateMany:
+		aggs, err := inputOf[[]Aggregate](op)
+		if err != nil {
+			return nil, err
+		}
+
+		return c.inner.AggregateMany(ctx, aggs, op.Params...)
+	case MethodUpdateMany:
+		updates, err := inputOf[map[string]any](op)
+		if err != nil {
+			return nil, err
+		}
+
+		return c.inner.UpdateMany(ctx, updates, op.Params...)
+	case MethodCreate, MethodUpsert, MethodUpdate, MethodPartialUpdate:
+		// methods taking an entity, called below.
+	default:
+		return nil, fmt.Errorf("unknown store method %q", op.Method)
+	}
+
+	entity, err := inputOf[T](op)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.Method {
+	case MethodCreate:
+		return c.inner.Create(ctx, entity)
+	case MethodUpsert:
+		return c.inner.Upsert(ctx, entity, op.OnConflict)
+	case MethodUpdate:
+		return nil, c.inner.Update(ctx, entity, op.Params...)
+	default:
+		return nil, c.inner.PartialUpdate(ctx, entity, op.Params...)
+	}
+}
+
+// inputOf returns the input of the operation as a V.
+func inputOf[V any](op *Operation) (V, error) {
+	input, ok := op.Input.(V)
+	if !ok {
+		return input, fmt.Errorf("%s input of %s is %T, want %T", op.Method, op.Entity, op.Input, input)
+	}
+
+	return input, nil
+}
+
+// resultOf returns the result of an operation as a V. A nil result is returned as the zero value of V.
+func resultOf[V any](result any, err error) (V, error) {
+	v, ok := result.(V)
+	if err != nil || result == nil {
+		return v, err
+	}
+
+	if !ok {
+		return v, fmt.Errorf("store operation result is %T, want %T", result, v)
+	}
+
+	return v, nil
+}
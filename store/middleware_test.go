@@ -0,0 +1,242 @@
+package store_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mockstore "github.com/infevocorp/goflexstore/mocks/store"
+	"github.com/infevocorp/goflexstore/query"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+type Article struct {
+	ID    int
+	Title string
+}
+
+func (a *Article) GetID() int {
+	return a.ID
+}
+
+// recorder returns a middleware appending the name and the method of the operations to calls.
+func recorder(name string, calls *[]string) store.Middleware {
+	return func(next store.OperationFunc) store.OperationFunc {
+		return func(ctx context.Context, op *store.Operation) (any, error) {
+			*calls = append(*calls, name+":"+op.Method)
+
+			return next(ctx, op)
+		}
+	}
+}
+
+func Test_Chain(t *testing.T) {
+	t.Run("should-return-inner-without-middleware", func(t *testing.T) {
+		inner := mockstore.NewStore[*Article, int](t)
+
+		assert.Same(t, inner, store.Chain[*Article, int](inner))
+	})
+
+	t.Run("should-call-middlewares-in-order", func(t *testing.T) {
+		// GIVEN
+		var (
+			ctx   = context.Background()
+			inner = mockstore.NewStore[*Article, int](t)
+			calls []string
+		)
+
+		inner.EXPECT().Count(ctx, query.Filter("Title", "a")).Return(2, nil)
+
+		s := store.Chain[*Article, int](inner, recorder("first", &calls), recorder("second", &calls))
+
+		// WHEN
+		count, err := s.Count(ctx, query.Filter("Title", "a"))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+		assert.Equal(t, []string{"first:Count", "second:Count"}, calls)
+	})
+
+	t.Run("should-return-list-with-count", func(t *testing.T) {
+		// GIVEN
+		var (
+			ctx      = context.Background()
+			inner    = mockstore.NewStore[*Article, int](t)
+			articles = []*Article{{ID: 1, Title: "a"}}
+			result   any
+		)
+
+		inner.EXPECT().ListWithCount(ctx, query.Paginate(0, 1)).Return(articles, 3, nil)
+
+		spy := func(next store.OperationFunc) store.OperationFunc {
+			return func(ctx context.Context, op *store.Operation) (any, error) {
+				r, err := next(ctx, op)
+				result = r
+
+				return r, err
+			}
+		}
+
+		s := store.Chain[*Article, int](inner, spy)
+
+		// WHEN
+		list, count, err := s.ListWithCount(ctx, query.Paginate(0, 1))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, articles, list)
+		assert.Equal(t, int64(3), count)
+		assert.Equal(t, store.ListWithCountResult[*Article]{Entities: articles, Count: 3}, result)
+	})
+
+	t.Run("should-forward-modified-operation", func(t *testing.T) {
+		// GIVEN
+		var (
+			ctx     = context.Background()
+			inner   = mockstore.NewStore[*Article, int](t)
+			article = &Article{Title: "a"}
+		)
+
+		inner.EXPECT().Update(ctx, article, query.Filter("ID", 1), query.Filter("TenantID", "acme")).Return(nil)
+
+		scoped := func(next store.OperationFunc) store.OperationFunc {
+			return func(ctx context.Context, op *store.Operation) (any, error) {
+				assert.Equal(t, "Article", op.Entity)
+				assert.Equal(t, article, op.Input)
+				op.Params = append(op.Params, query.Filter("TenantID", "acme"))
+
+				return next(ctx, op)
+			}
+		}
+
+		s := store.Chain[*Article, int](inner, scoped)
+
+		// WHEN
+		err := s.Update(ctx, article, query.Filter("ID", 1))
+
+		// THEN
+		require.NoError(t, err)
+	})
+
+	t.Run("should-return-result-of-short-circuiting-middleware", func(t *testing.T) {
+		// GIVEN
+		var (
+			inner  = mockstore.NewStore[*Article, int](t)
+			cached = &Article{ID: 1, Title: "cached"}
+		)
+
+		cache := func(store.OperationFunc) store.OperationFunc {
+			return func(context.Context, *store.Operation) (any, error) {
+				return cached, nil
+			}
+		}
+
+		s := store.Chain[*Article, int](inner, cache)
+
+		// WHEN
+		article, err := s.Get(context.Background(), query.Filter("ID", 1))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Same(t, cached, article)
+	})
+
+	t.Run("should-fail-on-unexpected-result-type", func(t *testing.T) {
+		// GIVEN
+		inner := mockstore.NewStore[*Article, int](t)
+
+		invalid := func(store.OperationFunc) store.OperationFunc {
+			return func(context.Context, *store.Operation) (any, error) {
+				return "invalid", nil
+			}
+		}
+
+		s := store.Chain[*Article, int](inner, invalid)
+
+		// WHEN
+		_, err := s.List(context.Background())
+
+		// THEN
+		assert.EqualError(t, err, "store operation result is string, want []*store_test.Article")
+	})
+
+	t.Run("should-fail-on-unexpected-input-type", func(t *testing.T) {
+		// GIVEN
+		inner := mockstore.NewStore[*Article, int](t)
+
+		invalid := func(next store.OperationFunc) store.OperationFunc {
+			return func(ctx context.Context, op *store.Operation) (any, error) {
+				op.Input = Article{}
+
+				return next(ctx, op)
+			}
+		}
+
+		s := store.Chain[*Article, int](inner, invalid)
+
+		// WHEN
+		_, err := s.Create(context.Background(), &Article{})
+
+		// THEN
+		assert.EqualError(t, err, "Create input of Article is store_test.Article, want *store_test.Article")
+	})
+
+	t.Run("should-dispatch-every-method", func(t *testing.T) {
+		// GIVEN
+		var (
+			ctx      = context.Background()
+			inner    = mockstore.NewStore[*Article, int](t)
+			article  = &Article{ID: 1}
+			articles = []*Article{article}
+			filter   = query.Filter("ID", 1)
+			errFail  = errors.New("fail")
+			calls    []string
+		)
+
+		inner.EXPECT().Get(ctx, filter).Return(article, nil)
+		inner.EXPECT().List(ctx, filter).Return(articles, nil)
+		inner.EXPECT().Exists(ctx, filter).Return(true, nil)
+		inner.EXPECT().Create(ctx, article).Return(1, nil)
+		inner.EXPECT().CreateMany(ctx, articles).Return(nil)
+		inner.EXPECT().Upsert(ctx, article, store.OnConflict{UpdateAll: true}).Return(1, nil)
+		inner.EXPECT().PartialUpdate(ctx, article, filter).Return(nil)
+		inner.EXPECT().Delete(ctx, filter).Return(errFail)
+		inner.EXPECT().DeleteReturning(ctx, filter).Return(articles, nil)
+
+		s := store.Chain[*Article, int](inner, recorder("m", &calls))
+
+		// WHEN
+		got, getErr := s.Get(ctx, filter)
+		list, listErr := s.List(ctx, filter)
+		exists, existsErr := s.Exists(ctx, filter)
+		id, createErr := s.Create(ctx, article)
+		createManyErr := s.CreateMany(ctx, articles)
+		upsertID, upsertErr := s.Upsert(ctx, article, store.OnConflict{UpdateAll: true})
+		partialUpdateErr := s.PartialUpdate(ctx, article, filter)
+		deleteErr := s.Delete(ctx, filter)
+		deleted, deleteReturningErr := s.DeleteReturning(ctx, filter)
+
+		// THEN
+		for _, err := range []error{
+			getErr, listErr, existsErr, createErr, createManyErr, upsertErr, partialUpdateErr, deleteReturningErr,
+		} {
+			require.NoError(t, err)
+		}
+
+		assert.ErrorIs(t, deleteErr, errFail)
+		assert.Same(t, article, got)
+		assert.Equal(t, articles, list)
+		assert.True(t, exists)
+		assert.Equal(t, 1, id)
+		assert.Equal(t, 1, upsertID)
+		assert.Equal(t, articles, deleted)
+		assert.Equal(t, []string{
+			"m:Get", "m:List", "m:Exists", "m:Create", "m:CreateMany", "m:Upsert", "m:PartialUpdate", "m:Delete",
+			"m:DeleteReturning",
+		}, calls)
+	})
+}
@@ -0,0 +1,14 @@
+package store
+
+import "reflect"
+
+// EntityName returns the name of the type T, dereferencing pointer types.
+// It is used by store decorators to label entities, e.g. "Article" for *model.Article.
+func EntityName[T any]() string {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return t.Name()
+}
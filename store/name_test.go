@@ -0,0 +1,17 @@
+package store_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infevocorp/goflexstore/store"
+)
+
+type article struct{}
+
+func Test_EntityName(t *testing.T) {
+	assert.Equal(t, "article", store.EntityName[article]())
+	assert.Equal(t, "article", store.EntityName[*article]())
+	assert.Equal(t, "article", store.EntityName[**article]())
+}
@@ -11,6 +11,7 @@
 // - Entity: A generic interface for models that can be stored.
 // - Store: A generic interface for CRUD operations on entities.
 // - OnConflict: A struct to define UPSERT operation behavior.
+// - Chain: A function decorating a Store with middlewares wrapping each operation.
 //
 // The package aims to provide a robust and flexible way to handle data storage
 // needs in a Go-based application, ensuring scalability and maintainability.
@@ -52,6 +53,20 @@ type Entity[ID comparable] interface {
 //     This field allows for partial updates, where only specified columns are updated.
 //   - OnConstraint: A string specifying the name of the constraint that should be considered for detecting a conflict.
 //     This is used in databases that support defining and naming constraints (e.g., unique constraints).
+//   - UpdateWhere: Filters the conflicting row must satisfy to be updated, e.g. to only apply changes newer than the
+//     stored ones. A filter value created with Excluded refers to the column of the row proposed for insertion.
+//     It is supported by databases having an ON CONFLICT ... DO UPDATE ... WHERE clause, such as PostgreSQL and
+//     SQLite, and ignored by MySQL.
+//   - UpdateExprs: A map where keys are column names and values are SQL expressions assigned to them when a
+//     conflict is detected, e.g. "counter + excluded.counter" to add the upserted value to the stored one.
+//     The assignments are combined with the ones of Updates or UpdateColumns. The expressions are raw SQL:
+//     they must never contain user input.
+//   - TargetWhere: The SQL predicate of the partial unique index the Columns belong to, e.g. "deleted_at IS NULL"
+//     for a unique index on "email" restricted to the rows not deleted. It is raw SQL rather than filters, since
+//     the databases only match the predicate of the index against constant conditions, not bound values.
+//     It is supported by PostgreSQL and SQLite, and ignored by MySQL.
+//
+// UpdateExprs and TargetWhere are supported by the GORM stores.
 //
 // The OnConflict struct is typically used with the Upsert method of a Store interface to define custom logic for
 // handling insert/update operations where there may be a conflict with existing data.
@@ -62,6 +77,27 @@ type OnConflict struct {
 	Updates       map[string]any
 	UpdateColumns []string
 	OnConstraint  string
+	UpdateWhere   []query.FilterParam
+	UpdateExprs   map[string]string
+	TargetWhere   string
+}
+
+// ExcludedColumn refers to a column of the row proposed for insertion by an upsert, see Excluded.
+type ExcludedColumn string
+
+// Excluded creates a reference to the field of the row proposed for insertion by an upsert, to be used as the value
+// of OnConflict.UpdateWhere filters. The name is mapped to its column like the names of the filters.
+//
+// Example:
+// Last write wins upsert, only updating the stored row when the upserted one is newer:
+//
+//	_, err := store.Upsert(ctx, entity, OnConflict{
+//	  Columns:     []string{"id"},
+//	  UpdateAll:   true,
+//	  UpdateWhere: []query.FilterParam{query.Filter("UpdatedAt", Excluded("UpdatedAt")).WithOP(query.LT)},
+//	})
+func Excluded(name string) ExcludedColumn {
+	return ExcludedColumn(name)
 }
 
 // Store defines a generic interface for CRUD (Create, Read, Update, Delete) operations
@@ -87,7 +123,7 @@ type Store[T Entity[ID], ID comparable] interface {
 	//
 	//	entity, err := store.Get(ctx, query.Filter("id", entityID))
 	//
-	// Note: If no entity matches the query parameters, an error indicating "not found" is typically returned.
+	// Note: If no entity matches the query parameters, ErrNotFound is returned, possibly wrapped.
 	Get(ctx context.Context, params ...query.Param) (T, error)
 
 	// List retrieves a list of entities based on the provided query parameters.
@@ -126,6 +162,69 @@ type Store[T Entity[ID], ID comparable] interface {
 	//	count, err := store.Count(ctx, query.Filter("status", "active"))
 	Count(ctx context.Context, params ...query.Param) (int64, error)
 
+	// ListWithCount retrieves a page of entities matching the provided query parameters, together with the total
+	// number of matching entities.
+	//
+	// This method lists the entities like List, and counts them like Count while ignoring the Paginate params,
+	// so that the paginated endpoints get the page and the total in one call. If an error occurs, nil, 0 and the
+	// error are returned.
+	//
+	// Parameters:
+	//   - ctx: A context.Context to control the request's deadline and cancellation.
+	//   - params: A variable number of query.Param, each representing a filter condition for the query.
+	//
+	// Returns: A slice of entities of type T and the total count of matching entities if successful, nil, 0 and
+	// an error otherwise.
+	//
+	// Example:
+	// Listing the second page of active entities with their total:
+	//
+	//	entities, total, err := store.ListWithCount(ctx, query.Filter("status", "active"), query.Paginate(20, 20))
+	ListWithCount(ctx context.Context, params ...query.Param) ([]T, int64, error)
+
+	// Aggregate computes an aggregate function over a numeric field of the entities matching the provided query
+	// parameters.
+	//
+	// This method returns the Sum, Avg, Min, Max or CountOf of the values of the field, ignoring the OrderBy,
+	// Paginate and Select params. The aggregate of no value, e.g. the average of no entity, is 0. If an error
+	// occurs, 0 and the error are returned.
+	//
+	// Parameters:
+	//   - ctx: A context.Context to control the request's deadline and cancellation.
+	//   - agg: The aggregate function.
+	//   - field: The name of the aggregated field.
+	//   - params: A variable number of query.Param, each representing a filter condition for the query.
+	//
+	// Returns: The aggregate as float64 if successful, 0 and an error otherwise.
+	//
+	// Example:
+	// Summing the amounts of the paid orders:
+	//
+	//	revenue, err := store.Aggregate(ctx, store.Sum, "Amount", query.Filter("Status", "paid"))
+	Aggregate(ctx context.Context, agg Aggregation, field string, params ...query.Param) (float64, error)
+
+	// AggregateMany computes several aggregates of the entities matching the provided query parameters in a
+	// single query.
+	//
+	// This method returns the aggregates in the order of aggs, computed like Aggregate. If an error occurs, nil and
+	// the error are returned.
+	//
+	// Parameters:
+	//   - ctx: A context.Context to control the request's deadline and cancellation.
+	//   - aggs: The aggregates to compute.
+	//   - params: A variable number of query.Param, each representing a filter condition for the query.
+	//
+	// Returns: The aggregates as float64 if successful, nil and an error otherwise.
+	//
+	// Example:
+	// Computing the range of the prices of a category:
+	//
+	//	prices, err := store.AggregateMany(ctx, []store.Aggregate{
+	//		{Aggregation: store.Min, Field: "Price"},
+	//		{Aggregation: store.Max, Field: "Price"},
+	//	}, query.Filter("Category", "books"))
+	AggregateMany(ctx context.Context, aggs []Aggregate, params ...query.Param) ([]float64, error)
+
 	// Exists checks if at least one entity exists based on the provided query parameters.
 	//
 	// This method determines the existence of any entity that matches the criteria specified by the query parameters.
@@ -272,6 +371,29 @@ type Store[T Entity[ID], ID comparable] interface {
 	//  updated, while omitting them defaults to using the entity's ID for identification.
 	PartialUpdate(ctx context.Context, entity T, params ...query.Param) error
 
+	// UpdateMany sets fields of every entity matching the provided query parameters in a single operation.
+	//
+	// This method writes the values of updates, keyed by field name, to all the matching entities at once, e.g. in
+	// a single UPDATE statement, instead of updating the entities one by one. It returns the number of updated
+	// entities if successful. If an error occurs, 0 and the error are returned.
+	//
+	// Parameters:
+	//   - ctx: A context.Context to control the request's deadline and cancellation.
+	//   - updates: The new values of the updated fields, keyed by field name.
+	//   - params: A variable number of query.Param, each representing a filter condition to select the entities
+	//     to be updated.
+	//
+	// Returns: The number of updated entities if successful, 0 and an error otherwise.
+	//
+	// Example:
+	// Archiving every draft of an author:
+	//
+	//	updated, err := store.UpdateMany(ctx, map[string]any{"Status": "archived"},
+	//		query.Filter("AuthorID", authorID),
+	//		query.Filter("Status", "draft"),
+	//	)
+	UpdateMany(ctx context.Context, updates map[string]any, params ...query.Param) (int64, error)
+
 	// Delete removes an entity from the store based on the provided query parameters.
 	//
 	// This method deletes an existing entity from the store that matches the criteria specified by the query
@@ -290,4 +412,41 @@ type Store[T Entity[ID], ID comparable] interface {
 	//
 	//	err := store.Delete(ctx, query.Filter("id", entityID))
 	Delete(ctx context.Context, params ...query.Param) error
+
+	// DeleteReturning removes the entities matching the provided query parameters and returns them.
+	//
+	// This method behaves like Delete but returns the entities as they were before their deletion, so that callers
+	// can invalidate caches or publish events for exactly the removed entities. Implementations should return the
+	// entities atomically with their deletion, e.g. with a RETURNING clause or by selecting and deleting them in a
+	// single transaction.
+	//
+	// Parameters:
+	//   - ctx: A context.Context to control the request's deadline and cancellation.
+	//   - params: A variable number of query.Param, each representing a filter condition to identify the entities to
+	//     be deleted.
+	//
+	// Returns: The deleted entities if successful, nil and an error otherwise.
+	//
+	// Example:
+	// Removing the expired sessions and evicting them from a cache:
+	//
+	//	sessions, err := store.DeleteReturning(ctx, query.Filter("ExpiresAt", now).WithOP(query.LT))
+	DeleteReturning(ctx context.Context, params ...query.Param) ([]T, error)
+
+	// Ping checks that the underlying data storage system is reachable.
+	//
+	// This method is intended for health and readiness checks: it returns nil if the storage system can serve
+	// operations, an error otherwise. Implementations should run the cheapest possible round trip, e.g. a
+	// `SELECT 1`, and honor the deadline of the context.
+	//
+	// Parameters:
+	//   - ctx: A context.Context to control the request's deadline and cancellation.
+	//
+	// Returns: Nil if the storage system is reachable, an error otherwise.
+	//
+	// Example:
+	// Checking the database before accepting traffic:
+	//
+	//	err := store.Ping(ctx)
+	Ping(ctx context.Context) error
 }
@@ -0,0 +1,129 @@
+package store
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// ValidationError is returned by stores rejecting invalid entities on writes.
+//
+// Fields:
+//   - Entity: The name of the invalid entity type, e.g. "Article".
+//   - Fields: The invalid fields, when the validator reports them.
+//   - Err: The error returned by the validator.
+type ValidationError struct {
+	Entity string
+	Fields []FieldError
+	Err    error
+}
+
+// FieldError describes why a field of an entity is invalid.
+//
+// Fields:
+//   - Field: The name of the field, e.g. "Title".
+//   - Rule: The validation rule the field failed, e.g. "required" or "max".
+//   - Param: The parameter of the rule, if any, e.g. "255" for "max=255".
+//   - Message: A human readable description of the error.
+type FieldError struct {
+	Field   string
+	Rule    string
+	Param   string
+	Message string
+}
+
+// fieldError is implemented by the field errors of github.com/go-playground/validator.
+type fieldError interface {
+	Field() string
+	Tag() string
+	Param() string
+	Error() string
+}
+
+// NewValidationError creates a ValidationError for an entity type from the error of a validator.
+//
+// The field errors of github.com/go-playground/validator are converted to FieldError, as are the FieldError
+// values returned by the validator. A ValidationError is returned as is.
+//
+// Parameters:
+//   - entity: The name of the entity type, see EntityName.
+//   - err: The error returned by the validator.
+//
+// Returns:
+// A ValidationError wrapping err.
+func NewValidationError(entity string, err error) *ValidationError {
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return validationErr
+	}
+
+	return &ValidationError{
+		Entity: entity,
+		Fields: fieldErrors(err),
+		Err:    err,
+	}
+}
+
+// Error returns the invalid fields of the entity, or the validator error when it has no field errors.
+func (e *ValidationError) Error() string {
+	if len(e.Fields) == 0 {
+		return "invalid " + e.Entity + ": " + e.Err.Error()
+	}
+
+	messages := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		messages[i] = field.Field + ": " + field.Message
+	}
+
+	return "invalid " + e.Entity + ": " + strings.Join(messages, "; ")
+}
+
+// Unwrap returns the validator error.
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// Error returns the message of the field error.
+func (e FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// fieldErrors extracts the field errors of a validator error, which is either a FieldError, an error implementing
+// fieldError, or a slice of them such as validator.ValidationErrors.
+func fieldErrors(err error) []FieldError {
+	switch e := err.(type) {
+	case FieldError:
+		return []FieldError{e}
+	case fieldError:
+		return []FieldError{toFieldError(e)}
+	}
+
+	v := reflect.ValueOf(err)
+	if v.Kind() != reflect.Slice {
+		return nil
+	}
+
+	fields := make([]FieldError, 0, v.Len())
+
+	for i := 0; i < v.Len(); i++ {
+		switch e := v.Index(i).Interface().(type) {
+		case FieldError:
+			fields = append(fields, e)
+		case fieldError:
+			fields = append(fields, toFieldError(e))
+		default:
+			return nil
+		}
+	}
+
+	return fields
+}
+
+func toFieldError(e fieldError) FieldError {
+	return FieldError{
+		Field:   e.Field(),
+		Rule:    e.Tag(),
+		Param:   e.Param(),
+		Message: e.Error(),
+	}
+}
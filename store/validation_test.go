@@ -0,0 +1,82 @@
+package store_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// validatorFieldError mimics the field errors of github.com/go-playground/validator.
+type validatorFieldError struct {
+	field, tag, param string
+}
+
+func (e validatorFieldError) Field() string { return e.field }
+func (e validatorFieldError) Tag() string   { return e.tag }
+func (e validatorFieldError) Param() string { return e.param }
+func (e validatorFieldError) Error() string { return "failed on the '" + e.tag + "' tag" }
+
+// validationErrors mimics validator.ValidationErrors.
+type validationErrors []validatorFieldError
+
+func (e validationErrors) Error() string { return "validation errors" }
+
+func Test_NewValidationError(t *testing.T) {
+	t.Run("should-convert-validator-field-errors", func(t *testing.T) {
+		// GIVEN
+		err := validationErrors{
+			{field: "Title", tag: "required"},
+			{field: "Slug", tag: "max", param: "64"},
+		}
+
+		// WHEN
+		validationErr := store.NewValidationError("Article", err)
+
+		// THEN
+		assert.Equal(t, []store.FieldError{
+			{Field: "Title", Rule: "required", Message: "failed on the 'required' tag"},
+			{Field: "Slug", Rule: "max", Param: "64", Message: "failed on the 'max' tag"},
+		}, validationErr.Fields)
+		assert.EqualError(t, validationErr,
+			"invalid Article: Title: failed on the 'required' tag; Slug: failed on the 'max' tag")
+		assert.Equal(t, err, errors.Unwrap(validationErr))
+	})
+
+	t.Run("should-keep-field-error", func(t *testing.T) {
+		// GIVEN
+		err := store.FieldError{Field: "Title", Rule: "required", Message: "is required"}
+
+		// WHEN
+		validationErr := store.NewValidationError("Article", err)
+
+		// THEN
+		assert.Equal(t, []store.FieldError{err}, validationErr.Fields)
+		assert.EqualError(t, validationErr, "invalid Article: Title: is required")
+	})
+
+	t.Run("should-wrap-other-errors", func(t *testing.T) {
+		// GIVEN
+		err := errors.New("title already taken")
+
+		// WHEN
+		validationErr := store.NewValidationError("Article", err)
+
+		// THEN
+		assert.Empty(t, validationErr.Fields)
+		assert.EqualError(t, validationErr, "invalid Article: title already taken")
+	})
+
+	t.Run("should-return-validation-error-as-is", func(t *testing.T) {
+		// GIVEN
+		err := &store.ValidationError{Entity: "Tag", Err: errors.New("invalid")}
+
+		// WHEN
+		validationErr := store.NewValidationError("Article", err)
+
+		// THEN
+		assert.Same(t, err, validationErr)
+	})
+}
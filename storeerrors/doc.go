@@ -0,0 +1,21 @@
+// Package storeerrors translates the constraint violation errors of the database drivers to a
+// store.ConstraintError, so that callers branch on the kind of violation with errors.Is, e.g.
+// errors.Is(err, store.ErrDuplicateKey), instead of matching the codes or messages of each driver.
+//
+// The errors are recognized without depending on the drivers:
+//   - PostgreSQL: the errors with a SQLState method, like the errors of pgx and lib/pq, by SQLSTATE code;
+//   - MySQL and MariaDB: the errors with a Number field, like the errors of github.com/go-sql-driver/mysql,
+//     by error number;
+//   - SQLite: the errors whose message reports a failed constraint, like the errors of mattn/go-sqlite3 and
+//     modernc.org/sqlite.
+//
+// The GORM stores translate the errors of their writes with Translate by default, see
+// gormstore.WithErrorTranslator.
+//
+// Example:
+//
+//	_, err := userStore.Create(ctx, user)
+//	if errors.Is(storeerrors.Translate(err), store.ErrDuplicateKey) {
+//		return ErrEmailTaken
+//	}
+package storeerrors
@@ -0,0 +1,178 @@
+package storeerrors
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// sqlStates are the kinds of violations of the SQLSTATE codes of the integrity constraint violations.
+var sqlStates = map[string]error{
+	"23505": store.ErrDuplicateKey,        // unique_violation
+	"23503": store.ErrForeignKeyViolation, // foreign_key_violation
+	"23514": store.ErrCheckViolation,      // check_violation
+	"23502": store.ErrNotNullViolation,    // not_null_violation
+}
+
+// mysqlErrors are the kinds of violations of the MySQL error numbers.
+var mysqlErrors = map[uint64]error{
+	1062: store.ErrDuplicateKey,        // ER_DUP_ENTRY
+	1586: store.ErrDuplicateKey,        // ER_DUP_ENTRY_WITH_KEY_NAME
+	1216: store.ErrForeignKeyViolation, // ER_NO_REFERENCED_ROW
+	1217: store.ErrForeignKeyViolation, // ER_ROW_IS_REFERENCED
+	1451: store.ErrForeignKeyViolation, // ER_ROW_IS_REFERENCED_2
+	1452: store.ErrForeignKeyViolation, // ER_NO_REFERENCED_ROW_2
+	3819: store.ErrCheckViolation,      // ER_CHECK_CONSTRAINT_VIOLATED
+	1048: store.ErrNotNullViolation,    // ER_BAD_NULL_ERROR
+}
+
+// sqliteMessages are the kinds of violations of the messages of the SQLite constraint errors.
+var sqliteMessages = []struct {
+	message string
+	kind    error
+}{
+	{"UNIQUE constraint failed", store.ErrDuplicateKey},
+	{"FOREIGN KEY constraint failed", store.ErrForeignKeyViolation},
+	{"CHECK constraint failed", store.ErrCheckViolation},
+	{"NOT NULL constraint failed", store.ErrNotNullViolation},
+}
+
+// Translate returns a store.ConstraintError wrapping err if it is a constraint violation, see Classify, and err
+// otherwise. A nil error and the errors already wrapping a store.ConstraintError are returned as is.
+func Translate(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var constraintErr *store.ConstraintError
+	if errors.As(err, &constraintErr) {
+		return err
+	}
+
+	kind, constraint, ok := Classify(err)
+	if !ok {
+		return err
+	}
+
+	return &store.ConstraintError{Kind: kind, Constraint: constraint, Err: err}
+}
+
+// Classify returns the kind of constraint violation of err, e.g. store.ErrDuplicateKey, and the name of the
+// violated constraint, or of its column, when the driver reports it. It returns false if err is not a constraint
+// violation of a supported driver.
+func Classify(err error) (kind error, constraint string, ok bool) {
+	var sqlErr interface{ SQLState() string }
+	if errors.As(err, &sqlErr) {
+		if kind, ok := sqlStates[sqlErr.SQLState()]; ok {
+			return kind, stringField(sqlErr, "ConstraintName", "Constraint", "ColumnName", "Column"), true
+		}
+	}
+
+	if number, mysqlErr, ok := mysqlNumber(err); ok {
+		if kind, ok := mysqlErrors[number]; ok {
+			return kind, mysqlConstraint(number, mysqlErr.Error()), true
+		}
+	}
+
+	msg := err.Error()
+
+	for _, m := range sqliteMessages {
+		if i := strings.Index(msg, m.message); i >= 0 {
+			return m.kind, sqliteConstraint(msg[i+len(m.message):]), true
+		}
+	}
+
+	return nil, "", false
+}
+
+// mysqlNumber returns the error number of the first error of the chain of err with a Number field, and this error.
+func mysqlNumber(err error) (uint64, error, bool) {
+	for err != nil {
+		v := reflect.Indirect(reflect.ValueOf(err))
+		if v.Kind() == reflect.Struct {
+			if f := v.FieldByName("Number"); f.IsValid() && f.CanUint() {
+				return f.Uint(), err, true
+			}
+		}
+
+		switch u := err.(type) {
+		case interface{ Unwrap() error }:
+			err = u.Unwrap()
+		case interface{ Unwrap() []error }:
+			for _, e := range u.Unwrap() {
+				if number, mysqlErr, ok := mysqlNumber(e); ok {
+					return number, mysqlErr, true
+				}
+			}
+
+			return 0, nil, false
+		default:
+			return 0, nil, false
+		}
+	}
+
+	return 0, nil, false
+}
+
+// mysqlConstraint returns the constraint, or column, quoted in the message of the MySQL error, e.g. users.email in
+// "Duplicate entry 'john@doe.com' for key 'users.email'".
+func mysqlConstraint(number uint64, msg string) string {
+	switch number {
+	case 1062, 1586:
+		return quoted(msg, "for key '", "'")
+	case 1216, 1217, 1451, 1452:
+		return quoted(msg, "CONSTRAINT `", "`")
+	case 3819:
+		return quoted(msg, "constraint '", "'")
+	case 1048:
+		return quoted(msg, "Column '", "'")
+	default:
+		return ""
+	}
+}
+
+// sqliteConstraint returns the constraint, or columns, following the message of a SQLite constraint error, e.g.
+// users.email in "UNIQUE constraint failed: users.email", without the error code of modernc.org/sqlite.
+func sqliteConstraint(rest string) string {
+	rest = strings.TrimPrefix(rest, ": ")
+
+	if i := strings.LastIndex(rest, " ("); i >= 0 && strings.HasSuffix(rest, ")") {
+		rest = rest[:i]
+	}
+
+	return rest
+}
+
+// quoted returns the text of msg between the first occurrence of start and the following end.
+func quoted(msg, start, end string) string {
+	i := strings.Index(msg, start)
+	if i < 0 {
+		return ""
+	}
+
+	rest := msg[i+len(start):]
+
+	if j := strings.Index(rest, end); j >= 0 {
+		return rest[:j]
+	}
+
+	return ""
+}
+
+// stringField returns the first non-empty string field of the struct pointed to by v among names.
+func stringField(v any, names ...string) string {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return ""
+	}
+
+	for _, name := range names {
+		if f := rv.FieldByName(name); f.IsValid() && f.Kind() == reflect.String && f.String() != "" {
+			return f.String()
+		}
+	}
+
+	return ""
+}
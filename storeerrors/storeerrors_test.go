@@ -0,0 +1,149 @@
+package storeerrors_test
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infevocorp/goflexstore/store"
+	"github.com/infevocorp/goflexstore/storeerrors"
+)
+
+// pgError mimics the errors of pgx, which report their SQLSTATE code and constraint.
+type pgError struct {
+	Code           string
+	ConstraintName string
+}
+
+func (e *pgError) Error() string {
+	return "ERROR: constraint violated (SQLSTATE " + e.Code + ")"
+}
+
+func (e *pgError) SQLState() string {
+	return e.Code
+}
+
+func Test_Translate(t *testing.T) {
+	t.Run("should-translate-postgres-errors", func(t *testing.T) {
+		for code, kind := range map[string]error{
+			"23505": store.ErrDuplicateKey,
+			"23503": store.ErrForeignKeyViolation,
+			"23514": store.ErrCheckViolation,
+			"23502": store.ErrNotNullViolation,
+		} {
+			// GIVEN
+			driverErr := &pgError{Code: code, ConstraintName: "users_constraint"}
+
+			// WHEN
+			err := storeerrors.Translate(fmt.Errorf("create user: %w", driverErr))
+
+			// THEN
+			var constraintErr *store.ConstraintError
+			require.ErrorAs(t, err, &constraintErr, code)
+			assert.ErrorIs(t, err, kind, code)
+			assert.ErrorIs(t, err, driverErr, code)
+			assert.Equal(t, "users_constraint", constraintErr.Constraint, code)
+		}
+	})
+
+	t.Run("should-translate-mysql-errors", func(t *testing.T) {
+		for _, test := range []struct {
+			err        *mysql.MySQLError
+			kind       error
+			constraint string
+		}{
+			{
+				err:        &mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'john@doe.com' for key 'users.email'"},
+				kind:       store.ErrDuplicateKey,
+				constraint: "users.email",
+			},
+			{
+				err: &mysql.MySQLError{Number: 1452, Message: "Cannot add or update a child row: a foreign key " +
+					"constraint fails (`cms`.`articles`, CONSTRAINT `fk_articles_author` FOREIGN KEY (`author_id`) " +
+					"REFERENCES `users` (`id`))"},
+				kind:       store.ErrForeignKeyViolation,
+				constraint: "fk_articles_author",
+			},
+			{
+				err:        &mysql.MySQLError{Number: 3819, Message: "Check constraint 'chk_age' is violated."},
+				kind:       store.ErrCheckViolation,
+				constraint: "chk_age",
+			},
+			{
+				err:        &mysql.MySQLError{Number: 1048, Message: "Column 'name' cannot be null"},
+				kind:       store.ErrNotNullViolation,
+				constraint: "name",
+			},
+		} {
+			// WHEN
+			err := storeerrors.Translate(test.err)
+
+			// THEN
+			var constraintErr *store.ConstraintError
+			require.ErrorAs(t, err, &constraintErr, test.err.Message)
+			assert.ErrorIs(t, err, test.kind, test.err.Message)
+			assert.Equal(t, test.constraint, constraintErr.Constraint, test.err.Message)
+		}
+	})
+
+	t.Run("should-translate-sqlite-errors", func(t *testing.T) {
+		// GIVEN
+		db, err := sql.Open("sqlite3", "file::memory:?_foreign_keys=on")
+		require.NoError(t, err)
+
+		t.Cleanup(func() { _ = db.Close() })
+
+		_, err = db.Exec(`
+			CREATE TABLE users (id INTEGER PRIMARY KEY, email TEXT NOT NULL UNIQUE, age INTEGER CHECK (age >= 0));
+			CREATE TABLE articles (id INTEGER PRIMARY KEY, author_id INTEGER REFERENCES users (id));
+			INSERT INTO users (id, email, age) VALUES (1, 'john@doe.com', 42);
+		`)
+		require.NoError(t, err)
+
+		for _, test := range []struct {
+			stmt       string
+			kind       error
+			constraint string
+		}{
+			{"INSERT INTO users (email) VALUES ('john@doe.com')", store.ErrDuplicateKey, "users.email"},
+			{"INSERT INTO articles (author_id) VALUES (2)", store.ErrForeignKeyViolation, ""},
+			{"INSERT INTO users (email, age) VALUES ('jane@doe.com', -1)", store.ErrCheckViolation, "age >= 0"},
+			{"INSERT INTO users (email) VALUES (NULL)", store.ErrNotNullViolation, "users.email"},
+		} {
+			// WHEN
+			_, driverErr := db.Exec(test.stmt)
+			err := storeerrors.Translate(driverErr)
+
+			// THEN
+			var constraintErr *store.ConstraintError
+			require.ErrorAs(t, err, &constraintErr, test.stmt)
+			assert.ErrorIs(t, err, test.kind, test.stmt)
+			assert.Equal(t, test.constraint, constraintErr.Constraint, test.stmt)
+		}
+	})
+
+	t.Run("should-return-other-errors-as-is", func(t *testing.T) {
+		// GIVEN
+		driverErr := errors.New("connection refused")
+
+		// THEN
+		assert.Nil(t, storeerrors.Translate(nil))
+		assert.Equal(t, driverErr, storeerrors.Translate(driverErr))
+		assert.Equal(t, store.ErrNotFound, storeerrors.Translate(store.ErrNotFound))
+		assert.Equal(t, error(&mysql.MySQLError{Number: 1213}), storeerrors.Translate(&mysql.MySQLError{Number: 1213}))
+	})
+
+	t.Run("should-not-translate-twice", func(t *testing.T) {
+		// GIVEN
+		err := storeerrors.Translate(&pgError{Code: "23505"})
+
+		// THEN
+		assert.Same(t, err, storeerrors.Translate(err))
+	})
+}
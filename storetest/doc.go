@@ -0,0 +1,27 @@
+// Package storetest provides an in-memory fake of store.Store for unit testing services.
+//
+// Unlike the mockery mocks, the fake evaluates the query params it receives against the seeded entities,
+// so a test only states the data and the expected outcome instead of the exact arguments of every call.
+// Every call is recorded, and AssertCalled checks the params a service passed with matchers such as
+// HasFilter, which keeps tests independent of the order and number of the params.
+//
+// The params are evaluated by a memstore.Store, see the memstore package for the supported params.
+//
+// Example:
+//
+//	articles := storetest.NewFake[*model.Article, int64](
+//		&model.Article{ID: 1, Status: "draft"},
+//		&model.Article{ID: 2, Status: "active"},
+//	)
+//
+//	svc := service.NewArticleService(articles)
+//	active, err := svc.ListActive(ctx)
+//
+//	require.NoError(t, err)
+//	assert.Len(t, active, 1)
+//	articles.AssertCalled(t, "List", storetest.HasFilter("status", "active"))
+//
+// For integration tests against a real database, Fixtures loads YAML or JSON fixture files through the stores
+// within a transaction rolled back at the end of each test, and Snapshot dumps the state of the stores in the
+// same format, to be compared with a golden file.
+package storetest
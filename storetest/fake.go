@@ -0,0 +1,300 @@
+package storetest
+
+import (
+	"context"
+	"reflect"
+	"sync"
+
+	"github.com/infevocorp/goflexstore/memstore"
+	"github.com/infevocorp/goflexstore/query"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// Call records an invocation of a Fake method.
+//
+// Fields:
+//   - Method: The name of the called method, e.g. "List".
+//   - Params: The query params passed to the method. It is empty for methods without params, such as Create.
+//   - Entities: The entities passed to the method, if any.
+type Call[T any] struct {
+	Method   string
+	Params   query.Params
+	Entities []T
+}
+
+// NewFake creates a Fake seeded with the given entities.
+//
+// Type parameters:
+//   - T: The entity type, either a struct or a pointer to a struct.
+//   - ID: The type of the entity's identifier.
+//
+// Parameters:
+//   - seed: The entities initially stored in the fake.
+//
+// Returns:
+// A Fake implementing store.Store[T, ID].
+func NewFake[T store.Entity[ID], ID comparable](seed ...T) *Fake[T, ID] {
+	return &Fake[T, ID]{
+		store: memstore.New[T, ID](seed...),
+		errs:  map[string]error{},
+	}
+}
+
+// Fake is an in-memory store.Store evaluating query params against its entities, through a memstore.Store, and
+// recording every call.
+//
+// Entities are copied when they are stored and returned, so changes made by the caller are only visible
+// through Update, PartialUpdate and the other store methods, like with a database. Upsert detects conflicts
+// by ID only. The fake is safe for concurrent use.
+type Fake[T store.Entity[ID], ID comparable] struct {
+	mu    sync.Mutex
+	store *memstore.Store[T, ID]
+	calls []Call[T]
+	errs  map[string]error
+}
+
+// Seed adds entities to the fake without recording a call.
+func (f *Fake[T, ID]) Seed(entities ...T) {
+	f.store.Seed(entities...)
+}
+
+// Entities returns a copy of the stored entities, in insertion order.
+func (f *Fake[T, ID]) Entities() []T {
+	return f.store.Entities()
+}
+
+// Calls returns the recorded calls of the given methods, or all calls when no method is given.
+func (f *Fake[T, ID]) Calls(methods ...string) []Call[T] {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	calls := make([]Call[T], 0, len(f.calls))
+
+	for _, call := range f.calls {
+		if len(methods) == 0 || contains(methods, call.Method) {
+			calls = append(calls, call)
+		}
+	}
+
+	return calls
+}
+
+// FailWith makes every subsequent call of method return err. Passing a nil error clears it.
+// Failing calls are still recorded.
+func (f *Fake[T, ID]) FailWith(method string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err == nil {
+		delete(f.errs, method)
+		return
+	}
+
+	f.errs[method] = err
+}
+
+// Get returns the first entity matching params, or store.ErrNotFound.
+func (f *Fake[T, ID]) Get(ctx context.Context, params ...query.Param) (T, error) {
+	if err := f.record("Get", params); err != nil {
+		return *new(T), err
+	}
+
+	return f.store.Get(ctx, params...)
+}
+
+// List returns the entities matching params, ordered and paginated accordingly.
+func (f *Fake[T, ID]) List(ctx context.Context, params ...query.Param) ([]T, error) {
+	if err := f.record("List", params); err != nil {
+		return nil, err
+	}
+
+	return f.store.List(ctx, params...)
+}
+
+// ListWithCount returns the entities matching params, ordered and paginated accordingly, and the number of entities
+// matching the filters of params.
+func (f *Fake[T, ID]) ListWithCount(ctx context.Context, params ...query.Param) ([]T, int64, error) {
+	if err := f.record("ListWithCount", params); err != nil {
+		return nil, 0, err
+	}
+
+	return f.store.ListWithCount(ctx, params...)
+}
+
+// Count returns the number of entities matching the filters of params.
+func (f *Fake[T, ID]) Count(ctx context.Context, params ...query.Param) (int64, error) {
+	if err := f.record("Count", params); err != nil {
+		return 0, err
+	}
+
+	return f.store.Count(ctx, params...)
+}
+
+// Aggregate computes the aggregate of the field over the entities matching the filters of params.
+func (f *Fake[T, ID]) Aggregate(
+	ctx context.Context,
+	agg store.Aggregation,
+	field string,
+	params ...query.Param,
+) (float64, error) {
+	if err := f.record("Aggregate", params); err != nil {
+		return 0, err
+	}
+
+	return f.store.Aggregate(ctx, agg, field, params...)
+}
+
+// AggregateMany computes the aggregates over the entities matching the filters of params.
+func (f *Fake[T, ID]) AggregateMany(
+	ctx context.Context,
+	aggs []store.Aggregate,
+	params ...query.Param,
+) ([]float64, error) {
+	if err := f.record("AggregateMany", params); err != nil {
+		return nil, err
+	}
+
+	return f.store.AggregateMany(ctx, aggs, params...)
+}
+
+// Exists reports whether an entity matches the filters of params.
+func (f *Fake[T, ID]) Exists(ctx context.Context, params ...query.Param) (bool, error) {
+	if err := f.record("Exists", params); err != nil {
+		return false, err
+	}
+
+	return f.store.Exists(ctx, params...)
+}
+
+// Create stores the entity and returns its ID.
+// When the ID is the zero value of an integer type, the next ID is generated and, for pointer entities,
+// set on the given entity.
+func (f *Fake[T, ID]) Create(ctx context.Context, entity T) (ID, error) {
+	if err := f.record("Create", nil, entity); err != nil {
+		return *new(ID), err
+	}
+
+	return f.store.Create(ctx, entity)
+}
+
+// CreateMany stores the entities, generating their IDs like Create.
+func (f *Fake[T, ID]) CreateMany(ctx context.Context, entities []T) error {
+	if err := f.record("CreateMany", nil, entities...); err != nil {
+		return err
+	}
+
+	return f.store.CreateMany(ctx, entities)
+}
+
+// Upsert replaces the stored entity having the same ID, or creates it when there is none.
+// With onConflict.DoNothing, or when it does not satisfy onConflict.UpdateWhere, an existing entity is left unchanged.
+func (f *Fake[T, ID]) Upsert(ctx context.Context, entity T, onConflict store.OnConflict) (ID, error) {
+	if err := f.record("Upsert", nil, entity); err != nil {
+		return *new(ID), err
+	}
+
+	return f.store.Upsert(ctx, entity, onConflict)
+}
+
+// Update replaces the entities matching the filters of params with entity.
+// Without filters, the stored entity having the same ID is replaced.
+func (f *Fake[T, ID]) Update(ctx context.Context, entity T, params ...query.Param) error {
+	if err := f.record("Update", params, entity); err != nil {
+		return err
+	}
+
+	return f.store.Update(ctx, entity, params...)
+}
+
+// PartialUpdate copies the non-zero fields of entity to the entities matching the filters of params.
+// Without filters, the stored entity having the same ID is updated.
+func (f *Fake[T, ID]) PartialUpdate(ctx context.Context, entity T, params ...query.Param) error {
+	if err := f.record("PartialUpdate", params, entity); err != nil {
+		return err
+	}
+
+	return f.store.PartialUpdate(ctx, entity, params...)
+}
+
+// UpdateMany sets the fields of updates, matched by name like the filters, on the entities matching the filters of
+// params, and returns their number.
+func (f *Fake[T, ID]) UpdateMany(ctx context.Context, updates map[string]any, params ...query.Param) (int64, error) {
+	if err := f.record("UpdateMany", params); err != nil {
+		return 0, err
+	}
+
+	return f.store.UpdateMany(ctx, updates, params...)
+}
+
+// Delete removes the entities matching the filters of params.
+func (f *Fake[T, ID]) Delete(ctx context.Context, params ...query.Param) error {
+	if err := f.record("Delete", params); err != nil {
+		return err
+	}
+
+	return f.store.Delete(ctx, params...)
+}
+
+// DeleteReturning removes the entities matching the filters of params and returns them.
+func (f *Fake[T, ID]) DeleteReturning(ctx context.Context, params ...query.Param) ([]T, error) {
+	if err := f.record("DeleteReturning", params); err != nil {
+		return nil, err
+	}
+
+	return f.store.DeleteReturning(ctx, params...)
+}
+
+// Ping records the call and returns the error configured by FailWith, if any.
+func (f *Fake[T, ID]) Ping(_ context.Context) error {
+	return f.record("Ping", nil)
+}
+
+// record stores the call and returns the error configured by FailWith.
+func (f *Fake[T, ID]) record(method string, params []query.Param, entities ...T) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls = append(f.calls, Call[T]{
+		Method:   method,
+		Params:   query.NewParams(params...),
+		Entities: cloneAll(entities),
+	})
+
+	return f.errs[method]
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// clone returns a shallow copy of the struct pointed to by pointer entities, and the entity itself otherwise.
+func clone[T any](entity T) T {
+	v := reflect.ValueOf(entity)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return entity
+	}
+
+	c := reflect.New(v.Elem().Type())
+	c.Elem().Set(v.Elem())
+
+	return c.Interface().(T)
+}
+
+func cloneAll[T any](entities []T) []T {
+	if len(entities) == 0 {
+		return nil
+	}
+
+	cloned := make([]T, len(entities))
+	for i, entity := range entities {
+		cloned[i] = clone(entity)
+	}
+
+	return cloned
+}
@@ -0,0 +1,501 @@
+package storetest_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infevocorp/goflexstore/filters"
+	"github.com/infevocorp/goflexstore/query"
+	"github.com/infevocorp/goflexstore/store"
+	"github.com/infevocorp/goflexstore/storetest"
+)
+
+type Article struct {
+	ID       int64
+	Title    string
+	Status   string
+	AuthorID *int64
+	Views    int
+}
+
+func (a *Article) GetID() int64 {
+	return a.ID
+}
+
+func newArticles() *storetest.Fake[*Article, int64] {
+	return storetest.NewFake[*Article, int64](
+		&Article{ID: 1, Title: "first", Status: "draft", Views: 10},
+		&Article{ID: 2, Title: "second", Status: "active", Views: 30},
+		&Article{ID: 3, Title: "third", Status: "active", Views: 20},
+	)
+}
+
+func Test_Fake_Get(t *testing.T) {
+	t.Run("should-get-by-ids", func(t *testing.T) {
+		// GIVEN
+		articles := newArticles()
+
+		// WHEN
+		article, err := articles.Get(context.Background(), filters.IDs[int64](2))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, "second", article.Title)
+	})
+
+	t.Run("should-return-not-found", func(t *testing.T) {
+		// GIVEN
+		articles := newArticles()
+
+		// WHEN
+		_, err := articles.Get(context.Background(), query.Filter("status", "archived"))
+
+		// THEN
+		assert.ErrorIs(t, err, store.ErrNotFound)
+	})
+
+	t.Run("should-return-copies", func(t *testing.T) {
+		// GIVEN
+		articles := newArticles()
+
+		// WHEN
+		article, err := articles.Get(context.Background(), filters.IDs[int64](1))
+		require.NoError(t, err)
+
+		article.Title = "changed"
+
+		// THEN
+		article, err = articles.Get(context.Background(), filters.IDs[int64](1))
+		require.NoError(t, err)
+		assert.Equal(t, "first", article.Title)
+	})
+
+	t.Run("should-return-error-of-unknown-field", func(t *testing.T) {
+		// GIVEN
+		articles := newArticles()
+
+		// WHEN
+		_, err := articles.Get(context.Background(), query.Filter("unknown", 1))
+
+		// THEN
+		assert.EqualError(t, err, "memstore: entity *storetest_test.Article has no field matching unknown")
+	})
+}
+
+func Test_Fake_List(t *testing.T) {
+	tests := []struct {
+		name   string
+		params []query.Param
+		want   []int64
+	}{
+		{
+			name:   "without-params",
+			params: nil,
+			want:   []int64{1, 2, 3},
+		},
+		{
+			name:   "with-filter",
+			params: []query.Param{query.Filter("status", "active")},
+			want:   []int64{2, 3},
+		},
+		{
+			name:   "with-snake-case-filter-and-operator",
+			params: []query.Param{query.Filter("views", 20).WithOP(query.GTE)},
+			want:   []int64{2, 3},
+		},
+		{
+			name:   "with-in-filter",
+			params: []query.Param{query.Filter("id", []int{1, 3})},
+			want:   []int64{1, 3},
+		},
+		{
+			name:   "with-not-in-filter",
+			params: []query.Param{query.Filter("id", []int{1, 3}).WithOP(query.NEQ)},
+			want:   []int64{2},
+		},
+		{
+			name:   "with-in-operator",
+			params: []query.Param{query.Filter("status", []string{"draft"}).WithOP(query.IN)},
+			want:   []int64{1},
+		},
+		{
+			name:   "with-empty-in-operator",
+			params: []query.Param{query.Filter("id", []int64{}).WithOP(query.IN)},
+			want:   nil,
+		},
+		{
+			name:   "with-empty-not-in-operator",
+			params: []query.Param{query.Filter("id", []int64{}).WithOP(query.NOTIN)},
+			want:   []int64{1, 2, 3},
+		},
+		{
+			name:   "with-null-filter",
+			params: []query.Param{query.Filter("author_id", nil)},
+			want:   []int64{1, 2, 3},
+		},
+		{
+			name:   "with-like-filter",
+			params: []query.Param{query.Filter("title", "%ir%").WithOP(query.LIKE)},
+			want:   []int64{1, 3},
+		},
+		{
+			name:   "with-ilike-filter",
+			params: []query.Param{query.Filter("status", "ACT_VE").WithOP(query.ILIKE)},
+			want:   []int64{2, 3},
+		},
+		{
+			name: "with-or",
+			params: []query.Param{
+				query.OR(query.Filter("status", "draft"), query.Filter("views", 20)),
+			},
+			want: []int64{1, 3},
+		},
+		{
+			name: "with-nested-groups",
+			params: []query.Param{
+				query.OR(
+					query.AND(query.Filter("status", "active"), query.Filter("views", 25).WithOP(query.GT)),
+					query.AND(query.Filter("status", "draft"), query.OR(query.Filter("id", 1), query.Filter("id", 2))),
+				),
+			},
+			want: []int64{1, 2},
+		},
+		{
+			name: "with-not",
+			params: []query.Param{
+				query.Not(query.AND(query.Filter("status", "active"), query.Filter("views", 25).WithOP(query.GT))),
+			},
+			want: []int64{1, 3},
+		},
+		{
+			name:   "with-full-text",
+			params: []query.Param{query.FullText("ACT", "Title", "Status")},
+			want:   []int64{2, 3},
+		},
+		{
+			name:   "with-full-text-matching-every-word",
+			params: []query.Param{query.FullText("third active", "Title", "Status")},
+			want:   []int64{3},
+		},
+		{
+			name:   "with-order-by",
+			params: []query.Param{query.OrderBy("views", true)},
+			want:   []int64{2, 3, 1},
+		},
+		{
+			name:   "with-paginate",
+			params: []query.Param{query.OrderBy("views", false), query.Paginate(1, 1)},
+			want:   []int64{3},
+		},
+		{
+			name:   "with-offset-out-of-range",
+			params: []query.Param{query.Paginate(5, 1)},
+			want:   nil,
+		},
+	}
+
+	for i := range tests {
+		tt := tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			articles := newArticles()
+
+			got, err := articles.List(context.Background(), tt.params...)
+			require.NoError(t, err)
+
+			var ids []int64
+			for _, article := range got {
+				ids = append(ids, article.ID)
+			}
+
+			assert.Equal(t, tt.want, ids)
+		})
+	}
+}
+
+func Test_Fake_Raw(t *testing.T) {
+	t.Run("should-return-error-of-raw-param", func(t *testing.T) {
+		// GIVEN
+		articles := newArticles()
+
+		// WHEN
+		_, err := articles.List(context.Background(), query.OR(query.Raw("views > ?", 10), query.Filter("id", 1)))
+
+		// THEN
+		assert.EqualError(t, err, "memstore: raw params cannot be evaluated: views > ?")
+	})
+}
+
+func Test_Fake_Count(t *testing.T) {
+	t.Run("should-count-and-check-existence", func(t *testing.T) {
+		// GIVEN
+		articles := newArticles()
+		ctx := context.Background()
+
+		// WHEN
+		count, err := articles.Count(ctx, query.Filter("status", "active"), query.Paginate(0, 1))
+		require.NoError(t, err)
+
+		exists, err := articles.Exists(ctx, query.Filter("status", "archived"))
+		require.NoError(t, err)
+
+		// THEN
+		assert.Equal(t, int64(2), count)
+		assert.False(t, exists)
+	})
+}
+
+func Test_Fake_ListWithCount(t *testing.T) {
+	t.Run("should-list-page-and-count-matching", func(t *testing.T) {
+		// GIVEN
+		articles := newArticles()
+
+		// WHEN
+		list, count, err := articles.ListWithCount(context.Background(),
+			query.Filter("status", "active"),
+			query.OrderBy("views", true),
+			query.Paginate(1, 1),
+		)
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, []*Article{{ID: 3, Title: "third", Status: "active", Views: 20}}, list)
+		assert.Equal(t, int64(2), count)
+	})
+}
+
+func Test_Fake_AggregateMany(t *testing.T) {
+	t.Run("should-compute-aggregates-of-matching-entities", func(t *testing.T) {
+		// GIVEN
+		articles := newArticles()
+
+		// WHEN
+		results, err := articles.AggregateMany(context.Background(), []store.Aggregate{
+			{Aggregation: store.Sum, Field: "views"},
+			{Aggregation: store.Avg, Field: "views"},
+			{Aggregation: store.Min, Field: "views"},
+			{Aggregation: store.Max, Field: "views"},
+			{Aggregation: store.CountOf, Field: "AuthorID"},
+			{Aggregation: store.CountOf},
+		}, query.Filter("status", "active"))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, []float64{50, 25, 20, 30, 0, 2}, results)
+	})
+
+	t.Run("should-return-zero-without-matching-entity", func(t *testing.T) {
+		// GIVEN
+		articles := newArticles()
+
+		// WHEN
+		avg, err := articles.Aggregate(context.Background(), store.Avg, "views", query.Filter("status", "archived"))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Zero(t, avg)
+	})
+
+	t.Run("should-return-error-of-non-numeric-field", func(t *testing.T) {
+		// GIVEN
+		articles := newArticles()
+
+		// WHEN
+		_, err := articles.Aggregate(context.Background(), store.Sum, "title")
+
+		// THEN
+		assert.ErrorContains(t, err, "is not a number")
+	})
+}
+
+func Test_Fake_UpdateMany(t *testing.T) {
+	t.Run("should-update-matching-entities", func(t *testing.T) {
+		// GIVEN
+		articles := newArticles()
+
+		// WHEN
+		updated, err := articles.UpdateMany(context.Background(),
+			map[string]any{"status": "archived", "Views": 0},
+			query.Filter("status", "active"),
+		)
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), updated)
+		assert.Equal(t, []*Article{
+			{ID: 1, Title: "first", Status: "draft", Views: 10},
+			{ID: 2, Title: "second", Status: "archived"},
+			{ID: 3, Title: "third", Status: "archived"},
+		}, articles.Entities())
+	})
+
+	t.Run("should-return-error-of-unknown-field", func(t *testing.T) {
+		// GIVEN
+		articles := newArticles()
+
+		// WHEN
+		_, err := articles.UpdateMany(context.Background(), map[string]any{"Author": "john"})
+
+		// THEN
+		assert.ErrorContains(t, err, "has no field matching Author")
+	})
+}
+
+func Test_Fake_Create(t *testing.T) {
+	t.Run("should-generate-id", func(t *testing.T) {
+		// GIVEN
+		articles := newArticles()
+		article := &Article{Title: "fourth"}
+
+		// WHEN
+		id, err := articles.Create(context.Background(), article)
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), id)
+		assert.Equal(t, int64(4), article.ID)
+		assert.Len(t, articles.Entities(), 4)
+	})
+
+	t.Run("should-keep-id", func(t *testing.T) {
+		// GIVEN
+		articles := storetest.NewFake[*Article, int64]()
+
+		// WHEN
+		err := articles.CreateMany(context.Background(), []*Article{{ID: 10}, {}})
+
+		// THEN
+		require.NoError(t, err)
+
+		entities := articles.Entities()
+		require.Len(t, entities, 2)
+		assert.Equal(t, int64(10), entities[0].ID)
+		assert.Equal(t, int64(11), entities[1].ID)
+	})
+
+	t.Run("should-upsert", func(t *testing.T) {
+		// GIVEN
+		articles := newArticles()
+		ctx := context.Background()
+
+		// WHEN
+		_, err := articles.Upsert(ctx, &Article{ID: 1, Title: "upserted"}, store.OnConflict{UpdateAll: true})
+		require.NoError(t, err)
+
+		_, err = articles.Upsert(ctx, &Article{ID: 2, Title: "ignored"}, store.OnConflict{DoNothing: true})
+		require.NoError(t, err)
+
+		// THEN
+		entities := articles.Entities()
+		assert.Equal(t, "upserted", entities[0].Title)
+		assert.Equal(t, "second", entities[1].Title)
+	})
+
+	t.Run("should-upsert-when-update-where-matches", func(t *testing.T) {
+		// GIVEN
+		articles := newArticles()
+		ctx := context.Background()
+		onConflict := store.OnConflict{
+			UpdateAll:   true,
+			UpdateWhere: []query.FilterParam{query.Filter("views", store.Excluded("views")).WithOP(query.LT)},
+		}
+
+		// WHEN
+		_, err := articles.Upsert(ctx, &Article{ID: 1, Title: "more-views", Views: 11}, onConflict)
+		require.NoError(t, err)
+
+		_, err = articles.Upsert(ctx, &Article{ID: 2, Title: "less-views", Views: 29}, onConflict)
+		require.NoError(t, err)
+
+		// THEN
+		entities := articles.Entities()
+		assert.Equal(t, "more-views", entities[0].Title)
+		assert.Equal(t, "second", entities[1].Title)
+	})
+}
+
+func Test_Fake_Update(t *testing.T) {
+	t.Run("should-update-by-id", func(t *testing.T) {
+		// GIVEN
+		articles := newArticles()
+
+		// WHEN
+		err := articles.Update(context.Background(), &Article{ID: 1, Title: "updated"})
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, &Article{ID: 1, Title: "updated"}, articles.Entities()[0])
+	})
+
+	t.Run("should-partially-update-matching-entities", func(t *testing.T) {
+		// GIVEN
+		articles := newArticles()
+
+		// WHEN
+		err := articles.PartialUpdate(context.Background(), &Article{Status: "archived"},
+			query.Filter("status", "active"))
+
+		// THEN
+		require.NoError(t, err)
+
+		entities := articles.Entities()
+		assert.Equal(t, "draft", entities[0].Status)
+		assert.Equal(t, &Article{ID: 2, Title: "second", Status: "archived", Views: 30}, entities[1])
+		assert.Equal(t, "archived", entities[2].Status)
+	})
+
+	t.Run("should-delete-matching-entities", func(t *testing.T) {
+		// GIVEN
+		articles := newArticles()
+
+		// WHEN
+		err := articles.Delete(context.Background(), query.Filter("status", "active"))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, []*Article{{ID: 1, Title: "first", Status: "draft", Views: 10}}, articles.Entities())
+	})
+}
+
+func Test_Fake_DeleteReturning(t *testing.T) {
+	t.Run("should-return-deleted-entities", func(t *testing.T) {
+		// GIVEN
+		var articles store.Store[*Article, int64] = newArticles()
+
+		// WHEN
+		deleted, err := articles.DeleteReturning(context.Background(), query.Filter("status", "draft"))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, []*Article{{ID: 1, Title: "first", Status: "draft", Views: 10}}, deleted)
+
+		count, err := articles.Count(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+	})
+}
+
+func Test_Fake_FailWith(t *testing.T) {
+	t.Run("should-fail-and-record-call", func(t *testing.T) {
+		// GIVEN
+		articles := newArticles()
+		errDB := errors.New("db error")
+
+		articles.FailWith("List", errDB)
+
+		// WHEN
+		_, err := articles.List(context.Background(), query.Filter("status", "active"))
+
+		// THEN
+		require.ErrorIs(t, err, errDB)
+		assert.Len(t, articles.Calls("List"), 1)
+
+		articles.FailWith("List", nil)
+
+		_, err = articles.List(context.Background())
+		assert.NoError(t, err)
+	})
+}
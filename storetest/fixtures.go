@@ -0,0 +1,267 @@
+package storetest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/infevocorp/goflexstore/opscope"
+	"github.com/infevocorp/goflexstore/query"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// errRollback ends the transactions begun by Fixtures.Begin, so that they are rolled back.
+var errRollback = errors.New("storetest: rolled back at the end of the test")
+
+// Fixtures loads fixture entities through the stores of an application, within a transaction of their
+// operation scope rolled back at the end of each test, so that integration tests against a real database
+// start from the same state without truncating tables.
+//
+// Fixture files are YAML or JSON documents mapping the name of a set, given to AddStore, to its entities.
+// Keys are resolved to entity fields like filter names: case insensitively, ignoring underscores. Values are
+// decoded into the fields as JSON values, so times are written in RFC 3339.
+//
+//	users:
+//	  - id: 1
+//	    name: john
+//	articles:
+//	  - title: Hello
+//	    author_id: 1
+//	    published_at: 2024-01-02T15:04:05Z
+//
+// Sets are created with CreateMany in the order of the AddStore calls, e.g. users before the articles
+// referencing them, whatever their order in the files.
+type Fixtures struct {
+	scope opscope.Scope
+	sets  []fixtureSet
+}
+
+// fixtureSet loads and snapshots the entities of a store.
+type fixtureSet struct {
+	name     string
+	load     func(ctx context.Context, records []map[string]any) error
+	snapshot func(ctx context.Context) ([]map[string]any, error)
+}
+
+// NewFixtures creates Fixtures loading entities within transactions of the scope shared by the stores.
+//
+// Example:
+//
+//	fixtures := storetest.NewFixtures(scope)
+//	storetest.AddStore(fixtures, "users", userStore)
+//	storetest.AddStore(fixtures, "articles", articleStore)
+//
+//	func TestArticleService(t *testing.T) {
+//		ctx := fixtures.Begin(t, "testdata/articles.yaml")
+//		// the services called with ctx see the fixtures, and their writes are rolled back after the test.
+//	}
+func NewFixtures(scope opscope.Scope) *Fixtures {
+	return &Fixtures{
+		scope: scope,
+	}
+}
+
+// AddStore adds the store loading the entities of the set named name in the fixture files.
+// The store must share the operation scope of the fixtures for its operations to be rolled back.
+func AddStore[T store.Entity[ID], ID comparable](f *Fixtures, name string, s store.Store[T, ID]) {
+	f.sets = append(f.sets, fixtureSet{
+		name: name,
+		load: func(ctx context.Context, records []map[string]any) error {
+			entities := make([]T, len(records))
+
+			for i, record := range records {
+				if err := decodeRecord(&entities[i], record); err != nil {
+					return fmt.Errorf("storetest: %s #%d: %w", name, i, err)
+				}
+			}
+
+			return s.CreateMany(ctx, entities)
+		},
+		snapshot: func(ctx context.Context) ([]map[string]any, error) {
+			entities, err := s.List(ctx, query.OrderBy("ID", false))
+			if err != nil {
+				return nil, err
+			}
+
+			records := make([]map[string]any, len(entities))
+			for i, entity := range entities {
+				records[i] = encodeRecord(entity)
+			}
+
+			return records, nil
+		},
+	})
+}
+
+// Begin begins a transaction of the scope, loads the fixture files within it and returns its context.
+// The transaction is rolled back when the test and its subtests complete.
+// The test fails immediately if the transaction cannot begin or a file cannot be loaded.
+func (f *Fixtures) Begin(t testing.TB, paths ...string) context.Context {
+	t.Helper()
+
+	ctx, err := f.scope.Begin(context.Background())
+	if err != nil {
+		t.Fatalf("storetest: begin fixtures transaction: %v", err)
+	}
+
+	t.Cleanup(func() {
+		// End returns the error the transaction ended with, joined with the rollback error if any.
+		if err := f.scope.End(ctx, errRollback); err != nil && err != errRollback {
+			t.Errorf("storetest: roll back fixtures transaction: %v", err)
+		}
+	})
+
+	for _, path := range paths {
+		if err := f.LoadFile(ctx, path); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return ctx
+}
+
+// LoadFile loads the fixtures of a YAML or JSON file, see Load.
+func (f *Fixtures) LoadFile(ctx context.Context, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("storetest: %w", err)
+	}
+
+	if err := f.Load(ctx, data); err != nil {
+		return fmt.Errorf("%w (%s)", err, path)
+	}
+
+	return nil
+}
+
+// Load creates the entities of a YAML or JSON document through the stores of their sets.
+// It returns an error if the document names a set without store.
+func (f *Fixtures) Load(ctx context.Context, data []byte) error {
+	var doc map[string][]map[string]any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("storetest: decode fixtures: %w", err)
+	}
+
+	for name := range doc {
+		if !f.has(name) {
+			return fmt.Errorf("storetest: no store added for fixtures %s", name)
+		}
+	}
+
+	for _, set := range f.sets {
+		if records, ok := doc[set.name]; ok {
+			if err := set.load(ctx, records); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Snapshot returns the entities of every store, ordered by ID, as a YAML document in the format of
+// the fixture files. Comparing it with a golden file asserts the whole state written by a test,
+// and it can be loaded back as fixtures.
+func (f *Fixtures) Snapshot(ctx context.Context) ([]byte, error) {
+	doc := make(map[string][]map[string]any, len(f.sets))
+
+	for _, set := range f.sets {
+		records, err := set.snapshot(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("storetest: snapshot %s: %w", set.name, err)
+		}
+
+		doc[set.name] = records
+	}
+
+	return yaml.Marshal(doc)
+}
+
+func (f *Fixtures) has(name string) bool {
+	for _, set := range f.sets {
+		if set.name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// decodeRecord sets the fields of the entity matching the keys of the record, allocating the entity when
+// it is a nil pointer.
+func decodeRecord[T any](entity *T, record map[string]any) error {
+	v := reflect.ValueOf(entity).Elem()
+	if v.Kind() == reflect.Pointer && v.IsNil() {
+		v.Set(reflect.New(v.Type().Elem()))
+	}
+
+	v = indirect(v)
+	if v.Kind() != reflect.Struct {
+		return fmt.Errorf("entity %T is not a struct", *entity)
+	}
+
+	for key, value := range record {
+		normalized := normalize(key)
+
+		field := v.FieldByNameFunc(func(fieldName string) bool {
+			return normalize(fieldName) == normalized
+		})
+
+		if !field.IsValid() || !field.CanSet() {
+			return fmt.Errorf("entity %T has no field matching %s", *entity, key)
+		}
+
+		data, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+
+		if err := json.Unmarshal(data, field.Addr().Interface()); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+// encodeRecord returns the exported fields of the entity by name.
+func encodeRecord(entity any) map[string]any {
+	v := indirect(reflect.ValueOf(entity))
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var (
+		t      = v.Type()
+		record = make(map[string]any, t.NumField())
+	)
+
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() {
+			record[t.Field(i).Name] = v.Field(i).Interface()
+		}
+	}
+
+	return record
+}
+
+// normalize returns the name in lower case without underscores, so that "FirstName" and "first_name" match.
+func normalize(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+}
+
+// indirect dereferences non-nil pointers.
+func indirect(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+
+	return v
+}
@@ -0,0 +1,164 @@
+package storetest_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	mockopscope "github.com/infevocorp/goflexstore/mocks/opscope"
+	"github.com/infevocorp/goflexstore/storetest"
+)
+
+type Author struct {
+	ID       int64
+	Name     string
+	JoinedAt time.Time
+}
+
+func (a *Author) GetID() int64 {
+	return a.ID
+}
+
+type txKey struct{}
+
+// newFixtures returns fixtures loading authors before articles, within transactions of a mocked scope.
+func newFixtures(t *testing.T) (
+	*storetest.Fixtures,
+	*storetest.Fake[*Author, int64],
+	*storetest.Fake[*Article, int64],
+	*mockopscope.Scope,
+) {
+	var (
+		scope    = mockopscope.NewScope(t)
+		fixtures = storetest.NewFixtures(scope)
+		authors  = storetest.NewFake[*Author, int64]()
+		articles = storetest.NewFake[*Article, int64]()
+	)
+
+	storetest.AddStore[*Author, int64](fixtures, "authors", authors)
+	storetest.AddStore[*Article, int64](fixtures, "articles", articles)
+
+	return fixtures, authors, articles, scope
+}
+
+func Test_Fixtures_Begin(t *testing.T) {
+	t.Run("should-load-fixtures-and-roll-back-after-test", func(t *testing.T) {
+		// GIVEN
+		fixtures, authors, articles, scope := newFixtures(t)
+
+		scope.EXPECT().Begin(mock.Anything).RunAndReturn(func(ctx context.Context) (context.Context, error) {
+			return context.WithValue(ctx, txKey{}, true), nil
+		})
+		scope.EXPECT().End(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, err error) error {
+			return err
+		})
+
+		// WHEN
+		t.Run("test", func(t *testing.T) {
+			ctx := fixtures.Begin(t, "testdata/fixtures.yaml")
+
+			// THEN
+			assert.Equal(t, true, ctx.Value(txKey{}))
+			scope.AssertNotCalled(t, "End", mock.Anything, mock.Anything)
+		})
+
+		authorID := int64(1)
+
+		assert.Equal(t, []*Author{
+			{ID: 1, Name: "john", JoinedAt: time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)},
+		}, authors.Entities())
+		assert.Equal(t, []*Article{
+			{ID: 10, Title: "Hello", Status: "active", AuthorID: &authorID},
+		}, articles.Entities())
+		assert.Equal(t, []string{"CreateMany"}, callMethods(authors.Calls()))
+		scope.AssertCalled(t, "End", mock.Anything, mock.Anything)
+	})
+}
+
+func Test_Fixtures_Load(t *testing.T) {
+	t.Run("should-load-json", func(t *testing.T) {
+		// GIVEN
+		fixtures, authors, _, _ := newFixtures(t)
+
+		// WHEN
+		err := fixtures.Load(context.Background(), []byte(`{"authors": [{"ID": 2, "Name": "jane"}]}`))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, []*Author{{ID: 2, Name: "jane"}}, authors.Entities())
+	})
+
+	t.Run("should-fail-with-unknown-set", func(t *testing.T) {
+		// GIVEN
+		fixtures, _, _, _ := newFixtures(t)
+
+		// WHEN
+		err := fixtures.Load(context.Background(), []byte("comments:\n  - id: 1\n"))
+
+		// THEN
+		assert.EqualError(t, err, "storetest: no store added for fixtures comments")
+	})
+
+	t.Run("should-fail-with-unknown-field", func(t *testing.T) {
+		// GIVEN
+		fixtures, _, _, _ := newFixtures(t)
+
+		// WHEN
+		err := fixtures.Load(context.Background(), []byte("authors:\n  - id: 1\n  - email: john@example.com\n"))
+
+		// THEN
+		assert.EqualError(t, err, "storetest: authors #1: entity *storetest_test.Author has no field matching email")
+	})
+
+	t.Run("should-fail-with-missing-file", func(t *testing.T) {
+		// GIVEN
+		fixtures, _, _, _ := newFixtures(t)
+
+		// WHEN
+		err := fixtures.LoadFile(context.Background(), "testdata/missing.yaml")
+
+		// THEN
+		assert.ErrorContains(t, err, "missing.yaml")
+	})
+}
+
+func Test_Fixtures_Snapshot(t *testing.T) {
+	t.Run("should-snapshot-entities-as-fixtures", func(t *testing.T) {
+		// GIVEN
+		fixtures, authors, _, _ := newFixtures(t)
+		authors.Seed(&Author{ID: 2, Name: "jane"}, &Author{ID: 1, Name: "john"})
+
+		// WHEN
+		snapshot, err := fixtures.Snapshot(context.Background())
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, `articles: []
+authors:
+    - ID: 1
+      JoinedAt: 0001-01-01T00:00:00Z
+      Name: john
+    - ID: 2
+      JoinedAt: 0001-01-01T00:00:00Z
+      Name: jane
+`, string(snapshot))
+
+		// the snapshot loads back as fixtures.
+		reloaded, reloadedAuthors, _, _ := newFixtures(t)
+		require.NoError(t, reloaded.Load(context.Background(), snapshot))
+		assert.ElementsMatch(t, authors.Entities(), reloadedAuthors.Entities())
+	})
+}
+
+func callMethods[T any](calls []storetest.Call[T]) []string {
+	methods := make([]string, len(calls))
+	for i, call := range calls {
+		methods[i] = call.Method
+	}
+
+	return methods
+}
@@ -0,0 +1,189 @@
+package storetest
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/infevocorp/goflexstore/memstore"
+	"github.com/infevocorp/goflexstore/query"
+)
+
+// TestingT is the subset of testing.TB used by the assertions of the fake.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+// Matcher checks the query params of a recorded call.
+type Matcher struct {
+	desc  string
+	match func(query.Params) bool
+}
+
+// Match reports whether params satisfy the matcher.
+func (m Matcher) Match(params query.Params) bool {
+	return m.match(params)
+}
+
+// String describes the matcher in assertion failures.
+func (m Matcher) String() string {
+	return m.desc
+}
+
+// MatchFunc creates a Matcher from a function, described by desc in assertion failures.
+func MatchFunc(desc string, match func(query.Params) bool) Matcher {
+	return Matcher{
+		desc:  desc,
+		match: match,
+	}
+}
+
+// HasFilter matches params having an EQ filter on name with the given value.
+// Filter names are compared case insensitively, ignoring underscores, and numbers are compared by value,
+// so HasFilter("status_id", 1) matches query.Filter("StatusID", int64(1)).
+func HasFilter(name string, value any) Matcher {
+	return HasFilterOP(name, query.EQ, value)
+}
+
+// HasFilterOP matches params having a filter on name with the given operator and value.
+func HasFilterOP(name string, op query.Operator, value any) Matcher {
+	return MatchFunc(fmt.Sprintf("filter %s %s %v", name, op, value), func(params query.Params) bool {
+		for _, p := range params.Get(query.TypeFilter) {
+			filter := p.(query.FilterParam)
+
+			if normalize(filter.Name) == normalize(name) && filter.Operator == op && sameValue(filter.Value, value) {
+				return true
+			}
+		}
+
+		return false
+	})
+}
+
+// HasParam matches params containing a param deeply equal to p.
+func HasParam(p query.Param) Matcher {
+	return MatchFunc(fmt.Sprintf("%s param %+v", p.ParamType(), p), func(params query.Params) bool {
+		for _, param := range params.Get(p.ParamType()) {
+			if reflect.DeepEqual(param, p) {
+				return true
+			}
+		}
+
+		return false
+	})
+}
+
+// HasParamType matches params containing at least one param of the given type, e.g. query.TypePaginate.
+func HasParamType(paramType string) Matcher {
+	return MatchFunc(paramType+" param", func(params query.Params) bool {
+		return len(params.Get(paramType)) > 0
+	})
+}
+
+// AssertCalled asserts that method was called at least once with params satisfying every matcher.
+// It reports the recorded calls of the method otherwise.
+func (f *Fake[T, ID]) AssertCalled(t TestingT, method string, matchers ...Matcher) bool {
+	t.Helper()
+
+	calls := f.Calls(method)
+
+	for _, call := range calls {
+		if matchesAll(call.Params, matchers) {
+			return true
+		}
+	}
+
+	t.Errorf("storetest: expected %s to be called with %s\nrecorded calls:%s",
+		method, describe(matchers), describeCalls(calls))
+
+	return false
+}
+
+// AssertNotCalled asserts that method was never called with params satisfying every matcher.
+func (f *Fake[T, ID]) AssertNotCalled(t TestingT, method string, matchers ...Matcher) bool {
+	t.Helper()
+
+	for _, call := range f.Calls(method) {
+		if matchesAll(call.Params, matchers) {
+			t.Errorf("storetest: expected %s not to be called with %s\nbut got params %+v",
+				method, describe(matchers), call.Params.Params())
+
+			return false
+		}
+	}
+
+	return true
+}
+
+func matchesAll(params query.Params, matchers []Matcher) bool {
+	for _, m := range matchers {
+		if !m.Match(params) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func sameValue(a, b any) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+
+	if isList(va) && isList(vb) {
+		if va.Len() != vb.Len() {
+			return false
+		}
+
+		for i := 0; i < va.Len(); i++ {
+			if !memstore.Equal(va.Index(i).Interface(), vb.Index(i).Interface()) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return memstore.Equal(a, b)
+}
+
+func isList(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice:
+		return v.Type().Elem().Kind() != reflect.Uint8
+	case reflect.Array:
+		return true
+	default:
+		return false
+	}
+}
+
+func describe(matchers []Matcher) string {
+	if len(matchers) == 0 {
+		return "any params"
+	}
+
+	descs := make([]string, len(matchers))
+	for i, m := range matchers {
+		descs[i] = m.String()
+	}
+
+	return strings.Join(descs, ", ")
+}
+
+func describeCalls[T any](calls []Call[T]) string {
+	if len(calls) == 0 {
+		return " none"
+	}
+
+	var b strings.Builder
+
+	for _, call := range calls {
+		fmt.Fprintf(&b, "\n  - %+v", call.Params.Params())
+	}
+
+	return b.String()
+}
@@ -0,0 +1,109 @@
+package storetest_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infevocorp/goflexstore/filters"
+	"github.com/infevocorp/goflexstore/query"
+	"github.com/infevocorp/goflexstore/storetest"
+)
+
+type recorderT struct {
+	errors []string
+}
+
+func (r *recorderT) Helper() {}
+
+func (r *recorderT) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func Test_Matchers(t *testing.T) {
+	params := query.NewParams(
+		query.Filter("StatusID", int64(1)),
+		query.Filter("views", 10).WithOP(query.GT),
+		filters.IDs(1, 2),
+		query.Paginate(0, 10),
+	)
+
+	tests := []struct {
+		name    string
+		matcher storetest.Matcher
+		want    bool
+	}{
+		{name: "has-filter", matcher: storetest.HasFilter("status_id", 1), want: true},
+		{name: "has-filter-with-other-value", matcher: storetest.HasFilter("status_id", 2), want: false},
+		{name: "has-filter-with-other-operator", matcher: storetest.HasFilter("views", 10), want: false},
+		{name: "has-filter-op", matcher: storetest.HasFilterOP("views", query.GT, 10), want: true},
+		{name: "has-filter-with-list", matcher: storetest.HasFilter("ID", []int64{1, 2}), want: true},
+		{name: "has-param", matcher: storetest.HasParam(query.Paginate(0, 10)), want: true},
+		{name: "has-param-with-other-value", matcher: storetest.HasParam(query.Paginate(10, 10)), want: false},
+		{name: "has-param-type", matcher: storetest.HasParamType(query.TypeOrderBy), want: false},
+	}
+
+	for i := range tests {
+		tt := tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.matcher.Match(params))
+		})
+	}
+}
+
+func Test_Fake_AssertCalled(t *testing.T) {
+	t.Run("should-pass-when-a-call-matches", func(t *testing.T) {
+		// GIVEN
+		articles := newArticles()
+		rt := &recorderT{}
+
+		_, err := articles.List(context.Background(), query.Filter("status", "active"), query.Paginate(0, 10))
+		require.NoError(t, err)
+
+		// WHEN
+		ok := articles.AssertCalled(rt, "List", storetest.HasFilter("status", "active"))
+
+		// THEN
+		assert.True(t, ok)
+		assert.Empty(t, rt.errors)
+		assert.True(t, articles.AssertNotCalled(rt, "Delete"))
+	})
+
+	t.Run("should-report-recorded-calls", func(t *testing.T) {
+		// GIVEN
+		articles := newArticles()
+		rt := &recorderT{}
+
+		_, err := articles.List(context.Background(), query.Filter("status", "draft"))
+		require.NoError(t, err)
+
+		// WHEN
+		ok := articles.AssertCalled(rt, "List", storetest.HasFilter("status", "active"))
+
+		// THEN
+		assert.False(t, ok)
+		assert.Equal(t, []string{
+			"storetest: expected List to be called with filter status EQ active\n" +
+				"recorded calls:\n  - [{Name:status Operator:EQ Value:draft}]",
+		}, rt.errors)
+	})
+
+	t.Run("should-fail-when-not-expected-call-matches", func(t *testing.T) {
+		// GIVEN
+		articles := newArticles()
+		rt := &recorderT{}
+
+		_, err := articles.Exists(context.Background(), query.Filter("status", "draft"))
+		require.NoError(t, err)
+
+		// WHEN
+		ok := articles.AssertNotCalled(rt, "Exists", storetest.HasFilter("status", "draft"))
+
+		// THEN
+		assert.False(t, ok)
+		assert.Len(t, rt.errors, 1)
+	})
+}
@@ -0,0 +1,23 @@
+package tenancy
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrMissingTenant is returned by tenant scoped stores when the context carries no tenant.
+var ErrMissingTenant = errors.New("missing tenant")
+
+type contextKey struct{}
+
+// WithTenant returns a copy of ctx carrying the given tenant ID.
+func WithTenant(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, contextKey{}, tenantID)
+}
+
+// FromContext returns the tenant ID carried by ctx, if any.
+func FromContext(ctx context.Context) (string, bool) {
+	tenantID, ok := ctx.Value(contextKey{}).(string)
+
+	return tenantID, ok && tenantID != ""
+}
@@ -0,0 +1,44 @@
+package tenancy_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infevocorp/goflexstore/tenancy"
+)
+
+func Test_FromContext(t *testing.T) {
+	t.Run("should-return-tenant", func(t *testing.T) {
+		// GIVEN
+		ctx := tenancy.WithTenant(context.Background(), "acme")
+
+		// WHEN
+		tenantID, ok := tenancy.FromContext(ctx)
+
+		// THEN
+		assert.True(t, ok)
+		assert.Equal(t, "acme", tenantID)
+	})
+
+	t.Run("should-return-false-when-no-tenant", func(t *testing.T) {
+		// WHEN
+		tenantID, ok := tenancy.FromContext(context.Background())
+
+		// THEN
+		assert.False(t, ok)
+		assert.Empty(t, tenantID)
+	})
+
+	t.Run("should-return-false-when-tenant-is-empty", func(t *testing.T) {
+		// GIVEN
+		ctx := tenancy.WithTenant(context.Background(), "")
+
+		// WHEN
+		_, ok := tenancy.FromContext(ctx)
+
+		// THEN
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,21 @@
+// Package tenancy scopes stores to the tenant carried by the context.
+//
+// The tenant is attached to the context with WithTenant, typically by an authentication middleware, and
+// stores decorated with NewStore automatically restrict every operation to that tenant: reads, updates and
+// deletes get a tenant filter appended to their params, and created entities get their tenant field set.
+// Operations on a context without a tenant fail with ErrMissingTenant, so a forgotten middleware cannot
+// leak data across tenants.
+//
+// Stores composed with store.Chain use Middleware instead of NewStore.
+//
+// Example:
+//
+//	projectStore := tenancy.NewStore[*model.Project, int64](
+//		gormstore.New[*model.Project, dto.Project, int64](scope),
+//	)
+//
+//	ctx = tenancy.WithTenant(ctx, "acme")
+//
+//	// SELECT * FROM projects WHERE tenant_id = 'acme'
+//	projects, err := projectStore.List(ctx)
+package tenancy
@@ -0,0 +1,103 @@
+package tenancy
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/infevocorp/goflexstore/query"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// Middleware returns a store.Middleware restricting every operation to the tenant carried by the context,
+// for stores decorated with store.Chain. It behaves like the Store returned by NewStore.
+// Ping is forwarded without requiring a tenant, so that health checks do not need one.
+//
+// Example:
+//
+//	projectStore := store.Chain[*model.Project, int64](inner, tenancy.Middleware(tenancy.WithField("OrgID")))
+func Middleware(opts ...Option) store.Middleware {
+	o := options{
+		field: "TenantID",
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return func(next store.OperationFunc) store.OperationFunc {
+		return func(ctx context.Context, op *store.Operation) (any, error) {
+			if op.Method == store.MethodPing {
+				return next(ctx, op)
+			}
+
+			tenantID, ok := FromContext(ctx)
+			if !ok {
+				return nil, ErrMissingTenant
+			}
+
+			switch op.Method {
+			case store.MethodCreate, store.MethodUpsert:
+				if op.Method == store.MethodUpsert {
+					if err := checkConflict(op.OnConflict, o.field); err != nil {
+						return nil, err
+					}
+				}
+
+				if err := setTenant(op.Input, o.field, tenantID); err != nil {
+					return nil, err
+				}
+
+				return next(ctx, op)
+			case store.MethodCreateMany:
+				entities := reflect.ValueOf(op.Input)
+				if entities.Kind() == reflect.Slice {
+					for i := 0; i < entities.Len(); i++ {
+						if err := setTenant(entities.Index(i).Interface(), o.field, tenantID); err != nil {
+							return nil, err
+						}
+					}
+				}
+
+				return next(ctx, op)
+			case store.MethodUpdate, store.MethodPartialUpdate:
+				if err := setTenant(op.Input, o.field, tenantID); err != nil {
+					return nil, err
+				}
+
+				if id, ok := entityID(op.Input); ok {
+					op.Params = append([]query.Param{id}, op.Params...)
+				}
+			case store.MethodUpdateMany:
+				if updates, ok := op.Input.(map[string]any); ok {
+					if err := checkUpdates(updates, o.field); err != nil {
+						return nil, err
+					}
+				}
+			}
+
+			scoped := make([]query.Param, 0, len(op.Params)+1)
+			scoped = append(scoped, op.Params...)
+			op.Params = append(scoped, query.Filter(o.field, tenantID))
+
+			return next(ctx, op)
+		}
+	}
+}
+
+// entityID returns an ID filter like filters.IDs when the entity has a non-zero ID.
+func entityID(entity any) (query.FilterParam, bool) {
+	getID := reflect.ValueOf(entity).MethodByName("GetID")
+	if !getID.IsValid() || getID.Type().NumIn() != 0 || getID.Type().NumOut() != 1 {
+		return query.FilterParam{}, false
+	}
+
+	id := getID.Call(nil)[0]
+	if id.IsZero() {
+		return query.FilterParam{}, false
+	}
+
+	ids := reflect.MakeSlice(reflect.SliceOf(id.Type()), 1, 1)
+	ids.Index(0).Set(id)
+
+	return query.Filter("ID", ids.Interface()), true
+}
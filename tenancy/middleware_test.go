@@ -0,0 +1,107 @@
+package tenancy_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infevocorp/goflexstore/filters"
+	mockstore "github.com/infevocorp/goflexstore/mocks/store"
+	"github.com/infevocorp/goflexstore/query"
+	"github.com/infevocorp/goflexstore/store"
+	"github.com/infevocorp/goflexstore/tenancy"
+)
+
+func Test_Middleware(t *testing.T) {
+	t.Run("should-append-tenant-filter", func(t *testing.T) {
+		// GIVEN
+		var (
+			ctx   = tenancy.WithTenant(context.Background(), "acme")
+			inner = mockstore.NewStore[*Project, int](t)
+		)
+
+		inner.EXPECT().
+			Delete(ctx, query.Filter("Name", "api"), query.Filter("TenantID", "acme")).
+			Return(nil)
+
+		s := store.Chain[*Project, int](inner, tenancy.Middleware())
+
+		// WHEN
+		err := s.Delete(ctx, query.Filter("Name", "api"))
+
+		// THEN
+		require.NoError(t, err)
+	})
+
+	t.Run("should-assign-tenant-to-created-entities", func(t *testing.T) {
+		// GIVEN
+		var (
+			ctx      = tenancy.WithTenant(context.Background(), "acme")
+			inner    = mockstore.NewStore[*Org, int](t)
+			entities = []*Org{{}, {}}
+		)
+
+		inner.EXPECT().CreateMany(ctx, entities).Return(nil)
+
+		s := store.Chain[*Org, int](inner, tenancy.Middleware(tenancy.WithField("OrgID")))
+
+		// WHEN
+		err := s.CreateMany(ctx, entities)
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, []*Org{{OrgID: "acme"}, {OrgID: "acme"}}, entities)
+	})
+
+	t.Run("should-scope-update-to-entity-and-tenant", func(t *testing.T) {
+		// GIVEN
+		var (
+			ctx     = tenancy.WithTenant(context.Background(), "acme")
+			inner   = mockstore.NewStore[*Project, int](t)
+			project = &Project{ID: 1, TenantID: "other", Name: "api"}
+		)
+
+		inner.EXPECT().
+			Update(ctx, project, filters.IDs(1), query.Filter("TenantID", "acme")).
+			Return(nil)
+
+		s := store.Chain[*Project, int](inner, tenancy.Middleware())
+
+		// WHEN
+		err := s.Update(ctx, project)
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, "acme", project.TenantID)
+	})
+
+	t.Run("should-reject-upsert-without-tenant-conflict-column", func(t *testing.T) {
+		// GIVEN
+		var (
+			ctx   = tenancy.WithTenant(context.Background(), "acme")
+			inner = mockstore.NewStore[*Project, int](t)
+		)
+
+		s := store.Chain[*Project, int](inner, tenancy.Middleware())
+
+		// WHEN
+		_, err := s.Upsert(ctx, &Project{Name: "api"}, store.OnConflict{Columns: []string{"name"}, UpdateAll: true})
+
+		// THEN
+		assert.ErrorIs(t, err, tenancy.ErrUnscopedConflict)
+	})
+
+	t.Run("should-fail-without-tenant", func(t *testing.T) {
+		// GIVEN
+		inner := mockstore.NewStore[*Project, int](t)
+		s := store.Chain[*Project, int](inner, tenancy.Middleware())
+
+		// WHEN
+		_, err := s.Create(context.Background(), &Project{})
+
+		// THEN
+		assert.ErrorIs(t, err, tenancy.ErrMissingTenant)
+	})
+}
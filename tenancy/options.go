@@ -0,0 +1,18 @@
+package tenancy
+
+// Option is a function that configures the tenant scoped Store.
+type Option func(*options)
+
+type options struct {
+	field string
+}
+
+// WithField sets the name of the entity field holding the tenant ID. It defaults to "TenantID".
+//
+// The same name is used for the filter appended to the query params, so the inner store must be able to
+// resolve it, e.g. through the field to column mapping of gormstore.
+func WithField(name string) Option {
+	return func(o *options) {
+		o.field = name
+	}
+}
@@ -0,0 +1,314 @@
+package tenancy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/infevocorp/goflexstore/filters"
+	"github.com/infevocorp/goflexstore/internal/entityutil"
+	"github.com/infevocorp/goflexstore/query"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// ErrUnscopedConflict is returned by the upserts which could update a conflicting row of another tenant: the ones
+// updating the conflicting row whose OnConflict.Columns do not include the tenant column.
+var ErrUnscopedConflict = errors.New("tenancy: conflict columns of upsert must include the tenant column")
+
+// NewStore decorates a store so that every operation is restricted to the tenant carried by the context.
+//
+// Get, List, ListWithCount, Count, Exists, Aggregate, AggregateMany, Update, PartialUpdate, Delete and
+// DeleteReturning get a tenant filter appended to their params.
+// Create, CreateMany and Upsert set the tenant field of the entities before forwarding them, and Update and
+// PartialUpdate do the same so that an entity cannot be moved to another tenant. Upsert fails with
+// ErrUnscopedConflict unless its conflict columns include the tenant column or it does nothing on conflict.
+// UpdateMany gets the tenant filter too, and fails if its updates set the tenant field.
+//
+// Type parameters:
+//   - T: The entity type. It must be a pointer to a struct having a string tenant field.
+//   - ID: The type of the entity's identifier.
+//
+// Parameters:
+//   - inner: The store to decorate.
+//   - opts: Options customizing the decorator, see WithField.
+//
+// Returns:
+// A Store implementing store.Store[T, ID].
+//
+// Example:
+//
+//	projectStore := tenancy.NewStore[*model.Project, int64](inner, tenancy.WithField("OrgID"))
+func NewStore[T store.Entity[ID], ID comparable](inner store.Store[T, ID], opts ...Option) *Store[T, ID] {
+	o := options{
+		field: "TenantID",
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return &Store[T, ID]{
+		Store:   inner,
+		options: o,
+	}
+}
+
+// Store is a store.Store decorator restricting every operation to the tenant carried by the context.
+type Store[T store.Entity[ID], ID comparable] struct {
+	store.Store[T, ID]
+
+	options options
+}
+
+// Get retrieves a single entity of the current tenant.
+func (s *Store[T, ID]) Get(ctx context.Context, params ...query.Param) (T, error) {
+	params, err := s.scoped(ctx, params)
+	if err != nil {
+		return *new(T), err
+	}
+
+	return s.Store.Get(ctx, params...)
+}
+
+// List retrieves the entities of the current tenant.
+func (s *Store[T, ID]) List(ctx context.Context, params ...query.Param) ([]T, error) {
+	params, err := s.scoped(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Store.List(ctx, params...)
+}
+
+// ListWithCount retrieves a page of the entities of the current tenant and counts them.
+func (s *Store[T, ID]) ListWithCount(ctx context.Context, params ...query.Param) ([]T, int64, error) {
+	params, err := s.scoped(ctx, params)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return s.Store.ListWithCount(ctx, params...)
+}
+
+// Count counts the entities of the current tenant.
+func (s *Store[T, ID]) Count(ctx context.Context, params ...query.Param) (int64, error) {
+	params, err := s.scoped(ctx, params)
+	if err != nil {
+		return 0, err
+	}
+
+	return s.Store.Count(ctx, params...)
+}
+
+// Exists checks whether the current tenant has a matching entity.
+func (s *Store[T, ID]) Exists(ctx context.Context, params ...query.Param) (bool, error) {
+	params, err := s.scoped(ctx, params)
+	if err != nil {
+		return false, err
+	}
+
+	return s.Store.Exists(ctx, params...)
+}
+
+// Aggregate computes the aggregate of the field over the entities of the current tenant.
+func (s *Store[T, ID]) Aggregate(
+	ctx context.Context,
+	agg store.Aggregation,
+	field string,
+	params ...query.Param,
+) (float64, error) {
+	params, err := s.scoped(ctx, params)
+	if err != nil {
+		return 0, err
+	}
+
+	return s.Store.Aggregate(ctx, agg, field, params...)
+}
+
+// AggregateMany computes the aggregates over the entities of the current tenant.
+func (s *Store[T, ID]) AggregateMany(
+	ctx context.Context,
+	aggs []store.Aggregate,
+	params ...query.Param,
+) ([]float64, error) {
+	params, err := s.scoped(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Store.AggregateMany(ctx, aggs, params...)
+}
+
+// Create assigns the entity to the current tenant and creates it.
+func (s *Store[T, ID]) Create(ctx context.Context, entity T) (ID, error) {
+	if err := s.assign(ctx, entity); err != nil {
+		return *new(ID), err
+	}
+
+	return s.Store.Create(ctx, entity)
+}
+
+// CreateMany assigns the entities to the current tenant and creates them.
+func (s *Store[T, ID]) CreateMany(ctx context.Context, entities []T) error {
+	if err := s.assign(ctx, entities...); err != nil {
+		return err
+	}
+
+	return s.Store.CreateMany(ctx, entities)
+}
+
+// Upsert assigns the entity to the current tenant and creates or updates it.
+//
+// The conflict columns must include the tenant column, unless onConflict does nothing on conflict, otherwise a
+// conflicting row of another tenant would be overwritten and ErrUnscopedConflict is returned.
+func (s *Store[T, ID]) Upsert(ctx context.Context, entity T, onConflict store.OnConflict) (ID, error) {
+	if err := checkConflict(onConflict, s.options.field); err != nil {
+		return *new(ID), err
+	}
+
+	if err := s.assign(ctx, entity); err != nil {
+		return *new(ID), err
+	}
+
+	return s.Store.Upsert(ctx, entity, onConflict)
+}
+
+// Update updates the entity if it belongs to the current tenant.
+// When the entity has an ID, it is added to the filters so that only this entity is updated.
+func (s *Store[T, ID]) Update(ctx context.Context, entity T, params ...query.Param) error {
+	params, err := s.scopedUpdate(ctx, entity, params)
+	if err != nil {
+		return err
+	}
+
+	return s.Store.Update(ctx, entity, params...)
+}
+
+// PartialUpdate partially updates the entity if it belongs to the current tenant.
+// When the entity has an ID, it is added to the filters so that only this entity is updated.
+func (s *Store[T, ID]) PartialUpdate(ctx context.Context, entity T, params ...query.Param) error {
+	params, err := s.scopedUpdate(ctx, entity, params)
+	if err != nil {
+		return err
+	}
+
+	return s.Store.PartialUpdate(ctx, entity, params...)
+}
+
+// UpdateMany updates the matching entities of the current tenant. The updates cannot set the tenant field.
+func (s *Store[T, ID]) UpdateMany(ctx context.Context, updates map[string]any, params ...query.Param) (int64, error) {
+	if err := checkUpdates(updates, s.options.field); err != nil {
+		return 0, err
+	}
+
+	params, err := s.scoped(ctx, params)
+	if err != nil {
+		return 0, err
+	}
+
+	return s.Store.UpdateMany(ctx, updates, params...)
+}
+
+// Delete deletes the matching entities of the current tenant.
+func (s *Store[T, ID]) Delete(ctx context.Context, params ...query.Param) error {
+	params, err := s.scoped(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	return s.Store.Delete(ctx, params...)
+}
+
+// DeleteReturning deletes the matching entities of the current tenant and returns them.
+func (s *Store[T, ID]) DeleteReturning(ctx context.Context, params ...query.Param) ([]T, error) {
+	params, err := s.scoped(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Store.DeleteReturning(ctx, params...)
+}
+
+// scoped returns a copy of params with the tenant filter appended.
+func (s *Store[T, ID]) scoped(ctx context.Context, params []query.Param) ([]query.Param, error) {
+	tenantID, ok := FromContext(ctx)
+	if !ok {
+		return nil, ErrMissingTenant
+	}
+
+	scoped := make([]query.Param, 0, len(params)+1)
+	scoped = append(scoped, params...)
+
+	return append(scoped, query.Filter(s.options.field, tenantID)), nil
+}
+
+func (s *Store[T, ID]) scopedUpdate(ctx context.Context, entity T, params []query.Param) ([]query.Param, error) {
+	if err := s.assign(ctx, entity); err != nil {
+		return nil, err
+	}
+
+	if id := entity.GetID(); id != *new(ID) {
+		params = append([]query.Param{filters.IDs(id)}, params...)
+	}
+
+	return s.scoped(ctx, params)
+}
+
+// assign sets the tenant field of the entities to the tenant carried by the context.
+func (s *Store[T, ID]) assign(ctx context.Context, entities ...T) error {
+	tenantID, ok := FromContext(ctx)
+	if !ok {
+		return ErrMissingTenant
+	}
+
+	for _, entity := range entities {
+		if err := setTenant(entity, s.options.field, tenantID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkUpdates returns an error if the updates set the tenant field, which would move entities to another tenant.
+func checkUpdates(updates map[string]any, fieldName string) error {
+	if _, ok := updates[fieldName]; ok {
+		return fmt.Errorf("tenancy: tenant field %s cannot be updated", fieldName)
+	}
+
+	return nil
+}
+
+// checkConflict returns ErrUnscopedConflict if the upsert updates a conflicting row which may belong to another
+// tenant, because the conflict columns do not include the tenant column, matched ignoring case and underscores.
+func checkConflict(onConflict store.OnConflict, fieldName string) error {
+	if onConflict.DoNothing {
+		return nil
+	}
+
+	for _, column := range onConflict.Columns {
+		if entityutil.NormalizeName(column) == entityutil.NormalizeName(fieldName) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %v", ErrUnscopedConflict, onConflict.Columns)
+}
+
+// setTenant sets the tenant field of the entity, which must be a pointer to a struct.
+func setTenant(entity any, fieldName, tenantID string) error {
+	v := reflect.ValueOf(entity)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("tenancy: entity %T is not a pointer to a struct", entity)
+	}
+
+	field := v.Elem().FieldByName(fieldName)
+	if !field.IsValid() || !field.CanSet() || field.Kind() != reflect.String {
+		return fmt.Errorf("tenancy: entity %T has no settable string field %s", entity, fieldName)
+	}
+
+	field.SetString(tenantID)
+
+	return nil
+}
@@ -0,0 +1,496 @@
+package tenancy_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infevocorp/goflexstore/filters"
+	mockstore "github.com/infevocorp/goflexstore/mocks/store"
+	"github.com/infevocorp/goflexstore/query"
+	"github.com/infevocorp/goflexstore/store"
+	"github.com/infevocorp/goflexstore/tenancy"
+)
+
+type Project struct {
+	ID       int
+	TenantID string
+	Name     string
+}
+
+func (p *Project) GetID() int {
+	return p.ID
+}
+
+type Org struct {
+	ID    int
+	OrgID string
+}
+
+func (o *Org) GetID() int {
+	return o.ID
+}
+
+func Test_Store_List(t *testing.T) {
+	t.Run("should-append-tenant-filter", func(t *testing.T) {
+		// GIVEN
+		var (
+			ctx      = tenancy.WithTenant(context.Background(), "acme")
+			inner    = mockstore.NewStore[*Project, int](t)
+			projects = []*Project{{ID: 1, TenantID: "acme"}}
+		)
+
+		inner.EXPECT().
+			List(ctx, query.Filter("Name", "api"), query.Filter("TenantID", "acme")).
+			Return(projects, nil)
+
+		s := tenancy.NewStore[*Project, int](inner)
+
+		// WHEN
+		result, err := s.List(ctx, query.Filter("Name", "api"))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, projects, result)
+	})
+
+	t.Run("should-use-custom-field", func(t *testing.T) {
+		// GIVEN
+		var (
+			ctx   = tenancy.WithTenant(context.Background(), "acme")
+			inner = mockstore.NewStore[*Org, int](t)
+		)
+
+		inner.EXPECT().List(ctx, query.Filter("OrgID", "acme")).Return(nil, nil)
+
+		s := tenancy.NewStore[*Org, int](inner, tenancy.WithField("OrgID"))
+
+		// WHEN
+		_, err := s.List(ctx)
+
+		// THEN
+		require.NoError(t, err)
+	})
+
+	t.Run("should-fail-without-tenant", func(t *testing.T) {
+		// GIVEN
+		s := tenancy.NewStore[*Project, int](mockstore.NewStore[*Project, int](t))
+
+		// WHEN
+		_, err := s.List(context.Background())
+
+		// THEN
+		assert.ErrorIs(t, err, tenancy.ErrMissingTenant)
+	})
+}
+
+func Test_Store_Get(t *testing.T) {
+	t.Run("should-append-tenant-filter", func(t *testing.T) {
+		// GIVEN
+		var (
+			ctx     = tenancy.WithTenant(context.Background(), "acme")
+			inner   = mockstore.NewStore[*Project, int](t)
+			project = &Project{ID: 1, TenantID: "acme"}
+		)
+
+		inner.EXPECT().Get(ctx, filters.IDs(1), query.Filter("TenantID", "acme")).Return(project, nil)
+
+		s := tenancy.NewStore[*Project, int](inner)
+
+		// WHEN
+		result, err := s.Get(ctx, filters.IDs(1))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, project, result)
+	})
+}
+
+func Test_Store_Count(t *testing.T) {
+	t.Run("should-append-tenant-filter", func(t *testing.T) {
+		// GIVEN
+		var (
+			ctx   = tenancy.WithTenant(context.Background(), "acme")
+			inner = mockstore.NewStore[*Project, int](t)
+		)
+
+		inner.EXPECT().Count(ctx, query.Filter("TenantID", "acme")).Return(int64(2), nil)
+
+		s := tenancy.NewStore[*Project, int](inner)
+
+		// WHEN
+		count, err := s.Count(ctx)
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+	})
+}
+
+func Test_Store_Aggregate(t *testing.T) {
+	t.Run("should-append-tenant-filter", func(t *testing.T) {
+		// GIVEN
+		var (
+			ctx   = tenancy.WithTenant(context.Background(), "acme")
+			inner = mockstore.NewStore[*Project, int](t)
+		)
+
+		inner.EXPECT().Aggregate(ctx, store.Max, "ID", query.Filter("TenantID", "acme")).Return(7, nil)
+
+		s := tenancy.NewStore[*Project, int](inner)
+
+		// WHEN
+		maxID, err := s.Aggregate(ctx, store.Max, "ID")
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, float64(7), maxID)
+	})
+}
+
+func Test_Store_ListWithCount(t *testing.T) {
+	t.Run("should-append-tenant-filter", func(t *testing.T) {
+		// GIVEN
+		var (
+			ctx      = tenancy.WithTenant(context.Background(), "acme")
+			inner    = mockstore.NewStore[*Project, int](t)
+			projects = []*Project{{ID: 1, TenantID: "acme"}}
+		)
+
+		inner.EXPECT().
+			ListWithCount(ctx, query.Paginate(0, 1), query.Filter("TenantID", "acme")).
+			Return(projects, int64(2), nil)
+
+		s := tenancy.NewStore[*Project, int](inner)
+
+		// WHEN
+		result, count, err := s.ListWithCount(ctx, query.Paginate(0, 1))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, projects, result)
+		assert.Equal(t, int64(2), count)
+	})
+}
+
+func Test_Store_Exists(t *testing.T) {
+	t.Run("should-append-tenant-filter", func(t *testing.T) {
+		// GIVEN
+		var (
+			ctx   = tenancy.WithTenant(context.Background(), "acme")
+			inner = mockstore.NewStore[*Project, int](t)
+		)
+
+		inner.EXPECT().Exists(ctx, filters.IDs(1), query.Filter("TenantID", "acme")).Return(true, nil)
+
+		s := tenancy.NewStore[*Project, int](inner)
+
+		// WHEN
+		exists, err := s.Exists(ctx, filters.IDs(1))
+
+		// THEN
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+}
+
+func Test_Store_Create(t *testing.T) {
+	t.Run("should-assign-tenant", func(t *testing.T) {
+		// GIVEN
+		var (
+			ctx     = tenancy.WithTenant(context.Background(), "acme")
+			inner   = mockstore.NewStore[*Project, int](t)
+			project = &Project{Name: "api", TenantID: "globex"}
+		)
+
+		inner.EXPECT().Create(ctx, &Project{Name: "api", TenantID: "acme"}).Return(1, nil)
+
+		s := tenancy.NewStore[*Project, int](inner)
+
+		// WHEN
+		id, err := s.Create(ctx, project)
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, 1, id)
+		assert.Equal(t, "acme", project.TenantID)
+	})
+
+	t.Run("should-fail-when-entity-has-no-tenant-field", func(t *testing.T) {
+		// GIVEN
+		var (
+			ctx = tenancy.WithTenant(context.Background(), "acme")
+			s   = tenancy.NewStore[*Org, int](mockstore.NewStore[*Org, int](t))
+		)
+
+		// WHEN
+		_, err := s.Create(ctx, &Org{})
+
+		// THEN
+		assert.EqualError(t, err, "tenancy: entity *tenancy_test.Org has no settable string field TenantID")
+	})
+
+	t.Run("should-fail-without-tenant", func(t *testing.T) {
+		// GIVEN
+		s := tenancy.NewStore[*Project, int](mockstore.NewStore[*Project, int](t))
+
+		// WHEN
+		_, err := s.Create(context.Background(), &Project{})
+
+		// THEN
+		assert.ErrorIs(t, err, tenancy.ErrMissingTenant)
+	})
+}
+
+func Test_Store_CreateMany(t *testing.T) {
+	t.Run("should-assign-tenant", func(t *testing.T) {
+		// GIVEN
+		var (
+			ctx      = tenancy.WithTenant(context.Background(), "acme")
+			inner    = mockstore.NewStore[*Project, int](t)
+			projects = []*Project{{Name: "api"}, {Name: "web"}}
+		)
+
+		inner.EXPECT().CreateMany(ctx, []*Project{
+			{Name: "api", TenantID: "acme"},
+			{Name: "web", TenantID: "acme"},
+		}).Return(nil)
+
+		s := tenancy.NewStore[*Project, int](inner)
+
+		// WHEN
+		err := s.CreateMany(ctx, projects)
+
+		// THEN
+		require.NoError(t, err)
+	})
+}
+
+func Test_Store_Upsert(t *testing.T) {
+	t.Run("should-assign-tenant", func(t *testing.T) {
+		// GIVEN
+		var (
+			ctx        = tenancy.WithTenant(context.Background(), "acme")
+			inner      = mockstore.NewStore[*Project, int](t)
+			onConflict = store.OnConflict{Columns: []string{"tenant_id", "name"}, UpdateAll: true}
+		)
+
+		inner.EXPECT().Upsert(ctx, &Project{Name: "api", TenantID: "acme"}, onConflict).Return(1, nil)
+
+		s := tenancy.NewStore[*Project, int](inner)
+
+		// WHEN
+		id, err := s.Upsert(ctx, &Project{Name: "api"}, onConflict)
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, 1, id)
+	})
+
+	t.Run("should-allow-do-nothing-on-conflict", func(t *testing.T) {
+		// GIVEN
+		var (
+			ctx        = tenancy.WithTenant(context.Background(), "acme")
+			inner      = mockstore.NewStore[*Project, int](t)
+			onConflict = store.OnConflict{Columns: []string{"name"}, DoNothing: true}
+		)
+
+		inner.EXPECT().Upsert(ctx, &Project{Name: "api", TenantID: "acme"}, onConflict).Return(1, nil)
+
+		s := tenancy.NewStore[*Project, int](inner)
+
+		// WHEN
+		_, err := s.Upsert(ctx, &Project{Name: "api"}, onConflict)
+
+		// THEN
+		require.NoError(t, err)
+	})
+
+	t.Run("should-reject-conflict-columns-without-tenant", func(t *testing.T) {
+		for _, onConflict := range []store.OnConflict{
+			{Columns: []string{"name"}, UpdateAll: true},
+			{UpdateColumns: []string{"name"}},
+			{OnConstraint: "projects_name_key", UpdateAll: true},
+		} {
+			// GIVEN
+			var (
+				ctx   = tenancy.WithTenant(context.Background(), "acme")
+				inner = mockstore.NewStore[*Project, int](t)
+			)
+
+			s := tenancy.NewStore[*Project, int](inner)
+
+			// WHEN
+			_, err := s.Upsert(ctx, &Project{Name: "api"}, onConflict)
+
+			// THEN
+			assert.ErrorIs(t, err, tenancy.ErrUnscopedConflict)
+		}
+	})
+}
+
+func Test_Store_Update(t *testing.T) {
+	t.Run("should-filter-by-id-and-tenant", func(t *testing.T) {
+		// GIVEN
+		var (
+			ctx   = tenancy.WithTenant(context.Background(), "acme")
+			inner = mockstore.NewStore[*Project, int](t)
+		)
+
+		inner.EXPECT().
+			Update(ctx, &Project{ID: 1, Name: "api", TenantID: "acme"}, filters.IDs(1), query.Filter("TenantID", "acme")).
+			Return(nil)
+
+		s := tenancy.NewStore[*Project, int](inner)
+
+		// WHEN
+		err := s.Update(ctx, &Project{ID: 1, Name: "api", TenantID: "globex"})
+
+		// THEN
+		require.NoError(t, err)
+	})
+
+	t.Run("should-keep-params-when-entity-has-no-id", func(t *testing.T) {
+		// GIVEN
+		var (
+			ctx   = tenancy.WithTenant(context.Background(), "acme")
+			inner = mockstore.NewStore[*Project, int](t)
+		)
+
+		inner.EXPECT().
+			Update(ctx, &Project{Name: "api", TenantID: "acme"}, query.Filter("Name", "web"), query.Filter("TenantID", "acme")).
+			Return(nil)
+
+		s := tenancy.NewStore[*Project, int](inner)
+
+		// WHEN
+		err := s.Update(ctx, &Project{Name: "api"}, query.Filter("Name", "web"))
+
+		// THEN
+		require.NoError(t, err)
+	})
+}
+
+func Test_Store_PartialUpdate(t *testing.T) {
+	t.Run("should-filter-by-id-and-tenant", func(t *testing.T) {
+		// GIVEN
+		var (
+			ctx   = tenancy.WithTenant(context.Background(), "acme")
+			inner = mockstore.NewStore[*Project, int](t)
+		)
+
+		inner.EXPECT().
+			PartialUpdate(ctx, &Project{ID: 1, Name: "api", TenantID: "acme"}, filters.IDs(1), query.Filter("TenantID", "acme")).
+			Return(nil)
+
+		s := tenancy.NewStore[*Project, int](inner)
+
+		// WHEN
+		err := s.PartialUpdate(ctx, &Project{ID: 1, Name: "api"})
+
+		// THEN
+		require.NoError(t, err)
+	})
+}
+
+func Test_Store_UpdateMany(t *testing.T) {
+	t.Run("should-append-tenant-filter", func(t *testing.T) {
+		// GIVEN
+		var (
+			ctx     = tenancy.WithTenant(context.Background(), "acme")
+			inner   = mockstore.NewStore[*Project, int](t)
+			updates = map[string]any{"Name": "api"}
+		)
+
+		inner.EXPECT().UpdateMany(ctx, updates, query.Filter("TenantID", "acme")).Return(int64(3), nil)
+
+		s := tenancy.NewStore[*Project, int](inner)
+
+		// WHEN
+		updated, err := s.UpdateMany(ctx, updates)
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), updated)
+	})
+
+	t.Run("should-fail-to-update-tenant-field", func(t *testing.T) {
+		// GIVEN
+		var (
+			ctx = tenancy.WithTenant(context.Background(), "acme")
+			s   = tenancy.NewStore[*Project, int](mockstore.NewStore[*Project, int](t))
+		)
+
+		// WHEN
+		_, err := s.UpdateMany(ctx, map[string]any{"TenantID": "other"})
+
+		// THEN
+		assert.EqualError(t, err, "tenancy: tenant field TenantID cannot be updated")
+	})
+}
+
+func Test_Store_Delete(t *testing.T) {
+	t.Run("should-append-tenant-filter", func(t *testing.T) {
+		// GIVEN
+		var (
+			ctx   = tenancy.WithTenant(context.Background(), "acme")
+			inner = mockstore.NewStore[*Project, int](t)
+		)
+
+		inner.EXPECT().Delete(ctx, filters.IDs(1), query.Filter("TenantID", "acme")).Return(nil)
+
+		s := tenancy.NewStore[*Project, int](inner)
+
+		// WHEN
+		err := s.Delete(ctx, filters.IDs(1))
+
+		// THEN
+		require.NoError(t, err)
+	})
+
+	t.Run("should-fail-without-tenant", func(t *testing.T) {
+		// GIVEN
+		s := tenancy.NewStore[*Project, int](mockstore.NewStore[*Project, int](t))
+
+		// WHEN
+		err := s.Delete(context.Background(), filters.IDs(1))
+
+		// THEN
+		assert.ErrorIs(t, err, tenancy.ErrMissingTenant)
+	})
+}
+
+func Test_Store_DeleteReturning(t *testing.T) {
+	t.Run("should-append-tenant-filter", func(t *testing.T) {
+		// GIVEN
+		var (
+			ctx     = tenancy.WithTenant(context.Background(), "acme")
+			inner   = mockstore.NewStore[*Project, int](t)
+			deleted = []*Project{{ID: 1, TenantID: "acme"}}
+		)
+
+		inner.EXPECT().DeleteReturning(ctx, filters.IDs(1), query.Filter("TenantID", "acme")).Return(deleted, nil)
+
+		s := tenancy.NewStore[*Project, int](inner)
+
+		// WHEN
+		got, err := s.DeleteReturning(ctx, filters.IDs(1))
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, deleted, got)
+	})
+
+	t.Run("should-fail-without-tenant", func(t *testing.T) {
+		// GIVEN
+		s := tenancy.NewStore[*Project, int](mockstore.NewStore[*Project, int](t))
+
+		// WHEN
+		_, err := s.DeleteReturning(context.Background(), filters.IDs(1))
+
+		// THEN
+		assert.ErrorIs(t, err, tenancy.ErrMissingTenant)
+	})
+}
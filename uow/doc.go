@@ -0,0 +1,41 @@
+// Package uow provides units of work: a set of stores bound to one transaction of an operation scope, with a
+// single Commit or Rollback.
+//
+// Application services otherwise begin a transaction, pass its context to every store call and end it with the
+// outcome of the operation. Begin does the first part: it begins the transaction and builds the stores of the
+// unit of work with a Binder, each store bound with Bind running its operations in the transaction whatever the
+// context given to its methods. The service then calls the stores and ends with Commit, or with Rollback, which
+// does nothing once the unit of work is committed so that it can be deferred.
+//
+// The stores given to Bind must share the operation scope of the unit of work, see the registry package.
+//
+// Example:
+//
+//	type Stores struct {
+//		Article store.Store[*model.Article, int64]
+//		Tag     store.Store[*model.Tag, int64]
+//	}
+//
+//	func bindStores(b *uow.Binder) Stores {
+//		return Stores{
+//			Article: uow.Bind(b, registry.MustResolve[*model.Article, int64](stores)),
+//			Tag:     uow.Bind(b, registry.MustResolve[*model.Tag, int64](stores)),
+//		}
+//	}
+//
+//	work, err := uow.Begin(ctx, stores.Scope(), bindStores)
+//	if err != nil {
+//		return err
+//	}
+//	defer work.Rollback()
+//
+//	if _, err := work.Stores().Article.Create(ctx, article); err != nil {
+//		return err
+//	}
+//
+//	if _, err := work.Stores().Tag.CreateMany(ctx, tags); err != nil {
+//		return err
+//	}
+//
+//	return work.Commit()
+package uow
@@ -0,0 +1,140 @@
+package uow
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/infevocorp/goflexstore/opscope"
+	"github.com/infevocorp/goflexstore/store"
+)
+
+// ErrDone is returned by the operations of the stores of a unit of work, and by Commit, once the unit of work
+// is committed or rolled back.
+var ErrDone = errors.New("unit of work already committed or rolled back")
+
+// errRollback is the outcome given to the operation scope to roll back the transaction.
+var errRollback = errors.New("unit of work rolled back")
+
+// UnitOfWork holds the stores of type S bound to one transaction, see Begin.
+// A UnitOfWork is safe for concurrent use, as long as the operation scope is.
+type UnitOfWork[S any] struct {
+	work   *work
+	stores S
+}
+
+// work is the transaction of a unit of work, shared with the stores bound to it.
+type work struct {
+	scope opscope.Scope
+	ctx   context.Context
+
+	mu   sync.RWMutex
+	done bool
+}
+
+// Binder binds stores to the transaction of a unit of work, see Bind.
+type Binder struct {
+	work *work
+}
+
+// Begin begins a transaction of scope and returns a unit of work holding the stores built by bind.
+//
+// Parameters:
+//   - ctx: The context the transaction is begun from.
+//   - scope: The operation scope of the transaction, shared by the stores.
+//   - bind: The function building the stores of the unit of work, calling Bind for each store.
+//
+// Returns:
+// The unit of work, or the error of the operation scope.
+func Begin[S any](ctx context.Context, scope opscope.Scope, bind func(b *Binder) S) (*UnitOfWork[S], error) {
+	txCtx, err := scope.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &work{
+		scope: scope,
+		ctx:   txCtx,
+	}
+
+	return &UnitOfWork[S]{
+		work:   w,
+		stores: bind(&Binder{work: w}),
+	}, nil
+}
+
+// Bind returns s running its operations in the transaction of the unit of work, with the context of the
+// unit of work instead of the one given to its methods. Its operations return ErrDone once the unit of work
+// is committed or rolled back.
+func Bind[T store.Entity[ID], ID comparable](b *Binder, s store.Store[T, ID]) store.Store[T, ID] {
+	return store.Chain(s, b.work.middleware)
+}
+
+// middleware runs the operations with the context of the transaction while it is active.
+func (w *work) middleware(next store.OperationFunc) store.OperationFunc {
+	return func(_ context.Context, op *store.Operation) (any, error) {
+		w.mu.RLock()
+		defer w.mu.RUnlock()
+
+		if w.done {
+			return nil, ErrDone
+		}
+
+		return next(w.ctx, op)
+	}
+}
+
+// Stores returns the stores of the unit of work.
+func (u *UnitOfWork[S]) Stores() S {
+	return u.stores
+}
+
+// Context returns the context of the transaction, e.g. to call the services taking part in it.
+func (u *UnitOfWork[S]) Context() context.Context {
+	return u.work.ctx
+}
+
+// Commit commits the transaction. It waits for the running operations of the stores to complete.
+//
+// Returns:
+// The error of the operation scope, or ErrDone if the unit of work is already committed or rolled back.
+func (u *UnitOfWork[S]) Commit() error {
+	if !u.work.finish() {
+		return ErrDone
+	}
+
+	return u.work.scope.End(u.work.ctx, nil)
+}
+
+// Rollback rolls back the transaction. It waits for the running operations of the stores to complete.
+// It does nothing if the unit of work is already committed or rolled back, so that it can be deferred
+// right after Begin.
+//
+// Returns:
+// The error of the operation scope if the rollback failed.
+func (u *UnitOfWork[S]) Rollback() error {
+	if !u.work.finish() {
+		return nil
+	}
+
+	err := u.work.scope.End(u.work.ctx, errRollback)
+	if err == nil || err == errRollback { //nolint:errorlint // errRollback alone means the rollback succeeded.
+		return nil
+	}
+
+	return err
+}
+
+// finish marks the work as done, and reports whether it was active.
+func (w *work) finish() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.done {
+		return false
+	}
+
+	w.done = true
+
+	return true
+}
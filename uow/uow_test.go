@@ -0,0 +1,175 @@
+package uow_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	mockopscope "github.com/infevocorp/goflexstore/mocks/opscope"
+	"github.com/infevocorp/goflexstore/store"
+	"github.com/infevocorp/goflexstore/storetest"
+	"github.com/infevocorp/goflexstore/uow"
+)
+
+type Article struct {
+	ID    int64
+	Title string
+}
+
+func (a *Article) GetID() int64 {
+	return a.ID
+}
+
+type Tag struct {
+	ID   int64
+	Name string
+}
+
+func (t *Tag) GetID() int64 {
+	return t.ID
+}
+
+type Stores struct {
+	Article store.Store[*Article, int64]
+	Tag     store.Store[*Tag, int64]
+}
+
+type txKey struct{}
+
+// txOf records the transaction of the context of every operation.
+func txOf(txs *[]any) store.Middleware {
+	return func(next store.OperationFunc) store.OperationFunc {
+		return func(ctx context.Context, op *store.Operation) (any, error) {
+			*txs = append(*txs, ctx.Value(txKey{}))
+			return next(ctx, op)
+		}
+	}
+}
+
+func newStores(txs *[]any) (*storetest.Fake[*Article, int64], *storetest.Fake[*Tag, int64], func(*uow.Binder) Stores) {
+	var (
+		articles = storetest.NewFake[*Article, int64]()
+		tags     = storetest.NewFake[*Tag, int64]()
+	)
+
+	return articles, tags, func(b *uow.Binder) Stores {
+		return Stores{
+			Article: uow.Bind(b, store.Chain[*Article, int64](articles, txOf(txs))),
+			Tag:     uow.Bind(b, store.Chain[*Tag, int64](tags, txOf(txs))),
+		}
+	}
+}
+
+func Test_UnitOfWork(t *testing.T) {
+	t.Run("should-run-stores-in-transaction-and-commit", func(t *testing.T) {
+		// GIVEN
+		var (
+			txs                  []any
+			scope                = mockopscope.NewScope(t)
+			ctx                  = context.Background()
+			txCtx                = context.WithValue(ctx, txKey{}, "tx")
+			articles, tags, bind = newStores(&txs)
+		)
+
+		scope.EXPECT().Begin(ctx).Return(txCtx, nil).Once()
+		scope.EXPECT().End(txCtx, nil).Return(nil).Once()
+
+		work, err := uow.Begin(ctx, scope, bind)
+		require.NoError(t, err)
+
+		// WHEN
+		_, err = work.Stores().Article.Create(ctx, &Article{ID: 1, Title: "Hello"})
+		require.NoError(t, err)
+
+		_, err = work.Stores().Tag.Create(ctx, &Tag{ID: 1, Name: "news"})
+		require.NoError(t, err)
+
+		err = work.Commit()
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, []any{"tx", "tx"}, txs)
+		assert.Len(t, articles.Entities(), 1)
+		assert.Len(t, tags.Entities(), 1)
+		assert.Equal(t, txCtx, work.Context())
+		assert.NoError(t, work.Rollback())
+	})
+
+	t.Run("should-rollback", func(t *testing.T) {
+		// GIVEN
+		var (
+			txs        []any
+			scope      = mockopscope.NewScope(t)
+			ctx        = context.Background()
+			_, _, bind = newStores(&txs)
+		)
+
+		scope.EXPECT().Begin(ctx).Return(ctx, nil).Once()
+		scope.EXPECT().End(ctx, mock.Anything).RunAndReturn(func(_ context.Context, err error) error {
+			return err
+		}).Once()
+
+		work, err := uow.Begin(ctx, scope, bind)
+		require.NoError(t, err)
+
+		// WHEN
+		err = work.Rollback()
+
+		// THEN
+		require.NoError(t, err)
+		assert.ErrorIs(t, work.Commit(), uow.ErrDone)
+
+		_, err = work.Stores().Article.Get(ctx)
+		assert.ErrorIs(t, err, uow.ErrDone)
+		assert.Empty(t, txs)
+	})
+
+	t.Run("should-return-rollback-error", func(t *testing.T) {
+		// GIVEN
+		var (
+			txs         []any
+			scope       = mockopscope.NewScope(t)
+			ctx         = context.Background()
+			_, _, bind  = newStores(&txs)
+			rollbackErr = errors.New("connection lost")
+		)
+
+		scope.EXPECT().Begin(ctx).Return(ctx, nil).Once()
+		scope.EXPECT().End(ctx, mock.Anything).RunAndReturn(func(_ context.Context, err error) error {
+			return errors.Join(err, rollbackErr)
+		}).Once()
+
+		work, err := uow.Begin(ctx, scope, bind)
+		require.NoError(t, err)
+
+		// WHEN
+		err = work.Rollback()
+
+		// THEN
+		assert.ErrorIs(t, err, rollbackErr)
+	})
+
+	t.Run("should-return-begin-error", func(t *testing.T) {
+		// GIVEN
+		var (
+			txs        []any
+			scope      = mockopscope.NewScope(t)
+			ctx        = context.Background()
+			_, _, bind = newStores(&txs)
+			beginErr   = errors.New("too many connections")
+		)
+
+		scope.EXPECT().Begin(ctx).Return(nil, beginErr).Once()
+
+		// WHEN
+		work, err := uow.Begin(ctx, scope, bind)
+
+		// THEN
+		assert.ErrorIs(t, err, beginErr)
+		assert.Nil(t, work)
+	})
+}